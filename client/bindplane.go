@@ -21,6 +21,7 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
@@ -54,6 +55,10 @@ type AgentInstallOptions struct {
 	// SecretKey is the secret key used to authenticate agents with BindPlane OP
 	SecretKey string
 
+	// EnrollmentToken is the name of the EnrollmentToken used to enroll agents with BindPlane OP. If specified, the token
+	// is used in place of the SecretKey and each agent is issued its own credentials.
+	EnrollmentToken string
+
 	// RemoteURL is the URL that the agent will use to connect to BindPlane OP
 	RemoteURL string
 }
@@ -125,8 +130,16 @@ type BindPlane interface {
 	Agents(ctx context.Context, options ...QueryOption) ([]*model.Agent, error)
 	// Agent returns a single Agent.
 	Agent(ctx context.Context, id string) (*model.Agent, error)
+	// AgentHistory returns the status changes of an Agent between start and end and the percentage of that time it was
+	// up. A zero start uses the first status change and a zero end uses the current time.
+	AgentHistory(ctx context.Context, id string, start, end time.Time) (*model.AgentHistory, error)
 	// DeleteAgents deletes multiple agents by ID.
 	DeleteAgents(ctx context.Context, agentIDs []string) ([]*model.Agent, error)
+	// RestartAgents requests a restart of the agents with the specified IDs and the agents matching the selector or query
+	// options, returning the agents that will be restarted.
+	RestartAgents(ctx context.Context, agentIDs []string, options ...QueryOption) ([]*model.Agent, error)
+	// RevokeAgentCredentials revokes the credentials issued to the agent with the specified ID, returning the agent.
+	RevokeAgentCredentials(ctx context.Context, id string) (*model.Agent, error)
 
 	// AgentVersions returns a list of AgentVersion resources.
 	AgentVersions(ctx context.Context) ([]*model.AgentVersion, error)
@@ -138,6 +151,23 @@ type BindPlane interface {
 	// SyncAgentVersions builds agent-version from the release data in GitHub.
 	// If version is empty, it syncs the last 10 releases.
 	SyncAgentVersions(ctx context.Context, version string) ([]*model.AnyResourceStatus, error)
+	// ImportAgentPackage uploads the package of an agent version with the specified name to the server so that agents
+	// can be upgraded without downloading it from GitHub.
+	ImportAgentPackage(ctx context.Context, version string, name string, reader io.Reader) error
+
+	// EnrollmentTokens returns a list of EnrollmentToken resources.
+	EnrollmentTokens(ctx context.Context) ([]*model.EnrollmentToken, error)
+	// EnrollmentToken returns a single EnrollmentToken resource by name.
+	EnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error)
+	// DeleteEnrollmentToken deletes an EnrollmentToken resource by name.
+	DeleteEnrollmentToken(ctx context.Context, name string) error
+
+	// Secrets returns a list of Secret resources without their values.
+	Secrets(ctx context.Context) ([]*model.Secret, error)
+	// Secret returns a single Secret resource by name without its value.
+	Secret(ctx context.Context, name string) (*model.Secret, error)
+	// DeleteSecret deletes a Secret resource by name.
+	DeleteSecret(ctx context.Context, name string) error
 
 	// Configurations returns a list of Configuration resources.
 	Configurations(ctx context.Context) ([]*model.Configuration, error)
@@ -149,8 +179,17 @@ type BindPlane interface {
 	// the specified name. This can either be the raw value of a raw configuration or the
 	// rendered value of a configuration with sources and destinations.
 	RawConfiguration(ctx context.Context, name string) (string, error)
+	// AgentRawConfiguration returns the raw OpenTelemetry configuration for the configuration with the specified name
+	// rendered with the values of the agent with the specified ID. References to Secrets are not replaced.
+	AgentRawConfiguration(ctx context.Context, name string, agentID string) (string, error)
 	// CopyConfig creates a deep copy of an existing resource under a new name.
 	CopyConfig(ctx context.Context, name, copyName string) error
+	// ConfigurationRevisions returns the revisions of the configuration with the specified name, ordered from oldest to
+	// newest.
+	ConfigurationRevisions(ctx context.Context, name string) ([]*model.Configuration, error)
+	// RollbackConfiguration restores the configuration with the specified name to the specified revision. The rollback
+	// creates a new revision which is returned.
+	RollbackConfiguration(ctx context.Context, name string, revision int) (int, error)
 
 	// Sources returns a list of all Source resources.
 	Sources(ctx context.Context) ([]*model.Source, error)
@@ -193,11 +232,47 @@ type BindPlane interface {
 	DestinationType(ctx context.Context, name string) (*model.DestinationType, error)
 	// DeleteDestinationType deletes a single Destination resource by name.
 	DeleteDestinationType(ctx context.Context, name string) error
+	// ExtensionTypes returns a list of all ExtensionType resources.
+	ExtensionTypes(ctx context.Context) ([]*model.ExtensionType, error)
+	// ExtensionType returns a single ExtensionType by name.
+	ExtensionType(ctx context.Context, name string) (*model.ExtensionType, error)
+	// DeleteExtensionType deletes a single ExtensionType resource by name.
+	DeleteExtensionType(ctx context.Context, name string) error
 
 	// Apply upserts multiple resources of any kind.
 	Apply(ctx context.Context, r []*model.AnyResource) ([]*model.AnyResourceStatus, error)
 	// Delete deletes multiple resources, minimum required fields to delete are Kind and Metadata.Name.
 	Delete(ctx context.Context, r []*model.AnyResource) ([]*model.AnyResourceStatus, error)
+	// DeleteWithOptions deletes multiple resources like Delete, removing the references to them or deleting their
+	// dependents depending on the cascade mode. For a dry run, the response contains the changes that would be made and
+	// the agents whose configuration would change.
+	DeleteWithOptions(ctx context.Context, r []*model.AnyResource, cascade model.CascadeMode, dryRun bool) (*model.DeleteResponseClientSide, error)
+
+	// AuditEntries returns the entries of the audit log that match the filter, ordered from newest to oldest.
+	AuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error)
+
+	// Rollouts returns the most recent rollout of each configuration that is rolled out in waves.
+	Rollouts(ctx context.Context) ([]*model.Rollout, error)
+	// Rollout returns the most recent rollout of the configuration with the specified name.
+	Rollout(ctx context.Context, name string) (*model.Rollout, error)
+	// PauseRollout pauses the rollout of the configuration with the specified name.
+	PauseRollout(ctx context.Context, name string) (*model.Rollout, error)
+	// ResumeRollout resumes the paused rollout of the configuration with the specified name.
+	ResumeRollout(ctx context.Context, name string) (*model.Rollout, error)
+	// AbortRollout stops the rollout of the configuration with the specified name and restores the previous revision
+	// of the configuration.
+	AbortRollout(ctx context.Context, name string) (*model.Rollout, error)
+
+	// UpgradeCampaigns returns all of the upgrade campaigns.
+	UpgradeCampaigns(ctx context.Context) ([]*model.UpgradeCampaign, error)
+	// UpgradeCampaign returns the upgrade campaign with the specified name.
+	UpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error)
+	// CreateUpgradeCampaign starts upgrading the agents matching the selector or query of the campaign in batches.
+	CreateUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) (*model.UpgradeCampaign, error)
+	// PauseUpgradeCampaign pauses the upgrade campaign with the specified name.
+	PauseUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error)
+	// ResumeUpgradeCampaign resumes the paused upgrade campaign with the specified name.
+	ResumeUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error)
 
 	// Version returns the version of the BindPlane-OP server.
 	Version(ctx context.Context) (version.Version, error)
@@ -279,6 +354,27 @@ func (c *bindplaneClient) Agent(ctx context.Context, id string) (*model.Agent, e
 	return ar.Agent, c.statusError(resp, err, "unable to get agents")
 }
 
+func (c *bindplaneClient) AgentHistory(ctx context.Context, id string, start, end time.Time) (*model.AgentHistory, error) {
+	c.Debug("AgentHistory called")
+
+	ar := &model.AgentHistoryResponse{}
+	endpoint := fmt.Sprintf("/agents/%s/history", id)
+	req := c.client.R().SetResult(ar)
+	if !start.IsZero() {
+		req.SetQueryParam("start", start.Format(time.RFC3339))
+	}
+	if !end.IsZero() {
+		req.SetQueryParam("end", end.Format(time.RFC3339))
+	}
+	resp, err := req.Get(endpoint)
+	if err != nil {
+		logRequestError(c.Logger, err, endpoint)
+		return nil, err
+	}
+
+	return ar.History, c.statusError(resp, err, "unable to get agent history")
+}
+
 func (c *bindplaneClient) DeleteAgents(ctx context.Context, ids []string) ([]*model.Agent, error) {
 	c.Debug("DeleteAgents called")
 
@@ -290,6 +386,28 @@ func (c *bindplaneClient) DeleteAgents(ctx context.Context, ids []string) ([]*mo
 	return result.Agents, c.statusError(resp, err, "unable to delete agents")
 }
 
+func (c *bindplaneClient) RestartAgents(ctx context.Context, ids []string, options ...QueryOption) ([]*model.Agent, error) {
+	c.Debug("RestartAgents called")
+
+	opts := makeQueryOptions(options)
+	body := &model.RestartAgentsPayload{
+		IDs:      ids,
+		Selector: opts.selector,
+		Query:    opts.query,
+	}
+	result := &model.RestartAgentsResponse{}
+	resp, err := c.client.R().SetBody(body).SetResult(result).Put("/agents/restart")
+	return result.Agents, c.statusError(resp, err, "unable to restart agents")
+}
+
+func (c *bindplaneClient) RevokeAgentCredentials(ctx context.Context, id string) (*model.Agent, error) {
+	c.Debug("RevokeAgentCredentials called")
+
+	result := &model.RevokeAgentCredentialsResponse{}
+	resp, err := c.client.R().SetResult(result).Delete(fmt.Sprintf("/agents/%s/credentials", id))
+	return result.Agent, c.statusError(resp, err, "unable to revoke agent credentials")
+}
+
 // ----------------------------------------------------------------------
 
 func (c *bindplaneClient) AgentVersions(ctx context.Context) ([]*model.AgentVersion, error) {
@@ -321,6 +439,51 @@ func (c *bindplaneClient) SyncAgentVersions(ctx context.Context, version string)
 	return ar.Updates, c.statusError(resp, err, "unable to sync agent-versions")
 }
 
+func (c *bindplaneClient) ImportAgentPackage(ctx context.Context, version string, name string, reader io.Reader) error {
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/octet-stream").
+		SetBody(reader).
+		Put(fmt.Sprintf("/agent-packages/%s/%s", version, name))
+	return c.statusError(resp, err, fmt.Sprintf("unable to import agent package %s", name))
+}
+
+// ----------------------------------------------------------------------
+
+func (c *bindplaneClient) EnrollmentTokens(ctx context.Context) ([]*model.EnrollmentToken, error) {
+	result := model.EnrollmentTokensResponse{}
+	err := c.resources(ctx, "/enrollment-tokens", &result)
+	return result.EnrollmentTokens, err
+}
+
+func (c *bindplaneClient) EnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	result := model.EnrollmentTokenResponse{}
+	err := c.resource(ctx, "/enrollment-tokens", name, &result)
+	return result.EnrollmentToken, err
+}
+
+func (c *bindplaneClient) DeleteEnrollmentToken(ctx context.Context, name string) error {
+	return c.deleteResource(ctx, "/enrollment-tokens", name)
+}
+
+// ----------------------------------------------------------------------
+
+func (c *bindplaneClient) Secrets(ctx context.Context) ([]*model.Secret, error) {
+	result := model.SecretsResponse{}
+	err := c.resources(ctx, "/secrets", &result)
+	return result.Secrets, err
+}
+
+func (c *bindplaneClient) Secret(ctx context.Context, name string) (*model.Secret, error) {
+	result := model.SecretResponse{}
+	err := c.resource(ctx, "/secrets", name, &result)
+	return result.Secret, err
+}
+
+func (c *bindplaneClient) DeleteSecret(ctx context.Context, name string) error {
+	return c.deleteResource(ctx, "/secrets", name)
+}
+
 // ----------------------------------------------------------------------
 
 func (c *bindplaneClient) Configurations(ctx context.Context) ([]*model.Configuration, error) {
@@ -347,6 +510,22 @@ func (c *bindplaneClient) RawConfiguration(ctx context.Context, name string) (st
 	return result.Raw, err
 }
 
+func (c *bindplaneClient) AgentRawConfiguration(ctx context.Context, name string, agentID string) (string, error) {
+	result := &model.ConfigurationResponse{}
+	endpoint := fmt.Sprintf("/configurations/%s", name)
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetResult(result).
+		SetQueryParam("agent", agentID).
+		Get(endpoint)
+	if err != nil {
+		logRequestError(c.Logger, err, endpoint)
+		return "", err
+	}
+
+	return result.Raw, c.statusError(resp, err, fmt.Sprintf("unable to get %s", endpoint))
+}
+
 // ----------------------------------------------------------------------
 
 func (c *bindplaneClient) Sources(ctx context.Context) ([]*model.Source, error) {
@@ -455,6 +634,22 @@ func (c *bindplaneClient) DeleteDestinationType(ctx context.Context, name string
 	return c.deleteResource(ctx, "/destination-types", name)
 }
 
+func (c *bindplaneClient) ExtensionTypes(ctx context.Context) ([]*model.ExtensionType, error) {
+	result := model.ExtensionTypesResponse{}
+	err := c.resources(ctx, "/extension-types", &result)
+	return result.ExtensionTypes, err
+}
+
+func (c *bindplaneClient) ExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	result := model.ExtensionTypeResponse{}
+	err := c.resource(ctx, "/extension-types", name, &result)
+	return result.ExtensionType, err
+}
+
+func (c *bindplaneClient) DeleteExtensionType(ctx context.Context, name string) error {
+	return c.deleteResource(ctx, "/extension-types", name)
+}
+
 // ----------------------------------------------------------------------
 
 func (c *bindplaneClient) Apply(ctx context.Context, resources []*model.AnyResource) ([]*model.AnyResourceStatus, error) {
@@ -476,10 +671,20 @@ func (c *bindplaneClient) Apply(ctx context.Context, resources []*model.AnyResou
 }
 
 func (c *bindplaneClient) Delete(ctx context.Context, resources []*model.AnyResource) ([]*model.AnyResourceStatus, error) {
+	dr, err := c.DeleteWithOptions(ctx, resources, model.CascadeNone, false)
+	if err != nil {
+		return nil, err
+	}
+	return dr.Updates, nil
+}
+
+func (c *bindplaneClient) DeleteWithOptions(ctx context.Context, resources []*model.AnyResource, cascade model.CascadeMode, dryRun bool) (*model.DeleteResponseClientSide, error) {
 	c.Debug("Batch Delete called")
 
 	payload := model.DeletePayload{
 		Resources: resources,
+		Cascade:   cascade,
+		DryRun:    dryRun,
 	}
 
 	data, err := jsoniter.Marshal(payload)
@@ -494,28 +699,143 @@ func (c *bindplaneClient) Delete(ctx context.Context, resources []*model.AnyReso
 		return nil, err
 	}
 
+	// parse the body directly because SetResult only works for status codes 200-299
 	dr := &model.DeleteResponseClientSide{}
+	parseErr := json.Unmarshal(resp.Body(), dr)
 
 	switch resp.StatusCode() {
 	case http.StatusAccepted:
-		return dr.Updates, nil
+		if parseErr != nil {
+			return nil, parseErr
+		}
+		return dr, nil
 	case http.StatusUnauthorized:
 		return nil, c.unauthorizedError(resp)
 	case http.StatusBadRequest:
-		if dr.Errors != nil {
+		if len(dr.Errors) > 0 {
 			return nil, errors.New(dr.Errors[0])
 		}
 		return nil, errors.New("bad request")
 	case http.StatusInternalServerError:
-		return nil, fmt.Errorf("%s", dr.Errors[0])
+		if len(dr.Errors) > 0 {
+			return nil, errors.New(dr.Errors[0])
+		}
+		return nil, errors.New("internal server error")
 	}
 
-	err = json.Unmarshal(resp.Body(), dr)
+	return nil, fmt.Errorf("unknown response from bindplane server")
+}
+
+func (c *bindplaneClient) AuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error) {
+	c.Debug("AuditEntries called")
+
+	ar := &model.AuditEntriesResponse{}
+	resp, err := c.client.R().
+		SetResult(ar).
+		SetQueryParam("kind", string(filter.Kind)).
+		SetQueryParam("name", filter.Name).
+		SetQueryParam("user", filter.User).
+		SetQueryParam("offset", fmt.Sprintf("%d", filter.Offset)).
+		SetQueryParam("limit", fmt.Sprintf("%d", filter.Limit)).
+		Get("/audit")
 	if err != nil {
+		logRequestError(c.Logger, err, "/audit")
 		return nil, err
 	}
 
-	return nil, fmt.Errorf("unknown response from bindplane server")
+	return ar.AuditEntries, c.statusError(resp, err, "unable to get audit entries")
+}
+
+func (c *bindplaneClient) Rollouts(ctx context.Context) ([]*model.Rollout, error) {
+	c.Debug("Rollouts called")
+
+	result := &model.RolloutsResponse{}
+	err := c.get(ctx, "/rollouts", result)
+	return result.Rollouts, err
+}
+
+func (c *bindplaneClient) Rollout(ctx context.Context, name string) (*model.Rollout, error) {
+	result := &model.RolloutResponse{}
+	err := c.resource(ctx, "/rollouts", name, result)
+	return result.Rollout, err
+}
+
+func (c *bindplaneClient) PauseRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return c.updateRollout(ctx, name, "pause")
+}
+
+func (c *bindplaneClient) ResumeRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return c.updateRollout(ctx, name, "resume")
+}
+
+func (c *bindplaneClient) AbortRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return c.updateRollout(ctx, name, "abort")
+}
+
+// updateRollout sends PUT /rollouts/:name/:action
+func (c *bindplaneClient) updateRollout(ctx context.Context, name string, action string) (*model.Rollout, error) {
+	c.Debug("updateRollout called", zap.String("action", action))
+
+	endpoint := fmt.Sprintf("/rollouts/%s/%s", name, action)
+	result := &model.RolloutResponse{}
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetResult(result).
+		Put(endpoint)
+	if err != nil {
+		logRequestError(c.Logger, err, endpoint)
+		return nil, err
+	}
+
+	return result.Rollout, c.statusError(resp, err, fmt.Sprintf("unable to %s rollout", action))
+}
+
+func (c *bindplaneClient) UpgradeCampaigns(ctx context.Context) ([]*model.UpgradeCampaign, error) {
+	c.Debug("UpgradeCampaigns called")
+
+	result := &model.UpgradeCampaignsResponse{}
+	err := c.get(ctx, "/upgrade-campaigns", result)
+	return result.UpgradeCampaigns, err
+}
+
+func (c *bindplaneClient) UpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	result := &model.UpgradeCampaignResponse{}
+	err := c.resource(ctx, "/upgrade-campaigns", name, result)
+	return result.UpgradeCampaign, err
+}
+
+func (c *bindplaneClient) CreateUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) (*model.UpgradeCampaign, error) {
+	c.Debug("CreateUpgradeCampaign called")
+
+	result := &model.UpgradeCampaignResponse{}
+	resp, err := c.client.R().SetContext(ctx).SetBody(campaign).SetResult(result).Post("/upgrade-campaigns")
+	return result.UpgradeCampaign, c.statusError(resp, err, "unable to create upgrade campaign")
+}
+
+func (c *bindplaneClient) PauseUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	return c.updateUpgradeCampaign(ctx, name, "pause")
+}
+
+func (c *bindplaneClient) ResumeUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	return c.updateUpgradeCampaign(ctx, name, "resume")
+}
+
+// updateUpgradeCampaign sends PUT /upgrade-campaigns/:name/:action
+func (c *bindplaneClient) updateUpgradeCampaign(ctx context.Context, name string, action string) (*model.UpgradeCampaign, error) {
+	c.Debug("updateUpgradeCampaign called", zap.String("action", action))
+
+	endpoint := fmt.Sprintf("/upgrade-campaigns/%s/%s", name, action)
+	result := &model.UpgradeCampaignResponse{}
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetResult(result).
+		Put(endpoint)
+	if err != nil {
+		logRequestError(c.Logger, err, endpoint)
+		return nil, err
+	}
+
+	return result.UpgradeCampaign, c.statusError(resp, err, fmt.Sprintf("unable to %s upgrade campaign", action))
 }
 
 func (c *bindplaneClient) Version(ctx context.Context) (version.Version, error) {
@@ -538,6 +858,7 @@ func (c *bindplaneClient) AgentInstallCommand(ctx context.Context, options Agent
 		SetQueryParam("labels", options.Labels).
 		SetQueryParam("remote-url", options.RemoteURL).
 		SetQueryParam("secret-key", options.SecretKey).
+		SetQueryParam("enrollment-token", options.EnrollmentToken).
 		SetResult(&command).
 		Get(endpoint)
 
@@ -671,6 +992,54 @@ func (c *bindplaneClient) CopyConfig(ctx context.Context, name, copyName string)
 	}
 }
 
+func (c *bindplaneClient) ConfigurationRevisions(ctx context.Context, name string) ([]*model.Configuration, error) {
+	result := model.ConfigurationRevisionsResponse{}
+	err := c.get(ctx, fmt.Sprintf("/configurations/%s/revisions", name), &result)
+	return result.Revisions, err
+}
+
+func (c *bindplaneClient) RollbackConfiguration(ctx context.Context, name string, revision int) (int, error) {
+	payload := model.PostRollbackRequest{
+		Revision: revision,
+	}
+
+	endpoint := fmt.Sprintf("/configurations/%s/rollback", name)
+
+	result := &model.PostRollbackResponse{}
+	resp, err := c.client.R().
+		SetContext(ctx).
+		SetHeader("Content-Type", "application/json").
+		SetBody(payload).
+		SetResult(result).
+		Post(endpoint)
+
+	if err != nil {
+		return 0, err
+	}
+
+	switch resp.StatusCode() {
+	case http.StatusOK:
+		return result.Revision, nil
+	case http.StatusNotFound:
+		return 0, fmt.Errorf("revision %d of configuration '%s' not found", revision, name)
+	default:
+		err := &multierror.Error{}
+		multierror.Append(err, fmt.Errorf("failed to rollback configuration, got status %v", resp.StatusCode()))
+
+		// check for errors field in response
+		errResponse := &model.ErrorResponse{}
+		if err := json.Unmarshal(resp.Body(), errResponse); err != nil {
+			c.Logger.Error("failed to unmarshal error response when rolling back config", zap.Error(err))
+		}
+
+		for _, e := range errResponse.Errors {
+			multierror.Append(err, errors.New(e))
+		}
+
+		return 0, err.ErrorOrNil()
+	}
+}
+
 // ----------------------------------------------------------------------
 
 // resources gets the resources from the REST server and stores them in the provided result.
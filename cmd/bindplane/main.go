@@ -22,12 +22,20 @@ import (
 	"github.com/observiq/bindplane-op/internal/cli"
 	"github.com/observiq/bindplane-op/internal/cli/commands"
 	"github.com/observiq/bindplane-op/internal/cli/commands/apply"
+	"github.com/observiq/bindplane-op/internal/cli/commands/backup"
+	"github.com/observiq/bindplane-op/internal/cli/commands/campaign"
 	"github.com/observiq/bindplane-op/internal/cli/commands/delete"
 	"github.com/observiq/bindplane-op/internal/cli/commands/get"
 	"github.com/observiq/bindplane-op/internal/cli/commands/initialize"
 	"github.com/observiq/bindplane-op/internal/cli/commands/install"
 	"github.com/observiq/bindplane-op/internal/cli/commands/label"
+	"github.com/observiq/bindplane-op/internal/cli/commands/migrate"
 	"github.com/observiq/bindplane-op/internal/cli/commands/profile"
+	"github.com/observiq/bindplane-op/internal/cli/commands/restart"
+	"github.com/observiq/bindplane-op/internal/cli/commands/restore"
+	"github.com/observiq/bindplane-op/internal/cli/commands/revoke"
+	"github.com/observiq/bindplane-op/internal/cli/commands/rollback"
+	"github.com/observiq/bindplane-op/internal/cli/commands/rollout"
 	"github.com/observiq/bindplane-op/internal/cli/commands/serve"
 	"github.com/observiq/bindplane-op/internal/cli/commands/sync"
 	"github.com/observiq/bindplane-op/internal/cli/commands/update"
@@ -62,7 +70,15 @@ func main() {
 		label.Command(bindplane),
 		delete.Command(bindplane),
 		serve.Command(bindplane, h),
+		backup.Command(bindplane),
+		restore.Command(bindplane),
+		migrate.Command(bindplane),
 		profile.Command(h),
+		rollback.Command(bindplane),
+		restart.Command(bindplane),
+		revoke.Command(bindplane),
+		rollout.Command(bindplane),
+		campaign.Command(bindplane),
 		version.Command(bindplane),
 		initialize.Command(bindplane, h, initialize.DualMode),
 		install.Command(bindplane),
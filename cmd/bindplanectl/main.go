@@ -22,6 +22,7 @@ import (
 	"github.com/observiq/bindplane-op/internal/cli"
 	"github.com/observiq/bindplane-op/internal/cli/commands"
 	"github.com/observiq/bindplane-op/internal/cli/commands/apply"
+	"github.com/observiq/bindplane-op/internal/cli/commands/campaign"
 	"github.com/observiq/bindplane-op/internal/cli/commands/copy"
 	"github.com/observiq/bindplane-op/internal/cli/commands/delete"
 	"github.com/observiq/bindplane-op/internal/cli/commands/get"
@@ -29,6 +30,10 @@ import (
 	"github.com/observiq/bindplane-op/internal/cli/commands/install"
 	"github.com/observiq/bindplane-op/internal/cli/commands/label"
 	"github.com/observiq/bindplane-op/internal/cli/commands/profile"
+	"github.com/observiq/bindplane-op/internal/cli/commands/restart"
+	"github.com/observiq/bindplane-op/internal/cli/commands/revoke"
+	"github.com/observiq/bindplane-op/internal/cli/commands/rollback"
+	"github.com/observiq/bindplane-op/internal/cli/commands/rollout"
 	"github.com/observiq/bindplane-op/internal/cli/commands/sync"
 	"github.com/observiq/bindplane-op/internal/cli/commands/update"
 	"github.com/observiq/bindplane-op/internal/cli/commands/validate"
@@ -62,6 +67,11 @@ func main() {
 		label.Command(bindplane),
 		delete.Command(bindplane),
 		profile.Command(h),
+		rollback.Command(bindplane),
+		restart.Command(bindplane),
+		revoke.Command(bindplane),
+		rollout.Command(bindplane),
+		campaign.Command(bindplane),
 		version.Command(bindplane),
 		initialize.Command(bindplane, h, initialize.ClientMode),
 		install.Command(bindplane),
@@ -153,11 +153,13 @@ const (
 	StoreTypeBbolt = "bbolt"
 	// StoreTypeGoogleCloud uses Google Cloud Datastore for storage
 	StoreTypeGoogleCloud = "googlecloud"
+	// StoreTypePostgres uses PostgreSQL for storage and LISTEN/NOTIFY for events
+	StoreTypePostgres = "postgres"
 )
 
 // Server TODO(doc)
 type Server struct {
-	// StoreType indicates the type of store to use. "map", "bbolt", and "googlecloud" are currently supported.
+	// StoreType indicates the type of store to use. "map", "bbolt", "googlecloud", and "postgres" are currently supported.
 	StoreType string `mapstructure:"storeType,omitempty" yaml:"storeType,omitempty"`
 
 	// GoogleCloudDatastore contains configuration for contacting Google Could Datastore and is used if StoreType == "googlecloud"
@@ -166,12 +168,19 @@ type Server struct {
 	// GoogleCloudPubSub contains configuration for contacting Google Could Pub/Sub and is used if StoreType == "googlecloud"
 	GoogleCloudPubSub *GoogleCloudPubSub `yaml:"pubsub,omitempty" mapstructure:"pubsub,omitempty"`
 
+	// Postgres contains configuration for connecting to PostgreSQL and is used if StoreType == "postgres"
+	Postgres *Postgres `mapstructure:"postgres,omitempty" yaml:"postgres,omitempty"`
+
 	// StorageFilePath TODO(doc)
 	StorageFilePath string `mapstructure:"storageFilePath,omitempty" yaml:"storageFilePath,omitempty"`
 
 	// SecretKey is a shared secret between the server and the agent to ensure agents are authorized to communicate with the server.
 	SecretKey string `mapstructure:"secretKey,omitempty" yaml:"secretKey,omitempty"`
 
+	// DisableSecretKeyEnrollment stops agents from enrolling with the SecretKey, so that agents without their own
+	// credentials must present an EnrollmentToken.
+	DisableSecretKeyEnrollment bool `mapstructure:"disableSecretKeyEnrollment,omitempty" yaml:"disableSecretKeyEnrollment,omitempty"`
+
 	// RemoteURL is the URL that agents should use to contact the server
 	RemoteURL string `mapstructure:"remoteURL,omitempty" yaml:"remoteURL,omitempty"`
 
@@ -179,14 +188,72 @@ type Server struct {
 	// any other services. It will still allow agents to connect and serve api requests.
 	Offline bool `mapstructure:"offline,omitempty" yaml:"offline,omitempty"`
 
+	// VerifyAgentCertificates requires agents to connect with a client certificate whose subject common name or subject
+	// alternative names include the ID of the agent, or the value of AgentCertificateLabel if the agent has that label.
+	// It requires mutual TLS.
+	VerifyAgentCertificates bool `mapstructure:"verifyAgentCertificates,omitempty" yaml:"verifyAgentCertificates,omitempty"`
+
+	// AgentCertificateLabel is the name of an agent label whose value must match the client certificate of the agent
+	// instead of the agent ID when VerifyAgentCertificates is enabled. Agents without the label must match the agent ID.
+	AgentCertificateLabel string `mapstructure:"agentCertificateLabel,omitempty" yaml:"agentCertificateLabel,omitempty"`
+
+	// OpAMPMaxConnections is the maximum number of agents that can be connected at once. Additional agents are rejected
+	// with 503 Service Unavailable and a Retry-After header. It is unlimited if 0.
+	OpAMPMaxConnections int `mapstructure:"opampMaxConnections,omitempty" yaml:"opampMaxConnections,omitempty"`
+
+	// OpAMPConnectionsPerSecond is the maximum number of new agent connections accepted each second. Additional agents
+	// are rejected with 429 Too Many Requests and a Retry-After header. It is unlimited if 0.
+	OpAMPConnectionsPerSecond int `mapstructure:"opampConnectionsPerSecond,omitempty" yaml:"opampConnectionsPerSecond,omitempty"`
+
+	// AuditLogFile is the path of a file that will receive each entry of the audit log as a line of JSON. Entries are
+	// always available from the API, the file is optional.
+	AuditLogFile string `mapstructure:"auditLogFile,omitempty" yaml:"auditLogFile,omitempty"`
+
+	// AuditRetention is how long entries are kept in the audit log. Older entries are removed every minute. Entries are
+	// kept until AuditMaxEntries is exceeded if 0.
+	AuditRetention time.Duration `mapstructure:"auditRetention,omitempty" yaml:"auditRetention,omitempty"`
+
+	// AuditMaxEntries is the maximum number of entries kept in the audit log. The oldest entries are removed every minute
+	// when there are more. It is unlimited if 0.
+	AuditMaxEntries int `mapstructure:"auditMaxEntries,omitempty" yaml:"auditMaxEntries,omitempty"`
+
 	// SessionSecret is used to encode the user sessions cookies.  It should be a uuid.
 	SessionsSecret string `mapstructure:"sessionsSecret,omitempty" yaml:"sessionsSecret,omitempty"`
 
+	// SecretsKey is used to encrypt the values of Secret resources before they are stored. Secrets cannot be applied or
+	// rendered for agents without it and it must not change once secrets have been applied.
+	SecretsKey string `mapstructure:"secretsKey,omitempty" yaml:"secretsKey,omitempty"`
+
 	Common `yaml:",inline" mapstructure:",squash"`
 
 	// SyncAgentVersionsInterval is the interval at which agent-versions will be synchronized with GitHub. Set to 0 to
 	// turn off synchronization. Disabled if Offline is true.
 	SyncAgentVersionsInterval time.Duration `mapstructure:"syncAgentVersionsInterval,omitempty" yaml:"syncAgentVersionsInterval,omitempty"`
+
+	// ServeAgentPackages sends agents being upgraded a URL of the server instead of GitHub to download the new version
+	// of the agent. The server downloads and caches the packages in the downloads directory of the BindPlane home, or
+	// they can be imported with 'bindplanectl sync agent-version --from-dir' when the server is offline.
+	ServeAgentPackages bool `mapstructure:"serveAgentPackages,omitempty" yaml:"serveAgentPackages,omitempty"`
+
+	// AgentHeartbeatInterval is the interval at which heartbeats are sent to connected agents to keep their connections
+	// open. Set to 0 to turn off heartbeats.
+	AgentHeartbeatInterval time.Duration `mapstructure:"agentHeartbeatInterval,omitempty" yaml:"agentHeartbeatInterval,omitempty"`
+
+	// AgentCleanupTTL is how long an agent can be disconnected before it is removed. Set to 0 to keep disconnected
+	// agents. With multiple servers, only one server at a time removes agents.
+	AgentCleanupTTL time.Duration `mapstructure:"agentCleanupTTL,omitempty" yaml:"agentCleanupTTL,omitempty"`
+
+	// MarkStaleAgents sets the status of agents that have been disconnected for longer than AgentCleanupTTL to Stale
+	// instead of removing them.
+	MarkStaleAgents bool `mapstructure:"markStaleAgents,omitempty" yaml:"markStaleAgents,omitempty"`
+
+	// AgentHistoryRetention is how long the status changes of agents are kept. Older changes are removed every minute,
+	// except the most recent change of each agent. Changes are kept until AgentHistoryMaxChanges is exceeded if 0.
+	AgentHistoryRetention time.Duration `mapstructure:"agentHistoryRetention,omitempty" yaml:"agentHistoryRetention,omitempty"`
+
+	// AgentHistoryMaxChanges is the maximum number of status changes kept for each agent. The oldest changes are removed
+	// every minute when there are more. It is unlimited if 0.
+	AgentHistoryMaxChanges int `mapstructure:"agentHistoryMaxChanges,omitempty" yaml:"agentHistoryMaxChanges,omitempty"`
 }
 
 // GoogleCloudDatastore contains the configuration for google cloud datastore
@@ -208,6 +275,22 @@ type GoogleCloudPubSub struct {
 	Subscription string `mapstructure:"subscription,omitempty" yaml:"subscription,omitempty"`
 }
 
+// Postgres contains the configuration for connecting to PostgreSQL
+type Postgres struct {
+	Host     string `mapstructure:"host,omitempty" yaml:"host,omitempty"`
+	Port     string `mapstructure:"port,omitempty" yaml:"port,omitempty"`
+	Database string `mapstructure:"database,omitempty" yaml:"database,omitempty"`
+	Username string `mapstructure:"username,omitempty" yaml:"username,omitempty"`
+	Password string `mapstructure:"password,omitempty" yaml:"password,omitempty"`
+
+	// SSLMode is passed to the server as sslmode and is one of disable, require, verify-ca, or verify-full. Defaults to
+	// require.
+	SSLMode string `mapstructure:"sslMode,omitempty" yaml:"sslMode,omitempty"`
+
+	// MaxConnections is the maximum number of open connections to the database. Zero means there is no limit.
+	MaxConnections int `mapstructure:"maxConnections,omitempty" yaml:"maxConnections,omitempty"`
+}
+
 // Client TODO(doc)
 type Client struct {
 	Common
@@ -260,6 +343,16 @@ func (c *Server) BoltDatabasePath() string {
 	return path.Join(c.BindPlaneHomePath(), BoldDatabaseName)
 }
 
+// SecretsEncryptionKey returns the key used to encrypt and decrypt the values of Secrets
+func (c *Server) SecretsEncryptionKey() string {
+	return c.SecretsKey
+}
+
+// DownloadsPath returns the path to the directory where agent packages are cached
+func (c *Server) DownloadsPath() string {
+	return path.Join(c.BindPlaneHomePath(), DownloadsDirectoryName)
+}
+
 // ----------------------------------------------------------------------
 // Common
 
@@ -59,6 +59,62 @@ func (s *Server) validate() (errGroup error) {
 		errGroup = multierror.Append(errGroup, err)
 	}
 
+	if s.VerifyAgentCertificates && len(s.CertificateAuthority) == 0 {
+		err := errors.New("tls certificate authority must be set when verifyAgentCertificates is enabled")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.OpAMPMaxConnections < 0 {
+		err := errors.New("opampMaxConnections must not be negative")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.OpAMPConnectionsPerSecond < 0 {
+		err := errors.New("opampConnectionsPerSecond must not be negative")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.AuditRetention < 0 {
+		err := errors.New("auditRetention must not be negative")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.AuditMaxEntries < 0 {
+		err := errors.New("auditMaxEntries must not be negative")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.AgentHeartbeatInterval < 0 {
+		err := errors.New("agentHeartbeatInterval must not be negative")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.AgentCleanupTTL < 0 {
+		err := errors.New("agentCleanupTTL must not be negative")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.MarkStaleAgents && s.AgentCleanupTTL == 0 {
+		err := errors.New("agentCleanupTTL must be set when markStaleAgents is enabled")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.AgentHistoryRetention < 0 {
+		err := errors.New("agentHistoryRetention must not be negative")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.AgentHistoryMaxChanges < 0 {
+		err := errors.New("agentHistoryMaxChanges must not be negative")
+		errGroup = multierror.Append(errGroup, err)
+	}
+
+	if s.StoreType == StoreTypePostgres {
+		if err := s.validatePostgres(); err != nil {
+			errGroup = multierror.Append(errGroup, err)
+		}
+	}
+
 	if err := s.Common.validate(); err != nil {
 		errGroup = multierror.Append(errGroup, err)
 	}
@@ -66,6 +122,28 @@ func (s *Server) validate() (errGroup error) {
 	return errGroup
 }
 
+func (s *Server) validatePostgres() error {
+	if s.Postgres == nil {
+		return nil
+	}
+
+	if err := validPort(s.Postgres.Port); err != nil {
+		return fmt.Errorf("failed to validate postgres port: %w", err)
+	}
+
+	switch s.Postgres.SSLMode {
+	case "", "disable", "require", "verify-ca", "verify-full":
+	default:
+		return fmt.Errorf("postgres sslMode must be one of disable, require, verify-ca, or verify-full: %s", s.Postgres.SSLMode)
+	}
+
+	if s.Postgres.MaxConnections < 0 {
+		return errors.New("postgres maxConnections must not be negative")
+	}
+
+	return nil
+}
+
 func (c *Client) validate() (errGroup error) {
 	return c.Common.validate()
 }
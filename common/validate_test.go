@@ -16,6 +16,7 @@ package common
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -187,6 +188,21 @@ func TestValidate(t *testing.T) {
 			},
 			"",
 		},
+		{
+			"valid-postgres",
+			Config{
+				Server: Server{
+					StoreType: StoreTypePostgres,
+					Postgres: &Postgres{
+						Host:     "localhost",
+						Port:     "5432",
+						Database: "bindplane",
+						SSLMode:  "verify-full",
+					},
+				},
+			},
+			"",
+		},
 		{
 			"invalid-directory",
 			Config{
@@ -459,6 +475,120 @@ func TestValidate(t *testing.T) {
 			},
 			"failed to lookup storage file path",
 		},
+		{
+			"verify-agent-certificates-without-tls-ca",
+			Config{
+				Server: Server{
+					VerifyAgentCertificates: true,
+				},
+			},
+			"tls certificate authority must be set when verifyAgentCertificates is enabled",
+		},
+		{
+			"negative-opamp-max-connections",
+			Config{
+				Server: Server{
+					OpAMPMaxConnections: -1,
+				},
+			},
+			"opampMaxConnections must not be negative",
+		},
+		{
+			"negative-opamp-connections-per-second",
+			Config{
+				Server: Server{
+					OpAMPConnectionsPerSecond: -1,
+				},
+			},
+			"opampConnectionsPerSecond must not be negative",
+		},
+		{
+			"negative-agent-heartbeat-interval",
+			Config{
+				Server: Server{
+					AgentHeartbeatInterval: -time.Second,
+				},
+			},
+			"agentHeartbeatInterval must not be negative",
+		},
+		{
+			"negative-audit-retention",
+			Config{
+				Server: Server{
+					AuditRetention: -time.Hour,
+				},
+			},
+			"auditRetention must not be negative",
+		},
+		{
+			"negative-audit-max-entries",
+			Config{
+				Server: Server{
+					AuditMaxEntries: -1,
+				},
+			},
+			"auditMaxEntries must not be negative",
+		},
+		{
+			"negative-agent-cleanup-ttl",
+			Config{
+				Server: Server{
+					AgentCleanupTTL: -time.Minute,
+				},
+			},
+			"agentCleanupTTL must not be negative",
+		},
+		{
+			"mark-stale-agents-without-ttl",
+			Config{
+				Server: Server{
+					MarkStaleAgents: true,
+				},
+			},
+			"agentCleanupTTL must be set when markStaleAgents is enabled",
+		},
+		{
+			"negative-agent-history-retention",
+			Config{
+				Server: Server{
+					AgentHistoryRetention: -time.Hour,
+				},
+			},
+			"agentHistoryRetention must not be negative",
+		},
+		{
+			"negative-agent-history-max-changes",
+			Config{
+				Server: Server{
+					AgentHistoryMaxChanges: -1,
+				},
+			},
+			"agentHistoryMaxChanges must not be negative",
+		},
+		{
+			"invalid-postgres-port",
+			Config{
+				Server: Server{
+					StoreType: StoreTypePostgres,
+					Postgres: &Postgres{
+						Port: "five",
+					},
+				},
+			},
+			"failed to validate postgres port",
+		},
+		{
+			"invalid-postgres-ssl-mode",
+			Config{
+				Server: Server{
+					StoreType: StoreTypePostgres,
+					Postgres: &Postgres{
+						SSLMode: "prefer-ish",
+					},
+				},
+			},
+			"postgres sslMode must be one of",
+		},
 	}
 
 	for _, tc := range cases {
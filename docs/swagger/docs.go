@@ -630,6 +630,7 @@ const docTemplate = `{
         },
         "/configurations/{name}": {
             "get": {
+                "description": "The raw configuration is rendered for the agent if one is specified.",
                 "produces": [
                     "application/json"
                 ],
@@ -641,6 +642,12 @@ const docTemplate = `{
                         "name": "name",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "the id of an agent to render the configuration for",
+                        "name": "agent",
+                        "in": "query"
                     }
                 ],
                 "responses": {
@@ -650,6 +657,12 @@ const docTemplate = `{
                             "$ref": "#/definitions/model.ConfigurationResponse"
                         }
                     },
+                    "404": {
+                        "description": "Not Found",
+                        "schema": {
+                            "$ref": "#/definitions/rest.ErrorResponse"
+                        }
+                    },
                     "500": {
                         "description": "Internal Server Error",
                         "schema": {
@@ -2130,6 +2143,9 @@ const docTemplate = `{
         "model.ResourceTypeOutput": {
             "type": "object",
             "properties": {
+                "connectors": {
+                    "type": "string"
+                },
                 "exporters": {
                     "type": "string"
                 },
@@ -141,7 +141,7 @@ func (c *github) Versions() ([]*model.AgentVersion, error) {
 
 func (c *github) GetSha256Sums(release *githubRelease) (sha256sums, error) {
 	// download and parse the sha256sums
-	sumsName := fmt.Sprintf("observiq-otel-collector-%s-SHA256SUMS", release.TagName)
+	sumsName := Sha256SumsName(release.TagName)
 	sumsURL := releaseAssetURL(sumsName, release.Assets)
 
 	res, err := c.client.R().Get(sumsURL)
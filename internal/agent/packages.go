@@ -0,0 +1,237 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/go-resty/resty/v2"
+	"github.com/observiq/bindplane-op/model"
+	"go.uber.org/zap"
+)
+
+var (
+	// ErrPackageNotFound is returned when a package is not cached and cannot be downloaded
+	ErrPackageNotFound = errors.New("agent package not found")
+
+	// ErrPackageHashMismatch is returned when the sha256 of a package does not match the hash of the agent-version
+	ErrPackageHashMismatch = errors.New("agent package sha256 does not match the agent-version")
+)
+
+// Packages caches the release packages of the agent on the server so that agents can be upgraded by downloading them
+// from the server instead of GitHub, which may not be reachable from the agents.
+type Packages interface {
+	// Open returns the cached package of the agent-version for the platform. If it isn't cached, it is downloaded from
+	// the URL of the agent-version first unless the server is offline, in which case ErrPackageNotFound is returned.
+	Open(ctx context.Context, agentVersion *model.AgentVersion, platform string) (*os.File, error)
+
+	// Import caches the package of the agent-version for the platform from the reader. The sha256 of the package must
+	// match the hash of the agent-version or ErrPackageHashMismatch is returned.
+	Import(ctx context.Context, agentVersion *model.AgentVersion, platform string, reader io.Reader) error
+}
+
+// PackagesSettings contains the settings for NewPackages
+type PackagesSettings struct {
+	Logger *zap.Logger
+
+	// Directory is the directory where packages are cached
+	Directory string
+
+	// Offline is true if the server is in offline mode and should not download packages. Packages can still be imported.
+	Offline bool
+}
+
+type packages struct {
+	client    *resty.Client
+	directory string
+	offline   bool
+	logger    *zap.Logger
+
+	// mtx ensures that a package is only downloaded once when many agents are upgraded at the same time
+	mtx sync.Mutex
+}
+
+var _ Packages = (*packages)(nil)
+
+// NewPackages creates an implementation of Packages that caches packages in the directory of the settings
+func NewPackages(settings PackagesSettings) Packages {
+	c := resty.New()
+	c.SetTimeout(time.Minute * 5)
+	return &packages{
+		client:    c,
+		directory: settings.Directory,
+		offline:   settings.Offline,
+		logger:    settings.Logger,
+	}
+}
+
+// Open returns the cached package of the agent-version for the platform, downloading it if necessary
+func (p *packages) Open(ctx context.Context, agentVersion *model.AgentVersion, platform string) (*os.File, error) {
+	download, name, err := packageDownload(agentVersion, platform)
+	if err != nil {
+		return nil, err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	path := p.path(agentVersion, name)
+	file, err := os.Open(path)
+	if err == nil || !errors.Is(err, os.ErrNotExist) {
+		return file, err
+	}
+
+	if p.offline || download.URL == "" {
+		return nil, fmt.Errorf("%w: %s is not cached", ErrPackageNotFound, name)
+	}
+
+	p.logger.Info("downloading agent package", zap.String("name", name), zap.String("url", download.URL))
+	response, err := p.client.R().SetContext(ctx).SetDoNotParseResponse(true).Get(download.URL)
+	if err != nil {
+		return nil, fmt.Errorf("unable to download %s: %w", name, err)
+	}
+	body := response.RawBody()
+	defer body.Close()
+
+	if response.StatusCode() != 200 {
+		return nil, fmt.Errorf("unable to download %s: %s", name, response.Status())
+	}
+
+	if err := p.write(path, download.Hash, body); err != nil {
+		return nil, err
+	}
+	return os.Open(path)
+}
+
+// Import caches the package of the agent-version for the platform from the reader
+func (p *packages) Import(_ context.Context, agentVersion *model.AgentVersion, platform string, reader io.Reader) error {
+	download, name, err := packageDownload(agentVersion, platform)
+	if err != nil {
+		return err
+	}
+
+	p.mtx.Lock()
+	defer p.mtx.Unlock()
+
+	return p.write(p.path(agentVersion, name), download.Hash, reader)
+}
+
+// path returns the path of the cached package with the name
+func (p *packages) path(agentVersion *model.AgentVersion, name string) string {
+	return filepath.Join(p.directory, agentVersion.Version(), name)
+}
+
+// write copies the package from the reader to a temporary file and moves it to the path if the sha256 of the package
+// matches the hash. This ensures that only complete and verified packages are ever served.
+func (p *packages) write(path string, hash string, reader io.Reader) error {
+	if err := os.MkdirAll(filepath.Dir(path), 0750); err != nil {
+		return fmt.Errorf("unable to create the agent package directory: %w", err)
+	}
+
+	file, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".*.tmp")
+	if err != nil {
+		return fmt.Errorf("unable to create the agent package: %w", err)
+	}
+	defer func() {
+		// the temporary file no longer exists if it was renamed
+		_ = os.Remove(file.Name())
+	}()
+
+	sha := sha256.New()
+	_, err = io.Copy(io.MultiWriter(file, sha), reader)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("unable to write the agent package: %w", err)
+	}
+
+	if hex.EncodeToString(sha.Sum(nil)) != hash {
+		return fmt.Errorf("%w: %s", ErrPackageHashMismatch, filepath.Base(path))
+	}
+
+	return os.Rename(file.Name(), path)
+}
+
+// packageDownload returns the download of the agent-version for the platform and the name of its package
+func packageDownload(agentVersion *model.AgentVersion, platform string) (*model.AgentDownload, string, error) {
+	name := PackageName(agentVersion.Version(), platform)
+	download := agentVersion.Download(platform)
+	if name == "" || download == nil {
+		return nil, "", fmt.Errorf("%w: no package for platform %s", ErrPackageNotFound, platform)
+	}
+	if download.Hash == "" {
+		return nil, "", fmt.Errorf("%w: no sha256 for %s", ErrPackageNotFound, name)
+	}
+	return download, name, nil
+}
+
+// ----------------------------------------------------------------------
+
+// PackageName returns the name of the release package of the agent version for the platform or "" if there is no
+// package for the platform
+func PackageName(version string, platform string) string {
+	artifacts, ok := platformArtifacts[platform]
+	if !ok {
+		return ""
+	}
+	return fmt.Sprintf(artifacts.downloadPackageFormat, version)
+}
+
+// PackagePlatform returns the platform of the release package of the agent version with the specified name or "" if
+// the name isn't the name of a package
+func PackagePlatform(version string, name string) string {
+	for platform := range platformArtifacts {
+		if PackageName(version, platform) == name {
+			return platform
+		}
+	}
+	return ""
+}
+
+// Sha256SumsName returns the name of the release asset with the sha256 of each package of the agent version
+func Sha256SumsName(version string) string {
+	return fmt.Sprintf("observiq-otel-collector-%s-SHA256SUMS", version)
+}
+
+// NewAgentVersionFromSha256Sums creates an agent-version from the contents of the SHA256SUMS release asset. It is used
+// to import packages when the release can't be synced from GitHub, so it only includes the hashes of the packages.
+func NewAgentVersionFromSha256Sums(version string, contents []byte) *model.AgentVersion {
+	sums := parseSha256Sums(contents)
+
+	download := map[string]model.AgentDownload{}
+	for platform := range platformArtifacts {
+		if hash := sums.sha256Sum(PackageName(version, platform)); hash != "" {
+			download[platform] = model.AgentDownload{
+				Hash: hash,
+			}
+		}
+	}
+
+	return model.NewAgentVersion(model.AgentVersionSpec{
+		Type:     repo,
+		Version:  version,
+		Download: download,
+	})
+}
@@ -0,0 +1,149 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package agent
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func testPackageVersion(url string, contents []byte) *model.AgentVersion {
+	sum := sha256.Sum256(contents)
+	return model.NewAgentVersion(model.AgentVersionSpec{
+		Type:    repo,
+		Version: "v1.14.0",
+		Download: map[string]model.AgentDownload{
+			"linux/amd64": {
+				URL:  url,
+				Hash: hex.EncodeToString(sum[:]),
+			},
+		},
+	})
+}
+
+func readPackage(t *testing.T, file *os.File) []byte {
+	defer file.Close()
+	contents, err := io.ReadAll(file)
+	require.NoError(t, err)
+	return contents
+}
+
+func TestPackagesOpen(t *testing.T) {
+	contents := []byte("observiq-otel-collector")
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_, _ = w.Write(contents)
+	}))
+	defer server.Close()
+
+	t.Run("downloads and caches the package", func(t *testing.T) {
+		directory := t.TempDir()
+		p := NewPackages(PackagesSettings{Logger: zap.NewNop(), Directory: directory})
+		agentVersion := testPackageVersion(server.URL, contents)
+		requests = 0
+
+		file, err := p.Open(context.Background(), agentVersion, "linux/amd64")
+		require.NoError(t, err)
+		require.Equal(t, contents, readPackage(t, file))
+		require.FileExists(t, filepath.Join(directory, "v1.14.0", "observiq-otel-collector-v1.14.0-linux-amd64.tar.gz"))
+
+		file, err = p.Open(context.Background(), agentVersion, "linux/amd64")
+		require.NoError(t, err)
+		require.Equal(t, contents, readPackage(t, file))
+		require.Equal(t, 1, requests)
+	})
+
+	t.Run("rejects a package with the wrong hash", func(t *testing.T) {
+		directory := t.TempDir()
+		p := NewPackages(PackagesSettings{Logger: zap.NewNop(), Directory: directory})
+		agentVersion := testPackageVersion(server.URL, []byte("something else"))
+
+		_, err := p.Open(context.Background(), agentVersion, "linux/amd64")
+		require.ErrorIs(t, err, ErrPackageHashMismatch)
+
+		entries, err := os.ReadDir(filepath.Join(directory, "v1.14.0"))
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("does not download when offline", func(t *testing.T) {
+		p := NewPackages(PackagesSettings{Logger: zap.NewNop(), Directory: t.TempDir(), Offline: true})
+		agentVersion := testPackageVersion(server.URL, contents)
+
+		_, err := p.Open(context.Background(), agentVersion, "linux/amd64")
+		require.ErrorIs(t, err, ErrPackageNotFound)
+	})
+
+	t.Run("unknown platform", func(t *testing.T) {
+		p := NewPackages(PackagesSettings{Logger: zap.NewNop(), Directory: t.TempDir()})
+		agentVersion := testPackageVersion(server.URL, contents)
+
+		_, err := p.Open(context.Background(), agentVersion, "windows/amd64")
+		require.ErrorIs(t, err, ErrPackageNotFound)
+	})
+}
+
+func TestPackagesImport(t *testing.T) {
+	contents := []byte("observiq-otel-collector")
+	agentVersion := testPackageVersion("", contents)
+
+	p := NewPackages(PackagesSettings{Logger: zap.NewNop(), Directory: t.TempDir(), Offline: true})
+
+	err := p.Import(context.Background(), agentVersion, "linux/amd64", bytes.NewReader([]byte("something else")))
+	require.ErrorIs(t, err, ErrPackageHashMismatch)
+
+	_, err = p.Open(context.Background(), agentVersion, "linux/amd64")
+	require.ErrorIs(t, err, ErrPackageNotFound)
+
+	err = p.Import(context.Background(), agentVersion, "linux/amd64", bytes.NewReader(contents))
+	require.NoError(t, err)
+
+	file, err := p.Open(context.Background(), agentVersion, "linux/amd64")
+	require.NoError(t, err)
+	require.Equal(t, contents, readPackage(t, file))
+}
+
+func TestPackagePlatform(t *testing.T) {
+	require.Equal(t, "linux/arm64", PackagePlatform("v1.4.0", "observiq-otel-collector-v1.4.0-linux-arm64.tar.gz"))
+	require.Equal(t, "windows/amd64", PackagePlatform("v1.4.0", "observiq-otel-collector-v1.4.0-windows-amd64.zip"))
+	require.Equal(t, "", PackagePlatform("v1.4.0", "observiq-otel-collector_v1.4.0_linux_amd64.deb"))
+	require.Equal(t, "", PackagePlatform("v1.5.0", "observiq-otel-collector-v1.4.0-linux-arm64.tar.gz"))
+}
+
+func TestNewAgentVersionFromSha256Sums(t *testing.T) {
+	file, err := os.ReadFile("testfiles/observiq-otel-collector-v1.4.0-SHA256SUMS")
+	require.NoError(t, err)
+
+	agentVersion := NewAgentVersionFromSha256Sums("v1.4.0", file)
+	require.Equal(t, "observiq-otel-collector-v1.4.0", agentVersion.Name())
+	require.Len(t, agentVersion.Spec.Download, len(platformArtifacts))
+	require.Equal(t, "80f2030439757a93f3471c94adc31f9f353daff5b32e6c26cee5a8ed55602976", agentVersion.Download("linux/amd64").Hash)
+
+	_, err = agentVersion.Validate()
+	require.NoError(t, err)
+}
@@ -0,0 +1,86 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/internal/store/backup"
+)
+
+// Command returns the BindPlane backup cobra command
+func Command(bindplane *cli.BindPlane) *cobra.Command {
+	var includeMeasurements bool
+
+	cmd := &cobra.Command{
+		Use:   "backup <file>",
+		Short: "Backup the store to a file",
+		Long: `Writes every resource, agent, and optionally measurement in the store configured for the server to a
+backup file. Use 'bindplane backup -' to write the backup to stdout. The backup can be restored with
+'bindplane restore' using any store type. When using the bbolt store, the server must be stopped first.`,
+		Example: "bindplane backup bindplane-backup.tar.gz --measurements",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := &bindplane.Config.Server
+
+			// sessions are not used but a secret is required to create the store
+			if config.SessionsSecret == "" {
+				config.SessionsSecret = uuid.NewString()
+			}
+
+			st, err := store.NewStore(cmd.Context(), config, bindplane.Logger())
+			if err != nil {
+				return fmt.Errorf("failed to open the store: %w", err)
+			}
+
+			var out io.Writer
+			if args[0] == "-" {
+				out = cmd.OutOrStdout()
+			} else {
+				file, err := os.OpenFile(filepath.Clean(args[0]), os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0600)
+				if err != nil {
+					return fmt.Errorf("failed to create the backup file: %w", err)
+				}
+				defer file.Close()
+				out = file
+			}
+
+			manifest, err := backup.Backup(cmd.Context(), st, out, backup.Options{
+				IncludeMeasurements: includeMeasurements,
+			})
+			if err != nil {
+				return err
+			}
+
+			if args[0] != "-" {
+				fmt.Fprintf(cmd.OutOrStdout(), "Backed up %d resources, %d agents, and %d measurements to %s\n",
+					manifest.Resources, manifest.Agents, manifest.Measurements, args[0])
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().BoolVar(&includeMeasurements, "measurements", false, "include agent measurements in the backup")
+
+	return cmd
+}
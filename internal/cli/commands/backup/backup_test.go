@@ -0,0 +1,54 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+)
+
+func TestBackupCommand(t *testing.T) {
+	dir := t.TempDir()
+
+	bindplane := cli.NewBindPlaneForTesting()
+	bindplane.Config.Server.StorageFilePath = filepath.Join(dir, "storage")
+
+	t.Run("errors when the file is not present", func(t *testing.T) {
+		cmd := Command(bindplane)
+		cmd.SetArgs([]string{})
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("writes the backup to a file", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		backupFile := filepath.Join(dir, "backup.tar.gz")
+
+		cmd := Command(bindplane)
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{backupFile, "--measurements"})
+		require.NoError(t, cmd.Execute())
+
+		require.Equal(t, "Backed up 0 resources, 0 agents, and 0 measurements to "+backupFile+"\n", out.String())
+		info, err := os.Stat(backupFile)
+		require.NoError(t, err)
+		require.NotZero(t, info.Size())
+	})
+}
@@ -0,0 +1,39 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package campaign
+
+import (
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+// Command returns the BindPlane campaign cobra command.
+func Command(bindplane *cli.BindPlane) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "campaign",
+		Short:   "Create and control agent upgrade campaigns",
+		Long:    `Upgrade campaigns upgrade agents in batches. The next batch starts when every agent in the current batch has upgraded or failed, and the campaign is paused when too many upgrades fail.`,
+		Example: "bindplanectl campaign create prod-upgrade --selector env=prod --batch-size 10 --max-failure-percent 5",
+	}
+
+	cmd.AddCommand(
+		CreateCommand(bindplane),
+		StatusCommand(bindplane),
+		PauseCommand(bindplane),
+		ResumeCommand(bindplane),
+	)
+
+	return cmd
+}
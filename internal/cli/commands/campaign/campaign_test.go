@@ -0,0 +1,150 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package campaign
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBindPlane(buffer *bytes.Buffer) *cli.BindPlane {
+	bindplane := cli.NewBindPlane(common.InitConfig(""), buffer)
+	bindplane.SetClient(&mockClient{})
+	return bindplane
+}
+
+type mockClient struct {
+	client.BindPlane
+	created *model.UpgradeCampaign
+}
+
+func testCampaign(name string, status model.UpgradeCampaignStatus) *model.UpgradeCampaign {
+	campaign := &model.UpgradeCampaign{Name: name, Version: "v1.9.0"}
+	campaign.Start([]string{"1", "2"}, time.Now())
+	campaign.Status = status
+	return campaign
+}
+
+func (mc *mockClient) UpgradeCampaigns(ctx context.Context) ([]*model.UpgradeCampaign, error) {
+	return []*model.UpgradeCampaign{testCampaign("campaign-a", model.UpgradeCampaignRunning), testCampaign("campaign-b", model.UpgradeCampaignCompleted)}, nil
+}
+
+func (mc *mockClient) UpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	if name == "missing" {
+		return nil, errors.New("not found")
+	}
+	return testCampaign(name, model.UpgradeCampaignRunning), nil
+}
+
+func (mc *mockClient) CreateUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) (*model.UpgradeCampaign, error) {
+	mc.created = campaign
+	return testCampaign(campaign.Name, model.UpgradeCampaignRunning), nil
+}
+
+func (mc *mockClient) PauseUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	return testCampaign(name, model.UpgradeCampaignPaused), nil
+}
+
+func (mc *mockClient) ResumeUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	return nil, errors.New("upgrade campaign is completed")
+}
+
+func TestCampaignCreateCommand(t *testing.T) {
+	t.Run("creates the campaign with the flags", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		mc := &mockClient{}
+		bindplane := cli.NewBindPlane(common.InitConfig(""), out)
+		bindplane.SetClient(mc)
+
+		cmd := CreateCommand(bindplane)
+		cmd.SetArgs([]string{"prod", "--selector", "env=prod", "--batch-size", "10", "--concurrency", "2", "--max-failure-percent", "5"})
+
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, out.String(), "prod")
+		require.Equal(t, &model.UpgradeCampaign{
+			Name:              "prod",
+			Version:           "latest",
+			Selector:          "env=prod",
+			BatchSize:         10,
+			Concurrency:       2,
+			MaxFailurePercent: 5,
+		}, mc.created)
+	})
+
+	t.Run("requires a selector or query", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := CreateCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"prod"})
+
+		require.ErrorContains(t, cmd.Execute(), "must specify --selector or --query")
+	})
+}
+
+func TestCampaignStatusCommand(t *testing.T) {
+	t.Run("prints all campaigns", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := StatusCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{})
+
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, out.String(), "campaign-a")
+		require.Contains(t, out.String(), "campaign-b")
+		require.Contains(t, out.String(), "completed")
+	})
+
+	t.Run("returns the error for a missing campaign", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := StatusCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"missing"})
+
+		require.ErrorContains(t, cmd.Execute(), "not found")
+	})
+}
+
+func TestCampaignUpdateCommands(t *testing.T) {
+	t.Run("pause prints the paused campaign", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := PauseCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"campaign-a"})
+
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, out.String(), "paused")
+	})
+
+	t.Run("resume returns the error", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := ResumeCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"campaign-a"})
+
+		require.ErrorContains(t, cmd.Execute(), "unable to resume upgrade campaign campaign-a: upgrade campaign is completed")
+	})
+
+	t.Run("requires a name", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := PauseCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{})
+
+		require.Error(t, cmd.Execute())
+	})
+}
@@ -0,0 +1,69 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package campaign
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/cli/printer"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// CreateCommand returns the BindPlane campaign create cobra command.
+func CreateCommand(bindplane *cli.BindPlane) *cobra.Command {
+	campaign := &model.UpgradeCampaign{}
+
+	cmd := &cobra.Command{
+		Use:   "create <name>",
+		Short: "Create an upgrade campaign",
+		Long:  `Creates an upgrade campaign that upgrades the agents matching the selector or query in batches. Agents that do not support upgrade or already have the version are not included.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) != 1 {
+				return errors.New("must specify the name of the upgrade campaign")
+			}
+			if campaign.Selector == "" && campaign.Query == "" {
+				return errors.New("must specify --selector or --query")
+			}
+			campaign.Name = args[0]
+
+			c, err := bindplane.Client()
+			if err != nil {
+				return fmt.Errorf("error creating client: %w", err)
+			}
+
+			created, err := c.CreateUpgradeCampaign(cmd.Context(), campaign)
+			if err != nil {
+				return fmt.Errorf("unable to create upgrade campaign %s: %w", campaign.Name, err)
+			}
+
+			printer.PrintResource(bindplane.Printer(), created)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&campaign.Version, "version", "latest", "version of the agent to upgrade to")
+	cmd.Flags().StringVar(&campaign.Selector, "selector", "", "label query to select the agents to upgrade, e.g. env=prod")
+	cmd.Flags().StringVar(&campaign.Query, "query", "", "search query to select the agents to upgrade")
+	cmd.Flags().IntVar(&campaign.BatchSize, "batch-size", 0, "number of agents in each batch, or 0 to upgrade all agents in one batch")
+	cmd.Flags().IntVar(&campaign.Concurrency, "concurrency", 0, "maximum number of agents upgraded at the same time, or 0 for no limit")
+	cmd.Flags().IntVar(&campaign.MaxFailurePercent, "max-failure-percent", 0, "percentage of failed upgrades that pauses the campaign")
+	cmd.Flags().DurationVar(&campaign.Timeout, "timeout", 0, "time an agent has to complete its upgrade before it is considered failed, or 0 for the default of 30m")
+
+	return cmd
+}
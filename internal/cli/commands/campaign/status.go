@@ -0,0 +1,58 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package campaign
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/cli/printer"
+)
+
+// StatusCommand returns the BindPlane campaign status cobra command.
+func StatusCommand(bindplane *cli.BindPlane) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [name]",
+		Short: "Displays the progress of an upgrade campaign or all upgrade campaigns",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := bindplane.Client()
+			if err != nil {
+				return fmt.Errorf("error creating client: %w", err)
+			}
+
+			if len(args) > 0 {
+				campaign, err := c.UpgradeCampaign(cmd.Context(), args[0])
+				if err != nil {
+					return err
+				}
+				if campaign == nil {
+					return fmt.Errorf("no upgrade campaign found with name %s", args[0])
+				}
+				printer.PrintResource(bindplane.Printer(), campaign)
+				return nil
+			}
+
+			campaigns, err := c.UpgradeCampaigns(cmd.Context())
+			if err != nil {
+				return err
+			}
+			printer.PrintResources(bindplane.Printer(), campaigns)
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,73 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package campaign
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/cli/printer"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// PauseCommand returns the BindPlane campaign pause cobra command.
+func PauseCommand(bindplane *cli.BindPlane) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause <name>",
+		Short: "Pause an upgrade campaign",
+		Long:  `Pausing an upgrade campaign stops it from starting new upgrades. Agents that are already upgrading continue to upgrade.`,
+		RunE: updateImpl(bindplane, "pause", func(ctx context.Context, c client.BindPlane, name string) (*model.UpgradeCampaign, error) {
+			return c.PauseUpgradeCampaign(ctx, name)
+		}),
+	}
+}
+
+// ResumeCommand returns the BindPlane campaign resume cobra command.
+func ResumeCommand(bindplane *cli.BindPlane) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <name>",
+		Short: "Resume a paused upgrade campaign",
+		Long:  `Resuming an upgrade campaign continues the current batch or starts the next batch, even if the campaign was paused because too many upgrades failed.`,
+		RunE: updateImpl(bindplane, "resume", func(ctx context.Context, c client.BindPlane, name string) (*model.UpgradeCampaign, error) {
+			return c.ResumeUpgradeCampaign(ctx, name)
+		}),
+	}
+}
+
+func updateImpl(bindplane *cli.BindPlane, action string, update func(ctx context.Context, c client.BindPlane, name string) (*model.UpgradeCampaign, error)) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("must specify the name of the upgrade campaign")
+		}
+
+		c, err := bindplane.Client()
+		if err != nil {
+			return fmt.Errorf("error creating client: %w", err)
+		}
+
+		campaign, err := update(cmd.Context(), c, args[0])
+		if err != nil {
+			return fmt.Errorf("unable to %s upgrade campaign %s: %w", action, args[0], err)
+		}
+
+		printer.PrintResource(bindplane.Printer(), campaign)
+		return nil
+	}
+}
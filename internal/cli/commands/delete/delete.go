@@ -16,14 +16,20 @@ package delete
 
 import (
 	"fmt"
+	"io"
 
 	"github.com/spf13/cobra"
 
+	"github.com/observiq/bindplane-op/client"
 	"github.com/observiq/bindplane-op/internal/cli"
 	"github.com/observiq/bindplane-op/model"
 )
 
-var file string
+var (
+	file    string
+	cascade string
+	dryRun  bool
+)
 
 // Command returns the bindplane delete cobra command
 func Command(bindplane *cli.BindPlane) *cobra.Command {
@@ -47,6 +53,10 @@ func Command(bindplane *cli.BindPlane) *cobra.Command {
 				return fmt.Errorf("error unmarshaling file: %s, %w", file, err)
 			}
 
+			if cascade != "" || dryRun {
+				return deleteWithOptions(cmd, c, resources)
+			}
+
 			resourceStatuses, err := c.Delete(cmd.Context(), resources)
 			if err != nil {
 				return err
@@ -58,6 +68,9 @@ func Command(bindplane *cli.BindPlane) *cobra.Command {
 	}
 
 	cmd.Flags().StringVarP(&file, "file", "f", "", "delete resources from a file")
+	cmd.PersistentFlags().StringVar(&cascade, "cascade", "", "remove the references to the deleted resources from the resources that use them (references) or delete those resources too (delete)")
+	cmd.PersistentFlags().Lookup("cascade").NoOptDefVal = string(model.CascadeReferences)
+	cmd.PersistentFlags().BoolVar(&dryRun, "dry-run", false, "print the resources that would change and the agents whose configuration would change without deleting anything")
 
 	cmd.AddCommand(
 		deleteResourceCommand(bindplane, "agent", []string{"agents"}),
@@ -69,6 +82,9 @@ func Command(bindplane *cli.BindPlane) *cobra.Command {
 		deleteResourceCommand(bindplane, "processor-type", []string{"processor-types", "processorType", "processorTypes"}),
 		deleteResourceCommand(bindplane, "destination", []string{"destinations"}),
 		deleteResourceCommand(bindplane, "destination-type", []string{"destination-types", "destinationType", "destinationTypes"}),
+		deleteResourceCommand(bindplane, "extension-type", []string{"extension-types", "extensionType", "extensionTypes"}),
+		deleteResourceCommand(bindplane, "enrollment-token", []string{"enrollment-tokens", "enrollmentToken", "enrollmentTokens"}),
+		deleteResourceCommand(bindplane, "secret", []string{"secrets"}),
 	)
 
 	return cmd
@@ -92,6 +108,20 @@ func deleteResourceCommand(bindplane *cli.BindPlane, resourceType string, aliase
 			name := args[0]
 			batch := false
 
+			if cascade != "" || dryRun {
+				kind, ok := resourceKinds[resourceType]
+				if !ok {
+					return fmt.Errorf("--cascade and --dry-run are not supported when deleting %s", resourceType)
+				}
+				resources := make([]*model.AnyResource, 0, len(args))
+				for _, name := range args {
+					resources = append(resources, &model.AnyResource{
+						ResourceMeta: model.ResourceMeta{Kind: kind, Metadata: model.Metadata{Name: name}},
+					})
+				}
+				return deleteWithOptions(cmd, c, resources)
+			}
+
 			switch resourceType {
 			case "agent":
 				_, err = c.DeleteAgents(ctx, args)
@@ -112,6 +142,12 @@ func deleteResourceCommand(bindplane *cli.BindPlane, resourceType string, aliase
 				err = c.DeleteDestination(ctx, name)
 			case "destination-type":
 				err = c.DeleteDestinationType(ctx, name)
+			case "extension-type":
+				err = c.DeleteExtensionType(ctx, name)
+			case "enrollment-token":
+				err = c.DeleteEnrollmentToken(ctx, name)
+			case "secret":
+				err = c.DeleteSecret(ctx, name)
 			default:
 				return fmt.Errorf("unknown type, unable to delete %s '%s'", resourceType, name)
 			}
@@ -133,3 +169,57 @@ func deleteResourceCommand(bindplane *cli.BindPlane, resourceType string, aliase
 
 	return cmd
 }
+
+// resourceKinds maps the resource types of the delete subcommands that support --cascade and --dry-run to their kinds
+var resourceKinds = map[string]model.Kind{
+	"agent-version":    model.KindAgentVersion,
+	"configuration":    model.KindConfiguration,
+	"source":           model.KindSource,
+	"source-type":      model.KindSourceType,
+	"processor":        model.KindProcessor,
+	"processor-type":   model.KindProcessorType,
+	"destination":      model.KindDestination,
+	"destination-type": model.KindDestinationType,
+	"extension-type":   model.KindExtensionType,
+	"enrollment-token": model.KindEnrollmentToken,
+	"secret":           model.KindSecret,
+}
+
+// deleteWithOptions deletes the resources with the --cascade and --dry-run flags and prints the result
+func deleteWithOptions(cmd *cobra.Command, c client.BindPlane, resources []*model.AnyResource) error {
+	mode, err := model.ParseCascadeMode(cascade)
+	if err != nil {
+		return err
+	}
+
+	response, err := c.DeleteWithOptions(cmd.Context(), resources, mode, dryRun)
+	if err != nil {
+		return err
+	}
+
+	printDeleteResponse(cmd.OutOrStdout(), response)
+	return nil
+}
+
+// printDeleteResponse prints the resource updates and, for a dry run, the agents whose configuration would change
+func printDeleteResponse(writer io.Writer, response *model.DeleteResponseClientSide) {
+	if !response.DryRun {
+		model.PrintResourceUpdates(writer, response.Updates)
+		return
+	}
+
+	fmt.Fprintln(writer, "Dry run, no changes were made. The delete would make these changes:")
+	if len(response.Updates) == 0 {
+		fmt.Fprintln(writer, "No resources would change")
+	}
+	model.PrintResourceUpdates(writer, response.Updates)
+
+	if len(response.Agents) == 0 {
+		fmt.Fprintln(writer, "No agent configurations would change")
+		return
+	}
+	fmt.Fprintf(writer, "The configuration of %d agent(s) would change:\n", len(response.Agents))
+	for _, id := range response.Agents {
+		fmt.Fprintf(writer, "\t%s\n", id)
+	}
+}
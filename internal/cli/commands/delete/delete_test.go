@@ -71,6 +71,11 @@ func (m *mockClient) Delete(ctx context.Context, resources []*model.AnyResource)
 	return args.Get(0).([]*model.AnyResourceStatus), args.Error(1)
 }
 
+func (m *mockClient) DeleteWithOptions(ctx context.Context, resources []*model.AnyResource, cascade model.CascadeMode, dryRun bool) (*model.DeleteResponseClientSide, error) {
+	args := m.Called(ctx, resources, cascade, dryRun)
+	return args.Get(0).(*model.DeleteResponseClientSide), args.Error(1)
+}
+
 type deleteReturn struct {
 	deleted []*model.AnyResourceStatus
 	err     error
@@ -211,3 +216,83 @@ func runDeleteTests(t *testing.T, resourceType string, mockFuncName string) {
 		require.Equal(t, want, string(out))
 	})
 }
+
+func TestDeleteWithOptions(t *testing.T) {
+	destination := &model.AnyResource{
+		ResourceMeta: model.ResourceMeta{Kind: model.KindDestination, Metadata: model.Metadata{Name: "cabin-1"}},
+	}
+	configuration := &model.AnyResource{
+		ResourceMeta: model.ResourceMeta{Kind: model.KindConfiguration, Metadata: model.Metadata{Name: "configuration-1"}},
+	}
+
+	t.Run("prints the changes and agents of a dry run", func(t *testing.T) {
+		client := &mockClient{}
+		stub := cli.NewBindPlaneForTesting()
+		stub.SetClient(client)
+		client.On("DeleteWithOptions", mock.Anything, []*model.AnyResource{destination}, model.CascadeReferences, true).Return(
+			&model.DeleteResponseClientSide{
+				Updates: []*model.AnyResourceStatus{
+					{Resource: *configuration, Status: model.StatusConfigured},
+					{Resource: *destination, Status: model.StatusDeleted},
+				},
+				Agents: []string{"agent-1", "agent-2"},
+				DryRun: true,
+			}, nil)
+
+		cmd := Command(stub)
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{"destination", "cabin-1", "--cascade", "--dry-run"})
+		require.NoError(t, cmd.Execute())
+
+		require.Equal(t, `Dry run, no changes were made. The delete would make these changes:
+Configuration configuration-1 configured
+Destination cabin-1 deleted
+The configuration of 2 agent(s) would change:
+	agent-1
+	agent-2
+`, b.String())
+		client.AssertExpectations(t)
+	})
+
+	t.Run("deletes dependents with --cascade=delete", func(t *testing.T) {
+		client := &mockClient{}
+		stub := cli.NewBindPlaneForTesting()
+		stub.SetClient(client)
+		client.On("DeleteWithOptions", mock.Anything, []*model.AnyResource{destination}, model.CascadeDelete, false).Return(
+			&model.DeleteResponseClientSide{
+				Updates: []*model.AnyResourceStatus{
+					{Resource: *configuration, Status: model.StatusDeleted},
+					{Resource: *destination, Status: model.StatusDeleted},
+				},
+			}, nil)
+
+		cmd := Command(stub)
+		b := bytes.NewBufferString("")
+		cmd.SetOut(b)
+		cmd.SetArgs([]string{"destination", "cabin-1", "--cascade=delete"})
+		require.NoError(t, cmd.Execute())
+
+		require.Equal(t, "Configuration configuration-1 deleted\nDestination cabin-1 deleted\n", b.String())
+	})
+
+	t.Run("returns an error for an invalid cascade mode", func(t *testing.T) {
+		client := &mockClient{}
+		stub := cli.NewBindPlaneForTesting()
+		stub.SetClient(client)
+
+		cmd := Command(stub)
+		cmd.SetArgs([]string{"destination", "cabin-1", "--cascade=everything"})
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("returns an error when deleting agents", func(t *testing.T) {
+		client := &mockClient{}
+		stub := cli.NewBindPlaneForTesting()
+		stub.SetClient(client)
+
+		cmd := Command(stub)
+		cmd.SetArgs([]string{"agent", "1", "--dry-run"})
+		require.Error(t, cmd.Execute())
+	})
+}
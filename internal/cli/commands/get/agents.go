@@ -16,6 +16,7 @@ package get
 
 import (
 	"fmt"
+	"time"
 
 	"github.com/spf13/cobra"
 
@@ -31,6 +32,8 @@ func AgentsCommand(bindplane *cli.BindPlane) *cobra.Command {
 		query    string
 		limit    int
 		offset   int
+		history  bool
+		since    time.Duration
 	)
 	cmd := &cobra.Command{
 		Use:     "agents [id]",
@@ -45,6 +48,10 @@ func AgentsCommand(bindplane *cli.BindPlane) *cobra.Command {
 
 			if len(args) > 0 {
 				id := args[0]
+				if history {
+					return printAgentHistory(cmd, bindplane, c, id, since)
+				}
+
 				agent, err := c.Agent(cmd.Context(), id)
 				if err != nil {
 					return err
@@ -58,6 +65,10 @@ func AgentsCommand(bindplane *cli.BindPlane) *cobra.Command {
 				return nil
 			}
 
+			if history {
+				return fmt.Errorf("--history requires the id of an agent")
+			}
+
 			agents, err := c.Agents(cmd.Context(),
 				client.WithSelector(selector),
 				client.WithQuery(query),
@@ -77,6 +88,31 @@ func AgentsCommand(bindplane *cli.BindPlane) *cobra.Command {
 	cmd.Flags().StringVarP(&query, "query", "q", "", "search query to filter agents")
 	cmd.Flags().IntVar(&offset, "offset", 0, "number of agents to skip for paging")
 	cmd.Flags().IntVar(&limit, "limit", 100, "maximum number of agents to return")
+	cmd.Flags().BoolVar(&history, "history", false, "display the status changes and uptime of the agent with the specified id")
+	cmd.Flags().DurationVar(&since, "since", 0, "with --history, only include the specified duration of history, e.g. 24h")
 
 	return cmd
 }
+
+// printAgentHistory prints the status changes of the agent followed by its uptime. With json or yaml output the entire
+// history is printed.
+func printAgentHistory(cmd *cobra.Command, bindplane *cli.BindPlane, c client.BindPlane, id string, since time.Duration) error {
+	var start time.Time
+	if since > 0 {
+		start = time.Now().Add(-since)
+	}
+
+	history, err := c.AgentHistory(cmd.Context(), id, start, time.Time{})
+	if err != nil {
+		return err
+	}
+
+	switch bindplane.Config.Output {
+	case "json", "yaml":
+		printer.PrintResource(bindplane.Printer(), history)
+	default:
+		printer.PrintResources(bindplane.Printer(), history.Changes)
+		fmt.Fprintf(cmd.OutOrStdout(), "Uptime %.2f%% from %s to %s\n", history.Uptime, history.Start.Format(time.RFC3339), history.End.Format(time.RFC3339))
+	}
+	return nil
+}
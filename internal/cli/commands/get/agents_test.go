@@ -188,4 +188,27 @@ status: 1
 		executeErr := cmd.Execute()
 		require.Error(t, executeErr, "No agent found with ID badId")
 	})
+
+	t.Run("can print the history of an agent as a table", func(t *testing.T) {
+		buffer := bytes.NewBufferString("")
+		bindplane := setupBindPlane(buffer)
+		bindplane.Config.Output = tableOutput
+
+		cmd := AgentsCommand(bindplane)
+		cmd.SetOut(buffer)
+		cmd.SetArgs([]string{"1", "--history"})
+		expected := "TIMESTAMP           \tSTATUS   \tERROR           \n2022-08-01T10:00:00Z\tConnected\t-              \t\n2022-08-01T11:00:00Z\tError    \tfailed to start\t\nUptime 50.00% from 2022-08-01T10:00:00Z to 2022-08-01T12:00:00Z\n"
+
+		executeAndAssertOutput(t, cmd, buffer, expected)
+	})
+
+	t.Run("requires an agent id with --history", func(t *testing.T) {
+		buffer := bytes.NewBufferString("")
+		bindplane := setupBindPlane(buffer)
+
+		cmd := AgentsCommand(bindplane)
+		cmd.SetOut(buffer)
+		cmd.SetArgs([]string{"--history"})
+		require.Error(t, cmd.Execute())
+	})
 }
@@ -0,0 +1,69 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package get
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/cli/printer"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// AuditCommand returns the BindPlane get audit cobra command
+func AuditCommand(bindplane *cli.BindPlane) *cobra.Command {
+	var (
+		kind   string
+		name   string
+		user   string
+		limit  int
+		offset int
+	)
+	cmd := &cobra.Command{
+		Use:   "audit",
+		Short: "Displays the audit log",
+		Long:  `The audit log records each change made to resources and agents, newest first. Use -o json or -o yaml to see the changes.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := bindplane.Client()
+			if err != nil {
+				return fmt.Errorf("error creating client: %w", err)
+			}
+
+			entries, err := c.AuditEntries(cmd.Context(), model.AuditFilter{
+				Kind:   model.Kind(kind),
+				Name:   name,
+				User:   user,
+				Offset: offset,
+				Limit:  limit,
+			})
+			if err != nil {
+				return err
+			}
+
+			printer.PrintResources(bindplane.Printer(), entries)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&kind, "kind", "", "kind of resource to filter entries, e.g. Configuration or Agent")
+	cmd.Flags().StringVar(&name, "name", "", "name of the resource or id of the agent to filter entries")
+	cmd.Flags().StringVar(&user, "user", "", "user to filter entries")
+	cmd.Flags().IntVar(&offset, "offset", 0, "number of entries to skip for paging")
+	cmd.Flags().IntVar(&limit, "limit", 100, "maximum number of entries to return")
+
+	return cmd
+}
@@ -0,0 +1,47 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package get
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestAuditCommand(t *testing.T) {
+	t.Run("can print audit entries as a table", func(t *testing.T) {
+		buffer := bytes.NewBufferString("")
+		bindplane := setupBindPlane(buffer)
+		bindplane.Config.Output = tableOutput
+
+		cmd := AuditCommand(bindplane)
+		cmd.SetOut(buffer)
+		expected := "TIMESTAMP           \tUSER \tACTION\tKIND  \tNAME \tCHANGES \n2022-08-02T10:00:00Z\tadmin\tlabel \tAgent \t1    \t1      \t\n2022-08-01T10:00:00Z\t-    \tcreate\tSource\tnginx\t2      \t\n"
+
+		executeAndAssertOutput(t, cmd, buffer, expected)
+	})
+
+	t.Run("can filter audit entries by kind", func(t *testing.T) {
+		buffer := bytes.NewBufferString("")
+		bindplane := setupBindPlane(buffer)
+		bindplane.Config.Output = tableOutput
+
+		cmd := AuditCommand(bindplane)
+		cmd.SetOut(buffer)
+		cmd.SetArgs([]string{"--kind", "source"})
+		expected := "TIMESTAMP           \tUSER\tACTION\tKIND  \tNAME \tCHANGES \n2022-08-01T10:00:00Z\t-   \tcreate\tSource\tnginx\t2      \t\n"
+
+		executeAndAssertOutput(t, cmd, buffer, expected)
+	})
+}
@@ -25,6 +25,7 @@ import (
 
 // ConfigurationsCommand returns the BindPlane get configurations cobra command
 func ConfigurationsCommand(bindplane *cli.BindPlane) *cobra.Command {
+	var agentID string
 	cmd := &cobra.Command{
 		Use:     "configurations [name]",
 		Aliases: []string{"configuration", "configs", "config"},
@@ -47,7 +48,12 @@ func ConfigurationsCommand(bindplane *cli.BindPlane) *cobra.Command {
 				}
 
 				if bindplane.Config.Output == "raw" {
-					raw, err := c.RawConfiguration(cmd.Context(), name)
+					var raw string
+					if agentID != "" {
+						raw, err = c.AgentRawConfiguration(cmd.Context(), name, agentID)
+					} else {
+						raw, err = c.RawConfiguration(cmd.Context(), name)
+					}
 					if err != nil {
 						return err
 					}
@@ -71,5 +77,7 @@ func ConfigurationsCommand(bindplane *cli.BindPlane) *cobra.Command {
 		},
 	}
 
+	cmd.Flags().StringVar(&agentID, "agent", "", "id of an agent to render the raw configuration for with -o raw")
+
 	return cmd
 }
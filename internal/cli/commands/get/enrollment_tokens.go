@@ -0,0 +1,44 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package get
+
+import (
+	"context"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/spf13/cobra"
+)
+
+// EnrollmentTokensCommand returns the BindPlane get enrollment-tokens cobra command
+func EnrollmentTokensCommand(bindplane *cli.BindPlane) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "enrollment-tokens [name]",
+		Aliases: []string{"enrollment-token"},
+		Short:   "Displays the enrollment tokens",
+		Long:    `An enrollment token is used by agents to connect to BindPlane for the first time and receive their own credentials.`,
+		RunE: getImpl(bindplane, "enrollment-token", getter[*model.EnrollmentToken]{
+			one: func(ctx context.Context, client client.BindPlane, name string) (*model.EnrollmentToken, bool, error) {
+				item, err := client.EnrollmentToken(ctx, name)
+				return item, item != nil, err
+			},
+			all: func(ctx context.Context, client client.BindPlane) ([]*model.EnrollmentToken, error) {
+				return client.EnrollmentTokens(ctx)
+			},
+		}),
+	}
+	return cmd
+}
@@ -37,11 +37,15 @@ func Command(bindplane *cli.BindPlane) *cobra.Command {
 		ResourcesCommand(bindplane),
 		AgentsCommand(bindplane),
 		AgentVersionsCommand(bindplane),
+		AuditCommand(bindplane),
 		ConfigurationsCommand(bindplane),
 		DestinationsCommand(bindplane),
 		DestinationTypesCommand(bindplane),
+		EnrollmentTokensCommand(bindplane),
+		ExtensionTypesCommand(bindplane),
 		ProcessorsCommand(bindplane),
 		ProcessorTypesCommand(bindplane),
+		SecretsCommand(bindplane),
 		SourcesCommand(bindplane),
 		SourceTypesCommand(bindplane),
 	)
@@ -49,7 +49,10 @@ func ResourcesCommand(bindplane *cli.BindPlane) *cobra.Command {
 				{name: "source-types", get: func() ([]model.Printable, error) { return cvt(c.SourceTypes(ctx)) }},
 				{name: "processor-types", get: func() ([]model.Printable, error) { return cvt(c.ProcessorTypes(ctx)) }},
 				{name: "destination-types", get: func() ([]model.Printable, error) { return cvt(c.DestinationTypes(ctx)) }},
+				{name: "extension-types", get: func() ([]model.Printable, error) { return cvt(c.ExtensionTypes(ctx)) }},
 				{name: "agent-versions", get: func() ([]model.Printable, error) { return cvt(c.AgentVersions(ctx)) }},
+				{name: "enrollment-tokens", get: func() ([]model.Printable, error) { return cvt(c.EnrollmentTokens(ctx)) }},
+				{name: "secrets", get: func() ([]model.Printable, error) { return cvt(c.Secrets(ctx)) }},
 			}
 
 			switch p.(type) {
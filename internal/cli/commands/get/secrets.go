@@ -0,0 +1,63 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package get
+
+import (
+	"fmt"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/cli/printer"
+	"github.com/spf13/cobra"
+)
+
+// SecretsCommand returns the BindPlane get secrets cobra command
+func SecretsCommand(bindplane *cli.BindPlane) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "secrets [name]",
+		Aliases: []string{"secret"},
+		Short:   "Displays the secrets",
+		Long:    `A secret is a value referenced by the parameters of resources that is encrypted by the server. Values of secrets are never displayed.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := bindplane.Client()
+			if err != nil {
+				return fmt.Errorf("error creating client: %w", err)
+			}
+
+			if len(args) > 0 {
+				name := args[0]
+				secret, err := c.Secret(cmd.Context(), name)
+				if err != nil {
+					return err
+				}
+
+				if secret == nil {
+					return fmt.Errorf("no secret found with name %s", name)
+				}
+
+				printer.PrintResource(bindplane.Printer(), secret)
+				return nil
+			}
+
+			secrets, err := c.Secrets(cmd.Context())
+			if err != nil {
+				return err
+			}
+
+			printer.PrintResources(bindplane.Printer(), secrets)
+			return nil
+		},
+	}
+	return cmd
+}
@@ -17,8 +17,10 @@ package get
 import (
 	"bytes"
 	"context"
+	"fmt"
 	"io/ioutil"
 	"testing"
+	"time"
 
 	"github.com/spf13/cobra"
 	"github.com/stretchr/testify/mock"
@@ -81,6 +83,50 @@ func (c *mockClient) Agent(ctx context.Context, id string) (*model.Agent, error)
 	return nil, nil
 }
 
+func (c *mockClient) AgentHistory(ctx context.Context, id string, start, end time.Time) (*model.AgentHistory, error) {
+	if id != "1" {
+		return nil, fmt.Errorf("unable to get agent history, got 404 Not Found")
+	}
+	changes := []*model.AgentStatusChange{
+		{AgentID: "1", Timestamp: time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC), Status: model.Connected},
+		{AgentID: "1", Timestamp: time.Date(2022, 8, 1, 11, 0, 0, 0, time.UTC), Status: model.Error, ErrorMessage: "failed to start"},
+	}
+	if end.IsZero() {
+		end = time.Date(2022, 8, 1, 12, 0, 0, 0, time.UTC)
+	}
+	return model.NewAgentHistory(changes, start, end), nil
+}
+
+func (c *mockClient) AuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error) {
+	entries := []*model.AuditEntry{
+		{
+			ID:        "2",
+			Timestamp: time.Date(2022, 8, 2, 10, 0, 0, 0, time.UTC),
+			User:      "admin",
+			Action:    model.AuditActionLabel,
+			Kind:      model.KindAgent,
+			Name:      "1",
+			Changes:   []string{"labels.env: dev -> prod"},
+		},
+		{
+			ID:        "1",
+			Timestamp: time.Date(2022, 8, 1, 10, 0, 0, 0, time.UTC),
+			Action:    model.AuditActionCreate,
+			Kind:      model.KindSource,
+			Name:      "nginx",
+			Changes:   []string{"kind: (added) -> Source", "metadata.name: (added) -> nginx"},
+		},
+	}
+
+	var result []*model.AuditEntry
+	for _, entry := range entries {
+		if filter.Matches(entry) {
+			result = append(result, entry)
+		}
+	}
+	return result, nil
+}
+
 func executeAndAssertOutput(t *testing.T, cmd *cobra.Command, buffer *bytes.Buffer, expected string) {
 	executeErr := cmd.Execute()
 	require.NoError(t, executeErr, "error while executing command")
@@ -25,11 +25,12 @@ import (
 )
 
 var (
-	platformFlag  string
-	versionFlag   string
-	labelsFlag    string
-	secretKeyFlag string
-	remoteURLFlag string
+	platformFlag        string
+	versionFlag         string
+	labelsFlag          string
+	secretKeyFlag       string
+	enrollmentTokenFlag string
+	remoteURLFlag       string
 )
 
 // AgentCommand returns the BindPlane install agent cobra command
@@ -45,11 +46,12 @@ func AgentCommand(bindplane *cli.BindPlane) *cobra.Command {
 			}
 
 			command, err := c.AgentInstallCommand(cmd.Context(), client.AgentInstallOptions{
-				Version:   versionFlag,
-				Labels:    labelsFlag,
-				Platform:  platformFlag,
-				SecretKey: secretKeyFlag,
-				RemoteURL: remoteURLFlag,
+				Version:         versionFlag,
+				Labels:          labelsFlag,
+				Platform:        platformFlag,
+				SecretKey:       secretKeyFlag,
+				EnrollmentToken: enrollmentTokenFlag,
+				RemoteURL:       remoteURLFlag,
 			})
 			if err != nil {
 				return err
@@ -76,6 +78,7 @@ func AgentCommand(bindplane *cli.BindPlane) *cobra.Command {
 	cmd.Flags().StringVar(&versionFlag, "version", "latest", "version of the agent to install")
 	cmd.Flags().StringVar(&labelsFlag, "labels", "", "labels to apply to the new agent")
 	cmd.Flags().StringVar(&secretKeyFlag, "secret-key", "", "secret-key to assign to the agent")
+	cmd.Flags().StringVar(&enrollmentTokenFlag, "enrollment-token", "", "name of the enrollment token the agent will use to enroll in place of the secret-key")
 	cmd.Flags().StringVar(&remoteURLFlag, "remote-url", "", "websocket address of the BindPlane agent management platform")
 
 	return cmd
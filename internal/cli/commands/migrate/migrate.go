@@ -0,0 +1,89 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/store"
+)
+
+// Command returns the BindPlane migrate cobra command
+func Command(bindplane *cli.BindPlane) *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate the storage schema",
+		Long: `Applies pending migrations to the bbolt storage file. Migrations also run automatically when the server
+starts. A copy of the storage file is saved before any migrations are applied. Use --dry-run to list the pending
+migrations without applying them. The server must be stopped first.`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := &bindplane.Config.Server
+			switch config.StoreType {
+			case "", common.StoreTypeBbolt:
+			default:
+				return fmt.Errorf("migrations are only supported for the %s store", common.StoreTypeBbolt)
+			}
+
+			db, err := store.InitDB(config.BoltDatabasePath())
+			if err != nil {
+				return err
+			}
+			defer db.Close()
+
+			out := cmd.OutOrStdout()
+
+			version, err := store.BoltSchemaVersion(db)
+			if err != nil {
+				return err
+			}
+			pending, err := store.PendingBoltMigrations(db)
+			if err != nil {
+				return err
+			}
+			if len(pending) == 0 {
+				fmt.Fprintf(out, "Storage is up to date at schema version %d\n", version)
+				return nil
+			}
+
+			if dryRun {
+				fmt.Fprintf(out, "Storage is at schema version %d, pending migrations:\n", version)
+				for _, migration := range pending {
+					fmt.Fprintf(out, "  %d: %s\n", migration.Version, migration.Description)
+				}
+				return nil
+			}
+
+			applied, backupPath, err := store.MigrateBolt(db, bindplane.Logger())
+			if backupPath != "" {
+				fmt.Fprintf(out, "Saved a backup of storage to %s\n", backupPath)
+			}
+			for _, migration := range applied {
+				fmt.Fprintf(out, "Applied migration %d: %s\n", migration.Version, migration.Description)
+			}
+			return err
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "list pending migrations without applying them")
+
+	return cmd
+}
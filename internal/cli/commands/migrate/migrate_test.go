@@ -0,0 +1,71 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package migrate
+
+import (
+	"bytes"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/internal/cli"
+)
+
+func TestMigrateCommand(t *testing.T) {
+	bindplane := cli.NewBindPlaneForTesting()
+	bindplane.Config.Server.StorageFilePath = filepath.Join(t.TempDir(), "storage")
+
+	run := func(args ...string) (string, error) {
+		out := bytes.NewBufferString("")
+		cmd := Command(bindplane)
+		cmd.SetOut(out)
+		cmd.SetArgs(args)
+		err := cmd.Execute()
+		return out.String(), err
+	}
+
+	t.Run("dry run lists pending migrations", func(t *testing.T) {
+		out, err := run("--dry-run")
+		require.NoError(t, err)
+		require.Equal(t, `Storage is at schema version 0, pending migrations:
+  1: restore case sensitive resource keys
+  2: add revision history for existing resources
+`, out)
+	})
+
+	t.Run("applies pending migrations", func(t *testing.T) {
+		out, err := run()
+		require.NoError(t, err)
+		require.Equal(t, `Applied migration 1: restore case sensitive resource keys
+Applied migration 2: add revision history for existing resources
+`, out)
+	})
+
+	t.Run("up to date", func(t *testing.T) {
+		out, err := run("--dry-run")
+		require.NoError(t, err)
+		require.Equal(t, "Storage is up to date at schema version 2\n", out)
+	})
+
+	t.Run("errors for other store types", func(t *testing.T) {
+		bindplane.Config.Server.StoreType = common.StoreTypePostgres
+		defer func() { bindplane.Config.Server.StoreType = "" }()
+
+		_, err := run()
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,71 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restart
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/cli/printer"
+)
+
+// AgentsCommand returns the BindPlane restart agents cobra command.
+func AgentsCommand(bindplane *cli.BindPlane) *cobra.Command {
+	var (
+		selector string
+		query    string
+	)
+	cmd := &cobra.Command{
+		Use:     "agents [id...]",
+		Aliases: []string{"agent"},
+		Short:   "Restart agents by id, label selector, or search query.",
+		Long:    `Requests a restart of connected agents. Agents restart the next time they receive a message from the server.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 && selector == "" && query == "" {
+				return errors.New("must specify the ids of the agents, a --selector, or a --query")
+			}
+
+			c, err := bindplane.Client()
+			if err != nil {
+				return fmt.Errorf("error creating client: %w", err)
+			}
+
+			agents, err := c.RestartAgents(cmd.Context(), args,
+				client.WithSelector(selector),
+				client.WithQuery(query),
+			)
+			if err != nil {
+				return err
+			}
+
+			if len(agents) == 0 {
+				fmt.Fprintln(cmd.OutOrStdout(), "No connected agents found to restart.")
+				return nil
+			}
+
+			printer.PrintResources(bindplane.Printer(), agents)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&selector, "selector", "l", "", "label selector to restart agents by label, e.g. name=value")
+	cmd.Flags().StringVarP(&query, "query", "q", "", "search query to restart the matching agents")
+
+	return cmd
+}
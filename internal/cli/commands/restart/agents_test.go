@@ -0,0 +1,88 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restart
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBindPlane(buffer *bytes.Buffer) *cli.BindPlane {
+	bindplane := cli.NewBindPlane(common.InitConfig(""), buffer)
+	bindplane.SetClient(&mockClient{})
+	return bindplane
+}
+
+type mockClient struct {
+	client.BindPlane
+}
+
+var gotIDs []string
+
+func (mc *mockClient) RestartAgents(ctx context.Context, ids []string, options ...client.QueryOption) ([]*model.Agent, error) {
+	gotIDs = ids
+	for _, id := range ids {
+		if id == "missing" {
+			return []*model.Agent{}, nil
+		}
+	}
+	agents := []*model.Agent{}
+	for _, id := range ids {
+		agents = append(agents, &model.Agent{ID: id, Name: "agent-" + id, Status: model.Restarting})
+	}
+	return agents, nil
+}
+
+func TestRestartAgentsCommand(t *testing.T) {
+	t.Run("errors when no ids, selector, or query are specified", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := AgentsCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{})
+
+		err := cmd.Execute()
+		require.Error(t, err)
+	})
+
+	t.Run("calls RestartAgents with the ids and prints the agents", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := AgentsCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"1", "2"})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "2"}, gotIDs)
+		require.Contains(t, out.String(), "agent-1")
+		require.Contains(t, out.String(), "agent-2")
+		require.Contains(t, out.String(), "Restarting")
+	})
+
+	t.Run("reports when no agents will be restarted", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := AgentsCommand(setupBindPlane(out))
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"missing"})
+
+		err := cmd.Execute()
+		require.NoError(t, err)
+		require.Contains(t, out.String(), "No connected agents found to restart.")
+	})
+}
@@ -0,0 +1,35 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restart
+
+import (
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+// Command returns the BindPlane restart cobra command.
+func Command(bindplane *cli.BindPlane) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "restart",
+		Short:   "Restart agents remotely",
+		Example: "bindplanectl restart agents -l env=production",
+	}
+
+	cmd.AddCommand(
+		AgentsCommand(bindplane),
+	)
+
+	return cmd
+}
@@ -0,0 +1,98 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/google/uuid"
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/internal/store/backup"
+)
+
+// Command returns the BindPlane restore cobra command
+func Command(bindplane *cli.BindPlane) *cobra.Command {
+	var backupSecretsKey string
+
+	cmd := &cobra.Command{
+		Use:   "restore <file>",
+		Short: "Restore the store from a backup file",
+		Long: `Restores a backup created by 'bindplane backup' into the store configured for the server. Use
+'bindplane restore -' to read the backup from stdin. Resources that already exist are updated. To migrate to a
+different store type, use --config with the configuration of the new store. Secrets are encrypted again with the
+secretsKey of the server. If the backup was created by a server with a different secretsKey, specify it with
+--backup-secrets-key. When using the bbolt store, the server must be stopped first.`,
+		Example: "bindplane restore bindplane-backup.tar.gz --config /etc/bindplane/postgres.yaml",
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var in io.Reader
+			if args[0] == "-" {
+				in = cmd.InOrStdin()
+			} else {
+				file, err := os.Open(filepath.Clean(args[0]))
+				if err != nil {
+					return fmt.Errorf("failed to open the backup file: %w", err)
+				}
+				defer file.Close()
+				in = file
+			}
+
+			config := &bindplane.Config.Server
+
+			// sessions are not used but a secret is required to create the store
+			if config.SessionsSecret == "" {
+				config.SessionsSecret = uuid.NewString()
+			}
+
+			st, err := store.NewStore(cmd.Context(), config, bindplane.Logger())
+			if err != nil {
+				return fmt.Errorf("failed to open the store: %w", err)
+			}
+
+			manifest, statuses, err := backup.Restore(cmd.Context(), st, in, backup.RestoreOptions{
+				SecretsKey:       config.SecretsEncryptionKey(),
+				BackupSecretsKey: backupSecretsKey,
+			})
+			if errors.Is(err, backup.ErrBackupSecretsKey) {
+				return fmt.Errorf("%w, use --backup-secrets-key with the secretsKey of the server that created the backup", err)
+			}
+			for _, status := range statuses {
+				if status.Reason != "" {
+					fmt.Fprintf(cmd.OutOrStdout(), "%s\n\t%s\n", status.String(), status.Reason)
+				} else {
+					fmt.Fprintln(cmd.OutOrStdout(), status.String())
+				}
+			}
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Restored %d resources, %d agents, and %d measurements from a backup created %s\n",
+				manifest.Resources, manifest.Agents, manifest.Measurements, manifest.Created.Format("2006-01-02 15:04:05 MST"))
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&backupSecretsKey, "backup-secrets-key", "", "secretsKey of the server that created the backup, if different")
+
+	return cmd
+}
@@ -0,0 +1,102 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package restore
+
+import (
+	"bytes"
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/internal/store/backup"
+	"github.com/observiq/bindplane-op/model"
+)
+
+func TestRestoreCommand(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// create a backup of a store with one resource
+	source := store.NewMapStore(ctx, store.Options{SessionsSecret: "secret"}, zap.NewNop())
+	_, err := source.ApplyResources(ctx, []model.Resource{
+		model.NewRawConfiguration("raw", "receivers:"),
+	})
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	_, err = backup.Backup(ctx, source, &archive, backup.Options{})
+	require.NoError(t, err)
+
+	bindplane := cli.NewBindPlaneForTesting()
+	bindplane.Config.Server.StorageFilePath = filepath.Join(t.TempDir(), "storage")
+
+	t.Run("errors when the file is not present", func(t *testing.T) {
+		cmd := Command(bindplane)
+		cmd.SetArgs([]string{})
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("errors when the file does not exist", func(t *testing.T) {
+		cmd := Command(bindplane)
+		cmd.SetArgs([]string{filepath.Join(t.TempDir(), "missing.tar.gz")})
+		require.Error(t, cmd.Execute())
+	})
+
+	t.Run("restores the backup from stdin", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+
+		cmd := Command(bindplane)
+		cmd.SetIn(&archive)
+		cmd.SetOut(out)
+		cmd.SetArgs([]string{"-"})
+		require.NoError(t, cmd.Execute())
+
+		require.Contains(t, out.String(), "Configuration raw created\n")
+		require.Contains(t, out.String(), "Restored 1 resources, 0 agents, and 0 measurements")
+	})
+}
+
+func TestRestoreCommandSecretsKey(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	// create a backup of a store with a secret encrypted by another server
+	source := store.NewMapStore(ctx, store.Options{SessionsSecret: "secret"}, zap.NewNop())
+	secret := model.NewSecret("token", "s3cr3t")
+	require.NoError(t, secret.Encrypt("source-key"))
+	_, err := source.ApplyResources(ctx, []model.Resource{secret})
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	_, err = backup.Backup(ctx, source, &archive, backup.Options{})
+	require.NoError(t, err)
+
+	bindplane := cli.NewBindPlaneForTesting()
+	bindplane.Config.Server.StorageFilePath = filepath.Join(t.TempDir(), "storage")
+	bindplane.Config.Server.SecretsKey = "target-key"
+
+	cmd := Command(bindplane)
+	cmd.SetIn(&archive)
+	cmd.SetOut(bytes.NewBufferString(""))
+	cmd.SetArgs([]string{"-"})
+	err = cmd.Execute()
+	require.ErrorIs(t, err, backup.ErrBackupSecretsKey)
+	require.ErrorContains(t, err, "use --backup-secrets-key")
+}
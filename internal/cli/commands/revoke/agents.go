@@ -0,0 +1,60 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revoke
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/cli/printer"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// AgentsCommand returns the BindPlane revoke agents cobra command.
+func AgentsCommand(bindplane *cli.BindPlane) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "agents <id...>",
+		Aliases: []string{"agent"},
+		Short:   "Revoke the credentials of agents by id.",
+		Long:    `Revokes the credentials issued to agents. The agents are disconnected and cannot reconnect or enroll again with the same agent ID, even after they are deleted.`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				return errors.New("must specify the ids of the agents")
+			}
+
+			c, err := bindplane.Client()
+			if err != nil {
+				return fmt.Errorf("error creating client: %w", err)
+			}
+
+			agents := make([]*model.Agent, 0, len(args))
+			for _, id := range args {
+				agent, err := c.RevokeAgentCredentials(cmd.Context(), id)
+				if err != nil {
+					return fmt.Errorf("unable to revoke the credentials of agent %s: %w", id, err)
+				}
+				agents = append(agents, agent)
+			}
+
+			printer.PrintResources(bindplane.Printer(), agents)
+			return nil
+		},
+	}
+
+	return cmd
+}
@@ -0,0 +1,35 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package revoke
+
+import (
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+// Command returns the BindPlane revoke cobra command.
+func Command(bindplane *cli.BindPlane) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:     "revoke",
+		Short:   "Revoke the credentials of agents",
+		Example: "bindplanectl revoke agents 01GF8XS8S0YSTZGBG6G7EPKGWR",
+	}
+
+	cmd.AddCommand(
+		AgentsCommand(bindplane),
+	)
+
+	return cmd
+}
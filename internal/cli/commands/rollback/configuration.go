@@ -0,0 +1,59 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollback
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/spf13/cobra"
+)
+
+// ConfigurationCommand returns the BindPlane rollback configuration cobra command.
+func ConfigurationCommand(bindplane *cli.BindPlane) *cobra.Command {
+	var revisionFlag int
+
+	cmd := &cobra.Command{
+		Use:     "configuration <name>",
+		Aliases: []string{"config"},
+		Short:   "Rollback a configuration resource to a previous revision.",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) < 1 {
+				return errors.New("missing required argument, must specify the configuration name")
+			}
+			if revisionFlag < 1 {
+				return errors.New("missing required flag, must specify the --revision to restore")
+			}
+
+			c, err := bindplane.Client()
+			if err != nil {
+				return err
+			}
+
+			revision, err := c.RollbackConfiguration(cmd.Context(), args[0], revisionFlag)
+			if err != nil {
+				return err
+			}
+
+			fmt.Fprintf(cmd.OutOrStdout(), "Successfully rolled back configuration %s to revision %d as revision %d.\n", args[0], revisionFlag, revision)
+			return nil
+		},
+	}
+
+	cmd.Flags().IntVar(&revisionFlag, "revision", 0, "revision of the configuration to restore")
+
+	return cmd
+}
@@ -0,0 +1,75 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollback
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBindPlane(buffer *bytes.Buffer) *cli.BindPlane {
+	bindplane := cli.NewBindPlane(common.InitConfig(""), buffer)
+	bindplane.SetClient(&mockClient{})
+	return bindplane
+}
+
+type mockClient struct {
+	client.BindPlane
+}
+
+var gotArgs []any
+
+func (mc *mockClient) RollbackConfiguration(ctx context.Context, name string, revision int) (int, error) {
+	gotArgs = []any{name, revision}
+	return revision + 1, nil
+}
+
+func TestRollbackConfigurationCommand(t *testing.T) {
+	out := bytes.NewBufferString("")
+	bp := setupBindPlane(out)
+	t.Run("errors when the name is not present", func(t *testing.T) {
+		cmd := ConfigurationCommand(bp)
+		cmd.SetArgs([]string{"--revision", "1"})
+		err := cmd.Execute()
+
+		require.Error(t, err)
+	})
+
+	t.Run("errors when the revision is not present", func(t *testing.T) {
+		cmd := ConfigurationCommand(bp)
+		cmd.SetArgs([]string{"blah"})
+		err := cmd.Execute()
+
+		require.Error(t, err)
+	})
+
+	t.Run("calls RollbackConfiguration with correct args", func(t *testing.T) {
+		cmd := ConfigurationCommand(bp)
+		cmd.SetArgs([]string{"blah", "--revision", "2"})
+		cmd.SetOut(out)
+
+		err := cmd.Execute()
+
+		require.NoError(t, err)
+		require.Equal(t, []any{"blah", 2}, gotArgs)
+		require.Equal(t, "Successfully rolled back configuration blah to revision 2 as revision 3.\n", out.String())
+	})
+}
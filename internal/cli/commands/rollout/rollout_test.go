@@ -0,0 +1,136 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/model"
+	"github.com/stretchr/testify/require"
+)
+
+func setupBindPlane(buffer *bytes.Buffer) *cli.BindPlane {
+	bindplane := cli.NewBindPlane(common.InitConfig(""), buffer)
+	bindplane.SetClient(&mockClient{})
+	return bindplane
+}
+
+type mockClient struct {
+	client.BindPlane
+}
+
+func testRollout(name string, status model.RolloutStatus) *model.Rollout {
+	rollout := model.NewRollout(model.NewConfiguration(name), 1, time.Now())
+	rollout.Revision = 2
+	rollout.Status = status
+	return rollout
+}
+
+func (mc *mockClient) Rollouts(ctx context.Context) ([]*model.Rollout, error) {
+	return []*model.Rollout{testRollout("config-a", model.RolloutRunning), testRollout("config-b", model.RolloutCompleted)}, nil
+}
+
+func (mc *mockClient) Rollout(ctx context.Context, name string) (*model.Rollout, error) {
+	if name == "missing" {
+		return nil, errors.New("not found")
+	}
+	return testRollout(name, model.RolloutRunning), nil
+}
+
+func (mc *mockClient) PauseRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return testRollout(name, model.RolloutPaused), nil
+}
+
+func (mc *mockClient) ResumeRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return testRollout(name, model.RolloutRunning), nil
+}
+
+func (mc *mockClient) AbortRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return nil, errors.New("rollout is not active")
+}
+
+func TestRolloutStatusCommand(t *testing.T) {
+	t.Run("prints all rollouts", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := StatusCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{})
+
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, out.String(), "config-a")
+		require.Contains(t, out.String(), "config-b")
+		require.Contains(t, out.String(), "completed")
+	})
+
+	t.Run("prints one rollout", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := StatusCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"config-a"})
+
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, out.String(), "config-a")
+		require.Contains(t, out.String(), "running")
+	})
+
+	t.Run("returns the error for a missing rollout", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := StatusCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"missing"})
+
+		require.ErrorContains(t, cmd.Execute(), "not found")
+	})
+}
+
+func TestRolloutUpdateCommands(t *testing.T) {
+	t.Run("pause prints the paused rollout", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := PauseCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"config-a"})
+
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, out.String(), "paused")
+	})
+
+	t.Run("resume prints the running rollout", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := ResumeCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"config-a"})
+
+		require.NoError(t, cmd.Execute())
+		require.Contains(t, out.String(), "running")
+	})
+
+	t.Run("abort returns the error", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := AbortCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{"config-a"})
+
+		require.ErrorContains(t, cmd.Execute(), "unable to abort the rollout of config-a: rollout is not active")
+	})
+
+	t.Run("requires a name", func(t *testing.T) {
+		out := bytes.NewBufferString("")
+		cmd := PauseCommand(setupBindPlane(out))
+		cmd.SetArgs([]string{})
+
+		require.Error(t, cmd.Execute())
+	})
+}
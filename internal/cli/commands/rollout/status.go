@@ -0,0 +1,58 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/cli/printer"
+)
+
+// StatusCommand returns the BindPlane rollout status cobra command.
+func StatusCommand(bindplane *cli.BindPlane) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "status [name]",
+		Short: "Displays the rollout of a configuration or the rollouts of all configurations",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			c, err := bindplane.Client()
+			if err != nil {
+				return fmt.Errorf("error creating client: %w", err)
+			}
+
+			if len(args) > 0 {
+				rollout, err := c.Rollout(cmd.Context(), args[0])
+				if err != nil {
+					return err
+				}
+				if rollout == nil {
+					return fmt.Errorf("no rollout found for configuration %s", args[0])
+				}
+				printer.PrintResource(bindplane.Printer(), rollout)
+				return nil
+			}
+
+			rollouts, err := c.Rollouts(cmd.Context())
+			if err != nil {
+				return err
+			}
+			printer.PrintResources(bindplane.Printer(), rollouts)
+			return nil
+		},
+	}
+	return cmd
+}
@@ -0,0 +1,84 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package rollout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/internal/cli"
+	"github.com/observiq/bindplane-op/internal/cli/printer"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// PauseCommand returns the BindPlane rollout pause cobra command.
+func PauseCommand(bindplane *cli.BindPlane) *cobra.Command {
+	return &cobra.Command{
+		Use:   "pause <name>",
+		Short: "Pause the rollout of a configuration",
+		Long:  `Pausing a rollout stops it from starting new waves. Agents that have not been updated continue to use the previous revision of the configuration.`,
+		RunE: updateImpl(bindplane, "pause", func(ctx context.Context, c client.BindPlane, name string) (*model.Rollout, error) {
+			return c.PauseRollout(ctx, name)
+		}),
+	}
+}
+
+// ResumeCommand returns the BindPlane rollout resume cobra command.
+func ResumeCommand(bindplane *cli.BindPlane) *cobra.Command {
+	return &cobra.Command{
+		Use:   "resume <name>",
+		Short: "Resume the paused rollout of a configuration",
+		RunE: updateImpl(bindplane, "resume", func(ctx context.Context, c client.BindPlane, name string) (*model.Rollout, error) {
+			return c.ResumeRollout(ctx, name)
+		}),
+	}
+}
+
+// AbortCommand returns the BindPlane rollout abort cobra command.
+func AbortCommand(bindplane *cli.BindPlane) *cobra.Command {
+	return &cobra.Command{
+		Use:   "abort <name>",
+		Short: "Abort the rollout of a configuration",
+		Long:  `Aborting a rollout stops it and restores the previous revision of the configuration on every agent.`,
+		RunE: updateImpl(bindplane, "abort", func(ctx context.Context, c client.BindPlane, name string) (*model.Rollout, error) {
+			return c.AbortRollout(ctx, name)
+		}),
+	}
+}
+
+func updateImpl(bindplane *cli.BindPlane, action string, update func(ctx context.Context, c client.BindPlane, name string) (*model.Rollout, error)) func(cmd *cobra.Command, args []string) error {
+	return func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("must specify the name of the configuration")
+		}
+
+		c, err := bindplane.Client()
+		if err != nil {
+			return fmt.Errorf("error creating client: %w", err)
+		}
+
+		rollout, err := update(cmd.Context(), c, args[0])
+		if err != nil {
+			return fmt.Errorf("unable to %s the rollout of %s: %w", action, args[0], err)
+		}
+
+		printer.PrintResource(bindplane.Printer(), rollout)
+		return nil
+	}
+}
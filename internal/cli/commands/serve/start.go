@@ -46,6 +46,7 @@ import (
 	"github.com/observiq/bindplane-op/internal/server/sessions"
 	"github.com/observiq/bindplane-op/internal/store"
 	"github.com/observiq/bindplane-op/internal/store/search"
+	"github.com/observiq/bindplane-op/model"
 	"github.com/observiq/bindplane-op/ui"
 )
 
@@ -220,33 +221,7 @@ func (s *Server) createStore(config *common.Server) (store.Store, error) {
 		return nil, errors.New("cannot create store with unset value for sessions-secret, run bindplane init server to set value")
 	}
 
-	switch config.StoreType {
-	case common.StoreTypeMap:
-		return store.NewMapStore(context.Background(), store.Options{
-			SessionsSecret:   config.SessionsSecret,
-			MaxEventsToMerge: 100,
-		}, s.logger), nil
-
-	case common.StoreTypeGoogleCloud:
-		s.logger.Info("Using Google Cloud Datastore and Pub/Sub")
-		return store.NewGoogleCloudStore(context.Background(), config, s.logger)
-
-	default:
-		// case common.StoreTypeBbolt:
-		storageFilePath := config.BoltDatabasePath()
-
-		db, err := store.InitDB(storageFilePath)
-		// Exit if DB creation is unsuccessful.
-		if err != nil {
-			return nil, fmt.Errorf("BBolt storage file failed to open: %w", err)
-		}
-
-		s.logger.Info("Using BBolt Storage", zap.String("storageFilePath", storageFilePath))
-		return store.NewBoltStore(context.Background(), db, store.Options{
-			SessionsSecret:   config.SessionsSecret,
-			MaxEventsToMerge: 100,
-		}, s.logger), nil
-	}
+	return store.NewStore(context.Background(), config, s.logger)
 }
 
 func (s *Server) createVersions(ctx context.Context, config *common.Server, st store.Store) agent.Versions {
@@ -284,6 +259,10 @@ func (s *Server) seedSearchIndexes(ctx context.Context, store store.Store) {
 	if err != nil {
 		s.logger.Error("unable to seed agents into the search index, search results will be empty", zap.Error(err))
 	}
+	err = seedResourceIndexes(ctx, store)
+	if err != nil {
+		s.logger.Error("unable to seed resources into the search indexes, search results will be empty", zap.Error(err))
+	}
 }
 
 func seedConfigurationsIndex(ctx context.Context, s store.Store) error {
@@ -295,14 +274,78 @@ func seedConfigurationsIndex(ctx context.Context, s store.Store) error {
 }
 
 func seedAgentsIndex(ctx context.Context, s store.Store) error {
+	index := s.AgentIndex(ctx)
+
+	// avoid reading every agent if a persisted index already contains all of them
+	if persistent, ok := index.(search.PersistentIndex); ok && persistent.Loaded() {
+		count, err := s.AgentsCount(ctx)
+		if err != nil {
+			return err
+		}
+		if count == persistent.Size() {
+			return nil
+		}
+	}
+
 	agents, err := s.Agents(ctx)
 	if err != nil {
 		return err
 	}
-	return seedIndex(agents, s.AgentIndex(ctx))
+	return seedIndex(agents, index)
 }
 
+func seedResourceIndexes(ctx context.Context, s store.Store) error {
+	var errs error
+	if err := seedResourceIndex(ctx, s, model.KindSource, s.Sources); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := seedResourceIndex(ctx, s, model.KindSourceType, s.SourceTypes); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := seedResourceIndex(ctx, s, model.KindProcessor, s.Processors); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := seedResourceIndex(ctx, s, model.KindProcessorType, s.ProcessorTypes); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := seedResourceIndex(ctx, s, model.KindDestination, s.Destinations); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := seedResourceIndex(ctx, s, model.KindDestinationType, s.DestinationTypes); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	if err := seedResourceIndex(ctx, s, model.KindExtensionType, s.ExtensionTypes); err != nil {
+		errs = multierror.Append(errs, err)
+	}
+	return errs
+}
+
+func seedResourceIndex[T model.Resource](ctx context.Context, s store.Store, kind model.Kind, list func(context.Context, ...store.QueryOption) ([]T, error)) error {
+	resources, err := list(ctx)
+	if err != nil {
+		return err
+	}
+	index := s.ResourceIndex(ctx, kind)
+	if index == nil {
+		return nil
+	}
+	return seedIndex(resources, index)
+}
+
+// seedIndex upserts all of the indexed resources into the index. A persisted index is only rebuilt if it could not be
+// loaded or does not contain the same number of documents.
 func seedIndex[T search.Indexed](indexed []T, index search.Index) error {
+	if persistent, ok := index.(search.PersistentIndex); ok {
+		if persistent.Loaded() && persistent.Size() == len(indexed) {
+			return nil
+		}
+		documents := make([]search.Indexed, 0, len(indexed))
+		for _, i := range indexed {
+			documents = append(documents, i)
+		}
+		return persistent.Rebuild(documents)
+	}
+
 	var errs error
 	for _, i := range indexed {
 		err := index.Upsert(i)
@@ -15,10 +15,20 @@
 package sync
 
 import (
+	"bytes"
+	"context"
 	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
 
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 
+	"github.com/observiq/bindplane-op/client"
+	"github.com/observiq/bindplane-op/internal/agent"
 	"github.com/observiq/bindplane-op/internal/cli"
 	"github.com/observiq/bindplane-op/model"
 )
@@ -26,6 +36,7 @@ import (
 var (
 	versionFlag string
 	allFlag     bool
+	fromDirFlag string
 )
 
 // AgentVersionCommand returns the iris sync agent-version cobra command
@@ -34,7 +45,10 @@ func AgentVersionCommand(bindplane *cli.BindPlane) *cobra.Command {
 		Use:     "agent-version",
 		Aliases: []string{"agent-versions"},
 		Short:   "Sync an agent-version from github releases",
-		Long:    `An agent-version identifies the release assets for a version of the agent.`,
+		Long: `An agent-version identifies the release assets for a version of the agent.
+
+With --from-dir, the agent-version and its packages are imported from release assets downloaded to a local directory
+instead of GitHub. The directory must contain the observiq-otel-collector-<version>-SHA256SUMS file of each version.`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			c, err := bindplane.Client()
 			if err != nil {
@@ -46,6 +60,14 @@ func AgentVersionCommand(bindplane *cli.BindPlane) *cobra.Command {
 				version = ""
 			}
 
+			if fromDirFlag != "" {
+				// import every version in the directory unless a version is specified
+				if !cmd.Flags().Changed("version") {
+					version = ""
+				}
+				return importAgentVersions(cmd.Context(), c, cmd.OutOrStdout(), fromDirFlag, version)
+			}
+
 			resourceStatuses, err := c.SyncAgentVersions(cmd.Context(), version)
 			if err != nil {
 				return err
@@ -58,6 +80,99 @@ func AgentVersionCommand(bindplane *cli.BindPlane) *cobra.Command {
 
 	cmd.Flags().StringVar(&versionFlag, "version", "latest", "version of the agent to sync from github")
 	cmd.Flags().BoolVar(&allFlag, "all", false, "sync all versions (>= v1.6.0)")
+	cmd.Flags().StringVar(&fromDirFlag, "from-dir", "", "import agent versions and packages from release assets in a local directory instead of github")
 
 	return cmd
 }
+
+// importAgentVersions imports the agent-version and packages of each version with a SHA256SUMS file in the directory.
+// If version is not empty, only that version is imported.
+func importAgentVersions(ctx context.Context, c client.BindPlane, out io.Writer, dir string, version string) error {
+	prefix, suffix, _ := strings.Cut(agent.Sha256SumsName("*"), "*")
+	sumsFiles, err := filepath.Glob(filepath.Join(dir, agent.Sha256SumsName("*")))
+	if err != nil {
+		return err
+	}
+
+	imported := 0
+	for _, sumsFile := range sumsFiles {
+		sumsVersion := strings.TrimSuffix(strings.TrimPrefix(filepath.Base(sumsFile), prefix), suffix)
+		if version != "" && version != sumsVersion {
+			continue
+		}
+		if err := importAgentVersion(ctx, c, out, dir, sumsVersion, sumsFile); err != nil {
+			return err
+		}
+		imported++
+	}
+
+	if imported == 0 {
+		return fmt.Errorf("no %s files found in %s", agent.Sha256SumsName(version), dir)
+	}
+	return nil
+}
+
+func importAgentVersion(ctx context.Context, c client.BindPlane, out io.Writer, dir string, version string, sumsFile string) error {
+	contents, err := os.ReadFile(sumsFile)
+	if err != nil {
+		return err
+	}
+	agentVersion := agent.NewAgentVersionFromSha256Sums(version, contents)
+
+	// keep an existing agent-version, which may have been synced from github with the release URLs
+	if existing, err := c.AgentVersion(ctx, agentVersion.Name()); err != nil || existing == nil {
+		resources, err := anyResources(agentVersion)
+		if err != nil {
+			return err
+		}
+		resourceStatuses, err := c.Apply(ctx, resources)
+		if err != nil {
+			return err
+		}
+		model.PrintResourceUpdates(out, resourceStatuses)
+	}
+
+	platforms := make([]string, 0, len(agentVersion.Spec.Download))
+	for platform := range agentVersion.Spec.Download {
+		platforms = append(platforms, platform)
+	}
+	sort.Strings(platforms)
+
+	for _, platform := range platforms {
+		name := agent.PackageName(version, platform)
+		imported, err := importAgentPackage(ctx, c, filepath.Join(dir, name), version, name)
+		if err != nil {
+			return err
+		}
+		if imported {
+			fmt.Fprintf(out, "%s imported\n", name)
+		}
+	}
+	return nil
+}
+
+// importAgentPackage uploads the package at the path and returns true if it was imported. The directory doesn't need
+// to contain the packages of every platform, so a missing package is skipped.
+func importAgentPackage(ctx context.Context, c client.BindPlane, path string, version string, name string) (bool, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	defer file.Close()
+
+	return true, c.ImportAgentPackage(ctx, version, name, file)
+}
+
+func anyResources(resources ...model.Resource) ([]*model.AnyResource, error) {
+	var buffer bytes.Buffer
+	encoder := yaml.NewEncoder(&buffer)
+	for _, resource := range resources {
+		if err := encoder.Encode(resource); err != nil {
+			return nil, err
+		}
+	}
+	return model.ResourcesFromReader(&buffer)
+}
@@ -56,9 +56,49 @@ func Serve(cmd *cobra.Command) {
 	f.String("store-type", "", "type of store to use for storing agent status and configuration resources")
 	f.String("remote-url", "", "websocket url that agents use to connect to the server")
 	f.String("secret-key", "", "secret key used by agents when connecting to the server")
+	f.Bool("disable-secret-key-enrollment", false, "require agents without their own credentials to enroll with an enrollment token instead of the secret key")
 	f.String("sessions-secret", "", "secret key used to sign cookies for session authentication, must be a UUID")
+	f.String("secrets-key", "", "key used to encrypt the values of secrets before they are stored")
 	f.String("storage-file-path", "", "full path to the desired storage file, defaults to the $HOME/.bindplane/storage")
 	f.String("downloads-folder-path", "", "full path to the downloads folder where agents are cached, defaults to $HOME/.bindplane/downloads")
 	f.Bool("disable-downloads-cache", false, "true if agent distributions should be cached")
+	f.Bool("verify-agent-certificates", false, "require the client certificate of each agent to match its agent id, requires mutual TLS")
+	f.String("agent-certificate-label", "", "name of the agent label whose value must match the client certificate of the agent instead of the agent id")
+	f.Int("opamp-max-connections", 0, "maximum number of agents connected at once, 0 for unlimited")
+	f.Int("opamp-connections-per-second", 0, "maximum number of new agent connections accepted each second, 0 for unlimited")
+	f.String("audit-log-file", "", "full path to a file that receives each audit log entry as a line of JSON, disabled if empty")
+	f.Duration("audit-retention", 0, "time entries are kept in the audit log, 0 to keep entries until audit-max-entries is exceeded")
+	f.Int("audit-max-entries", 0, "maximum number of entries kept in the audit log, 0 for unlimited")
 	f.Duration("sync-agent-versions-interval", 1*time.Hour, "time interval to sync agent-version resources from GitHub releases, 0 to disable or minimum 1h")
+	f.Bool("serve-agent-packages", false, "serve agent packages for upgrades from the server instead of GitHub")
+	f.Duration("agent-heartbeat-interval", 30*time.Second, "time interval to send heartbeats to connected agents, 0 to disable")
+	f.Duration("agent-cleanup-ttl", 0, "time an agent can be disconnected before it is removed, 0 to keep disconnected agents")
+	f.Bool("mark-stale-agents", false, "mark agents disconnected longer than agent-cleanup-ttl as stale instead of removing them")
+	f.Duration("agent-history-retention", 0, "time the status changes of agents are kept, 0 to keep changes until agent-history-max-changes is exceeded")
+	f.Int("agent-history-max-changes", 0, "maximum number of status changes kept for each agent, 0 for unlimited")
+	f.String("postgres-host", "", "host of the PostgreSQL server when store-type is postgres, defaults to localhost")
+	f.String("postgres-port", "", "port of the PostgreSQL server when store-type is postgres, defaults to 5432")
+	f.String("postgres-database", "", "name of the PostgreSQL database when store-type is postgres, defaults to bindplane")
+	f.String("postgres-username", "", "username used to connect to PostgreSQL when store-type is postgres")
+	f.String("postgres-password", "", "password used to connect to PostgreSQL when store-type is postgres")
+	f.String("postgres-ssl-mode", "", "sslmode used to connect to PostgreSQL when store-type is postgres, defaults to require")
+
+	// Custom handling for some flags.
+	_ = viper.BindPFlag("postgres.host", f.set.Lookup("postgres-host"))
+	_ = viper.BindEnv("postgres.host", "BINDPLANE_CONFIG_POSTGRES_HOST")
+
+	_ = viper.BindPFlag("postgres.port", f.set.Lookup("postgres-port"))
+	_ = viper.BindEnv("postgres.port", "BINDPLANE_CONFIG_POSTGRES_PORT")
+
+	_ = viper.BindPFlag("postgres.database", f.set.Lookup("postgres-database"))
+	_ = viper.BindEnv("postgres.database", "BINDPLANE_CONFIG_POSTGRES_DATABASE")
+
+	_ = viper.BindPFlag("postgres.username", f.set.Lookup("postgres-username"))
+	_ = viper.BindEnv("postgres.username", "BINDPLANE_CONFIG_POSTGRES_USERNAME")
+
+	_ = viper.BindPFlag("postgres.password", f.set.Lookup("postgres-password"))
+	_ = viper.BindEnv("postgres.password", "BINDPLANE_CONFIG_POSTGRES_PASSWORD")
+
+	_ = viper.BindPFlag("postgres.sslMode", f.set.Lookup("postgres-ssl-mode"))
+	_ = viper.BindEnv("postgres.sslMode", "BINDPLANE_CONFIG_POSTGRES_SSL_MODE")
 }
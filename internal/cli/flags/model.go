@@ -47,6 +47,10 @@ func (s *flags) Bool(name string, value bool, usage string, opts ...flagOption)
 	newflag(name, opts, withUsage(usage)).Bool(s.set, value)
 }
 
+func (s *flags) Int(name string, value int, usage string, opts ...flagOption) {
+	newflag(name, opts, withUsage(usage)).Int(s.set, value)
+}
+
 func (s *flags) Duration(name string, value time.Duration, usage string, opts ...flagOption) {
 	newflag(name, opts, withUsage(usage)).Duration(s.set, value)
 }
@@ -90,6 +94,11 @@ func (f *flag) Bool(set *pflag.FlagSet, defaultValue bool) {
 	f.BindViper(set)
 }
 
+func (f *flag) Int(set *pflag.FlagSet, defaultValue int) {
+	set.IntP(f.name, f.shorthand, defaultValue, f.usage)
+	f.BindViper(set)
+}
+
 func (f *flag) Duration(set *pflag.FlagSet, defaultValue time.Duration) {
 	set.DurationP(f.name, f.shorthand, defaultValue, f.usage)
 	f.BindViper(set)
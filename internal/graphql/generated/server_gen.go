@@ -44,11 +44,15 @@ type Config struct {
 
 type ResolverRoot interface {
 	Agent() AgentResolver
+	AgentRestart() AgentRestartResolver
 	AgentSelector() AgentSelectorResolver
+	AgentStatusChange() AgentStatusChangeResolver
 	AgentUpgrade() AgentUpgradeResolver
+	AuditEntry() AuditEntryResolver
 	Configuration() ConfigurationResolver
 	Destination() DestinationResolver
 	DestinationType() DestinationTypeResolver
+	ExtensionType() ExtensionTypeResolver
 	Metadata() MetadataResolver
 	Mutation() MutationResolver
 	ParameterDefinition() ParameterDefinitionResolver
@@ -56,6 +60,8 @@ type ResolverRoot interface {
 	ProcessorType() ProcessorTypeResolver
 	Query() QueryResolver
 	RelevantIfCondition() RelevantIfConditionResolver
+	Rollout() RolloutResolver
+	RolloutOptions() RolloutOptionsResolver
 	Source() SourceResolver
 	SourceType() SourceTypeResolver
 	Subscription() SubscriptionResolver
@@ -73,6 +79,7 @@ type ComplexityRoot struct {
 		DisconnectedAt        func(childComplexity int) int
 		ErrorMessage          func(childComplexity int) int
 		Features              func(childComplexity int) int
+		History               func(childComplexity int, start *time.Time, end *time.Time) int
 		Home                  func(childComplexity int) int
 		HostName              func(childComplexity int) int
 		ID                    func(childComplexity int) int
@@ -82,6 +89,7 @@ type ComplexityRoot struct {
 		OperatingSystem       func(childComplexity int) int
 		Platform              func(childComplexity int) int
 		RemoteAddress         func(childComplexity int) int
+		Restart               func(childComplexity int) int
 		Status                func(childComplexity int) int
 		Type                  func(childComplexity int) int
 		Upgrade               func(childComplexity int) int
@@ -100,10 +108,30 @@ type ComplexityRoot struct {
 		Manager   func(childComplexity int) int
 	}
 
+	AgentHistory struct {
+		Changes func(childComplexity int) int
+		End     func(childComplexity int) int
+		Start   func(childComplexity int) int
+		Uptime  func(childComplexity int) int
+	}
+
+	AgentRestart struct {
+		Error       func(childComplexity int) int
+		RequestedAt func(childComplexity int) int
+		Status      func(childComplexity int) int
+	}
+
 	AgentSelector struct {
 		MatchLabels func(childComplexity int) int
 	}
 
+	AgentStatusChange struct {
+		AgentID      func(childComplexity int) int
+		ErrorMessage func(childComplexity int) int
+		Status       func(childComplexity int) int
+		Timestamp    func(childComplexity int) int
+	}
+
 	AgentUpgrade struct {
 		Error   func(childComplexity int) int
 		Status  func(childComplexity int) int
@@ -117,13 +145,26 @@ type ComplexityRoot struct {
 		Suggestions   func(childComplexity int) int
 	}
 
+	AuditEntry struct {
+		Action    func(childComplexity int) int
+		After     func(childComplexity int) int
+		Before    func(childComplexity int) int
+		Changes   func(childComplexity int) int
+		ID        func(childComplexity int) int
+		Kind      func(childComplexity int) int
+		Name      func(childComplexity int) int
+		Timestamp func(childComplexity int) int
+		User      func(childComplexity int) int
+	}
+
 	Configuration struct {
 		APIVersion func(childComplexity int) int
 		AgentCount func(childComplexity int) int
 		Graph      func(childComplexity int) int
 		Kind       func(childComplexity int) int
 		Metadata   func(childComplexity int) int
-		Rendered   func(childComplexity int) int
+		Rendered   func(childComplexity int, agentID *string) int
+		Rollout    func(childComplexity int) int
 		Spec       func(childComplexity int) int
 	}
 
@@ -132,12 +173,21 @@ type ComplexityRoot struct {
 		EventType     func(childComplexity int) int
 	}
 
-	ConfigurationSpec struct {
-		ContentType  func(childComplexity int) int
+	ConfigurationRoute struct {
 		Destinations func(childComplexity int) int
-		Raw          func(childComplexity int) int
-		Selector     func(childComplexity int) int
 		Sources      func(childComplexity int) int
+		Types        func(childComplexity int) int
+	}
+
+	ConfigurationSpec struct {
+		ContentType      func(childComplexity int) int
+		Destinations     func(childComplexity int) int
+		Extensions       func(childComplexity int) int
+		Raw              func(childComplexity int) int
+		Routes           func(childComplexity int) int
+		Selector         func(childComplexity int) int
+		ServiceTelemetry func(childComplexity int) int
+		Sources          func(childComplexity int) int
 	}
 
 	Configurations struct {
@@ -176,6 +226,13 @@ type ComplexityRoot struct {
 		Target func(childComplexity int) int
 	}
 
+	ExtensionType struct {
+		APIVersion func(childComplexity int) int
+		Kind       func(childComplexity int) int
+		Metadata   func(childComplexity int) int
+		Spec       func(childComplexity int) int
+	}
+
 	Graph struct {
 		Attributes    func(childComplexity int) int
 		Edges         func(childComplexity int) int
@@ -206,12 +263,14 @@ type ComplexityRoot struct {
 	}
 
 	Metadata struct {
-		Description func(childComplexity int) int
-		DisplayName func(childComplexity int) int
-		ID          func(childComplexity int) int
-		Icon        func(childComplexity int) int
-		Labels      func(childComplexity int) int
-		Name        func(childComplexity int) int
+		Description     func(childComplexity int) int
+		DisplayName     func(childComplexity int) int
+		ID              func(childComplexity int) int
+		Icon            func(childComplexity int) int
+		Labels          func(childComplexity int) int
+		Name            func(childComplexity int) int
+		ResourceVersion func(childComplexity int) int
+		Revision        func(childComplexity int) int
 	}
 
 	Metric struct {
@@ -237,7 +296,12 @@ type ComplexityRoot struct {
 	}
 
 	Mutation struct {
-		UpdateProcessors func(childComplexity int, input model.UpdateProcessorsInput) int
+		AbortRollout          func(childComplexity int, name string) int
+		PauseRollout          func(childComplexity int, name string) int
+		RestartAgents         func(childComplexity int, ids []string, selector *string, query *string) int
+		ResumeRollout         func(childComplexity int, name string) int
+		RollbackConfiguration func(childComplexity int, name string, revision int) int
+		UpdateProcessors      func(childComplexity int, input model.UpdateProcessorsInput) int
 	}
 
 	Node struct {
@@ -301,29 +365,35 @@ type ComplexityRoot struct {
 	}
 
 	Query struct {
-		Agent                 func(childComplexity int, id string) int
-		AgentMetrics          func(childComplexity int, period string, ids []string) int
-		Agents                func(childComplexity int, selector *string, query *string) int
-		Configuration         func(childComplexity int, name string) int
-		ConfigurationMetrics  func(childComplexity int, period string, name *string) int
-		Configurations        func(childComplexity int, selector *string, query *string, onlyDeployedConfigurations *bool) int
-		Destination           func(childComplexity int, name string) int
-		DestinationType       func(childComplexity int, name string) int
-		DestinationTypes      func(childComplexity int) int
-		DestinationWithType   func(childComplexity int, name string) int
-		Destinations          func(childComplexity int) int
-		DestinationsInConfigs func(childComplexity int) int
-		OverviewMetrics       func(childComplexity int, period string, configIDs []string, destinationIDs []string) int
-		OverviewPage          func(childComplexity int, configIDs []string, destinationIDs []string, period string, telemetryType string) int
-		Processor             func(childComplexity int, name string) int
-		ProcessorType         func(childComplexity int, name string) int
-		ProcessorTypes        func(childComplexity int) int
-		Processors            func(childComplexity int) int
-		Snapshot              func(childComplexity int, agentID string, pipelineType otel.PipelineType) int
-		Source                func(childComplexity int, name string) int
-		SourceType            func(childComplexity int, name string) int
-		SourceTypes           func(childComplexity int) int
-		Sources               func(childComplexity int) int
+		Agent                  func(childComplexity int, id string) int
+		AgentMetrics           func(childComplexity int, period string, ids []string) int
+		Agents                 func(childComplexity int, selector *string, query *string) int
+		AuditEntries           func(childComplexity int, kind *string, name *string, user *string, offset *int, limit *int) int
+		Configuration          func(childComplexity int, name string) int
+		ConfigurationMetrics   func(childComplexity int, period string, name *string) int
+		ConfigurationRevisions func(childComplexity int, name string) int
+		Configurations         func(childComplexity int, selector *string, query *string, onlyDeployedConfigurations *bool) int
+		Destination            func(childComplexity int, name string) int
+		DestinationType        func(childComplexity int, name string) int
+		DestinationTypes       func(childComplexity int, selector *string, query *string, offset *int, limit *int, sort *string) int
+		DestinationWithType    func(childComplexity int, name string) int
+		Destinations           func(childComplexity int, selector *string, query *string, offset *int, limit *int, sort *string) int
+		DestinationsInConfigs  func(childComplexity int) int
+		ExtensionType          func(childComplexity int, name string) int
+		ExtensionTypes         func(childComplexity int, selector *string, query *string, offset *int, limit *int, sort *string) int
+		OverviewMetrics        func(childComplexity int, period string, configIDs []string, destinationIDs []string) int
+		OverviewPage           func(childComplexity int, configIDs []string, destinationIDs []string, period string, telemetryType string) int
+		Processor              func(childComplexity int, name string) int
+		ProcessorType          func(childComplexity int, name string) int
+		ProcessorTypes         func(childComplexity int, selector *string, query *string, offset *int, limit *int, sort *string) int
+		Processors             func(childComplexity int, selector *string, query *string, offset *int, limit *int, sort *string) int
+		Rollout                func(childComplexity int, name string) int
+		Rollouts               func(childComplexity int) int
+		Snapshot               func(childComplexity int, agentID string, pipelineType otel.PipelineType) int
+		Source                 func(childComplexity int, name string) int
+		SourceType             func(childComplexity int, name string) int
+		SourceTypes            func(childComplexity int, selector *string, query *string, offset *int, limit *int, sort *string) int
+		Sources                func(childComplexity int, selector *string, query *string, offset *int, limit *int, sort *string) int
 	}
 
 	RelevantIfCondition struct {
@@ -347,6 +417,31 @@ type ComplexityRoot struct {
 		Version            func(childComplexity int) int
 	}
 
+	Rollout struct {
+		AgentIds         func(childComplexity int, status *string, wave *int) int
+		Applied          func(childComplexity int) int
+		Failed           func(childComplexity int) int
+		Message          func(childComplexity int) int
+		Name             func(childComplexity int) int
+		Options          func(childComplexity int) int
+		Pending          func(childComplexity int) int
+		PreviousRevision func(childComplexity int) int
+		Revision         func(childComplexity int) int
+		RollbackRevision func(childComplexity int) int
+		StartedAt        func(childComplexity int) int
+		Status           func(childComplexity int) int
+		UpdatedAt        func(childComplexity int) int
+		Wave             func(childComplexity int) int
+		WaveStartedAt    func(childComplexity int) int
+	}
+
+	RolloutOptions struct {
+		BatchPercent    func(childComplexity int) int
+		BatchSize       func(childComplexity int) int
+		MaxErrorPercent func(childComplexity int) int
+		OnError         func(childComplexity int) int
+	}
+
 	Snapshot struct {
 		Logs    func(childComplexity int) int
 		Metrics func(childComplexity int) int
@@ -402,19 +497,31 @@ type AgentResolver interface {
 
 	UpgradeAvailable(ctx context.Context, obj *model1.Agent) (*string, error)
 	Features(ctx context.Context, obj *model1.Agent) (int, error)
+	History(ctx context.Context, obj *model1.Agent, start *time.Time, end *time.Time) (*model1.AgentHistory, error)
+}
+type AgentRestartResolver interface {
+	Status(ctx context.Context, obj *model1.AgentRestart) (int, error)
 }
 type AgentSelectorResolver interface {
 	MatchLabels(ctx context.Context, obj *model1.AgentSelector) (map[string]interface{}, error)
 }
+type AgentStatusChangeResolver interface {
+	Status(ctx context.Context, obj *model1.AgentStatusChange) (int, error)
+}
 type AgentUpgradeResolver interface {
 	Status(ctx context.Context, obj *model1.AgentUpgrade) (int, error)
 }
+type AuditEntryResolver interface {
+	Action(ctx context.Context, obj *model1.AuditEntry) (string, error)
+	Kind(ctx context.Context, obj *model1.AuditEntry) (string, error)
+}
 type ConfigurationResolver interface {
 	Kind(ctx context.Context, obj *model1.Configuration) (string, error)
 
 	AgentCount(ctx context.Context, obj *model1.Configuration) (*int, error)
 	Graph(ctx context.Context, obj *model1.Configuration) (*graph.Graph, error)
-	Rendered(ctx context.Context, obj *model1.Configuration) (*string, error)
+	Rendered(ctx context.Context, obj *model1.Configuration, agentID *string) (*string, error)
+	Rollout(ctx context.Context, obj *model1.Configuration) (*model1.Rollout, error)
 }
 type DestinationResolver interface {
 	Kind(ctx context.Context, obj *model1.Destination) (string, error)
@@ -422,11 +529,21 @@ type DestinationResolver interface {
 type DestinationTypeResolver interface {
 	Kind(ctx context.Context, obj *model1.DestinationType) (string, error)
 }
+type ExtensionTypeResolver interface {
+	Kind(ctx context.Context, obj *model1.ExtensionType) (string, error)
+}
 type MetadataResolver interface {
 	Labels(ctx context.Context, obj *model1.Metadata) (map[string]interface{}, error)
+
+	ResourceVersion(ctx context.Context, obj *model1.Metadata) (*string, error)
 }
 type MutationResolver interface {
 	UpdateProcessors(ctx context.Context, input model.UpdateProcessorsInput) (*bool, error)
+	RollbackConfiguration(ctx context.Context, name string, revision int) (*model1.Configuration, error)
+	RestartAgents(ctx context.Context, ids []string, selector *string, query *string) ([]*model1.Agent, error)
+	PauseRollout(ctx context.Context, name string) (*model1.Rollout, error)
+	ResumeRollout(ctx context.Context, name string) (*model1.Rollout, error)
+	AbortRollout(ctx context.Context, name string) (*model1.Rollout, error)
 }
 type ParameterDefinitionResolver interface {
 	Type(ctx context.Context, obj *model1.ParameterDefinition) (model.ParameterType, error)
@@ -443,21 +560,27 @@ type QueryResolver interface {
 	Agent(ctx context.Context, id string) (*model1.Agent, error)
 	Configurations(ctx context.Context, selector *string, query *string, onlyDeployedConfigurations *bool) (*model.Configurations, error)
 	Configuration(ctx context.Context, name string) (*model1.Configuration, error)
-	Sources(ctx context.Context) ([]*model1.Source, error)
+	ConfigurationRevisions(ctx context.Context, name string) ([]*model1.Configuration, error)
+	Sources(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model1.Source, error)
 	Source(ctx context.Context, name string) (*model1.Source, error)
-	SourceTypes(ctx context.Context) ([]*model1.SourceType, error)
+	SourceTypes(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model1.SourceType, error)
 	SourceType(ctx context.Context, name string) (*model1.SourceType, error)
-	Processors(ctx context.Context) ([]*model1.Processor, error)
+	Processors(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model1.Processor, error)
 	Processor(ctx context.Context, name string) (*model1.Processor, error)
-	ProcessorTypes(ctx context.Context) ([]*model1.ProcessorType, error)
+	ProcessorTypes(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model1.ProcessorType, error)
 	ProcessorType(ctx context.Context, name string) (*model1.ProcessorType, error)
-	Destinations(ctx context.Context) ([]*model1.Destination, error)
+	Destinations(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model1.Destination, error)
 	Destination(ctx context.Context, name string) (*model1.Destination, error)
 	DestinationWithType(ctx context.Context, name string) (*model.DestinationWithType, error)
 	DestinationsInConfigs(ctx context.Context) ([]*model1.Destination, error)
-	DestinationTypes(ctx context.Context) ([]*model1.DestinationType, error)
+	DestinationTypes(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model1.DestinationType, error)
 	DestinationType(ctx context.Context, name string) (*model1.DestinationType, error)
+	ExtensionTypes(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model1.ExtensionType, error)
+	ExtensionType(ctx context.Context, name string) (*model1.ExtensionType, error)
 	Snapshot(ctx context.Context, agentID string, pipelineType otel.PipelineType) (*model.Snapshot, error)
+	AuditEntries(ctx context.Context, kind *string, name *string, user *string, offset *int, limit *int) ([]*model1.AuditEntry, error)
+	Rollouts(ctx context.Context) ([]*model1.Rollout, error)
+	Rollout(ctx context.Context, name string) (*model1.Rollout, error)
 	AgentMetrics(ctx context.Context, period string, ids []string) (*model.GraphMetrics, error)
 	ConfigurationMetrics(ctx context.Context, period string, name *string) (*model.GraphMetrics, error)
 	OverviewMetrics(ctx context.Context, period string, configIDs []string, destinationIDs []string) (*model.GraphMetrics, error)
@@ -465,6 +588,17 @@ type QueryResolver interface {
 type RelevantIfConditionResolver interface {
 	Operator(ctx context.Context, obj *model1.RelevantIfCondition) (model.RelevantIfOperatorType, error)
 }
+type RolloutResolver interface {
+	Status(ctx context.Context, obj *model1.Rollout) (string, error)
+
+	AgentIds(ctx context.Context, obj *model1.Rollout, status *string, wave *int) ([]string, error)
+	Pending(ctx context.Context, obj *model1.Rollout) (int, error)
+	Applied(ctx context.Context, obj *model1.Rollout) (int, error)
+	Failed(ctx context.Context, obj *model1.Rollout) (int, error)
+}
+type RolloutOptionsResolver interface {
+	OnError(ctx context.Context, obj *model1.RolloutOptions) (string, error)
+}
 type SourceResolver interface {
 	Kind(ctx context.Context, obj *model1.Source) (string, error)
 }
@@ -543,6 +677,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Agent.Features(childComplexity), true
 
+	case "Agent.history":
+		if e.complexity.Agent.History == nil {
+			break
+		}
+
+		args, err := ec.field_Agent_history_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Agent.History(childComplexity, args["start"].(*time.Time), args["end"].(*time.Time)), true
+
 	case "Agent.home":
 		if e.complexity.Agent.Home == nil {
 			break
@@ -606,6 +752,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Agent.RemoteAddress(childComplexity), true
 
+	case "Agent.restart":
+		if e.complexity.Agent.Restart == nil {
+			break
+		}
+
+		return e.complexity.Agent.Restart(childComplexity), true
+
 	case "Agent.status":
 		if e.complexity.Agent.Status == nil {
 			break
@@ -676,6 +829,55 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.AgentConfiguration.Manager(childComplexity), true
 
+	case "AgentHistory.changes":
+		if e.complexity.AgentHistory.Changes == nil {
+			break
+		}
+
+		return e.complexity.AgentHistory.Changes(childComplexity), true
+
+	case "AgentHistory.end":
+		if e.complexity.AgentHistory.End == nil {
+			break
+		}
+
+		return e.complexity.AgentHistory.End(childComplexity), true
+
+	case "AgentHistory.start":
+		if e.complexity.AgentHistory.Start == nil {
+			break
+		}
+
+		return e.complexity.AgentHistory.Start(childComplexity), true
+
+	case "AgentHistory.uptime":
+		if e.complexity.AgentHistory.Uptime == nil {
+			break
+		}
+
+		return e.complexity.AgentHistory.Uptime(childComplexity), true
+
+	case "AgentRestart.error":
+		if e.complexity.AgentRestart.Error == nil {
+			break
+		}
+
+		return e.complexity.AgentRestart.Error(childComplexity), true
+
+	case "AgentRestart.requestedAt":
+		if e.complexity.AgentRestart.RequestedAt == nil {
+			break
+		}
+
+		return e.complexity.AgentRestart.RequestedAt(childComplexity), true
+
+	case "AgentRestart.status":
+		if e.complexity.AgentRestart.Status == nil {
+			break
+		}
+
+		return e.complexity.AgentRestart.Status(childComplexity), true
+
 	case "AgentSelector.matchLabels":
 		if e.complexity.AgentSelector.MatchLabels == nil {
 			break
@@ -683,6 +885,34 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.AgentSelector.MatchLabels(childComplexity), true
 
+	case "AgentStatusChange.agentId":
+		if e.complexity.AgentStatusChange.AgentID == nil {
+			break
+		}
+
+		return e.complexity.AgentStatusChange.AgentID(childComplexity), true
+
+	case "AgentStatusChange.errorMessage":
+		if e.complexity.AgentStatusChange.ErrorMessage == nil {
+			break
+		}
+
+		return e.complexity.AgentStatusChange.ErrorMessage(childComplexity), true
+
+	case "AgentStatusChange.status":
+		if e.complexity.AgentStatusChange.Status == nil {
+			break
+		}
+
+		return e.complexity.AgentStatusChange.Status(childComplexity), true
+
+	case "AgentStatusChange.timestamp":
+		if e.complexity.AgentStatusChange.Timestamp == nil {
+			break
+		}
+
+		return e.complexity.AgentStatusChange.Timestamp(childComplexity), true
+
 	case "AgentUpgrade.error":
 		if e.complexity.AgentUpgrade.Error == nil {
 			break
@@ -732,6 +962,69 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Agents.Suggestions(childComplexity), true
 
+	case "AuditEntry.action":
+		if e.complexity.AuditEntry.Action == nil {
+			break
+		}
+
+		return e.complexity.AuditEntry.Action(childComplexity), true
+
+	case "AuditEntry.after":
+		if e.complexity.AuditEntry.After == nil {
+			break
+		}
+
+		return e.complexity.AuditEntry.After(childComplexity), true
+
+	case "AuditEntry.before":
+		if e.complexity.AuditEntry.Before == nil {
+			break
+		}
+
+		return e.complexity.AuditEntry.Before(childComplexity), true
+
+	case "AuditEntry.changes":
+		if e.complexity.AuditEntry.Changes == nil {
+			break
+		}
+
+		return e.complexity.AuditEntry.Changes(childComplexity), true
+
+	case "AuditEntry.id":
+		if e.complexity.AuditEntry.ID == nil {
+			break
+		}
+
+		return e.complexity.AuditEntry.ID(childComplexity), true
+
+	case "AuditEntry.kind":
+		if e.complexity.AuditEntry.Kind == nil {
+			break
+		}
+
+		return e.complexity.AuditEntry.Kind(childComplexity), true
+
+	case "AuditEntry.name":
+		if e.complexity.AuditEntry.Name == nil {
+			break
+		}
+
+		return e.complexity.AuditEntry.Name(childComplexity), true
+
+	case "AuditEntry.timestamp":
+		if e.complexity.AuditEntry.Timestamp == nil {
+			break
+		}
+
+		return e.complexity.AuditEntry.Timestamp(childComplexity), true
+
+	case "AuditEntry.user":
+		if e.complexity.AuditEntry.User == nil {
+			break
+		}
+
+		return e.complexity.AuditEntry.User(childComplexity), true
+
 	case "Configuration.apiVersion":
 		if e.complexity.Configuration.APIVersion == nil {
 			break
@@ -772,7 +1065,19 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			break
 		}
 
-		return e.complexity.Configuration.Rendered(childComplexity), true
+		args, err := ec.field_Configuration_rendered_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Configuration.Rendered(childComplexity, args["agentId"].(*string)), true
+
+	case "Configuration.rollout":
+		if e.complexity.Configuration.Rollout == nil {
+			break
+		}
+
+		return e.complexity.Configuration.Rollout(childComplexity), true
 
 	case "Configuration.spec":
 		if e.complexity.Configuration.Spec == nil {
@@ -795,6 +1100,27 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ConfigurationChange.EventType(childComplexity), true
 
+	case "ConfigurationRoute.destinations":
+		if e.complexity.ConfigurationRoute.Destinations == nil {
+			break
+		}
+
+		return e.complexity.ConfigurationRoute.Destinations(childComplexity), true
+
+	case "ConfigurationRoute.sources":
+		if e.complexity.ConfigurationRoute.Sources == nil {
+			break
+		}
+
+		return e.complexity.ConfigurationRoute.Sources(childComplexity), true
+
+	case "ConfigurationRoute.types":
+		if e.complexity.ConfigurationRoute.Types == nil {
+			break
+		}
+
+		return e.complexity.ConfigurationRoute.Types(childComplexity), true
+
 	case "ConfigurationSpec.contentType":
 		if e.complexity.ConfigurationSpec.ContentType == nil {
 			break
@@ -809,6 +1135,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ConfigurationSpec.Destinations(childComplexity), true
 
+	case "ConfigurationSpec.extensions":
+		if e.complexity.ConfigurationSpec.Extensions == nil {
+			break
+		}
+
+		return e.complexity.ConfigurationSpec.Extensions(childComplexity), true
+
 	case "ConfigurationSpec.raw":
 		if e.complexity.ConfigurationSpec.Raw == nil {
 			break
@@ -816,6 +1149,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ConfigurationSpec.Raw(childComplexity), true
 
+	case "ConfigurationSpec.routes":
+		if e.complexity.ConfigurationSpec.Routes == nil {
+			break
+		}
+
+		return e.complexity.ConfigurationSpec.Routes(childComplexity), true
+
 	case "ConfigurationSpec.selector":
 		if e.complexity.ConfigurationSpec.Selector == nil {
 			break
@@ -823,6 +1163,13 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ConfigurationSpec.Selector(childComplexity), true
 
+	case "ConfigurationSpec.serviceTelemetry":
+		if e.complexity.ConfigurationSpec.ServiceTelemetry == nil {
+			break
+		}
+
+		return e.complexity.ConfigurationSpec.ServiceTelemetry(childComplexity), true
+
 	case "ConfigurationSpec.sources":
 		if e.complexity.ConfigurationSpec.Sources == nil {
 			break
@@ -956,6 +1303,34 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Edge.Target(childComplexity), true
 
+	case "ExtensionType.apiVersion":
+		if e.complexity.ExtensionType.APIVersion == nil {
+			break
+		}
+
+		return e.complexity.ExtensionType.APIVersion(childComplexity), true
+
+	case "ExtensionType.kind":
+		if e.complexity.ExtensionType.Kind == nil {
+			break
+		}
+
+		return e.complexity.ExtensionType.Kind(childComplexity), true
+
+	case "ExtensionType.metadata":
+		if e.complexity.ExtensionType.Metadata == nil {
+			break
+		}
+
+		return e.complexity.ExtensionType.Metadata(childComplexity), true
+
+	case "ExtensionType.spec":
+		if e.complexity.ExtensionType.Spec == nil {
+			break
+		}
+
+		return e.complexity.ExtensionType.Spec(childComplexity), true
+
 	case "Graph.attributes":
 		if e.complexity.Graph.Attributes == nil {
 			break
@@ -1117,6 +1492,20 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Metadata.Name(childComplexity), true
 
+	case "Metadata.resourceVersion":
+		if e.complexity.Metadata.ResourceVersion == nil {
+			break
+		}
+
+		return e.complexity.Metadata.ResourceVersion(childComplexity), true
+
+	case "Metadata.revision":
+		if e.complexity.Metadata.Revision == nil {
+			break
+		}
+
+		return e.complexity.Metadata.Revision(childComplexity), true
+
 	case "Metric.attributes":
 		if e.complexity.Metric.Attributes == nil {
 			break
@@ -1208,6 +1597,66 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.MetricOption.Name(childComplexity), true
 
+	case "Mutation.abortRollout":
+		if e.complexity.Mutation.AbortRollout == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_abortRollout_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.AbortRollout(childComplexity, args["name"].(string)), true
+
+	case "Mutation.pauseRollout":
+		if e.complexity.Mutation.PauseRollout == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_pauseRollout_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.PauseRollout(childComplexity, args["name"].(string)), true
+
+	case "Mutation.restartAgents":
+		if e.complexity.Mutation.RestartAgents == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_restartAgents_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RestartAgents(childComplexity, args["ids"].([]string), args["selector"].(*string), args["query"].(*string)), true
+
+	case "Mutation.resumeRollout":
+		if e.complexity.Mutation.ResumeRollout == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_resumeRollout_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.ResumeRollout(childComplexity, args["name"].(string)), true
+
+	case "Mutation.rollbackConfiguration":
+		if e.complexity.Mutation.RollbackConfiguration == nil {
+			break
+		}
+
+		args, err := ec.field_Mutation_rollbackConfiguration_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Mutation.RollbackConfiguration(childComplexity, args["name"].(string), args["revision"].(int)), true
+
 	case "Mutation.updateProcessors":
 		if e.complexity.Mutation.UpdateProcessors == nil {
 			break
@@ -1508,6 +1957,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.Agents(childComplexity, args["selector"].(*string), args["query"].(*string)), true
 
+	case "Query.auditEntries":
+		if e.complexity.Query.AuditEntries == nil {
+			break
+		}
+
+		args, err := ec.field_Query_auditEntries_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.AuditEntries(childComplexity, args["kind"].(*string), args["name"].(*string), args["user"].(*string), args["offset"].(*int), args["limit"].(*int)), true
+
 	case "Query.configuration":
 		if e.complexity.Query.Configuration == nil {
 			break
@@ -1532,6 +1993,18 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.ConfigurationMetrics(childComplexity, args["period"].(string), args["name"].(*string)), true
 
+	case "Query.configurationRevisions":
+		if e.complexity.Query.ConfigurationRevisions == nil {
+			break
+		}
+
+		args, err := ec.field_Query_configurationRevisions_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ConfigurationRevisions(childComplexity, args["name"].(string)), true
+
 	case "Query.configurations":
 		if e.complexity.Query.Configurations == nil {
 			break
@@ -1573,7 +2046,12 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			break
 		}
 
-		return e.complexity.Query.DestinationTypes(childComplexity), true
+		args, err := ec.field_Query_destinationTypes_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.DestinationTypes(childComplexity, args["selector"].(*string), args["query"].(*string), args["offset"].(*int), args["limit"].(*int), args["sort"].(*string)), true
 
 	case "Query.destinationWithType":
 		if e.complexity.Query.DestinationWithType == nil {
@@ -1592,7 +2070,12 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			break
 		}
 
-		return e.complexity.Query.Destinations(childComplexity), true
+		args, err := ec.field_Query_destinations_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Destinations(childComplexity, args["selector"].(*string), args["query"].(*string), args["offset"].(*int), args["limit"].(*int), args["sort"].(*string)), true
 
 	case "Query.destinationsInConfigs":
 		if e.complexity.Query.DestinationsInConfigs == nil {
@@ -1601,33 +2084,57 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.Query.DestinationsInConfigs(childComplexity), true
 
-	case "Query.overviewMetrics":
-		if e.complexity.Query.OverviewMetrics == nil {
+	case "Query.extensionType":
+		if e.complexity.Query.ExtensionType == nil {
 			break
 		}
 
-		args, err := ec.field_Query_overviewMetrics_args(context.TODO(), rawArgs)
+		args, err := ec.field_Query_extensionType_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.OverviewMetrics(childComplexity, args["period"].(string), args["configIDs"].([]string), args["destinationIDs"].([]string)), true
+		return e.complexity.Query.ExtensionType(childComplexity, args["name"].(string)), true
 
-	case "Query.overviewPage":
-		if e.complexity.Query.OverviewPage == nil {
+	case "Query.extensionTypes":
+		if e.complexity.Query.ExtensionTypes == nil {
 			break
 		}
 
-		args, err := ec.field_Query_overviewPage_args(context.TODO(), rawArgs)
+		args, err := ec.field_Query_extensionTypes_args(context.TODO(), rawArgs)
 		if err != nil {
 			return 0, false
 		}
 
-		return e.complexity.Query.OverviewPage(childComplexity, args["configIDs"].([]string), args["destinationIDs"].([]string), args["period"].(string), args["telemetryType"].(string)), true
+		return e.complexity.Query.ExtensionTypes(childComplexity, args["selector"].(*string), args["query"].(*string), args["offset"].(*int), args["limit"].(*int), args["sort"].(*string)), true
 
-	case "Query.processor":
-		if e.complexity.Query.Processor == nil {
-			break
+	case "Query.overviewMetrics":
+		if e.complexity.Query.OverviewMetrics == nil {
+			break
+		}
+
+		args, err := ec.field_Query_overviewMetrics_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.OverviewMetrics(childComplexity, args["period"].(string), args["configIDs"].([]string), args["destinationIDs"].([]string)), true
+
+	case "Query.overviewPage":
+		if e.complexity.Query.OverviewPage == nil {
+			break
+		}
+
+		args, err := ec.field_Query_overviewPage_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.OverviewPage(childComplexity, args["configIDs"].([]string), args["destinationIDs"].([]string), args["period"].(string), args["telemetryType"].(string)), true
+
+	case "Query.processor":
+		if e.complexity.Query.Processor == nil {
+			break
 		}
 
 		args, err := ec.field_Query_processor_args(context.TODO(), rawArgs)
@@ -1654,14 +2161,43 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			break
 		}
 
-		return e.complexity.Query.ProcessorTypes(childComplexity), true
+		args, err := ec.field_Query_processorTypes_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.ProcessorTypes(childComplexity, args["selector"].(*string), args["query"].(*string), args["offset"].(*int), args["limit"].(*int), args["sort"].(*string)), true
 
 	case "Query.processors":
 		if e.complexity.Query.Processors == nil {
 			break
 		}
 
-		return e.complexity.Query.Processors(childComplexity), true
+		args, err := ec.field_Query_processors_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Processors(childComplexity, args["selector"].(*string), args["query"].(*string), args["offset"].(*int), args["limit"].(*int), args["sort"].(*string)), true
+
+	case "Query.rollout":
+		if e.complexity.Query.Rollout == nil {
+			break
+		}
+
+		args, err := ec.field_Query_rollout_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Rollout(childComplexity, args["name"].(string)), true
+
+	case "Query.rollouts":
+		if e.complexity.Query.Rollouts == nil {
+			break
+		}
+
+		return e.complexity.Query.Rollouts(childComplexity), true
 
 	case "Query.snapshot":
 		if e.complexity.Query.Snapshot == nil {
@@ -1704,14 +2240,24 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 			break
 		}
 
-		return e.complexity.Query.SourceTypes(childComplexity), true
+		args, err := ec.field_Query_sourceTypes_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.SourceTypes(childComplexity, args["selector"].(*string), args["query"].(*string), args["offset"].(*int), args["limit"].(*int), args["sort"].(*string)), true
 
 	case "Query.sources":
 		if e.complexity.Query.Sources == nil {
 			break
 		}
 
-		return e.complexity.Query.Sources(childComplexity), true
+		args, err := ec.field_Query_sources_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Query.Sources(childComplexity, args["selector"].(*string), args["query"].(*string), args["offset"].(*int), args["limit"].(*int), args["sort"].(*string)), true
 
 	case "RelevantIfCondition.name":
 		if e.complexity.RelevantIfCondition.Name == nil {
@@ -1797,6 +2343,144 @@ func (e *executableSchema) Complexity(typeName, field string, childComplexity in
 
 		return e.complexity.ResourceTypeSpec.Version(childComplexity), true
 
+	case "Rollout.agentIds":
+		if e.complexity.Rollout.AgentIds == nil {
+			break
+		}
+
+		args, err := ec.field_Rollout_agentIds_args(context.TODO(), rawArgs)
+		if err != nil {
+			return 0, false
+		}
+
+		return e.complexity.Rollout.AgentIds(childComplexity, args["status"].(*string), args["wave"].(*int)), true
+
+	case "Rollout.applied":
+		if e.complexity.Rollout.Applied == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Applied(childComplexity), true
+
+	case "Rollout.failed":
+		if e.complexity.Rollout.Failed == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Failed(childComplexity), true
+
+	case "Rollout.message":
+		if e.complexity.Rollout.Message == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Message(childComplexity), true
+
+	case "Rollout.name":
+		if e.complexity.Rollout.Name == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Name(childComplexity), true
+
+	case "Rollout.options":
+		if e.complexity.Rollout.Options == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Options(childComplexity), true
+
+	case "Rollout.pending":
+		if e.complexity.Rollout.Pending == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Pending(childComplexity), true
+
+	case "Rollout.previousRevision":
+		if e.complexity.Rollout.PreviousRevision == nil {
+			break
+		}
+
+		return e.complexity.Rollout.PreviousRevision(childComplexity), true
+
+	case "Rollout.revision":
+		if e.complexity.Rollout.Revision == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Revision(childComplexity), true
+
+	case "Rollout.rollbackRevision":
+		if e.complexity.Rollout.RollbackRevision == nil {
+			break
+		}
+
+		return e.complexity.Rollout.RollbackRevision(childComplexity), true
+
+	case "Rollout.startedAt":
+		if e.complexity.Rollout.StartedAt == nil {
+			break
+		}
+
+		return e.complexity.Rollout.StartedAt(childComplexity), true
+
+	case "Rollout.status":
+		if e.complexity.Rollout.Status == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Status(childComplexity), true
+
+	case "Rollout.updatedAt":
+		if e.complexity.Rollout.UpdatedAt == nil {
+			break
+		}
+
+		return e.complexity.Rollout.UpdatedAt(childComplexity), true
+
+	case "Rollout.wave":
+		if e.complexity.Rollout.Wave == nil {
+			break
+		}
+
+		return e.complexity.Rollout.Wave(childComplexity), true
+
+	case "Rollout.waveStartedAt":
+		if e.complexity.Rollout.WaveStartedAt == nil {
+			break
+		}
+
+		return e.complexity.Rollout.WaveStartedAt(childComplexity), true
+
+	case "RolloutOptions.batchPercent":
+		if e.complexity.RolloutOptions.BatchPercent == nil {
+			break
+		}
+
+		return e.complexity.RolloutOptions.BatchPercent(childComplexity), true
+
+	case "RolloutOptions.batchSize":
+		if e.complexity.RolloutOptions.BatchSize == nil {
+			break
+		}
+
+		return e.complexity.RolloutOptions.BatchSize(childComplexity), true
+
+	case "RolloutOptions.maxErrorPercent":
+		if e.complexity.RolloutOptions.MaxErrorPercent == nil {
+			break
+		}
+
+		return e.complexity.RolloutOptions.MaxErrorPercent(childComplexity), true
+
+	case "RolloutOptions.onError":
+		if e.complexity.RolloutOptions.OnError == nil {
+			break
+		}
+
+		return e.complexity.RolloutOptions.OnError(childComplexity), true
+
 	case "Snapshot.logs":
 		if e.complexity.Snapshot.Logs == nil {
 			break
@@ -2110,6 +2794,12 @@ type AgentUpgrade {
   error: String
 }
 
+type AgentRestart {
+  status: Int!
+  requestedAt: Time!
+  error: String
+}
+
 type Agent {
   id: ID!
   architecture: String
@@ -2139,11 +2829,31 @@ type Agent {
 
   upgrade: AgentUpgrade
 
+  restart: AgentRestart
+
   # latest version of the agent if an upgrade is available
   upgradeAvailable: String
 
   # bitmask of features supported by this agent
   features: Int!
+
+  # status changes and uptime between start and end, which default to the first change and now
+  history(start: Time, end: Time): AgentHistory!
+}
+
+type AgentStatusChange {
+  agentId: String!
+  timestamp: Time!
+  status: Int!
+  errorMessage: String
+}
+
+type AgentHistory {
+  start: Time!
+  end: Time!
+  changes: [AgentStatusChange!]!
+  # percentage of the window from 0 to 100 that the agent was up
+  uptime: Float!
 }
 
 type AgentConfiguration {
@@ -2162,6 +2872,8 @@ type Metadata {
   description: String
   icon: String
   labels: Map
+  revision: Int
+  resourceVersion: String
 }
 
 type AgentSelector {
@@ -2183,8 +2895,11 @@ type Configuration {
 
   graph: Graph
 
-  # the rendered yaml of a managed configuration
-  rendered: String
+  # the rendered yaml of a managed configuration, rendered for the agent if an agentId is specified
+  rendered(agentId: ID): String
+
+  # the most recent rollout of the configuration if it is rolled out in waves
+  rollout: Rollout
 }
 
 type ConfigurationSpec {
@@ -2193,6 +2908,18 @@ type ConfigurationSpec {
   sources: [ResourceConfiguration!]
   destinations: [ResourceConfiguration!]
   selector: AgentSelector
+  routes: [ConfigurationRoute!]
+  # extensions rendered by extension types and shared by all pipelines
+  extensions: [ResourceConfiguration!]
+  serviceTelemetry: Map
+}
+
+# restricts the sources sent to destinations and the types of telemetry sent
+type ConfigurationRoute {
+  sources: [String!]!
+  destinations: [String!]!
+  # all types are sent if it is empty
+  types: [PipelineType!]
 }
 
 type ResourceConfiguration {
@@ -2308,6 +3035,13 @@ type DestinationType {
   spec: ResourceTypeSpec!
 }
 
+type ExtensionType {
+  apiVersion: String!
+  metadata: Metadata!
+  kind: String!
+  spec: ResourceTypeSpec!
+}
+
 type ResourceTypeSpec {
   version: String!
 
@@ -2494,6 +3228,61 @@ type GraphMetrics {
   metrics: [GraphMetric!]!
 }
 
+# ----------------------------------------------------------------------
+# audit
+
+type AuditEntry {
+  id: ID!
+  timestamp: Time!
+  # user that made the change, empty for changes made by the server
+  user: String!
+  # create, update, delete, label, or upgrade
+  action: String!
+  kind: String!
+  # name of the resource or id of the agent
+  name: String!
+  before: Map
+  after: Map
+  # description of each field that changed, e.g. "spec.raw: a -> b"
+  changes: [String!]!
+}
+
+# ----------------------------------------------------------------------
+# rollouts
+
+type RolloutOptions {
+  batchSize: Int!
+  batchPercent: Int!
+  maxErrorPercent: Int!
+  # pause or rollback
+  onError: String!
+}
+
+type Rollout {
+  # name of the configuration
+  name: String!
+  revision: Int!
+  # revision used by agents that have not been updated
+  previousRevision: Int!
+  # revision created to restore the previous revision when the rollout was aborted
+  rollbackRevision: Int!
+  # running, paused, completed, aborted, or superseded
+  status: String!
+  options: RolloutOptions!
+  message: String!
+  wave: Int!
+  waveStartedAt: Time!
+  startedAt: Time!
+  updatedAt: Time!
+
+  # ids of the agents updated by the rollout, optionally only those with the specified status (pending, applied, or
+  # failed) in the specified wave
+  agentIds(status: String, wave: Int): [String!]!
+  pending: Int!
+  applied: Int!
+  failed: Int!
+}
+
 # ----------------------------------------------------------------------
 # queries
 
@@ -2514,29 +3303,86 @@ type Query {
     onlyDeployedConfigurations: Boolean
   ): Configurations!
   configuration(name: String!): Configuration
+  configurationRevisions(name: String!): [Configuration!]!
 
-  sources: [Source!]!
+  sources(
+    selector: String
+    query: String
+    offset: Int
+    limit: Int
+    sort: String
+  ): [Source!]!
   source(name: String!): Source
 
-  sourceTypes: [SourceType!]!
+  sourceTypes(
+    selector: String
+    query: String
+    offset: Int
+    limit: Int
+    sort: String
+  ): [SourceType!]!
   sourceType(name: String!): SourceType
 
-  processors: [Processor!]!
+  processors(
+    selector: String
+    query: String
+    offset: Int
+    limit: Int
+    sort: String
+  ): [Processor!]!
   processor(name: String!): Processor
 
-  processorTypes: [ProcessorType!]!
+  processorTypes(
+    selector: String
+    query: String
+    offset: Int
+    limit: Int
+    sort: String
+  ): [ProcessorType!]!
   processorType(name: String!): ProcessorType
 
-  destinations: [Destination!]!
+  destinations(
+    selector: String
+    query: String
+    offset: Int
+    limit: Int
+    sort: String
+  ): [Destination!]!
   destination(name: String!): Destination
   destinationWithType(name: String!): DestinationWithType!
   destinationsInConfigs: [Destination!]!
 
-  destinationTypes: [DestinationType!]!
+  destinationTypes(
+    selector: String
+    query: String
+    offset: Int
+    limit: Int
+    sort: String
+  ): [DestinationType!]!
   destinationType(name: String!): DestinationType
 
+  extensionTypes(
+    selector: String
+    query: String
+    offset: Int
+    limit: Int
+    sort: String
+  ): [ExtensionType!]!
+  extensionType(name: String!): ExtensionType
+
   snapshot(agentID: String!, pipelineType: PipelineType!): Snapshot!
 
+  auditEntries(
+    kind: String
+    name: String
+    user: String
+    offset: Int
+    limit: Int
+  ): [AuditEntry!]!
+
+  rollouts: [Rollout!]!
+  rollout(name: String!): Rollout
+
   agentMetrics(period: String!, ids: [ID!]): GraphMetrics!
   configurationMetrics(period: String!, name: String): GraphMetrics!
   overviewMetrics(
@@ -2591,10 +3437,20 @@ input UpdateProcessorsInput {
   resourceType: ResourceTypeKind!
   resourceIndex: Int!
   processors: [ProcessorInput!]!
+  # if specified, the processors are only updated if the resourceVersion of the configuration matches
+  resourceVersion: String
 }
 
 type Mutation {
   updateProcessors(input: UpdateProcessorsInput!): Boolean
+  rollbackConfiguration(name: String!, revision: Int!): Configuration
+  # restarts the agents with the specified ids and the agents matching the selector or query, returning the agents that
+  # will be restarted
+  restartAgents(ids: [ID!], selector: String, query: String): [Agent!]!
+  pauseRollout(name: String!): Rollout!
+  resumeRollout(name: String!): Rollout!
+  # stops the rollout and restores the previous revision of the configuration
+  abortRollout(name: String!): Rollout!
 }
 `, BuiltIn: false},
 }
@@ -2604,124 +3460,124 @@ var parsedSchema = gqlparser.MustLoadSchema(sources...)
 
 // region    ***************************** args.gotpl *****************************
 
-func (ec *executionContext) field_Mutation_updateProcessors_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Agent_history_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 model.UpdateProcessorsInput
-	if tmp, ok := rawArgs["input"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
-		arg0, err = ec.unmarshalNUpdateProcessorsInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐUpdateProcessorsInput(ctx, tmp)
+	var arg0 *time.Time
+	if tmp, ok := rawArgs["start"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("start"))
+		arg0, err = ec.unmarshalOTime2ᚖtimeᚐTime(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["input"] = arg0
+	args["start"] = arg0
+	var arg1 *time.Time
+	if tmp, ok := rawArgs["end"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("end"))
+		arg1, err = ec.unmarshalOTime2ᚖtimeᚐTime(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["end"] = arg1
 	return args, nil
 }
 
-func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Configuration_rendered_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 string
-	if tmp, ok := rawArgs["name"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
-		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+	var arg0 *string
+	if tmp, ok := rawArgs["agentId"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("agentId"))
+		arg0, err = ec.unmarshalOID2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["name"] = arg0
+	args["agentId"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_agentMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_abortRollout_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
-	if tmp, ok := rawArgs["period"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
 		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["period"] = arg0
-	var arg1 []string
-	if tmp, ok := rawArgs["ids"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("ids"))
-		arg1, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
-	}
-	args["ids"] = arg1
+	args["name"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_agent_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_pauseRollout_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
-	if tmp, ok := rawArgs["id"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
-		arg0, err = ec.unmarshalNID2string(ctx, tmp)
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["id"] = arg0
+	args["name"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_agents_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_restartAgents_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 *string
+	var arg0 []string
+	if tmp, ok := rawArgs["ids"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("ids"))
+		arg0, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["ids"] = arg0
+	var arg1 *string
 	if tmp, ok := rawArgs["selector"]; ok {
 		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
-		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["selector"] = arg0
-	var arg1 *string
+	args["selector"] = arg1
+	var arg2 *string
 	if tmp, ok := rawArgs["query"]; ok {
 		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
-		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		arg2, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["query"] = arg1
+	args["query"] = arg2
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_configurationMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_resumeRollout_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
-	if tmp, ok := rawArgs["period"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
 		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["period"] = arg0
-	var arg1 *string
-	if tmp, ok := rawArgs["name"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
-		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
-	}
-	args["name"] = arg1
+	args["name"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_configuration_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_rollbackConfiguration_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
@@ -2733,43 +3589,34 @@ func (ec *executionContext) field_Query_configuration_args(ctx context.Context,
 		}
 	}
 	args["name"] = arg0
+	var arg1 int
+	if tmp, ok := rawArgs["revision"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("revision"))
+		arg1, err = ec.unmarshalNInt2int(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["revision"] = arg1
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_configurations_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Mutation_updateProcessors_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 *string
-	if tmp, ok := rawArgs["selector"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
-		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
-	}
-	args["selector"] = arg0
-	var arg1 *string
-	if tmp, ok := rawArgs["query"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
-		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
-	}
-	args["query"] = arg1
-	var arg2 *bool
-	if tmp, ok := rawArgs["onlyDeployedConfigurations"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("onlyDeployedConfigurations"))
-		arg2, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
+	var arg0 model.UpdateProcessorsInput
+	if tmp, ok := rawArgs["input"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("input"))
+		arg0, err = ec.unmarshalNUpdateProcessorsInput2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐUpdateProcessorsInput(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["onlyDeployedConfigurations"] = arg2
+	args["input"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_destinationType_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query___type_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
@@ -2784,112 +3631,145 @@ func (ec *executionContext) field_Query_destinationType_args(ctx context.Context
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_destinationWithType_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_agentMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
-	if tmp, ok := rawArgs["name"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+	if tmp, ok := rawArgs["period"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
 		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["name"] = arg0
+	args["period"] = arg0
+	var arg1 []string
+	if tmp, ok := rawArgs["ids"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("ids"))
+		arg1, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["ids"] = arg1
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_destination_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_agent_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
-	if tmp, ok := rawArgs["name"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
-		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+	if tmp, ok := rawArgs["id"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("id"))
+		arg0, err = ec.unmarshalNID2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["name"] = arg0
+	args["id"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_overviewMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_agents_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 string
-	if tmp, ok := rawArgs["period"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
-		arg0, err = ec.unmarshalNString2string(ctx, tmp)
-		if err != nil {
-			return nil, err
-		}
-	}
-	args["period"] = arg0
-	var arg1 []string
-	if tmp, ok := rawArgs["configIDs"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("configIDs"))
-		arg1, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+	var arg0 *string
+	if tmp, ok := rawArgs["selector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["configIDs"] = arg1
-	var arg2 []string
-	if tmp, ok := rawArgs["destinationIDs"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("destinationIDs"))
-		arg2, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+	args["selector"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["query"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["destinationIDs"] = arg2
+	args["query"] = arg1
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_overviewPage_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_auditEntries_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 []string
-	if tmp, ok := rawArgs["configIDs"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("configIDs"))
-		arg0, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+	var arg0 *string
+	if tmp, ok := rawArgs["kind"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("kind"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["configIDs"] = arg0
-	var arg1 []string
-	if tmp, ok := rawArgs["destinationIDs"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("destinationIDs"))
-		arg1, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+	args["kind"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["destinationIDs"] = arg1
-	var arg2 string
+	args["name"] = arg1
+	var arg2 *string
+	if tmp, ok := rawArgs["user"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("user"))
+		arg2, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["user"] = arg2
+	var arg3 *int
+	if tmp, ok := rawArgs["offset"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("offset"))
+		arg3, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["offset"] = arg3
+	var arg4 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg4, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg4
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_configurationMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
 	if tmp, ok := rawArgs["period"]; ok {
 		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
-		arg2, err = ec.unmarshalNString2string(ctx, tmp)
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["period"] = arg2
-	var arg3 string
-	if tmp, ok := rawArgs["telemetryType"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("telemetryType"))
-		arg3, err = ec.unmarshalNString2string(ctx, tmp)
+	args["period"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["telemetryType"] = arg3
+	args["name"] = arg1
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_processorType_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_configurationRevisions_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
@@ -2904,7 +3784,7 @@ func (ec *executionContext) field_Query_processorType_args(ctx context.Context,
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_processor_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_configuration_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
@@ -2919,46 +3799,40 @@ func (ec *executionContext) field_Query_processor_args(ctx context.Context, rawA
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_snapshot_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_configurations_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 string
-	if tmp, ok := rawArgs["agentID"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("agentID"))
-		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+	var arg0 *string
+	if tmp, ok := rawArgs["selector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["agentID"] = arg0
-	var arg1 otel.PipelineType
-	if tmp, ok := rawArgs["pipelineType"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("pipelineType"))
-		arg1, err = ec.unmarshalNPipelineType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋotelᚐPipelineType(ctx, tmp)
+	args["selector"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["query"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["pipelineType"] = arg1
-	return args, nil
-}
-
-func (ec *executionContext) field_Query_sourceType_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
-	var err error
-	args := map[string]interface{}{}
-	var arg0 string
-	if tmp, ok := rawArgs["name"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
-		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+	args["query"] = arg1
+	var arg2 *bool
+	if tmp, ok := rawArgs["onlyDeployedConfigurations"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("onlyDeployedConfigurations"))
+		arg2, err = ec.unmarshalOBoolean2ᚖbool(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["name"] = arg0
+	args["onlyDeployedConfigurations"] = arg2
 	return args, nil
 }
 
-func (ec *executionContext) field_Query_source_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_destinationType_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
@@ -2973,7 +3847,7 @@ func (ec *executionContext) field_Query_source_args(ctx context.Context, rawArgs
 	return args, nil
 }
 
-func (ec *executionContext) field_Subscription_agentChanges_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_destinationTypes_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 *string
@@ -2994,34 +3868,67 @@ func (ec *executionContext) field_Subscription_agentChanges_args(ctx context.Con
 		}
 	}
 	args["query"] = arg1
+	var arg2 *int
+	if tmp, ok := rawArgs["offset"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("offset"))
+		arg2, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["offset"] = arg2
+	var arg3 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg3, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg3
+	var arg4 *string
+	if tmp, ok := rawArgs["sort"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sort"))
+		arg4, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["sort"] = arg4
 	return args, nil
 }
 
-func (ec *executionContext) field_Subscription_agentMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_destinationWithType_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
-	if tmp, ok := rawArgs["period"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
 		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["period"] = arg0
-	var arg1 []string
-	if tmp, ok := rawArgs["ids"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("ids"))
-		arg1, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+	args["name"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_destination_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["ids"] = arg1
+	args["name"] = arg0
 	return args, nil
 }
 
-func (ec *executionContext) field_Subscription_configurationChanges_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_destinations_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 *string
@@ -3042,43 +3949,103 @@ func (ec *executionContext) field_Subscription_configurationChanges_args(ctx con
 		}
 	}
 	args["query"] = arg1
+	var arg2 *int
+	if tmp, ok := rawArgs["offset"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("offset"))
+		arg2, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["offset"] = arg2
+	var arg3 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg3, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg3
+	var arg4 *string
+	if tmp, ok := rawArgs["sort"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sort"))
+		arg4, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["sort"] = arg4
 	return args, nil
 }
 
-func (ec *executionContext) field_Subscription_configurationMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_extensionType_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
-	if tmp, ok := rawArgs["period"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
 		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["period"] = arg0
+	args["name"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_extensionTypes_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["selector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["selector"] = arg0
 	var arg1 *string
-	if tmp, ok := rawArgs["name"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+	if tmp, ok := rawArgs["query"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
 		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["name"] = arg1
-	var arg2 *string
-	if tmp, ok := rawArgs["agent"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("agent"))
-		arg2, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+	args["query"] = arg1
+	var arg2 *int
+	if tmp, ok := rawArgs["offset"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("offset"))
+		arg2, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["agent"] = arg2
+	args["offset"] = arg2
+	var arg3 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg3, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg3
+	var arg4 *string
+	if tmp, ok := rawArgs["sort"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sort"))
+		arg4, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["sort"] = arg4
 	return args, nil
 }
 
-func (ec *executionContext) field_Subscription_overviewMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_overviewMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
 	var arg0 string
@@ -3111,90 +4078,3598 @@ func (ec *executionContext) field_Subscription_overviewMetrics_args(ctx context.
 	return args, nil
 }
 
-func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_overviewPage_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
+	var arg0 []string
+	if tmp, ok := rawArgs["configIDs"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("configIDs"))
+		arg0, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["includeDeprecated"] = arg0
+	args["configIDs"] = arg0
+	var arg1 []string
+	if tmp, ok := rawArgs["destinationIDs"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("destinationIDs"))
+		arg1, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["destinationIDs"] = arg1
+	var arg2 string
+	if tmp, ok := rawArgs["period"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
+		arg2, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["period"] = arg2
+	var arg3 string
+	if tmp, ok := rawArgs["telemetryType"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("telemetryType"))
+		arg3, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["telemetryType"] = arg3
 	return args, nil
 }
 
-func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) field_Query_processorType_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
 	var err error
 	args := map[string]interface{}{}
-	var arg0 bool
-	if tmp, ok := rawArgs["includeDeprecated"]; ok {
-		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
-		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
 		if err != nil {
 			return nil, err
 		}
 	}
-	args["includeDeprecated"] = arg0
+	args["name"] = arg0
 	return args, nil
 }
 
-// endregion ***************************** args.gotpl *****************************
+func (ec *executionContext) field_Query_processorTypes_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["selector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["selector"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["query"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["query"] = arg1
+	var arg2 *int
+	if tmp, ok := rawArgs["offset"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("offset"))
+		arg2, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["offset"] = arg2
+	var arg3 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg3, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg3
+	var arg4 *string
+	if tmp, ok := rawArgs["sort"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sort"))
+		arg4, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["sort"] = arg4
+	return args, nil
+}
 
-// region    ************************** directives.gotpl **************************
+func (ec *executionContext) field_Query_processor_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	return args, nil
+}
 
-// endregion ************************** directives.gotpl **************************
+func (ec *executionContext) field_Query_processors_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["selector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["selector"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["query"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["query"] = arg1
+	var arg2 *int
+	if tmp, ok := rawArgs["offset"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("offset"))
+		arg2, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["offset"] = arg2
+	var arg3 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg3, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg3
+	var arg4 *string
+	if tmp, ok := rawArgs["sort"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sort"))
+		arg4, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["sort"] = arg4
+	return args, nil
+}
 
-// region    **************************** field.gotpl *****************************
+func (ec *executionContext) field_Query_rollout_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	return args, nil
+}
 
-func (ec *executionContext) _Agent_id(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_id(ctx, field)
-	if err != nil {
-		return graphql.Null
+func (ec *executionContext) field_Query_snapshot_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["agentID"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("agentID"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
 	}
-	ctx = graphql.WithFieldContext(ctx, fc)
-	defer func() {
-		if r := recover(); r != nil {
-			ec.Error(ctx, ec.Recover(ctx, r))
-			ret = graphql.Null
+	args["agentID"] = arg0
+	var arg1 otel.PipelineType
+	if tmp, ok := rawArgs["pipelineType"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("pipelineType"))
+		arg1, err = ec.unmarshalNPipelineType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋotelᚐPipelineType(ctx, tmp)
+		if err != nil {
+			return nil, err
 		}
-	}()
-	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
-		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
-	})
-	if err != nil {
-		ec.Error(ctx, err)
-		return graphql.Null
 	}
-	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
+	args["pipelineType"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_sourceType_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
 		}
-		return graphql.Null
 	}
-	res := resTmp.(string)
-	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	args["name"] = arg0
+	return args, nil
 }
 
-func (ec *executionContext) fieldContext_Agent_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
-	fc = &graphql.FieldContext{
-		Object:     "Agent",
-		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+func (ec *executionContext) field_Query_sourceTypes_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["selector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["selector"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["query"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["query"] = arg1
+	var arg2 *int
+	if tmp, ok := rawArgs["offset"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("offset"))
+		arg2, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["offset"] = arg2
+	var arg3 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg3, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg3
+	var arg4 *string
+	if tmp, ok := rawArgs["sort"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sort"))
+		arg4, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["sort"] = arg4
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_source_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field_Query_sources_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["selector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["selector"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["query"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["query"] = arg1
+	var arg2 *int
+	if tmp, ok := rawArgs["offset"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("offset"))
+		arg2, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["offset"] = arg2
+	var arg3 *int
+	if tmp, ok := rawArgs["limit"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("limit"))
+		arg3, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["limit"] = arg3
+	var arg4 *string
+	if tmp, ok := rawArgs["sort"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("sort"))
+		arg4, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["sort"] = arg4
+	return args, nil
+}
+
+func (ec *executionContext) field_Rollout_agentIds_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["status"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("status"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["status"] = arg0
+	var arg1 *int
+	if tmp, ok := rawArgs["wave"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("wave"))
+		arg1, err = ec.unmarshalOInt2ᚖint(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["wave"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Subscription_agentChanges_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["selector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["selector"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["query"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["query"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Subscription_agentMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["period"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["period"] = arg0
+	var arg1 []string
+	if tmp, ok := rawArgs["ids"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("ids"))
+		arg1, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["ids"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Subscription_configurationChanges_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 *string
+	if tmp, ok := rawArgs["selector"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("selector"))
+		arg0, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["selector"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["query"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("query"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["query"] = arg1
+	return args, nil
+}
+
+func (ec *executionContext) field_Subscription_configurationMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["period"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["period"] = arg0
+	var arg1 *string
+	if tmp, ok := rawArgs["name"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("name"))
+		arg1, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["name"] = arg1
+	var arg2 *string
+	if tmp, ok := rawArgs["agent"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("agent"))
+		arg2, err = ec.unmarshalOString2ᚖstring(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["agent"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field_Subscription_overviewMetrics_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 string
+	if tmp, ok := rawArgs["period"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("period"))
+		arg0, err = ec.unmarshalNString2string(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["period"] = arg0
+	var arg1 []string
+	if tmp, ok := rawArgs["configIDs"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("configIDs"))
+		arg1, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["configIDs"] = arg1
+	var arg2 []string
+	if tmp, ok := rawArgs["destinationIDs"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("destinationIDs"))
+		arg2, err = ec.unmarshalOID2ᚕstringᚄ(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["destinationIDs"] = arg2
+	return args, nil
+}
+
+func (ec *executionContext) field___Type_enumValues_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 bool
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+func (ec *executionContext) field___Type_fields_args(ctx context.Context, rawArgs map[string]interface{}) (map[string]interface{}, error) {
+	var err error
+	args := map[string]interface{}{}
+	var arg0 bool
+	if tmp, ok := rawArgs["includeDeprecated"]; ok {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("includeDeprecated"))
+		arg0, err = ec.unmarshalOBoolean2bool(ctx, tmp)
+		if err != nil {
+			return nil, err
+		}
+	}
+	args["includeDeprecated"] = arg0
+	return args, nil
+}
+
+// endregion ***************************** args.gotpl *****************************
+
+// region    ************************** directives.gotpl **************************
+
+// endregion ************************** directives.gotpl **************************
+
+// region    **************************** field.gotpl *****************************
+
+func (ec *executionContext) _Agent_id(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_architecture(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_architecture(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Architecture, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_architecture(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_hostName(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_hostName(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.HostName, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_hostName(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_labels(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_labels(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Agent().Labels(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(map[string]interface{})
+	fc.Result = res
+	return ec.marshalOMap2map(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_labels(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Map does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_platform(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_platform(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Platform, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_platform(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_operatingSystem(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_operatingSystem(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.OperatingSystem, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_operatingSystem(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_version(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_version(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Version, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_version(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_name(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_name(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_home(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_home(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Home, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_home(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_macAddress(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_macAddress(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.MacAddress, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_macAddress(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_remoteAddress(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_remoteAddress(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.RemoteAddress, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_remoteAddress(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_type(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_type(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Type, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_status(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Agent().Status(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_errorMessage(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_errorMessage(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ErrorMessage, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_errorMessage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_connectedAt(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_connectedAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ConnectedAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	fc.Result = res
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_connectedAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_disconnectedAt(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_disconnectedAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.DisconnectedAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*time.Time)
+	fc.Result = res
+	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_disconnectedAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_configuration(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_configuration(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Agent().Configuration(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model.AgentConfiguration)
+	fc.Result = res
+	return ec.marshalOAgentConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐAgentConfiguration(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_configuration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "Collector":
+				return ec.fieldContext_AgentConfiguration_Collector(ctx, field)
+			case "Logging":
+				return ec.fieldContext_AgentConfiguration_Logging(ctx, field)
+			case "Manager":
+				return ec.fieldContext_AgentConfiguration_Manager(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AgentConfiguration", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_configurationResource(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_configurationResource(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Agent().ConfigurationResource(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model1.Configuration)
+	fc.Result = res
+	return ec.marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_configurationResource(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Configuration_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Configuration_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Configuration_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Configuration_spec(ctx, field)
+			case "agentCount":
+				return ec.fieldContext_Configuration_agentCount(ctx, field)
+			case "graph":
+				return ec.fieldContext_Configuration_graph(ctx, field)
+			case "rendered":
+				return ec.fieldContext_Configuration_rendered(ctx, field)
+			case "rollout":
+				return ec.fieldContext_Configuration_rollout(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_upgrade(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_upgrade(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Upgrade, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model1.AgentUpgrade)
+	fc.Result = res
+	return ec.marshalOAgentUpgrade2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentUpgrade(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_upgrade(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "status":
+				return ec.fieldContext_AgentUpgrade_status(ctx, field)
+			case "version":
+				return ec.fieldContext_AgentUpgrade_version(ctx, field)
+			case "error":
+				return ec.fieldContext_AgentUpgrade_error(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AgentUpgrade", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_restart(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_restart(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Restart, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model1.AgentRestart)
+	fc.Result = res
+	return ec.marshalOAgentRestart2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentRestart(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_restart(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "status":
+				return ec.fieldContext_AgentRestart_status(ctx, field)
+			case "requestedAt":
+				return ec.fieldContext_AgentRestart_requestedAt(ctx, field)
+			case "error":
+				return ec.fieldContext_AgentRestart_error(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AgentRestart", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_upgradeAvailable(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_upgradeAvailable(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Agent().UpgradeAvailable(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_upgradeAvailable(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_features(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_features(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Agent().Features(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_features(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agent_history(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agent_history(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Agent().History(rctx, obj, fc.Args["start"].(*time.Time), fc.Args["end"].(*time.Time))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model1.AgentHistory)
+	fc.Result = res
+	return ec.marshalNAgentHistory2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentHistory(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agent_history(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agent",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "start":
+				return ec.fieldContext_AgentHistory_start(ctx, field)
+			case "end":
+				return ec.fieldContext_AgentHistory_end(ctx, field)
+			case "changes":
+				return ec.fieldContext_AgentHistory_changes(ctx, field)
+			case "uptime":
+				return ec.fieldContext_AgentHistory_uptime(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AgentHistory", field.Name)
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Agent_history_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentChange_agent(ctx context.Context, field graphql.CollectedField, obj *model.AgentChange) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentChange_agent(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Agent, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model1.Agent)
+	fc.Result = res
+	return ec.marshalNAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentChange_agent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentChange",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Agent_id(ctx, field)
+			case "architecture":
+				return ec.fieldContext_Agent_architecture(ctx, field)
+			case "hostName":
+				return ec.fieldContext_Agent_hostName(ctx, field)
+			case "labels":
+				return ec.fieldContext_Agent_labels(ctx, field)
+			case "platform":
+				return ec.fieldContext_Agent_platform(ctx, field)
+			case "operatingSystem":
+				return ec.fieldContext_Agent_operatingSystem(ctx, field)
+			case "version":
+				return ec.fieldContext_Agent_version(ctx, field)
+			case "name":
+				return ec.fieldContext_Agent_name(ctx, field)
+			case "home":
+				return ec.fieldContext_Agent_home(ctx, field)
+			case "macAddress":
+				return ec.fieldContext_Agent_macAddress(ctx, field)
+			case "remoteAddress":
+				return ec.fieldContext_Agent_remoteAddress(ctx, field)
+			case "type":
+				return ec.fieldContext_Agent_type(ctx, field)
+			case "status":
+				return ec.fieldContext_Agent_status(ctx, field)
+			case "errorMessage":
+				return ec.fieldContext_Agent_errorMessage(ctx, field)
+			case "connectedAt":
+				return ec.fieldContext_Agent_connectedAt(ctx, field)
+			case "disconnectedAt":
+				return ec.fieldContext_Agent_disconnectedAt(ctx, field)
+			case "configuration":
+				return ec.fieldContext_Agent_configuration(ctx, field)
+			case "configurationResource":
+				return ec.fieldContext_Agent_configurationResource(ctx, field)
+			case "upgrade":
+				return ec.fieldContext_Agent_upgrade(ctx, field)
+			case "restart":
+				return ec.fieldContext_Agent_restart(ctx, field)
+			case "upgradeAvailable":
+				return ec.fieldContext_Agent_upgradeAvailable(ctx, field)
+			case "features":
+				return ec.fieldContext_Agent_features(ctx, field)
+			case "history":
+				return ec.fieldContext_Agent_history(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Agent", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentChange_changeType(ctx context.Context, field graphql.CollectedField, obj *model.AgentChange) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentChange_changeType(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ChangeType, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(model.AgentChangeType)
+	fc.Result = res
+	return ec.marshalNAgentChangeType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐAgentChangeType(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentChange_changeType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentChange",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type AgentChangeType does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentConfiguration_Collector(ctx context.Context, field graphql.CollectedField, obj *model.AgentConfiguration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentConfiguration_Collector(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Collector, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentConfiguration_Collector(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentConfiguration",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentConfiguration_Logging(ctx context.Context, field graphql.CollectedField, obj *model.AgentConfiguration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentConfiguration_Logging(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Logging, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentConfiguration_Logging(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentConfiguration",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentConfiguration_Manager(ctx context.Context, field graphql.CollectedField, obj *model.AgentConfiguration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentConfiguration_Manager(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Manager, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(map[string]interface{})
+	fc.Result = res
+	return ec.marshalOMap2map(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentConfiguration_Manager(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentConfiguration",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Map does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentHistory_start(ctx context.Context, field graphql.CollectedField, obj *model1.AgentHistory) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentHistory_start(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Start, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentHistory_start(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentHistory",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentHistory_end(ctx context.Context, field graphql.CollectedField, obj *model1.AgentHistory) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentHistory_end(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.End, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentHistory_end(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentHistory",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentHistory_changes(ctx context.Context, field graphql.CollectedField, obj *model1.AgentHistory) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentHistory_changes(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Changes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model1.AgentStatusChange)
+	fc.Result = res
+	return ec.marshalNAgentStatusChange2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentStatusChangeᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentHistory_changes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentHistory",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "agentId":
+				return ec.fieldContext_AgentStatusChange_agentId(ctx, field)
+			case "timestamp":
+				return ec.fieldContext_AgentStatusChange_timestamp(ctx, field)
+			case "status":
+				return ec.fieldContext_AgentStatusChange_status(ctx, field)
+			case "errorMessage":
+				return ec.fieldContext_AgentStatusChange_errorMessage(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AgentStatusChange", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentHistory_uptime(ctx context.Context, field graphql.CollectedField, obj *model1.AgentHistory) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentHistory_uptime(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Uptime, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(float64)
+	fc.Result = res
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentHistory_uptime(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentHistory",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Float does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentRestart_status(ctx context.Context, field graphql.CollectedField, obj *model1.AgentRestart) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentRestart_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.AgentRestart().Status(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentRestart_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentRestart",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentRestart_requestedAt(ctx context.Context, field graphql.CollectedField, obj *model1.AgentRestart) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentRestart_requestedAt(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.RequestedAt, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentRestart_requestedAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentRestart",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentRestart_error(ctx context.Context, field graphql.CollectedField, obj *model1.AgentRestart) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentRestart_error(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Error, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentRestart_error(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentRestart",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentSelector_matchLabels(ctx context.Context, field graphql.CollectedField, obj *model1.AgentSelector) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentSelector_matchLabels(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.AgentSelector().MatchLabels(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(map[string]interface{})
+	fc.Result = res
+	return ec.marshalOMap2map(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentSelector_matchLabels(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentSelector",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Map does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentStatusChange_agentId(ctx context.Context, field graphql.CollectedField, obj *model1.AgentStatusChange) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentStatusChange_agentId(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.AgentID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentStatusChange_agentId(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentStatusChange",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentStatusChange_timestamp(ctx context.Context, field graphql.CollectedField, obj *model1.AgentStatusChange) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentStatusChange_timestamp(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Timestamp, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentStatusChange_timestamp(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentStatusChange",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentStatusChange_status(ctx context.Context, field graphql.CollectedField, obj *model1.AgentStatusChange) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentStatusChange_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.AgentStatusChange().Status(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentStatusChange_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentStatusChange",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentStatusChange_errorMessage(ctx context.Context, field graphql.CollectedField, obj *model1.AgentStatusChange) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentStatusChange_errorMessage(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ErrorMessage, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentStatusChange_errorMessage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentStatusChange",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentUpgrade_status(ctx context.Context, field graphql.CollectedField, obj *model1.AgentUpgrade) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentUpgrade_status(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.AgentUpgrade().Status(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(int)
+	fc.Result = res
+	return ec.marshalNInt2int(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentUpgrade_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentUpgrade",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentUpgrade_version(ctx context.Context, field graphql.CollectedField, obj *model1.AgentUpgrade) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentUpgrade_version(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Version, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentUpgrade_version(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentUpgrade",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AgentUpgrade_error(ctx context.Context, field graphql.CollectedField, obj *model1.AgentUpgrade) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AgentUpgrade_error(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Error, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalOString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AgentUpgrade_error(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AgentUpgrade",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agents_query(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agents_query(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Query, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agents_query(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agents",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agents_agents(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agents_agents(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Agents, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]*model1.Agent)
+	fc.Result = res
+	return ec.marshalNAgent2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agents_agents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agents",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Agent_id(ctx, field)
+			case "architecture":
+				return ec.fieldContext_Agent_architecture(ctx, field)
+			case "hostName":
+				return ec.fieldContext_Agent_hostName(ctx, field)
+			case "labels":
+				return ec.fieldContext_Agent_labels(ctx, field)
+			case "platform":
+				return ec.fieldContext_Agent_platform(ctx, field)
+			case "operatingSystem":
+				return ec.fieldContext_Agent_operatingSystem(ctx, field)
+			case "version":
+				return ec.fieldContext_Agent_version(ctx, field)
+			case "name":
+				return ec.fieldContext_Agent_name(ctx, field)
+			case "home":
+				return ec.fieldContext_Agent_home(ctx, field)
+			case "macAddress":
+				return ec.fieldContext_Agent_macAddress(ctx, field)
+			case "remoteAddress":
+				return ec.fieldContext_Agent_remoteAddress(ctx, field)
+			case "type":
+				return ec.fieldContext_Agent_type(ctx, field)
+			case "status":
+				return ec.fieldContext_Agent_status(ctx, field)
+			case "errorMessage":
+				return ec.fieldContext_Agent_errorMessage(ctx, field)
+			case "connectedAt":
+				return ec.fieldContext_Agent_connectedAt(ctx, field)
+			case "disconnectedAt":
+				return ec.fieldContext_Agent_disconnectedAt(ctx, field)
+			case "configuration":
+				return ec.fieldContext_Agent_configuration(ctx, field)
+			case "configurationResource":
+				return ec.fieldContext_Agent_configurationResource(ctx, field)
+			case "upgrade":
+				return ec.fieldContext_Agent_upgrade(ctx, field)
+			case "restart":
+				return ec.fieldContext_Agent_restart(ctx, field)
+			case "upgradeAvailable":
+				return ec.fieldContext_Agent_upgradeAvailable(ctx, field)
+			case "features":
+				return ec.fieldContext_Agent_features(ctx, field)
+			case "history":
+				return ec.fieldContext_Agent_history(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Agent", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agents_suggestions(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agents_suggestions(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Suggestions, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.([]*search.Suggestion)
+	fc.Result = res
+	return ec.marshalOSuggestion2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋstoreᚋsearchᚐSuggestionᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agents_suggestions(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agents",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "label":
+				return ec.fieldContext_Suggestion_label(ctx, field)
+			case "query":
+				return ec.fieldContext_Suggestion_query(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Suggestion", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Agents_latestVersion(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Agents_latestVersion(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.LatestVersion, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Agents_latestVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Agents",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuditEntry_id(ctx context.Context, field graphql.CollectedField, obj *model1.AuditEntry) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AuditEntry_id(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.ID, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNID2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AuditEntry_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuditEntry",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type ID does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuditEntry_timestamp(ctx context.Context, field graphql.CollectedField, obj *model1.AuditEntry) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AuditEntry_timestamp(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Timestamp, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(time.Time)
+	fc.Result = res
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AuditEntry_timestamp(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuditEntry",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Time does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuditEntry_user(ctx context.Context, field graphql.CollectedField, obj *model1.AuditEntry) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AuditEntry_user(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.User, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AuditEntry_user(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuditEntry",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuditEntry_action(ctx context.Context, field graphql.CollectedField, obj *model1.AuditEntry) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AuditEntry_action(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.AuditEntry().Action(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AuditEntry_action(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuditEntry",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuditEntry_kind(ctx context.Context, field graphql.CollectedField, obj *model1.AuditEntry) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AuditEntry_kind(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.AuditEntry().Kind(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AuditEntry_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuditEntry",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuditEntry_name(ctx context.Context, field graphql.CollectedField, obj *model1.AuditEntry) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AuditEntry_name(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Name, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AuditEntry_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuditEntry",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuditEntry_before(ctx context.Context, field graphql.CollectedField, obj *model1.AuditEntry) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AuditEntry_before(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Before, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(map[string]any)
+	fc.Result = res
+	return ec.marshalOMap2map(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AuditEntry_before(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuditEntry",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Map does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuditEntry_after(ctx context.Context, field graphql.CollectedField, obj *model1.AuditEntry) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AuditEntry_after(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.After, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(map[string]any)
+	fc.Result = res
+	return ec.marshalOMap2map(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AuditEntry_after(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuditEntry",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Map does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _AuditEntry_changes(ctx context.Context, field graphql.CollectedField, obj *model1.AuditEntry) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_AuditEntry_changes(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Changes, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.([]string)
+	fc.Result = res
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_AuditEntry_changes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "AuditEntry",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Configuration_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configuration_apiVersion(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.APIVersion, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Configuration_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Configuration",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Configuration_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configuration_kind(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Configuration().Kind(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(string)
+	fc.Result = res
+	return ec.marshalNString2string(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Configuration_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Configuration",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Configuration_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configuration_metadata(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Metadata, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(model1.Metadata)
+	fc.Result = res
+	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Configuration_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Configuration",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Metadata_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Metadata_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Metadata_displayName(ctx, field)
+			case "description":
+				return ec.fieldContext_Metadata_description(ctx, field)
+			case "icon":
+				return ec.fieldContext_Metadata_icon(ctx, field)
+			case "labels":
+				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "revision":
+				return ec.fieldContext_Metadata_revision(ctx, field)
+			case "resourceVersion":
+				return ec.fieldContext_Metadata_resourceVersion(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Configuration_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configuration_spec(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Spec, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(model1.ConfigurationSpec)
+	fc.Result = res
+	return ec.marshalNConfigurationSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationSpec(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Configuration_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Configuration",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "contentType":
+				return ec.fieldContext_ConfigurationSpec_contentType(ctx, field)
+			case "raw":
+				return ec.fieldContext_ConfigurationSpec_raw(ctx, field)
+			case "sources":
+				return ec.fieldContext_ConfigurationSpec_sources(ctx, field)
+			case "destinations":
+				return ec.fieldContext_ConfigurationSpec_destinations(ctx, field)
+			case "selector":
+				return ec.fieldContext_ConfigurationSpec_selector(ctx, field)
+			case "routes":
+				return ec.fieldContext_ConfigurationSpec_routes(ctx, field)
+			case "extensions":
+				return ec.fieldContext_ConfigurationSpec_extensions(ctx, field)
+			case "serviceTelemetry":
+				return ec.fieldContext_ConfigurationSpec_serviceTelemetry(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ConfigurationSpec", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Configuration_agentCount(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configuration_agentCount(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Configuration().AgentCount(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*int)
+	fc.Result = res
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Configuration_agentCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Configuration",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Int does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Configuration_graph(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configuration_graph(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Configuration().Graph(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*graph.Graph)
+	fc.Result = res
+	return ec.marshalOGraph2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐGraph(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Configuration_graph(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Configuration",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "sources":
+				return ec.fieldContext_Graph_sources(ctx, field)
+			case "intermediates":
+				return ec.fieldContext_Graph_intermediates(ctx, field)
+			case "targets":
+				return ec.fieldContext_Graph_targets(ctx, field)
+			case "edges":
+				return ec.fieldContext_Graph_edges(ctx, field)
+			case "attributes":
+				return ec.fieldContext_Graph_attributes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Graph", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Configuration_rendered(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configuration_rendered(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Configuration().Rendered(rctx, obj, fc.Args["agentId"].(*string))
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*string)
+	fc.Result = res
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Configuration_rendered(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Configuration",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Configuration_rendered_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _Configuration_rollout(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configuration_rollout(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return ec.resolvers.Configuration().Rollout(rctx, obj)
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(*model1.Rollout)
+	fc.Result = res
+	return ec.marshalORollout2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Configuration_rollout(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Configuration",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_Rollout_name(ctx, field)
+			case "revision":
+				return ec.fieldContext_Rollout_revision(ctx, field)
+			case "previousRevision":
+				return ec.fieldContext_Rollout_previousRevision(ctx, field)
+			case "rollbackRevision":
+				return ec.fieldContext_Rollout_rollbackRevision(ctx, field)
+			case "status":
+				return ec.fieldContext_Rollout_status(ctx, field)
+			case "options":
+				return ec.fieldContext_Rollout_options(ctx, field)
+			case "message":
+				return ec.fieldContext_Rollout_message(ctx, field)
+			case "wave":
+				return ec.fieldContext_Rollout_wave(ctx, field)
+			case "waveStartedAt":
+				return ec.fieldContext_Rollout_waveStartedAt(ctx, field)
+			case "startedAt":
+				return ec.fieldContext_Rollout_startedAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Rollout_updatedAt(ctx, field)
+			case "agentIds":
+				return ec.fieldContext_Rollout_agentIds(ctx, field)
+			case "pending":
+				return ec.fieldContext_Rollout_pending(ctx, field)
+			case "applied":
+				return ec.fieldContext_Rollout_applied(ctx, field)
+			case "failed":
+				return ec.fieldContext_Rollout_failed(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Rollout", field.Name)
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ConfigurationChange_configuration(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationChange) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationChange_configuration(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Configuration, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(*model1.Configuration)
+	fc.Result = res
+	return ec.marshalNConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ConfigurationChange_configuration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ConfigurationChange",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Configuration_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Configuration_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Configuration_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Configuration_spec(ctx, field)
+			case "agentCount":
+				return ec.fieldContext_Configuration_agentCount(ctx, field)
+			case "graph":
+				return ec.fieldContext_Configuration_graph(ctx, field)
+			case "rendered":
+				return ec.fieldContext_Configuration_rendered(ctx, field)
+			case "rollout":
+				return ec.fieldContext_Configuration_rollout(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_architecture(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_architecture(ctx, field)
+func (ec *executionContext) _ConfigurationChange_eventType(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationChange) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationChange_eventType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3207,35 +7682,38 @@ func (ec *executionContext) _Agent_architecture(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Architecture, nil
+		return obj.EventType, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(model.EventType)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNEventType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐEventType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_architecture(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationChange_eventType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationChange",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type EventType does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_hostName(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_hostName(ctx, field)
+func (ec *executionContext) _ConfigurationRoute_sources(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationRoute) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationRoute_sources(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3248,23 +7726,26 @@ func (ec *executionContext) _Agent_hostName(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.HostName, nil
+		return obj.Sources, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_hostName(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationRoute_sources(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationRoute",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3275,8 +7756,8 @@ func (ec *executionContext) fieldContext_Agent_hostName(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_labels(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_labels(ctx, field)
+func (ec *executionContext) _ConfigurationRoute_destinations(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationRoute) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationRoute_destinations(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3289,35 +7770,38 @@ func (ec *executionContext) _Agent_labels(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Agent().Labels(rctx, obj)
+		return obj.Destinations, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(map[string]interface{})
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalOMap2map(ctx, field.Selections, res)
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_labels(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationRoute_destinations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationRoute",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Map does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_platform(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_platform(ctx, field)
+func (ec *executionContext) _ConfigurationRoute_types(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationRoute) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationRoute_types(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3330,7 +7814,7 @@ func (ec *executionContext) _Agent_platform(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Platform, nil
+		return obj.Types, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3339,26 +7823,26 @@ func (ec *executionContext) _Agent_platform(ctx context.Context, field graphql.C
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]otel.PipelineType)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOPipelineType2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋotelᚐPipelineTypeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_platform(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationRoute_types(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationRoute",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type PipelineType does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_operatingSystem(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_operatingSystem(ctx, field)
+func (ec *executionContext) _ConfigurationSpec_contentType(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationSpec_contentType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3371,7 +7855,7 @@ func (ec *executionContext) _Agent_operatingSystem(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.OperatingSystem, nil
+		return obj.ContentType, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3385,9 +7869,9 @@ func (ec *executionContext) _Agent_operatingSystem(ctx context.Context, field gr
 	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_operatingSystem(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationSpec_contentType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3398,8 +7882,8 @@ func (ec *executionContext) fieldContext_Agent_operatingSystem(ctx context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_version(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_version(ctx, field)
+func (ec *executionContext) _ConfigurationSpec_raw(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationSpec_raw(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3412,7 +7896,7 @@ func (ec *executionContext) _Agent_version(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Version, nil
+		return obj.Raw, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3426,9 +7910,9 @@ func (ec *executionContext) _Agent_version(ctx context.Context, field graphql.Co
 	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_version(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationSpec_raw(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3439,8 +7923,8 @@ func (ec *executionContext) fieldContext_Agent_version(ctx context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_name(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_name(ctx, field)
+func (ec *executionContext) _ConfigurationSpec_sources(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationSpec_sources(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3453,38 +7937,47 @@ func (ec *executionContext) _Agent_name(ctx context.Context, field graphql.Colle
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.Sources, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]model1.ResourceConfiguration)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationSpec_sources(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
+			case "type":
+				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
+			case "processors":
+				return ec.fieldContext_ResourceConfiguration_processors(ctx, field)
+			case "disabled":
+				return ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ResourceConfiguration", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_home(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_home(ctx, field)
+func (ec *executionContext) _ConfigurationSpec_destinations(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationSpec_destinations(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3497,7 +7990,7 @@ func (ec *executionContext) _Agent_home(ctx context.Context, field graphql.Colle
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Home, nil
+		return obj.Destinations, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3506,26 +7999,38 @@ func (ec *executionContext) _Agent_home(ctx context.Context, field graphql.Colle
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]model1.ResourceConfiguration)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_home(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationSpec_destinations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
+			case "type":
+				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
+			case "processors":
+				return ec.fieldContext_ResourceConfiguration_processors(ctx, field)
+			case "disabled":
+				return ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ResourceConfiguration", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_macAddress(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_macAddress(ctx, field)
+func (ec *executionContext) _ConfigurationSpec_selector(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationSpec_selector(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3538,7 +8043,7 @@ func (ec *executionContext) _Agent_macAddress(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MacAddress, nil
+		return obj.Selector, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3547,26 +8052,30 @@ func (ec *executionContext) _Agent_macAddress(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(model1.AgentSelector)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOAgentSelector2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentSelector(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_macAddress(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationSpec_selector(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "matchLabels":
+				return ec.fieldContext_AgentSelector_matchLabels(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AgentSelector", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_remoteAddress(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_remoteAddress(ctx, field)
+func (ec *executionContext) _ConfigurationSpec_routes(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationSpec_routes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3579,7 +8088,7 @@ func (ec *executionContext) _Agent_remoteAddress(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.RemoteAddress, nil
+		return obj.Routes, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3588,26 +8097,34 @@ func (ec *executionContext) _Agent_remoteAddress(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]model1.ConfigurationRoute)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOConfigurationRoute2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationRouteᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_remoteAddress(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationSpec_routes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "sources":
+				return ec.fieldContext_ConfigurationRoute_sources(ctx, field)
+			case "destinations":
+				return ec.fieldContext_ConfigurationRoute_destinations(ctx, field)
+			case "types":
+				return ec.fieldContext_ConfigurationRoute_types(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ConfigurationRoute", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_type(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_type(ctx, field)
+func (ec *executionContext) _ConfigurationSpec_extensions(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationSpec_extensions(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3620,7 +8137,7 @@ func (ec *executionContext) _Agent_type(ctx context.Context, field graphql.Colle
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return obj.Extensions, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3629,26 +8146,38 @@ func (ec *executionContext) _Agent_type(ctx context.Context, field graphql.Colle
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]model1.ResourceConfiguration)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationSpec_extensions(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
+			case "type":
+				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
+			case "processors":
+				return ec.fieldContext_ResourceConfiguration_processors(ctx, field)
+			case "disabled":
+				return ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ResourceConfiguration", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_status(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_status(ctx, field)
+func (ec *executionContext) _ConfigurationSpec_serviceTelemetry(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ConfigurationSpec_serviceTelemetry(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3661,38 +8190,35 @@ func (ec *executionContext) _Agent_status(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Agent().Status(rctx, obj)
+		return obj.ServiceTelemetry, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(map[string]any)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ConfigurationSpec_serviceTelemetry(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "ConfigurationSpec",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type Map does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_errorMessage(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_errorMessage(ctx, field)
+func (ec *executionContext) _Configurations_query(ctx context.Context, field graphql.CollectedField, obj *model.Configurations) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configurations_query(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3705,7 +8231,7 @@ func (ec *executionContext) _Agent_errorMessage(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ErrorMessage, nil
+		return obj.Query, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3714,14 +8240,14 @@ func (ec *executionContext) _Agent_errorMessage(ctx context.Context, field graph
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_errorMessage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Configurations_query(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "Configurations",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -3732,8 +8258,8 @@ func (ec *executionContext) fieldContext_Agent_errorMessage(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_connectedAt(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_connectedAt(ctx, field)
+func (ec *executionContext) _Configurations_configurations(ctx context.Context, field graphql.CollectedField, obj *model.Configurations) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configurations_configurations(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3746,35 +8272,56 @@ func (ec *executionContext) _Agent_connectedAt(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ConnectedAt, nil
+		return obj.Configurations, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*time.Time)
+	res := resTmp.([]*model1.Configuration)
 	fc.Result = res
-	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+	return ec.marshalNConfiguration2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_connectedAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Configurations_configurations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "Configurations",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Configuration_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Configuration_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Configuration_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Configuration_spec(ctx, field)
+			case "agentCount":
+				return ec.fieldContext_Configuration_agentCount(ctx, field)
+			case "graph":
+				return ec.fieldContext_Configuration_graph(ctx, field)
+			case "rendered":
+				return ec.fieldContext_Configuration_rendered(ctx, field)
+			case "rollout":
+				return ec.fieldContext_Configuration_rollout(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_disconnectedAt(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_disconnectedAt(ctx, field)
+func (ec *executionContext) _Configurations_suggestions(ctx context.Context, field graphql.CollectedField, obj *model.Configurations) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Configurations_suggestions(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3787,7 +8334,7 @@ func (ec *executionContext) _Agent_disconnectedAt(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DisconnectedAt, nil
+		return obj.Suggestions, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -3796,26 +8343,32 @@ func (ec *executionContext) _Agent_disconnectedAt(ctx context.Context, field gra
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*time.Time)
+	res := resTmp.([]*search.Suggestion)
 	fc.Result = res
-	return ec.marshalOTime2ᚖtimeᚐTime(ctx, field.Selections, res)
+	return ec.marshalOSuggestion2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋstoreᚋsearchᚐSuggestionᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_disconnectedAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Configurations_suggestions(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "Configurations",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			switch field.Name {
+			case "label":
+				return ec.fieldContext_Suggestion_label(ctx, field)
+			case "query":
+				return ec.fieldContext_Suggestion_query(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Suggestion", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_configuration(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_configuration(ctx, field)
+func (ec *executionContext) _Destination_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Destination_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3828,43 +8381,38 @@ func (ec *executionContext) _Agent_configuration(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Agent().Configuration(rctx, obj)
+		return obj.APIVersion, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.AgentConfiguration)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOAgentConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐAgentConfiguration(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_configuration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Destination_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "Destination",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "Collector":
-				return ec.fieldContext_AgentConfiguration_Collector(ctx, field)
-			case "Logging":
-				return ec.fieldContext_AgentConfiguration_Logging(ctx, field)
-			case "Manager":
-				return ec.fieldContext_AgentConfiguration_Manager(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type AgentConfiguration", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_configurationResource(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_configurationResource(ctx, field)
+func (ec *executionContext) _Destination_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Destination_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3877,51 +8425,38 @@ func (ec *executionContext) _Agent_configurationResource(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Agent().ConfigurationResource(rctx, obj)
+		return ec.resolvers.Destination().Kind(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Configuration)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_configurationResource(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Destination_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "Destination",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Configuration_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Configuration_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Configuration_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Configuration_spec(ctx, field)
-			case "agentCount":
-				return ec.fieldContext_Configuration_agentCount(ctx, field)
-			case "graph":
-				return ec.fieldContext_Configuration_graph(ctx, field)
-			case "rendered":
-				return ec.fieldContext_Configuration_rendered(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_upgrade(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_upgrade(ctx, field)
+func (ec *executionContext) _Destination_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Destination_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3934,43 +8469,56 @@ func (ec *executionContext) _Agent_upgrade(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Upgrade, nil
+		return obj.Metadata, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.AgentUpgrade)
+	res := resTmp.(model1.Metadata)
 	fc.Result = res
-	return ec.marshalOAgentUpgrade2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentUpgrade(ctx, field.Selections, res)
+	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_upgrade(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Destination_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "Destination",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "status":
-				return ec.fieldContext_AgentUpgrade_status(ctx, field)
-			case "version":
-				return ec.fieldContext_AgentUpgrade_version(ctx, field)
-			case "error":
-				return ec.fieldContext_AgentUpgrade_error(ctx, field)
+			case "id":
+				return ec.fieldContext_Metadata_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Metadata_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Metadata_displayName(ctx, field)
+			case "description":
+				return ec.fieldContext_Metadata_description(ctx, field)
+			case "icon":
+				return ec.fieldContext_Metadata_icon(ctx, field)
+			case "labels":
+				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "revision":
+				return ec.fieldContext_Metadata_revision(ctx, field)
+			case "resourceVersion":
+				return ec.fieldContext_Metadata_resourceVersion(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type AgentUpgrade", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_upgradeAvailable(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_upgradeAvailable(ctx, field)
+func (ec *executionContext) _Destination_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Destination_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -3983,35 +8531,48 @@ func (ec *executionContext) _Agent_upgradeAvailable(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Agent().UpgradeAvailable(rctx, obj)
+		return obj.Spec, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(model1.ParameterizedSpec)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNParameterizedSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterizedSpec(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_upgradeAvailable(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Destination_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "Destination",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "type":
+				return ec.fieldContext_ParameterizedSpec_type(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ParameterizedSpec_parameters(ctx, field)
+			case "processors":
+				return ec.fieldContext_ParameterizedSpec_processors(ctx, field)
+			case "disabled":
+				return ec.fieldContext_ParameterizedSpec_disabled(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ParameterizedSpec", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agent_features(ctx context.Context, field graphql.CollectedField, obj *model1.Agent) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agent_features(ctx, field)
+func (ec *executionContext) _DestinationType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DestinationType_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4024,7 +8585,7 @@ func (ec *executionContext) _Agent_features(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Agent().Features(rctx, obj)
+		return obj.APIVersion, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4036,26 +8597,26 @@ func (ec *executionContext) _Agent_features(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agent_features(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DestinationType_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agent",
+		Object:     "DestinationType",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentChange_agent(ctx context.Context, field graphql.CollectedField, obj *model.AgentChange) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_AgentChange_agent(ctx, field)
+func (ec *executionContext) _DestinationType_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DestinationType_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4068,7 +8629,7 @@ func (ec *executionContext) _AgentChange_agent(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Agent, nil
+		return obj.Metadata, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4080,70 +8641,44 @@ func (ec *executionContext) _AgentChange_agent(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Agent)
+	res := resTmp.(model1.Metadata)
 	fc.Result = res
-	return ec.marshalNAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx, field.Selections, res)
+	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_AgentChange_agent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DestinationType_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "AgentChange",
+		Object:     "DestinationType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Agent_id(ctx, field)
-			case "architecture":
-				return ec.fieldContext_Agent_architecture(ctx, field)
-			case "hostName":
-				return ec.fieldContext_Agent_hostName(ctx, field)
-			case "labels":
-				return ec.fieldContext_Agent_labels(ctx, field)
-			case "platform":
-				return ec.fieldContext_Agent_platform(ctx, field)
-			case "operatingSystem":
-				return ec.fieldContext_Agent_operatingSystem(ctx, field)
-			case "version":
-				return ec.fieldContext_Agent_version(ctx, field)
-			case "name":
-				return ec.fieldContext_Agent_name(ctx, field)
-			case "home":
-				return ec.fieldContext_Agent_home(ctx, field)
-			case "macAddress":
-				return ec.fieldContext_Agent_macAddress(ctx, field)
-			case "remoteAddress":
-				return ec.fieldContext_Agent_remoteAddress(ctx, field)
-			case "type":
-				return ec.fieldContext_Agent_type(ctx, field)
-			case "status":
-				return ec.fieldContext_Agent_status(ctx, field)
-			case "errorMessage":
-				return ec.fieldContext_Agent_errorMessage(ctx, field)
-			case "connectedAt":
-				return ec.fieldContext_Agent_connectedAt(ctx, field)
-			case "disconnectedAt":
-				return ec.fieldContext_Agent_disconnectedAt(ctx, field)
-			case "configuration":
-				return ec.fieldContext_Agent_configuration(ctx, field)
-			case "configurationResource":
-				return ec.fieldContext_Agent_configurationResource(ctx, field)
-			case "upgrade":
-				return ec.fieldContext_Agent_upgrade(ctx, field)
-			case "upgradeAvailable":
-				return ec.fieldContext_Agent_upgradeAvailable(ctx, field)
-			case "features":
-				return ec.fieldContext_Agent_features(ctx, field)
+			case "id":
+				return ec.fieldContext_Metadata_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Metadata_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Metadata_displayName(ctx, field)
+			case "description":
+				return ec.fieldContext_Metadata_description(ctx, field)
+			case "icon":
+				return ec.fieldContext_Metadata_icon(ctx, field)
+			case "labels":
+				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "revision":
+				return ec.fieldContext_Metadata_revision(ctx, field)
+			case "resourceVersion":
+				return ec.fieldContext_Metadata_resourceVersion(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Agent", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentChange_changeType(ctx context.Context, field graphql.CollectedField, obj *model.AgentChange) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_AgentChange_changeType(ctx, field)
+func (ec *executionContext) _DestinationType_kind(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DestinationType_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4156,7 +8691,7 @@ func (ec *executionContext) _AgentChange_changeType(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ChangeType, nil
+		return ec.resolvers.DestinationType().Kind(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4168,26 +8703,26 @@ func (ec *executionContext) _AgentChange_changeType(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model.AgentChangeType)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNAgentChangeType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐAgentChangeType(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_AgentChange_changeType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DestinationType_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "AgentChange",
+		Object:     "DestinationType",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type AgentChangeType does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentConfiguration_Collector(ctx context.Context, field graphql.CollectedField, obj *model.AgentConfiguration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_AgentConfiguration_Collector(ctx, field)
+func (ec *executionContext) _DestinationType_spec(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DestinationType_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4200,35 +8735,48 @@ func (ec *executionContext) _AgentConfiguration_Collector(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Collector, nil
+		return obj.Spec, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(model1.ResourceTypeSpec)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_AgentConfiguration_Collector(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DestinationType_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "AgentConfiguration",
+		Object:     "DestinationType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "version":
+				return ec.fieldContext_ResourceTypeSpec_version(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ResourceTypeSpec_parameters(ctx, field)
+			case "supportedPlatforms":
+				return ec.fieldContext_ResourceTypeSpec_supportedPlatforms(ctx, field)
+			case "telemetryTypes":
+				return ec.fieldContext_ResourceTypeSpec_telemetryTypes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ResourceTypeSpec", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentConfiguration_Logging(ctx context.Context, field graphql.CollectedField, obj *model.AgentConfiguration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_AgentConfiguration_Logging(ctx, field)
+func (ec *executionContext) _DestinationWithType_destination(ctx context.Context, field graphql.CollectedField, obj *model.DestinationWithType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DestinationWithType_destination(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4241,7 +8789,7 @@ func (ec *executionContext) _AgentConfiguration_Logging(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Logging, nil
+		return obj.Destination, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4250,26 +8798,36 @@ func (ec *executionContext) _AgentConfiguration_Logging(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model1.Destination)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalODestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_AgentConfiguration_Logging(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DestinationWithType_destination(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "AgentConfiguration",
+		Object:     "DestinationWithType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Destination_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Destination_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Destination_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Destination_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Destination", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentConfiguration_Manager(ctx context.Context, field graphql.CollectedField, obj *model.AgentConfiguration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_AgentConfiguration_Manager(ctx, field)
+func (ec *executionContext) _DestinationWithType_destinationType(ctx context.Context, field graphql.CollectedField, obj *model.DestinationWithType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DestinationWithType_destinationType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4282,7 +8840,7 @@ func (ec *executionContext) _AgentConfiguration_Manager(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Manager, nil
+		return obj.DestinationType, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4291,26 +8849,36 @@ func (ec *executionContext) _AgentConfiguration_Manager(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(map[string]interface{})
+	res := resTmp.(*model1.DestinationType)
 	fc.Result = res
-	return ec.marshalOMap2map(ctx, field.Selections, res)
+	return ec.marshalODestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_AgentConfiguration_Manager(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DestinationWithType_destinationType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "AgentConfiguration",
+		Object:     "DestinationWithType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Map does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_DestinationType_apiVersion(ctx, field)
+			case "metadata":
+				return ec.fieldContext_DestinationType_metadata(ctx, field)
+			case "kind":
+				return ec.fieldContext_DestinationType_kind(ctx, field)
+			case "spec":
+				return ec.fieldContext_DestinationType_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DestinationType", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentSelector_matchLabels(ctx context.Context, field graphql.CollectedField, obj *model1.AgentSelector) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_AgentSelector_matchLabels(ctx, field)
+func (ec *executionContext) _DocumentationLink_text(ctx context.Context, field graphql.CollectedField, obj *model1.DocumentationLink) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DocumentationLink_text(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4323,35 +8891,38 @@ func (ec *executionContext) _AgentSelector_matchLabels(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.AgentSelector().MatchLabels(rctx, obj)
+		return obj.Text, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(map[string]interface{})
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOMap2map(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_AgentSelector_matchLabels(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DocumentationLink_text(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "AgentSelector",
+		Object:     "DocumentationLink",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Map does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentUpgrade_status(ctx context.Context, field graphql.CollectedField, obj *model1.AgentUpgrade) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_AgentUpgrade_status(ctx, field)
+func (ec *executionContext) _DocumentationLink_url(ctx context.Context, field graphql.CollectedField, obj *model1.DocumentationLink) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_DocumentationLink_url(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4364,7 +8935,7 @@ func (ec *executionContext) _AgentUpgrade_status(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.AgentUpgrade().Status(rctx, obj)
+		return obj.URL, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4376,26 +8947,26 @@ func (ec *executionContext) _AgentUpgrade_status(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_AgentUpgrade_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_DocumentationLink_url(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "AgentUpgrade",
+		Object:     "DocumentationLink",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentUpgrade_version(ctx context.Context, field graphql.CollectedField, obj *model1.AgentUpgrade) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_AgentUpgrade_version(ctx, field)
+func (ec *executionContext) _Edge_id(ctx context.Context, field graphql.CollectedField, obj *graph.Edge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Edge_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4408,7 +8979,7 @@ func (ec *executionContext) _AgentUpgrade_version(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Version, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4425,9 +8996,9 @@ func (ec *executionContext) _AgentUpgrade_version(ctx context.Context, field gra
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_AgentUpgrade_version(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Edge_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "AgentUpgrade",
+		Object:     "Edge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -4438,8 +9009,8 @@ func (ec *executionContext) fieldContext_AgentUpgrade_version(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _AgentUpgrade_error(ctx context.Context, field graphql.CollectedField, obj *model1.AgentUpgrade) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_AgentUpgrade_error(ctx, field)
+func (ec *executionContext) _Edge_source(ctx context.Context, field graphql.CollectedField, obj *graph.Edge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Edge_source(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4452,23 +9023,26 @@ func (ec *executionContext) _AgentUpgrade_error(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Error, nil
+		return obj.Source, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_AgentUpgrade_error(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Edge_source(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "AgentUpgrade",
+		Object:     "Edge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -4479,8 +9053,8 @@ func (ec *executionContext) fieldContext_AgentUpgrade_error(ctx context.Context,
 	return fc, nil
 }
 
-func (ec *executionContext) _Agents_query(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agents_query(ctx, field)
+func (ec *executionContext) _Edge_target(ctx context.Context, field graphql.CollectedField, obj *graph.Edge) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Edge_target(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4493,23 +9067,26 @@ func (ec *executionContext) _Agents_query(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Query, nil
+		return obj.Target, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agents_query(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Edge_target(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agents",
+		Object:     "Edge",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -4520,8 +9097,8 @@ func (ec *executionContext) fieldContext_Agents_query(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Agents_agents(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agents_agents(ctx, field)
+func (ec *executionContext) _ExtensionType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.ExtensionType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ExtensionType_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4534,7 +9111,7 @@ func (ec *executionContext) _Agents_agents(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Agents, nil
+		return obj.APIVersion, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4546,70 +9123,26 @@ func (ec *executionContext) _Agents_agents(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Agent)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNAgent2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agents_agents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ExtensionType_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agents",
+		Object:     "ExtensionType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Agent_id(ctx, field)
-			case "architecture":
-				return ec.fieldContext_Agent_architecture(ctx, field)
-			case "hostName":
-				return ec.fieldContext_Agent_hostName(ctx, field)
-			case "labels":
-				return ec.fieldContext_Agent_labels(ctx, field)
-			case "platform":
-				return ec.fieldContext_Agent_platform(ctx, field)
-			case "operatingSystem":
-				return ec.fieldContext_Agent_operatingSystem(ctx, field)
-			case "version":
-				return ec.fieldContext_Agent_version(ctx, field)
-			case "name":
-				return ec.fieldContext_Agent_name(ctx, field)
-			case "home":
-				return ec.fieldContext_Agent_home(ctx, field)
-			case "macAddress":
-				return ec.fieldContext_Agent_macAddress(ctx, field)
-			case "remoteAddress":
-				return ec.fieldContext_Agent_remoteAddress(ctx, field)
-			case "type":
-				return ec.fieldContext_Agent_type(ctx, field)
-			case "status":
-				return ec.fieldContext_Agent_status(ctx, field)
-			case "errorMessage":
-				return ec.fieldContext_Agent_errorMessage(ctx, field)
-			case "connectedAt":
-				return ec.fieldContext_Agent_connectedAt(ctx, field)
-			case "disconnectedAt":
-				return ec.fieldContext_Agent_disconnectedAt(ctx, field)
-			case "configuration":
-				return ec.fieldContext_Agent_configuration(ctx, field)
-			case "configurationResource":
-				return ec.fieldContext_Agent_configurationResource(ctx, field)
-			case "upgrade":
-				return ec.fieldContext_Agent_upgrade(ctx, field)
-			case "upgradeAvailable":
-				return ec.fieldContext_Agent_upgradeAvailable(ctx, field)
-			case "features":
-				return ec.fieldContext_Agent_features(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Agent", field.Name)
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agents_suggestions(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agents_suggestions(ctx, field)
+func (ec *executionContext) _ExtensionType_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.ExtensionType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ExtensionType_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4622,41 +9155,56 @@ func (ec *executionContext) _Agents_suggestions(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Suggestions, nil
+		return obj.Metadata, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]*search.Suggestion)
+	res := resTmp.(model1.Metadata)
 	fc.Result = res
-	return ec.marshalOSuggestion2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋstoreᚋsearchᚐSuggestionᚄ(ctx, field.Selections, res)
+	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agents_suggestions(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ExtensionType_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agents",
+		Object:     "ExtensionType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "label":
-				return ec.fieldContext_Suggestion_label(ctx, field)
-			case "query":
-				return ec.fieldContext_Suggestion_query(ctx, field)
+			case "id":
+				return ec.fieldContext_Metadata_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Metadata_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Metadata_displayName(ctx, field)
+			case "description":
+				return ec.fieldContext_Metadata_description(ctx, field)
+			case "icon":
+				return ec.fieldContext_Metadata_icon(ctx, field)
+			case "labels":
+				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "revision":
+				return ec.fieldContext_Metadata_revision(ctx, field)
+			case "resourceVersion":
+				return ec.fieldContext_Metadata_resourceVersion(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Suggestion", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Agents_latestVersion(ctx context.Context, field graphql.CollectedField, obj *model.Agents) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Agents_latestVersion(ctx, field)
+func (ec *executionContext) _ExtensionType_kind(ctx context.Context, field graphql.CollectedField, obj *model1.ExtensionType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ExtensionType_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4669,7 +9217,7 @@ func (ec *executionContext) _Agents_latestVersion(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.LatestVersion, nil
+		return ec.resolvers.ExtensionType().Kind(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4686,21 +9234,75 @@ func (ec *executionContext) _Agents_latestVersion(ctx context.Context, field gra
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Agents_latestVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ExtensionType_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Agents",
+		Object:     "ExtensionType",
+		Field:      field,
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
+		},
+	}
+	return fc, nil
+}
+
+func (ec *executionContext) _ExtensionType_spec(ctx context.Context, field graphql.CollectedField, obj *model1.ExtensionType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ExtensionType_spec(ctx, field)
+	if err != nil {
+		return graphql.Null
+	}
+	ctx = graphql.WithFieldContext(ctx, fc)
+	defer func() {
+		if r := recover(); r != nil {
+			ec.Error(ctx, ec.Recover(ctx, r))
+			ret = graphql.Null
+		}
+	}()
+	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
+		ctx = rctx // use context from middleware stack in children
+		return obj.Spec, nil
+	})
+	if err != nil {
+		ec.Error(ctx, err)
+		return graphql.Null
+	}
+	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
+		return graphql.Null
+	}
+	res := resTmp.(model1.ResourceTypeSpec)
+	fc.Result = res
+	return ec.marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_ExtensionType_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "ExtensionType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "version":
+				return ec.fieldContext_ResourceTypeSpec_version(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ResourceTypeSpec_parameters(ctx, field)
+			case "supportedPlatforms":
+				return ec.fieldContext_ResourceTypeSpec_supportedPlatforms(ctx, field)
+			case "telemetryTypes":
+				return ec.fieldContext_ResourceTypeSpec_telemetryTypes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ResourceTypeSpec", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configuration_apiVersion(ctx, field)
+func (ec *executionContext) _Graph_sources(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Graph_sources(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4713,7 +9315,7 @@ func (ec *executionContext) _Configuration_apiVersion(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.APIVersion, nil
+		return obj.Sources, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4725,26 +9327,36 @@ func (ec *executionContext) _Configuration_apiVersion(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*graph.Node)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNNode2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐNodeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Configuration_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Graph_sources(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Configuration",
+		Object:     "Graph",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Node_id(ctx, field)
+			case "type":
+				return ec.fieldContext_Node_type(ctx, field)
+			case "label":
+				return ec.fieldContext_Node_label(ctx, field)
+			case "attributes":
+				return ec.fieldContext_Node_attributes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configuration_kind(ctx, field)
+func (ec *executionContext) _Graph_intermediates(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Graph_intermediates(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4757,7 +9369,7 @@ func (ec *executionContext) _Configuration_kind(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Configuration().Kind(rctx, obj)
+		return obj.Intermediates, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4769,26 +9381,36 @@ func (ec *executionContext) _Configuration_kind(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*graph.Node)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNNode2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐNodeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Configuration_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Graph_intermediates(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Configuration",
+		Object:     "Graph",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Node_id(ctx, field)
+			case "type":
+				return ec.fieldContext_Node_type(ctx, field)
+			case "label":
+				return ec.fieldContext_Node_label(ctx, field)
+			case "attributes":
+				return ec.fieldContext_Node_attributes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configuration_metadata(ctx, field)
+func (ec *executionContext) _Graph_targets(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Graph_targets(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4801,7 +9423,7 @@ func (ec *executionContext) _Configuration_metadata(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Metadata, nil
+		return obj.Targets, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4813,40 +9435,36 @@ func (ec *executionContext) _Configuration_metadata(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.([]*graph.Node)
 	fc.Result = res
-	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
+	return ec.marshalNNode2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐNodeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Configuration_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Graph_targets(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Configuration",
+		Object:     "Graph",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "id":
-				return ec.fieldContext_Metadata_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Metadata_name(ctx, field)
-			case "displayName":
-				return ec.fieldContext_Metadata_displayName(ctx, field)
-			case "description":
-				return ec.fieldContext_Metadata_description(ctx, field)
-			case "icon":
-				return ec.fieldContext_Metadata_icon(ctx, field)
-			case "labels":
-				return ec.fieldContext_Metadata_labels(ctx, field)
+				return ec.fieldContext_Node_id(ctx, field)
+			case "type":
+				return ec.fieldContext_Node_type(ctx, field)
+			case "label":
+				return ec.fieldContext_Node_label(ctx, field)
+			case "attributes":
+				return ec.fieldContext_Node_attributes(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configuration_spec(ctx, field)
+func (ec *executionContext) _Graph_edges(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Graph_edges(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4859,7 +9477,7 @@ func (ec *executionContext) _Configuration_spec(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Spec, nil
+		return obj.Edges, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -4871,38 +9489,34 @@ func (ec *executionContext) _Configuration_spec(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ConfigurationSpec)
+	res := resTmp.([]*graph.Edge)
 	fc.Result = res
-	return ec.marshalNConfigurationSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationSpec(ctx, field.Selections, res)
+	return ec.marshalNEdge2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐEdgeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Configuration_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Graph_edges(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Configuration",
+		Object:     "Graph",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "contentType":
-				return ec.fieldContext_ConfigurationSpec_contentType(ctx, field)
-			case "raw":
-				return ec.fieldContext_ConfigurationSpec_raw(ctx, field)
-			case "sources":
-				return ec.fieldContext_ConfigurationSpec_sources(ctx, field)
-			case "destinations":
-				return ec.fieldContext_ConfigurationSpec_destinations(ctx, field)
-			case "selector":
-				return ec.fieldContext_ConfigurationSpec_selector(ctx, field)
+			case "id":
+				return ec.fieldContext_Edge_id(ctx, field)
+			case "source":
+				return ec.fieldContext_Edge_source(ctx, field)
+			case "target":
+				return ec.fieldContext_Edge_target(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ConfigurationSpec", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Edge", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_agentCount(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configuration_agentCount(ctx, field)
+func (ec *executionContext) _Graph_attributes(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Graph_attributes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4915,35 +9529,38 @@ func (ec *executionContext) _Configuration_agentCount(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Configuration().AgentCount(rctx, obj)
+		return obj.Attributes, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(map[string]any)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalNMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Configuration_agentCount(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Graph_attributes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Configuration",
+		Object:     "Graph",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type Map does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_graph(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configuration_graph(ctx, field)
+func (ec *executionContext) _GraphMetric_name(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GraphMetric_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -4956,47 +9573,38 @@ func (ec *executionContext) _Configuration_graph(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Configuration().Graph(rctx, obj)
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*graph.Graph)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOGraph2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐGraph(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Configuration_graph(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GraphMetric_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Configuration",
+		Object:     "GraphMetric",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "sources":
-				return ec.fieldContext_Graph_sources(ctx, field)
-			case "intermediates":
-				return ec.fieldContext_Graph_intermediates(ctx, field)
-			case "targets":
-				return ec.fieldContext_Graph_targets(ctx, field)
-			case "edges":
-				return ec.fieldContext_Graph_edges(ctx, field)
-			case "attributes":
-				return ec.fieldContext_Graph_attributes(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Graph", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Configuration_rendered(ctx context.Context, field graphql.CollectedField, obj *model1.Configuration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configuration_rendered(ctx, field)
+func (ec *executionContext) _GraphMetric_nodeID(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GraphMetric_nodeID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5009,26 +9617,29 @@ func (ec *executionContext) _Configuration_rendered(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Configuration().Rendered(rctx, obj)
+		return obj.NodeID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Configuration_rendered(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GraphMetric_nodeID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Configuration",
+		Object:     "GraphMetric",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			return nil, errors.New("field of type String does not have child fields")
 		},
@@ -5036,8 +9647,8 @@ func (ec *executionContext) fieldContext_Configuration_rendered(ctx context.Cont
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationChange_configuration(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationChange) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConfigurationChange_configuration(ctx, field)
+func (ec *executionContext) _GraphMetric_pipelineType(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GraphMetric_pipelineType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5050,7 +9661,7 @@ func (ec *executionContext) _ConfigurationChange_configuration(ctx context.Conte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Configuration, nil
+		return obj.PipelineType, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5062,42 +9673,26 @@ func (ec *executionContext) _ConfigurationChange_configuration(ctx context.Conte
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Configuration)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConfigurationChange_configuration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GraphMetric_pipelineType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConfigurationChange",
+		Object:     "GraphMetric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Configuration_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Configuration_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Configuration_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Configuration_spec(ctx, field)
-			case "agentCount":
-				return ec.fieldContext_Configuration_agentCount(ctx, field)
-			case "graph":
-				return ec.fieldContext_Configuration_graph(ctx, field)
-			case "rendered":
-				return ec.fieldContext_Configuration_rendered(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationChange_eventType(ctx context.Context, field graphql.CollectedField, obj *model.ConfigurationChange) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConfigurationChange_eventType(ctx, field)
+func (ec *executionContext) _GraphMetric_value(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GraphMetric_value(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5110,7 +9705,7 @@ func (ec *executionContext) _ConfigurationChange_eventType(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.EventType, nil
+		return obj.Value, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5122,26 +9717,26 @@ func (ec *executionContext) _ConfigurationChange_eventType(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model.EventType)
+	res := resTmp.(float64)
 	fc.Result = res
-	return ec.marshalNEventType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐEventType(ctx, field.Selections, res)
+	return ec.marshalNFloat2float64(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConfigurationChange_eventType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GraphMetric_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConfigurationChange",
+		Object:     "GraphMetric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type EventType does not have child fields")
+			return nil, errors.New("field of type Float does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_contentType(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConfigurationSpec_contentType(ctx, field)
+func (ec *executionContext) _GraphMetric_unit(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GraphMetric_unit(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5154,23 +9749,26 @@ func (ec *executionContext) _ConfigurationSpec_contentType(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ContentType, nil
+		return obj.Unit, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConfigurationSpec_contentType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GraphMetric_unit(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConfigurationSpec",
+		Object:     "GraphMetric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5181,8 +9779,8 @@ func (ec *executionContext) fieldContext_ConfigurationSpec_contentType(ctx conte
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_raw(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConfigurationSpec_raw(ctx, field)
+func (ec *executionContext) _GraphMetric_agentID(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GraphMetric_agentID(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5195,7 +9793,7 @@ func (ec *executionContext) _ConfigurationSpec_raw(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Raw, nil
+		return obj.AgentID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5204,26 +9802,26 @@ func (ec *executionContext) _ConfigurationSpec_raw(ctx context.Context, field gr
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConfigurationSpec_raw(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GraphMetric_agentID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConfigurationSpec",
+		Object:     "GraphMetric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_sources(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConfigurationSpec_sources(ctx, field)
+func (ec *executionContext) _GraphMetrics_metrics(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetrics) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_GraphMetrics_metrics(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5236,47 +9834,52 @@ func (ec *executionContext) _ConfigurationSpec_sources(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Sources, nil
+		return obj.Metrics, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ResourceConfiguration)
+	res := resTmp.([]*model.GraphMetric)
 	fc.Result = res
-	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
+	return ec.marshalNGraphMetric2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐGraphMetricᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConfigurationSpec_sources(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_GraphMetrics_metrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConfigurationSpec",
+		Object:     "GraphMetrics",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "name":
-				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
-			case "type":
-				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
-			case "parameters":
-				return ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
-			case "processors":
-				return ec.fieldContext_ResourceConfiguration_processors(ctx, field)
-			case "disabled":
-				return ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
+				return ec.fieldContext_GraphMetric_name(ctx, field)
+			case "nodeID":
+				return ec.fieldContext_GraphMetric_nodeID(ctx, field)
+			case "pipelineType":
+				return ec.fieldContext_GraphMetric_pipelineType(ctx, field)
+			case "value":
+				return ec.fieldContext_GraphMetric_value(ctx, field)
+			case "unit":
+				return ec.fieldContext_GraphMetric_unit(ctx, field)
+			case "agentID":
+				return ec.fieldContext_GraphMetric_agentID(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ResourceConfiguration", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type GraphMetric", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_destinations(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConfigurationSpec_destinations(ctx, field)
+func (ec *executionContext) _Log_timestamp(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Log_timestamp(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5289,7 +9892,7 @@ func (ec *executionContext) _ConfigurationSpec_destinations(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Destinations, nil
+		return obj.Timestamp, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5298,38 +9901,26 @@ func (ec *executionContext) _ConfigurationSpec_destinations(ctx context.Context,
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ResourceConfiguration)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
+	return ec.marshalOTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConfigurationSpec_destinations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Log_timestamp(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConfigurationSpec",
+		Object:     "Log",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "name":
-				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
-			case "type":
-				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
-			case "parameters":
-				return ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
-			case "processors":
-				return ec.fieldContext_ResourceConfiguration_processors(ctx, field)
-			case "disabled":
-				return ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ResourceConfiguration", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ConfigurationSpec_selector(ctx context.Context, field graphql.CollectedField, obj *model1.ConfigurationSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ConfigurationSpec_selector(ctx, field)
+func (ec *executionContext) _Log_body(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Log_body(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5342,7 +9933,7 @@ func (ec *executionContext) _ConfigurationSpec_selector(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Selector, nil
+		return obj.Body, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5351,30 +9942,26 @@ func (ec *executionContext) _ConfigurationSpec_selector(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(model1.AgentSelector)
+	res := resTmp.(interface{})
 	fc.Result = res
-	return ec.marshalOAgentSelector2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentSelector(ctx, field.Selections, res)
+	return ec.marshalOAny2interface(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ConfigurationSpec_selector(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Log_body(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ConfigurationSpec",
+		Object:     "Log",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "matchLabels":
-				return ec.fieldContext_AgentSelector_matchLabels(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type AgentSelector", field.Name)
+			return nil, errors.New("field of type Any does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Configurations_query(ctx context.Context, field graphql.CollectedField, obj *model.Configurations) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configurations_query(ctx, field)
+func (ec *executionContext) _Log_severity(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Log_severity(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5387,7 +9974,7 @@ func (ec *executionContext) _Configurations_query(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Query, nil
+		return obj.Severity, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5396,14 +9983,14 @@ func (ec *executionContext) _Configurations_query(ctx context.Context, field gra
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Configurations_query(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Log_severity(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Configurations",
+		Object:     "Log",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5414,8 +10001,8 @@ func (ec *executionContext) fieldContext_Configurations_query(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Configurations_configurations(ctx context.Context, field graphql.CollectedField, obj *model.Configurations) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configurations_configurations(ctx, field)
+func (ec *executionContext) _Log_attributes(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Log_attributes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5428,54 +10015,35 @@ func (ec *executionContext) _Configurations_configurations(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Configurations, nil
+		return obj.Attributes, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
-	}
-	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
-		return graphql.Null
-	}
-	res := resTmp.([]*model1.Configuration)
-	fc.Result = res
-	return ec.marshalNConfiguration2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationᚄ(ctx, field.Selections, res)
-}
-
-func (ec *executionContext) fieldContext_Configurations_configurations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
-	fc = &graphql.FieldContext{
-		Object:     "Configurations",
-		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Configuration_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Configuration_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Configuration_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Configuration_spec(ctx, field)
-			case "agentCount":
-				return ec.fieldContext_Configuration_agentCount(ctx, field)
-			case "graph":
-				return ec.fieldContext_Configuration_graph(ctx, field)
-			case "rendered":
-				return ec.fieldContext_Configuration_rendered(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
+	}
+	if resTmp == nil {
+		return graphql.Null
+	}
+	res := resTmp.(map[string]interface{})
+	fc.Result = res
+	return ec.marshalOMap2map(ctx, field.Selections, res)
+}
+
+func (ec *executionContext) fieldContext_Log_attributes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+	fc = &graphql.FieldContext{
+		Object:     "Log",
+		Field:      field,
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type Map does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Configurations_suggestions(ctx context.Context, field graphql.CollectedField, obj *model.Configurations) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Configurations_suggestions(ctx, field)
+func (ec *executionContext) _Log_resource(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Log_resource(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5488,7 +10056,7 @@ func (ec *executionContext) _Configurations_suggestions(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Suggestions, nil
+		return obj.Resource, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5497,32 +10065,26 @@ func (ec *executionContext) _Configurations_suggestions(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]*search.Suggestion)
+	res := resTmp.(map[string]interface{})
 	fc.Result = res
-	return ec.marshalOSuggestion2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋstoreᚋsearchᚐSuggestionᚄ(ctx, field.Selections, res)
+	return ec.marshalOMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Configurations_suggestions(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Log_resource(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Configurations",
+		Object:     "Log",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "label":
-				return ec.fieldContext_Suggestion_label(ctx, field)
-			case "query":
-				return ec.fieldContext_Suggestion_query(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Suggestion", field.Name)
+			return nil, errors.New("field of type Map does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Destination_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Destination_apiVersion(ctx, field)
+func (ec *executionContext) _Metadata_id(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5535,7 +10097,7 @@ func (ec *executionContext) _Destination_apiVersion(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.APIVersion, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5549,24 +10111,24 @@ func (ec *executionContext) _Destination_apiVersion(ctx context.Context, field g
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNID2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Destination_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Destination",
+		Object:     "Metadata",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type ID does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Destination_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Destination_kind(ctx, field)
+func (ec *executionContext) _Metadata_name(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5579,7 +10141,7 @@ func (ec *executionContext) _Destination_kind(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Destination().Kind(rctx, obj)
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5596,12 +10158,12 @@ func (ec *executionContext) _Destination_kind(ctx context.Context, field graphql
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Destination_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Destination",
+		Object:     "Metadata",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			return nil, errors.New("field of type String does not have child fields")
 		},
@@ -5609,8 +10171,8 @@ func (ec *executionContext) fieldContext_Destination_kind(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _Destination_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Destination_metadata(ctx, field)
+func (ec *executionContext) _Metadata_displayName(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_displayName(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5623,52 +10185,35 @@ func (ec *executionContext) _Destination_metadata(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Metadata, nil
+		return obj.DisplayName, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Destination_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_displayName(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Destination",
+		Object:     "Metadata",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Metadata_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Metadata_name(ctx, field)
-			case "displayName":
-				return ec.fieldContext_Metadata_displayName(ctx, field)
-			case "description":
-				return ec.fieldContext_Metadata_description(ctx, field)
-			case "icon":
-				return ec.fieldContext_Metadata_icon(ctx, field)
-			case "labels":
-				return ec.fieldContext_Metadata_labels(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Destination_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Destination) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Destination_spec(ctx, field)
+func (ec *executionContext) _Metadata_description(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_description(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5681,48 +10226,35 @@ func (ec *executionContext) _Destination_spec(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Spec, nil
+		return obj.Description, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ParameterizedSpec)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNParameterizedSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterizedSpec(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Destination_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_description(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Destination",
+		Object:     "Metadata",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "type":
-				return ec.fieldContext_ParameterizedSpec_type(ctx, field)
-			case "parameters":
-				return ec.fieldContext_ParameterizedSpec_parameters(ctx, field)
-			case "processors":
-				return ec.fieldContext_ParameterizedSpec_processors(ctx, field)
-			case "disabled":
-				return ec.fieldContext_ParameterizedSpec_disabled(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ParameterizedSpec", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DestinationType_apiVersion(ctx, field)
+func (ec *executionContext) _Metadata_icon(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_icon(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5735,26 +10267,23 @@ func (ec *executionContext) _DestinationType_apiVersion(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.APIVersion, nil
+		return obj.Icon, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DestinationType_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_icon(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DestinationType",
+		Object:     "Metadata",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -5765,8 +10294,8 @@ func (ec *executionContext) fieldContext_DestinationType_apiVersion(ctx context.
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationType_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DestinationType_metadata(ctx, field)
+func (ec *executionContext) _Metadata_labels(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_labels(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5779,52 +10308,35 @@ func (ec *executionContext) _DestinationType_metadata(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Metadata, nil
+		return ec.resolvers.Metadata().Labels(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(map[string]interface{})
 	fc.Result = res
-	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
+	return ec.marshalOMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DestinationType_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_labels(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DestinationType",
+		Object:     "Metadata",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Metadata_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Metadata_name(ctx, field)
-			case "displayName":
-				return ec.fieldContext_Metadata_displayName(ctx, field)
-			case "description":
-				return ec.fieldContext_Metadata_description(ctx, field)
-			case "icon":
-				return ec.fieldContext_Metadata_icon(ctx, field)
-			case "labels":
-				return ec.fieldContext_Metadata_labels(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
+			return nil, errors.New("field of type Map does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationType_kind(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DestinationType_kind(ctx, field)
+func (ec *executionContext) _Metadata_revision(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_revision(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5837,38 +10349,35 @@ func (ec *executionContext) _DestinationType_kind(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.DestinationType().Kind(rctx, obj)
+		return obj.Revision, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DestinationType_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_revision(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DestinationType",
+		Object:     "Metadata",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationType_spec(ctx context.Context, field graphql.CollectedField, obj *model1.DestinationType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DestinationType_spec(ctx, field)
+func (ec *executionContext) _Metadata_resourceVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metadata_resourceVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5881,48 +10390,35 @@ func (ec *executionContext) _DestinationType_spec(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Spec, nil
+		return ec.resolvers.Metadata().ResourceVersion(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ResourceTypeSpec)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DestinationType_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metadata_resourceVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DestinationType",
+		Object:     "Metadata",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "version":
-				return ec.fieldContext_ResourceTypeSpec_version(ctx, field)
-			case "parameters":
-				return ec.fieldContext_ResourceTypeSpec_parameters(ctx, field)
-			case "supportedPlatforms":
-				return ec.fieldContext_ResourceTypeSpec_supportedPlatforms(ctx, field)
-			case "telemetryTypes":
-				return ec.fieldContext_ResourceTypeSpec_telemetryTypes(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ResourceTypeSpec", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationWithType_destination(ctx context.Context, field graphql.CollectedField, obj *model.DestinationWithType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DestinationWithType_destination(ctx, field)
+func (ec *executionContext) _Metric_name(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metric_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5935,7 +10431,7 @@ func (ec *executionContext) _DestinationWithType_destination(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Destination, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5944,36 +10440,26 @@ func (ec *executionContext) _DestinationWithType_destination(ctx context.Context
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Destination)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalODestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DestinationWithType_destination(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metric_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DestinationWithType",
+		Object:     "Metric",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Destination_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Destination_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Destination_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Destination_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Destination", field.Name)
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DestinationWithType_destinationType(ctx context.Context, field graphql.CollectedField, obj *model.DestinationWithType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DestinationWithType_destinationType(ctx, field)
+func (ec *executionContext) _Metric_timestamp(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metric_timestamp(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -5986,7 +10472,7 @@ func (ec *executionContext) _DestinationWithType_destinationType(ctx context.Con
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DestinationType, nil
+		return obj.Timestamp, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -5995,36 +10481,26 @@ func (ec *executionContext) _DestinationWithType_destinationType(ctx context.Con
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.DestinationType)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalODestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx, field.Selections, res)
+	return ec.marshalOTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DestinationWithType_destinationType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metric_timestamp(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DestinationWithType",
+		Object:     "Metric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_DestinationType_apiVersion(ctx, field)
-			case "metadata":
-				return ec.fieldContext_DestinationType_metadata(ctx, field)
-			case "kind":
-				return ec.fieldContext_DestinationType_kind(ctx, field)
-			case "spec":
-				return ec.fieldContext_DestinationType_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type DestinationType", field.Name)
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DocumentationLink_text(ctx context.Context, field graphql.CollectedField, obj *model1.DocumentationLink) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DocumentationLink_text(ctx, field)
+func (ec *executionContext) _Metric_value(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metric_value(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6037,38 +10513,35 @@ func (ec *executionContext) _DocumentationLink_text(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Text, nil
+		return obj.Value, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(interface{})
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOAny2interface(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DocumentationLink_text(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metric_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DocumentationLink",
+		Object:     "Metric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Any does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _DocumentationLink_url(ctx context.Context, field graphql.CollectedField, obj *model1.DocumentationLink) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_DocumentationLink_url(ctx, field)
+func (ec *executionContext) _Metric_unit(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metric_unit(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6081,26 +10554,23 @@ func (ec *executionContext) _DocumentationLink_url(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.URL, nil
+		return obj.Unit, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_DocumentationLink_url(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metric_unit(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "DocumentationLink",
+		Object:     "Metric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6111,8 +10581,8 @@ func (ec *executionContext) fieldContext_DocumentationLink_url(ctx context.Conte
 	return fc, nil
 }
 
-func (ec *executionContext) _Edge_id(ctx context.Context, field graphql.CollectedField, obj *graph.Edge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Edge_id(ctx, field)
+func (ec *executionContext) _Metric_type(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metric_type(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6125,26 +10595,23 @@ func (ec *executionContext) _Edge_id(ctx context.Context, field graphql.Collecte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Edge_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metric_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Edge",
+		Object:     "Metric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6155,8 +10622,8 @@ func (ec *executionContext) fieldContext_Edge_id(ctx context.Context, field grap
 	return fc, nil
 }
 
-func (ec *executionContext) _Edge_source(ctx context.Context, field graphql.CollectedField, obj *graph.Edge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Edge_source(ctx, field)
+func (ec *executionContext) _Metric_attributes(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metric_attributes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6169,38 +10636,35 @@ func (ec *executionContext) _Edge_source(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Source, nil
+		return obj.Attributes, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(map[string]interface{})
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Edge_source(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metric_attributes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Edge",
+		Object:     "Metric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Map does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Edge_target(ctx context.Context, field graphql.CollectedField, obj *graph.Edge) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Edge_target(ctx, field)
+func (ec *executionContext) _Metric_resource(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Metric_resource(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6213,38 +10677,35 @@ func (ec *executionContext) _Edge_target(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Target, nil
+		return obj.Resource, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(map[string]interface{})
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Edge_target(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Metric_resource(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Edge",
+		Object:     "Metric",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Map does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Graph_sources(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Graph_sources(ctx, field)
+func (ec *executionContext) _MetricCategory_label(ctx context.Context, field graphql.CollectedField, obj *model1.MetricCategory) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MetricCategory_label(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6257,7 +10718,7 @@ func (ec *executionContext) _Graph_sources(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Sources, nil
+		return obj.Label, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6269,36 +10730,26 @@ func (ec *executionContext) _Graph_sources(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*graph.Node)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNNode2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐNodeᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Graph_sources(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MetricCategory_label(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Graph",
+		Object:     "MetricCategory",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Node_id(ctx, field)
-			case "type":
-				return ec.fieldContext_Node_type(ctx, field)
-			case "label":
-				return ec.fieldContext_Node_label(ctx, field)
-			case "attributes":
-				return ec.fieldContext_Node_attributes(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Graph_intermediates(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Graph_intermediates(ctx, field)
+func (ec *executionContext) _MetricCategory_column(ctx context.Context, field graphql.CollectedField, obj *model1.MetricCategory) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MetricCategory_column(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6311,7 +10762,7 @@ func (ec *executionContext) _Graph_intermediates(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Intermediates, nil
+		return obj.Column, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6323,36 +10774,26 @@ func (ec *executionContext) _Graph_intermediates(ctx context.Context, field grap
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*graph.Node)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNNode2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐNodeᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Graph_intermediates(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MetricCategory_column(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Graph",
+		Object:     "MetricCategory",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Node_id(ctx, field)
-			case "type":
-				return ec.fieldContext_Node_type(ctx, field)
-			case "label":
-				return ec.fieldContext_Node_label(ctx, field)
-			case "attributes":
-				return ec.fieldContext_Node_attributes(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Graph_targets(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Graph_targets(ctx, field)
+func (ec *executionContext) _MetricCategory_metrics(ctx context.Context, field graphql.CollectedField, obj *model1.MetricCategory) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MetricCategory_metrics(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6365,7 +10806,7 @@ func (ec *executionContext) _Graph_targets(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Targets, nil
+		return obj.Metrics, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6377,36 +10818,34 @@ func (ec *executionContext) _Graph_targets(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*graph.Node)
+	res := resTmp.([]model1.MetricOption)
 	fc.Result = res
-	return ec.marshalNNode2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐNodeᚄ(ctx, field.Selections, res)
+	return ec.marshalNMetricOption2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricOptionᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Graph_targets(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MetricCategory_metrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Graph",
+		Object:     "MetricCategory",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Node_id(ctx, field)
-			case "type":
-				return ec.fieldContext_Node_type(ctx, field)
-			case "label":
-				return ec.fieldContext_Node_label(ctx, field)
-			case "attributes":
-				return ec.fieldContext_Node_attributes(ctx, field)
+			case "name":
+				return ec.fieldContext_MetricOption_name(ctx, field)
+			case "description":
+				return ec.fieldContext_MetricOption_description(ctx, field)
+			case "kpi":
+				return ec.fieldContext_MetricOption_kpi(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Node", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type MetricOption", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Graph_edges(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Graph_edges(ctx, field)
+func (ec *executionContext) _MetricOption_name(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MetricOption_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6419,7 +10858,7 @@ func (ec *executionContext) _Graph_edges(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Edges, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6431,34 +10870,26 @@ func (ec *executionContext) _Graph_edges(ctx context.Context, field graphql.Coll
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*graph.Edge)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNEdge2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐEdgeᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Graph_edges(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MetricOption_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Graph",
+		Object:     "MetricOption",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "id":
-				return ec.fieldContext_Edge_id(ctx, field)
-			case "source":
-				return ec.fieldContext_Edge_source(ctx, field)
-			case "target":
-				return ec.fieldContext_Edge_target(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Edge", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Graph_attributes(ctx context.Context, field graphql.CollectedField, obj *graph.Graph) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Graph_attributes(ctx, field)
+func (ec *executionContext) _MetricOption_description(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MetricOption_description(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6471,38 +10902,35 @@ func (ec *executionContext) _Graph_attributes(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Attributes, nil
+		return obj.Description, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(map[string]any)
+	res := resTmp.(*string)
 	fc.Result = res
-	return ec.marshalNMap2map(ctx, field.Selections, res)
+	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Graph_attributes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MetricOption_description(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Graph",
+		Object:     "MetricOption",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Map does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_name(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GraphMetric_name(ctx, field)
+func (ec *executionContext) _MetricOption_kpi(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_MetricOption_kpi(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6515,38 +10943,35 @@ func (ec *executionContext) _GraphMetric_name(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.KPI, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GraphMetric_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_MetricOption_kpi(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GraphMetric",
+		Object:     "MetricOption",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_nodeID(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GraphMetric_nodeID(ctx, field)
+func (ec *executionContext) _Mutation_updateProcessors(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_updateProcessors(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6559,38 +10984,46 @@ func (ec *executionContext) _GraphMetric_nodeID(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.NodeID, nil
+		return ec.resolvers.Mutation().UpdateProcessors(rctx, fc.Args["input"].(model.UpdateProcessorsInput))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GraphMetric_nodeID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_updateProcessors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GraphMetric",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_updateProcessors_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_pipelineType(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GraphMetric_pipelineType(ctx, field)
+func (ec *executionContext) _Mutation_rollbackConfiguration(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_rollbackConfiguration(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6603,38 +11036,64 @@ func (ec *executionContext) _GraphMetric_pipelineType(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.PipelineType, nil
+		return ec.resolvers.Mutation().RollbackConfiguration(rctx, fc.Args["name"].(string), fc.Args["revision"].(int))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*model1.Configuration)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GraphMetric_pipelineType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_rollbackConfiguration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GraphMetric",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Configuration_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Configuration_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Configuration_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Configuration_spec(ctx, field)
+			case "agentCount":
+				return ec.fieldContext_Configuration_agentCount(ctx, field)
+			case "graph":
+				return ec.fieldContext_Configuration_graph(ctx, field)
+			case "rendered":
+				return ec.fieldContext_Configuration_rendered(ctx, field)
+			case "rollout":
+				return ec.fieldContext_Configuration_rollout(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_rollbackConfiguration_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_value(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GraphMetric_value(ctx, field)
+func (ec *executionContext) _Mutation_restartAgents(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_restartAgents(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6647,7 +11106,7 @@ func (ec *executionContext) _GraphMetric_value(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Value, nil
+		return ec.resolvers.Mutation().RestartAgents(rctx, fc.Args["ids"].([]string), fc.Args["selector"].(*string), fc.Args["query"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6659,26 +11118,85 @@ func (ec *executionContext) _GraphMetric_value(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.(float64)
+	res := resTmp.([]*model1.Agent)
 	fc.Result = res
-	return ec.marshalNFloat2float64(ctx, field.Selections, res)
+	return ec.marshalNAgent2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GraphMetric_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_restartAgents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GraphMetric",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Float does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Agent_id(ctx, field)
+			case "architecture":
+				return ec.fieldContext_Agent_architecture(ctx, field)
+			case "hostName":
+				return ec.fieldContext_Agent_hostName(ctx, field)
+			case "labels":
+				return ec.fieldContext_Agent_labels(ctx, field)
+			case "platform":
+				return ec.fieldContext_Agent_platform(ctx, field)
+			case "operatingSystem":
+				return ec.fieldContext_Agent_operatingSystem(ctx, field)
+			case "version":
+				return ec.fieldContext_Agent_version(ctx, field)
+			case "name":
+				return ec.fieldContext_Agent_name(ctx, field)
+			case "home":
+				return ec.fieldContext_Agent_home(ctx, field)
+			case "macAddress":
+				return ec.fieldContext_Agent_macAddress(ctx, field)
+			case "remoteAddress":
+				return ec.fieldContext_Agent_remoteAddress(ctx, field)
+			case "type":
+				return ec.fieldContext_Agent_type(ctx, field)
+			case "status":
+				return ec.fieldContext_Agent_status(ctx, field)
+			case "errorMessage":
+				return ec.fieldContext_Agent_errorMessage(ctx, field)
+			case "connectedAt":
+				return ec.fieldContext_Agent_connectedAt(ctx, field)
+			case "disconnectedAt":
+				return ec.fieldContext_Agent_disconnectedAt(ctx, field)
+			case "configuration":
+				return ec.fieldContext_Agent_configuration(ctx, field)
+			case "configurationResource":
+				return ec.fieldContext_Agent_configurationResource(ctx, field)
+			case "upgrade":
+				return ec.fieldContext_Agent_upgrade(ctx, field)
+			case "restart":
+				return ec.fieldContext_Agent_restart(ctx, field)
+			case "upgradeAvailable":
+				return ec.fieldContext_Agent_upgradeAvailable(ctx, field)
+			case "features":
+				return ec.fieldContext_Agent_features(ctx, field)
+			case "history":
+				return ec.fieldContext_Agent_history(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Agent", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_restartAgents_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_unit(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GraphMetric_unit(ctx, field)
+func (ec *executionContext) _Mutation_pauseRollout(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_pauseRollout(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6691,7 +11209,7 @@ func (ec *executionContext) _GraphMetric_unit(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Unit, nil
+		return ec.resolvers.Mutation().PauseRollout(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6703,26 +11221,69 @@ func (ec *executionContext) _GraphMetric_unit(ctx context.Context, field graphql
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*model1.Rollout)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNRollout2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GraphMetric_unit(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_pauseRollout(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GraphMetric",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_Rollout_name(ctx, field)
+			case "revision":
+				return ec.fieldContext_Rollout_revision(ctx, field)
+			case "previousRevision":
+				return ec.fieldContext_Rollout_previousRevision(ctx, field)
+			case "rollbackRevision":
+				return ec.fieldContext_Rollout_rollbackRevision(ctx, field)
+			case "status":
+				return ec.fieldContext_Rollout_status(ctx, field)
+			case "options":
+				return ec.fieldContext_Rollout_options(ctx, field)
+			case "message":
+				return ec.fieldContext_Rollout_message(ctx, field)
+			case "wave":
+				return ec.fieldContext_Rollout_wave(ctx, field)
+			case "waveStartedAt":
+				return ec.fieldContext_Rollout_waveStartedAt(ctx, field)
+			case "startedAt":
+				return ec.fieldContext_Rollout_startedAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Rollout_updatedAt(ctx, field)
+			case "agentIds":
+				return ec.fieldContext_Rollout_agentIds(ctx, field)
+			case "pending":
+				return ec.fieldContext_Rollout_pending(ctx, field)
+			case "applied":
+				return ec.fieldContext_Rollout_applied(ctx, field)
+			case "failed":
+				return ec.fieldContext_Rollout_failed(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Rollout", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_pauseRollout_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetric_agentID(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GraphMetric_agentID(ctx, field)
+func (ec *executionContext) _Mutation_resumeRollout(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_resumeRollout(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6735,35 +11296,81 @@ func (ec *executionContext) _GraphMetric_agentID(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.AgentID, nil
+		return ec.resolvers.Mutation().ResumeRollout(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.(*model1.Rollout)
 	fc.Result = res
-	return ec.marshalOID2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalNRollout2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GraphMetric_agentID(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_resumeRollout(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GraphMetric",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_Rollout_name(ctx, field)
+			case "revision":
+				return ec.fieldContext_Rollout_revision(ctx, field)
+			case "previousRevision":
+				return ec.fieldContext_Rollout_previousRevision(ctx, field)
+			case "rollbackRevision":
+				return ec.fieldContext_Rollout_rollbackRevision(ctx, field)
+			case "status":
+				return ec.fieldContext_Rollout_status(ctx, field)
+			case "options":
+				return ec.fieldContext_Rollout_options(ctx, field)
+			case "message":
+				return ec.fieldContext_Rollout_message(ctx, field)
+			case "wave":
+				return ec.fieldContext_Rollout_wave(ctx, field)
+			case "waveStartedAt":
+				return ec.fieldContext_Rollout_waveStartedAt(ctx, field)
+			case "startedAt":
+				return ec.fieldContext_Rollout_startedAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Rollout_updatedAt(ctx, field)
+			case "agentIds":
+				return ec.fieldContext_Rollout_agentIds(ctx, field)
+			case "pending":
+				return ec.fieldContext_Rollout_pending(ctx, field)
+			case "applied":
+				return ec.fieldContext_Rollout_applied(ctx, field)
+			case "failed":
+				return ec.fieldContext_Rollout_failed(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Rollout", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_resumeRollout_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _GraphMetrics_metrics(ctx context.Context, field graphql.CollectedField, obj *model.GraphMetrics) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_GraphMetrics_metrics(ctx, field)
+func (ec *executionContext) _Mutation_abortRollout(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Mutation_abortRollout(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6776,7 +11383,7 @@ func (ec *executionContext) _GraphMetrics_metrics(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Metrics, nil
+		return ec.resolvers.Mutation().AbortRollout(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -6788,40 +11395,69 @@ func (ec *executionContext) _GraphMetrics_metrics(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model.GraphMetric)
+	res := resTmp.(*model1.Rollout)
 	fc.Result = res
-	return ec.marshalNGraphMetric2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐGraphMetricᚄ(ctx, field.Selections, res)
+	return ec.marshalNRollout2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_GraphMetrics_metrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Mutation_abortRollout(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "GraphMetrics",
+		Object:     "Mutation",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
 			case "name":
-				return ec.fieldContext_GraphMetric_name(ctx, field)
-			case "nodeID":
-				return ec.fieldContext_GraphMetric_nodeID(ctx, field)
-			case "pipelineType":
-				return ec.fieldContext_GraphMetric_pipelineType(ctx, field)
-			case "value":
-				return ec.fieldContext_GraphMetric_value(ctx, field)
-			case "unit":
-				return ec.fieldContext_GraphMetric_unit(ctx, field)
-			case "agentID":
-				return ec.fieldContext_GraphMetric_agentID(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type GraphMetric", field.Name)
+				return ec.fieldContext_Rollout_name(ctx, field)
+			case "revision":
+				return ec.fieldContext_Rollout_revision(ctx, field)
+			case "previousRevision":
+				return ec.fieldContext_Rollout_previousRevision(ctx, field)
+			case "rollbackRevision":
+				return ec.fieldContext_Rollout_rollbackRevision(ctx, field)
+			case "status":
+				return ec.fieldContext_Rollout_status(ctx, field)
+			case "options":
+				return ec.fieldContext_Rollout_options(ctx, field)
+			case "message":
+				return ec.fieldContext_Rollout_message(ctx, field)
+			case "wave":
+				return ec.fieldContext_Rollout_wave(ctx, field)
+			case "waveStartedAt":
+				return ec.fieldContext_Rollout_waveStartedAt(ctx, field)
+			case "startedAt":
+				return ec.fieldContext_Rollout_startedAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Rollout_updatedAt(ctx, field)
+			case "agentIds":
+				return ec.fieldContext_Rollout_agentIds(ctx, field)
+			case "pending":
+				return ec.fieldContext_Rollout_pending(ctx, field)
+			case "applied":
+				return ec.fieldContext_Rollout_applied(ctx, field)
+			case "failed":
+				return ec.fieldContext_Rollout_failed(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Rollout", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Mutation_abortRollout_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Log_timestamp(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Log_timestamp(ctx, field)
+func (ec *executionContext) _Node_id(ctx context.Context, field graphql.CollectedField, obj *graph.Node) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Node_id(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6834,35 +11470,38 @@ func (ec *executionContext) _Log_timestamp(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Timestamp, nil
+		return obj.ID, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Log_timestamp(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Node_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Log",
+		Object:     "Node",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Log_body(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Log_body(ctx, field)
+func (ec *executionContext) _Node_type(ctx context.Context, field graphql.CollectedField, obj *graph.Node) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Node_type(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6875,35 +11514,38 @@ func (ec *executionContext) _Log_body(ctx context.Context, field graphql.Collect
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Body, nil
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(interface{})
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOAny2interface(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Log_body(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Node_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Log",
+		Object:     "Node",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Any does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Log_severity(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Log_severity(ctx, field)
+func (ec *executionContext) _Node_label(ctx context.Context, field graphql.CollectedField, obj *graph.Node) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Node_label(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6916,23 +11558,26 @@ func (ec *executionContext) _Log_severity(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Severity, nil
+		return obj.Label, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Log_severity(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Node_label(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Log",
+		Object:     "Node",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6943,8 +11588,8 @@ func (ec *executionContext) fieldContext_Log_severity(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Log_attributes(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Log_attributes(ctx, field)
+func (ec *executionContext) _Node_attributes(ctx context.Context, field graphql.CollectedField, obj *graph.Node) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Node_attributes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6964,16 +11609,19 @@ func (ec *executionContext) _Log_attributes(ctx context.Context, field graphql.C
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(map[string]interface{})
+	res := resTmp.(map[string]any)
 	fc.Result = res
-	return ec.marshalOMap2map(ctx, field.Selections, res)
+	return ec.marshalNMap2map(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Log_attributes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Node_attributes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Log",
+		Object:     "Node",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -6984,8 +11632,8 @@ func (ec *executionContext) fieldContext_Log_attributes(ctx context.Context, fie
 	return fc, nil
 }
 
-func (ec *executionContext) _Log_resource(ctx context.Context, field graphql.CollectedField, obj *record.Log) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Log_resource(ctx, field)
+func (ec *executionContext) _OverviewPage_graph(ctx context.Context, field graphql.CollectedField, obj *model.OverviewPage) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_OverviewPage_graph(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -6998,35 +11646,50 @@ func (ec *executionContext) _Log_resource(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Resource, nil
+		return obj.Graph, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(map[string]interface{})
+	res := resTmp.(*graph.Graph)
 	fc.Result = res
-	return ec.marshalOMap2map(ctx, field.Selections, res)
+	return ec.marshalNGraph2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐGraph(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Log_resource(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_OverviewPage_graph(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Log",
+		Object:     "OverviewPage",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Map does not have child fields")
+			switch field.Name {
+			case "sources":
+				return ec.fieldContext_Graph_sources(ctx, field)
+			case "intermediates":
+				return ec.fieldContext_Graph_intermediates(ctx, field)
+			case "targets":
+				return ec.fieldContext_Graph_targets(ctx, field)
+			case "edges":
+				return ec.fieldContext_Graph_edges(ctx, field)
+			case "attributes":
+				return ec.fieldContext_Graph_attributes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Graph", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_id(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_id(ctx, field)
+func (ec *executionContext) _Parameter_name(ctx context.Context, field graphql.CollectedField, obj *model1.Parameter) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Parameter_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7039,7 +11702,7 @@ func (ec *executionContext) _Metadata_id(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7053,24 +11716,24 @@ func (ec *executionContext) _Metadata_id(ctx context.Context, field graphql.Coll
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNID2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Parameter_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metadata",
+		Object:     "Parameter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ID does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_name(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_name(ctx, field)
+func (ec *executionContext) _Parameter_value(ctx context.Context, field graphql.CollectedField, obj *model1.Parameter) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Parameter_value(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7083,7 +11746,7 @@ func (ec *executionContext) _Metadata_name(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.Value, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7095,26 +11758,26 @@ func (ec *executionContext) _Metadata_name(ctx context.Context, field graphql.Co
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(interface{})
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNAny2interface(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Parameter_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metadata",
+		Object:     "Parameter",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Any does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_displayName(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_displayName(ctx, field)
+func (ec *executionContext) _ParameterDefinition_name(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7127,23 +11790,26 @@ func (ec *executionContext) _Metadata_displayName(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.DisplayName, nil
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_displayName(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metadata",
+		Object:     "ParameterDefinition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -7154,8 +11820,8 @@ func (ec *executionContext) fieldContext_Metadata_displayName(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_description(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_description(ctx, field)
+func (ec *executionContext) _ParameterDefinition_label(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_label(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7168,23 +11834,26 @@ func (ec *executionContext) _Metadata_description(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.Label, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_description(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_label(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metadata",
+		Object:     "ParameterDefinition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -7195,8 +11864,8 @@ func (ec *executionContext) fieldContext_Metadata_description(ctx context.Contex
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_icon(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_icon(ctx, field)
+func (ec *executionContext) _ParameterDefinition_description(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_description(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7209,23 +11878,26 @@ func (ec *executionContext) _Metadata_icon(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Icon, nil
+		return obj.Description, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
 	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_icon(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_description(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metadata",
+		Object:     "ParameterDefinition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -7236,8 +11908,8 @@ func (ec *executionContext) fieldContext_Metadata_icon(ctx context.Context, fiel
 	return fc, nil
 }
 
-func (ec *executionContext) _Metadata_labels(ctx context.Context, field graphql.CollectedField, obj *model1.Metadata) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metadata_labels(ctx, field)
+func (ec *executionContext) _ParameterDefinition_required(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_required(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7250,35 +11922,38 @@ func (ec *executionContext) _Metadata_labels(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Metadata().Labels(rctx, obj)
+		return obj.Required, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(map[string]interface{})
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOMap2map(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metadata_labels(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_required(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metadata",
+		Object:     "ParameterDefinition",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Map does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metric_name(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metric_name(ctx, field)
+func (ec *executionContext) _ParameterDefinition_type(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_type(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7291,35 +11966,38 @@ func (ec *executionContext) _Metric_name(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return ec.resolvers.ParameterDefinition().Type(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(model.ParameterType)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNParameterType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐParameterType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metric_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metric",
+		Object:     "ParameterDefinition",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type ParameterType does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metric_timestamp(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metric_timestamp(ctx, field)
+func (ec *executionContext) _ParameterDefinition_validValues(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_validValues(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7332,7 +12010,7 @@ func (ec *executionContext) _Metric_timestamp(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Timestamp, nil
+		return obj.ValidValues, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7341,26 +12019,26 @@ func (ec *executionContext) _Metric_timestamp(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(time.Time)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalOTime2timeᚐTime(ctx, field.Selections, res)
+	return ec.marshalOString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metric_timestamp(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_validValues(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metric",
+		Object:     "ParameterDefinition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Time does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metric_value(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metric_value(ctx, field)
+func (ec *executionContext) _ParameterDefinition_default(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_default(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7373,7 +12051,7 @@ func (ec *executionContext) _Metric_value(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Value, nil
+		return obj.Default, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7387,9 +12065,9 @@ func (ec *executionContext) _Metric_value(ctx context.Context, field graphql.Col
 	return ec.marshalOAny2interface(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metric_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_default(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metric",
+		Object:     "ParameterDefinition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -7400,8 +12078,8 @@ func (ec *executionContext) fieldContext_Metric_value(ctx context.Context, field
 	return fc, nil
 }
 
-func (ec *executionContext) _Metric_unit(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metric_unit(ctx, field)
+func (ec *executionContext) _ParameterDefinition_relevantIf(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_relevantIf(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7414,7 +12092,7 @@ func (ec *executionContext) _Metric_unit(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Unit, nil
+		return obj.RelevantIf, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7423,26 +12101,34 @@ func (ec *executionContext) _Metric_unit(ctx context.Context, field graphql.Coll
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]model1.RelevantIfCondition)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalORelevantIfCondition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRelevantIfConditionᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metric_unit(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_relevantIf(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metric",
+		Object:     "ParameterDefinition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_RelevantIfCondition_name(ctx, field)
+			case "operator":
+				return ec.fieldContext_RelevantIfCondition_operator(ctx, field)
+			case "value":
+				return ec.fieldContext_RelevantIfCondition_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type RelevantIfCondition", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metric_type(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metric_type(ctx, field)
+func (ec *executionContext) _ParameterDefinition_advancedConfig(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_advancedConfig(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7455,7 +12141,7 @@ func (ec *executionContext) _Metric_type(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return obj.AdvancedConfig, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7464,26 +12150,26 @@ func (ec *executionContext) _Metric_type(ctx context.Context, field graphql.Coll
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metric_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_advancedConfig(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metric",
+		Object:     "ParameterDefinition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metric_attributes(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metric_attributes(ctx, field)
+func (ec *executionContext) _ParameterDefinition_options(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_options(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7496,35 +12182,52 @@ func (ec *executionContext) _Metric_attributes(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Attributes, nil
+		return obj.Options, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(map[string]interface{})
+	res := resTmp.(model1.ParameterOptions)
 	fc.Result = res
-	return ec.marshalOMap2map(ctx, field.Selections, res)
+	return ec.marshalNParameterOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterOptions(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metric_attributes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_options(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metric",
+		Object:     "ParameterDefinition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Map does not have child fields")
+			switch field.Name {
+			case "creatable":
+				return ec.fieldContext_ParameterOptions_creatable(ctx, field)
+			case "trackUnchecked":
+				return ec.fieldContext_ParameterOptions_trackUnchecked(ctx, field)
+			case "gridColumns":
+				return ec.fieldContext_ParameterOptions_gridColumns(ctx, field)
+			case "sectionHeader":
+				return ec.fieldContext_ParameterOptions_sectionHeader(ctx, field)
+			case "metricCategories":
+				return ec.fieldContext_ParameterOptions_metricCategories(ctx, field)
+			case "multiline":
+				return ec.fieldContext_ParameterOptions_multiline(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ParameterOptions", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Metric_resource(ctx context.Context, field graphql.CollectedField, obj *record.Metric) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Metric_resource(ctx, field)
+func (ec *executionContext) _ParameterDefinition_documentation(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterDefinition_documentation(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7537,7 +12240,7 @@ func (ec *executionContext) _Metric_resource(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Resource, nil
+		return obj.Documentation, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7546,26 +12249,32 @@ func (ec *executionContext) _Metric_resource(ctx context.Context, field graphql.
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(map[string]interface{})
+	res := resTmp.([]model1.DocumentationLink)
 	fc.Result = res
-	return ec.marshalOMap2map(ctx, field.Selections, res)
+	return ec.marshalODocumentationLink2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLinkᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Metric_resource(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterDefinition_documentation(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Metric",
+		Object:     "ParameterDefinition",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Map does not have child fields")
+			switch field.Name {
+			case "text":
+				return ec.fieldContext_DocumentationLink_text(ctx, field)
+			case "url":
+				return ec.fieldContext_DocumentationLink_url(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DocumentationLink", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricCategory_label(ctx context.Context, field graphql.CollectedField, obj *model1.MetricCategory) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MetricCategory_label(ctx, field)
+func (ec *executionContext) _ParameterOptions_creatable(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterOptions_creatable(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7578,38 +12287,35 @@ func (ec *executionContext) _MetricCategory_label(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Label, nil
+		return obj.Creatable, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MetricCategory_label(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterOptions_creatable(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MetricCategory",
+		Object:     "ParameterOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricCategory_column(ctx context.Context, field graphql.CollectedField, obj *model1.MetricCategory) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MetricCategory_column(ctx, field)
+func (ec *executionContext) _ParameterOptions_trackUnchecked(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterOptions_trackUnchecked(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7622,38 +12328,35 @@ func (ec *executionContext) _MetricCategory_column(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Column, nil
+		return obj.TrackUnchecked, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(int)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNInt2int(ctx, field.Selections, res)
+	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MetricCategory_column(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterOptions_trackUnchecked(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MetricCategory",
+		Object:     "ParameterOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricCategory_metrics(ctx context.Context, field graphql.CollectedField, obj *model1.MetricCategory) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MetricCategory_metrics(ctx, field)
+func (ec *executionContext) _ParameterOptions_gridColumns(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterOptions_gridColumns(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7666,46 +12369,35 @@ func (ec *executionContext) _MetricCategory_metrics(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Metrics, nil
+		return obj.GridColumns, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.MetricOption)
+	res := resTmp.(*int)
 	fc.Result = res
-	return ec.marshalNMetricOption2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricOptionᚄ(ctx, field.Selections, res)
+	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MetricCategory_metrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterOptions_gridColumns(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MetricCategory",
+		Object:     "ParameterOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "name":
-				return ec.fieldContext_MetricOption_name(ctx, field)
-			case "description":
-				return ec.fieldContext_MetricOption_description(ctx, field)
-			case "kpi":
-				return ec.fieldContext_MetricOption_kpi(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type MetricOption", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricOption_name(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MetricOption_name(ctx, field)
+func (ec *executionContext) _ParameterOptions_sectionHeader(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterOptions_sectionHeader(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7718,38 +12410,35 @@ func (ec *executionContext) _MetricOption_name(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.SectionHeader, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MetricOption_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterOptions_sectionHeader(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MetricOption",
+		Object:     "ParameterOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricOption_description(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MetricOption_description(ctx, field)
+func (ec *executionContext) _ParameterOptions_metricCategories(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterOptions_metricCategories(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7762,7 +12451,7 @@ func (ec *executionContext) _MetricOption_description(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return obj.MetricCategories, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7771,26 +12460,34 @@ func (ec *executionContext) _MetricOption_description(ctx context.Context, field
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*string)
+	res := resTmp.([]model1.MetricCategory)
 	fc.Result = res
-	return ec.marshalOString2ᚖstring(ctx, field.Selections, res)
+	return ec.marshalOMetricCategory2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricCategoryᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MetricOption_description(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterOptions_metricCategories(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MetricOption",
+		Object:     "ParameterOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "label":
+				return ec.fieldContext_MetricCategory_label(ctx, field)
+			case "column":
+				return ec.fieldContext_MetricCategory_column(ctx, field)
+			case "metrics":
+				return ec.fieldContext_MetricCategory_metrics(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type MetricCategory", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _MetricOption_kpi(ctx context.Context, field graphql.CollectedField, obj *model1.MetricOption) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_MetricOption_kpi(ctx, field)
+func (ec *executionContext) _ParameterOptions_multiline(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterOptions_multiline(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7803,7 +12500,7 @@ func (ec *executionContext) _MetricOption_kpi(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.KPI, nil
+		return obj.Multiline, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7812,14 +12509,14 @@ func (ec *executionContext) _MetricOption_kpi(ctx context.Context, field graphql
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*bool)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
+	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_MetricOption_kpi(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterOptions_multiline(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "MetricOption",
+		Object:     "ParameterOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -7830,8 +12527,8 @@ func (ec *executionContext) fieldContext_MetricOption_kpi(ctx context.Context, f
 	return fc, nil
 }
 
-func (ec *executionContext) _Mutation_updateProcessors(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Mutation_updateProcessors(ctx, field)
+func (ec *executionContext) _ParameterizedSpec_type(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterizedSpec_type(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7844,46 +12541,38 @@ func (ec *executionContext) _Mutation_updateProcessors(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Mutation().UpdateProcessors(rctx, fc.Args["input"].(model.UpdateProcessorsInput))
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*bool)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Mutation_updateProcessors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterizedSpec_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Mutation",
+		Object:     "ParameterizedSpec",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Mutation_updateProcessors_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Node_id(ctx context.Context, field graphql.CollectedField, obj *graph.Node) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Node_id(ctx, field)
+func (ec *executionContext) _ParameterizedSpec_parameters(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterizedSpec_parameters(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7896,38 +12585,41 @@ func (ec *executionContext) _Node_id(ctx context.Context, field graphql.Collecte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ID, nil
+		return obj.Parameters, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]model1.Parameter)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOParameter2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Node_id(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterizedSpec_parameters(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Node",
+		Object:     "ParameterizedSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_Parameter_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Parameter_value(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Parameter", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Node_type(ctx context.Context, field graphql.CollectedField, obj *graph.Node) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Node_type(ctx, field)
+func (ec *executionContext) _ParameterizedSpec_processors(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterizedSpec_processors(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7940,38 +12632,47 @@ func (ec *executionContext) _Node_type(ctx context.Context, field graphql.Collec
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return obj.Processors, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]model1.ResourceConfiguration)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Node_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterizedSpec_processors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Node",
+		Object:     "ParameterizedSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
+			case "type":
+				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
+			case "processors":
+				return ec.fieldContext_ResourceConfiguration_processors(ctx, field)
+			case "disabled":
+				return ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ResourceConfiguration", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Node_label(ctx context.Context, field graphql.CollectedField, obj *graph.Node) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Node_label(ctx, field)
+func (ec *executionContext) _ParameterizedSpec_disabled(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ParameterizedSpec_disabled(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -7984,7 +12685,7 @@ func (ec *executionContext) _Node_label(ctx context.Context, field graphql.Colle
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Label, nil
+		return obj.Disabled, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -7996,26 +12697,26 @@ func (ec *executionContext) _Node_label(ctx context.Context, field graphql.Colle
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Node_label(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ParameterizedSpec_disabled(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Node",
+		Object:     "ParameterizedSpec",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Node_attributes(ctx context.Context, field graphql.CollectedField, obj *graph.Node) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Node_attributes(ctx, field)
+func (ec *executionContext) _Processor_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Processor_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8028,7 +12729,7 @@ func (ec *executionContext) _Node_attributes(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Attributes, nil
+		return obj.APIVersion, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8040,26 +12741,26 @@ func (ec *executionContext) _Node_attributes(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(map[string]any)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNMap2map(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Node_attributes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Processor_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Node",
+		Object:     "Processor",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Map does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _OverviewPage_graph(ctx context.Context, field graphql.CollectedField, obj *model.OverviewPage) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_OverviewPage_graph(ctx, field)
+func (ec *executionContext) _Processor_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Processor_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8072,7 +12773,7 @@ func (ec *executionContext) _OverviewPage_graph(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Graph, nil
+		return ec.resolvers.Processor().Kind(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8084,38 +12785,26 @@ func (ec *executionContext) _OverviewPage_graph(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*graph.Graph)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNGraph2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐGraph(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_OverviewPage_graph(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Processor_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "OverviewPage",
+		Object:     "Processor",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "sources":
-				return ec.fieldContext_Graph_sources(ctx, field)
-			case "intermediates":
-				return ec.fieldContext_Graph_intermediates(ctx, field)
-			case "targets":
-				return ec.fieldContext_Graph_targets(ctx, field)
-			case "edges":
-				return ec.fieldContext_Graph_edges(ctx, field)
-			case "attributes":
-				return ec.fieldContext_Graph_attributes(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Graph", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Parameter_name(ctx context.Context, field graphql.CollectedField, obj *model1.Parameter) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Parameter_name(ctx, field)
+func (ec *executionContext) _Processor_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Processor_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8128,7 +12817,7 @@ func (ec *executionContext) _Parameter_name(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.Metadata, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8140,26 +12829,44 @@ func (ec *executionContext) _Parameter_name(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(model1.Metadata)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Parameter_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Processor_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Parameter",
+		Object:     "Processor",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Metadata_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Metadata_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Metadata_displayName(ctx, field)
+			case "description":
+				return ec.fieldContext_Metadata_description(ctx, field)
+			case "icon":
+				return ec.fieldContext_Metadata_icon(ctx, field)
+			case "labels":
+				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "revision":
+				return ec.fieldContext_Metadata_revision(ctx, field)
+			case "resourceVersion":
+				return ec.fieldContext_Metadata_resourceVersion(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Parameter_value(ctx context.Context, field graphql.CollectedField, obj *model1.Parameter) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Parameter_value(ctx, field)
+func (ec *executionContext) _Processor_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Processor_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8172,7 +12879,7 @@ func (ec *executionContext) _Parameter_value(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Value, nil
+		return obj.Spec, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8184,26 +12891,36 @@ func (ec *executionContext) _Parameter_value(ctx context.Context, field graphql.
 		}
 		return graphql.Null
 	}
-	res := resTmp.(interface{})
+	res := resTmp.(model1.ParameterizedSpec)
 	fc.Result = res
-	return ec.marshalNAny2interface(ctx, field.Selections, res)
+	return ec.marshalNParameterizedSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterizedSpec(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Parameter_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Processor_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Parameter",
+		Object:     "Processor",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Any does not have child fields")
+			switch field.Name {
+			case "type":
+				return ec.fieldContext_ParameterizedSpec_type(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ParameterizedSpec_parameters(ctx, field)
+			case "processors":
+				return ec.fieldContext_ParameterizedSpec_processors(ctx, field)
+			case "disabled":
+				return ec.fieldContext_ParameterizedSpec_disabled(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ParameterizedSpec", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_name(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_name(ctx, field)
+func (ec *executionContext) _ProcessorType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ProcessorType_apiVersion(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8216,7 +12933,7 @@ func (ec *executionContext) _ParameterDefinition_name(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.APIVersion, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8233,9 +12950,9 @@ func (ec *executionContext) _ParameterDefinition_name(ctx context.Context, field
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ProcessorType_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "ProcessorType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
@@ -8246,8 +12963,8 @@ func (ec *executionContext) fieldContext_ParameterDefinition_name(ctx context.Co
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_label(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_label(ctx, field)
+func (ec *executionContext) _ProcessorType_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ProcessorType_metadata(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8260,7 +12977,7 @@ func (ec *executionContext) _ParameterDefinition_label(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Label, nil
+		return obj.Metadata, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8272,26 +12989,44 @@ func (ec *executionContext) _ParameterDefinition_label(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(model1.Metadata)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_label(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ProcessorType_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "ProcessorType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Metadata_id(ctx, field)
+			case "name":
+				return ec.fieldContext_Metadata_name(ctx, field)
+			case "displayName":
+				return ec.fieldContext_Metadata_displayName(ctx, field)
+			case "description":
+				return ec.fieldContext_Metadata_description(ctx, field)
+			case "icon":
+				return ec.fieldContext_Metadata_icon(ctx, field)
+			case "labels":
+				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "revision":
+				return ec.fieldContext_Metadata_revision(ctx, field)
+			case "resourceVersion":
+				return ec.fieldContext_Metadata_resourceVersion(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_description(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_description(ctx, field)
+func (ec *executionContext) _ProcessorType_kind(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ProcessorType_kind(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8304,7 +13039,7 @@ func (ec *executionContext) _ParameterDefinition_description(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Description, nil
+		return ec.resolvers.ProcessorType().Kind(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8321,12 +13056,12 @@ func (ec *executionContext) _ParameterDefinition_description(ctx context.Context
 	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_description(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ProcessorType_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "ProcessorType",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			return nil, errors.New("field of type String does not have child fields")
 		},
@@ -8334,8 +13069,8 @@ func (ec *executionContext) fieldContext_ParameterDefinition_description(ctx con
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_required(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_required(ctx, field)
+func (ec *executionContext) _ProcessorType_spec(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ProcessorType_spec(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8348,7 +13083,7 @@ func (ec *executionContext) _ParameterDefinition_required(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Required, nil
+		return obj.Spec, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8360,26 +13095,36 @@ func (ec *executionContext) _ParameterDefinition_required(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(model1.ResourceTypeSpec)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_required(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ProcessorType_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "ProcessorType",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "version":
+				return ec.fieldContext_ResourceTypeSpec_version(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ResourceTypeSpec_parameters(ctx, field)
+			case "supportedPlatforms":
+				return ec.fieldContext_ResourceTypeSpec_supportedPlatforms(ctx, field)
+			case "telemetryTypes":
+				return ec.fieldContext_ResourceTypeSpec_telemetryTypes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ResourceTypeSpec", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_type(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_type(ctx, field)
+func (ec *executionContext) _Query_overviewPage(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_overviewPage(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8392,7 +13137,7 @@ func (ec *executionContext) _ParameterDefinition_type(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.ParameterDefinition().Type(rctx, obj)
+		return ec.resolvers.Query().OverviewPage(rctx, fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string), fc.Args["period"].(string), fc.Args["telemetryType"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8404,26 +13149,41 @@ func (ec *executionContext) _ParameterDefinition_type(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model.ParameterType)
+	res := resTmp.(*model.OverviewPage)
 	fc.Result = res
-	return ec.marshalNParameterType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐParameterType(ctx, field.Selections, res)
+	return ec.marshalNOverviewPage2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐOverviewPage(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_overviewPage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "Query",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type ParameterType does not have child fields")
+			switch field.Name {
+			case "graph":
+				return ec.fieldContext_OverviewPage_graph(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type OverviewPage", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_overviewPage_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_validValues(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_validValues(ctx, field)
+func (ec *executionContext) _Query_agents(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_agents(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8436,35 +13196,59 @@ func (ec *executionContext) _ParameterDefinition_validValues(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.ValidValues, nil
+		return ec.resolvers.Query().Agents(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.(*model.Agents)
 	fc.Result = res
-	return ec.marshalOString2ᚕstringᚄ(ctx, field.Selections, res)
+	return ec.marshalNAgents2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐAgents(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_validValues(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_agents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "query":
+				return ec.fieldContext_Agents_query(ctx, field)
+			case "agents":
+				return ec.fieldContext_Agents_agents(ctx, field)
+			case "suggestions":
+				return ec.fieldContext_Agents_suggestions(ctx, field)
+			case "latestVersion":
+				return ec.fieldContext_Agents_latestVersion(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Agents", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_agents_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_default(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_default(ctx, field)
+func (ec *executionContext) _Query_agent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_agent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8477,7 +13261,7 @@ func (ec *executionContext) _ParameterDefinition_default(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Default, nil
+		return ec.resolvers.Query().Agent(rctx, fc.Args["id"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8486,26 +13270,85 @@ func (ec *executionContext) _ParameterDefinition_default(ctx context.Context, fi
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(interface{})
+	res := resTmp.(*model1.Agent)
 	fc.Result = res
-	return ec.marshalOAny2interface(ctx, field.Selections, res)
+	return ec.marshalOAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_default(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_agent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Any does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_Agent_id(ctx, field)
+			case "architecture":
+				return ec.fieldContext_Agent_architecture(ctx, field)
+			case "hostName":
+				return ec.fieldContext_Agent_hostName(ctx, field)
+			case "labels":
+				return ec.fieldContext_Agent_labels(ctx, field)
+			case "platform":
+				return ec.fieldContext_Agent_platform(ctx, field)
+			case "operatingSystem":
+				return ec.fieldContext_Agent_operatingSystem(ctx, field)
+			case "version":
+				return ec.fieldContext_Agent_version(ctx, field)
+			case "name":
+				return ec.fieldContext_Agent_name(ctx, field)
+			case "home":
+				return ec.fieldContext_Agent_home(ctx, field)
+			case "macAddress":
+				return ec.fieldContext_Agent_macAddress(ctx, field)
+			case "remoteAddress":
+				return ec.fieldContext_Agent_remoteAddress(ctx, field)
+			case "type":
+				return ec.fieldContext_Agent_type(ctx, field)
+			case "status":
+				return ec.fieldContext_Agent_status(ctx, field)
+			case "errorMessage":
+				return ec.fieldContext_Agent_errorMessage(ctx, field)
+			case "connectedAt":
+				return ec.fieldContext_Agent_connectedAt(ctx, field)
+			case "disconnectedAt":
+				return ec.fieldContext_Agent_disconnectedAt(ctx, field)
+			case "configuration":
+				return ec.fieldContext_Agent_configuration(ctx, field)
+			case "configurationResource":
+				return ec.fieldContext_Agent_configurationResource(ctx, field)
+			case "upgrade":
+				return ec.fieldContext_Agent_upgrade(ctx, field)
+			case "restart":
+				return ec.fieldContext_Agent_restart(ctx, field)
+			case "upgradeAvailable":
+				return ec.fieldContext_Agent_upgradeAvailable(ctx, field)
+			case "features":
+				return ec.fieldContext_Agent_features(ctx, field)
+			case "history":
+				return ec.fieldContext_Agent_history(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Agent", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_agent_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_relevantIf(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_relevantIf(ctx, field)
+func (ec *executionContext) _Query_configurations(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_configurations(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8518,43 +13361,57 @@ func (ec *executionContext) _ParameterDefinition_relevantIf(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.RelevantIf, nil
+		return ec.resolvers.Query().Configurations(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["onlyDeployedConfigurations"].(*bool))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.RelevantIfCondition)
+	res := resTmp.(*model.Configurations)
 	fc.Result = res
-	return ec.marshalORelevantIfCondition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRelevantIfConditionᚄ(ctx, field.Selections, res)
+	return ec.marshalNConfigurations2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐConfigurations(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_relevantIf(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_configurations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "name":
-				return ec.fieldContext_RelevantIfCondition_name(ctx, field)
-			case "operator":
-				return ec.fieldContext_RelevantIfCondition_operator(ctx, field)
-			case "value":
-				return ec.fieldContext_RelevantIfCondition_value(ctx, field)
+			case "query":
+				return ec.fieldContext_Configurations_query(ctx, field)
+			case "configurations":
+				return ec.fieldContext_Configurations_configurations(ctx, field)
+			case "suggestions":
+				return ec.fieldContext_Configurations_suggestions(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type RelevantIfCondition", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Configurations", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_configurations_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_advancedConfig(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_advancedConfig(ctx, field)
+func (ec *executionContext) _Query_configuration(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_configuration(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8567,7 +13424,7 @@ func (ec *executionContext) _ParameterDefinition_advancedConfig(ctx context.Cont
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.AdvancedConfig, nil
+		return ec.resolvers.Query().Configuration(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8576,26 +13433,55 @@ func (ec *executionContext) _ParameterDefinition_advancedConfig(ctx context.Cont
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model1.Configuration)
 	fc.Result = res
-	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_advancedConfig(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_configuration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Configuration_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Configuration_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Configuration_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Configuration_spec(ctx, field)
+			case "agentCount":
+				return ec.fieldContext_Configuration_agentCount(ctx, field)
+			case "graph":
+				return ec.fieldContext_Configuration_graph(ctx, field)
+			case "rendered":
+				return ec.fieldContext_Configuration_rendered(ctx, field)
+			case "rollout":
+				return ec.fieldContext_Configuration_rollout(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_configuration_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_options(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_options(ctx, field)
+func (ec *executionContext) _Query_configurationRevisions(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_configurationRevisions(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8608,7 +13494,7 @@ func (ec *executionContext) _ParameterDefinition_options(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Options, nil
+		return ec.resolvers.Query().ConfigurationRevisions(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8620,40 +13506,55 @@ func (ec *executionContext) _ParameterDefinition_options(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ParameterOptions)
+	res := resTmp.([]*model1.Configuration)
 	fc.Result = res
-	return ec.marshalNParameterOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterOptions(ctx, field.Selections, res)
+	return ec.marshalNConfiguration2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_options(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_configurationRevisions(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "creatable":
-				return ec.fieldContext_ParameterOptions_creatable(ctx, field)
-			case "trackUnchecked":
-				return ec.fieldContext_ParameterOptions_trackUnchecked(ctx, field)
-			case "gridColumns":
-				return ec.fieldContext_ParameterOptions_gridColumns(ctx, field)
-			case "sectionHeader":
-				return ec.fieldContext_ParameterOptions_sectionHeader(ctx, field)
-			case "metricCategories":
-				return ec.fieldContext_ParameterOptions_metricCategories(ctx, field)
-			case "multiline":
-				return ec.fieldContext_ParameterOptions_multiline(ctx, field)
+			case "apiVersion":
+				return ec.fieldContext_Configuration_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Configuration_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Configuration_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Configuration_spec(ctx, field)
+			case "agentCount":
+				return ec.fieldContext_Configuration_agentCount(ctx, field)
+			case "graph":
+				return ec.fieldContext_Configuration_graph(ctx, field)
+			case "rendered":
+				return ec.fieldContext_Configuration_rendered(ctx, field)
+			case "rollout":
+				return ec.fieldContext_Configuration_rollout(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ParameterOptions", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_configurationRevisions_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterDefinition_documentation(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterDefinition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterDefinition_documentation(ctx, field)
+func (ec *executionContext) _Query_sources(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_sources(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8666,41 +13567,59 @@ func (ec *executionContext) _ParameterDefinition_documentation(ctx context.Conte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Documentation, nil
+		return ec.resolvers.Query().Sources(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["offset"].(*int), fc.Args["limit"].(*int), fc.Args["sort"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.DocumentationLink)
+	res := resTmp.([]*model1.Source)
 	fc.Result = res
-	return ec.marshalODocumentationLink2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDocumentationLinkᚄ(ctx, field.Selections, res)
+	return ec.marshalNSource2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterDefinition_documentation(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_sources(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterDefinition",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "text":
-				return ec.fieldContext_DocumentationLink_text(ctx, field)
-			case "url":
-				return ec.fieldContext_DocumentationLink_url(ctx, field)
+			case "apiVersion":
+				return ec.fieldContext_Source_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Source_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Source_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Source_spec(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type DocumentationLink", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Source", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_sources_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_creatable(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterOptions_creatable(ctx, field)
+func (ec *executionContext) _Query_source(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_source(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8713,7 +13632,7 @@ func (ec *executionContext) _ParameterOptions_creatable(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Creatable, nil
+		return ec.resolvers.Query().Source(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8722,26 +13641,47 @@ func (ec *executionContext) _ParameterOptions_creatable(ctx context.Context, fie
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model1.Source)
 	fc.Result = res
-	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalOSource2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSource(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterOptions_creatable(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_source(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterOptions",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Source_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Source_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Source_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Source_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Source", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_source_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_trackUnchecked(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterOptions_trackUnchecked(ctx, field)
+func (ec *executionContext) _Query_sourceTypes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_sourceTypes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8754,35 +13694,59 @@ func (ec *executionContext) _ParameterOptions_trackUnchecked(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TrackUnchecked, nil
+		return ec.resolvers.Query().SourceTypes(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["offset"].(*int), fc.Args["limit"].(*int), fc.Args["sort"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.([]*model1.SourceType)
 	fc.Result = res
-	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNSourceType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceTypeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterOptions_trackUnchecked(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_sourceTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterOptions",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_SourceType_apiVersion(ctx, field)
+			case "metadata":
+				return ec.fieldContext_SourceType_metadata(ctx, field)
+			case "kind":
+				return ec.fieldContext_SourceType_kind(ctx, field)
+			case "spec":
+				return ec.fieldContext_SourceType_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type SourceType", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_sourceTypes_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_gridColumns(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterOptions_gridColumns(ctx, field)
+func (ec *executionContext) _Query_sourceType(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_sourceType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8795,7 +13759,7 @@ func (ec *executionContext) _ParameterOptions_gridColumns(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.GridColumns, nil
+		return ec.resolvers.Query().SourceType(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8804,26 +13768,47 @@ func (ec *executionContext) _ParameterOptions_gridColumns(ctx context.Context, f
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*int)
+	res := resTmp.(*model1.SourceType)
 	fc.Result = res
-	return ec.marshalOInt2ᚖint(ctx, field.Selections, res)
+	return ec.marshalOSourceType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterOptions_gridColumns(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_sourceType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterOptions",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Int does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_SourceType_apiVersion(ctx, field)
+			case "metadata":
+				return ec.fieldContext_SourceType_metadata(ctx, field)
+			case "kind":
+				return ec.fieldContext_SourceType_kind(ctx, field)
+			case "spec":
+				return ec.fieldContext_SourceType_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type SourceType", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_sourceType_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_sectionHeader(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterOptions_sectionHeader(ctx, field)
+func (ec *executionContext) _Query_processors(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_processors(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8836,35 +13821,59 @@ func (ec *executionContext) _ParameterOptions_sectionHeader(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SectionHeader, nil
+		return ec.resolvers.Query().Processors(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["offset"].(*int), fc.Args["limit"].(*int), fc.Args["sort"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*bool)
+	res := resTmp.([]*model1.Processor)
 	fc.Result = res
-	return ec.marshalOBoolean2ᚖbool(ctx, field.Selections, res)
+	return ec.marshalNProcessor2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterOptions_sectionHeader(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_processors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterOptions",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Processor_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Processor_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Processor_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Processor_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Processor", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_processors_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_metricCategories(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterOptions_metricCategories(ctx, field)
+func (ec *executionContext) _Query_processor(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_processor(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8877,7 +13886,7 @@ func (ec *executionContext) _ParameterOptions_metricCategories(ctx context.Conte
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.MetricCategories, nil
+		return ec.resolvers.Query().Processor(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -8886,34 +13895,47 @@ func (ec *executionContext) _ParameterOptions_metricCategories(ctx context.Conte
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.MetricCategory)
+	res := resTmp.(*model1.Processor)
 	fc.Result = res
-	return ec.marshalOMetricCategory2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetricCategoryᚄ(ctx, field.Selections, res)
+	return ec.marshalOProcessor2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessor(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterOptions_metricCategories(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_processor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterOptions",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "label":
-				return ec.fieldContext_MetricCategory_label(ctx, field)
-			case "column":
-				return ec.fieldContext_MetricCategory_column(ctx, field)
-			case "metrics":
-				return ec.fieldContext_MetricCategory_metrics(ctx, field)
+			case "apiVersion":
+				return ec.fieldContext_Processor_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Processor_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Processor_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Processor_spec(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type MetricCategory", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Processor", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_processor_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterOptions_multiline(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterOptions) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterOptions_multiline(ctx, field)
+func (ec *executionContext) _Query_processorTypes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_processorTypes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8926,35 +13948,59 @@ func (ec *executionContext) _ParameterOptions_multiline(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Multiline, nil
+		return ec.resolvers.Query().ProcessorTypes(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["offset"].(*int), fc.Args["limit"].(*int), fc.Args["sort"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.([]*model1.ProcessorType)
 	fc.Result = res
-	return ec.marshalOBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNProcessorType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorTypeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterOptions_multiline(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_processorTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterOptions",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_ProcessorType_apiVersion(ctx, field)
+			case "metadata":
+				return ec.fieldContext_ProcessorType_metadata(ctx, field)
+			case "kind":
+				return ec.fieldContext_ProcessorType_kind(ctx, field)
+			case "spec":
+				return ec.fieldContext_ProcessorType_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ProcessorType", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_processorTypes_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterizedSpec_type(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterizedSpec_type(ctx, field)
+func (ec *executionContext) _Query_processorType(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_processorType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -8967,38 +14013,56 @@ func (ec *executionContext) _ParameterizedSpec_type(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return ec.resolvers.Query().ProcessorType(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*model1.ProcessorType)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOProcessorType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterizedSpec_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_processorType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterizedSpec",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_ProcessorType_apiVersion(ctx, field)
+			case "metadata":
+				return ec.fieldContext_ProcessorType_metadata(ctx, field)
+			case "kind":
+				return ec.fieldContext_ProcessorType_kind(ctx, field)
+			case "spec":
+				return ec.fieldContext_ProcessorType_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ProcessorType", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_processorType_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterizedSpec_parameters(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterizedSpec_parameters(ctx, field)
+func (ec *executionContext) _Query_destinations(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_destinations(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9011,41 +14075,59 @@ func (ec *executionContext) _ParameterizedSpec_parameters(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Parameters, nil
+		return ec.resolvers.Query().Destinations(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["offset"].(*int), fc.Args["limit"].(*int), fc.Args["sort"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.Parameter)
+	res := resTmp.([]*model1.Destination)
 	fc.Result = res
-	return ec.marshalOParameter2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx, field.Selections, res)
+	return ec.marshalNDestination2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterizedSpec_parameters(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_destinations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterizedSpec",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "name":
-				return ec.fieldContext_Parameter_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Parameter_value(ctx, field)
+			case "apiVersion":
+				return ec.fieldContext_Destination_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Destination_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Destination_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Destination_spec(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Parameter", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Destination", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_destinations_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterizedSpec_processors(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterizedSpec_processors(ctx, field)
+func (ec *executionContext) _Query_destination(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_destination(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9058,7 +14140,7 @@ func (ec *executionContext) _ParameterizedSpec_processors(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Processors, nil
+		return ec.resolvers.Query().Destination(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9067,38 +14149,47 @@ func (ec *executionContext) _ParameterizedSpec_processors(ctx context.Context, f
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ResourceConfiguration)
+	res := resTmp.(*model1.Destination)
 	fc.Result = res
-	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
+	return ec.marshalODestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterizedSpec_processors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_destination(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterizedSpec",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "name":
-				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
-			case "type":
-				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
-			case "parameters":
-				return ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
-			case "processors":
-				return ec.fieldContext_ResourceConfiguration_processors(ctx, field)
-			case "disabled":
-				return ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
+			case "apiVersion":
+				return ec.fieldContext_Destination_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Destination_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Destination_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Destination_spec(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ResourceConfiguration", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Destination", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_destination_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ParameterizedSpec_disabled(ctx context.Context, field graphql.CollectedField, obj *model1.ParameterizedSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ParameterizedSpec_disabled(ctx, field)
+func (ec *executionContext) _Query_destinationWithType(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_destinationWithType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9111,7 +14202,7 @@ func (ec *executionContext) _ParameterizedSpec_disabled(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Disabled, nil
+		return ec.resolvers.Query().DestinationWithType(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9123,26 +14214,43 @@ func (ec *executionContext) _ParameterizedSpec_disabled(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(*model.DestinationWithType)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNDestinationWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐDestinationWithType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ParameterizedSpec_disabled(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_destinationWithType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ParameterizedSpec",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			switch field.Name {
+			case "destination":
+				return ec.fieldContext_DestinationWithType_destination(ctx, field)
+			case "destinationType":
+				return ec.fieldContext_DestinationWithType_destinationType(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DestinationWithType", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_destinationWithType_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Processor_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Processor_apiVersion(ctx, field)
+func (ec *executionContext) _Query_destinationsInConfigs(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_destinationsInConfigs(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9155,7 +14263,7 @@ func (ec *executionContext) _Processor_apiVersion(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.APIVersion, nil
+		return ec.resolvers.Query().DestinationsInConfigs(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9167,26 +14275,36 @@ func (ec *executionContext) _Processor_apiVersion(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*model1.Destination)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNDestination2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Processor_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_destinationsInConfigs(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Processor",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_Destination_apiVersion(ctx, field)
+			case "kind":
+				return ec.fieldContext_Destination_kind(ctx, field)
+			case "metadata":
+				return ec.fieldContext_Destination_metadata(ctx, field)
+			case "spec":
+				return ec.fieldContext_Destination_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Destination", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Processor_kind(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Processor_kind(ctx, field)
+func (ec *executionContext) _Query_destinationTypes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_destinationTypes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9199,7 +14317,7 @@ func (ec *executionContext) _Processor_kind(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Processor().Kind(rctx, obj)
+		return ec.resolvers.Query().DestinationTypes(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["offset"].(*int), fc.Args["limit"].(*int), fc.Args["sort"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9211,26 +14329,47 @@ func (ec *executionContext) _Processor_kind(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*model1.DestinationType)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNDestinationType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationTypeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Processor_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_destinationTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Processor",
+		Object:     "Query",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_DestinationType_apiVersion(ctx, field)
+			case "metadata":
+				return ec.fieldContext_DestinationType_metadata(ctx, field)
+			case "kind":
+				return ec.fieldContext_DestinationType_kind(ctx, field)
+			case "spec":
+				return ec.fieldContext_DestinationType_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type DestinationType", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_destinationTypes_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Processor_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Processor_metadata(ctx, field)
+func (ec *executionContext) _Query_destinationType(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_destinationType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9243,52 +14382,56 @@ func (ec *executionContext) _Processor_metadata(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Metadata, nil
+		return ec.resolvers.Query().DestinationType(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(*model1.DestinationType)
 	fc.Result = res
-	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
+	return ec.marshalODestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Processor_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_destinationType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Processor",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Metadata_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Metadata_name(ctx, field)
-			case "displayName":
-				return ec.fieldContext_Metadata_displayName(ctx, field)
-			case "description":
-				return ec.fieldContext_Metadata_description(ctx, field)
-			case "icon":
-				return ec.fieldContext_Metadata_icon(ctx, field)
-			case "labels":
-				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "apiVersion":
+				return ec.fieldContext_DestinationType_apiVersion(ctx, field)
+			case "metadata":
+				return ec.fieldContext_DestinationType_metadata(ctx, field)
+			case "kind":
+				return ec.fieldContext_DestinationType_kind(ctx, field)
+			case "spec":
+				return ec.fieldContext_DestinationType_spec(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type DestinationType", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_destinationType_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Processor_spec(ctx context.Context, field graphql.CollectedField, obj *model1.Processor) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Processor_spec(ctx, field)
+func (ec *executionContext) _Query_extensionTypes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_extensionTypes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9301,7 +14444,7 @@ func (ec *executionContext) _Processor_spec(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Spec, nil
+		return ec.resolvers.Query().ExtensionTypes(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["offset"].(*int), fc.Args["limit"].(*int), fc.Args["sort"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9313,36 +14456,47 @@ func (ec *executionContext) _Processor_spec(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ParameterizedSpec)
+	res := resTmp.([]*model1.ExtensionType)
 	fc.Result = res
-	return ec.marshalNParameterizedSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterizedSpec(ctx, field.Selections, res)
+	return ec.marshalNExtensionType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐExtensionTypeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Processor_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_extensionTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Processor",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "type":
-				return ec.fieldContext_ParameterizedSpec_type(ctx, field)
-			case "parameters":
-				return ec.fieldContext_ParameterizedSpec_parameters(ctx, field)
-			case "processors":
-				return ec.fieldContext_ParameterizedSpec_processors(ctx, field)
-			case "disabled":
-				return ec.fieldContext_ParameterizedSpec_disabled(ctx, field)
+			case "apiVersion":
+				return ec.fieldContext_ExtensionType_apiVersion(ctx, field)
+			case "metadata":
+				return ec.fieldContext_ExtensionType_metadata(ctx, field)
+			case "kind":
+				return ec.fieldContext_ExtensionType_kind(ctx, field)
+			case "spec":
+				return ec.fieldContext_ExtensionType_spec(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ParameterizedSpec", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ExtensionType", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_extensionTypes_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorType_apiVersion(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ProcessorType_apiVersion(ctx, field)
+func (ec *executionContext) _Query_extensionType(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_extensionType(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9355,38 +14509,56 @@ func (ec *executionContext) _ProcessorType_apiVersion(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.APIVersion, nil
+		return ec.resolvers.Query().ExtensionType(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(*model1.ExtensionType)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalOExtensionType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐExtensionType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ProcessorType_apiVersion(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_extensionType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ProcessorType",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "apiVersion":
+				return ec.fieldContext_ExtensionType_apiVersion(ctx, field)
+			case "metadata":
+				return ec.fieldContext_ExtensionType_metadata(ctx, field)
+			case "kind":
+				return ec.fieldContext_ExtensionType_kind(ctx, field)
+			case "spec":
+				return ec.fieldContext_ExtensionType_spec(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type ExtensionType", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_extensionType_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorType_metadata(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ProcessorType_metadata(ctx, field)
+func (ec *executionContext) _Query_snapshot(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_snapshot(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9399,7 +14571,7 @@ func (ec *executionContext) _ProcessorType_metadata(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Metadata, nil
+		return ec.resolvers.Query().Snapshot(rctx, fc.Args["agentID"].(string), fc.Args["pipelineType"].(otel.PipelineType))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9411,40 +14583,45 @@ func (ec *executionContext) _ProcessorType_metadata(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.Metadata)
+	res := resTmp.(*model.Snapshot)
 	fc.Result = res
-	return ec.marshalNMetadata2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐMetadata(ctx, field.Selections, res)
+	return ec.marshalNSnapshot2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐSnapshot(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ProcessorType_metadata(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_snapshot(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ProcessorType",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Metadata_id(ctx, field)
-			case "name":
-				return ec.fieldContext_Metadata_name(ctx, field)
-			case "displayName":
-				return ec.fieldContext_Metadata_displayName(ctx, field)
-			case "description":
-				return ec.fieldContext_Metadata_description(ctx, field)
-			case "icon":
-				return ec.fieldContext_Metadata_icon(ctx, field)
-			case "labels":
-				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "logs":
+				return ec.fieldContext_Snapshot_logs(ctx, field)
+			case "metrics":
+				return ec.fieldContext_Snapshot_metrics(ctx, field)
+			case "traces":
+				return ec.fieldContext_Snapshot_traces(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Snapshot", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_snapshot_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorType_kind(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ProcessorType_kind(ctx, field)
+func (ec *executionContext) _Query_auditEntries(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_auditEntries(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9457,7 +14634,7 @@ func (ec *executionContext) _ProcessorType_kind(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.ProcessorType().Kind(rctx, obj)
+		return ec.resolvers.Query().AuditEntries(rctx, fc.Args["kind"].(*string), fc.Args["name"].(*string), fc.Args["user"].(*string), fc.Args["offset"].(*int), fc.Args["limit"].(*int))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9469,26 +14646,57 @@ func (ec *executionContext) _ProcessorType_kind(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]*model1.AuditEntry)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNAuditEntry2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAuditEntryᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ProcessorType_kind(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_auditEntries(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ProcessorType",
+		Object:     "Query",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			switch field.Name {
+			case "id":
+				return ec.fieldContext_AuditEntry_id(ctx, field)
+			case "timestamp":
+				return ec.fieldContext_AuditEntry_timestamp(ctx, field)
+			case "user":
+				return ec.fieldContext_AuditEntry_user(ctx, field)
+			case "action":
+				return ec.fieldContext_AuditEntry_action(ctx, field)
+			case "kind":
+				return ec.fieldContext_AuditEntry_kind(ctx, field)
+			case "name":
+				return ec.fieldContext_AuditEntry_name(ctx, field)
+			case "before":
+				return ec.fieldContext_AuditEntry_before(ctx, field)
+			case "after":
+				return ec.fieldContext_AuditEntry_after(ctx, field)
+			case "changes":
+				return ec.fieldContext_AuditEntry_changes(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type AuditEntry", field.Name)
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Query_auditEntries_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ProcessorType_spec(ctx context.Context, field graphql.CollectedField, obj *model1.ProcessorType) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ProcessorType_spec(ctx, field)
+func (ec *executionContext) _Query_rollouts(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_rollouts(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9501,7 +14709,7 @@ func (ec *executionContext) _ProcessorType_spec(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Spec, nil
+		return ec.resolvers.Query().Rollouts(rctx)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9513,36 +14721,58 @@ func (ec *executionContext) _ProcessorType_spec(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model1.ResourceTypeSpec)
+	res := resTmp.([]*model1.Rollout)
 	fc.Result = res
-	return ec.marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceTypeSpec(ctx, field.Selections, res)
+	return ec.marshalNRollout2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ProcessorType_spec(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_rollouts(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ProcessorType",
+		Object:     "Query",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "version":
-				return ec.fieldContext_ResourceTypeSpec_version(ctx, field)
-			case "parameters":
-				return ec.fieldContext_ResourceTypeSpec_parameters(ctx, field)
-			case "supportedPlatforms":
-				return ec.fieldContext_ResourceTypeSpec_supportedPlatforms(ctx, field)
-			case "telemetryTypes":
-				return ec.fieldContext_ResourceTypeSpec_telemetryTypes(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ResourceTypeSpec", field.Name)
+		IsMethod:   true,
+		IsResolver: true,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			switch field.Name {
+			case "name":
+				return ec.fieldContext_Rollout_name(ctx, field)
+			case "revision":
+				return ec.fieldContext_Rollout_revision(ctx, field)
+			case "previousRevision":
+				return ec.fieldContext_Rollout_previousRevision(ctx, field)
+			case "rollbackRevision":
+				return ec.fieldContext_Rollout_rollbackRevision(ctx, field)
+			case "status":
+				return ec.fieldContext_Rollout_status(ctx, field)
+			case "options":
+				return ec.fieldContext_Rollout_options(ctx, field)
+			case "message":
+				return ec.fieldContext_Rollout_message(ctx, field)
+			case "wave":
+				return ec.fieldContext_Rollout_wave(ctx, field)
+			case "waveStartedAt":
+				return ec.fieldContext_Rollout_waveStartedAt(ctx, field)
+			case "startedAt":
+				return ec.fieldContext_Rollout_startedAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Rollout_updatedAt(ctx, field)
+			case "agentIds":
+				return ec.fieldContext_Rollout_agentIds(ctx, field)
+			case "pending":
+				return ec.fieldContext_Rollout_pending(ctx, field)
+			case "applied":
+				return ec.fieldContext_Rollout_applied(ctx, field)
+			case "failed":
+				return ec.fieldContext_Rollout_failed(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Rollout", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_overviewPage(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_overviewPage(ctx, field)
+func (ec *executionContext) _Query_rollout(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_rollout(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9555,24 +14785,21 @@ func (ec *executionContext) _Query_overviewPage(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().OverviewPage(rctx, fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string), fc.Args["period"].(string), fc.Args["telemetryType"].(string))
+		return ec.resolvers.Query().Rollout(rctx, fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.OverviewPage)
+	res := resTmp.(*model1.Rollout)
 	fc.Result = res
-	return ec.marshalNOverviewPage2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐOverviewPage(ctx, field.Selections, res)
+	return ec.marshalORollout2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_overviewPage(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_rollout(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
@@ -9580,10 +14807,38 @@ func (ec *executionContext) fieldContext_Query_overviewPage(ctx context.Context,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "graph":
-				return ec.fieldContext_OverviewPage_graph(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type OverviewPage", field.Name)
+			case "name":
+				return ec.fieldContext_Rollout_name(ctx, field)
+			case "revision":
+				return ec.fieldContext_Rollout_revision(ctx, field)
+			case "previousRevision":
+				return ec.fieldContext_Rollout_previousRevision(ctx, field)
+			case "rollbackRevision":
+				return ec.fieldContext_Rollout_rollbackRevision(ctx, field)
+			case "status":
+				return ec.fieldContext_Rollout_status(ctx, field)
+			case "options":
+				return ec.fieldContext_Rollout_options(ctx, field)
+			case "message":
+				return ec.fieldContext_Rollout_message(ctx, field)
+			case "wave":
+				return ec.fieldContext_Rollout_wave(ctx, field)
+			case "waveStartedAt":
+				return ec.fieldContext_Rollout_waveStartedAt(ctx, field)
+			case "startedAt":
+				return ec.fieldContext_Rollout_startedAt(ctx, field)
+			case "updatedAt":
+				return ec.fieldContext_Rollout_updatedAt(ctx, field)
+			case "agentIds":
+				return ec.fieldContext_Rollout_agentIds(ctx, field)
+			case "pending":
+				return ec.fieldContext_Rollout_pending(ctx, field)
+			case "applied":
+				return ec.fieldContext_Rollout_applied(ctx, field)
+			case "failed":
+				return ec.fieldContext_Rollout_failed(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type Rollout", field.Name)
 		},
 	}
 	defer func() {
@@ -9593,15 +14848,15 @@ func (ec *executionContext) fieldContext_Query_overviewPage(ctx context.Context,
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_overviewPage_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Query_rollout_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_agents(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_agents(ctx, field)
+func (ec *executionContext) _Query_agentMetrics(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_agentMetrics(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9614,7 +14869,7 @@ func (ec *executionContext) _Query_agents(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Agents(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string))
+		return ec.resolvers.Query().AgentMetrics(rctx, fc.Args["period"].(string), fc.Args["ids"].([]string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9626,12 +14881,12 @@ func (ec *executionContext) _Query_agents(ctx context.Context, field graphql.Col
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Agents)
+	res := resTmp.(*model.GraphMetrics)
 	fc.Result = res
-	return ec.marshalNAgents2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐAgents(ctx, field.Selections, res)
+	return ec.marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐGraphMetrics(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_agents(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_agentMetrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
@@ -9639,16 +14894,10 @@ func (ec *executionContext) fieldContext_Query_agents(ctx context.Context, field
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "query":
-				return ec.fieldContext_Agents_query(ctx, field)
-			case "agents":
-				return ec.fieldContext_Agents_agents(ctx, field)
-			case "suggestions":
-				return ec.fieldContext_Agents_suggestions(ctx, field)
-			case "latestVersion":
-				return ec.fieldContext_Agents_latestVersion(ctx, field)
+			case "metrics":
+				return ec.fieldContext_GraphMetrics_metrics(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Agents", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type GraphMetrics", field.Name)
 		},
 	}
 	defer func() {
@@ -9658,15 +14907,15 @@ func (ec *executionContext) fieldContext_Query_agents(ctx context.Context, field
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_agents_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Query_agentMetrics_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_agent(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_agent(ctx, field)
+func (ec *executionContext) _Query_configurationMetrics(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_configurationMetrics(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9679,21 +14928,24 @@ func (ec *executionContext) _Query_agent(ctx context.Context, field graphql.Coll
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Agent(rctx, fc.Args["id"].(string))
+		return ec.resolvers.Query().ConfigurationMetrics(rctx, fc.Args["period"].(string), fc.Args["name"].(*string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Agent)
+	res := resTmp.(*model.GraphMetrics)
 	fc.Result = res
-	return ec.marshalOAgent2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgent(ctx, field.Selections, res)
+	return ec.marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐGraphMetrics(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_agent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_configurationMetrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
@@ -9701,50 +14953,10 @@ func (ec *executionContext) fieldContext_Query_agent(ctx context.Context, field
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "id":
-				return ec.fieldContext_Agent_id(ctx, field)
-			case "architecture":
-				return ec.fieldContext_Agent_architecture(ctx, field)
-			case "hostName":
-				return ec.fieldContext_Agent_hostName(ctx, field)
-			case "labels":
-				return ec.fieldContext_Agent_labels(ctx, field)
-			case "platform":
-				return ec.fieldContext_Agent_platform(ctx, field)
-			case "operatingSystem":
-				return ec.fieldContext_Agent_operatingSystem(ctx, field)
-			case "version":
-				return ec.fieldContext_Agent_version(ctx, field)
-			case "name":
-				return ec.fieldContext_Agent_name(ctx, field)
-			case "home":
-				return ec.fieldContext_Agent_home(ctx, field)
-			case "macAddress":
-				return ec.fieldContext_Agent_macAddress(ctx, field)
-			case "remoteAddress":
-				return ec.fieldContext_Agent_remoteAddress(ctx, field)
-			case "type":
-				return ec.fieldContext_Agent_type(ctx, field)
-			case "status":
-				return ec.fieldContext_Agent_status(ctx, field)
-			case "errorMessage":
-				return ec.fieldContext_Agent_errorMessage(ctx, field)
-			case "connectedAt":
-				return ec.fieldContext_Agent_connectedAt(ctx, field)
-			case "disconnectedAt":
-				return ec.fieldContext_Agent_disconnectedAt(ctx, field)
-			case "configuration":
-				return ec.fieldContext_Agent_configuration(ctx, field)
-			case "configurationResource":
-				return ec.fieldContext_Agent_configurationResource(ctx, field)
-			case "upgrade":
-				return ec.fieldContext_Agent_upgrade(ctx, field)
-			case "upgradeAvailable":
-				return ec.fieldContext_Agent_upgradeAvailable(ctx, field)
-			case "features":
-				return ec.fieldContext_Agent_features(ctx, field)
+			case "metrics":
+				return ec.fieldContext_GraphMetrics_metrics(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Agent", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type GraphMetrics", field.Name)
 		},
 	}
 	defer func() {
@@ -9754,15 +14966,15 @@ func (ec *executionContext) fieldContext_Query_agent(ctx context.Context, field
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_agent_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Query_configurationMetrics_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_configurations(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_configurations(ctx, field)
+func (ec *executionContext) _Query_overviewMetrics(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query_overviewMetrics(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9775,7 +14987,7 @@ func (ec *executionContext) _Query_configurations(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Configurations(rctx, fc.Args["selector"].(*string), fc.Args["query"].(*string), fc.Args["onlyDeployedConfigurations"].(*bool))
+		return ec.resolvers.Query().OverviewMetrics(rctx, fc.Args["period"].(string), fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9787,12 +14999,12 @@ func (ec *executionContext) _Query_configurations(ctx context.Context, field gra
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Configurations)
+	res := resTmp.(*model.GraphMetrics)
 	fc.Result = res
-	return ec.marshalNConfigurations2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐConfigurations(ctx, field.Selections, res)
+	return ec.marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐGraphMetrics(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_configurations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query_overviewMetrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
@@ -9800,14 +15012,10 @@ func (ec *executionContext) fieldContext_Query_configurations(ctx context.Contex
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "query":
-				return ec.fieldContext_Configurations_query(ctx, field)
-			case "configurations":
-				return ec.fieldContext_Configurations_configurations(ctx, field)
-			case "suggestions":
-				return ec.fieldContext_Configurations_suggestions(ctx, field)
+			case "metrics":
+				return ec.fieldContext_GraphMetrics_metrics(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Configurations", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type GraphMetrics", field.Name)
 		},
 	}
 	defer func() {
@@ -9817,15 +15025,15 @@ func (ec *executionContext) fieldContext_Query_configurations(ctx context.Contex
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_configurations_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Query_overviewMetrics_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_configuration(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_configuration(ctx, field)
+func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query___type(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9838,7 +15046,7 @@ func (ec *executionContext) _Query_configuration(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Configuration(rctx, fc.Args["name"].(string))
+		return ec.introspectType(fc.Args["name"].(string))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -9847,35 +15055,41 @@ func (ec *executionContext) _Query_configuration(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Configuration)
+	res := resTmp.(*introspection.Type)
 	fc.Result = res
-	return ec.marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfiguration(ctx, field.Selections, res)
+	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_configuration(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: true,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Configuration_apiVersion(ctx, field)
 			case "kind":
-				return ec.fieldContext_Configuration_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Configuration_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Configuration_spec(ctx, field)
-			case "agentCount":
-				return ec.fieldContext_Configuration_agentCount(ctx, field)
-			case "graph":
-				return ec.fieldContext_Configuration_graph(ctx, field)
-			case "rendered":
-				return ec.fieldContext_Configuration_rendered(ctx, field)
+				return ec.fieldContext___Type_kind(ctx, field)
+			case "name":
+				return ec.fieldContext___Type_name(ctx, field)
+			case "description":
+				return ec.fieldContext___Type_description(ctx, field)
+			case "fields":
+				return ec.fieldContext___Type_fields(ctx, field)
+			case "interfaces":
+				return ec.fieldContext___Type_interfaces(ctx, field)
+			case "possibleTypes":
+				return ec.fieldContext___Type_possibleTypes(ctx, field)
+			case "enumValues":
+				return ec.fieldContext___Type_enumValues(ctx, field)
+			case "inputFields":
+				return ec.fieldContext___Type_inputFields(ctx, field)
+			case "ofType":
+				return ec.fieldContext___Type_ofType(ctx, field)
+			case "specifiedByURL":
+				return ec.fieldContext___Type_specifiedByURL(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Configuration", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
 		},
 	}
 	defer func() {
@@ -9885,15 +15099,15 @@ func (ec *executionContext) fieldContext_Query_configuration(ctx context.Context
 		}
 	}()
 	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_configuration_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
 		ec.Error(ctx, err)
 		return
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_sources(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_sources(ctx, field)
+func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Query___schema(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9906,48 +15120,49 @@ func (ec *executionContext) _Query_sources(ctx context.Context, field graphql.Co
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Sources(rctx)
+		return ec.introspectSchema()
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Source)
+	res := resTmp.(*introspection.Schema)
 	fc.Result = res
-	return ec.marshalNSource2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceᚄ(ctx, field.Selections, res)
+	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_sources(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Query___schema(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
 		Object:     "Query",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: true,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Source_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Source_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Source_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Source_spec(ctx, field)
+			case "description":
+				return ec.fieldContext___Schema_description(ctx, field)
+			case "types":
+				return ec.fieldContext___Schema_types(ctx, field)
+			case "queryType":
+				return ec.fieldContext___Schema_queryType(ctx, field)
+			case "mutationType":
+				return ec.fieldContext___Schema_mutationType(ctx, field)
+			case "subscriptionType":
+				return ec.fieldContext___Schema_subscriptionType(ctx, field)
+			case "directives":
+				return ec.fieldContext___Schema_directives(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type Source", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_source(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_source(ctx, field)
+func (ec *executionContext) _RelevantIfCondition_name(ctx context.Context, field graphql.CollectedField, obj *model1.RelevantIfCondition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RelevantIfCondition_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -9960,56 +15175,38 @@ func (ec *executionContext) _Query_source(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Source(rctx, fc.Args["name"].(string))
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Source)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOSource2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSource(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_source(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RelevantIfCondition_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "RelevantIfCondition",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
-		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Source_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Source_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Source_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Source_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Source", field.Name)
+		IsMethod:   false,
+		IsResolver: false,
+		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_source_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_sourceTypes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_sourceTypes(ctx, field)
+func (ec *executionContext) _RelevantIfCondition_operator(ctx context.Context, field graphql.CollectedField, obj *model1.RelevantIfCondition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RelevantIfCondition_operator(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10022,7 +15219,7 @@ func (ec *executionContext) _Query_sourceTypes(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().SourceTypes(rctx)
+		return ec.resolvers.RelevantIfCondition().Operator(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10034,36 +15231,26 @@ func (ec *executionContext) _Query_sourceTypes(ctx context.Context, field graphq
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.SourceType)
+	res := resTmp.(model.RelevantIfOperatorType)
 	fc.Result = res
-	return ec.marshalNSourceType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceTypeᚄ(ctx, field.Selections, res)
+	return ec.marshalNRelevantIfOperatorType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐRelevantIfOperatorType(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_sourceTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RelevantIfCondition_operator(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "RelevantIfCondition",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_SourceType_apiVersion(ctx, field)
-			case "metadata":
-				return ec.fieldContext_SourceType_metadata(ctx, field)
-			case "kind":
-				return ec.fieldContext_SourceType_kind(ctx, field)
-			case "spec":
-				return ec.fieldContext_SourceType_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type SourceType", field.Name)
+			return nil, errors.New("field of type RelevantIfOperatorType does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_sourceType(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_sourceType(ctx, field)
+func (ec *executionContext) _RelevantIfCondition_value(ctx context.Context, field graphql.CollectedField, obj *model1.RelevantIfCondition) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RelevantIfCondition_value(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10076,56 +15263,38 @@ func (ec *executionContext) _Query_sourceType(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().SourceType(rctx, fc.Args["name"].(string))
+		return obj.Value, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.SourceType)
+	res := resTmp.(any)
 	fc.Result = res
-	return ec.marshalOSourceType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSourceType(ctx, field.Selections, res)
+	return ec.marshalNAny2interface(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_sourceType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RelevantIfCondition_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "RelevantIfCondition",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_SourceType_apiVersion(ctx, field)
-			case "metadata":
-				return ec.fieldContext_SourceType_metadata(ctx, field)
-			case "kind":
-				return ec.fieldContext_SourceType_kind(ctx, field)
-			case "spec":
-				return ec.fieldContext_SourceType_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type SourceType", field.Name)
+			return nil, errors.New("field of type Any does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_sourceType_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_processors(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_processors(ctx, field)
+func (ec *executionContext) _ResourceConfiguration_name(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceConfiguration_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10138,48 +15307,35 @@ func (ec *executionContext) _Query_processors(ctx context.Context, field graphql
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Processors(rctx)
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Processor)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNProcessor2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorᚄ(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_processors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ResourceConfiguration_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ResourceConfiguration",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Processor_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Processor_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Processor_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Processor_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Processor", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_processor(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_processor(ctx, field)
+func (ec *executionContext) _ResourceConfiguration_type(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceConfiguration_type(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10192,7 +15348,7 @@ func (ec *executionContext) _Query_processor(ctx context.Context, field graphql.
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Processor(rctx, fc.Args["name"].(string))
+		return obj.Type, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10201,47 +15357,26 @@ func (ec *executionContext) _Query_processor(ctx context.Context, field graphql.
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Processor)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalOProcessor2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessor(ctx, field.Selections, res)
+	return ec.marshalOString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_processor(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ResourceConfiguration_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ResourceConfiguration",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Processor_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Processor_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Processor_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Processor_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Processor", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_processor_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_processorTypes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_processorTypes(ctx, field)
+func (ec *executionContext) _ResourceConfiguration_parameters(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10254,48 +15389,41 @@ func (ec *executionContext) _Query_processorTypes(ctx context.Context, field gra
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ProcessorTypes(rctx)
+		return obj.Parameters, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
-		if !graphql.HasFieldError(ctx, fc) {
-			ec.Errorf(ctx, "must not be null")
-		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.ProcessorType)
+	res := resTmp.([]model1.Parameter)
 	fc.Result = res
-	return ec.marshalNProcessorType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorTypeᚄ(ctx, field.Selections, res)
+	return ec.marshalOParameter2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_processorTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ResourceConfiguration_parameters(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ResourceConfiguration",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_ProcessorType_apiVersion(ctx, field)
-			case "metadata":
-				return ec.fieldContext_ProcessorType_metadata(ctx, field)
-			case "kind":
-				return ec.fieldContext_ProcessorType_kind(ctx, field)
-			case "spec":
-				return ec.fieldContext_ProcessorType_spec(ctx, field)
+			case "name":
+				return ec.fieldContext_Parameter_name(ctx, field)
+			case "value":
+				return ec.fieldContext_Parameter_value(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ProcessorType", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type Parameter", field.Name)
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_processorType(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_processorType(ctx, field)
+func (ec *executionContext) _ResourceConfiguration_processors(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceConfiguration_processors(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10308,7 +15436,7 @@ func (ec *executionContext) _Query_processorType(ctx context.Context, field grap
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ProcessorType(rctx, fc.Args["name"].(string))
+		return obj.Processors, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10317,47 +15445,38 @@ func (ec *executionContext) _Query_processorType(ctx context.Context, field grap
 	if resTmp == nil {
 		return graphql.Null
 	}
-	res := resTmp.(*model1.ProcessorType)
+	res := resTmp.([]model1.ResourceConfiguration)
 	fc.Result = res
-	return ec.marshalOProcessorType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessorType(ctx, field.Selections, res)
+	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_processorType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ResourceConfiguration_processors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ResourceConfiguration",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_ProcessorType_apiVersion(ctx, field)
-			case "metadata":
-				return ec.fieldContext_ProcessorType_metadata(ctx, field)
-			case "kind":
-				return ec.fieldContext_ProcessorType_kind(ctx, field)
-			case "spec":
-				return ec.fieldContext_ProcessorType_spec(ctx, field)
+			case "name":
+				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
+			case "type":
+				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
+			case "parameters":
+				return ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
+			case "processors":
+				return ec.fieldContext_ResourceConfiguration_processors(ctx, field)
+			case "disabled":
+				return ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type ProcessorType", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ResourceConfiguration", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_processorType_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_destinations(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_destinations(ctx, field)
+func (ec *executionContext) _ResourceConfiguration_disabled(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10370,7 +15489,7 @@ func (ec *executionContext) _Query_destinations(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Destinations(rctx)
+		return obj.Disabled, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10382,36 +15501,26 @@ func (ec *executionContext) _Query_destinations(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Destination)
+	res := resTmp.(bool)
 	fc.Result = res
-	return ec.marshalNDestination2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationᚄ(ctx, field.Selections, res)
+	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_destinations(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ResourceConfiguration_disabled(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ResourceConfiguration",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Destination_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Destination_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Destination_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Destination_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Destination", field.Name)
+			return nil, errors.New("field of type Boolean does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_destination(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_destination(ctx, field)
+func (ec *executionContext) _ResourceTypeSpec_version(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceTypeSpec_version(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10424,56 +15533,38 @@ func (ec *executionContext) _Query_destination(ctx context.Context, field graphq
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Destination(rctx, fc.Args["name"].(string))
+		return obj.Version, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.Destination)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalODestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_destination(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ResourceTypeSpec_version(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ResourceTypeSpec",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Destination_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Destination_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Destination_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Destination_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Destination", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_destination_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_destinationWithType(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_destinationWithType(ctx, field)
+func (ec *executionContext) _ResourceTypeSpec_parameters(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceTypeSpec_parameters(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10486,7 +15577,7 @@ func (ec *executionContext) _Query_destinationWithType(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DestinationWithType(rctx, fc.Args["name"].(string))
+		return obj.Parameters, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10498,43 +15589,50 @@ func (ec *executionContext) _Query_destinationWithType(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.DestinationWithType)
+	res := resTmp.([]model1.ParameterDefinition)
 	fc.Result = res
-	return ec.marshalNDestinationWithType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐDestinationWithType(ctx, field.Selections, res)
+	return ec.marshalNParameterDefinition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterDefinitionᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_destinationWithType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ResourceTypeSpec_parameters(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ResourceTypeSpec",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "destination":
-				return ec.fieldContext_DestinationWithType_destination(ctx, field)
-			case "destinationType":
-				return ec.fieldContext_DestinationWithType_destinationType(ctx, field)
+			case "name":
+				return ec.fieldContext_ParameterDefinition_name(ctx, field)
+			case "label":
+				return ec.fieldContext_ParameterDefinition_label(ctx, field)
+			case "description":
+				return ec.fieldContext_ParameterDefinition_description(ctx, field)
+			case "required":
+				return ec.fieldContext_ParameterDefinition_required(ctx, field)
+			case "type":
+				return ec.fieldContext_ParameterDefinition_type(ctx, field)
+			case "validValues":
+				return ec.fieldContext_ParameterDefinition_validValues(ctx, field)
+			case "default":
+				return ec.fieldContext_ParameterDefinition_default(ctx, field)
+			case "relevantIf":
+				return ec.fieldContext_ParameterDefinition_relevantIf(ctx, field)
+			case "advancedConfig":
+				return ec.fieldContext_ParameterDefinition_advancedConfig(ctx, field)
+			case "options":
+				return ec.fieldContext_ParameterDefinition_options(ctx, field)
+			case "documentation":
+				return ec.fieldContext_ParameterDefinition_documentation(ctx, field)
 			}
-			return nil, fmt.Errorf("no field named %q was found under type DestinationWithType", field.Name)
+			return nil, fmt.Errorf("no field named %q was found under type ParameterDefinition", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_destinationWithType_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_destinationsInConfigs(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_destinationsInConfigs(ctx, field)
+func (ec *executionContext) _ResourceTypeSpec_supportedPlatforms(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceTypeSpec_supportedPlatforms(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10547,7 +15645,7 @@ func (ec *executionContext) _Query_destinationsInConfigs(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DestinationsInConfigs(rctx)
+		return obj.SupportedPlatforms, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10559,36 +15657,26 @@ func (ec *executionContext) _Query_destinationsInConfigs(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.Destination)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalNDestination2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_destinationsInConfigs(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ResourceTypeSpec_supportedPlatforms(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ResourceTypeSpec",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_Destination_apiVersion(ctx, field)
-			case "kind":
-				return ec.fieldContext_Destination_kind(ctx, field)
-			case "metadata":
-				return ec.fieldContext_Destination_metadata(ctx, field)
-			case "spec":
-				return ec.fieldContext_Destination_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Destination", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_destinationTypes(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_destinationTypes(ctx, field)
+func (ec *executionContext) _ResourceTypeSpec_telemetryTypes(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_ResourceTypeSpec_telemetryTypes(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10601,7 +15689,7 @@ func (ec *executionContext) _Query_destinationTypes(ctx context.Context, field g
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DestinationTypes(rctx)
+		return obj.TelemetryTypes(), nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10613,36 +15701,26 @@ func (ec *executionContext) _Query_destinationTypes(ctx context.Context, field g
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]*model1.DestinationType)
+	res := resTmp.([]otel.PipelineType)
 	fc.Result = res
-	return ec.marshalNDestinationType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationTypeᚄ(ctx, field.Selections, res)
+	return ec.marshalNPipelineType2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋotelᚐPipelineTypeᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_destinationTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_ResourceTypeSpec_telemetryTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "ResourceTypeSpec",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: true,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_DestinationType_apiVersion(ctx, field)
-			case "metadata":
-				return ec.fieldContext_DestinationType_metadata(ctx, field)
-			case "kind":
-				return ec.fieldContext_DestinationType_kind(ctx, field)
-			case "spec":
-				return ec.fieldContext_DestinationType_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type DestinationType", field.Name)
+			return nil, errors.New("field of type PipelineType does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_destinationType(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_destinationType(ctx, field)
+func (ec *executionContext) _Rollout_name(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_name(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10655,56 +15733,38 @@ func (ec *executionContext) _Query_destinationType(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().DestinationType(rctx, fc.Args["name"].(string))
+		return obj.Name, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*model1.DestinationType)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalODestinationType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestinationType(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_destinationType(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "apiVersion":
-				return ec.fieldContext_DestinationType_apiVersion(ctx, field)
-			case "metadata":
-				return ec.fieldContext_DestinationType_metadata(ctx, field)
-			case "kind":
-				return ec.fieldContext_DestinationType_kind(ctx, field)
-			case "spec":
-				return ec.fieldContext_DestinationType_spec(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type DestinationType", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_destinationType_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_snapshot(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_snapshot(ctx, field)
+func (ec *executionContext) _Rollout_revision(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_revision(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10717,7 +15777,7 @@ func (ec *executionContext) _Query_snapshot(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().Snapshot(rctx, fc.Args["agentID"].(string), fc.Args["pipelineType"].(otel.PipelineType))
+		return obj.Revision, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10729,45 +15789,26 @@ func (ec *executionContext) _Query_snapshot(ctx context.Context, field graphql.C
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.Snapshot)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNSnapshot2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐSnapshot(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_snapshot(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_revision(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "logs":
-				return ec.fieldContext_Snapshot_logs(ctx, field)
-			case "metrics":
-				return ec.fieldContext_Snapshot_metrics(ctx, field)
-			case "traces":
-				return ec.fieldContext_Snapshot_traces(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Snapshot", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_snapshot_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_agentMetrics(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_agentMetrics(ctx, field)
+func (ec *executionContext) _Rollout_previousRevision(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_previousRevision(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10780,7 +15821,7 @@ func (ec *executionContext) _Query_agentMetrics(ctx context.Context, field graph
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().AgentMetrics(rctx, fc.Args["period"].(string), fc.Args["ids"].([]string))
+		return obj.PreviousRevision, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10792,41 +15833,26 @@ func (ec *executionContext) _Query_agentMetrics(ctx context.Context, field graph
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.GraphMetrics)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐGraphMetrics(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_agentMetrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_previousRevision(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "metrics":
-				return ec.fieldContext_GraphMetrics_metrics(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type GraphMetrics", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_agentMetrics_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_configurationMetrics(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_configurationMetrics(ctx, field)
+func (ec *executionContext) _Rollout_rollbackRevision(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_rollbackRevision(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10839,7 +15865,7 @@ func (ec *executionContext) _Query_configurationMetrics(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().ConfigurationMetrics(rctx, fc.Args["period"].(string), fc.Args["name"].(*string))
+		return obj.RollbackRevision, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10851,41 +15877,26 @@ func (ec *executionContext) _Query_configurationMetrics(ctx context.Context, fie
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.GraphMetrics)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐGraphMetrics(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_configurationMetrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_rollbackRevision(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "metrics":
-				return ec.fieldContext_GraphMetrics_metrics(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type GraphMetrics", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_configurationMetrics_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query_overviewMetrics(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query_overviewMetrics(ctx, field)
+func (ec *executionContext) _Rollout_status(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_status(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10898,7 +15909,7 @@ func (ec *executionContext) _Query_overviewMetrics(ctx context.Context, field gr
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.Query().OverviewMetrics(rctx, fc.Args["period"].(string), fc.Args["configIDs"].([]string), fc.Args["destinationIDs"].([]string))
+		return ec.resolvers.Rollout().Status(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -10910,41 +15921,26 @@ func (ec *executionContext) _Query_overviewMetrics(ctx context.Context, field gr
 		}
 		return graphql.Null
 	}
-	res := resTmp.(*model.GraphMetrics)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNGraphMetrics2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐGraphMetrics(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query_overviewMetrics(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_status(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Rollout",
 		Field:      field,
 		IsMethod:   true,
 		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "metrics":
-				return ec.fieldContext_GraphMetrics_metrics(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type GraphMetrics", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query_overviewMetrics_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___type(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query___type(ctx, field)
+func (ec *executionContext) _Rollout_options(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_options(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -10957,68 +15953,48 @@ func (ec *executionContext) _Query___type(ctx context.Context, field graphql.Col
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectType(fc.Args["name"].(string))
+		return obj.Options, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Type)
+	res := resTmp.(model1.RolloutOptions)
 	fc.Result = res
-	return ec.marshalO__Type2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐType(ctx, field.Selections, res)
+	return ec.marshalNRolloutOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutOptions(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query___type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_options(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			switch field.Name {
-			case "kind":
-				return ec.fieldContext___Type_kind(ctx, field)
-			case "name":
-				return ec.fieldContext___Type_name(ctx, field)
-			case "description":
-				return ec.fieldContext___Type_description(ctx, field)
-			case "fields":
-				return ec.fieldContext___Type_fields(ctx, field)
-			case "interfaces":
-				return ec.fieldContext___Type_interfaces(ctx, field)
-			case "possibleTypes":
-				return ec.fieldContext___Type_possibleTypes(ctx, field)
-			case "enumValues":
-				return ec.fieldContext___Type_enumValues(ctx, field)
-			case "inputFields":
-				return ec.fieldContext___Type_inputFields(ctx, field)
-			case "ofType":
-				return ec.fieldContext___Type_ofType(ctx, field)
-			case "specifiedByURL":
-				return ec.fieldContext___Type_specifiedByURL(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Type", field.Name)
+			case "batchSize":
+				return ec.fieldContext_RolloutOptions_batchSize(ctx, field)
+			case "batchPercent":
+				return ec.fieldContext_RolloutOptions_batchPercent(ctx, field)
+			case "maxErrorPercent":
+				return ec.fieldContext_RolloutOptions_maxErrorPercent(ctx, field)
+			case "onError":
+				return ec.fieldContext_RolloutOptions_onError(ctx, field)
+			}
+			return nil, fmt.Errorf("no field named %q was found under type RolloutOptions", field.Name)
 		},
 	}
-	defer func() {
-		if r := recover(); r != nil {
-			err = ec.Recover(ctx, r)
-			ec.Error(ctx, err)
-		}
-	}()
-	ctx = graphql.WithFieldContext(ctx, fc)
-	if fc.Args, err = ec.field_Query___type_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
-		ec.Error(ctx, err)
-		return
-	}
 	return fc, nil
 }
 
-func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.CollectedField) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_Query___schema(ctx, field)
+func (ec *executionContext) _Rollout_message(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_message(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11031,49 +16007,38 @@ func (ec *executionContext) _Query___schema(ctx context.Context, field graphql.C
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.introspectSchema()
+		return obj.Message, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(*introspection.Schema)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalO__Schema2ᚖgithubᚗcomᚋ99designsᚋgqlgenᚋgraphqlᚋintrospectionᚐSchema(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_Query___schema(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_message(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "Query",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   true,
+		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "description":
-				return ec.fieldContext___Schema_description(ctx, field)
-			case "types":
-				return ec.fieldContext___Schema_types(ctx, field)
-			case "queryType":
-				return ec.fieldContext___Schema_queryType(ctx, field)
-			case "mutationType":
-				return ec.fieldContext___Schema_mutationType(ctx, field)
-			case "subscriptionType":
-				return ec.fieldContext___Schema_subscriptionType(ctx, field)
-			case "directives":
-				return ec.fieldContext___Schema_directives(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type __Schema", field.Name)
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _RelevantIfCondition_name(ctx context.Context, field graphql.CollectedField, obj *model1.RelevantIfCondition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_RelevantIfCondition_name(ctx, field)
+func (ec *executionContext) _Rollout_wave(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_wave(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11086,7 +16051,7 @@ func (ec *executionContext) _RelevantIfCondition_name(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.Wave, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11098,26 +16063,26 @@ func (ec *executionContext) _RelevantIfCondition_name(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_RelevantIfCondition_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_wave(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "RelevantIfCondition",
+		Object:     "Rollout",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _RelevantIfCondition_operator(ctx context.Context, field graphql.CollectedField, obj *model1.RelevantIfCondition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_RelevantIfCondition_operator(ctx, field)
+func (ec *executionContext) _Rollout_waveStartedAt(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_waveStartedAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11130,7 +16095,7 @@ func (ec *executionContext) _RelevantIfCondition_operator(ctx context.Context, f
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return ec.resolvers.RelevantIfCondition().Operator(rctx, obj)
+		return obj.WaveStartedAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11142,26 +16107,26 @@ func (ec *executionContext) _RelevantIfCondition_operator(ctx context.Context, f
 		}
 		return graphql.Null
 	}
-	res := resTmp.(model.RelevantIfOperatorType)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalNRelevantIfOperatorType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐRelevantIfOperatorType(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_RelevantIfCondition_operator(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_waveStartedAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "RelevantIfCondition",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   true,
-		IsResolver: true,
+		IsMethod:   false,
+		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type RelevantIfOperatorType does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _RelevantIfCondition_value(ctx context.Context, field graphql.CollectedField, obj *model1.RelevantIfCondition) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_RelevantIfCondition_value(ctx, field)
+func (ec *executionContext) _Rollout_startedAt(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_startedAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11174,7 +16139,7 @@ func (ec *executionContext) _RelevantIfCondition_value(ctx context.Context, fiel
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Value, nil
+		return obj.StartedAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11186,26 +16151,26 @@ func (ec *executionContext) _RelevantIfCondition_value(ctx context.Context, fiel
 		}
 		return graphql.Null
 	}
-	res := resTmp.(any)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalNAny2interface(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_RelevantIfCondition_value(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_startedAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "RelevantIfCondition",
+		Object:     "Rollout",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Any does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_name(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ResourceConfiguration_name(ctx, field)
+func (ec *executionContext) _Rollout_updatedAt(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_updatedAt(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11218,35 +16183,38 @@ func (ec *executionContext) _ResourceConfiguration_name(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Name, nil
+		return obj.UpdatedAt, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(time.Time)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNTime2timeᚐTime(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ResourceConfiguration_name(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_updatedAt(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ResourceConfiguration",
+		Object:     "Rollout",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Time does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_type(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ResourceConfiguration_type(ctx, field)
+func (ec *executionContext) _Rollout_agentIds(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_agentIds(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11259,35 +16227,49 @@ func (ec *executionContext) _ResourceConfiguration_type(ctx context.Context, fie
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Type, nil
+		return ec.resolvers.Rollout().AgentIds(rctx, obj, fc.Args["status"].(*string), fc.Args["wave"].(*int))
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.([]string)
 	fc.Result = res
-	return ec.marshalOString2string(ctx, field.Selections, res)
+	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ResourceConfiguration_type(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_agentIds(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ResourceConfiguration",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
 			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
+	defer func() {
+		if r := recover(); r != nil {
+			err = ec.Recover(ctx, r)
+			ec.Error(ctx, err)
+		}
+	}()
+	ctx = graphql.WithFieldContext(ctx, fc)
+	if fc.Args, err = ec.field_Rollout_agentIds_args(ctx, field.ArgumentMap(ec.Variables)); err != nil {
+		ec.Error(ctx, err)
+		return
+	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_parameters(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
+func (ec *executionContext) _Rollout_pending(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_pending(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11300,41 +16282,38 @@ func (ec *executionContext) _ResourceConfiguration_parameters(ctx context.Contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Parameters, nil
+		return ec.resolvers.Rollout().Pending(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.Parameter)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOParameter2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ResourceConfiguration_parameters(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_pending(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ResourceConfiguration",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "name":
-				return ec.fieldContext_Parameter_name(ctx, field)
-			case "value":
-				return ec.fieldContext_Parameter_value(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type Parameter", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_processors(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ResourceConfiguration_processors(ctx, field)
+func (ec *executionContext) _Rollout_applied(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_applied(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11347,47 +16326,38 @@ func (ec *executionContext) _ResourceConfiguration_processors(ctx context.Contex
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Processors, nil
+		return ec.resolvers.Rollout().Applied(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
 		return graphql.Null
 	}
 	if resTmp == nil {
+		if !graphql.HasFieldError(ctx, fc) {
+			ec.Errorf(ctx, "must not be null")
+		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ResourceConfiguration)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalOResourceConfiguration2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐResourceConfigurationᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ResourceConfiguration_processors(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_applied(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ResourceConfiguration",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "name":
-				return ec.fieldContext_ResourceConfiguration_name(ctx, field)
-			case "type":
-				return ec.fieldContext_ResourceConfiguration_type(ctx, field)
-			case "parameters":
-				return ec.fieldContext_ResourceConfiguration_parameters(ctx, field)
-			case "processors":
-				return ec.fieldContext_ResourceConfiguration_processors(ctx, field)
-			case "disabled":
-				return ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ResourceConfiguration", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceConfiguration_disabled(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceConfiguration) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ResourceConfiguration_disabled(ctx, field)
+func (ec *executionContext) _Rollout_failed(ctx context.Context, field graphql.CollectedField, obj *model1.Rollout) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_Rollout_failed(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11400,7 +16370,7 @@ func (ec *executionContext) _ResourceConfiguration_disabled(ctx context.Context,
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Disabled, nil
+		return ec.resolvers.Rollout().Failed(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11412,26 +16382,26 @@ func (ec *executionContext) _ResourceConfiguration_disabled(ctx context.Context,
 		}
 		return graphql.Null
 	}
-	res := resTmp.(bool)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNBoolean2bool(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ResourceConfiguration_disabled(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_Rollout_failed(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ResourceConfiguration",
+		Object:     "Rollout",
 		Field:      field,
-		IsMethod:   false,
-		IsResolver: false,
+		IsMethod:   true,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type Boolean does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceTypeSpec_version(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ResourceTypeSpec_version(ctx, field)
+func (ec *executionContext) _RolloutOptions_batchSize(ctx context.Context, field graphql.CollectedField, obj *model1.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_batchSize(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11444,7 +16414,7 @@ func (ec *executionContext) _ResourceTypeSpec_version(ctx context.Context, field
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Version, nil
+		return obj.BatchSize, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11456,26 +16426,26 @@ func (ec *executionContext) _ResourceTypeSpec_version(ctx context.Context, field
 		}
 		return graphql.Null
 	}
-	res := resTmp.(string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2string(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ResourceTypeSpec_version(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_batchSize(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ResourceTypeSpec",
+		Object:     "RolloutOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceTypeSpec_parameters(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ResourceTypeSpec_parameters(ctx, field)
+func (ec *executionContext) _RolloutOptions_batchPercent(ctx context.Context, field graphql.CollectedField, obj *model1.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_batchPercent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11488,7 +16458,7 @@ func (ec *executionContext) _ResourceTypeSpec_parameters(ctx context.Context, fi
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.Parameters, nil
+		return obj.BatchPercent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11500,50 +16470,26 @@ func (ec *executionContext) _ResourceTypeSpec_parameters(ctx context.Context, fi
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]model1.ParameterDefinition)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNParameterDefinition2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐParameterDefinitionᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ResourceTypeSpec_parameters(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_batchPercent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ResourceTypeSpec",
+		Object:     "RolloutOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			switch field.Name {
-			case "name":
-				return ec.fieldContext_ParameterDefinition_name(ctx, field)
-			case "label":
-				return ec.fieldContext_ParameterDefinition_label(ctx, field)
-			case "description":
-				return ec.fieldContext_ParameterDefinition_description(ctx, field)
-			case "required":
-				return ec.fieldContext_ParameterDefinition_required(ctx, field)
-			case "type":
-				return ec.fieldContext_ParameterDefinition_type(ctx, field)
-			case "validValues":
-				return ec.fieldContext_ParameterDefinition_validValues(ctx, field)
-			case "default":
-				return ec.fieldContext_ParameterDefinition_default(ctx, field)
-			case "relevantIf":
-				return ec.fieldContext_ParameterDefinition_relevantIf(ctx, field)
-			case "advancedConfig":
-				return ec.fieldContext_ParameterDefinition_advancedConfig(ctx, field)
-			case "options":
-				return ec.fieldContext_ParameterDefinition_options(ctx, field)
-			case "documentation":
-				return ec.fieldContext_ParameterDefinition_documentation(ctx, field)
-			}
-			return nil, fmt.Errorf("no field named %q was found under type ParameterDefinition", field.Name)
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceTypeSpec_supportedPlatforms(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ResourceTypeSpec_supportedPlatforms(ctx, field)
+func (ec *executionContext) _RolloutOptions_maxErrorPercent(ctx context.Context, field graphql.CollectedField, obj *model1.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_maxErrorPercent(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11556,7 +16502,7 @@ func (ec *executionContext) _ResourceTypeSpec_supportedPlatforms(ctx context.Con
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.SupportedPlatforms, nil
+		return obj.MaxErrorPercent, nil
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11568,26 +16514,26 @@ func (ec *executionContext) _ResourceTypeSpec_supportedPlatforms(ctx context.Con
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]string)
+	res := resTmp.(int)
 	fc.Result = res
-	return ec.marshalNString2ᚕstringᚄ(ctx, field.Selections, res)
+	return ec.marshalNInt2int(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ResourceTypeSpec_supportedPlatforms(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_maxErrorPercent(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ResourceTypeSpec",
+		Object:     "RolloutOptions",
 		Field:      field,
 		IsMethod:   false,
 		IsResolver: false,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type String does not have child fields")
+			return nil, errors.New("field of type Int does not have child fields")
 		},
 	}
 	return fc, nil
 }
 
-func (ec *executionContext) _ResourceTypeSpec_telemetryTypes(ctx context.Context, field graphql.CollectedField, obj *model1.ResourceTypeSpec) (ret graphql.Marshaler) {
-	fc, err := ec.fieldContext_ResourceTypeSpec_telemetryTypes(ctx, field)
+func (ec *executionContext) _RolloutOptions_onError(ctx context.Context, field graphql.CollectedField, obj *model1.RolloutOptions) (ret graphql.Marshaler) {
+	fc, err := ec.fieldContext_RolloutOptions_onError(ctx, field)
 	if err != nil {
 		return graphql.Null
 	}
@@ -11600,7 +16546,7 @@ func (ec *executionContext) _ResourceTypeSpec_telemetryTypes(ctx context.Context
 	}()
 	resTmp, err := ec.ResolverMiddleware(ctx, func(rctx context.Context) (interface{}, error) {
 		ctx = rctx // use context from middleware stack in children
-		return obj.TelemetryTypes(), nil
+		return ec.resolvers.RolloutOptions().OnError(rctx, obj)
 	})
 	if err != nil {
 		ec.Error(ctx, err)
@@ -11612,19 +16558,19 @@ func (ec *executionContext) _ResourceTypeSpec_telemetryTypes(ctx context.Context
 		}
 		return graphql.Null
 	}
-	res := resTmp.([]otel.PipelineType)
+	res := resTmp.(string)
 	fc.Result = res
-	return ec.marshalNPipelineType2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋotelᚐPipelineTypeᚄ(ctx, field.Selections, res)
+	return ec.marshalNString2string(ctx, field.Selections, res)
 }
 
-func (ec *executionContext) fieldContext_ResourceTypeSpec_telemetryTypes(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
+func (ec *executionContext) fieldContext_RolloutOptions_onError(ctx context.Context, field graphql.CollectedField) (fc *graphql.FieldContext, err error) {
 	fc = &graphql.FieldContext{
-		Object:     "ResourceTypeSpec",
+		Object:     "RolloutOptions",
 		Field:      field,
 		IsMethod:   true,
-		IsResolver: false,
+		IsResolver: true,
 		Child: func(ctx context.Context, field graphql.CollectedField) (*graphql.FieldContext, error) {
-			return nil, errors.New("field of type PipelineType does not have child fields")
+			return nil, errors.New("field of type String does not have child fields")
 		},
 	}
 	return fc, nil
@@ -11947,6 +16893,10 @@ func (ec *executionContext) fieldContext_Source_metadata(ctx context.Context, fi
 				return ec.fieldContext_Metadata_icon(ctx, field)
 			case "labels":
 				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "revision":
+				return ec.fieldContext_Metadata_revision(ctx, field)
+			case "resourceVersion":
+				return ec.fieldContext_Metadata_resourceVersion(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
 		},
@@ -12103,6 +17053,10 @@ func (ec *executionContext) fieldContext_SourceType_metadata(ctx context.Context
 				return ec.fieldContext_Metadata_icon(ctx, field)
 			case "labels":
 				return ec.fieldContext_Metadata_labels(ctx, field)
+			case "revision":
+				return ec.fieldContext_Metadata_revision(ctx, field)
+			case "resourceVersion":
+				return ec.fieldContext_Metadata_resourceVersion(ctx, field)
 			}
 			return nil, fmt.Errorf("no field named %q was found under type Metadata", field.Name)
 		},
@@ -14861,7 +19815,7 @@ func (ec *executionContext) unmarshalInputUpdateProcessorsInput(ctx context.Cont
 		asMap[k] = v
 	}
 
-	fieldsInOrder := [...]string{"configuration", "resourceType", "resourceIndex", "processors"}
+	fieldsInOrder := [...]string{"configuration", "resourceType", "resourceIndex", "processors", "resourceVersion"}
 	for _, k := range fieldsInOrder {
 		v, ok := asMap[k]
 		if !ok {
@@ -14900,6 +19854,14 @@ func (ec *executionContext) unmarshalInputUpdateProcessorsInput(ctx context.Cont
 			if err != nil {
 				return it, err
 			}
+		case "resourceVersion":
+			var err error
+
+			ctx := graphql.WithPathContext(ctx, graphql.NewPathWithField("resourceVersion"))
+			it.ResourceVersion, err = ec.unmarshalOString2ᚖstring(ctx, v)
+			if err != nil {
+				return it, err
+			}
 		}
 	}
 
@@ -15061,6 +20023,10 @@ func (ec *executionContext) _Agent(ctx context.Context, sel ast.SelectionSet, ob
 
 			out.Values[i] = ec._Agent_upgrade(ctx, field, obj)
 
+		case "restart":
+
+			out.Values[i] = ec._Agent_restart(ctx, field, obj)
+
 		case "upgradeAvailable":
 			field := field
 
@@ -15094,6 +20060,26 @@ func (ec *executionContext) _Agent(ctx context.Context, sel ast.SelectionSet, ob
 				return res
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "history":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Agent_history(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return innerFunc(ctx)
 
@@ -15177,6 +20163,107 @@ func (ec *executionContext) _AgentConfiguration(ctx context.Context, sel ast.Sel
 	return out
 }
 
+var agentHistoryImplementors = []string{"AgentHistory"}
+
+func (ec *executionContext) _AgentHistory(ctx context.Context, sel ast.SelectionSet, obj *model1.AgentHistory) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, agentHistoryImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("AgentHistory")
+		case "start":
+
+			out.Values[i] = ec._AgentHistory_start(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "end":
+
+			out.Values[i] = ec._AgentHistory_end(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "changes":
+
+			out.Values[i] = ec._AgentHistory_changes(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "uptime":
+
+			out.Values[i] = ec._AgentHistory_uptime(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var agentRestartImplementors = []string{"AgentRestart"}
+
+func (ec *executionContext) _AgentRestart(ctx context.Context, sel ast.SelectionSet, obj *model1.AgentRestart) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, agentRestartImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("AgentRestart")
+		case "status":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._AgentRestart_status(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "requestedAt":
+
+			out.Values[i] = ec._AgentRestart_requestedAt(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "error":
+
+			out.Values[i] = ec._AgentRestart_error(ctx, field, obj)
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var agentSelectorImplementors = []string{"AgentSelector"}
 
 func (ec *executionContext) _AgentSelector(ctx context.Context, sel ast.SelectionSet, obj *model1.AgentSelector) graphql.Marshaler {
@@ -15196,7 +20283,62 @@ func (ec *executionContext) _AgentSelector(ctx context.Context, sel ast.Selectio
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._AgentSelector_matchLabels(ctx, field, obj)
+				res = ec._AgentSelector_matchLabels(ctx, field, obj)
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var agentStatusChangeImplementors = []string{"AgentStatusChange"}
+
+func (ec *executionContext) _AgentStatusChange(ctx context.Context, sel ast.SelectionSet, obj *model1.AgentStatusChange) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, agentStatusChangeImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("AgentStatusChange")
+		case "agentId":
+
+			out.Values[i] = ec._AgentStatusChange_agentId(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "timestamp":
+
+			out.Values[i] = ec._AgentStatusChange_timestamp(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "status":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._AgentStatusChange_status(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
 				return res
 			}
 
@@ -15204,6 +20346,10 @@ func (ec *executionContext) _AgentSelector(ctx context.Context, sel ast.Selectio
 				return innerFunc(ctx)
 
 			})
+		case "errorMessage":
+
+			out.Values[i] = ec._AgentStatusChange_errorMessage(ctx, field, obj)
+
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -15310,6 +20456,110 @@ func (ec *executionContext) _Agents(ctx context.Context, sel ast.SelectionSet, o
 	return out
 }
 
+var auditEntryImplementors = []string{"AuditEntry"}
+
+func (ec *executionContext) _AuditEntry(ctx context.Context, sel ast.SelectionSet, obj *model1.AuditEntry) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, auditEntryImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("AuditEntry")
+		case "id":
+
+			out.Values[i] = ec._AuditEntry_id(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "timestamp":
+
+			out.Values[i] = ec._AuditEntry_timestamp(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "user":
+
+			out.Values[i] = ec._AuditEntry_user(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "action":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._AuditEntry_action(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "kind":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._AuditEntry_kind(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "name":
+
+			out.Values[i] = ec._AuditEntry_name(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "before":
+
+			out.Values[i] = ec._AuditEntry_before(ctx, field, obj)
+
+		case "after":
+
+			out.Values[i] = ec._AuditEntry_after(ctx, field, obj)
+
+		case "changes":
+
+			out.Values[i] = ec._AuditEntry_changes(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var configurationImplementors = []string{"Configuration"}
 
 func (ec *executionContext) _Configuration(ctx context.Context, sel ast.SelectionSet, obj *model1.Configuration) graphql.Marshaler {
@@ -15408,6 +20658,23 @@ func (ec *executionContext) _Configuration(ctx context.Context, sel ast.Selectio
 				return res
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "rollout":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Configuration_rollout(ctx, field, obj)
+				return res
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return innerFunc(ctx)
 
@@ -15458,6 +20725,45 @@ func (ec *executionContext) _ConfigurationChange(ctx context.Context, sel ast.Se
 	return out
 }
 
+var configurationRouteImplementors = []string{"ConfigurationRoute"}
+
+func (ec *executionContext) _ConfigurationRoute(ctx context.Context, sel ast.SelectionSet, obj *model1.ConfigurationRoute) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, configurationRouteImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ConfigurationRoute")
+		case "sources":
+
+			out.Values[i] = ec._ConfigurationRoute_sources(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "destinations":
+
+			out.Values[i] = ec._ConfigurationRoute_destinations(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "types":
+
+			out.Values[i] = ec._ConfigurationRoute_types(ctx, field, obj)
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var configurationSpecImplementors = []string{"ConfigurationSpec"}
 
 func (ec *executionContext) _ConfigurationSpec(ctx context.Context, sel ast.SelectionSet, obj *model1.ConfigurationSpec) graphql.Marshaler {
@@ -15488,6 +20794,18 @@ func (ec *executionContext) _ConfigurationSpec(ctx context.Context, sel ast.Sele
 
 			out.Values[i] = ec._ConfigurationSpec_selector(ctx, field, obj)
 
+		case "routes":
+
+			out.Values[i] = ec._ConfigurationSpec_routes(ctx, field, obj)
+
+		case "extensions":
+
+			out.Values[i] = ec._ConfigurationSpec_extensions(ctx, field, obj)
+
+		case "serviceTelemetry":
+
+			out.Values[i] = ec._ConfigurationSpec_serviceTelemetry(ctx, field, obj)
+
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -15765,6 +21083,68 @@ func (ec *executionContext) _Edge(ctx context.Context, sel ast.SelectionSet, obj
 	return out
 }
 
+var extensionTypeImplementors = []string{"ExtensionType"}
+
+func (ec *executionContext) _ExtensionType(ctx context.Context, sel ast.SelectionSet, obj *model1.ExtensionType) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, extensionTypeImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ExtensionType")
+		case "apiVersion":
+
+			out.Values[i] = ec._ExtensionType_apiVersion(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "metadata":
+
+			out.Values[i] = ec._ExtensionType_metadata(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "kind":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._ExtensionType_kind(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "spec":
+
+			out.Values[i] = ec._ExtensionType_spec(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
 var graphImplementors = []string{"Graph"}
 
 func (ec *executionContext) _Graph(ctx context.Context, sel ast.SelectionSet, obj *graph.Graph) graphql.Marshaler {
@@ -15999,6 +21379,27 @@ func (ec *executionContext) _Metadata(ctx context.Context, sel ast.SelectionSet,
 				return res
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
+
+			})
+		case "revision":
+
+			out.Values[i] = ec._Metadata_revision(ctx, field, obj)
+
+		case "resourceVersion":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Metadata_resourceVersion(ctx, field, obj)
+				return res
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return innerFunc(ctx)
 
@@ -16149,23 +21550,65 @@ func (ec *executionContext) _Mutation(ctx context.Context, sel ast.SelectionSet)
 		Object: "Mutation",
 	})
 
-	out := graphql.NewFieldSet(fields)
-	var invalids uint32
-	for i, field := range fields {
-		innerCtx := graphql.WithRootFieldContext(ctx, &graphql.RootFieldContext{
-			Object: field.Name,
-			Field:  field,
-		})
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		innerCtx := graphql.WithRootFieldContext(ctx, &graphql.RootFieldContext{
+			Object: field.Name,
+			Field:  field,
+		})
+
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Mutation")
+		case "updateProcessors":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_updateProcessors(ctx, field)
+			})
+
+		case "rollbackConfiguration":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_rollbackConfiguration(ctx, field)
+			})
+
+		case "restartAgents":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_restartAgents(ctx, field)
+			})
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "pauseRollout":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_pauseRollout(ctx, field)
+			})
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "resumeRollout":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Mutation_resumeRollout(ctx, field)
+			})
 
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("Mutation")
-		case "updateProcessors":
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "abortRollout":
 
 			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Mutation_updateProcessors(ctx, field)
+				return ec._Mutation_abortRollout(ctx, field)
 			})
 
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -16722,6 +22165,29 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
 			}
 
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "configurationRevisions":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_configurationRevisions(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
@@ -16920,7 +22386,228 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "destination":
+		case "destination":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_destination(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "destinationWithType":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_destinationWithType(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "destinationsInConfigs":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_destinationsInConfigs(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "destinationTypes":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_destinationTypes(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "destinationType":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_destinationType(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "extensionTypes":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_extensionTypes(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "extensionType":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_extensionType(ctx, field)
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "snapshot":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_snapshot(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "auditEntries":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_auditEntries(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "rollouts":
+			field := field
+
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._Query_rollouts(ctx, field)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
+			}
+
+			rrm := func(ctx context.Context) graphql.Marshaler {
+				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+			}
+
+			out.Concurrently(i, func() graphql.Marshaler {
+				return rrm(innerCtx)
+			})
+		case "rollout":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -16929,7 +22616,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_destination(ctx, field)
+				res = ec._Query_rollout(ctx, field)
 				return res
 			}
 
@@ -16940,7 +22627,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "destinationWithType":
+		case "agentMetrics":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -16949,7 +22636,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_destinationWithType(ctx, field)
+				res = ec._Query_agentMetrics(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
@@ -16963,7 +22650,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "destinationsInConfigs":
+		case "configurationMetrics":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -16972,7 +22659,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_destinationsInConfigs(ctx, field)
+				res = ec._Query_configurationMetrics(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
@@ -16986,7 +22673,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "destinationTypes":
+		case "overviewMetrics":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -16995,7 +22682,7 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_destinationTypes(ctx, field)
+				res = ec._Query_overviewMetrics(ctx, field)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
@@ -17009,7 +22696,47 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 			out.Concurrently(i, func() graphql.Marshaler {
 				return rrm(innerCtx)
 			})
-		case "destinationType":
+		case "__type":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Query___type(ctx, field)
+			})
+
+		case "__schema":
+
+			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
+				return ec._Query___schema(ctx, field)
+			})
+
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var relevantIfConditionImplementors = []string{"RelevantIfCondition"}
+
+func (ec *executionContext) _RelevantIfCondition(ctx context.Context, sel ast.SelectionSet, obj *model1.RelevantIfCondition) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, relevantIfConditionImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("RelevantIfCondition")
+		case "name":
+
+			out.Values[i] = ec._RelevantIfCondition_name(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "operator":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -17018,18 +22745,167 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_destinationType(ctx, field)
+				res = ec._RelevantIfCondition_operator(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
 				return res
 			}
 
-			rrm := func(ctx context.Context) graphql.Marshaler {
-				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
-			}
-
 			out.Concurrently(i, func() graphql.Marshaler {
-				return rrm(innerCtx)
+				return innerFunc(ctx)
+
 			})
-		case "snapshot":
+		case "value":
+
+			out.Values[i] = ec._RelevantIfCondition_value(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var resourceConfigurationImplementors = []string{"ResourceConfiguration"}
+
+func (ec *executionContext) _ResourceConfiguration(ctx context.Context, sel ast.SelectionSet, obj *model1.ResourceConfiguration) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, resourceConfigurationImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ResourceConfiguration")
+		case "name":
+
+			out.Values[i] = ec._ResourceConfiguration_name(ctx, field, obj)
+
+		case "type":
+
+			out.Values[i] = ec._ResourceConfiguration_type(ctx, field, obj)
+
+		case "parameters":
+
+			out.Values[i] = ec._ResourceConfiguration_parameters(ctx, field, obj)
+
+		case "processors":
+
+			out.Values[i] = ec._ResourceConfiguration_processors(ctx, field, obj)
+
+		case "disabled":
+
+			out.Values[i] = ec._ResourceConfiguration_disabled(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var resourceTypeSpecImplementors = []string{"ResourceTypeSpec"}
+
+func (ec *executionContext) _ResourceTypeSpec(ctx context.Context, sel ast.SelectionSet, obj *model1.ResourceTypeSpec) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, resourceTypeSpecImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("ResourceTypeSpec")
+		case "version":
+
+			out.Values[i] = ec._ResourceTypeSpec_version(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "parameters":
+
+			out.Values[i] = ec._ResourceTypeSpec_parameters(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "supportedPlatforms":
+
+			out.Values[i] = ec._ResourceTypeSpec_supportedPlatforms(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		case "telemetryTypes":
+
+			out.Values[i] = ec._ResourceTypeSpec_telemetryTypes(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				invalids++
+			}
+		default:
+			panic("unknown field " + strconv.Quote(field.Name))
+		}
+	}
+	out.Dispatch()
+	if invalids > 0 {
+		return graphql.Null
+	}
+	return out
+}
+
+var rolloutImplementors = []string{"Rollout"}
+
+func (ec *executionContext) _Rollout(ctx context.Context, sel ast.SelectionSet, obj *model1.Rollout) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutImplementors)
+	out := graphql.NewFieldSet(fields)
+	var invalids uint32
+	for i, field := range fields {
+		switch field.Name {
+		case "__typename":
+			out.Values[i] = graphql.MarshalString("Rollout")
+		case "name":
+
+			out.Values[i] = ec._Rollout_name(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "revision":
+
+			out.Values[i] = ec._Rollout_revision(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "previousRevision":
+
+			out.Values[i] = ec._Rollout_previousRevision(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "rollbackRevision":
+
+			out.Values[i] = ec._Rollout_rollbackRevision(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "status":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -17038,21 +22914,60 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_snapshot(ctx, field)
+				res = ec._Rollout_status(ctx, field, obj)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
 				return res
 			}
 
-			rrm := func(ctx context.Context) graphql.Marshaler {
-				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
-			}
-
 			out.Concurrently(i, func() graphql.Marshaler {
-				return rrm(innerCtx)
+				return innerFunc(ctx)
+
 			})
-		case "agentMetrics":
+		case "options":
+
+			out.Values[i] = ec._Rollout_options(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "message":
+
+			out.Values[i] = ec._Rollout_message(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "wave":
+
+			out.Values[i] = ec._Rollout_wave(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "waveStartedAt":
+
+			out.Values[i] = ec._Rollout_waveStartedAt(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "startedAt":
+
+			out.Values[i] = ec._Rollout_startedAt(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "updatedAt":
+
+			out.Values[i] = ec._Rollout_updatedAt(ctx, field, obj)
+
+			if out.Values[i] == graphql.Null {
+				atomic.AddUint32(&invalids, 1)
+			}
+		case "agentIds":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -17061,21 +22976,18 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_agentMetrics(ctx, field)
+				res = ec._Rollout_agentIds(ctx, field, obj)
 				if res == graphql.Null {
-					atomic.AddUint32(&invalids, 1)
-				}
-				return res
-			}
-
-			rrm := func(ctx context.Context) graphql.Marshaler {
-				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
 			}
 
 			out.Concurrently(i, func() graphql.Marshaler {
-				return rrm(innerCtx)
+				return innerFunc(ctx)
+
 			})
-		case "configurationMetrics":
+		case "pending":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -17084,21 +22996,18 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_configurationMetrics(ctx, field)
+				res = ec._Rollout_pending(ctx, field, obj)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
 				return res
 			}
 
-			rrm := func(ctx context.Context) graphql.Marshaler {
-				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
-			}
-
 			out.Concurrently(i, func() graphql.Marshaler {
-				return rrm(innerCtx)
+				return innerFunc(ctx)
+
 			})
-		case "overviewMetrics":
+		case "applied":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -17107,61 +23016,18 @@ func (ec *executionContext) _Query(ctx context.Context, sel ast.SelectionSet) gr
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._Query_overviewMetrics(ctx, field)
+				res = ec._Rollout_applied(ctx, field, obj)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
 				return res
 			}
 
-			rrm := func(ctx context.Context) graphql.Marshaler {
-				return ec.OperationContext.RootResolverMiddleware(ctx, innerFunc)
-			}
-
 			out.Concurrently(i, func() graphql.Marshaler {
-				return rrm(innerCtx)
-			})
-		case "__type":
-
-			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Query___type(ctx, field)
-			})
-
-		case "__schema":
+				return innerFunc(ctx)
 
-			out.Values[i] = ec.OperationContext.RootResolverMiddleware(innerCtx, func(ctx context.Context) (res graphql.Marshaler) {
-				return ec._Query___schema(ctx, field)
 			})
-
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
-		}
-	}
-	out.Dispatch()
-	if invalids > 0 {
-		return graphql.Null
-	}
-	return out
-}
-
-var relevantIfConditionImplementors = []string{"RelevantIfCondition"}
-
-func (ec *executionContext) _RelevantIfCondition(ctx context.Context, sel ast.SelectionSet, obj *model1.RelevantIfCondition) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, relevantIfConditionImplementors)
-	out := graphql.NewFieldSet(fields)
-	var invalids uint32
-	for i, field := range fields {
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("RelevantIfCondition")
-		case "name":
-
-			out.Values[i] = ec._RelevantIfCondition_name(ctx, field, obj)
-
-			if out.Values[i] == graphql.Null {
-				atomic.AddUint32(&invalids, 1)
-			}
-		case "operator":
+		case "failed":
 			field := field
 
 			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
@@ -17170,7 +23036,7 @@ func (ec *executionContext) _RelevantIfCondition(ctx context.Context, sel ast.Se
 						ec.Error(ctx, ec.Recover(ctx, r))
 					}
 				}()
-				res = ec._RelevantIfCondition_operator(ctx, field, obj)
+				res = ec._Rollout_failed(ctx, field, obj)
 				if res == graphql.Null {
 					atomic.AddUint32(&invalids, 1)
 				}
@@ -17181,13 +23047,6 @@ func (ec *executionContext) _RelevantIfCondition(ctx context.Context, sel ast.Se
 				return innerFunc(ctx)
 
 			})
-		case "value":
-
-			out.Values[i] = ec._RelevantIfCondition_value(ctx, field, obj)
-
-			if out.Values[i] == graphql.Null {
-				atomic.AddUint32(&invalids, 1)
-			}
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -17199,88 +23058,57 @@ func (ec *executionContext) _RelevantIfCondition(ctx context.Context, sel ast.Se
 	return out
 }
 
-var resourceConfigurationImplementors = []string{"ResourceConfiguration"}
+var rolloutOptionsImplementors = []string{"RolloutOptions"}
 
-func (ec *executionContext) _ResourceConfiguration(ctx context.Context, sel ast.SelectionSet, obj *model1.ResourceConfiguration) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, resourceConfigurationImplementors)
+func (ec *executionContext) _RolloutOptions(ctx context.Context, sel ast.SelectionSet, obj *model1.RolloutOptions) graphql.Marshaler {
+	fields := graphql.CollectFields(ec.OperationContext, sel, rolloutOptionsImplementors)
 	out := graphql.NewFieldSet(fields)
 	var invalids uint32
 	for i, field := range fields {
 		switch field.Name {
 		case "__typename":
-			out.Values[i] = graphql.MarshalString("ResourceConfiguration")
-		case "name":
-
-			out.Values[i] = ec._ResourceConfiguration_name(ctx, field, obj)
-
-		case "type":
+			out.Values[i] = graphql.MarshalString("RolloutOptions")
+		case "batchSize":
 
-			out.Values[i] = ec._ResourceConfiguration_type(ctx, field, obj)
-
-		case "parameters":
-
-			out.Values[i] = ec._ResourceConfiguration_parameters(ctx, field, obj)
-
-		case "processors":
-
-			out.Values[i] = ec._ResourceConfiguration_processors(ctx, field, obj)
-
-		case "disabled":
-
-			out.Values[i] = ec._ResourceConfiguration_disabled(ctx, field, obj)
+			out.Values[i] = ec._RolloutOptions_batchSize(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
-				invalids++
+				atomic.AddUint32(&invalids, 1)
 			}
-		default:
-			panic("unknown field " + strconv.Quote(field.Name))
-		}
-	}
-	out.Dispatch()
-	if invalids > 0 {
-		return graphql.Null
-	}
-	return out
-}
-
-var resourceTypeSpecImplementors = []string{"ResourceTypeSpec"}
-
-func (ec *executionContext) _ResourceTypeSpec(ctx context.Context, sel ast.SelectionSet, obj *model1.ResourceTypeSpec) graphql.Marshaler {
-	fields := graphql.CollectFields(ec.OperationContext, sel, resourceTypeSpecImplementors)
-	out := graphql.NewFieldSet(fields)
-	var invalids uint32
-	for i, field := range fields {
-		switch field.Name {
-		case "__typename":
-			out.Values[i] = graphql.MarshalString("ResourceTypeSpec")
-		case "version":
+		case "batchPercent":
 
-			out.Values[i] = ec._ResourceTypeSpec_version(ctx, field, obj)
+			out.Values[i] = ec._RolloutOptions_batchPercent(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
-				invalids++
+				atomic.AddUint32(&invalids, 1)
 			}
-		case "parameters":
+		case "maxErrorPercent":
 
-			out.Values[i] = ec._ResourceTypeSpec_parameters(ctx, field, obj)
+			out.Values[i] = ec._RolloutOptions_maxErrorPercent(ctx, field, obj)
 
 			if out.Values[i] == graphql.Null {
-				invalids++
+				atomic.AddUint32(&invalids, 1)
 			}
-		case "supportedPlatforms":
-
-			out.Values[i] = ec._ResourceTypeSpec_supportedPlatforms(ctx, field, obj)
+		case "onError":
+			field := field
 
-			if out.Values[i] == graphql.Null {
-				invalids++
+			innerFunc := func(ctx context.Context) (res graphql.Marshaler) {
+				defer func() {
+					if r := recover(); r != nil {
+						ec.Error(ctx, ec.Recover(ctx, r))
+					}
+				}()
+				res = ec._RolloutOptions_onError(ctx, field, obj)
+				if res == graphql.Null {
+					atomic.AddUint32(&invalids, 1)
+				}
+				return res
 			}
-		case "telemetryTypes":
 
-			out.Values[i] = ec._ResourceTypeSpec_telemetryTypes(ctx, field, obj)
+			out.Concurrently(i, func() graphql.Marshaler {
+				return innerFunc(ctx)
 
-			if out.Values[i] == graphql.Null {
-				invalids++
-			}
+			})
 		default:
 			panic("unknown field " + strconv.Quote(field.Name))
 		}
@@ -18010,6 +23838,74 @@ func (ec *executionContext) marshalNAgentChangeType2githubᚗcomᚋobserviqᚋbi
 	return v
 }
 
+func (ec *executionContext) marshalNAgentHistory2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentHistory(ctx context.Context, sel ast.SelectionSet, v model1.AgentHistory) graphql.Marshaler {
+	return ec._AgentHistory(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNAgentHistory2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentHistory(ctx context.Context, sel ast.SelectionSet, v *model1.AgentHistory) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._AgentHistory(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNAgentStatusChange2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentStatusChangeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.AgentStatusChange) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNAgentStatusChange2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentStatusChange(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNAgentStatusChange2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentStatusChange(ctx context.Context, sel ast.SelectionSet, v *model1.AgentStatusChange) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._AgentStatusChange(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalNAgents2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐAgents(ctx context.Context, sel ast.SelectionSet, v model.Agents) graphql.Marshaler {
 	return ec._Agents(ctx, sel, &v)
 }
@@ -18024,12 +23920,12 @@ func (ec *executionContext) marshalNAgents2ᚖgithubᚗcomᚋobserviqᚋbindplan
 	return ec._Agents(ctx, sel, v)
 }
 
-func (ec *executionContext) unmarshalNAny2interface(ctx context.Context, v interface{}) (any, error) {
+func (ec *executionContext) unmarshalNAny2interface(ctx context.Context, v interface{}) (interface{}, error) {
 	res, err := graphql.UnmarshalAny(v)
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalNAny2interface(ctx context.Context, sel ast.SelectionSet, v any) graphql.Marshaler {
+func (ec *executionContext) marshalNAny2interface(ctx context.Context, sel ast.SelectionSet, v interface{}) graphql.Marshaler {
 	if v == nil {
 		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
@@ -18042,7 +23938,61 @@ func (ec *executionContext) marshalNAny2interface(ctx context.Context, sel ast.S
 			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
 		}
 	}
-	return res
+	return res
+}
+
+func (ec *executionContext) marshalNAuditEntry2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAuditEntryᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.AuditEntry) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNAuditEntry2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAuditEntry(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNAuditEntry2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAuditEntry(ctx context.Context, sel ast.SelectionSet, v *model1.AuditEntry) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._AuditEntry(ctx, sel, v)
 }
 
 func (ec *executionContext) unmarshalNBoolean2bool(ctx context.Context, v interface{}) (bool, error) {
@@ -18168,6 +24118,10 @@ func (ec *executionContext) marshalNConfigurationChange2ᚖgithubᚗcomᚋobserv
 	return ec._ConfigurationChange(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalNConfigurationRoute2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationRoute(ctx context.Context, sel ast.SelectionSet, v model1.ConfigurationRoute) graphql.Marshaler {
+	return ec._ConfigurationRoute(ctx, sel, &v)
+}
+
 func (ec *executionContext) marshalNConfigurationSpec2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationSpec(ctx context.Context, sel ast.SelectionSet, v model1.ConfigurationSpec) graphql.Marshaler {
 	return ec._ConfigurationSpec(ctx, sel, &v)
 }
@@ -18376,6 +24330,60 @@ func (ec *executionContext) marshalNEventType2githubᚗcomᚋobserviqᚋbindplan
 	return v
 }
 
+func (ec *executionContext) marshalNExtensionType2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐExtensionTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.ExtensionType) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNExtensionType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐExtensionType(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNExtensionType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐExtensionType(ctx context.Context, sel ast.SelectionSet, v *model1.ExtensionType) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._ExtensionType(ctx, sel, v)
+}
+
 func (ec *executionContext) unmarshalNFloat2float64(ctx context.Context, v interface{}) (float64, error) {
 	res, err := graphql.UnmarshalFloatContext(ctx, v)
 	return res, graphql.ErrorOnPath(ctx, err)
@@ -19066,6 +25074,68 @@ func (ec *executionContext) marshalNResourceTypeSpec2githubᚗcomᚋobserviqᚋb
 	return ec._ResourceTypeSpec(ctx, sel, &v)
 }
 
+func (ec *executionContext) marshalNRollout2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx context.Context, sel ast.SelectionSet, v model1.Rollout) graphql.Marshaler {
+	return ec._Rollout(ctx, sel, &v)
+}
+
+func (ec *executionContext) marshalNRollout2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutᚄ(ctx context.Context, sel ast.SelectionSet, v []*model1.Rollout) graphql.Marshaler {
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNRollout2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
+func (ec *executionContext) marshalNRollout2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx context.Context, sel ast.SelectionSet, v *model1.Rollout) graphql.Marshaler {
+	if v == nil {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+		return graphql.Null
+	}
+	return ec._Rollout(ctx, sel, v)
+}
+
+func (ec *executionContext) marshalNRolloutOptions2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRolloutOptions(ctx context.Context, sel ast.SelectionSet, v model1.RolloutOptions) graphql.Marshaler {
+	return ec._RolloutOptions(ctx, sel, &v)
+}
+
 func (ec *executionContext) marshalNSnapshot2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋgraphqlᚋmodelᚐSnapshot(ctx context.Context, sel ast.SelectionSet, v model.Snapshot) graphql.Marshaler {
 	return ec._Snapshot(ctx, sel, &v)
 }
@@ -19245,6 +25315,21 @@ func (ec *executionContext) marshalNSuggestion2ᚖgithubᚗcomᚋobserviqᚋbind
 	return ec._Suggestion(ctx, sel, v)
 }
 
+func (ec *executionContext) unmarshalNTime2timeᚐTime(ctx context.Context, v interface{}) (time.Time, error) {
+	res, err := graphql.UnmarshalTime(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalNTime2timeᚐTime(ctx context.Context, sel ast.SelectionSet, v time.Time) graphql.Marshaler {
+	res := graphql.MarshalTime(v)
+	if res == graphql.Null {
+		if !graphql.HasFieldError(ctx, graphql.GetFieldContext(ctx)) {
+			ec.Errorf(ctx, "the requested element is null which the schema does not allow")
+		}
+	}
+	return res
+}
+
 func (ec *executionContext) marshalNTrace2ᚕᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋinternalᚋotlpᚋrecordᚐTraceᚄ(ctx context.Context, sel ast.SelectionSet, v []*record.Trace) graphql.Marshaler {
 	ret := make(graphql.Array, len(v))
 	var wg sync.WaitGroup
@@ -19571,6 +25656,13 @@ func (ec *executionContext) marshalOAgentConfiguration2ᚖgithubᚗcomᚋobservi
 	return ec._AgentConfiguration(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalOAgentRestart2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentRestart(ctx context.Context, sel ast.SelectionSet, v *model1.AgentRestart) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._AgentRestart(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOAgentSelector2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐAgentSelector(ctx context.Context, sel ast.SelectionSet, v model1.AgentSelector) graphql.Marshaler {
 	return ec._AgentSelector(ctx, sel, &v)
 }
@@ -19631,6 +25723,53 @@ func (ec *executionContext) marshalOConfiguration2ᚖgithubᚗcomᚋobserviqᚋb
 	return ec._Configuration(ctx, sel, v)
 }
 
+func (ec *executionContext) marshalOConfigurationRoute2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationRouteᚄ(ctx context.Context, sel ast.SelectionSet, v []model1.ConfigurationRoute) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNConfigurationRoute2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐConfigurationRoute(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) marshalODestination2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐDestination(ctx context.Context, sel ast.SelectionSet, v *model1.Destination) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -19692,6 +25831,13 @@ func (ec *executionContext) marshalODocumentationLink2ᚕgithubᚗcomᚋobserviq
 	return ret
 }
 
+func (ec *executionContext) marshalOExtensionType2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐExtensionType(ctx context.Context, sel ast.SelectionSet, v *model1.ExtensionType) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._ExtensionType(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOGraph2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋgraphᚐGraph(ctx context.Context, sel ast.SelectionSet, v *graph.Graph) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -19753,6 +25899,16 @@ func (ec *executionContext) marshalOID2ᚖstring(ctx context.Context, sel ast.Se
 	return res
 }
 
+func (ec *executionContext) unmarshalOInt2int(ctx context.Context, v interface{}) (int, error) {
+	res, err := graphql.UnmarshalInt(v)
+	return res, graphql.ErrorOnPath(ctx, err)
+}
+
+func (ec *executionContext) marshalOInt2int(ctx context.Context, sel ast.SelectionSet, v int) graphql.Marshaler {
+	res := graphql.MarshalInt(v)
+	return res
+}
+
 func (ec *executionContext) unmarshalOInt2ᚖint(ctx context.Context, v interface{}) (*int, error) {
 	if v == nil {
 		return nil, nil
@@ -19769,7 +25925,7 @@ func (ec *executionContext) marshalOInt2ᚖint(ctx context.Context, sel ast.Sele
 	return res
 }
 
-func (ec *executionContext) unmarshalOMap2map(ctx context.Context, v interface{}) (map[string]interface{}, error) {
+func (ec *executionContext) unmarshalOMap2map(ctx context.Context, v interface{}) (map[string]any, error) {
 	if v == nil {
 		return nil, nil
 	}
@@ -19777,7 +25933,7 @@ func (ec *executionContext) unmarshalOMap2map(ctx context.Context, v interface{}
 	return res, graphql.ErrorOnPath(ctx, err)
 }
 
-func (ec *executionContext) marshalOMap2map(ctx context.Context, sel ast.SelectionSet, v map[string]interface{}) graphql.Marshaler {
+func (ec *executionContext) marshalOMap2map(ctx context.Context, sel ast.SelectionSet, v map[string]any) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
 	}
@@ -19899,6 +26055,73 @@ func (ec *executionContext) unmarshalOParameterInput2ᚕgithubᚗcomᚋobserviq
 	return res, nil
 }
 
+func (ec *executionContext) unmarshalOPipelineType2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋotelᚐPipelineTypeᚄ(ctx context.Context, v interface{}) ([]otel.PipelineType, error) {
+	if v == nil {
+		return nil, nil
+	}
+	var vSlice []interface{}
+	if v != nil {
+		vSlice = graphql.CoerceList(v)
+	}
+	var err error
+	res := make([]otel.PipelineType, len(vSlice))
+	for i := range vSlice {
+		ctx := graphql.WithPathContext(ctx, graphql.NewPathWithIndex(i))
+		res[i], err = ec.unmarshalNPipelineType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋotelᚐPipelineType(ctx, vSlice[i])
+		if err != nil {
+			return nil, err
+		}
+	}
+	return res, nil
+}
+
+func (ec *executionContext) marshalOPipelineType2ᚕgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋotelᚐPipelineTypeᚄ(ctx context.Context, sel ast.SelectionSet, v []otel.PipelineType) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	ret := make(graphql.Array, len(v))
+	var wg sync.WaitGroup
+	isLen1 := len(v) == 1
+	if !isLen1 {
+		wg.Add(len(v))
+	}
+	for i := range v {
+		i := i
+		fc := &graphql.FieldContext{
+			Index:  &i,
+			Result: &v[i],
+		}
+		ctx := graphql.WithFieldContext(ctx, fc)
+		f := func(i int) {
+			defer func() {
+				if r := recover(); r != nil {
+					ec.Error(ctx, ec.Recover(ctx, r))
+					ret = nil
+				}
+			}()
+			if !isLen1 {
+				defer wg.Done()
+			}
+			ret[i] = ec.marshalNPipelineType2githubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚋotelᚐPipelineType(ctx, sel, v[i])
+		}
+		if isLen1 {
+			f(i)
+		} else {
+			go f(i)
+		}
+
+	}
+	wg.Wait()
+
+	for _, e := range ret {
+		if e == graphql.Null {
+			return graphql.Null
+		}
+	}
+
+	return ret
+}
+
 func (ec *executionContext) marshalOProcessor2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐProcessor(ctx context.Context, sel ast.SelectionSet, v *model1.Processor) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
@@ -20007,6 +26230,13 @@ func (ec *executionContext) marshalOResourceConfiguration2ᚕgithubᚗcomᚋobse
 	return ret
 }
 
+func (ec *executionContext) marshalORollout2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐRollout(ctx context.Context, sel ast.SelectionSet, v *model1.Rollout) graphql.Marshaler {
+	if v == nil {
+		return graphql.Null
+	}
+	return ec._Rollout(ctx, sel, v)
+}
+
 func (ec *executionContext) marshalOSource2ᚖgithubᚗcomᚋobserviqᚋbindplaneᚑopᚋmodelᚐSource(ctx context.Context, sel ast.SelectionSet, v *model1.Source) graphql.Marshaler {
 	if v == nil {
 		return graphql.Null
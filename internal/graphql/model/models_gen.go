@@ -71,10 +71,11 @@ type Snapshot struct {
 }
 
 type UpdateProcessorsInput struct {
-	Configuration string                         `json:"configuration"`
-	ResourceType  ResourceTypeKind               `json:"resourceType"`
-	ResourceIndex int                            `json:"resourceIndex"`
-	Processors    []*model.ResourceConfiguration `json:"processors"`
+	Configuration   string                         `json:"configuration"`
+	ResourceType    ResourceTypeKind               `json:"resourceType"`
+	ResourceIndex   int                            `json:"resourceIndex"`
+	Processors      []*model.ResourceConfiguration `json:"processors"`
+	ResourceVersion *string                        `json:"resourceVersion"`
 }
 
 type AgentChangeType string
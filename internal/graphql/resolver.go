@@ -171,6 +171,32 @@ func (r *Resolver) queryOptionsAndSuggestions(selector *string, query *string, i
 	return options, suggestions, nil
 }
 
+// queryOptions returns the options used to filter, sort, and page a list of resources
+func (r *Resolver) queryOptions(selector *string, query *string, offset *int, limit *int, sort *string) ([]store.QueryOption, error) {
+	parsedSelector, parsedQuery, err := r.parseSelectorAndQuery(selector, query)
+	if err != nil {
+		return nil, err
+	}
+
+	options := []store.QueryOption{}
+	if parsedSelector != nil {
+		options = append(options, store.WithSelector(*parsedSelector))
+	}
+	if parsedQuery != nil {
+		options = append(options, store.WithQuery(parsedQuery))
+	}
+	if offset != nil {
+		options = append(options, store.WithOffset(*offset))
+	}
+	if limit != nil {
+		options = append(options, store.WithLimit(*limit))
+	}
+	if sort != nil && *sort != "" {
+		options = append(options, store.WithSort(*sort))
+	}
+	return options, nil
+}
+
 // hasAgentConfigurationChanges determines if there is an agent update
 // in updates that would affect the list of configurations
 func (r *Resolver) hasAgentConfigurationChanges(updates *store.Updates) bool {
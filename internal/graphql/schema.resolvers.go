@@ -22,6 +22,7 @@ import (
 	"errors"
 	"fmt"
 	"net/http"
+	"sort"
 	"time"
 
 	"github.com/mitchellh/mapstructure"
@@ -54,8 +55,13 @@ func (r *agentResolver) Status(ctx context.Context, obj *model.Agent) (int, erro
 
 // Configuration is the resolver for the configuration field.
 func (r *agentResolver) Configuration(ctx context.Context, obj *model.Agent) (*model1.AgentConfiguration, error) {
+	values, err := store.SecretValues(ctx, r.bindplane.Store(), r.bindplane.Config().SecretsKey)
+	if err != nil {
+		return &model1.AgentConfiguration{}, err
+	}
+
 	ac := &model1.AgentConfiguration{}
-	if err := mapstructure.Decode(obj.Configuration, ac); err != nil {
+	if err := mapstructure.Decode(obj.RedactSecrets(values).Configuration, ac); err != nil {
 		return &model1.AgentConfiguration{}, err
 	}
 
@@ -90,6 +96,28 @@ func (r *agentResolver) Features(ctx context.Context, obj *model.Agent) (int, er
 	return int(obj.Features()), nil
 }
 
+// History is the resolver for the history field.
+func (r *agentResolver) History(ctx context.Context, obj *model.Agent, start *time.Time, end *time.Time) (*model.AgentHistory, error) {
+	changes, err := r.bindplane.Store().AgentHistory(ctx, obj.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var startTime, endTime time.Time
+	if start != nil {
+		startTime = *start
+	}
+	if end != nil {
+		endTime = *end
+	}
+	return model.NewAgentHistory(changes, startTime, endTime), nil
+}
+
+// Status is the resolver for the status field.
+func (r *agentRestartResolver) Status(ctx context.Context, obj *model.AgentRestart) (int, error) {
+	return int(obj.Status), nil
+}
+
 // MatchLabels is the resolver for the matchLabels field.
 func (r *agentSelectorResolver) MatchLabels(ctx context.Context, obj *model.AgentSelector) (map[string]interface{}, error) {
 	labels := map[string]interface{}{}
@@ -99,11 +127,26 @@ func (r *agentSelectorResolver) MatchLabels(ctx context.Context, obj *model.Agen
 	return labels, nil
 }
 
+// Status is the resolver for the status field.
+func (r *agentStatusChangeResolver) Status(ctx context.Context, obj *model.AgentStatusChange) (int, error) {
+	return int(obj.Status), nil
+}
+
 // Status is the resolver for the status field.
 func (r *agentUpgradeResolver) Status(ctx context.Context, obj *model.AgentUpgrade) (int, error) {
 	return int(obj.Status), nil
 }
 
+// Action is the resolver for the action field.
+func (r *auditEntryResolver) Action(ctx context.Context, obj *model.AuditEntry) (string, error) {
+	return string(obj.Action), nil
+}
+
+// Kind is the resolver for the kind field.
+func (r *auditEntryResolver) Kind(ctx context.Context, obj *model.AuditEntry) (string, error) {
+	return string(obj.Kind), nil
+}
+
 // Kind is the resolver for the kind field.
 func (r *configurationResolver) Kind(ctx context.Context, obj *model.Configuration) (string, error) {
 	return string(obj.GetKind()), nil
@@ -125,14 +168,31 @@ func (r *configurationResolver) Graph(ctx context.Context, obj *model.Configurat
 }
 
 // Rendered is the resolver for the rendered field.
-func (r *configurationResolver) Rendered(ctx context.Context, obj *model.Configuration) (*string, error) {
-	rendered, err := obj.Render(ctx, nil, r.bindplane.Config(), r.bindplane.Store())
+func (r *configurationResolver) Rendered(ctx context.Context, obj *model.Configuration, agentID *string) (*string, error) {
+	var agent *model.Agent
+	if agentID != nil && *agentID != "" {
+		var err error
+		agent, err = r.bindplane.Store().Agent(ctx, *agentID)
+		if err != nil {
+			return nil, err
+		}
+		if agent == nil {
+			return nil, fmt.Errorf("unknown agent: %s", *agentID)
+		}
+	}
+
+	rendered, err := obj.Preview(ctx, agent, r.bindplane.Config(), r.bindplane.Store())
 	if err != nil {
 		return nil, err
 	}
 	return &rendered, nil
 }
 
+// Rollout is the resolver for the rollout field.
+func (r *configurationResolver) Rollout(ctx context.Context, obj *model.Configuration) (*model.Rollout, error) {
+	return r.bindplane.Store().Rollout(ctx, obj.Name())
+}
+
 // Kind is the resolver for the kind field.
 func (r *destinationResolver) Kind(ctx context.Context, obj *model.Destination) (string, error) {
 	return string(obj.GetKind()), nil
@@ -143,6 +203,11 @@ func (r *destinationTypeResolver) Kind(ctx context.Context, obj *model.Destinati
 	return string(obj.GetKind()), nil
 }
 
+// Kind is the resolver for the kind field.
+func (r *extensionTypeResolver) Kind(ctx context.Context, obj *model.ExtensionType) (string, error) {
+	return string(obj.GetKind()), nil
+}
+
 // Labels is the resolver for the labels field.
 func (r *metadataResolver) Labels(ctx context.Context, obj *model.Metadata) (map[string]interface{}, error) {
 	labels := map[string]interface{}{}
@@ -152,6 +217,16 @@ func (r *metadataResolver) Labels(ctx context.Context, obj *model.Metadata) (map
 	return labels, nil
 }
 
+// ResourceVersion is the resolver for the resourceVersion field.
+func (r *metadataResolver) ResourceVersion(ctx context.Context, obj *model.Metadata) (*string, error) {
+	// the resourceVersion is derived from the revision and is not stored
+	version := model.RevisionResourceVersion(obj.Revision)
+	if version == "" {
+		return nil, nil
+	}
+	return &version, nil
+}
+
 // UpdateProcessors is the resolver for the updateProcessors field.
 func (r *mutationResolver) UpdateProcessors(ctx context.Context, input model1.UpdateProcessorsInput) (*bool, error) {
 	config, err := r.bindplane.Store().Configuration(ctx, input.Configuration)
@@ -162,6 +237,9 @@ func (r *mutationResolver) UpdateProcessors(ctx context.Context, input model1.Up
 	if config == nil {
 		return nil, fmt.Errorf("configuration not found")
 	}
+	if input.ResourceVersion != nil && *input.ResourceVersion != "" {
+		config.SetResourceVersion(*input.ResourceVersion)
+	}
 
 	processors := make([]model.ResourceConfiguration, len(input.Processors))
 	for ix, p := range input.Processors {
@@ -182,13 +260,69 @@ func (r *mutationResolver) UpdateProcessors(ctx context.Context, input model1.Up
 		return nil, err
 	}
 
-	if statuses[0].Status == model.StatusError {
+	switch statuses[0].Status {
+	case model.StatusError, model.StatusConflict:
 		return nil, errors.New(statuses[0].Reason)
 	}
 
 	return nil, nil
 }
 
+// RollbackConfiguration is the resolver for the rollbackConfiguration field.
+func (r *mutationResolver) RollbackConfiguration(ctx context.Context, name string, revision int) (*model.Configuration, error) {
+	status, err := store.RollbackResource(ctx, r.bindplane.Store(), model.KindConfiguration, name, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	switch status.Status {
+	case model.StatusError, model.StatusInvalid:
+		return nil, errors.New(status.Reason)
+	}
+
+	return r.bindplane.Store().Configuration(ctx, name)
+}
+
+// RestartAgents is the resolver for the restartAgents field.
+func (r *mutationResolver) RestartAgents(ctx context.Context, ids []string, selector *string, query *string) ([]*model.Agent, error) {
+	ctx, span := tracer.Start(ctx, "graphql/RestartAgents")
+	defer span.End()
+
+	if len(ids) == 0 && (selector == nil || *selector == "") && (query == nil || *query == "") {
+		return nil, errors.New("ids, selector, or query must be specified")
+	}
+	if selector != nil && *selector == "" {
+		selector = nil
+	}
+
+	options, err := r.queryOptions(selector, query, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	agents, err := store.RequestAgentRestarts(ctx, r.bindplane.Store(), ids, options...)
+	if err != nil {
+		r.bindplane.Logger().Error("error in graphql RestartAgents", zap.Error(err))
+		return nil, err
+	}
+	return agents, nil
+}
+
+// PauseRollout is the resolver for the pauseRollout field.
+func (r *mutationResolver) PauseRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return r.bindplane.Manager().PauseRollout(ctx, name)
+}
+
+// ResumeRollout is the resolver for the resumeRollout field.
+func (r *mutationResolver) ResumeRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return r.bindplane.Manager().ResumeRollout(ctx, name)
+}
+
+// AbortRollout is the resolver for the abortRollout field.
+func (r *mutationResolver) AbortRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return r.bindplane.Manager().AbortRollout(ctx, name)
+}
+
 // Type is the resolver for the type field.
 func (r *parameterDefinitionResolver) Type(ctx context.Context, obj *model.ParameterDefinition) (model1.ParameterType, error) {
 	switch obj.Type {
@@ -319,9 +453,29 @@ func (r *queryResolver) Configuration(ctx context.Context, name string) (*model.
 	return r.bindplane.Store().Configuration(ctx, name)
 }
 
+// ConfigurationRevisions is the resolver for the configurationRevisions field.
+func (r *queryResolver) ConfigurationRevisions(ctx context.Context, name string) ([]*model.Configuration, error) {
+	revisions, err := r.bindplane.Store().ResourceRevisions(ctx, model.KindConfiguration, name)
+	if err != nil {
+		return nil, err
+	}
+
+	configurations := make([]*model.Configuration, 0, len(revisions))
+	for _, revision := range revisions {
+		if configuration, ok := revision.(*model.Configuration); ok {
+			configurations = append(configurations, configuration)
+		}
+	}
+	return configurations, nil
+}
+
 // Sources is the resolver for the sources field.
-func (r *queryResolver) Sources(ctx context.Context) ([]*model.Source, error) {
-	return r.bindplane.Store().Sources(ctx)
+func (r *queryResolver) Sources(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model.Source, error) {
+	options, err := r.queryOptions(selector, query, offset, limit, sort)
+	if err != nil {
+		return nil, err
+	}
+	return r.bindplane.Store().Sources(ctx, options...)
 }
 
 // Source is the resolver for the source field.
@@ -330,8 +484,12 @@ func (r *queryResolver) Source(ctx context.Context, name string) (*model.Source,
 }
 
 // SourceTypes is the resolver for the sourceTypes field.
-func (r *queryResolver) SourceTypes(ctx context.Context) ([]*model.SourceType, error) {
-	return r.bindplane.Store().SourceTypes(ctx)
+func (r *queryResolver) SourceTypes(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model.SourceType, error) {
+	options, err := r.queryOptions(selector, query, offset, limit, sort)
+	if err != nil {
+		return nil, err
+	}
+	return r.bindplane.Store().SourceTypes(ctx, options...)
 }
 
 // SourceType is the resolver for the sourceType field.
@@ -340,8 +498,12 @@ func (r *queryResolver) SourceType(ctx context.Context, name string) (*model.Sou
 }
 
 // Processors is the resolver for the processors field.
-func (r *queryResolver) Processors(ctx context.Context) ([]*model.Processor, error) {
-	return r.bindplane.Store().Processors(ctx)
+func (r *queryResolver) Processors(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model.Processor, error) {
+	options, err := r.queryOptions(selector, query, offset, limit, sort)
+	if err != nil {
+		return nil, err
+	}
+	return r.bindplane.Store().Processors(ctx, options...)
 }
 
 // Processor is the resolver for the processor field.
@@ -350,8 +512,12 @@ func (r *queryResolver) Processor(ctx context.Context, name string) (*model.Proc
 }
 
 // ProcessorTypes is the resolver for the processorTypes field.
-func (r *queryResolver) ProcessorTypes(ctx context.Context) ([]*model.ProcessorType, error) {
-	return r.bindplane.Store().ProcessorTypes(ctx)
+func (r *queryResolver) ProcessorTypes(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model.ProcessorType, error) {
+	options, err := r.queryOptions(selector, query, offset, limit, sort)
+	if err != nil {
+		return nil, err
+	}
+	return r.bindplane.Store().ProcessorTypes(ctx, options...)
 }
 
 // ProcessorType is the resolver for the processorType field.
@@ -360,8 +526,12 @@ func (r *queryResolver) ProcessorType(ctx context.Context, name string) (*model.
 }
 
 // Destinations is the resolver for the destinations field.
-func (r *queryResolver) Destinations(ctx context.Context) ([]*model.Destination, error) {
-	return r.bindplane.Store().Destinations(ctx)
+func (r *queryResolver) Destinations(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model.Destination, error) {
+	options, err := r.queryOptions(selector, query, offset, limit, sort)
+	if err != nil {
+		return nil, err
+	}
+	return r.bindplane.Store().Destinations(ctx, options...)
 }
 
 // Destination is the resolver for the destination field.
@@ -432,8 +602,12 @@ func (r *queryResolver) DestinationsInConfigs(ctx context.Context) ([]*model.Des
 }
 
 // DestinationTypes is the resolver for the destinationTypes field.
-func (r *queryResolver) DestinationTypes(ctx context.Context) ([]*model.DestinationType, error) {
-	return r.bindplane.Store().DestinationTypes(ctx)
+func (r *queryResolver) DestinationTypes(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model.DestinationType, error) {
+	options, err := r.queryOptions(selector, query, offset, limit, sort)
+	if err != nil {
+		return nil, err
+	}
+	return r.bindplane.Store().DestinationTypes(ctx, options...)
 }
 
 // DestinationType is the resolver for the destinationType field.
@@ -441,6 +615,20 @@ func (r *queryResolver) DestinationType(ctx context.Context, name string) (*mode
 	return r.bindplane.Store().DestinationType(ctx, name)
 }
 
+// ExtensionTypes is the resolver for the extensionTypes field.
+func (r *queryResolver) ExtensionTypes(ctx context.Context, selector *string, query *string, offset *int, limit *int, sort *string) ([]*model.ExtensionType, error) {
+	options, err := r.queryOptions(selector, query, offset, limit, sort)
+	if err != nil {
+		return nil, err
+	}
+	return r.bindplane.Store().ExtensionTypes(ctx, options...)
+}
+
+// ExtensionType is the resolver for the extensionType field.
+func (r *queryResolver) ExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	return r.bindplane.Store().ExtensionType(ctx, name)
+}
+
 // Snapshot is the resolver for the snapshot field.
 func (r *queryResolver) Snapshot(ctx context.Context, agentID string, pipelineType otel.PipelineType) (*model1.Snapshot, error) {
 	ctx, cancel := context.WithTimeout(ctx, 30*time.Second)
@@ -524,6 +712,48 @@ func (r *queryResolver) Snapshot(ctx context.Context, agentID string, pipelineTy
 	return signals, nil
 }
 
+// AuditEntries is the resolver for the auditEntries field.
+func (r *queryResolver) AuditEntries(ctx context.Context, kind *string, name *string, user *string, offset *int, limit *int) ([]*model.AuditEntry, error) {
+	filter := model.AuditFilter{}
+	if kind != nil {
+		filter.Kind = model.Kind(*kind)
+	}
+	if name != nil {
+		filter.Name = *name
+	}
+	if user != nil {
+		filter.User = *user
+	}
+	if offset != nil {
+		filter.Offset = *offset
+	}
+	if limit != nil {
+		filter.Limit = *limit
+	}
+	entries, err := r.bindplane.Store().AuditEntries(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+	values, err := store.SecretValues(ctx, r.bindplane.Store(), r.bindplane.Config().SecretsKey)
+	if err != nil {
+		return nil, err
+	}
+	for i, entry := range entries {
+		entries[i] = entry.RedactSecrets(values)
+	}
+	return entries, nil
+}
+
+// Rollouts is the resolver for the rollouts field.
+func (r *queryResolver) Rollouts(ctx context.Context) ([]*model.Rollout, error) {
+	return r.bindplane.Store().Rollouts(ctx)
+}
+
+// Rollout is the resolver for the rollout field.
+func (r *queryResolver) Rollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return r.bindplane.Store().Rollout(ctx, name)
+}
+
 // AgentMetrics is the resolver for the agentMetrics field.
 func (r *queryResolver) AgentMetrics(ctx context.Context, period string, ids []string) (*model1.GraphMetrics, error) {
 	return agentMetrics(ctx, r.bindplane, period, ids)
@@ -544,6 +774,50 @@ func (r *relevantIfConditionResolver) Operator(ctx context.Context, obj *model.R
 	return model1.RelevantIfOperatorType(obj.Operator), nil
 }
 
+// Status is the resolver for the status field.
+func (r *rolloutResolver) Status(ctx context.Context, obj *model.Rollout) (string, error) {
+	return string(obj.Status), nil
+}
+
+// AgentIds is the resolver for the agentIds field.
+func (r *rolloutResolver) AgentIds(ctx context.Context, obj *model.Rollout, status *string, wave *int) ([]string, error) {
+	ids := []string{}
+	for id, agent := range obj.Agents {
+		if status != nil && *status != "" && string(agent.Status) != *status {
+			continue
+		}
+		if wave != nil && agent.Wave != *wave {
+			continue
+		}
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Pending is the resolver for the pending field.
+func (r *rolloutResolver) Pending(ctx context.Context, obj *model.Rollout) (int, error) {
+	return obj.AgentCount(model.RolloutAgentPending), nil
+}
+
+// Applied is the resolver for the applied field.
+func (r *rolloutResolver) Applied(ctx context.Context, obj *model.Rollout) (int, error) {
+	return obj.AgentCount(model.RolloutAgentApplied), nil
+}
+
+// Failed is the resolver for the failed field.
+func (r *rolloutResolver) Failed(ctx context.Context, obj *model.Rollout) (int, error) {
+	return obj.AgentCount(model.RolloutAgentFailed), nil
+}
+
+// OnError is the resolver for the onError field.
+func (r *rolloutOptionsResolver) OnError(ctx context.Context, obj *model.RolloutOptions) (string, error) {
+	if obj.OnError == "" {
+		return string(model.RolloutActionPause), nil
+	}
+	return string(obj.OnError), nil
+}
+
 // Kind is the resolver for the kind field.
 func (r *sourceResolver) Kind(ctx context.Context, obj *model.Source) (string, error) {
 	return string(obj.GetKind()), nil
@@ -682,12 +956,23 @@ func (r *subscriptionResolver) OverviewMetrics(ctx context.Context, period strin
 // Agent returns generated.AgentResolver implementation.
 func (r *Resolver) Agent() generated.AgentResolver { return &agentResolver{r} }
 
+// AgentRestart returns generated.AgentRestartResolver implementation.
+func (r *Resolver) AgentRestart() generated.AgentRestartResolver { return &agentRestartResolver{r} }
+
 // AgentSelector returns generated.AgentSelectorResolver implementation.
 func (r *Resolver) AgentSelector() generated.AgentSelectorResolver { return &agentSelectorResolver{r} }
 
+// AgentStatusChange returns generated.AgentStatusChangeResolver implementation.
+func (r *Resolver) AgentStatusChange() generated.AgentStatusChangeResolver {
+	return &agentStatusChangeResolver{r}
+}
+
 // AgentUpgrade returns generated.AgentUpgradeResolver implementation.
 func (r *Resolver) AgentUpgrade() generated.AgentUpgradeResolver { return &agentUpgradeResolver{r} }
 
+// AuditEntry returns generated.AuditEntryResolver implementation.
+func (r *Resolver) AuditEntry() generated.AuditEntryResolver { return &auditEntryResolver{r} }
+
 // Configuration returns generated.ConfigurationResolver implementation.
 func (r *Resolver) Configuration() generated.ConfigurationResolver { return &configurationResolver{r} }
 
@@ -699,6 +984,9 @@ func (r *Resolver) DestinationType() generated.DestinationTypeResolver {
 	return &destinationTypeResolver{r}
 }
 
+// ExtensionType returns generated.ExtensionTypeResolver implementation.
+func (r *Resolver) ExtensionType() generated.ExtensionTypeResolver { return &extensionTypeResolver{r} }
+
 // Metadata returns generated.MetadataResolver implementation.
 func (r *Resolver) Metadata() generated.MetadataResolver { return &metadataResolver{r} }
 
@@ -724,6 +1012,14 @@ func (r *Resolver) RelevantIfCondition() generated.RelevantIfConditionResolver {
 	return &relevantIfConditionResolver{r}
 }
 
+// Rollout returns generated.RolloutResolver implementation.
+func (r *Resolver) Rollout() generated.RolloutResolver { return &rolloutResolver{r} }
+
+// RolloutOptions returns generated.RolloutOptionsResolver implementation.
+func (r *Resolver) RolloutOptions() generated.RolloutOptionsResolver {
+	return &rolloutOptionsResolver{r}
+}
+
 // Source returns generated.SourceResolver implementation.
 func (r *Resolver) Source() generated.SourceResolver { return &sourceResolver{r} }
 
@@ -734,11 +1030,15 @@ func (r *Resolver) SourceType() generated.SourceTypeResolver { return &sourceTyp
 func (r *Resolver) Subscription() generated.SubscriptionResolver { return &subscriptionResolver{r} }
 
 type agentResolver struct{ *Resolver }
+type agentRestartResolver struct{ *Resolver }
 type agentSelectorResolver struct{ *Resolver }
+type agentStatusChangeResolver struct{ *Resolver }
 type agentUpgradeResolver struct{ *Resolver }
+type auditEntryResolver struct{ *Resolver }
 type configurationResolver struct{ *Resolver }
 type destinationResolver struct{ *Resolver }
 type destinationTypeResolver struct{ *Resolver }
+type extensionTypeResolver struct{ *Resolver }
 type metadataResolver struct{ *Resolver }
 type mutationResolver struct{ *Resolver }
 type parameterDefinitionResolver struct{ *Resolver }
@@ -746,6 +1046,8 @@ type processorResolver struct{ *Resolver }
 type processorTypeResolver struct{ *Resolver }
 type queryResolver struct{ *Resolver }
 type relevantIfConditionResolver struct{ *Resolver }
+type rolloutResolver struct{ *Resolver }
+type rolloutOptionsResolver struct{ *Resolver }
 type sourceResolver struct{ *Resolver }
 type sourceTypeResolver struct{ *Resolver }
 type subscriptionResolver struct{ *Resolver }
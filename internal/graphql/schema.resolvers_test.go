@@ -17,6 +17,7 @@ package graphql
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/99designs/gqlgen/client"
 	"github.com/stretchr/testify/mock"
@@ -163,6 +164,134 @@ func TestQueryResolvers(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, resp["agent"].ID, agent.ID)
 	})
+
+	t.Run("configuration returns the resourceVersion of the stored configuration", func(t *testing.T) {
+		s.Clear()
+
+		var resp struct {
+			Configuration struct {
+				Metadata struct {
+					ResourceVersion *string
+				}
+			}
+		}
+
+		for _, raw := range []string{"raw: 1", "raw: 2"} {
+			_, err := s.ApplyResources(ctx, []model.Resource{model.NewRawConfiguration("versioned", raw)})
+			require.NoError(t, err)
+		}
+
+		err := c.Post("query TestQuery($name: String!) { configuration(name: $name) { metadata { resourceVersion } } }", &resp, client.Var("name", "versioned"))
+		require.NoError(t, err)
+		require.NotNil(t, resp.Configuration.Metadata.ResourceVersion)
+		require.Equal(t, "2", *resp.Configuration.Metadata.ResourceVersion)
+	})
+
+	t.Run("agent history returns the status changes and uptime", func(t *testing.T) {
+		s.Clear()
+
+		var resp struct {
+			Agent struct {
+				History struct {
+					Changes []struct {
+						Status       int
+						ErrorMessage *string
+					}
+					Uptime float64
+				}
+			}
+		}
+
+		addAgent(s, &model.Agent{ID: "1", Status: model.Connected})
+		addAgent(s, &model.Agent{ID: "1", Status: model.Error, ErrorMessage: "failed"})
+
+		err := c.Post("query TestQuery($id: ID!) { agent(id: $id) { history { changes { status errorMessage } uptime } } }", &resp, client.Var("id", "1"))
+		require.NoError(t, err)
+		require.Len(t, resp.Agent.History.Changes, 2)
+		require.Equal(t, int(model.Connected), resp.Agent.History.Changes[0].Status)
+		require.Equal(t, int(model.Error), resp.Agent.History.Changes[1].Status)
+		require.Equal(t, "failed", *resp.Agent.History.Changes[1].ErrorMessage)
+	})
+
+	t.Run("restartAgents requests a restart of the matching agents", func(t *testing.T) {
+		s.Clear()
+
+		var resp struct {
+			RestartAgents []struct {
+				ID      string
+				Status  int
+				Restart *struct {
+					Status int
+				}
+			}
+		}
+
+		xy, err := model.LabelsFromSelector("x=y")
+		require.NoError(t, err)
+
+		addAgent(s, &model.Agent{ID: "1", Status: model.Connected, Labels: xy})
+		addAgent(s, &model.Agent{ID: "2", Status: model.Connected})
+		addAgent(s, &model.Agent{ID: "3", Status: model.Disconnected, Labels: xy})
+
+		err = c.Post(`mutation { restartAgents(selector: "x=y") { id status restart { status } } }`, &resp)
+		require.NoError(t, err)
+		require.Len(t, resp.RestartAgents, 1)
+		require.Equal(t, "1", resp.RestartAgents[0].ID)
+		require.Equal(t, int(model.Restarting), resp.RestartAgents[0].Status)
+		require.Equal(t, int(model.RestartPending), resp.RestartAgents[0].Restart.Status)
+
+		err = c.Post(`mutation { restartAgents { id } }`, &resp)
+		require.Error(t, err)
+	})
+
+	t.Run("rollouts returns rollouts and pauseRollout pauses a rollout", func(t *testing.T) {
+		s.Clear()
+
+		configuration := model.NewConfiguration("test")
+		configuration.Spec.Rollout = &model.RolloutOptions{BatchSize: 2}
+		rollout := model.NewRollout(configuration, 1, time.Now())
+		rollout.StartWave([]string{"2", "1"}, time.Now())
+		rollout.Agents["2"].Status = model.RolloutAgentFailed
+		require.NoError(t, s.UpsertRollout(ctx, rollout))
+
+		var resp struct {
+			Rollouts []struct {
+				Name     string
+				Status   string
+				AgentIds []string
+				Pending  int
+				Failed   int
+				Options  struct {
+					BatchSize int
+					OnError   string
+				}
+			}
+		}
+		err := c.Post(`query { rollouts { name status agentIds pending failed options { batchSize onError } } }`, &resp)
+		require.NoError(t, err)
+		require.Len(t, resp.Rollouts, 1)
+		require.Equal(t, "test", resp.Rollouts[0].Name)
+		require.Equal(t, "running", resp.Rollouts[0].Status)
+		require.Equal(t, []string{"1", "2"}, resp.Rollouts[0].AgentIds)
+		require.Equal(t, 1, resp.Rollouts[0].Pending)
+		require.Equal(t, 1, resp.Rollouts[0].Failed)
+		require.Equal(t, 2, resp.Rollouts[0].Options.BatchSize)
+		require.Equal(t, "pause", resp.Rollouts[0].Options.OnError)
+
+		var pauseResp struct {
+			PauseRollout struct {
+				Status   string
+				AgentIds []string
+			}
+		}
+		err = c.Post(`mutation { pauseRollout(name: "test") { status agentIds(status: "failed") } }`, &pauseResp)
+		require.NoError(t, err)
+		require.Equal(t, "paused", pauseResp.PauseRollout.Status)
+		require.Equal(t, []string{"2"}, pauseResp.PauseRollout.AgentIds)
+
+		err = c.Post(`mutation { pauseRollout(name: "missing") { status } }`, &pauseResp)
+		require.Error(t, err)
+	})
 }
 
 func TestConfigForAgent(t *testing.T) {
@@ -0,0 +1,180 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opamp
+
+import (
+	"context"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	opamp "github.com/open-telemetry/opamp-go/server/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric/global"
+	"go.opentelemetry.io/otel/metric/instrument"
+	"go.opentelemetry.io/otel/metric/instrument/syncint64"
+	"golang.org/x/time/rate"
+)
+
+// admissionRetryAfter is the minimum delay sent to agents in the Retry-After header when they are rejected because the
+// server is busy. A random jitter of up to the same amount is added so that the agents don't reconnect at once.
+const admissionRetryAfter = 30 * time.Second
+
+// admissionReservationTTL is how long a slot reserved by OnConnecting is held for a connection that is never
+// established, e.g. because the WebSocket handshake failed
+const admissionReservationTTL = 30 * time.Second
+
+// reasons for rejecting a connection, used as the reason attribute of the rejected connections metric
+const (
+	rejectReasonMaxConnections = "max_connections"
+	rejectReasonRateLimit      = "rate_limit"
+	rejectReasonIncompatible   = "incompatible"
+	rejectReasonUnauthorized   = "unauthorized"
+	rejectReasonError          = "error"
+)
+
+var meter = global.Meter("bindplane/opamp")
+
+// rejectedConnections counts the connections rejected by OnConnecting by reason
+var rejectedConnections = func() syncint64.Counter {
+	counter, err := meter.SyncInt64().Counter("bindplane.opamp.connections.rejected",
+		instrument.WithDescription("number of agent connections rejected by the server"),
+	)
+	if err != nil {
+		// the global meter provider returns noop instruments which never fail
+		panic(err)
+	}
+	return counter
+}()
+
+// admission limits the number of agents that can be connected at once and the rate at which new connections are
+// accepted so that the server isn't overwhelmed when many agents reconnect at once, e.g. after a restart. A slot is
+// reserved when a connection is accepted and held until the connection is closed so that concurrent connections cannot
+// exceed the limit.
+type admission struct {
+	// maxConnections is the maximum number of connected agents, unlimited if 0
+	maxConnections int
+	// limiter limits the rate of new connections, unlimited if nil
+	limiter *rate.Limiter
+
+	mtx sync.Mutex
+	// connected is the number of established connections holding a slot
+	connected int
+	// reserved are the expiration times of the slots reserved for accepted connections that are not established yet,
+	// oldest first
+	reserved []time.Time
+}
+
+// newAdmission returns a new admission that allows maxConnections agents to be connected at once and accepts
+// connectionsPerSecond new connections each second. Either limit is disabled if it is 0.
+func newAdmission(maxConnections int, connectionsPerSecond int) *admission {
+	a := &admission{
+		maxConnections: maxConnections,
+	}
+	if connectionsPerSecond > 0 {
+		a.limiter = rate.NewLimiter(rate.Limit(connectionsPerSecond), connectionsPerSecond)
+	}
+	return a
+}
+
+// admit returns true and reserves a slot if a new connection can be accepted. The slot must be released with cancel if
+// the connection is rejected later and it is held by the connection once it is established with connect. Otherwise it
+// returns the response used to reject the connection and the reason it was rejected.
+func (a *admission) admit() (response opamp.ConnectionResponse, reason string, ok bool) {
+	if a == nil {
+		return response, "", true
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	a.expireReservations(time.Now())
+	switch {
+	case a.maxConnections > 0 && a.connected+len(a.reserved) >= a.maxConnections:
+		return busyResponse(http.StatusServiceUnavailable), rejectReasonMaxConnections, false
+	case a.limiter != nil && !a.limiter.Allow():
+		return busyResponse(http.StatusTooManyRequests), rejectReasonRateLimit, false
+	}
+	a.reserved = append(a.reserved, time.Now().Add(admissionReservationTTL))
+	return response, "", true
+}
+
+// cancel releases a slot reserved by admit for a connection that was rejected
+func (a *admission) cancel() {
+	if a == nil {
+		return
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if len(a.reserved) > 0 {
+		a.reserved = a.reserved[1:]
+	}
+}
+
+// connect moves a slot reserved by admit to an established connection, which holds it until disconnect is called
+func (a *admission) connect() {
+	if a == nil {
+		return
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if len(a.reserved) > 0 {
+		a.reserved = a.reserved[1:]
+	}
+	a.connected++
+}
+
+// disconnect releases the slot held by an established connection when it is closed
+func (a *admission) disconnect() {
+	if a == nil {
+		return
+	}
+	a.mtx.Lock()
+	defer a.mtx.Unlock()
+
+	if a.connected > 0 {
+		a.connected--
+	}
+}
+
+// expireReservations releases the slots reserved for connections that were never established. It must be called with
+// the mtx held.
+func (a *admission) expireReservations(now time.Time) {
+	i := 0
+	for i < len(a.reserved) && !now.Before(a.reserved[i]) {
+		i++
+	}
+	a.reserved = a.reserved[i:]
+}
+
+// busyResponse returns a rejected ConnectionResponse with the specified status code and a jittered Retry-After header
+func busyResponse(statusCode int) opamp.ConnectionResponse {
+	retryAfter := admissionRetryAfter + time.Duration(rand.Int63n(int64(admissionRetryAfter)))
+	return opamp.ConnectionResponse{
+		Accept:         false,
+		HTTPStatusCode: statusCode,
+		HTTPResponseHeader: map[string]string{
+			"Retry-After": strconv.Itoa(int(retryAfter.Seconds())),
+		},
+	}
+}
+
+// recordRejectedConnection increments the rejected connections metric for the reason
+func recordRejectedConnection(ctx context.Context, reason string) {
+	rejectedConnections.Add(ctx, 1, attribute.String("reason", reason))
+}
@@ -0,0 +1,199 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package opamp
+
+import (
+	"crypto/x509"
+	"net/http"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/observiq/bindplane-op/internal/server/mocks"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAdmissionAdmit(t *testing.T) {
+	tests := []struct {
+		name                 string
+		admission            *admission
+		connected            int
+		expectStatusCodes    []int
+		expectRejectedReason string
+	}{
+		{
+			name:              "unlimited",
+			admission:         nil,
+			connected:         1000,
+			expectStatusCodes: []int{0, 0, 0},
+		},
+		{
+			name:                 "under max connections",
+			admission:            newAdmission(10, 0),
+			connected:            8,
+			expectStatusCodes:    []int{0, 0, http.StatusServiceUnavailable},
+			expectRejectedReason: rejectReasonMaxConnections,
+		},
+		{
+			name:                 "at max connections",
+			admission:            newAdmission(10, 0),
+			connected:            10,
+			expectStatusCodes:    []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			expectRejectedReason: rejectReasonMaxConnections,
+		},
+		{
+			name:                 "over connections per second",
+			admission:            newAdmission(0, 2),
+			connected:            0,
+			expectStatusCodes:    []int{0, 0, http.StatusTooManyRequests},
+			expectRejectedReason: rejectReasonRateLimit,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if test.admission != nil {
+				test.admission.connected = test.connected
+			}
+			for _, expectStatusCode := range test.expectStatusCodes {
+				response, reason, ok := test.admission.admit()
+				if expectStatusCode == 0 {
+					require.True(t, ok)
+					continue
+				}
+				require.False(t, ok)
+				require.False(t, response.Accept)
+				require.Equal(t, expectStatusCode, response.HTTPStatusCode)
+				require.Equal(t, test.expectRejectedReason, reason)
+
+				retryAfter, err := strconv.Atoi(response.HTTPResponseHeader["Retry-After"])
+				require.NoError(t, err)
+				require.GreaterOrEqual(t, retryAfter, int(admissionRetryAfter.Seconds()))
+				require.Less(t, retryAfter, 2*int(admissionRetryAfter.Seconds()))
+			}
+		})
+	}
+}
+
+func TestServerOnConnectingAdmission(t *testing.T) {
+	request := &http.Request{
+		Header: http.Header{
+			"Opamp-Version": []string{"v0.2.0"},
+			"Agent-Id":      []string{"agent-1"},
+			"Authorization": []string{"Secret-Key secret"},
+		},
+	}
+
+	manager := &mocks.Manager{}
+	server := testServer(manager)
+	server.compatibleOpAMPVersions = []string{"v0.2.0"}
+	server.admission = newAdmission(1, 0)
+	require.Equal(t, 0, server.admission.connected)
+	_, _, ok := server.admission.admit()
+	require.True(t, ok)
+	server.OnConnected(&testConnection{agentID: "agent-2"})
+
+	response := server.OnConnecting(request)
+	require.False(t, response.Accept)
+	require.Equal(t, http.StatusServiceUnavailable, response.HTTPStatusCode)
+	require.Contains(t, response.HTTPResponseHeader, "Retry-After")
+	manager.AssertNotCalled(t, "VerifyAgentCertificate")
+	manager.AssertNotCalled(t, "AuthenticateAgent")
+}
+
+func TestAdmissionSlots(t *testing.T) {
+	a := newAdmission(2, 0)
+
+	// slots are reserved when connections are accepted, before they are established
+	_, _, ok := a.admit()
+	require.True(t, ok)
+	_, _, ok = a.admit()
+	require.True(t, ok)
+	_, reason, ok := a.admit()
+	require.False(t, ok)
+	require.Equal(t, rejectReasonMaxConnections, reason)
+
+	// a rejected connection releases its slot
+	a.cancel()
+	_, _, ok = a.admit()
+	require.True(t, ok)
+
+	// established connections hold their slots until they are closed
+	a.connect()
+	a.connect()
+	_, _, ok = a.admit()
+	require.False(t, ok)
+	a.disconnect()
+	_, _, ok = a.admit()
+	require.True(t, ok)
+
+	// reservations for connections that are never established expire
+	require.Len(t, a.reserved, 1)
+	a.mtx.Lock()
+	a.expireReservations(time.Now().Add(admissionReservationTTL))
+	a.mtx.Unlock()
+	require.Empty(t, a.reserved)
+	require.Equal(t, 1, a.connected)
+}
+
+func TestServerOnConnectingConcurrentAdmission(t *testing.T) {
+	manager := &mocks.Manager{}
+	manager.On("VerifyAgentCertificate", mock.Anything, mock.Anything, (*x509.Certificate)(nil)).Return(true, nil)
+	manager.On("AuthenticateAgent", mock.Anything, mock.Anything, "secret").Return(true, nil)
+	manager.On("AuthenticateAgent", mock.Anything, mock.Anything, "other").Return(false, nil)
+
+	server := testServer(manager)
+	server.compatibleOpAMPVersions = []string{"v0.2.0"}
+	server.admission = newAdmission(10, 0)
+
+	request := func(secretKey string) *http.Request {
+		return &http.Request{
+			Header: http.Header{
+				"Opamp-Version": []string{"v0.2.0"},
+				"Authorization": []string{"Secret-Key " + secretKey},
+			},
+		}
+	}
+
+	// connections rejected after the admission check release their slots
+	for i := 0; i < 20; i++ {
+		require.Equal(t, http.StatusUnauthorized, server.OnConnecting(request("other")).HTTPStatusCode)
+	}
+
+	// concurrent connections that have not sent a message yet cannot exceed the cap
+	var accepted int32
+	var wg sync.WaitGroup
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if server.OnConnecting(request("secret")).Accept {
+				atomic.AddInt32(&accepted, 1)
+			}
+		}()
+	}
+	wg.Wait()
+	require.Equal(t, int32(10), accepted)
+
+	// a closed connection releases its slot
+	conn := &testConnection{agentID: "agent-1"}
+	server.OnConnected(conn)
+	require.False(t, server.OnConnecting(request("secret")).Accept)
+	server.OnConnectionClose(conn)
+	require.True(t, server.OnConnecting(request("secret")).Accept)
+}
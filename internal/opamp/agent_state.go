@@ -51,11 +51,33 @@ func (s *opampServer) updateAgentState(ctx context.Context, agentID string, conn
 		syncOne[*protobufs.RemoteConfigStatus](ctx, s.logger, msg, state, conn, agent, response, &syncRemoteConfigStatus)
 		syncOne[*protobufs.PackageStatuses](ctx, s.logger, msg, state, conn, agent, response, &syncPackageStatuses)
 
+		// capabilities are sent with every message and used to determine if commands like restart are supported
+		if capabilities := msg.GetCapabilities(); capabilities != 0 {
+			state.Status.Capabilities = capabilities
+		}
+
 		// after sync, update sequence number
 		state.SequenceNum = msg.GetSequenceNum()
 
+		// the issued secret key is confirmed when the agent reports it in manager.yaml and enrollment labels are kept
+		// until then
+		if agent.Credentials.Pending() {
+			if raw := state.Configuration(); raw != nil {
+				if configuration, err := raw.Parse(); err == nil && configuration.Manager != nil {
+					agent.ConfirmCredentials(configuration.Manager.SecretKey)
+				}
+			}
+			agent.ApplyEnrollmentLabels()
+		}
+
+		// a message received after the restart was sent completes the restart
+		if agent.Restart != nil && agent.Restart.Status == model.RestartStarted {
+			agent.RestartComplete("")
+		}
+
 		// always update the agent status, regardless of RemoteConfigStatus message being present
 		updateAgentStatus(s.logger, agent, state.Status.GetRemoteConfigStatus())
+		updateConfigurationStatus(agent, state.Status.GetRemoteConfigStatus())
 
 		// update ConnectedAt, etc
 		if msg.GetAgentDisconnect() != nil {
@@ -18,10 +18,12 @@ import (
 	"bytes"
 	"context"
 	"crypto/sha256"
+	"crypto/x509"
 	"encoding/hex"
 	"fmt"
 	"net/http"
 	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/open-telemetry/opamp-go/protobufs"
@@ -33,8 +35,11 @@ import (
 	"go.uber.org/zap"
 	"golang.org/x/exp/slices"
 
+	"github.com/observiq/bindplane-op/internal/agent"
 	"github.com/observiq/bindplane-op/internal/server"
+	"github.com/observiq/bindplane-op/internal/server/auth"
 	"github.com/observiq/bindplane-op/internal/server/report"
+	"github.com/observiq/bindplane-op/internal/store"
 	"github.com/observiq/bindplane-op/model"
 	"github.com/observiq/bindplane-op/model/observiq"
 )
@@ -49,6 +54,9 @@ const CollectorPackageName = "observiq-otel-collector"
 
 var compatibleOpAMPVersions = []string{"v0.2.0"}
 
+// agentPackageURLExpiration is how long the URLs that agents use to download packages from the server are valid
+const agentPackageURLExpiration = 24 * time.Hour
+
 const (
 	headerAuthorization = "Authorization"
 	headerUserAgent     = "User-Agent"
@@ -63,6 +71,17 @@ func AddRoutes(router gin.IRouter, bindplane server.BindPlane) error {
 	server := opampSvr.New(bindplane.Logger().Sugar())
 
 	callbacks := newServer(bindplane.Manager(), bindplane.Logger())
+	if config := bindplane.Config(); config != nil {
+		callbacks.admission = newAdmission(config.OpAMPMaxConnections, config.OpAMPConnectionsPerSecond)
+		if config.ServeAgentPackages {
+			callbacks.packagesURL = config.BindPlaneURL()
+			callbacks.signingKey = auth.SigningKey(config)
+		}
+		if config.SecretsKey != "" {
+			callbacks.store = bindplane.Store()
+			callbacks.secretsKey = config.SecretsKey
+		}
+	}
 	settings := opampSvr.Settings{
 		Callbacks: callbacks,
 	}
@@ -87,7 +106,18 @@ type opampServer struct {
 	manager                 server.Manager
 	connections             *connections
 	compatibleOpAMPVersions []string
+	admission               *admission
 	logger                  *zap.Logger
+
+	// packagesURL is the URL of the server used by agents to download packages from the server instead of GitHub. It is
+	// empty if the server doesn't serve agent packages. signingKey is used to sign the download URLs.
+	packagesURL string
+	signingKey  string
+
+	// store and secretsKey are used to redact the values of secrets from the drift of agents. store is nil if there is
+	// no secrets key.
+	store      store.Store
+	secretsKey string
 }
 
 var _ server.Protocol = (*opampServer)(nil)
@@ -120,17 +150,36 @@ func (s *opampServer) OnConnecting(request *http.Request) opamp.ConnectionRespon
 	ctx, span := tracer.Start(request.Context(), "opamp/connecting")
 	defer span.End()
 
-	s.logger.Info("OnConnecting", zap.Any("headers", request.Header), zap.String("RemoteAddr", request.RemoteAddr))
+	s.logger.Info("OnConnecting", zap.Any("headers", redactHeaders(request.Header)), zap.String("RemoteAddr", request.RemoteAddr))
+
+	// reject connections before doing any work if the server is busy. otherwise a slot is reserved for the connection
+	// and released if it is rejected below.
+	if response, reason, ok := s.admission.admit(); !ok {
+		s.logger.Warn("rejecting agent connection",
+			zap.String("reason", reason),
+			zap.String("RemoteAddr", request.RemoteAddr),
+			zap.String("Retry-After", response.HTTPResponseHeader["Retry-After"]),
+		)
+		recordRejectedConnection(ctx, reason)
+		return response
+	}
+	accepted := false
+	defer func() {
+		if !accepted {
+			s.admission.cancel()
+		}
+	}()
 
 	// check for compatibility
 	headers := parseAgentHeaders(request)
 	if headers == nil || !slices.Contains(s.compatibleOpAMPVersions, headers.opampVersion) {
 		// no version header, agent version is <= 1.2.0 or OpAMP version incompatible
 		s.logger.Error("unable to connect to incompatible agent",
-			zap.Any("headers", request.Header),
+			zap.Any("headers", redactHeaders(request.Header)),
 			zap.String("RemoteAddr", request.RemoteAddr),
 			zap.Strings("compatibleOpAMPVersions", s.compatibleOpAMPVersions),
 		)
+		recordRejectedConnection(ctx, rejectReasonIncompatible)
 		return opamp.ConnectionResponse{
 			Accept:         false,
 			HTTPStatusCode: http.StatusUpgradeRequired,
@@ -140,14 +189,46 @@ func (s *opampServer) OnConnecting(request *http.Request) opamp.ConnectionRespon
 		}
 	}
 
-	accept := s.manager.VerifySecretKey(ctx, headers.secretKey)
+	// the client certificate must identify the agent before it is authenticated and issued credentials
+	var certificate *x509.Certificate
+	if request.TLS != nil && len(request.TLS.PeerCertificates) > 0 {
+		certificate = request.TLS.PeerCertificates[0]
+	}
+	accept, err := s.manager.VerifyAgentCertificate(ctx, headers.id, certificate)
+	if err != nil {
+		s.logger.Error("unable to verify agent certificate", zap.String("agentID", headers.id), zap.Error(err))
+		recordRejectedConnection(ctx, rejectReasonError)
+		return opamp.ConnectionResponse{
+			Accept:         false,
+			HTTPStatusCode: http.StatusInternalServerError,
+		}
+	}
 	if !accept {
+		recordRejectedConnection(ctx, rejectReasonUnauthorized)
 		return opamp.ConnectionResponse{
 			Accept:         false,
 			HTTPStatusCode: http.StatusUnauthorized,
 		}
 	}
 
+	accept, err = s.manager.AuthenticateAgent(ctx, headers.id, headers.secretKey)
+	if err != nil {
+		s.logger.Error("unable to authenticate agent", zap.String("agentID", headers.id), zap.Error(err))
+		recordRejectedConnection(ctx, rejectReasonError)
+		return opamp.ConnectionResponse{
+			Accept:         false,
+			HTTPStatusCode: http.StatusInternalServerError,
+		}
+	}
+	if !accept {
+		recordRejectedConnection(ctx, rejectReasonUnauthorized)
+		return opamp.ConnectionResponse{
+			Accept:         false,
+			HTTPStatusCode: http.StatusUnauthorized,
+		}
+	}
+
+	accepted = true
 	return opamp.ConnectionResponse{
 		Accept:         true,
 		HTTPStatusCode: http.StatusOK,
@@ -178,6 +259,15 @@ func parseAgentHeaders(request *http.Request) *agentHeaders {
 	}
 }
 
+// redactHeaders returns a copy of the request headers that can be logged without the secret key of the agent
+func redactHeaders(header http.Header) http.Header {
+	redacted := header.Clone()
+	if redacted.Get(headerAuthorization) != "" {
+		redacted.Set(headerAuthorization, "(redacted)")
+	}
+	return redacted
+}
+
 // OnConnected is called when the WebSocket connection is successfully established after OnConnecting() returns and the
 // HTTP connection is upgraded to WebSocket.
 //
@@ -185,6 +275,9 @@ func parseAgentHeaders(request *http.Request) *agentHeaders {
 func (s *opampServer) OnConnected(conn opamp.Connection) {
 	_, span := tracer.Start(context.TODO(), "opamp/connected")
 	defer span.End()
+
+	// the connection holds the slot reserved by OnConnecting until it is closed
+	s.admission.connect()
 }
 
 // OnMessage is called when a message is received from the connection. Can happen
@@ -236,6 +329,7 @@ func (s *opampServer) OnConnectionClose(conn opamp.Connection) {
 	agentID := s.connections.agentID(conn)
 	s.logger.Info("OpAMP agent disconnected", zap.String("AgentID", agentID))
 	s.connections.disconnect(conn)
+	s.admission.disconnect()
 	if agentID == "" {
 		return
 	}
@@ -314,11 +408,16 @@ func (s *opampServer) UpdateAgent(ctx context.Context, agent *model.Agent, updat
 		newRawConfiguration := newConfiguration.Raw()
 
 		serverToAgent.RemoteConfig = agentRemoteConfig(&newRawConfiguration, &agentRawConfiguration)
+		configHash := serverToAgent.RemoteConfig.GetConfigHash()
 
 		// use a separate goroutine to avoid blocking on the channel write
 		go func() {
-			// change the agent status to Configuring, but ignore any failure as this status is considered nice to have and not required to update the agent
-			_, _ = s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) { current.Status = model.Configuring })
+			// change the agent status to Configuring and record the configuration sent, but ignore any failure as the
+			// configuration sent is recorded again when the agent reports its configuration
+			_, _ = s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) {
+				current.Status = model.Configuring
+				configurationSent(current, updates.Configuration, configHash)
+			})
 		}()
 	}
 
@@ -377,6 +476,9 @@ func (s *opampServer) getDownloadableFile(ctx context.Context, a *model.Agent, v
 
 	url := artifact.URL
 	hash := artifact.Hash
+	if s.packagesURL != "" {
+		url = s.agentPackageURL(version.Version(), platform)
+	}
 	if url == "" || hash == "" {
 		return nil, nil
 	}
@@ -392,6 +494,18 @@ func (s *opampServer) getDownloadableFile(ctx context.Context, a *model.Agent, v
 	}, nil
 }
 
+// agentPackageURL returns a signed URL of the server that agents can use to download the package of the version for the
+// platform
+func (s *opampServer) agentPackageURL(version string, platform string) string {
+	name := agent.PackageName(version, platform)
+	if name == "" {
+		return ""
+	}
+	path := fmt.Sprintf("/v1/agent-packages/%s/%s", version, name)
+	query := auth.SignPath(s.signingKey, path, time.Now().Add(agentPackageURLExpiration))
+	return fmt.Sprintf("%s%s?%s", strings.TrimSuffix(s.packagesURL, "/"), path, query.Encode())
+}
+
 // SendHeartbeat sends a heartbeat to the agent to keep the websocket open
 func (s *opampServer) SendHeartbeat(agentID string) error {
 	conn := s.connections.connection(agentID)
@@ -427,6 +541,48 @@ func (s *opampServer) RequestReport(ctx context.Context, agentID string, configu
 	return nil
 }
 
+// RestartAgent sends a Restart command to the specified agent if it is connected and accepts the command
+func (s *opampServer) RestartAgent(ctx context.Context, agent *model.Agent) error {
+	conn := s.connections.connection(agent.ID)
+	if conn == nil {
+		// agent not connected, nothing to do
+		return nil
+	}
+	ctx, span := tracer.Start(ctx, "opamp/RestartAgent", trace.WithAttributes(
+		attribute.String("bindplane.agent.id", agent.ID),
+	))
+	defer span.End()
+
+	state, err := decodeState(agent.State)
+	if err != nil || !hasCapability(&state.Status, protobufs.AgentCapabilities_AcceptsRestartCommand) {
+		err = fmt.Errorf("agent %s does not support restart", agent.ID)
+		_, _ = s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) {
+			current.RestartComplete(err.Error())
+		})
+		return err
+	}
+
+	s.logger.Info("sending restart command to the agent", zap.String("agentID", agent.ID))
+	err = s.send(context.Background(), conn, &protobufs.ServerToAgent{
+		InstanceUid:  agent.ID,
+		Capabilities: capabilities,
+		Command: &protobufs.ServerToAgentCommand{
+			Type: protobufs.ServerToAgentCommand_Restart,
+		},
+	})
+	if err != nil {
+		_, _ = s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) {
+			current.RestartComplete(err.Error())
+		})
+		return err
+	}
+
+	_, err = s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) {
+		current.RestartStarted()
+	})
+	return err
+}
+
 func computeReportConfigurationHash(contents ...[]byte) []byte {
 	h := sha256.New()
 	for _, b := range contents {
@@ -454,11 +610,12 @@ func (s *opampServer) verifyAgentConfig(ctx context.Context, conn opamp.Connecti
 		return fmt.Errorf("unable to update agent [%s]: %w", agentID, err)
 	}
 
-	return s.updateAgentConfig(ctx, agent, state, response)
+	return s.updateAgentConfig(ctx, agent, state, message.GetEffectiveConfig() != nil, response)
 }
 
-// updateAgentConfig updates the current configuration by setting the RemoteConfig message if necessary
-func (s *opampServer) updateAgentConfig(ctx context.Context, agent *model.Agent, state *agentState, response *protobufs.ServerToAgent) error {
+// updateAgentConfig updates the current configuration by setting the RemoteConfig message if necessary. If the agent
+// reported its effective configuration, the drift of the agent is also updated.
+func (s *opampServer) updateAgentConfig(ctx context.Context, agent *model.Agent, state *agentState, reported bool, response *protobufs.ServerToAgent) error {
 	agentRawConfiguration := state.Configuration()
 	if agentRawConfiguration == nil {
 		s.logger.Info("no configuration available to verify, requesting from agent")
@@ -483,12 +640,24 @@ func (s *opampServer) updateAgentConfig(ctx context.Context, agent *model.Agent,
 		return fmt.Errorf("unable to compute the updated agent configuration [%s]: %w", agent.ID, err)
 	}
 
+	if reported {
+		s.updateAgentDrift(ctx, agent, updates.Configuration, serverConfiguration.Collector, agentConfiguration.Collector)
+	}
+
 	// compare the configurations and compute a difference
 	newConfiguration := observiq.ComputeConfigurationUpdates(&serverConfiguration, agentConfiguration)
 
 	if newConfiguration.Empty() {
 		// existing config is correct
 		s.logger.Info("agent running with the correct config")
+		if updates.Configuration != nil && !agent.ConfigurationStatus.Sent(updates.Configuration, "") {
+			_, err := s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) {
+				configurationSent(current, updates.Configuration, nil)
+			})
+			if err != nil {
+				s.logger.Error("unable to update agent configuration status", zap.String("agentID", agent.ID), zap.Error(err))
+			}
+		}
 		return nil
 	}
 
@@ -498,12 +667,20 @@ func (s *opampServer) updateAgentConfig(ctx context.Context, agent *model.Agent,
 	// check to see if we already tried this and received an error
 	if bytes.Equal(state.Status.GetRemoteConfigStatus().GetLastRemoteConfigHash(), remoteConfig.GetConfigHash()) {
 		s.logger.Info("already attempted to send this configuration")
+		if updates.Configuration != nil && !agent.ConfigurationStatus.Sent(updates.Configuration, hex.EncodeToString(remoteConfig.GetConfigHash())) {
+			_, _ = s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) {
+				configurationSent(current, updates.Configuration, remoteConfig.GetConfigHash())
+			})
+		}
 		return nil
 	}
 
-	// change the agent status to Configuring, but ignore any failure as this status is considered nice to have and not
-	// required to update the agent
-	_, _ = s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) { current.Status = model.Configuring })
+	// change the agent status to Configuring and record the configuration sent, but ignore any failure as the
+	// configuration sent is recorded again when the agent reports its configuration
+	_, _ = s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) {
+		current.Status = model.Configuring
+		configurationSent(current, updates.Configuration, remoteConfig.GetConfigHash())
+	})
 
 	s.logger.Info("agent running with outdated config", zap.Any("cur", agentConfiguration.Collector), zap.Any("new", serverConfiguration.Collector))
 	response.RemoteConfig = remoteConfig
@@ -511,6 +688,44 @@ func (s *opampServer) updateAgentConfig(ctx context.Context, agent *model.Agent,
 	return nil
 }
 
+// updateAgentDrift records whether the collector configuration reported by the agent differs from the configuration
+// rendered for it. Agents without a configuration have no drift.
+func (s *opampServer) updateAgentDrift(ctx context.Context, agent *model.Agent, configuration *model.Configuration, rendered string, reported string) {
+	var secrets []string
+	if s.store != nil && configuration != nil && rendered != reported {
+		var err error
+		secrets, err = store.SecretValues(ctx, s.store, s.secretsKey)
+		if err != nil {
+			// the drift cannot be stored without redacting the secrets
+			s.logger.Error("unable to read secrets to redact agent drift", zap.String("agentID", agent.ID), zap.Error(err))
+			return
+		}
+	}
+
+	update := func(current *model.Agent) bool {
+		if configuration == nil {
+			changed := current.Drift != nil
+			current.Drift = nil
+			return changed
+		}
+		return current.UpdateDrift(configuration.Name(), rendered, reported, secrets, time.Now())
+	}
+
+	// check a copy first to avoid storing the agent when nothing changed
+	check := *agent
+	if !update(&check) {
+		return
+	}
+	if check.Drifted() {
+		s.logger.Info("agent configuration drift detected", zap.String("agentID", agent.ID), zap.String("configuration", check.Drift.Configuration))
+	}
+
+	_, err := s.manager.UpsertAgent(ctx, agent.ID, func(current *model.Agent) { update(current) })
+	if err != nil {
+		s.logger.Error("unable to update agent drift", zap.String("agentID", agent.ID), zap.Error(err))
+	}
+}
+
 func (s *opampServer) updatedConfiguration(ctx context.Context, agent *model.Agent, agentConfiguration *observiq.AgentConfiguration, updates *server.AgentUpdates) (diff observiq.AgentConfiguration, err error) {
 	// Configuration => collector.yaml
 	if updates.Configuration != nil {
@@ -527,6 +742,14 @@ func (s *opampServer) updatedConfiguration(ctx context.Context, agent *model.Age
 		diff.ReplaceLabels(updates.Labels.String())
 	}
 
+	// Credentials => manager.yaml
+	if agent.Credentials.Pending() && !agentConfiguration.HasSecretKey(agent.Credentials.PendingSecretKey) {
+		if diff.Manager == nil {
+			diff.Manager = agentConfiguration.Manager
+		}
+		diff.ReplaceSecretKey(agent.Credentials.PendingSecretKey)
+	}
+
 	return diff, nil
 }
 
@@ -552,6 +775,14 @@ func agentRemoteConfig(updates *observiq.RawAgentConfiguration, agentRaw *observ
 	}
 }
 
+// configurationSent records the revision of the configuration and the hash of the remote configuration sent to the
+// agent. A nil hash indicates that the agent is already using the configuration.
+func configurationSent(agent *model.Agent, configuration *model.Configuration, hash []byte) {
+	if configuration != nil {
+		agent.ConfigurationSent(configuration, hex.EncodeToString(hash))
+	}
+}
+
 func computeHash(updates *observiq.RawAgentConfiguration, agentRaw *observiq.RawAgentConfiguration) []byte {
 	combined := agentRaw.ApplyUpdates(updates)
 	return combined.Hash()
@@ -16,6 +16,11 @@ package opamp
 
 import (
 	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"errors"
 	"fmt"
 	"net"
 	"net/http"
@@ -28,6 +33,7 @@ import (
 	"github.com/observiq/bindplane-op/internal/server"
 	"github.com/observiq/bindplane-op/internal/server/mocks"
 	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/internal/store/search"
 	"github.com/observiq/bindplane-op/model"
 	"github.com/observiq/bindplane-op/model/observiq"
 	"github.com/open-telemetry/opamp-go/protobufs"
@@ -75,6 +81,65 @@ func TestServerSendHeartbeat(t *testing.T) {
 	conn.AssertExpectations(t)
 }
 
+func TestServerRestartAgent(t *testing.T) {
+	newAgent := func(id string, capabilities protobufs.AgentCapabilities) *model.Agent {
+		agent := &model.Agent{ID: id, Status: model.Connected}
+		agent.State = encodeState(&agentState{Status: protobufs.AgentToServer{Capabilities: capabilities}})
+		agent.RequestRestart()
+		return agent
+	}
+
+	t.Run("sends the restart command to agents that accept it", func(t *testing.T) {
+		manager := &mocks.Manager{}
+		conn := &mocks.Connection{}
+		server := testServer(manager)
+		server.connections.connect(conn, "known")
+
+		agent := newAgent("known", protobufs.AgentCapabilities_ReportsStatus|protobufs.AgentCapabilities_AcceptsRestartCommand)
+		conn.On("Send", mock.Anything, mock.MatchedBy(func(msg *protobufs.ServerToAgent) bool {
+			return msg.GetCommand().GetType() == protobufs.ServerToAgentCommand_Restart
+		})).Return(nil)
+		manager.On("UpsertAgent", mock.Anything, "known", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(2).(store.AgentUpdater)(agent)
+		}).Return(agent, nil)
+
+		require.NoError(t, server.RestartAgent(context.Background(), agent))
+		require.Equal(t, model.Restarting, agent.Status)
+		require.Equal(t, model.RestartStarted, agent.Restart.Status)
+
+		conn.AssertExpectations(t)
+		manager.AssertExpectations(t)
+	})
+
+	t.Run("fails the restart of agents that do not accept the command", func(t *testing.T) {
+		manager := &mocks.Manager{}
+		conn := &mocks.Connection{}
+		server := testServer(manager)
+		server.connections.connect(conn, "known")
+
+		agent := newAgent("known", protobufs.AgentCapabilities_ReportsStatus)
+		manager.On("UpsertAgent", mock.Anything, "known", mock.Anything).Run(func(args mock.Arguments) {
+			args.Get(2).(store.AgentUpdater)(agent)
+		}).Return(agent, nil)
+
+		require.Error(t, server.RestartAgent(context.Background(), agent))
+		require.Equal(t, model.Connected, agent.Status)
+		require.Equal(t, model.RestartFailed, agent.Restart.Status)
+		require.NotEmpty(t, agent.Restart.Error)
+
+		conn.AssertNotCalled(t, "Send", mock.Anything, mock.Anything)
+		manager.AssertExpectations(t)
+	})
+
+	t.Run("ignores agents that are not connected", func(t *testing.T) {
+		manager := &mocks.Manager{}
+		server := testServer(manager)
+
+		require.NoError(t, server.RestartAgent(context.Background(), newAgent("unknown", protobufs.AgentCapabilities_AcceptsRestartCommand)))
+		manager.AssertExpectations(t)
+	})
+}
+
 type TestAddr struct {
 	network string
 	address string
@@ -244,6 +309,7 @@ func TestServerOnConnecting(t *testing.T) {
 	goodKey := "secret"
 	badKey := "other"
 	noKey := ""
+	errorKey := "error"
 	tests := []struct {
 		name          string
 		authorization string
@@ -273,6 +339,14 @@ func TestServerOnConnecting(t *testing.T) {
 				HTTPStatusCode: http.StatusOK,
 			},
 		},
+		{
+			name:          "authentication error",
+			authorization: fmt.Sprintf("Secret-Key %s", errorKey),
+			expect: opamp.ConnectionResponse{
+				Accept:         false,
+				HTTPStatusCode: http.StatusInternalServerError,
+			},
+		},
 		{
 			name:          "bad format",
 			authorization: badKey,
@@ -293,9 +367,11 @@ func TestServerOnConnecting(t *testing.T) {
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
 			manager := &mocks.Manager{}
-			manager.On("VerifySecretKey", mock.Anything, goodKey).Return(true)
-			manager.On("VerifySecretKey", mock.Anything, badKey).Return(false)
-			manager.On("VerifySecretKey", mock.Anything, noKey).Return(false)
+			manager.On("VerifyAgentCertificate", mock.Anything, "", (*x509.Certificate)(nil)).Return(true, nil)
+			manager.On("AuthenticateAgent", mock.Anything, "", goodKey).Return(true, nil)
+			manager.On("AuthenticateAgent", mock.Anything, "", badKey).Return(false, nil)
+			manager.On("AuthenticateAgent", mock.Anything, "", noKey).Return(false, nil)
+			manager.On("AuthenticateAgent", mock.Anything, "", errorKey).Return(false, errors.New("store unavailable"))
 			server := testServer(manager)
 			server.compatibleOpAMPVersions = []string{"v0.2.0"}
 			request := &http.Request{
@@ -313,6 +389,56 @@ func TestServerOnConnecting(t *testing.T) {
 	}
 }
 
+func TestServerOnConnectingCertificate(t *testing.T) {
+	certificate := &x509.Certificate{Subject: pkix.Name{CommonName: "agent-1"}}
+	request := &http.Request{
+		Header: http.Header{
+			"Opamp-Version": []string{"v0.2.0"},
+			"Agent-Id":      []string{"agent-1"},
+			"Authorization": []string{"Secret-Key secret"},
+		},
+		TLS: &tls.ConnectionState{PeerCertificates: []*x509.Certificate{certificate}},
+	}
+
+	t.Run("rejected certificate", func(t *testing.T) {
+		manager := &mocks.Manager{}
+		manager.On("VerifyAgentCertificate", mock.Anything, "agent-1", certificate).Return(false, nil)
+		server := testServer(manager)
+		server.compatibleOpAMPVersions = []string{"v0.2.0"}
+
+		response := server.OnConnecting(request)
+		require.False(t, response.Accept)
+		require.Equal(t, http.StatusUnauthorized, response.HTTPStatusCode)
+		manager.AssertNotCalled(t, "AuthenticateAgent", mock.Anything, mock.Anything, mock.Anything)
+	})
+
+	t.Run("accepted certificate", func(t *testing.T) {
+		manager := &mocks.Manager{}
+		manager.On("VerifyAgentCertificate", mock.Anything, "agent-1", certificate).Return(true, nil)
+		manager.On("AuthenticateAgent", mock.Anything, "agent-1", "secret").Return(true, nil)
+		server := testServer(manager)
+		server.compatibleOpAMPVersions = []string{"v0.2.0"}
+
+		response := server.OnConnecting(request)
+		require.True(t, response.Accept)
+		require.Equal(t, http.StatusOK, response.HTTPStatusCode)
+		manager.AssertExpectations(t)
+	})
+}
+
+func TestRedactHeaders(t *testing.T) {
+	header := http.Header{
+		"Agent-Id":      []string{"agent-1"},
+		"Authorization": []string{"Secret-Key secret"},
+	}
+	redacted := redactHeaders(header)
+	require.Equal(t, "(redacted)", redacted.Get("Authorization"))
+	require.Equal(t, "agent-1", redacted.Get("Agent-Id"))
+	require.Equal(t, "Secret-Key secret", header.Get("Authorization"), "redacting should not modify the original")
+
+	require.Empty(t, redactHeaders(http.Header{}).Get("Authorization"))
+}
+
 func makeAgentDescription(version string) *protobufs.AgentDescription {
 	return &protobufs.AgentDescription{
 		IdentifyingAttributes: []*protobufs.KeyValue{
@@ -669,6 +795,178 @@ service:
 	}
 }
 
+func TestServerOnMessageCredentials(t *testing.T) {
+	agentID := "0b9a3f0e-9b56-4d0b-9e56-3a8f6b0f3c1d"
+	ctx := context.Background()
+
+	testMapStore := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "supersecret-key",
+		MaxEventsToMerge: 1000,
+	}, zap.NewNop())
+	testManager, err := server.NewManager(&common.Server{SecretKey: "secret"}, testMapStore, nil, zap.NewNop())
+	require.NoError(t, err)
+	server := testServer(testManager)
+
+	token := model.NewEnrollmentToken("production", model.EnrollmentTokenSpec{Labels: map[string]string{"env": "production"}})
+	_, err = testMapStore.ApplyResources(ctx, []model.Resource{token})
+	require.NoError(t, err)
+	token, err = testMapStore.EnrollmentToken(ctx, "production")
+	require.NoError(t, err)
+
+	accept, err := testManager.AuthenticateAgent(ctx, agentID, token.Token())
+	require.NoError(t, err)
+	require.True(t, accept)
+
+	agent, err := testMapStore.Agent(ctx, agentID)
+	require.NoError(t, err)
+	secretKey := agent.Credentials.PendingSecretKey
+	require.NotEmpty(t, secretKey)
+
+	message := func(sequenceNum uint64, manager string) *protobufs.AgentToServer {
+		return &protobufs.AgentToServer{
+			SequenceNum:  sequenceNum,
+			InstanceUid:  agentID,
+			Capabilities: protobufs.AgentCapabilities_ReportsEffectiveConfig | protobufs.AgentCapabilities_AcceptsRemoteConfig,
+			EffectiveConfig: &protobufs.EffectiveConfig{
+				ConfigMap: &protobufs.AgentConfigMap{
+					ConfigMap: map[string]*protobufs.AgentConfigFile{
+						observiq.ManagerFilename:   {Body: []byte(manager)},
+						observiq.CollectorFilename: {Body: []byte("")},
+						observiq.LoggingFilename:   {Body: []byte("")},
+					},
+				},
+			},
+			AgentDescription: makeAgentDescription("1.0"),
+		}
+	}
+
+	conn := &testConnection{addr: testAddr{"127.0.0.1"}}
+
+	// the issued secret key and the enrollment labels are sent to the agent
+	result := server.OnMessage(conn, message(1, "labels: a=b,c=d,configuration=api-test"))
+	managerConfig := result.GetRemoteConfig().GetConfig().GetConfigMap()[observiq.ManagerFilename]
+	require.NotNil(t, managerConfig)
+	require.Contains(t, string(managerConfig.Body), secretKey)
+	require.Contains(t, string(managerConfig.Body), "env=production")
+
+	agent, err = testMapStore.Agent(ctx, agentID)
+	require.NoError(t, err)
+	require.True(t, agent.Credentials.Pending())
+	require.Equal(t, "production", agent.Labels.Set["env"])
+
+	// the agent reports the issued secret key which confirms the credentials
+	result = server.OnMessage(conn, message(2, fmt.Sprintf("labels: a=b,c=d,configuration=api-test,env=production\nsecret_key: %s", secretKey)))
+	require.Nil(t, result.GetRemoteConfig())
+
+	agent, err = testMapStore.Agent(ctx, agentID)
+	require.NoError(t, err)
+	require.False(t, agent.Credentials.Pending())
+	require.True(t, agent.Credentials.Verify(secretKey))
+
+	accept, err = testManager.AuthenticateAgent(ctx, agentID, token.Token())
+	require.NoError(t, err)
+	require.False(t, accept)
+}
+
+func TestServerUpdateAgentDriftRedactsSecrets(t *testing.T) {
+	agentID := "5c2a3f7e-8d0b-4b6a-9a0f-2f4d3b1c7e9a"
+	ctx := context.Background()
+
+	testMapStore := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "supersecret-key",
+		MaxEventsToMerge: 1000,
+	}, zap.NewNop())
+	testManager, err := server.NewManager(&common.Server{SecretKey: "secret"}, testMapStore, nil, zap.NewNop())
+	require.NoError(t, err)
+	server := testServer(testManager)
+	server.store = testMapStore
+	server.secretsKey = "key"
+
+	secret := model.NewSecret("token", "s3cr3t")
+	require.NoError(t, secret.Encrypt(server.secretsKey))
+	_, err = testMapStore.ApplyResources(ctx, []model.Resource{secret})
+	require.NoError(t, err)
+
+	agent, err := testMapStore.UpsertAgent(ctx, agentID, func(current *model.Agent) {})
+	require.NoError(t, err)
+
+	configuration := model.NewRawConfiguration("api-test", "")
+	server.updateAgentDrift(ctx, agent, configuration, "authorization: Bearer s3cr3t\n", "authorization: Bearer other\n")
+
+	agent, err = testMapStore.Agent(ctx, agentID)
+	require.NoError(t, err)
+	require.True(t, agent.Drifted())
+	require.Contains(t, agent.Drift.Diff, "-authorization: Bearer (redacted)")
+	require.NotContains(t, agent.Drift.Diff, "s3cr3t")
+}
+
+func TestServerOnMessageDrift(t *testing.T) {
+	agentID := "5c2a3f7e-8d0b-4b6a-9a0f-2f4d3b1c7e9a"
+	ctx := context.Background()
+
+	testMapStore := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "supersecret-key",
+		MaxEventsToMerge: 1000,
+	}, zap.NewNop())
+	testManager, err := server.NewManager(&common.Server{SecretKey: "secret"}, testMapStore, nil, zap.NewNop())
+	require.NoError(t, err)
+	server := testServer(testManager)
+
+	configuration := model.NewRawConfiguration("api-test", "receivers:\n  otlp:\n")
+	configuration.Spec.Selector = model.AgentSelector{MatchLabels: model.MatchLabels{"configuration": "api-test"}}
+	_, err = testMapStore.ApplyResources(ctx, []model.Resource{configuration})
+	require.NoError(t, err)
+
+	message := func(sequenceNum uint64, collector string) *protobufs.AgentToServer {
+		return &protobufs.AgentToServer{
+			SequenceNum:  sequenceNum,
+			InstanceUid:  agentID,
+			Capabilities: protobufs.AgentCapabilities_ReportsEffectiveConfig | protobufs.AgentCapabilities_AcceptsRemoteConfig,
+			EffectiveConfig: &protobufs.EffectiveConfig{
+				ConfigMap: &protobufs.AgentConfigMap{
+					ConfigMap: map[string]*protobufs.AgentConfigFile{
+						observiq.ManagerFilename:   {Body: []byte("labels: a=b,c=d,configuration=api-test")},
+						observiq.CollectorFilename: {Body: []byte(collector)},
+						observiq.LoggingFilename:   {Body: []byte("")},
+					},
+				},
+			},
+			AgentDescription: makeAgentDescription("1.0"),
+		}
+	}
+
+	conn := &testConnection{addr: testAddr{"127.0.0.1"}}
+
+	// the agent reports the rendered configuration
+	server.OnMessage(conn, message(1, "receivers:\n  otlp:\n"))
+	agent, err := testMapStore.Agent(ctx, agentID)
+	require.NoError(t, err)
+	require.False(t, agent.Drifted())
+
+	// the agent reports a modified configuration
+	server.OnMessage(conn, message(2, "receivers:\n  hostmetrics:\n"))
+	agent, err = testMapStore.Agent(ctx, agentID)
+	require.NoError(t, err)
+	require.True(t, agent.Drifted())
+	require.Equal(t, "api-test", agent.Drift.Configuration)
+	require.Contains(t, agent.Drift.Diff, "-  otlp:")
+	require.Contains(t, agent.Drift.Diff, "+  hostmetrics:")
+
+	drifted, err := testMapStore.Agents(ctx, store.WithQuery(search.ParseQuery("drift:true")))
+	require.NoError(t, err)
+	require.Len(t, drifted, 1)
+
+	// the agent applies the rendered configuration
+	server.OnMessage(conn, message(3, "receivers:\n  otlp:\n"))
+	agent, err = testMapStore.Agent(ctx, agentID)
+	require.NoError(t, err)
+	require.False(t, agent.Drifted())
+
+	drifted, err = testMapStore.Agents(ctx, store.WithQuery(search.ParseQuery("drift:true")))
+	require.NoError(t, err)
+	require.Len(t, drifted, 0)
+}
+
 func TestUpdateAgentStatus(t *testing.T) {
 	tests := []struct {
 		name                string
@@ -693,6 +991,11 @@ func TestUpdateAgentStatus(t *testing.T) {
 			initialStatus: model.Error,
 			expectStatus:  model.Error,
 		},
+		{
+			name:          "nil status, preserve Restarting",
+			initialStatus: model.Restarting,
+			expectStatus:  model.Restarting,
+		},
 		{
 			name:                "UNSET status, preserve Error",
 			initialStatus:       model.Error,
@@ -749,6 +1052,48 @@ func TestUpdateAgentStatus(t *testing.T) {
 	}
 }
 
+func TestUpdateConfigurationStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		remoteStatus *protobufs.RemoteConfigStatus
+		expect       *model.AgentConfigurationStatus
+	}{
+		{
+			name: "nil status",
+		},
+		{
+			name: "no hash",
+			remoteStatus: &protobufs.RemoteConfigStatus{
+				Status: protobufs.RemoteConfigStatus_APPLIED,
+			},
+		},
+		{
+			name: "APPLIED status",
+			remoteStatus: &protobufs.RemoteConfigStatus{
+				LastRemoteConfigHash: []byte{0xab, 0x01},
+				Status:               protobufs.RemoteConfigStatus_APPLIED,
+			},
+			expect: &model.AgentConfigurationStatus{ReportedHash: "ab01", ReportedStatus: model.RemoteConfigApplied},
+		},
+		{
+			name: "FAILED status",
+			remoteStatus: &protobufs.RemoteConfigStatus{
+				LastRemoteConfigHash: []byte{0xab, 0x01},
+				Status:               protobufs.RemoteConfigStatus_FAILED,
+				ErrorMessage:         "error",
+			},
+			expect: &model.AgentConfigurationStatus{ReportedHash: "ab01", ReportedStatus: model.RemoteConfigFailed},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			agent := &model.Agent{}
+			updateConfigurationStatus(agent, test.remoteStatus)
+			require.Equal(t, test.expect, agent.ConfigurationStatus)
+		})
+	}
+}
+
 func TestOnConnectingOpAMPCompatibility(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -843,7 +1188,8 @@ func TestOnConnectingOpAMPCompatibility(t *testing.T) {
 	}
 
 	for _, test := range tests {
-		testManager, err := server.NewManager(&common.Server{SecretKey: "a0f1db77-818a-4f1a-81a3-7b6a9613ef41"}, nil, nil, zap.NewNop())
+		testStore := store.NewMapStore(context.TODO(), store.Options{SessionsSecret: "super-secret-key"}, zap.NewNop())
+		testManager, err := server.NewManager(&common.Server{SecretKey: "a0f1db77-818a-4f1a-81a3-7b6a9613ef41"}, testStore, nil, zap.NewNop())
 		require.NoError(t, err)
 		testServer := newServer(testManager, zap.NewNop())
 		testServer.compatibleOpAMPVersions = []string{"v0.2.0"}
@@ -855,3 +1201,42 @@ func TestOnConnectingOpAMPCompatibility(t *testing.T) {
 		})
 	}
 }
+
+func TestServerGetDownloadableFile(t *testing.T) {
+	hash := "80f2030439757a93f3471c94adc31f9f353daff5b32e6c26cee5a8ed55602976"
+	agentVersion := model.NewAgentVersion(model.AgentVersionSpec{
+		Type:    "observiq-otel-collector",
+		Version: "v1.14.0",
+		Download: map[string]model.AgentDownload{
+			"linux/amd64": {
+				URL:  "https://github.com/observIQ/observiq-otel-collector/releases/download/v1.14.0/observiq-otel-collector-v1.14.0-linux-amd64.tar.gz",
+				Hash: hash,
+			},
+		},
+	})
+	agent := &model.Agent{ID: "agent-1", Platform: "linux", Architecture: "amd64"}
+
+	manager := &mocks.Manager{}
+	manager.On("AgentVersion", mock.Anything, "v1.14.0").Return(agentVersion, nil)
+
+	t.Run("release url", func(t *testing.T) {
+		server := testServer(manager)
+
+		file, err := server.getDownloadableFile(context.Background(), agent, "v1.14.0")
+		require.NoError(t, err)
+		require.Equal(t, agentVersion.Spec.Download["linux/amd64"].URL, file.DownloadUrl)
+		require.Equal(t, hash, hex.EncodeToString(file.ContentHash))
+	})
+
+	t.Run("server url", func(t *testing.T) {
+		server := testServer(manager)
+		server.packagesURL = "https://bindplane.example.com:3001"
+		server.signingKey = "secret"
+
+		file, err := server.getDownloadableFile(context.Background(), agent, "v1.14.0")
+		require.NoError(t, err)
+		require.True(t, strings.HasPrefix(file.DownloadUrl, "https://bindplane.example.com:3001/v1/agent-packages/v1.14.0/observiq-otel-collector-v1.14.0-linux-amd64.tar.gz?expires="), file.DownloadUrl)
+		require.Contains(t, file.DownloadUrl, "&signature=")
+		require.Equal(t, hash, hex.EncodeToString(file.ContentHash))
+	})
+}
@@ -88,3 +88,10 @@ func (c *connections) agentIDs() []string {
 	}
 	return ids
 }
+
+// count returns the number of connected agents
+func (c *connections) count() int {
+	c.mtx.RLock()
+	defer c.mtx.RUnlock()
+	return len(c.connections)
+}
@@ -16,6 +16,7 @@ package opamp
 
 import (
 	"context"
+	"encoding/hex"
 
 	"github.com/observiq/bindplane-op/model"
 	"github.com/open-telemetry/opamp-go/protobufs"
@@ -66,9 +67,29 @@ func updateAgentStatus(logger *zap.Logger, agent *model.Agent, remoteStatus *pro
 		}
 	case model.Upgrading:
 		// upgrading will be cleared by model.Agent.UpgradeComplete
+	case model.Restarting:
+		// restarting will be cleared by model.Agent.RestartComplete
 	default:
 		// either RemoteConfigStatus wasn't sent or wasn't failed
 		agent.Status = model.Connected
 		agent.ErrorMessage = ""
 	}
 }
+
+// updateConfigurationStatus records the status reported by the agent for the last remote configuration it received
+func updateConfigurationStatus(agent *model.Agent, remoteStatus *protobufs.RemoteConfigStatus) {
+	hash := remoteStatus.GetLastRemoteConfigHash()
+	if len(hash) == 0 {
+		return
+	}
+	var status model.RemoteConfigStatus
+	switch remoteStatus.GetStatus() {
+	case protobufs.RemoteConfigStatus_APPLIED:
+		status = model.RemoteConfigApplied
+	case protobufs.RemoteConfigStatus_APPLYING:
+		status = model.RemoteConfigApplying
+	case protobufs.RemoteConfigStatus_FAILED:
+		status = model.RemoteConfigFailed
+	}
+	agent.ConfigurationReported(hex.EncodeToString(hash), status)
+}
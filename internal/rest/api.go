@@ -15,16 +15,20 @@
 package rest
 
 import (
+	"context"
 	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/hashicorp/go-multierror"
 	"go.opentelemetry.io/otel"
 	"go.uber.org/zap"
 
+	"github.com/observiq/bindplane-op/internal/agent"
 	"github.com/observiq/bindplane-op/internal/server"
 	"github.com/observiq/bindplane-op/internal/store"
 	"github.com/observiq/bindplane-op/internal/store/search"
@@ -37,54 +41,100 @@ var tracer = otel.Tracer("rest")
 // AddRestRoutes adds all API routes to the gin HTTP router
 func AddRestRoutes(router gin.IRouter, bindplane server.BindPlane) {
 	router.GET("/agents", func(c *gin.Context) { agents(c, bindplane) })
+	router.GET("/agents/drift", func(c *gin.Context) { driftedAgents(c, bindplane) })
 	router.GET("/agents/:id", func(c *gin.Context) { getAgent(c, bindplane) })
 	router.DELETE("/agents", func(c *gin.Context) { deleteAgents(c, bindplane) })
 	router.PATCH("/agents/labels", func(c *gin.Context) { labelAgents(c, bindplane) })
 	router.GET("/agents/:id/labels", func(c *gin.Context) { getAgentLabels(c, bindplane) })
 	router.PATCH("/agents/:id/labels", func(c *gin.Context) { patchAgentLabels(c, bindplane) })
 	router.PUT("/agents/:id/restart", func(c *gin.Context) { restartAgent(c, bindplane) })
+	router.PUT("/agents/restart", func(c *gin.Context) { restartAgents(c, bindplane) })
+	router.DELETE("/agents/:id/credentials", func(c *gin.Context) { revokeAgentCredentials(c, bindplane) })
 	router.POST("/agents/:id/version", func(c *gin.Context) { upgradeAgent(c, bindplane) })
 	router.PATCH("/agents/version", func(c *gin.Context) { upgradeAgents(c, bindplane) })
 	router.GET("/agents/:id/configuration", func(c *gin.Context) { getAgentConfiguration(c, bindplane) })
+	router.GET("/agents/:id/history", func(c *gin.Context) { getAgentHistory(c, bindplane) })
 
 	router.GET("/agent-versions", func(c *gin.Context) { agentVersions(c, bindplane) })
 	router.GET("/agent-versions/:name", func(c *gin.Context) { agentVersion(c, bindplane) })
 	router.DELETE("/agent-versions/:name", func(c *gin.Context) { deleteAgentVersion(c, bindplane) })
+	router.GET("/agent-versions/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindAgentVersion) })
 	router.GET("/agent-versions/:name/install-command", func(c *gin.Context) { getInstallCommand(c, bindplane) })
 	router.POST("/agent-versions/:name/sync", func(c *gin.Context) { syncAgentVersion(c, bindplane) })
 
+	router.GET("/agent-packages/:version/:name", func(c *gin.Context) { agentPackage(c, bindplane) })
+	router.PUT("/agent-packages/:version/:name", func(c *gin.Context) { importAgentPackage(c, bindplane) })
+
+	router.GET("/enrollment-tokens", func(c *gin.Context) { enrollmentTokens(c, bindplane) })
+	router.GET("/enrollment-tokens/:name", func(c *gin.Context) { enrollmentToken(c, bindplane) })
+	router.DELETE("/enrollment-tokens/:name", func(c *gin.Context) { deleteEnrollmentToken(c, bindplane) })
+	router.GET("/enrollment-tokens/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindEnrollmentToken) })
+
+	router.GET("/secrets", func(c *gin.Context) { secrets(c, bindplane) })
+	router.GET("/secrets/:name", func(c *gin.Context) { secret(c, bindplane) })
+	router.DELETE("/secrets/:name", func(c *gin.Context) { deleteSecret(c, bindplane) })
+	router.GET("/secrets/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindSecret) })
+
 	router.GET("/configurations", func(c *gin.Context) { configurations(c, bindplane) })
 	router.GET("/configurations/:name", func(c *gin.Context) { configuration(c, bindplane) })
 	router.DELETE("/configurations/:name", func(c *gin.Context) { deleteConfiguration(c, bindplane) })
+	router.GET("/configurations/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindConfiguration) })
 	router.POST("/configurations/:name/copy", func(c *gin.Context) { copyConfig(c, bindplane) })
+	router.GET("/configurations/:name/revisions", func(c *gin.Context) { configurationRevisions(c, bindplane) })
+	router.POST("/configurations/:name/rollback", func(c *gin.Context) { rollbackConfiguration(c, bindplane) })
 
 	router.GET("/sources", func(c *gin.Context) { sources(c, bindplane) })
 	router.GET("/sources/:name", func(c *gin.Context) { source(c, bindplane) })
 	router.DELETE("/sources/:name", func(c *gin.Context) { deleteSource(c, bindplane) })
+	router.GET("/sources/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindSource) })
 
 	router.GET("/source-types", func(c *gin.Context) { sourceTypes(c, bindplane) })
 	router.GET("/source-types/:name", func(c *gin.Context) { sourceType(c, bindplane) })
 	router.DELETE("/source-types/:name", func(c *gin.Context) { deleteSourceType(c, bindplane) })
+	router.GET("/source-types/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindSourceType) })
 
 	router.GET("/processors", func(c *gin.Context) { processors(c, bindplane) })
 	router.GET("/processors/:name", func(c *gin.Context) { processor(c, bindplane) })
 	router.DELETE("/processors/:name", func(c *gin.Context) { deleteProcessor(c, bindplane) })
+	router.GET("/processors/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindProcessor) })
 
 	router.GET("/processor-types", func(c *gin.Context) { processorTypes(c, bindplane) })
 	router.GET("/processor-types/:name", func(c *gin.Context) { processorType(c, bindplane) })
 	router.DELETE("/processor-types/:name", func(c *gin.Context) { deleteProcessorType(c, bindplane) })
+	router.GET("/processor-types/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindProcessorType) })
 
 	router.GET("/destinations", func(c *gin.Context) { destinations(c, bindplane) })
 	router.GET("/destinations/:name", func(c *gin.Context) { destination(c, bindplane) })
 	router.DELETE("/destinations/:name", func(c *gin.Context) { deleteDestination(c, bindplane) })
+	router.GET("/destinations/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindDestination) })
 
 	router.GET("/destination-types", func(c *gin.Context) { destinationTypes(c, bindplane) })
 	router.GET("/destination-types/:name", func(c *gin.Context) { destinationType(c, bindplane) })
 	router.DELETE("/destination-types/:name", func(c *gin.Context) { deleteDestinationType(c, bindplane) })
+	router.GET("/destination-types/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindDestinationType) })
+
+	router.GET("/extension-types", func(c *gin.Context) { extensionTypes(c, bindplane) })
+	router.GET("/extension-types/:name", func(c *gin.Context) { extensionType(c, bindplane) })
+	router.DELETE("/extension-types/:name", func(c *gin.Context) { deleteExtensionType(c, bindplane) })
+	router.GET("/extension-types/:name/dependents", func(c *gin.Context) { dependents(c, bindplane, model.KindExtensionType) })
 
 	router.POST("/apply", func(c *gin.Context) { applyResources(c, bindplane) })
 	router.POST("/delete", func(c *gin.Context) { deleteResources(c, bindplane) })
 
+	router.GET("/audit", func(c *gin.Context) { auditEntries(c, bindplane) })
+
+	router.GET("/rollouts", func(c *gin.Context) { rollouts(c, bindplane) })
+	router.GET("/rollouts/:name", func(c *gin.Context) { rollout(c, bindplane) })
+	router.PUT("/rollouts/:name/pause", func(c *gin.Context) { updateRollout(c, bindplane.Manager().PauseRollout) })
+	router.PUT("/rollouts/:name/resume", func(c *gin.Context) { updateRollout(c, bindplane.Manager().ResumeRollout) })
+	router.PUT("/rollouts/:name/abort", func(c *gin.Context) { updateRollout(c, bindplane.Manager().AbortRollout) })
+
+	router.GET("/upgrade-campaigns", func(c *gin.Context) { upgradeCampaigns(c, bindplane) })
+	router.GET("/upgrade-campaigns/:name", func(c *gin.Context) { upgradeCampaign(c, bindplane) })
+	router.POST("/upgrade-campaigns", func(c *gin.Context) { createUpgradeCampaign(c, bindplane) })
+	router.PUT("/upgrade-campaigns/:name/pause", func(c *gin.Context) { updateUpgradeCampaign(c, bindplane.Manager().PauseUpgradeCampaign) })
+	router.PUT("/upgrade-campaigns/:name/resume", func(c *gin.Context) { updateUpgradeCampaign(c, bindplane.Manager().ResumeUpgradeCampaign) })
+
 	router.GET("/version", func(c *gin.Context) { bindplaneVersion(c) })
 }
 
@@ -97,36 +147,74 @@ func agents(c *gin.Context, bindplane server.BindPlane) {
 	ctx, span := tracer.Start(c.Request.Context(), "rest/agents")
 	defer span.End()
 
+	options, err := queryOptions(c, bindplane)
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	agents, err := bindplane.Store().Agents(ctx, options...)
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !redactAgents(c, bindplane, agents) {
+		return
+	}
+
+	c.JSON(http.StatusOK, model.AgentsResponse{
+		Agents: agents,
+	})
+}
+
+// @Summary List drifted agents
+// @Description Returns the agents whose reported collector configuration differs from the configuration rendered for
+// @Description them. The drift of each agent includes a diff of the configurations.
+// @Produce json
+// @Router /agents/drift [get]
+// @Success 200 {object} model.DriftedAgentsResponse
+// @Failure 500 {object} ErrorResponse
+func driftedAgents(c *gin.Context, bindplane server.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "rest/driftedAgents")
+	defer span.End()
+
+	agents, err := bindplane.Store().Agents(ctx, store.WithQuery(search.ParseQuery("drift:true")))
+	if okResponse(c, err) && redactAgents(c, bindplane, agents) {
+		c.JSON(http.StatusOK, model.DriftedAgentsResponse{
+			Agents: agents,
+		})
+	}
+}
+
+// queryOptions parses the selector, query, offset, limit, and sort query parameters used to filter and page lists
+func queryOptions(c *gin.Context, bindplane server.BindPlane) ([]store.QueryOption, error) {
 	options := []store.QueryOption{}
 
 	selectorString := c.DefaultQuery("selector", "")
 	selector, err := model.SelectorFromString(selectorString)
 	if err != nil {
-		handleErrorResponse(c, http.StatusBadRequest, err)
-		return
+		return nil, err
 	}
 	options = append(options, store.WithSelector(selector))
 
 	query := c.DefaultQuery("query", "")
 	if query != "" {
 		q := search.ParseQuery(query)
-		q.ReplaceVersionLatest(ctx, bindplane.Versions())
+		q.ReplaceVersionLatest(c.Request.Context(), bindplane.Versions())
 		options = append(options, store.WithQuery(q))
 	}
 
 	offset := c.DefaultQuery("offset", "0")
 	offsetValue, err := strconv.Atoi(offset)
 	if err != nil {
-		handleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("offset must be a number: %v", err))
-		return
+		return nil, fmt.Errorf("offset must be a number: %v", err)
 	}
 	options = append(options, store.WithOffset(offsetValue))
 
 	limit := c.DefaultQuery("limit", "0")
 	limitValue, err := strconv.Atoi(limit)
 	if err != nil {
-		handleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("limit must be a number: %v", err))
-		return
+		return nil, fmt.Errorf("limit must be a number: %v", err)
 	}
 	options = append(options, store.WithLimit(limitValue))
 
@@ -135,15 +223,7 @@ func agents(c *gin.Context, bindplane server.BindPlane) {
 		options = append(options, store.WithSort(sort))
 	}
 
-	agents, err := bindplane.Store().Agents(ctx, options...)
-	if err != nil {
-		handleErrorResponse(c, http.StatusInternalServerError, err)
-		return
-	}
-
-	c.JSON(http.StatusOK, model.AgentsResponse{
-		Agents: agents,
-	})
+	return options, nil
 }
 
 // @Summary delete agents by ids
@@ -169,6 +249,9 @@ func deleteAgents(c *gin.Context, bindplane server.BindPlane) {
 		handleErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
+	if !redactAgents(c, bindplane, deleted) {
+		return
+	}
 
 	c.JSON(http.StatusOK, &model.DeleteAgentsResponse{
 		Agents: deleted,
@@ -185,7 +268,7 @@ func deleteAgents(c *gin.Context, bindplane server.BindPlane) {
 func getAgent(c *gin.Context, bindplane server.BindPlane) {
 	id := c.Param("id")
 
-	agent, err := bindplane.Store().Agent(c, id)
+	agent, err := bindplane.Store().Agent(c.Request.Context(), id)
 
 	switch {
 	case err != nil:
@@ -193,9 +276,12 @@ func getAgent(c *gin.Context, bindplane server.BindPlane) {
 	case agent == nil:
 		handleErrorResponse(c, http.StatusNotFound, store.ErrResourceMissing)
 	default:
-		c.JSON(http.StatusOK, model.AgentResponse{
-			Agent: agent,
-		})
+		agents := []*model.Agent{agent}
+		if redactAgents(c, bindplane, agents) {
+			c.JSON(http.StatusOK, model.AgentResponse{
+				Agent: agents[0],
+			})
+		}
 	}
 }
 
@@ -209,7 +295,7 @@ func getAgent(c *gin.Context, bindplane server.BindPlane) {
 func getAgentLabels(c *gin.Context, bindplane server.BindPlane) {
 	id := c.Param("id")
 
-	agent, err := bindplane.Store().Agent(c, id)
+	agent, err := bindplane.Store().Agent(c.Request.Context(), id)
 
 	switch {
 	case err != nil:
@@ -233,7 +319,7 @@ func getAgentLabels(c *gin.Context, bindplane server.BindPlane) {
 func getAgentConfiguration(c *gin.Context, bindplane server.BindPlane) {
 	id := c.Param("id")
 
-	agent, err := bindplane.Store().Agent(c, id)
+	agent, err := bindplane.Store().Agent(c.Request.Context(), id)
 	switch {
 	case err != nil:
 		handleErrorResponse(c, http.StatusInternalServerError, err)
@@ -243,7 +329,7 @@ func getAgentConfiguration(c *gin.Context, bindplane server.BindPlane) {
 		return
 	}
 
-	config, err := bindplane.Store().AgentConfiguration(c, id)
+	config, err := bindplane.Store().AgentConfiguration(c.Request.Context(), id)
 	if err != nil {
 		handleErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -252,6 +338,55 @@ func getAgentConfiguration(c *gin.Context, bindplane server.BindPlane) {
 	c.JSON(http.StatusOK, &model.ConfigurationResponse{Configuration: config})
 }
 
+// @Summary Get the connection history and uptime of an agent
+// @Produce json
+// @Router /agents/{id}/history [get]
+// @Param 	id	path	string	true "the id of the agent"
+// @Param start	query	string	false	"the start of the window in RFC3339 format, defaults to the first status change"
+// @Param end	query	string	false	"the end of the window in RFC3339 format, defaults to now"
+// @Success 200 {object} model.AgentHistoryResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func getAgentHistory(c *gin.Context, bindplane server.BindPlane) {
+	id := c.Param("id")
+
+	var start, end time.Time
+	var err error
+	if value := c.Query("start"); value != "" {
+		if start, err = time.Parse(time.RFC3339, value); err != nil {
+			handleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("start must be in RFC3339 format: %v", err))
+			return
+		}
+	}
+	if value := c.Query("end"); value != "" {
+		if end, err = time.Parse(time.RFC3339, value); err != nil {
+			handleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("end must be in RFC3339 format: %v", err))
+			return
+		}
+	}
+
+	agent, err := bindplane.Store().Agent(c.Request.Context(), id)
+	switch {
+	case err != nil:
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	case agent == nil:
+		handleErrorResponse(c, http.StatusNotFound, store.ErrResourceMissing)
+		return
+	}
+
+	changes, err := bindplane.Store().AgentHistory(c.Request.Context(), id)
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, &model.AgentHistoryResponse{
+		History: model.NewAgentHistory(changes, start, end),
+	})
+}
+
 // @Summary Bulk apply labels to agents
 // @Produce json
 // @Router /agents/labels [patch]
@@ -291,7 +426,7 @@ func labelAgents(c *gin.Context, bindplane server.BindPlane) {
 	upsertIDs := make([]string, 0, len(p.IDs))
 	apiErrors := make([]string, 0)
 	for _, id := range p.IDs {
-		curAgent, err := bindplane.Store().Agent(c, id)
+		curAgent, err := bindplane.Store().Agent(c.Request.Context(), id)
 
 		switch {
 		case err != nil:
@@ -358,7 +493,7 @@ func patchAgentLabels(c *gin.Context, bindplane server.BindPlane) {
 		handleErrorResponse(c, http.StatusBadRequest, err)
 	}
 
-	curAgent, err := bindplane.Store().Agent(c, id)
+	curAgent, err := bindplane.Store().Agent(c.Request.Context(), id)
 	switch {
 	case err != nil:
 		handleErrorResponse(c, http.StatusInternalServerError, err)
@@ -391,17 +526,154 @@ func patchAgentLabels(c *gin.Context, bindplane server.BindPlane) {
 	})
 }
 
-// @Summary TODO restart agent
+// @Summary Restart agent
 // @Produce json
 // @Router /agents/{id}/restart [put]
 // @Param 	id	path	string	true "the id of the agent"
+// @Success 202 {object} model.AgentResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "If the agent is not connected"
+// @Failure 500 {object} ErrorResponse
 func restartAgent(c *gin.Context, bindplane server.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "rest/restartAgent")
+	defer span.End()
+
+	id := c.Param("id")
+
+	agent, err := bindplane.Store().Agent(ctx, id)
+	switch {
+	case err != nil:
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+
+	case agent == nil:
+		handleErrorResponse(c, http.StatusNotFound, store.ErrResourceMissing)
+		return
+
+	case agent.Status == model.Disconnected:
+		handleErrorResponse(c, http.StatusConflict, fmt.Errorf("agent %s is not connected", agent.ID))
+		return
+	}
+
+	restarted, err := store.RequestAgentRestarts(ctx, bindplane.Store(), []string{id})
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	if len(restarted) > 0 {
+		agent = restarted[0]
+	}
+	agents := []*model.Agent{agent}
+	if !redactAgents(c, bindplane, agents) {
+		return
+	}
+
+	c.JSON(http.StatusAccepted, model.AgentResponse{
+		Agent: agents[0],
+	})
+}
+
+// @Summary Restart multiple agents
+// @Produce json
+// @Router /agents/restart [put]
+// @Param body body model.RestartAgentsPayload true "request body containing ids, a selector, or a query"
+// @Success 202 {object} model.RestartAgentsResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func restartAgents(c *gin.Context, bindplane server.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "rest/restartAgents")
+	defer span.End()
+
+	payload := &model.RestartAgentsPayload{}
+	if err := c.BindJSON(payload); err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if len(payload.IDs) == 0 && payload.Selector == "" && payload.Query == "" {
+		handleErrorResponse(c, http.StatusBadRequest, errors.New("ids, selector, or query must be specified"))
+		return
+	}
+
+	options := []store.QueryOption{}
+	if payload.Selector != "" {
+		selector, err := model.SelectorFromString(payload.Selector)
+		if err != nil {
+			handleErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		options = append(options, store.WithSelector(selector))
+	}
+	if payload.Query != "" {
+		q := search.ParseQuery(payload.Query)
+		q.ReplaceVersionLatest(ctx, bindplane.Versions())
+		options = append(options, store.WithQuery(q))
+	}
+
+	agents, err := store.RequestAgentRestarts(ctx, bindplane.Store(), payload.IDs, options...)
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	if !redactAgents(c, bindplane, agents) {
+		return
+	}
+
+	c.JSON(http.StatusAccepted, &model.RestartAgentsResponse{
+		Agents: agents,
+	})
+}
+
+// @Summary Revoke agent credentials
+// @Description Revoke the credentials issued to the agent. The agent is disconnected and cannot reconnect or enroll
+// @Description again with the same agent ID, even after it is deleted.
+// @Produce json
+// @Router /agents/{id}/credentials [delete]
+// @Param 	id	path	string	true "the id of the agent"
+// @Success 200 {object} model.RevokeAgentCredentialsResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "If the agent has no credentials"
+// @Failure 500 {object} ErrorResponse
+func revokeAgentCredentials(c *gin.Context, bindplane server.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "rest/revokeAgentCredentials")
+	defer span.End()
+
 	id := c.Param("id")
 
-	// TODO(andy): Do a restart
-	bindplane.Logger().Info("TODO Restart agent", zap.String("id", id))
+	agent, err := bindplane.Store().Agent(ctx, id)
+	switch {
+	case err != nil:
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+
+	case agent == nil:
+		handleErrorResponse(c, http.StatusNotFound, store.ErrResourceMissing)
+		return
+
+	case agent.Credentials == nil:
+		handleErrorResponse(c, http.StatusConflict, fmt.Errorf("agent %s has no credentials", agent.ID))
+		return
+	}
+
+	// record the revocation first so that the agent cannot enroll again if it is deleted
+	if err := bindplane.Store().RevokeAgent(ctx, id); err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	agent, err = bindplane.Store().UpsertAgent(ctx, id, func(current *model.Agent) {
+		current.RevokeCredentials()
+	})
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+	agents := []*model.Agent{agent}
+	if !redactAgents(c, bindplane, agents) {
+		return
+	}
 
-	c.Status(http.StatusAccepted)
+	c.JSON(http.StatusOK, model.RevokeAgentCredentialsResponse{
+		Agent: agents[0],
+	})
 }
 
 // @Summary Update multiple agents
@@ -430,7 +702,7 @@ func upgradeAgents(c *gin.Context, bindplane server.BindPlane) {
 
 	for _, id := range req.IDs {
 		// just ignore agents that don't exist or don't support upgrade
-		agent, err := bindplane.Store().Agent(c, id)
+		agent, err := bindplane.Store().Agent(c.Request.Context(), id)
 		if err != nil || agent == nil || !agent.SupportsUpgrade() {
 			continue
 		}
@@ -466,7 +738,7 @@ func upgradeAgent(c *gin.Context, bindplane server.BindPlane) {
 		return
 	}
 
-	agent, err := bindplane.Store().Agent(c, id)
+	agent, err := bindplane.Store().Agent(c.Request.Context(), id)
 	switch {
 	case err != nil:
 		handleErrorResponse(c, http.StatusInternalServerError, err)
@@ -501,7 +773,7 @@ func upgradeAgent(c *gin.Context, bindplane server.BindPlane) {
 // @Success 200 {object} model.AgentVersionsResponse
 // @Failure 500 {object} ErrorResponse
 func agentVersions(c *gin.Context, bindplane server.BindPlane) {
-	agentVersions, err := bindplane.Store().AgentVersions(c)
+	agentVersions, err := bindplane.Store().AgentVersions(c.Request.Context())
 	if okResponse(c, err) {
 		c.JSON(http.StatusOK, model.AgentVersionsResponse{
 			AgentVersions: agentVersions,
@@ -518,8 +790,9 @@ func agentVersions(c *gin.Context, bindplane server.BindPlane) {
 // @Failure 500 {object} ErrorResponse
 func agentVersion(c *gin.Context, bindplane server.BindPlane) {
 	name := c.Param("name")
-	agentVersion, err := bindplane.Store().AgentVersion(c, name)
+	agentVersion, err := bindplane.Store().AgentVersion(c.Request.Context(), name)
 	if okResource(c, agentVersion == nil, err) {
+		setETag(c, agentVersion)
 		c.JSON(http.StatusOK, model.AgentVersionResponse{
 			AgentVersion: agentVersion,
 		})
@@ -530,12 +803,18 @@ func agentVersion(c *gin.Context, bindplane server.BindPlane) {
 // @Produce json
 // @Router /agent-versions/{name} [delete]
 // @Param 	name	path	string	true "the name of the agent version to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
 // @Success 204	"Successful Delete, no content"
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func deleteAgentVersion(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindAgentVersion) {
+		return
+	}
+
 	name := c.Param("name")
-	agentVersion, err := bindplane.Store().DeleteAgentVersion(c, name)
+	agentVersion, err := bindplane.Store().DeleteAgentVersion(c.Request.Context(), name)
 	if okResource(c, agentVersion == nil, err) {
 		c.Status(http.StatusNoContent)
 	}
@@ -543,13 +822,134 @@ func deleteAgentVersion(c *gin.Context, bindplane server.BindPlane) {
 
 // ----------------------------------------------------------------------
 
+// @Summary List enrollment tokens
+// @Produce json
+// @Router /enrollment-tokens [get]
+// @Success 200 {object} model.EnrollmentTokensResponse
+// @Failure 500 {object} ErrorResponse
+func enrollmentTokens(c *gin.Context, bindplane server.BindPlane) {
+	enrollmentTokens, err := bindplane.Store().EnrollmentTokens(c.Request.Context())
+	if okResponse(c, err) {
+		c.JSON(http.StatusOK, model.EnrollmentTokensResponse{
+			EnrollmentTokens: enrollmentTokens,
+		})
+	}
+}
+
+// @Summary Get enrollment token by name
+// @Produce json
+// @Router /enrollment-tokens/{name} [get]
+// @Param 	name	path	string	true "the name of the enrollment token"
+// @Success 200 {object} model.EnrollmentTokenResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func enrollmentToken(c *gin.Context, bindplane server.BindPlane) {
+	name := c.Param("name")
+	enrollmentToken, err := bindplane.Store().EnrollmentToken(c.Request.Context(), name)
+	if okResource(c, enrollmentToken == nil, err) {
+		setETag(c, enrollmentToken)
+		c.JSON(http.StatusOK, model.EnrollmentTokenResponse{
+			EnrollmentToken: enrollmentToken,
+		})
+	}
+}
+
+// @Summary Delete enrollment token by name
+// @Produce json
+// @Router /enrollment-tokens/{name} [delete]
+// @Param 	name	path	string	true "the name of the enrollment token to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
+// @Success 204	"Successful Delete, no content"
+// @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func deleteEnrollmentToken(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindEnrollmentToken) {
+		return
+	}
+
+	name := c.Param("name")
+	enrollmentToken, err := bindplane.Store().DeleteEnrollmentToken(c.Request.Context(), name)
+	if okResource(c, enrollmentToken == nil, err) {
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// @Summary List secrets without their values
+// @Produce json
+// @Router /secrets [get]
+// @Success 200 {object} model.SecretsResponse
+// @Failure 500 {object} ErrorResponse
+func secrets(c *gin.Context, bindplane server.BindPlane) {
+	secrets, err := bindplane.Store().Secrets(c.Request.Context())
+	if okResponse(c, err) {
+		redacted := make([]*model.Secret, 0, len(secrets))
+		for _, secret := range secrets {
+			redacted = append(redacted, secret.Redacted())
+		}
+		c.JSON(http.StatusOK, model.SecretsResponse{
+			Secrets: redacted,
+		})
+	}
+}
+
+// @Summary Get secret by name without its value
+// @Produce json
+// @Router /secrets/{name} [get]
+// @Param 	name	path	string	true "the name of the secret"
+// @Success 200 {object} model.SecretResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func secret(c *gin.Context, bindplane server.BindPlane) {
+	name := c.Param("name")
+	secret, err := bindplane.Store().Secret(c.Request.Context(), name)
+	if okResource(c, secret == nil, err) {
+		setETag(c, secret)
+		c.JSON(http.StatusOK, model.SecretResponse{
+			Secret: secret.Redacted(),
+		})
+	}
+}
+
+// @Summary Delete secret by name
+// @Produce json
+// @Router /secrets/{name} [delete]
+// @Param 	name	path	string	true "the name of the secret to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
+// @Success 204	"Successful Delete, no content"
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "If the secret is used by other resources"
+// @Failure 412 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func deleteSecret(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindSecret) {
+		return
+	}
+
+	name := c.Param("name")
+	secret, err := bindplane.Store().DeleteSecret(c.Request.Context(), name)
+	if okResource(c, secret == nil, err) {
+		c.Status(http.StatusNoContent)
+	}
+}
+
+// ----------------------------------------------------------------------
+
 // @Summary List Configurations
 // @Produce json
 // @Router /configurations [get]
 // @Success 200 {object} model.ConfigurationsResponse
 // @Failure 500 {object} ErrorResponse
 func configurations(c *gin.Context, bindplane server.BindPlane) {
-	configs, err := bindplane.Store().Configurations(c)
+	options, err := queryOptions(c, bindplane)
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	configs, err := bindplane.Store().Configurations(c.Request.Context(), options...)
 	if err != nil {
 		handleErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -561,10 +961,13 @@ func configurations(c *gin.Context, bindplane server.BindPlane) {
 }
 
 // @Summary Get configuration by name
+// @Description The raw configuration is rendered for the agent if one is specified.
 // @Produce json
 // @Router /configurations/{name} [get]
 // @Param 	name	path	string	true "the name of the configuration"
+// @Param 	agent	query	string	false "the id of an agent to render the configuration for"
 // @Success 200 {object} model.ConfigurationResponse
+// @Failure 404 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func configuration(c *gin.Context, bindplane server.BindPlane) {
 	ctx, span := tracer.Start(c.Request.Context(), "rest/configuration")
@@ -577,11 +980,20 @@ func configuration(c *gin.Context, bindplane server.BindPlane) {
 		return
 	}
 
-	raw, err := config.Render(ctx, nil, bindplane.Config(), bindplane.Store())
+	var agent *model.Agent
+	if id := c.Query("agent"); id != "" {
+		agent, err = bindplane.Store().Agent(ctx, id)
+		if !okResource(c, agent == nil, err) {
+			return
+		}
+	}
+
+	raw, err := config.Preview(ctx, agent, bindplane.Config(), bindplane.Store())
 	if !okResponse(c, err) {
 		return
 	}
 
+	setETag(c, config)
 	c.JSON(http.StatusOK, model.ConfigurationResponse{
 		Configuration: config,
 		Raw:           raw,
@@ -592,12 +1004,18 @@ func configuration(c *gin.Context, bindplane server.BindPlane) {
 // @Produce json
 // @Router /configurations/{name} [delete]
 // @Param 	name	path	string	true "the name of the configuration to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
 // @Success 204	"Successful Delete, no content"
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func deleteConfiguration(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindConfiguration) {
+		return
+	}
+
 	name := c.Param("name")
-	configuration, err := bindplane.Store().DeleteConfiguration(c, name)
+	configuration, err := bindplane.Store().DeleteConfiguration(c.Request.Context(), name)
 	if okResource(c, configuration == nil, err) {
 		c.Status(http.StatusNoContent)
 	}
@@ -617,7 +1035,7 @@ func copyConfig(c *gin.Context, bindplane server.BindPlane) {
 	name := c.Param("name")
 
 	// The config to make a duplicate of
-	config, err := bindplane.Store().Configuration(c, name)
+	config, err := bindplane.Store().Configuration(c.Request.Context(), name)
 	if err != nil {
 		handleErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -635,7 +1053,7 @@ func copyConfig(c *gin.Context, bindplane server.BindPlane) {
 	}
 
 	duplicateName := req.Name
-	duplicateConfig, err := bindplane.Store().Configuration(c, duplicateName)
+	duplicateConfig, err := bindplane.Store().Configuration(c.Request.Context(), duplicateName)
 	if err != nil {
 		handleErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -648,7 +1066,7 @@ func copyConfig(c *gin.Context, bindplane server.BindPlane) {
 
 	duplicateConfig = config.Duplicate(duplicateName)
 
-	updates, err := bindplane.Store().ApplyResources(c, []model.Resource{duplicateConfig})
+	updates, err := bindplane.Store().ApplyResources(c.Request.Context(), []model.Resource{duplicateConfig})
 	if err != nil {
 		handleErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -679,15 +1097,88 @@ func copyConfig(c *gin.Context, bindplane server.BindPlane) {
 	handleErrorResponse(c, http.StatusBadRequest, errs.ErrorOrNil())
 }
 
-// ----------------------------------------------------------------------
-
-// @Summary List sources
+// @Summary List the revisions of a configuration
 // @Produce json
-// @Router /sources [get]
-// @Success 200 {object} model.SourcesResponse
+// @Router /configurations/{name}/revisions [get]
+// @Param 	name	path	string	true "the name of the configuration"
+// @Success 200 {object} model.ConfigurationRevisionsResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func configurationRevisions(c *gin.Context, bindplane server.BindPlane) {
+	name := c.Param("name")
+	revisions, err := bindplane.Store().ResourceRevisions(c.Request.Context(), model.KindConfiguration, name)
+	if !okResource(c, len(revisions) == 0, err) {
+		return
+	}
+
+	configurations := make([]*model.Configuration, 0, len(revisions))
+	for _, revision := range revisions {
+		if configuration, ok := revision.(*model.Configuration); ok {
+			configurations = append(configurations, configuration)
+		}
+	}
+
+	c.JSON(http.StatusOK, model.ConfigurationRevisionsResponse{
+		Revisions: configurations,
+	})
+}
+
+// @Summary Rollback a configuration to a previous revision
+// @Produce json
+// @Router /configurations/{name}/rollback [post]
+// @Param 	name	path	string	true "the name of the configuration to rollback"
+// @Param 	body	body	model.PostRollbackRequest	true "the revision to restore"
+// @Success 200 {object} model.PostRollbackResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func rollbackConfiguration(c *gin.Context, bindplane server.BindPlane) {
+	name := c.Param("name")
+
+	var req model.PostRollbackRequest
+	if err := c.BindJSON(&req); err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	update, err := store.RollbackResource(c.Request.Context(), bindplane.Store(), model.KindConfiguration, name, req.Revision)
+	if !okResponse(c, err) {
+		return
+	}
+
+	switch update.Status {
+	case model.StatusCreated, model.StatusConfigured, model.StatusUnchanged:
+		c.JSON(http.StatusOK, model.PostRollbackResponse{
+			Name:     name,
+			Revision: update.Resource.Revision(),
+		})
+		return
+	}
+
+	errs := &multierror.Error{}
+	multierror.Append(errs, fmt.Errorf("failed to rollback configuration, got status %s", update.Status))
+
+	if update.Reason != "" {
+		multierror.Append(errs, errors.New(update.Reason))
+	}
+	handleErrorResponse(c, http.StatusBadRequest, errs.ErrorOrNil())
+}
+
+// ----------------------------------------------------------------------
+
+// @Summary List sources
+// @Produce json
+// @Router /sources [get]
+// @Success 200 {object} model.SourcesResponse
 // @Failure 500 {object} ErrorResponse
 func sources(c *gin.Context, bindplane server.BindPlane) {
-	sources, err := bindplane.Store().Sources(c)
+	options, err := queryOptions(c, bindplane)
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	sources, err := bindplane.Store().Sources(c.Request.Context(), options...)
 	if okResponse(c, err) {
 		c.JSON(http.StatusOK, model.SourcesResponse{
 			Sources: sources,
@@ -704,8 +1195,9 @@ func sources(c *gin.Context, bindplane server.BindPlane) {
 // @Failure 500 {object} ErrorResponse
 func source(c *gin.Context, bindplane server.BindPlane) {
 	name := c.Param("name")
-	source, err := bindplane.Store().Source(c, name)
+	source, err := bindplane.Store().Source(c.Request.Context(), name)
 	if okResource(c, source == nil, err) {
+		setETag(c, source)
 		c.JSON(http.StatusOK, model.SourceResponse{
 			Source: source,
 		})
@@ -716,12 +1208,18 @@ func source(c *gin.Context, bindplane server.BindPlane) {
 // @Produce json
 // @Router /sources/{name} [delete]
 // @Param 	name	path	string	true "the name of the source to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
 // @Success 204	"Successful Delete, no content"
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func deleteSource(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindSource) {
+		return
+	}
+
 	name := c.Param("name")
-	source, err := bindplane.Store().DeleteSource(c, name)
+	source, err := bindplane.Store().DeleteSource(c.Request.Context(), name)
 
 	if okResource(c, source == nil, err) {
 		c.Status(http.StatusNoContent)
@@ -736,7 +1234,13 @@ func deleteSource(c *gin.Context, bindplane server.BindPlane) {
 // @Success 200 {object} model.SourceTypesResponse
 // @Failure 500 {object} ErrorResponse
 func sourceTypes(c *gin.Context, bindplane server.BindPlane) {
-	sourceTypes, err := bindplane.Store().SourceTypes(c)
+	options, err := queryOptions(c, bindplane)
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	sourceTypes, err := bindplane.Store().SourceTypes(c.Request.Context(), options...)
 	if okResponse(c, err) {
 		c.JSON(http.StatusOK, model.SourceTypesResponse{
 			SourceTypes: sourceTypes,
@@ -753,8 +1257,9 @@ func sourceTypes(c *gin.Context, bindplane server.BindPlane) {
 // @Failure 500 {object} ErrorResponse
 func sourceType(c *gin.Context, bindplane server.BindPlane) {
 	name := c.Param("name")
-	sourceType, err := bindplane.Store().SourceType(c, name)
+	sourceType, err := bindplane.Store().SourceType(c.Request.Context(), name)
 	if okResource(c, sourceType == nil, err) {
+		setETag(c, sourceType)
 		c.JSON(http.StatusOK, model.SourceTypeResponse{
 			SourceType: sourceType,
 		})
@@ -765,12 +1270,18 @@ func sourceType(c *gin.Context, bindplane server.BindPlane) {
 // @Produce json
 // @Router /source-types/{name} [delete]
 // @Param 	name	path	string	true "the name of the source type to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
 // @Success 204	"Successful Delete, no content"
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func deleteSourceType(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindSourceType) {
+		return
+	}
+
 	name := c.Param("name")
-	sourceType, err := bindplane.Store().DeleteSourceType(c, name)
+	sourceType, err := bindplane.Store().DeleteSourceType(c.Request.Context(), name)
 	if okResource(c, sourceType == nil, err) {
 		c.Status(http.StatusNoContent)
 	}
@@ -784,7 +1295,13 @@ func deleteSourceType(c *gin.Context, bindplane server.BindPlane) {
 // @Success 200 {object} model.ProcessorsResponse
 // @Failure 500 {object} ErrorResponse
 func processors(c *gin.Context, bindplane server.BindPlane) {
-	processors, err := bindplane.Store().Processors(c)
+	options, err := queryOptions(c, bindplane)
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	processors, err := bindplane.Store().Processors(c.Request.Context(), options...)
 	if okResponse(c, err) {
 		c.JSON(http.StatusOK, model.ProcessorsResponse{
 			Processors: processors,
@@ -801,8 +1318,9 @@ func processors(c *gin.Context, bindplane server.BindPlane) {
 // @Failure 500 {object} ErrorResponse
 func processor(c *gin.Context, bindplane server.BindPlane) {
 	name := c.Param("name")
-	processor, err := bindplane.Store().Processor(c, name)
+	processor, err := bindplane.Store().Processor(c.Request.Context(), name)
 	if okResource(c, processor == nil, err) {
+		setETag(c, processor)
 		c.JSON(http.StatusOK, model.ProcessorResponse{
 			Processor: processor,
 		})
@@ -813,12 +1331,18 @@ func processor(c *gin.Context, bindplane server.BindPlane) {
 // @Produce json
 // @Router /processors/{name} [delete]
 // @Param 	name	path	string	true "the name of the processor to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
 // @Success 204	"Successful Delete, no content"
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func deleteProcessor(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindProcessor) {
+		return
+	}
+
 	name := c.Param("name")
-	processor, err := bindplane.Store().DeleteProcessor(c, name)
+	processor, err := bindplane.Store().DeleteProcessor(c.Request.Context(), name)
 	if okResource(c, processor == nil, err) {
 		c.Status(http.StatusNoContent)
 	}
@@ -832,7 +1356,13 @@ func deleteProcessor(c *gin.Context, bindplane server.BindPlane) {
 // @Success 200 {object} model.ProcessorTypesResponse
 // @Failure 500 {object} ErrorResponse
 func processorTypes(c *gin.Context, bindplane server.BindPlane) {
-	processorTypes, err := bindplane.Store().ProcessorTypes(c)
+	options, err := queryOptions(c, bindplane)
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	processorTypes, err := bindplane.Store().ProcessorTypes(c.Request.Context(), options...)
 	if okResponse(c, err) {
 		c.JSON(http.StatusOK, model.ProcessorTypesResponse{
 			ProcessorTypes: processorTypes,
@@ -849,8 +1379,9 @@ func processorTypes(c *gin.Context, bindplane server.BindPlane) {
 // @Failure 500 {object} ErrorResponse
 func processorType(c *gin.Context, bindplane server.BindPlane) {
 	name := c.Param("name")
-	processorType, err := bindplane.Store().ProcessorType(c, name)
+	processorType, err := bindplane.Store().ProcessorType(c.Request.Context(), name)
 	if okResource(c, processorType == nil, err) {
+		setETag(c, processorType)
 		c.JSON(http.StatusOK, model.ProcessorTypeResponse{
 			ProcessorType: processorType,
 		})
@@ -861,12 +1392,18 @@ func processorType(c *gin.Context, bindplane server.BindPlane) {
 // @Produce json
 // @Router /processor-types/{name} [delete]
 // @Param 	name	path	string	true "the name of the processor type to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
 // @Success 204	"Successful Delete, no content"
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func deleteProcessorType(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindProcessorType) {
+		return
+	}
+
 	name := c.Param("name")
-	processorType, err := bindplane.Store().DeleteProcessorType(c, name)
+	processorType, err := bindplane.Store().DeleteProcessorType(c.Request.Context(), name)
 	if okResource(c, processorType == nil, err) {
 		c.Status(http.StatusNoContent)
 	}
@@ -880,7 +1417,13 @@ func deleteProcessorType(c *gin.Context, bindplane server.BindPlane) {
 // @Success 200 {object} model.DestinationsResponse
 // @Failure 500 {object} ErrorResponse
 func destinations(c *gin.Context, bindplane server.BindPlane) {
-	destinations, err := bindplane.Store().Destinations(c)
+	options, err := queryOptions(c, bindplane)
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	destinations, err := bindplane.Store().Destinations(c.Request.Context(), options...)
 	if okResponse(c, err) {
 		c.JSON(http.StatusOK, model.DestinationsResponse{
 			Destinations: destinations,
@@ -897,8 +1440,9 @@ func destinations(c *gin.Context, bindplane server.BindPlane) {
 // @Failure 500 {object} ErrorResponse
 func destination(c *gin.Context, bindplane server.BindPlane) {
 	name := c.Param("name")
-	destination, err := bindplane.Store().Destination(c, name)
+	destination, err := bindplane.Store().Destination(c.Request.Context(), name)
 	if okResource(c, destination == nil, err) {
+		setETag(c, destination)
 		c.JSON(http.StatusOK, model.DestinationResponse{
 			Destination: destination,
 		})
@@ -909,12 +1453,18 @@ func destination(c *gin.Context, bindplane server.BindPlane) {
 // @Produce json
 // @Router /destinations/{name} [delete]
 // @Param 	name	path	string	true "the name of the destination to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
 // @Success 204	"Successful Delete, no content"
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func deleteDestination(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindDestination) {
+		return
+	}
+
 	name := c.Param("name")
-	destination, err := bindplane.Store().DeleteDestination(c, name)
+	destination, err := bindplane.Store().DeleteDestination(c.Request.Context(), name)
 	if okResource(c, destination == nil, err) {
 		c.Status(http.StatusNoContent)
 	}
@@ -928,7 +1478,13 @@ func deleteDestination(c *gin.Context, bindplane server.BindPlane) {
 // @Success 200 {object} model.DestinationTypesResponse
 // @Failure 500 {object} ErrorResponse
 func destinationTypes(c *gin.Context, bindplane server.BindPlane) {
-	destinationTypes, err := bindplane.Store().DestinationTypes(c)
+	options, err := queryOptions(c, bindplane)
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	destinationTypes, err := bindplane.Store().DestinationTypes(c.Request.Context(), options...)
 	if okResponse(c, err) {
 		c.JSON(http.StatusOK, model.DestinationTypesResponse{
 			DestinationTypes: destinationTypes,
@@ -945,8 +1501,9 @@ func destinationTypes(c *gin.Context, bindplane server.BindPlane) {
 // @Failure 500 {object} ErrorResponse
 func destinationType(c *gin.Context, bindplane server.BindPlane) {
 	name := c.Param("name")
-	destinationType, err := bindplane.Store().DestinationType(c, name)
+	destinationType, err := bindplane.Store().DestinationType(c.Request.Context(), name)
 	if okResource(c, destinationType == nil, err) {
+		setETag(c, destinationType)
 		c.JSON(http.StatusOK, model.DestinationTypeResponse{
 			DestinationType: destinationType,
 		})
@@ -957,28 +1514,97 @@ func destinationType(c *gin.Context, bindplane server.BindPlane) {
 // @Produce json
 // @Router /destination-types/{name} [delete]
 // @Param 	name	path	string	true "the name of the destination type to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
 // @Success 204	"Successful Delete, no content"
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
 // @Failure 500 {object} ErrorResponse
 func deleteDestinationType(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindDestinationType) {
+		return
+	}
+
 	name := c.Param("name")
-	destinationType, err := bindplane.Store().DeleteDestinationType(c, name)
+	destinationType, err := bindplane.Store().DeleteDestinationType(c.Request.Context(), name)
 	if okResource(c, destinationType == nil, err) {
 		c.Status(http.StatusNoContent)
 	}
 }
 
+// @Summary List extension types
+// @Produce json
+// @Router /extension-types [get]
+// @Success 200 {object} model.ExtensionTypesResponse
+// @Failure 500 {object} ErrorResponse
+func extensionTypes(c *gin.Context, bindplane server.BindPlane) {
+	options, err := queryOptions(c, bindplane)
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	extensionTypes, err := bindplane.Store().ExtensionTypes(c.Request.Context(), options...)
+	if okResponse(c, err) {
+		c.JSON(http.StatusOK, model.ExtensionTypesResponse{
+			ExtensionTypes: extensionTypes,
+		})
+	}
+}
+
+// @Summary Get extension type by name
+// @Produce json
+// @Router /extension-types/{name} [get]
+// @Param 	name	path	string	true "the name of the extension type"
+// @Success 200 {object} model.ExtensionTypeResponse
+// @Failure 401 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func extensionType(c *gin.Context, bindplane server.BindPlane) {
+	name := c.Param("name")
+	extensionType, err := bindplane.Store().ExtensionType(c.Request.Context(), name)
+	if okResource(c, extensionType == nil, err) {
+		setETag(c, extensionType)
+		c.JSON(http.StatusOK, model.ExtensionTypeResponse{
+			ExtensionType: extensionType,
+		})
+	}
+}
+
+// @Summary Delete extension type by name
+// @Produce json
+// @Router /extension-types/{name} [delete]
+// @Param 	name	path	string	true "the name of the extension type to delete"
+// @Param If-Match	header	string	false "only delete the resource if its resourceVersion matches"
+// @Success 204	"Successful Delete, no content"
+// @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func deleteExtensionType(c *gin.Context, bindplane server.BindPlane) {
+	if deleteIfMatch(c, bindplane, model.KindExtensionType) {
+		return
+	}
+
+	name := c.Param("name")
+	extensionType, err := bindplane.Store().DeleteExtensionType(c.Request.Context(), name)
+	if okResource(c, extensionType == nil, err) {
+		c.Status(http.StatusNoContent)
+	}
+}
+
 // ----------------------------------------------------------------------
 
 // @Summary Create, edit, and configure multiple resources.
 // @Description The /apply route will try to parse resources
 // @Description and upsert them into the store.  Additionally
 // @Description it will send reconfigure tasks to affected agents.
+// @Description If-Match can be used with a single resource to only apply it if the resourceVersion matches.
 // @Produce json
 // @Router /apply [post]
 // @Param resources 	body	[]model.AnyResource	true "Resources"
+// @Param If-Match	header	string	false "the resourceVersion of the resource being applied"
 // @Success 200 {object} model.ApplyResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} model.ApplyResponse
 // @Failure 500 {object} ErrorResponse
 func applyResources(c *gin.Context, bindplane server.BindPlane) {
 	p := &model.ApplyPayload{}
@@ -1000,16 +1626,31 @@ func applyResources(c *gin.Context, bindplane server.BindPlane) {
 		resources = append(resources, parsed)
 	}
 
+	// If-Match replaces the resourceVersion of a single resource
+	version, hasIfMatch := ifMatch(c)
+	if hasIfMatch {
+		if len(resources) != 1 {
+			handleErrorResponse(c, http.StatusBadRequest, errors.New("the If-Match header can only be used when applying a single resource"))
+			return
+		}
+		resources[0].SetResourceVersion(version)
+	}
+
 	bindplane.Logger().Info("/apply", zap.Int("count", len(resources)))
 
-	resourceStatuses, err := bindplane.Store().ApplyResources(c, resources)
+	resourceStatuses, err := bindplane.Store().ApplyResources(c.Request.Context(), resources)
 	if err != nil {
 		handleErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusAccepted, &model.ApplyResponse{
-		Updates: resourceStatuses,
+	status := http.StatusAccepted
+	if hasIfMatch && len(resourceStatuses) == 1 && resourceStatuses[0].Status == model.StatusConflict {
+		status = http.StatusPreconditionFailed
+	}
+
+	c.JSON(status, &model.ApplyResponse{
+		Updates: model.RedactSecretStatuses(resourceStatuses),
 	})
 }
 
@@ -1017,11 +1658,18 @@ func applyResources(c *gin.Context, bindplane server.BindPlane) {
 // @Description /delete endpoint will try to parse resources
 // @Description and delete them from the store.  Additionally
 // @Description it will send reconfigure tasks to affected agents.
+// @Description With cascade, the references to the resources are removed
+// @Description or their dependents are deleted. With dryRun, the changes
+// @Description are returned without being made. Resources with a resourceVersion are only deleted if
+// @Description it matches. If-Match can be used with a single resource instead of its resourceVersion.
 // @Produce json
 // @Router /delete [post]
-// @Param resources 	body	[]model.AnyResource	true "Resources"
+// @Param payload 	body	model.DeletePayload	true "Resources and options"
+// @Param If-Match	header	string	false "the resourceVersion of the resource being deleted"
 // @Success 200 {object} model.DeleteResponse
+// @Failure 400 {object} ErrorResponse
 // @Failure 404 {object} ErrorResponse
+// @Failure 412 {object} model.DeleteResponse
 // @Failure 500 {object} ErrorResponse
 func deleteResources(c *gin.Context, bindplane server.BindPlane) {
 	p := &model.DeletePayload{}
@@ -1041,19 +1689,288 @@ func deleteResources(c *gin.Context, bindplane server.BindPlane) {
 		resources = append(resources, parsed)
 	}
 
-	bindplane.Logger().Info("/delete", zap.Int("count", len(resources)))
+	cascade, err := model.ParseCascadeMode(string(p.Cascade))
+	if err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+
+	// If-Match replaces the resourceVersion of a single resource
+	version, hasIfMatch := ifMatch(c)
+	if hasIfMatch {
+		if len(resources) != 1 {
+			handleErrorResponse(c, http.StatusBadRequest, errors.New("the If-Match header can only be used when deleting a single resource"))
+			return
+		}
+		resources[0].SetResourceVersion(version)
+	}
+
+	bindplane.Logger().Info("/delete", zap.Int("count", len(resources)), zap.String("cascade", string(cascade)), zap.Bool("dryRun", p.DryRun))
 
-	resourceStatuses, err := bindplane.Store().DeleteResources(c, resources)
+	resourceStatuses, err := bindplane.Store().DeleteResources(c.Request.Context(), resources, store.WithCascade(cascade), store.WithDryRun(p.DryRun))
 	if err != nil {
 		handleErrorResponse(c, http.StatusInternalServerError, err)
 		return
 	}
 
-	c.JSON(http.StatusAccepted, &model.DeleteResponse{
-		Updates: resourceStatuses,
+	response := &model.DeleteResponse{
+		Updates: model.RedactSecretStatuses(resourceStatuses),
+		DryRun:  p.DryRun,
+	}
+	if p.DryRun {
+		response.Agents, err = store.AffectedAgentIDs(c.Request.Context(), bindplane.Store(), resourceStatuses)
+		if err != nil {
+			handleErrorResponse(c, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	status := http.StatusAccepted
+	if hasIfMatch && len(resourceStatuses) == 1 && resourceStatuses[0].Status == model.StatusConflict {
+		status = http.StatusPreconditionFailed
+	}
+
+	c.JSON(status, response)
+}
+
+// @Summary List the resources that depend on a resource
+// @Description Returns a tree of the resources that depend on the resource, including the resources that depend on
+// @Description them. A resource with dependents cannot be deleted.
+// @Produce json
+// @Router /{kind}/{name}/dependents [get]
+// @Param 	name	path	string	true "the name of the resource"
+// @Success 200 {object} model.DependentsResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func dependents(c *gin.Context, bindplane server.BindPlane, kind model.Kind) {
+	name := c.Param("name")
+	resource, err := store.GetResource(c.Request.Context(), bindplane.Store(), kind, name)
+	if !okResource(c, resource == nil, err) {
+		return
+	}
+
+	dependencies, err := store.FindDependentResources(c.Request.Context(), bindplane.Store(), resource)
+	if !okResponse(c, err) {
+		return
+	}
+
+	c.JSON(http.StatusOK, &model.DependentsResponse{
+		Dependents: dependencies.Dependents(),
+	})
+}
+
+// @Summary List audit log entries, newest first
+// @Produce json
+// @Router /audit [get]
+// @Param kind	query	string	false	"the kind of resource"
+// @Param name	query	string	false	"the name of the resource or the id of the agent"
+// @Param user	query	string	false	"the user that made the change"
+// @Param offset	query	int	false	"the number of entries to skip"
+// @Param limit	query	int	false	"the maximum number of entries to return"
+// @Success 200 {object} model.AuditEntriesResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func auditEntries(c *gin.Context, bindplane server.BindPlane) {
+	filter := model.AuditFilter{
+		Kind: model.Kind(c.Query("kind")),
+		Name: c.Query("name"),
+		User: c.Query("user"),
+	}
+
+	var err error
+	if filter.Offset, err = strconv.Atoi(c.DefaultQuery("offset", "0")); err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("offset must be a number: %v", err))
+		return
+	}
+	if filter.Limit, err = strconv.Atoi(c.DefaultQuery("limit", "0")); err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("limit must be a number: %v", err))
+		return
+	}
+
+	entries, err := bindplane.Store().AuditEntries(c.Request.Context(), filter)
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	values, err := store.SecretValues(c.Request.Context(), bindplane.Store(), bindplane.Config().SecretsKey)
+	if !okResponse(c, err) {
+		return
+	}
+	for i, entry := range entries {
+		entries[i] = entry.RedactSecrets(values)
+	}
+
+	c.JSON(http.StatusOK, &model.AuditEntriesResponse{
+		AuditEntries: entries,
 	})
 }
 
+// @Summary List rollouts
+// @Description Returns the most recent rollout of each configuration that is rolled out in waves.
+// @Produce json
+// @Router /rollouts [get]
+// @Success 200 {object} model.RolloutsResponse
+// @Failure 500 {object} ErrorResponse
+func rollouts(c *gin.Context, bindplane server.BindPlane) {
+	rollouts, err := bindplane.Store().Rollouts(c.Request.Context())
+	if okResponse(c, err) {
+		c.JSON(http.StatusOK, &model.RolloutsResponse{
+			Rollouts: rollouts,
+		})
+	}
+}
+
+// @Summary Get the rollout of a configuration
+// @Produce json
+// @Router /rollouts/{name} [get]
+// @Param 	name	path	string	true "the name of the configuration"
+// @Success 200 {object} model.RolloutResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func rollout(c *gin.Context, bindplane server.BindPlane) {
+	rollout, err := bindplane.Store().Rollout(c.Request.Context(), c.Param("name"))
+	if okResource(c, rollout == nil, err) {
+		c.JSON(http.StatusOK, &model.RolloutResponse{
+			Rollout: rollout,
+		})
+	}
+}
+
+// @Summary Pause, resume, or abort the rollout of a configuration
+// @Description Pausing stops the rollout from starting new waves. Resuming continues a paused rollout. Aborting stops
+// @Description the rollout and restores the previous revision of the configuration on every agent.
+// @Produce json
+// @Router /rollouts/{name}/pause [put]
+// @Router /rollouts/{name}/resume [put]
+// @Router /rollouts/{name}/abort [put]
+// @Param 	name	path	string	true "the name of the configuration"
+// @Success 200 {object} model.RolloutResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "If the rollout has completed, was aborted, or was superseded"
+// @Failure 500 {object} ErrorResponse
+func updateRollout(c *gin.Context, update func(ctx context.Context, name string) (*model.Rollout, error)) {
+	rollout, err := update(c.Request.Context(), c.Param("name"))
+	if errors.Is(err, server.ErrRolloutInactive) {
+		handleErrorResponse(c, http.StatusConflict, err)
+		return
+	}
+	if okResponse(c, err) {
+		c.JSON(http.StatusOK, &model.RolloutResponse{
+			Rollout: rollout,
+		})
+	}
+}
+
+// @Summary List upgrade campaigns
+// @Produce json
+// @Router /upgrade-campaigns [get]
+// @Success 200 {object} model.UpgradeCampaignsResponse
+// @Failure 500 {object} ErrorResponse
+func upgradeCampaigns(c *gin.Context, bindplane server.BindPlane) {
+	campaigns, err := bindplane.Store().UpgradeCampaigns(c.Request.Context())
+	if okResponse(c, err) {
+		c.JSON(http.StatusOK, &model.UpgradeCampaignsResponse{
+			UpgradeCampaigns: campaigns,
+		})
+	}
+}
+
+// @Summary Get an upgrade campaign
+// @Produce json
+// @Router /upgrade-campaigns/{name} [get]
+// @Param 	name	path	string	true "the name of the upgrade campaign"
+// @Success 200 {object} model.UpgradeCampaignResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func upgradeCampaign(c *gin.Context, bindplane server.BindPlane) {
+	campaign, err := bindplane.Store().UpgradeCampaign(c.Request.Context(), c.Param("name"))
+	if okResource(c, campaign == nil, err) {
+		c.JSON(http.StatusOK, &model.UpgradeCampaignResponse{
+			UpgradeCampaign: campaign,
+		})
+	}
+}
+
+// @Summary Create an upgrade campaign
+// @Description Upgrades the agents matching the selector or query in batches. The campaign is paused when more than
+// @Description maxFailurePercent of the completed upgrades have failed. The latest version is used if no version is specified.
+// @Produce json
+// @Router /upgrade-campaigns [post]
+// @Param body body model.UpgradeCampaign true "the name, version, agents, and options of the campaign"
+// @Success 201 {object} model.UpgradeCampaignResponse
+// @Failure 400 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "If a campaign with the same name has not completed"
+// @Failure 500 {object} ErrorResponse
+func createUpgradeCampaign(c *gin.Context, bindplane server.BindPlane) {
+	ctx, span := tracer.Start(c.Request.Context(), "rest/createUpgradeCampaign")
+	defer span.End()
+
+	campaign := &model.UpgradeCampaign{}
+	if err := c.BindJSON(campaign); err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if err := campaign.Validate(); err != nil {
+		handleErrorResponse(c, http.StatusBadRequest, err)
+		return
+	}
+	if campaign.Selector == "" && campaign.Query == "" {
+		handleErrorResponse(c, http.StatusBadRequest, errors.New("selector or query must be specified"))
+		return
+	}
+
+	options := []store.QueryOption{}
+	if campaign.Selector != "" {
+		selector, err := model.SelectorFromString(campaign.Selector)
+		if err != nil {
+			handleErrorResponse(c, http.StatusBadRequest, err)
+			return
+		}
+		options = append(options, store.WithSelector(selector))
+	}
+	if campaign.Query != "" {
+		q := search.ParseQuery(campaign.Query)
+		q.ReplaceVersionLatest(ctx, bindplane.Versions())
+		options = append(options, store.WithQuery(q))
+	}
+
+	campaign, err := bindplane.Manager().CreateUpgradeCampaign(ctx, campaign, options...)
+	if errors.Is(err, server.ErrUpgradeCampaignExists) {
+		handleErrorResponse(c, http.StatusConflict, err)
+		return
+	}
+	if okResponse(c, err) {
+		c.JSON(http.StatusCreated, &model.UpgradeCampaignResponse{
+			UpgradeCampaign: campaign,
+		})
+	}
+}
+
+// @Summary Pause or resume an upgrade campaign
+// @Description Pausing stops the campaign from starting new upgrades. Resuming continues a paused campaign and starts
+// @Description the next batch if the current batch is complete.
+// @Produce json
+// @Router /upgrade-campaigns/{name}/pause [put]
+// @Router /upgrade-campaigns/{name}/resume [put]
+// @Param 	name	path	string	true "the name of the upgrade campaign"
+// @Success 200 {object} model.UpgradeCampaignResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 409 {object} ErrorResponse "If the campaign has completed"
+// @Failure 500 {object} ErrorResponse
+func updateUpgradeCampaign(c *gin.Context, update func(ctx context.Context, name string) (*model.UpgradeCampaign, error)) {
+	campaign, err := update(c.Request.Context(), c.Param("name"))
+	if errors.Is(err, server.ErrUpgradeCampaignCompleted) {
+		handleErrorResponse(c, http.StatusConflict, err)
+		return
+	}
+	if okResponse(c, err) {
+		c.JSON(http.StatusOK, &model.UpgradeCampaignResponse{
+			UpgradeCampaign: campaign,
+		})
+	}
+}
+
 // @Summary Server version
 // @Description Returns the current bindplane version of the server.
 // @Produce json
@@ -1069,16 +1986,38 @@ func bindplaneVersion(c *gin.Context) {
 // @Router /agent-versions/{version}/install-command [get]
 // @Param version 	path	string	true "2.1.1"
 // @Param secret-key query string false "uuid"
+// @Param enrollment-token query string false "production"
 // @Param remote-url query string false "http%3A%2F%2Flocalhost%3A3001"
 // @Param platform query string false "windows-amd64"
 // @Param labels query string false "env=stage,app=bindplane"
 // @Success 200 {object} model.InstallCommandResponse
+// @Failure 400 {object} ErrorResponse "If the enrollment token is unknown or expired"
 func getInstallCommand(c *gin.Context, bindplane server.BindPlane) {
 	config := bindplane.Config()
 
 	// note: don't use DefaultQuery because caller may specify secret-key=(empty string) but we want to use the default
 	// value in that case
 	secretKey := c.Query("secret-key")
+
+	// an enrollment token is used in place of the secret key so that each agent is issued its own credentials
+	if name := c.Query("enrollment-token"); name != "" {
+		token, err := bindplane.Store().EnrollmentToken(c.Request.Context(), name)
+		switch {
+		case err != nil:
+			handleErrorResponse(c, http.StatusInternalServerError, err)
+			return
+
+		case token == nil:
+			handleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("unknown enrollment token: %s", name))
+			return
+
+		case token.Expired(time.Now()):
+			handleErrorResponse(c, http.StatusBadRequest, fmt.Errorf("enrollment token %s has expired", name))
+			return
+		}
+		secretKey = token.Token()
+	}
+
 	if secretKey == "" {
 		secretKey = config.SecretKey
 	}
@@ -1093,7 +2032,7 @@ func getInstallCommand(c *gin.Context, bindplane server.BindPlane) {
 	// if version is empty or "latest", find the latest version
 	version := c.Param("name")
 	if version == "" || version == "latest" {
-		v, err := bindplane.Versions().LatestVersion(c)
+		v, err := bindplane.Versions().LatestVersion(c.Request.Context())
 		if err != nil {
 			handleErrorResponse(c, http.StatusInternalServerError,
 				fmt.Errorf("unable to get the latest version of the agent: %w", err),
@@ -1158,7 +2097,7 @@ func syncAgentVersion(c *gin.Context, bindplane server.BindPlane) {
 		resources = append(resources, agentVersion)
 	}
 
-	resourceStatuses, err := bindplane.Store().ApplyResources(c, resources)
+	resourceStatuses, err := bindplane.Store().ApplyResources(c.Request.Context(), resources)
 	if err != nil {
 		handleErrorResponse(c, http.StatusInternalServerError, err)
 		return
@@ -1169,6 +2108,98 @@ func syncAgentVersion(c *gin.Context, bindplane server.BindPlane) {
 	})
 }
 
+// @Summary Get Agent Package
+// @Description Download the package of an agent version, downloading and caching it on the server if necessary.
+// @Produce octet-stream
+// @Router /agent-packages/{version}/{name} [get]
+// @Param version 	path	string	true "v1.14.0"
+// @Param name 	path	string	true "observiq-otel-collector-v1.14.0-linux-amd64.tar.gz"
+// @Success 200 {file} binary
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func agentPackage(c *gin.Context, bindplane server.BindPlane) {
+	agentVersion, platform, ok := agentPackageVersion(c, bindplane)
+	if !ok {
+		return
+	}
+
+	file, err := bindplane.Packages().Open(c.Request.Context(), agentVersion, platform)
+	if err != nil {
+		agentPackageErrorResponse(c, err)
+		return
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return
+	}
+
+	http.ServeContent(c.Writer, c.Request, c.Param("name"), info.ModTime(), file)
+}
+
+// @Summary Import Agent Package
+// @Description Upload the package of an agent version to the server. The sha256 of the package must match the agent-version.
+// @Accept octet-stream
+// @Router /agent-packages/{version}/{name} [put]
+// @Param version 	path	string	true "v1.14.0"
+// @Param name 	path	string	true "observiq-otel-collector-v1.14.0-linux-amd64.tar.gz"
+// @Success 204
+// @Failure 400 {object} ErrorResponse
+// @Failure 404 {object} ErrorResponse
+// @Failure 500 {object} ErrorResponse
+func importAgentPackage(c *gin.Context, bindplane server.BindPlane) {
+	agentVersion, platform, ok := agentPackageVersion(c, bindplane)
+	if !ok {
+		return
+	}
+
+	err := bindplane.Packages().Import(c.Request.Context(), agentVersion, platform, c.Request.Body)
+	if err != nil {
+		agentPackageErrorResponse(c, err)
+		return
+	}
+
+	c.Status(http.StatusNoContent)
+}
+
+// agentPackageVersion returns the agent-version and platform of the package in the request. If the agent-version
+// doesn't exist or the package name isn't a package of the agent-version, it sets a 404 response and returns false.
+func agentPackageVersion(c *gin.Context, bindplane server.BindPlane) (*model.AgentVersion, string, bool) {
+	version := c.Param("version")
+	name := c.Param("name")
+
+	platform := agent.PackagePlatform(version, name)
+	if platform == "" {
+		handleErrorResponse(c, http.StatusNotFound, fmt.Errorf("%s is not a package of agent version %s", name, version))
+		return nil, "", false
+	}
+
+	agentVersion, err := bindplane.Store().AgentVersion(c.Request.Context(), fmt.Sprintf("%s-%s", model.AgentTypeNameObservIQOtelCollector, version))
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return nil, "", false
+	}
+	if agentVersion == nil {
+		handleErrorResponse(c, http.StatusNotFound, fmt.Errorf("agent version %s not found", version))
+		return nil, "", false
+	}
+
+	return agentVersion, platform, true
+}
+
+func agentPackageErrorResponse(c *gin.Context, err error) {
+	switch {
+	case errors.Is(err, agent.ErrPackageNotFound):
+		handleErrorResponse(c, http.StatusNotFound, err)
+	case errors.Is(err, agent.ErrPackageHashMismatch):
+		handleErrorResponse(c, http.StatusBadRequest, err)
+	default:
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+	}
+}
+
 // ----------------------------------------------------------------------
 
 // okResponse returns true if there should be an OK response based on the error provided. It will set an error response on the
@@ -1200,6 +2231,86 @@ func okResource(c *gin.Context, resourceIsNil bool, err error) bool {
 	return true
 }
 
+// redactAgents replaces the agents with copies that have the values of secrets redacted from the configurations they
+// reported and without the secret keys issued to them. It returns false after responding with an error if the secrets
+// cannot be read.
+func redactAgents(c *gin.Context, bindplane server.BindPlane, agents []*model.Agent) bool {
+	values, err := store.SecretValues(c.Request.Context(), bindplane.Store(), bindplane.Config().SecretsKey)
+	if !okResponse(c, err) {
+		return false
+	}
+	for i, agent := range agents {
+		agents[i] = agent.RedactSecrets(values).RedactCredentials()
+	}
+	return true
+}
+
+// deleteIfMatch handles a request to delete a resource with an If-Match header by only deleting the resource if its
+// resourceVersion matches. It returns false if there is no If-Match header and the delete should be handled normally.
+func deleteIfMatch(c *gin.Context, bindplane server.BindPlane, kind model.Kind) bool {
+	version, ok := ifMatch(c)
+	if !ok {
+		return false
+	}
+
+	resource, err := model.ParseResource(&model.AnyResource{
+		ResourceMeta: model.ResourceMeta{
+			Kind: kind,
+			Metadata: model.Metadata{
+				Name:            c.Param("name"),
+				ResourceVersion: version,
+			},
+		},
+	})
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return true
+	}
+
+	statuses, err := bindplane.Store().DeleteResources(c.Request.Context(), []model.Resource{resource})
+	if err != nil {
+		handleErrorResponse(c, http.StatusInternalServerError, err)
+		return true
+	}
+	if len(statuses) == 0 {
+		handleErrorResponse(c, http.StatusNotFound, store.ErrResourceMissing)
+		return true
+	}
+
+	switch status := statuses[0]; status.Status {
+	case model.StatusDeleted:
+		c.Status(http.StatusNoContent)
+	case model.StatusConflict:
+		handleErrorResponse(c, http.StatusPreconditionFailed, errors.New(status.Reason))
+	case model.StatusInUse:
+		handleErrorResponse(c, http.StatusConflict, errors.New(status.Reason))
+	default:
+		handleErrorResponse(c, http.StatusInternalServerError, errors.New(status.Reason))
+	}
+	return true
+}
+
+// setETag sets the ETag header to the resourceVersion of the resource, if it has one
+func setETag(c *gin.Context, resource model.Resource) {
+	if version := model.RevisionResourceVersion(resource.Revision()); version != "" {
+		c.Header("ETag", strconv.Quote(version))
+	}
+}
+
+// ifMatch returns the resourceVersion in the If-Match header and true if the header is present. A wildcard If-Match
+// matches any version and is treated the same as no If-Match header.
+func ifMatch(c *gin.Context) (string, bool) {
+	value := strings.TrimSpace(c.GetHeader("If-Match"))
+	if value == "" || value == "*" {
+		return "", false
+	}
+	value = strings.TrimPrefix(value, "W/")
+	if version, err := strconv.Unquote(value); err == nil {
+		return version, true
+	}
+	return value, true
+}
+
 func isDependencyError(err error) bool {
 	_, ok := err.(*store.DependencyError)
 	return ok
@@ -16,6 +16,8 @@ package rest
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -24,6 +26,7 @@ import (
 	"net/url"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-resty/resty/v2"
@@ -232,6 +235,26 @@ func TestREST(t *testing.T) {
 		require.Equal(t, ar.Agent, agent)
 	})
 
+	t.Run("GET /agents does not return the secret keys issued to agents", func(t *testing.T) {
+		resetStore(t, s)
+
+		agent := &model.Agent{ID: "1", Name: "Fake Agent 1", Labels: model.MakeLabels()}
+		require.NoError(t, agent.IssueCredentials(nil))
+		secretKey := agent.Credentials.PendingSecretKey
+		secretKeyHash := agent.Credentials.SecretKeyHash
+		_, err := addAgent(s, agent)
+		require.NoError(t, err)
+
+		for _, endpoint := range []string{"/agents", "/agents/1"} {
+			resp, err := client.R().Get(endpoint)
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode())
+			require.Contains(t, resp.String(), `"credentials"`)
+			require.NotContains(t, resp.String(), secretKey)
+			require.NotContains(t, resp.String(), secretKeyHash)
+		}
+	})
+
 	t.Run("GET /destinations returns all Destinations in the store", func(t *testing.T) {
 		resetStore(t, s)
 
@@ -331,6 +354,80 @@ func TestREST(t *testing.T) {
 		assert.NotContains(t, destinations, destination1)
 	})
 
+	t.Run("GET /destination-types/:name/dependents returns the tree of dependent resources", func(t *testing.T) {
+		resetStore(t, s)
+		dest1 := testDestination("dest-1", "cabin")
+		config := model.NewConfigurationWithSpec("test-config", model.ConfigurationSpec{
+			Destinations: []model.ResourceConfiguration{{Name: "dest-1"}},
+		})
+
+		_, err := s.ApplyResources(ctx, []model.Resource{dest1, config})
+		require.NoError(t, err)
+
+		rr := &model.DependentsResponse{}
+		getRequest(t, client, "/destination-types/cabin/dependents", rr)
+
+		require.Equal(t, []*model.Dependent{
+			{
+				Kind: model.KindDestination,
+				Name: "dest-1",
+				Dependents: []*model.Dependent{
+					{Kind: model.KindConfiguration, Name: "test-config"},
+				},
+			},
+			{Kind: model.KindDestination, Name: "destination-2"},
+		}, rr.Dependents)
+
+		getRequest(t, client, "/configurations/test-config/dependents", rr)
+		require.Empty(t, rr.Dependents)
+
+		resp, err := client.R().Get("/destination-types/missing/dependents")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("POST /delete with cascade and dryRun returns the changes and affected agents", func(t *testing.T) {
+		resetStore(t, s)
+		dest1 := testDestination("dest-1", "cabin")
+		config := model.NewConfigurationWithSpec("test-config", model.ConfigurationSpec{
+			Destinations: []model.ResourceConfiguration{{Name: "dest-1"}},
+			Selector:     model.AgentSelector{MatchLabels: model.MatchLabels{"configuration": "test-config"}},
+		})
+		_, err := s.ApplyResources(ctx, []model.Resource{dest1, config})
+		require.NoError(t, err)
+		_, err = s.UpsertAgent(ctx, "agent-1", func(current *model.Agent) {
+			current.Labels, _ = model.LabelsFromMap(map[string]string{"configuration": "test-config"})
+		})
+		require.NoError(t, err)
+
+		payload := model.DeletePayload{
+			Resources: []*model.AnyResource{{ResourceMeta: model.ResourceMeta{Kind: model.KindDestination, Metadata: model.Metadata{Name: "dest-1"}}}},
+			Cascade:   model.CascadeReferences,
+			DryRun:    true,
+		}
+		result := &model.DeleteResponseClientSide{}
+		resp, err := client.R().SetBody(payload).SetResult(result).Post("/delete")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode())
+
+		require.True(t, result.DryRun)
+		require.Equal(t, []string{"agent-1"}, result.Agents)
+		require.Len(t, result.Updates, 2)
+		require.Equal(t, "test-config", result.Updates[0].Resource.Name())
+		require.Equal(t, model.StatusConfigured, result.Updates[0].Status)
+		require.Equal(t, "dest-1", result.Updates[1].Resource.Name())
+		require.Equal(t, model.StatusDeleted, result.Updates[1].Status)
+
+		destination, err := s.Destination(ctx, "dest-1")
+		require.NoError(t, err)
+		require.NotNil(t, destination, "a dry run does not delete")
+
+		payload.Cascade = "everything"
+		resp, err = client.R().SetBody(payload).Post("/delete")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
 	t.Run("DELETE /destinations/:name 409 Conflict", func(t *testing.T) {
 		resetStore(t, s)
 		dest1 := testDestination(
@@ -391,6 +488,33 @@ func TestREST(t *testing.T) {
 		require.ElementsMatch(t, rr.Sources, []*model.Source{source1, source2})
 	})
 
+	t.Run("GET /sources?query= returns matching Sources in the store", func(t *testing.T) {
+		resetStore(t, s)
+
+		source1 := testSource("source-1", "nginx")
+		source2 := testSource("source-2", "macos")
+		source3 := testSource("source-3", "nginx")
+
+		_, err := s.ApplyResources(ctx, []model.Resource{source1, source2, source3})
+		require.NoError(t, err)
+
+		rr := &model.SourcesResponse{}
+		getRequest(t, client, "/sources?query=type:nginx&sort=name", rr)
+
+		require.Len(t, rr.Sources, 2)
+		require.Equal(t, "source-1", rr.Sources[0].Name())
+		require.Equal(t, "source-3", rr.Sources[1].Name())
+
+		getRequest(t, client, "/sources?query=type:nginx&sort=name&offset=1&limit=1", rr)
+
+		require.Len(t, rr.Sources, 1)
+		require.Equal(t, "source-3", rr.Sources[0].Name())
+
+		resp, err := client.R().SetError(&ErrorResponse{}).Get("/sources?limit=ten")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
 	t.Run("GET /sources/:name returns a specific Source by name", func(t *testing.T) {
 		resetStore(t, s)
 
@@ -607,6 +731,41 @@ func TestREST(t *testing.T) {
 		require.Equal(t, testConfiguration2, pr.Configuration)
 	})
 
+	t.Run("GET /configurations/:name?agent=:id renders the configuration for the agent", func(t *testing.T) {
+		resetStore(t, s)
+
+		sourceType := model.NewSourceTypeWithSpec("otlp", model.ResourceTypeSpec{
+			Logs: model.ResourceTypeOutput{
+				Receivers: "- otlp:\n    endpoint: {{ .Agent.Hostname }}:4317\n",
+			},
+		})
+		destinationType := model.NewDestinationTypeWithSpec("logging", model.ResourceTypeSpec{
+			Logs: model.ResourceTypeOutput{
+				Exporters: "- logging:\n",
+			},
+		})
+		configuration := model.NewConfigurationWithSpec("per-agent", model.ConfigurationSpec{
+			Sources:      []model.ResourceConfiguration{{ParameterizedSpec: model.ParameterizedSpec{Type: "otlp"}}},
+			Destinations: []model.ResourceConfiguration{{ParameterizedSpec: model.ParameterizedSpec{Type: "logging"}}},
+		})
+		_, err := bindplane.Store().ApplyResources(ctx, []model.Resource{sourceType, destinationType, configuration})
+		require.NoError(t, err)
+
+		_, err = addAgent(s, &model.Agent{ID: "1", HostName: "host-1", Labels: model.MakeLabels()})
+		require.NoError(t, err)
+
+		pr := &model.ConfigurationResponse{}
+		getRequest(t, client, "/configurations/per-agent", pr)
+		require.Contains(t, pr.Raw, "endpoint: :4317")
+
+		getRequest(t, client, "/configurations/per-agent?agent=1", pr)
+		require.Contains(t, pr.Raw, "endpoint: host-1:4317")
+
+		resp, err := client.R().Get("/configurations/per-agent?agent=2")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
 	t.Run("DELETE /configurations/:name 404 Not Found", func(t *testing.T) {
 		resetStore(t, s)
 
@@ -687,6 +846,204 @@ func TestREST(t *testing.T) {
 		})
 	})
 
+	t.Run("GET /configurations/:name/revisions and POST /configurations/:name/rollback", func(t *testing.T) {
+		resetStore(t, s)
+
+		for _, raw := range []string{"raw: 1", "raw: 2"} {
+			_, err := bindplane.Store().ApplyResources(ctx, []model.Resource{model.NewRawConfiguration("revisions", raw)})
+			require.NoError(t, err)
+		}
+
+		t.Run("404 Not Found revisions", func(t *testing.T) {
+			resp, err := client.R().Get("/configurations/does-not-exist/revisions")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusNotFound, resp.StatusCode())
+		})
+
+		t.Run("200 OK revisions", func(t *testing.T) {
+			result := &model.ConfigurationRevisionsResponse{}
+			getRequest(t, client, "/configurations/revisions/revisions", result)
+
+			require.Len(t, result.Revisions, 2)
+			require.Equal(t, 1, result.Revisions[0].Revision())
+			require.Equal(t, "raw: 1", result.Revisions[0].Spec.Raw)
+			require.Equal(t, 2, result.Revisions[1].Revision())
+			require.Equal(t, "raw: 2", result.Revisions[1].Spec.Raw)
+		})
+
+		t.Run("400 Bad Request rollback", func(t *testing.T) {
+			resp, err := client.R().SetBody(`{"""`).Post("/configurations/revisions/rollback")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+		})
+
+		t.Run("404 Not Found rollback", func(t *testing.T) {
+			resp, err := client.R().SetBody(&model.PostRollbackRequest{Revision: 10}).Post("/configurations/revisions/rollback")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusNotFound, resp.StatusCode())
+		})
+
+		t.Run("200 OK rollback", func(t *testing.T) {
+			result := &model.PostRollbackResponse{}
+			resp, err := client.R().SetBody(&model.PostRollbackRequest{Revision: 1}).SetResult(result).Post("/configurations/revisions/rollback")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusOK, resp.StatusCode())
+			require.Equal(t, 3, result.Revision)
+
+			config, err := s.Configuration(ctx, "revisions")
+			require.NoError(t, err)
+			require.Equal(t, "raw: 1", config.Spec.Raw)
+		})
+	})
+
+	t.Run("GET ETag and POST /apply If-Match", func(t *testing.T) {
+		resetStore(t, s)
+
+		_, err := bindplane.Store().ApplyResources(ctx, []model.Resource{model.NewRawConfiguration("if-match", "raw: 1")})
+		require.NoError(t, err)
+
+		t.Run("GET /configurations/:name returns the ETag", func(t *testing.T) {
+			resp, err := client.R().Get("/configurations/if-match")
+			require.NoError(t, err)
+			require.Equal(t, `"1"`, resp.Header().Get("ETag"))
+		})
+
+		t.Run("400 Bad Request with multiple resources", func(t *testing.T) {
+			payload := &model.ApplyPayload{Resources: []*model.AnyResource{
+				testConfigurationAsAny(t, uuid.NewString(), "if-match"),
+				testConfigurationAsAny(t, uuid.NewString(), "other"),
+			}}
+			resp, err := client.R().SetHeader("If-Match", `"1"`).SetBody(payload).Post("/apply")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+		})
+
+		t.Run("412 Precondition Failed with a stale version", func(t *testing.T) {
+			payload := &model.ApplyPayload{Resources: []*model.AnyResource{testConfigurationAsAny(t, uuid.NewString(), "if-match")}}
+			result := &model.ApplyResponseClientSide{}
+			resp, err := client.R().SetHeader("If-Match", `"0"`).SetBody(payload).SetError(result).Post("/apply")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode())
+			require.Len(t, result.Updates, 1)
+			require.Equal(t, model.StatusConflict, result.Updates[0].Status)
+		})
+
+		t.Run("202 Accepted with the current version", func(t *testing.T) {
+			payload := &model.ApplyPayload{Resources: []*model.AnyResource{testConfigurationAsAny(t, uuid.NewString(), "if-match")}}
+			result := &model.ApplyResponseClientSide{}
+			resp, err := client.R().SetHeader("If-Match", `"1"`).SetBody(payload).SetResult(result).Post("/apply")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusAccepted, resp.StatusCode())
+			require.Len(t, result.Updates, 1)
+			require.Equal(t, model.StatusConfigured, result.Updates[0].Status)
+			require.Equal(t, 2, result.Updates[0].Resource.Revision())
+		})
+
+		t.Run("412 Precondition Failed when the resource does not exist", func(t *testing.T) {
+			payload := &model.ApplyPayload{Resources: []*model.AnyResource{testConfigurationAsAny(t, uuid.NewString(), "if-match-missing")}}
+			result := &model.ApplyResponseClientSide{}
+			resp, err := client.R().SetHeader("If-Match", `"1"`).SetBody(payload).SetError(result).Post("/apply")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode())
+
+			config, err := bindplane.Store().Configuration(ctx, "if-match-missing")
+			require.NoError(t, err)
+			require.Nil(t, config)
+		})
+	})
+
+	t.Run("DELETE and POST /delete If-Match", func(t *testing.T) {
+		resetStore(t, s)
+
+		_, err := bindplane.Store().ApplyResources(ctx, []model.Resource{
+			model.NewRawConfiguration("if-match", "raw: 1"),
+			model.NewRawConfiguration("if-match-body", "raw: 1"),
+		})
+		require.NoError(t, err)
+
+		t.Run("DELETE /configurations/:name 412 Precondition Failed with a stale version", func(t *testing.T) {
+			resp, err := client.R().SetHeader("If-Match", `"0"`).SetError(&ErrorResponse{}).Delete("/configurations/if-match")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode())
+
+			config, err := bindplane.Store().Configuration(ctx, "if-match")
+			require.NoError(t, err)
+			require.NotNil(t, config)
+		})
+
+		t.Run("DELETE /configurations/:name 204 No Content with the current version", func(t *testing.T) {
+			resp, err := client.R().SetHeader("If-Match", `"1"`).Delete("/configurations/if-match")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusNoContent, resp.StatusCode())
+
+			config, err := bindplane.Store().Configuration(ctx, "if-match")
+			require.NoError(t, err)
+			require.Nil(t, config)
+		})
+
+		t.Run("DELETE /configurations/:name 412 Precondition Failed when the resource does not exist", func(t *testing.T) {
+			resp, err := client.R().SetHeader("If-Match", `"1"`).SetError(&ErrorResponse{}).Delete("/configurations/if-match")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode())
+		})
+
+		t.Run("POST /delete 412 Precondition Failed with a stale version", func(t *testing.T) {
+			payload := &model.DeletePayload{Resources: []*model.AnyResource{testConfigurationAsAny(t, uuid.NewString(), "if-match-body")}}
+			result := &model.DeleteResponseClientSide{}
+			resp, err := client.R().SetHeader("If-Match", `"0"`).SetBody(payload).SetError(result).Post("/delete")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode())
+			require.Len(t, result.Updates, 1)
+			require.Equal(t, model.StatusConflict, result.Updates[0].Status)
+		})
+
+		t.Run("POST /delete 202 Accepted with the current version", func(t *testing.T) {
+			payload := &model.DeletePayload{Resources: []*model.AnyResource{testConfigurationAsAny(t, uuid.NewString(), "if-match-body")}}
+			result := &model.DeleteResponseClientSide{}
+			resp, err := client.R().SetHeader("If-Match", `"1"`).SetBody(payload).SetResult(result).Post("/delete")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusAccepted, resp.StatusCode())
+			require.Len(t, result.Updates, 1)
+			require.Equal(t, model.StatusDeleted, result.Updates[0].Status)
+		})
+
+		t.Run("POST /delete 412 Precondition Failed when the resource does not exist", func(t *testing.T) {
+			payload := &model.DeletePayload{Resources: []*model.AnyResource{testConfigurationAsAny(t, uuid.NewString(), "if-match-body")}}
+			result := &model.DeleteResponseClientSide{}
+			resp, err := client.R().SetHeader("If-Match", `"1"`).SetBody(payload).SetError(result).Post("/delete")
+			require.NoError(t, err)
+			require.Equal(t, http.StatusPreconditionFailed, resp.StatusCode())
+			require.Len(t, result.Updates, 1)
+			require.Equal(t, model.StatusConflict, result.Updates[0].Status)
+		})
+	})
+
+	t.Run("GET /audit returns matching audit entries, newest first", func(t *testing.T) {
+		resetStore(t, s)
+
+		require.NoError(t, s.AddAuditEntries(ctx, []*model.AuditEntry{
+			{User: "admin", Action: model.AuditActionCreate, Kind: model.KindSource, Name: "source-1"},
+			{User: "admin", Action: model.AuditActionDelete, Kind: model.KindDestination, Name: "destination-1"},
+			{User: "admin", Action: model.AuditActionUpdate, Kind: model.KindSource, Name: "source-1"},
+		}))
+
+		rr := &model.AuditEntriesResponse{}
+		getRequest(t, client, "/audit?kind=Source", rr)
+
+		require.Len(t, rr.AuditEntries, 2)
+		require.Equal(t, model.AuditActionUpdate, rr.AuditEntries[0].Action)
+		require.Equal(t, model.AuditActionCreate, rr.AuditEntries[1].Action)
+
+		getRequest(t, client, "/audit?offset=1&limit=1", rr)
+
+		require.Len(t, rr.AuditEntries, 1)
+		require.Equal(t, model.AuditActionDelete, rr.AuditEntries[0].Action)
+
+		resp, err := client.R().SetError(&ErrorResponse{}).Get("/audit?limit=ten")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
 	t.Run("POST /delete Status 200 Accepted", func(t *testing.T) {
 		tests := []struct {
 			description   string
@@ -822,6 +1179,333 @@ func TestREST(t *testing.T) {
 
 	})
 
+	t.Run("GET /agents/:id/history returns the status changes and uptime", func(t *testing.T) {
+		resetStore(t, bindplane.Store())
+
+		addAgent(store, &model.Agent{ID: "1", Status: model.Connected})
+		_, err := store.UpsertAgent(ctx, "1", func(current *model.Agent) {
+			current.Status = model.Error
+			current.ErrorMessage = "failed"
+		})
+		require.NoError(t, err)
+
+		result := &model.AgentHistoryResponse{}
+		resp, err := client.R().SetResult(result).Get("/agents/1/history")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Len(t, result.History.Changes, 2)
+		require.Equal(t, model.Connected, result.History.Changes[0].Status)
+		require.Equal(t, model.Error, result.History.Changes[1].Status)
+		require.Equal(t, "failed", result.History.Changes[1].ErrorMessage)
+
+		// a window after the changes only includes the current status
+		start := time.Now().Add(time.Hour).UTC()
+		end := start.Add(time.Hour)
+		result = &model.AgentHistoryResponse{}
+		resp, err = client.R().SetResult(result).
+			SetQueryParam("start", start.Format(time.RFC3339)).
+			SetQueryParam("end", end.Format(time.RFC3339)).
+			Get("/agents/1/history")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Empty(t, result.History.Changes)
+		require.Equal(t, float64(0), result.History.Uptime)
+
+		resp, err = client.R().SetQueryParam("start", "yesterday").Get("/agents/1/history")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		resp, err = client.R().Get("/agents/missing/history")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("PUT /agents/:id/restart requests a restart of the agent", func(t *testing.T) {
+		resetStore(t, bindplane.Store())
+
+		addAgent(store, &model.Agent{ID: "1", Status: model.Connected})
+		addAgent(store, &model.Agent{ID: "2", Status: model.Disconnected})
+
+		result := &model.AgentResponse{}
+		resp, err := client.R().SetResult(result).Put("/agents/1/restart")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode())
+		require.Equal(t, model.Restarting, result.Agent.Status)
+		require.Equal(t, model.RestartPending, result.Agent.Restart.Status)
+
+		resp, err = client.R().Put("/agents/2/restart")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode())
+
+		resp, err = client.R().Put("/agents/missing/restart")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("PUT /agents/restart requests a restart of the matching agents", func(t *testing.T) {
+		resetStore(t, bindplane.Store())
+
+		addAgent(store, &model.Agent{ID: "1", Status: model.Connected, Labels: model.Labels{Set: map[string]string{"env": "dev"}}})
+		addAgent(store, &model.Agent{ID: "2", Status: model.Connected, Labels: model.Labels{Set: map[string]string{"env": "prod"}}})
+		addAgent(store, &model.Agent{ID: "3", Status: model.Connected, Labels: model.Labels{Set: map[string]string{"env": "test"}}})
+
+		result := &model.RestartAgentsResponse{}
+		resp, err := client.R().
+			SetBody(&model.RestartAgentsPayload{IDs: []string{"1"}, Selector: "env=prod"}).
+			SetResult(result).
+			Put("/agents/restart")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusAccepted, resp.StatusCode())
+		require.Len(t, result.Agents, 2)
+
+		agent, err := store.Agent(ctx, "3")
+		require.NoError(t, err)
+		require.Nil(t, agent.Restart)
+
+		resp, err = client.R().SetBody(&model.RestartAgentsPayload{}).Put("/agents/restart")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		resp, err = client.R().SetBody(&model.RestartAgentsPayload{Selector: "not a selector!"}).Put("/agents/restart")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("DELETE /agents/:id/credentials revokes the credentials of the agent", func(t *testing.T) {
+		resetStore(t, bindplane.Store())
+
+		addAgent(store, &model.Agent{ID: "1", Status: model.Connected})
+		addAgent(store, &model.Agent{ID: "2", Status: model.Connected})
+		_, err := store.UpsertAgent(ctx, "1", func(current *model.Agent) {
+			require.NoError(t, current.IssueCredentials(nil))
+		})
+		require.NoError(t, err)
+
+		result := &model.RevokeAgentCredentialsResponse{}
+		resp, err := client.R().SetResult(result).Delete("/agents/1/credentials")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.True(t, result.Agent.Credentials.Revoked())
+
+		revoked, err := store.AgentRevoked(ctx, "1")
+		require.NoError(t, err)
+		require.True(t, revoked)
+
+		resp, err = client.R().Delete("/agents/2/credentials")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode())
+
+		resp, err = client.R().Delete("/agents/missing/credentials")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("GET /enrollment-tokens returns all EnrollmentTokens in the store", func(t *testing.T) {
+		resetStore(t, bindplane.Store())
+
+		_, err := store.ApplyResources(ctx, []model.Resource{
+			model.NewEnrollmentToken("production", model.EnrollmentTokenSpec{MaxUses: 10}),
+			model.NewEnrollmentToken("staging", model.EnrollmentTokenSpec{}),
+		})
+		require.NoError(t, err)
+
+		result := &model.EnrollmentTokensResponse{}
+		getRequest(t, client, "/enrollment-tokens", result)
+		require.Len(t, result.EnrollmentTokens, 2)
+
+		tokenResult := &model.EnrollmentTokenResponse{}
+		getRequest(t, client, "/enrollment-tokens/production", tokenResult)
+		require.Equal(t, 10, tokenResult.EnrollmentToken.Spec.MaxUses)
+		require.NotEmpty(t, tokenResult.EnrollmentToken.Token())
+
+		resp, err := client.R().Delete("/enrollment-tokens/staging")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode())
+
+		resp, err = client.R().Get("/enrollment-tokens/staging")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("GET /agent-versions/:name/install-command uses the enrollment token", func(t *testing.T) {
+		resetStore(t, bindplane.Store())
+
+		_, err := store.ApplyResources(ctx, []model.Resource{
+			model.NewEnrollmentToken("production", model.EnrollmentTokenSpec{}),
+			model.NewEnrollmentToken("expired", model.EnrollmentTokenSpec{ExpiresAt: "2020-01-01T00:00:00Z"}),
+		})
+		require.NoError(t, err)
+		token, err := store.EnrollmentToken(ctx, "production")
+		require.NoError(t, err)
+
+		result := &model.InstallCommandResponse{}
+		resp, err := client.R().SetResult(result).
+			SetQueryParam("platform", "linux-amd64").
+			SetQueryParam("enrollment-token", "production").
+			Get("/agent-versions/2.1.1/install-command")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Contains(t, result.Command, fmt.Sprintf("-s %s", token.Token()))
+
+		resp, err = client.R().SetQueryParam("enrollment-token", "expired").Get("/agent-versions/2.1.1/install-command")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		resp, err = client.R().SetQueryParam("enrollment-token", "missing").Get("/agent-versions/2.1.1/install-command")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("GET and PUT /rollouts show and control rollouts", func(t *testing.T) {
+		resetStore(t, bindplane.Store())
+
+		configuration := model.NewConfiguration("test")
+		configuration.Spec.Rollout = &model.RolloutOptions{BatchSize: 1}
+		running := model.NewRollout(configuration, 1, time.Now())
+		running.StartWave([]string{"1"}, time.Now())
+		require.NoError(t, store.UpsertRollout(ctx, running))
+
+		completed := model.NewRollout(model.NewConfiguration("other"), 1, time.Now())
+		completed.Status = model.RolloutCompleted
+		require.NoError(t, store.UpsertRollout(ctx, completed))
+
+		rollouts := &model.RolloutsResponse{}
+		resp, err := client.R().SetResult(rollouts).Get("/rollouts")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Len(t, rollouts.Rollouts, 2)
+
+		result := &model.RolloutResponse{}
+		resp, err = client.R().SetResult(result).Get("/rollouts/test")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Equal(t, model.RolloutRunning, result.Rollout.Status)
+		require.True(t, result.Rollout.Includes("1"))
+
+		resp, err = client.R().SetResult(result).Put("/rollouts/test/pause")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Equal(t, model.RolloutPaused, result.Rollout.Status)
+
+		resp, err = client.R().SetResult(result).Put("/rollouts/test/resume")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Equal(t, model.RolloutRunning, result.Rollout.Status)
+
+		resp, err = client.R().Put("/rollouts/other/abort")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode())
+
+		resp, err = client.R().Get("/rollouts/missing")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+		resp, err = client.R().Put("/rollouts/missing/pause")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
+	t.Run("GET /agents/drift returns the drifted agents", func(t *testing.T) {
+		resetStore(t, bindplane.Store())
+
+		drifted := &model.Agent{ID: "1", Name: "drifted", Labels: model.MakeLabels()}
+		drifted.UpdateDrift("test", "receivers:\n  otlp:\n", "receivers:\n  hostmetrics:\n", nil, time.Now())
+		_, err := addAgent(s, drifted)
+		require.NoError(t, err)
+		_, err = addAgent(s, &model.Agent{ID: "2", Name: "in sync", Labels: model.MakeLabels()})
+		require.NoError(t, err)
+
+		result := &model.DriftedAgentsResponse{}
+		resp, err := client.R().SetResult(result).Get("/agents/drift")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Len(t, result.Agents, 1)
+		require.Equal(t, "1", result.Agents[0].ID)
+		require.Equal(t, "test", result.Agents[0].Drift.Configuration)
+		require.Contains(t, result.Agents[0].Drift.Diff, "+  hostmetrics:")
+
+		// other agent routes are unaffected
+		agent := &model.AgentResponse{}
+		resp, err = client.R().SetResult(agent).Get("/agents/2")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.False(t, agent.Agent.Drifted())
+	})
+
+	t.Run("POST, GET, and PUT /upgrade-campaigns create, show, and control upgrade campaigns", func(t *testing.T) {
+		resetStore(t, bindplane.Store())
+
+		_, err := addAgent(s, &model.Agent{ID: "1", Version: "v1.8.0", Labels: model.LabelsFromValidatedMap(map[string]string{"env": "prod"})})
+		require.NoError(t, err)
+
+		result := &model.UpgradeCampaignResponse{}
+		resp, err := client.R().SetResult(result).SetBody(&model.UpgradeCampaign{Name: "prod", Version: "v1.9.0", Selector: "env=prod"}).Post("/upgrade-campaigns")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+		require.Equal(t, "v1.9.0", result.UpgradeCampaign.Version)
+		require.Len(t, result.UpgradeCampaign.Agents, 1)
+
+		// agents that are not connected are skipped
+		require.Equal(t, model.UpgradeCampaignRunning, result.UpgradeCampaign.Status)
+		require.Equal(t, model.UpgradeCampaignAgentSkipped, result.UpgradeCampaign.Agents["1"].Status)
+
+		resp, err = client.R().SetBody(&model.UpgradeCampaign{Name: "all", Version: "v1.9.0"}).Post("/upgrade-campaigns")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		resp, err = client.R().SetBody(&model.UpgradeCampaign{Name: "prod", Selector: "env=prod", MaxFailurePercent: 101}).Post("/upgrade-campaigns")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+		running := &model.UpgradeCampaign{Name: "dev", Version: "v1.9.0"}
+		running.Start([]string{"2"}, time.Now())
+		running.StartBatch(time.Now())
+		running.Agents["2"].Status = model.UpgradeCampaignAgentUpgrading
+		require.NoError(t, store.UpsertUpgradeCampaign(ctx, running))
+
+		resp, err = client.R().SetBody(&model.UpgradeCampaign{Name: "dev", Selector: "env=dev"}).Post("/upgrade-campaigns")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode())
+
+		campaigns := &model.UpgradeCampaignsResponse{}
+		resp, err = client.R().SetResult(campaigns).Get("/upgrade-campaigns")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Len(t, campaigns.UpgradeCampaigns, 2)
+
+		resp, err = client.R().SetResult(result).Get("/upgrade-campaigns/dev")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Equal(t, model.UpgradeCampaignRunning, result.UpgradeCampaign.Status)
+
+		resp, err = client.R().SetResult(result).Put("/upgrade-campaigns/dev/pause")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Equal(t, model.UpgradeCampaignPaused, result.UpgradeCampaign.Status)
+
+		resp, err = client.R().SetResult(result).Put("/upgrade-campaigns/dev/resume")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Equal(t, model.UpgradeCampaignRunning, result.UpgradeCampaign.Status)
+
+		completed, err := store.UpgradeCampaign(ctx, "prod")
+		require.NoError(t, err)
+		completed.Status = model.UpgradeCampaignCompleted
+		require.NoError(t, store.UpsertUpgradeCampaign(ctx, completed))
+
+		resp, err = client.R().Put("/upgrade-campaigns/prod/pause")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode())
+
+		resp, err = client.R().Get("/upgrade-campaigns/missing")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+		resp, err = client.R().Put("/upgrade-campaigns/missing/resume")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+
 	t.Run("PATCH /agents/labels status 200", func(t *testing.T) {
 		resetStore(t, bindplane.Store())
 
@@ -902,6 +1586,102 @@ func getRequest(t *testing.T, client *resty.Client, endpoint string, result inte
 	}
 }
 
+func TestRESTSecrets(t *testing.T) {
+	router := gin.Default()
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	mapstore := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+	s := store.NewAuditStore(store.NewSecretStore(mapstore, "secrets-key"), nil, zap.NewNop())
+
+	bindplane, err := server.NewBindPlane(&common.Server{SecretsKey: "secrets-key"}, zaptest.NewLogger(t), s, nil)
+	require.NoError(t, err)
+	AddRestRoutes(router, bindplane)
+
+	client := resty.New()
+	client.SetBaseURL(svr.URL)
+
+	_, err = s.ApplyResources(ctx, []model.Resource{model.NewSecret("token", "s3cr3t")})
+	require.NoError(t, err)
+
+	t.Run("GET /secrets does not return the values of secrets", func(t *testing.T) {
+		resp, err := client.R().Get("/secrets/token")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.NotContains(t, resp.String(), "s3cr3t")
+		require.NotContains(t, resp.String(), "encryptedValue")
+	})
+
+	t.Run("GET /audit redacts the values of secrets from agents", func(t *testing.T) {
+		_, err := addAgent(s, &model.Agent{ID: "1", Labels: model.MakeLabels()})
+		require.NoError(t, err)
+
+		userCtx := store.ContextWithUser(ctx, "alice")
+		_, err = s.UpsertAgent(userCtx, "1", func(current *model.Agent) {
+			current.Labels = model.LabelsFromMerge(current.Labels, model.LabelsFromValidatedMap(map[string]string{"env": "prod"}))
+			current.Configuration = map[string]any{"collector": "authorization: Bearer s3cr3t\n"}
+			require.NoError(t, current.IssueCredentials(nil))
+		})
+		require.NoError(t, err)
+
+		entries, err := mapstore.AuditEntries(ctx, model.AuditFilter{Kind: model.KindAgent})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		data, err := json.Marshal(entries[0])
+		require.NoError(t, err)
+		require.Contains(t, string(data), "s3cr3t", "the value is stored in the audit entry")
+
+		resp, err := client.R().Get("/audit?kind=Agent")
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Contains(t, resp.String(), "labels.env: (added)")
+		require.Contains(t, resp.String(), "authorization: Bearer (redacted)")
+		require.NotContains(t, resp.String(), "s3cr3t")
+		require.NotContains(t, resp.String(), "secretKeyHash")
+	})
+}
+
+func TestRESTAuditUser(t *testing.T) {
+	router := gin.New()
+	// authentication middleware attaches the user to the request context
+	router.Use(func(c *gin.Context) {
+		c.Request = c.Request.WithContext(store.ContextWithUser(c.Request.Context(), "alice"))
+	})
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	s := store.NewAuditStore(store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop()), nil, zap.NewNop())
+
+	bindplane, err := server.NewBindPlane(&common.Server{}, zaptest.NewLogger(t), s, nil)
+	require.NoError(t, err)
+	AddRestRoutes(router, bindplane)
+
+	client := resty.New()
+	client.SetBaseURL(svr.URL)
+
+	_, err = s.ApplyResources(ctx, []model.Resource{testRawConfiguration("1", "configuration1")})
+	require.NoError(t, err)
+
+	resp, err := client.R().Delete("/configurations/configuration1")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode())
+
+	entries, err := s.AuditEntries(ctx, model.AuditFilter{Kind: model.KindConfiguration, User: "alice"})
+	require.NoError(t, err)
+	require.Len(t, entries, 1)
+	require.Equal(t, model.AuditActionDelete, entries[0].Action)
+}
+
 func TestRESTMock(t *testing.T) {
 	source1 := testSource("source1", "macos")
 	source2 := testSource("source2", "macos")
@@ -1691,12 +2471,12 @@ func (m *mockStore) ApplyResources(ctx context.Context, resources []model.Resour
 	return args.Get(0).([]model.ResourceStatus), args.Error(1)
 }
 
-func (m *mockStore) DeleteResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+func (m *mockStore) DeleteResources(ctx context.Context, resources []model.Resource, options ...store.DeleteOption) ([]model.ResourceStatus, error) {
 	args := m.Called(resources)
 	return args.Get(0).([]model.ResourceStatus), args.Error(1)
 }
 
-func (m *mockStore) Sources(ctx context.Context) ([]*model.Source, error) {
+func (m *mockStore) Sources(ctx context.Context, options ...store.QueryOption) ([]*model.Source, error) {
 	args := m.Called()
 	return args.Get(0).([]*model.Source), args.Error(1)
 }
@@ -1736,7 +2516,7 @@ func (m *mockStore) Agent(ctx context.Context, id string) (*model.Agent, error)
 	}
 }
 
-func (m *mockStore) Destinations(ctx context.Context) ([]*model.Destination, error) {
+func (m *mockStore) Destinations(ctx context.Context, options ...store.QueryOption) ([]*model.Destination, error) {
 	args := m.Called()
 	return args.Get(0).([]*model.Destination), args.Error(1)
 }
@@ -1785,3 +2565,66 @@ func (m *mockStore) DeleteConfiguration(ctx context.Context, name string) (*mode
 		return args.Get(0).(*model.Configuration), args.Error(1)
 	}
 }
+
+func TestAgentPackages(t *testing.T) {
+	router := gin.Default()
+	svr := httptest.NewServer(router)
+	defer svr.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, zap.NewNop())
+
+	config := common.InitConfig(t.TempDir()).Server
+	config.Offline = true
+	bindplane, err := server.NewBindPlane(&config, zaptest.NewLogger(t), store, nil)
+	require.NoError(t, err)
+	AddRestRoutes(router, bindplane)
+
+	client := resty.New()
+	client.SetBaseURL(svr.URL)
+
+	contents := []byte("observiq-otel-collector")
+	sum := sha256.Sum256(contents)
+	_, err = store.ApplyResources(ctx, []model.Resource{
+		model.NewAgentVersion(model.AgentVersionSpec{
+			Type:    string(model.AgentTypeNameObservIQOtelCollector),
+			Version: "v1.14.0",
+			Download: map[string]model.AgentDownload{
+				"linux/amd64": {Hash: hex.EncodeToString(sum[:])},
+			},
+		}),
+	})
+	require.NoError(t, err)
+
+	endpoint := "/agent-packages/v1.14.0/observiq-otel-collector-v1.14.0-linux-amd64.tar.gz"
+
+	// not imported and the server is offline
+	resp, err := client.R().Get(endpoint)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+	resp, err = client.R().SetBody([]byte("something else")).Put(endpoint)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+
+	resp, err = client.R().SetBody(contents).Put(endpoint)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNoContent, resp.StatusCode())
+
+	resp, err = client.R().Get(endpoint)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, resp.StatusCode())
+	require.Equal(t, contents, resp.Body())
+
+	resp, err = client.R().Get("/agent-packages/v1.14.0/observiq-otel-collector-v1.14.0-linux-amd64.deb")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+
+	resp, err = client.R().Get("/agent-packages/v1.15.0/observiq-otel-collector-v1.15.0-linux-amd64.tar.gz")
+	require.NoError(t, err)
+	require.Equal(t, http.StatusNotFound, resp.StatusCode())
+}
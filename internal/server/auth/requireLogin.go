@@ -36,6 +36,7 @@ func RequireLogin() gin.HandlerFunc {
 func Chain(server server.BindPlane) []gin.HandlerFunc {
 	return []gin.HandlerFunc{
 		CheckBasic(server),
+		CheckSignature(server),
 		CheckSession(server),
 		RequireLogin(),
 	}
@@ -20,6 +20,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/observiq/bindplane-op/internal/server"
 	"github.com/observiq/bindplane-op/internal/server/sessions"
+	"github.com/observiq/bindplane-op/internal/store"
 )
 
 // CheckSession checks to see if the attached cookie session is authenticated
@@ -50,6 +51,9 @@ func CheckSession(server server.BindPlane) gin.HandlerFunc {
 		}
 
 		c.Keys["authenticated"] = true
+		if user, ok := session.Values["user"].(string); ok {
+			c.Request = c.Request.WithContext(store.ContextWithUser(c.Request.Context(), user))
+		}
 		// Extend the cookies life by 15 minutes since the user is active and making requests.
 		session.Options.MaxAge = 15 * 60
 		err = session.Save(c.Request, c.Writer)
@@ -0,0 +1,138 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/stretchr/testify/require"
+
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/internal/server/mocks"
+)
+
+func TestValidSignature(t *testing.T) {
+	now := time.Date(2023, time.January, 1, 0, 0, 0, 0, time.UTC)
+	path := "/v1/agent-packages/v1.14.0/observiq-otel-collector-v1.14.0-linux-amd64.tar.gz"
+	query := SignPath("secret", path, now.Add(time.Hour))
+
+	request := func(method string, path string, query url.Values) *http.Request {
+		return &http.Request{Method: method, URL: &url.URL{Path: path, RawQuery: query.Encode()}}
+	}
+
+	tests := []struct {
+		name      string
+		request   *http.Request
+		secretKey string
+		now       time.Time
+		expect    bool
+	}{
+		{
+			name:      "valid",
+			request:   request(http.MethodGet, path, query),
+			secretKey: "secret",
+			now:       now,
+			expect:    true,
+		},
+		{
+			name:      "expired",
+			request:   request(http.MethodGet, path, query),
+			secretKey: "secret",
+			now:       now.Add(2 * time.Hour),
+			expect:    false,
+		},
+		{
+			name:      "different path",
+			request:   request(http.MethodGet, "/v1/agent-packages/v1.14.0/observiq-otel-collector-v1.14.0-linux-arm64.tar.gz", query),
+			secretKey: "secret",
+			now:       now,
+			expect:    false,
+		},
+		{
+			name:      "different secret key",
+			request:   request(http.MethodGet, path, query),
+			secretKey: "other",
+			now:       now,
+			expect:    false,
+		},
+		{
+			name:      "not a GET",
+			request:   request(http.MethodPut, path, query),
+			secretKey: "secret",
+			now:       now,
+			expect:    false,
+		},
+		{
+			name:      "no signature",
+			request:   request(http.MethodGet, path, url.Values{}),
+			secretKey: "secret",
+			now:       now,
+			expect:    false,
+		},
+		{
+			name:      "no secret key",
+			request:   request(http.MethodGet, path, SignPath("", path, now.Add(time.Hour))),
+			secretKey: "",
+			now:       now,
+			expect:    false,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, validSignature(test.request, test.secretKey, test.now))
+		})
+	}
+}
+
+func TestCheckSignature(t *testing.T) {
+	config := &common.Server{SecretKey: "agent-secret", SessionsSecret: "sessions-secret"}
+	bindplane := mocks.NewBindPlane(t)
+	bindplane.On("Config").Return(config)
+
+	gin.SetMode(gin.TestMode)
+	router := gin.New()
+	v1 := router.Group("/v1", CheckSignature(bindplane), RequireLogin())
+	v1.GET("/agent-packages/:version/:name", func(c *gin.Context) { c.Status(http.StatusOK) })
+	v1.GET("/secrets", func(c *gin.Context) { c.Status(http.StatusOK) })
+
+	get := func(path string, query url.Values) int {
+		recorder := httptest.NewRecorder()
+		request := httptest.NewRequest(http.MethodGet, path+"?"+query.Encode(), nil)
+		router.ServeHTTP(recorder, request)
+		return recorder.Code
+	}
+
+	expires := time.Now().Add(time.Hour)
+	packagePath := "/v1/agent-packages/v1.14.0/observiq-otel-collector-v1.14.0-linux-amd64.tar.gz"
+
+	t.Run("signed package download is accepted", func(t *testing.T) {
+		require.Equal(t, http.StatusOK, get(packagePath, SignPath(SigningKey(config), packagePath, expires)))
+	})
+
+	t.Run("signed path of another route is rejected", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, get("/v1/secrets", SignPath(SigningKey(config), "/v1/secrets", expires)))
+	})
+
+	t.Run("path signed with the agent secret key is rejected", func(t *testing.T) {
+		require.Equal(t, http.StatusUnauthorized, get(packagePath, SignPath(config.SecretKey, packagePath, expires)))
+		require.Equal(t, http.StatusUnauthorized, get("/v1/secrets", SignPath(config.SecretKey, "/v1/secrets", expires)))
+	})
+}
@@ -32,6 +32,8 @@ type BindPlane interface {
 	Relayers() *Relayers
 	// Versions TODO(doc)
 	Versions() agent.Versions
+	// Packages caches agent packages so that agents can be upgraded from the server
+	Packages() agent.Packages
 	// Config TODO(doc)
 	Config() *common.Server
 	// Logger TODO(doc)
@@ -53,6 +55,11 @@ func NewBindPlane(config *common.Server, logger *zap.Logger, s store.Store, vers
 			manager:  manager,
 			relayers: NewRelayers(logger),
 			versions: versions,
+			packages: agent.NewPackages(agent.PackagesSettings{
+				Logger:    logger.Named("packages"),
+				Directory: config.DownloadsPath(),
+				Offline:   config.Offline,
+			}),
 		},
 	}, nil
 }
@@ -63,6 +70,7 @@ type bindplane struct {
 	manager  Manager
 	logger   *zap.Logger
 	versions agent.Versions
+	packages agent.Packages
 	relayers *Relayers
 }
 
@@ -80,6 +88,11 @@ func (s *bindplane) Logger() *zap.Logger {
 	return s.logger
 }
 
+// Packages returns the agent packages cached by the server
+func (s *bindplane) Packages() agent.Packages {
+	return s.packages
+}
+
 // Config TODO(doc)
 func (s *bindplane) Config() *common.Server {
 	return s.config
@@ -16,13 +16,18 @@ package server
 
 import (
 	"context"
+	"crypto/subtle"
+	"crypto/x509"
+	"fmt"
 	"math"
 	"sync"
 	"time"
 
+	"github.com/google/uuid"
 	"go.opentelemetry.io/otel"
 	"go.opentelemetry.io/otel/attribute"
 	"go.uber.org/zap"
+	"golang.org/x/exp/slices"
 
 	"github.com/observiq/bindplane-op/common"
 	"github.com/observiq/bindplane-op/internal/agent"
@@ -35,12 +40,30 @@ import (
 var tracer = otel.Tracer("bindplane/manager")
 
 const (
-	// AgentCleanupInterval is the default agent cleanup interval.
+	// AgentCleanupInterval is the interval at which agents disconnected for longer than the AgentCleanupTTL setting are
+	// removed or marked stale.
 	AgentCleanupInterval = time.Minute
-	// AgentCleanupTTL is the default agent cleanup time to live.
-	AgentCleanupTTL = 15 * time.Minute
-	// AgentHeartbeatInterval is the default interval for the heartbeat sent to the agent to keep the websocket live.
-	AgentHeartbeatInterval = 30 * time.Second
+	// AgentCleanupLease is the name of the lease held by the server that cleans up disconnected agents.
+	AgentCleanupLease = "agent-cleanup"
+	// AgentCleanupLeaseTTL is how long the agent cleanup lease is held without being renewed. Another server will take
+	// over cleanup if the server holding the lease stops.
+	AgentCleanupLeaseTTL = 3 * AgentCleanupInterval
+
+	// AuditCleanupInterval is the interval at which entries beyond the AuditRetention and AuditMaxEntries settings are
+	// removed from the audit log.
+	AuditCleanupInterval = time.Minute
+	// AuditCleanupLease is the name of the lease held by the server that cleans up the audit log.
+	AuditCleanupLease = "audit-cleanup"
+	// AuditCleanupLeaseTTL is how long the audit cleanup lease is held without being renewed.
+	AuditCleanupLeaseTTL = 3 * AuditCleanupInterval
+
+	// AgentHistoryCleanupInterval is the interval at which status changes beyond the AgentHistoryRetention and
+	// AgentHistoryMaxChanges settings are removed from the history of agents.
+	AgentHistoryCleanupInterval = time.Minute
+	// AgentHistoryCleanupLease is the name of the lease held by the server that cleans up the history of agents.
+	AgentHistoryCleanupLease = "agent-history-cleanup"
+	// AgentHistoryCleanupLeaseTTL is how long the agent history cleanup lease is held without being renewed.
+	AgentHistoryCleanupLeaseTTL = 3 * AgentHistoryCleanupInterval
 )
 
 // Manager manages agent connects and communications with them
@@ -57,6 +80,13 @@ type Manager interface {
 	AgentUpdates(ctx context.Context, agent *model.Agent) (*AgentUpdates, error)
 	// VerifySecretKey checks to see if the specified secretKey matches configured secretKey
 	VerifySecretKey(ctx context.Context, secretKey string) bool
+	// AuthenticateAgent checks to see if the agent with the specified agentID can connect using the specified secretKey,
+	// issuing credentials to agents that have not yet received them.
+	AuthenticateAgent(ctx context.Context, agentID string, secretKey string) (bool, error)
+	// VerifyAgentCertificate checks to see if the client certificate of the agent with the specified agentID identifies
+	// the agent and records the fingerprint of the certificate on the agent. The certificate is nil if the agent did not
+	// present one.
+	VerifyAgentCertificate(ctx context.Context, agentID string, certificate *x509.Certificate) (bool, error)
 	// ResourceStore provides access to the store to render configurations
 	ResourceStore() model.ResourceStore
 	// BindPlaneConfiguration provides access to the config to render configurations
@@ -65,19 +95,42 @@ type Manager interface {
 	RequestReport(ctx context.Context, agentID string, configuration report.Configuration) error
 	// AgentVersion returns information about a version of an agent
 	AgentVersion(ctx context.Context, version string) (*model.AgentVersion, error)
+	// PauseRollout pauses the rollout of the configuration with the specified name
+	PauseRollout(ctx context.Context, name string) (*model.Rollout, error)
+	// ResumeRollout resumes the paused rollout of the configuration with the specified name
+	ResumeRollout(ctx context.Context, name string) (*model.Rollout, error)
+	// AbortRollout stops the rollout of the configuration with the specified name and restores the previous revision
+	AbortRollout(ctx context.Context, name string) (*model.Rollout, error)
+	// CreateUpgradeCampaign starts upgrading the agents that match the options in batches
+	CreateUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign, options ...store.QueryOption) (*model.UpgradeCampaign, error)
+	// PauseUpgradeCampaign pauses the upgrade campaign with the specified name
+	PauseUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error)
+	// ResumeUpgradeCampaign resumes the paused upgrade campaign with the specified name
+	ResumeUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error)
 }
 
 // ----------------------------------------------------------------------
 
 type manager struct {
-	// agentCleanupTicker   *time.Ticker
-	// agentHeartbeatTicker *time.Ticker
 	config    *common.Server
 	store     store.Store
 	versions  agent.Versions
 	logger    *zap.Logger
 	protocols []Protocol
 	secretKey string
+
+	// nodeID identifies this server when acquiring leases shared with other servers
+	nodeID string
+
+	// rolloutMtx serializes changes to rollouts made by this server. Servers sharing a store use the rollout lease.
+	rolloutMtx sync.Mutex
+
+	// rolloutWaves is the wave of each rollout most recently sent to the agents connected to this server by
+	// configuration name. It is guarded by the rolloutMtx.
+	rolloutWaves map[string]rolloutWave
+
+	// campaignMtx serializes changes to upgrade campaigns
+	campaignMtx sync.Mutex
 }
 
 var _ Manager = (*manager)(nil)
@@ -85,14 +138,13 @@ var _ Manager = (*manager)(nil)
 // NewManager returns a new implementation of the Manager interface
 func NewManager(config *common.Server, store store.Store, versions agent.Versions, logger *zap.Logger) (Manager, error) {
 	return &manager{
-		// agentCleanupTicker:   time.NewTicker(AgentCleanupInterval),
-		// agentHeartbeatTicker: time.NewTicker(AgentHeartbeatInterval),
 		config:    config,
 		store:     store,
 		versions:  versions,
 		logger:    logger,
 		protocols: []Protocol{},
 		secretKey: config.SecretKey,
+		nodeID:    uuid.NewString(),
 	}, nil
 }
 
@@ -105,13 +157,58 @@ func (m *manager) Start(ctx context.Context) {
 	updatesChannel, unsubscribe := eventbus.Subscribe(m.store.Updates(), eventbus.WithChannel(make(chan *store.Updates, 10_000)))
 	defer unsubscribe()
 
+	rolloutTicker := time.NewTicker(RolloutCheckInterval)
+	defer rolloutTicker.Stop()
+
+	campaignTicker := time.NewTicker(UpgradeCampaignCheckInterval)
+	defer campaignTicker.Stop()
+
+	// heartbeats and cleanup are disabled by leaving their channels nil
+	var heartbeat, cleanup, auditCleanup, historyCleanup <-chan time.Time
+	if m.config.AgentHeartbeatInterval > 0 {
+		heartbeatTicker := time.NewTicker(m.config.AgentHeartbeatInterval)
+		defer heartbeatTicker.Stop()
+		heartbeat = heartbeatTicker.C
+	}
+	if m.config.AgentCleanupTTL > 0 {
+		cleanupTicker := time.NewTicker(AgentCleanupInterval)
+		defer cleanupTicker.Stop()
+		cleanup = cleanupTicker.C
+	}
+	if m.config.AuditRetention > 0 || m.config.AuditMaxEntries > 0 {
+		auditCleanupTicker := time.NewTicker(AuditCleanupInterval)
+		defer auditCleanupTicker.Stop()
+		auditCleanup = auditCleanupTicker.C
+	}
+	if m.config.AgentHistoryRetention > 0 || m.config.AgentHistoryMaxChanges > 0 {
+		historyCleanupTicker := time.NewTicker(AgentHistoryCleanupInterval)
+		defer historyCleanupTicker.Stop()
+		historyCleanup = historyCleanupTicker.C
+	}
+
 	for {
 		select {
 		case <-ctx.Done():
-			// m.agentCleanupTicker.Stop()
-			// m.agentHeartbeatTicker.Stop()
 			return
 
+		case <-heartbeat:
+			m.handleAgentHeartbeat(ctx)
+
+		case <-cleanup:
+			m.handleAgentCleanup(ctx)
+
+		case <-auditCleanup:
+			m.handleAuditCleanup(ctx)
+
+		case <-historyCleanup:
+			m.handleAgentHistoryCleanup(ctx)
+
+		case <-rolloutTicker.C:
+			m.handleRollouts(ctx)
+
+		case <-campaignTicker.C:
+			m.handleUpgradeCampaigns(ctx)
+
 		case updates := <-updatesChannel:
 			m.logger.Info("Received configuration updates",
 				zap.Int("size", updates.Size()),
@@ -119,13 +216,6 @@ func (m *manager) Start(ctx context.Context) {
 				zap.Int("Configurations", len(updates.Configurations)),
 			)
 			m.handleUpdates(ctx, updates)
-
-			// TODO: determine if these need to be replaced and if so, replace them
-			// case <-m.agentCleanupTicker.C:
-			// 	m.handleAgentCleanup()
-
-			// case <-m.agentHeartbeatTicker.C:
-			// 	m.handleAgentHeartbeat()
 		}
 	}
 }
@@ -219,6 +309,18 @@ func (m *manager) handleUpdates(ctx context.Context, updates *store.Updates) {
 			continue
 		}
 		agent := change.Item
+
+		// disconnect agents whose credentials were revoked
+		if agent.Credentials.Revoked() && m.disconnect(agent.ID) {
+			m.logger.Info("disconnected agent with revoked credentials", zap.String("agentID", agent.ID))
+			continue
+		}
+
+		// restart the agent if a restart was requested
+		if agent.Restart != nil && agent.Restart.Status == model.RestartPending && m.connected(agent.ID) {
+			m.restartAgent(ctx, agent)
+		}
+
 		// otherwise, we only care able label changes
 		if change.Type != store.EventTypeLabel {
 			// unless there is a pending version update
@@ -243,7 +345,7 @@ func (m *manager) handleUpdates(ctx context.Context, updates *store.Updates) {
 		}
 
 		// if the labels changed, there may be new configuration
-		if configuration, err := m.store.AgentConfiguration(ctx, agent.ID); err != nil {
+		if configuration, err := m.agentConfiguration(ctx, agent.ID); err != nil {
 			m.logger.Error("unable to find new agent configuration", zap.String("agentID", agent.ID), zap.String("labels", agent.Labels.String()))
 		} else {
 			if configuration != nil {
@@ -261,6 +363,20 @@ func (m *manager) handleUpdates(ctx context.Context, updates *store.Updates) {
 			continue
 		}
 
+		// agents that have not been updated by an active rollout continue to use the previous revision
+		var rollout *model.Rollout
+		previous := configuration
+		if event.Type != store.EventTypeRemove {
+			rollout, err = m.startRollout(ctx, configuration)
+			if err == nil && rollout != nil && rollout.Active() {
+				previous, err = m.previousConfiguration(ctx, rollout)
+			}
+			if err != nil {
+				m.logger.Error("unable to roll out configuration to agents", zap.String("configuration.name", configuration.Name()), zap.Error(err))
+				continue
+			}
+		}
+
 		for _, agentID := range agentIDs {
 			// only consider connected agents
 			if !m.connected(agentID) {
@@ -280,6 +396,8 @@ func (m *manager) handleUpdates(ctx context.Context, updates *store.Updates) {
 				// TODO(andy): we need a default configuration
 				// https://github.com/observIQ/bindplane/issues/279
 				// agentUpdates.Configuration = otel.EmptyConfig()
+			} else if rollout != nil && rollout.Active() && !rollout.Includes(agent.ID) {
+				pending.agent(agent).updates.Configuration = previous
 			} else {
 				m.logger.Info("updating configuration for agent", zap.String("agentID", agent.ID))
 				pending.agent(agent).updates.Configuration = configuration
@@ -301,7 +419,7 @@ func (m *manager) UpsertAgent(ctx context.Context, agentID string, updater store
 
 // AgentUpdates returns the updates that should be applied to an agent based on the current bindplane configuration
 func (m *manager) AgentUpdates(ctx context.Context, agent *model.Agent) (*AgentUpdates, error) {
-	newConfiguration, err := m.store.AgentConfiguration(ctx, agent.ID)
+	newConfiguration, err := m.agentConfiguration(ctx, agent.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -312,10 +430,172 @@ func (m *manager) AgentUpdates(ctx context.Context, agent *model.Agent) (*AgentU
 	}, nil
 }
 
+// agentConfiguration returns the configuration that should be applied to an agent, which is the previous revision of
+// the configuration if the agent has not been updated by an active rollout
+func (m *manager) agentConfiguration(ctx context.Context, agentID string) (*model.Configuration, error) {
+	configuration, err := m.store.AgentConfiguration(ctx, agentID)
+	if err != nil {
+		return nil, err
+	}
+	return m.rolloutConfiguration(ctx, agentID, configuration)
+}
+
 // VerifySecretKey checks to see if the specified secretKey matches configured secretKey. If the BindPlane server does not
 // have a configured secretKey, this returns true.
 func (m *manager) VerifySecretKey(ctx context.Context, secretKey string) bool {
-	return m.secretKey == "" || m.secretKey == secretKey
+	return m.secretKey == "" || subtle.ConstantTimeCompare([]byte(m.secretKey), []byte(secretKey)) == 1
+}
+
+// verifyEnrollmentSecretKey checks to see if an agent without its own credentials can connect using the configured
+// secretKey, which is not accepted when DisableSecretKeyEnrollment is enabled
+func (m *manager) verifyEnrollmentSecretKey(ctx context.Context, secretKey string) bool {
+	if m.config != nil && m.config.DisableSecretKeyEnrollment {
+		return false
+	}
+	return m.VerifySecretKey(ctx, secretKey)
+}
+
+// AuthenticateAgent checks to see if the agent with the specified agentID can connect using the specified secretKey.
+// Agents with credentials must present the secret key issued to them, unless they have not yet reported using it in
+// which case they can continue to present the key they used to enroll. Agents whose credentials were revoked cannot
+// connect, even after they are deleted. Other agents can present the token of an available EnrollmentToken or, unless
+// DisableSecretKeyEnrollment is enabled, the configured secretKey and are issued credentials when they are accepted.
+func (m *manager) AuthenticateAgent(ctx context.Context, agentID string, secretKey string) (bool, error) {
+	ctx, span := tracer.Start(ctx, "manager/AuthenticateAgent")
+	defer span.End()
+
+	if agentID == "" {
+		// credentials can only be issued to agents that identify themselves
+		return m.verifyEnrollmentSecretKey(ctx, secretKey), nil
+	}
+
+	agent, err := m.store.Agent(ctx, agentID)
+	if err != nil {
+		return false, err
+	}
+	if agent != nil && agent.Credentials != nil {
+		switch {
+		case agent.Credentials.Revoked():
+			return false, nil
+		case agent.Credentials.Verify(secretKey):
+			return true, nil
+		case !agent.Credentials.Pending():
+			return false, nil
+		case agent.Credentials.EnrollmentToken != "":
+			token, err := m.store.EnrollmentToken(ctx, agent.Credentials.EnrollmentToken)
+			if err != nil {
+				return false, err
+			}
+			return token != nil && subtle.ConstantTimeCompare([]byte(token.Token()), []byte(secretKey)) == 1 &&
+				!token.Expired(time.Now()), nil
+		default:
+			return m.verifyEnrollmentSecretKey(ctx, secretKey), nil
+		}
+	}
+
+	revoked, err := m.store.AgentRevoked(ctx, agentID)
+	if err != nil {
+		return false, err
+	}
+	if revoked {
+		m.logger.Info("rejecting agent enrollment, the credentials of the agent were revoked", zap.String("agentID", agentID))
+		return false, nil
+	}
+
+	token, err := store.FindEnrollmentToken(ctx, m.store, secretKey)
+	if err != nil {
+		return false, err
+	}
+	if token != nil {
+		now := time.Now()
+		if err := token.Available(now, 0); err != nil {
+			m.logger.Info("rejecting agent enrollment", zap.String("agentID", agentID), zap.Error(err))
+			return false, nil
+		}
+		// the use is counted by the store so that servers sharing it cannot exceed MaxUses
+		used, err := m.store.UseEnrollmentToken(ctx, token.Token(), token.Spec.MaxUses)
+		if err != nil {
+			return false, err
+		}
+		if !used {
+			m.logger.Info("rejecting agent enrollment", zap.String("agentID", agentID), zap.Error(token.Available(now, token.Spec.MaxUses)))
+			return false, nil
+		}
+	} else if !m.verifyEnrollmentSecretKey(ctx, secretKey) {
+		return false, nil
+	}
+
+	var issueErr error
+	_, err = m.store.UpsertAgent(ctx, agentID, func(current *model.Agent) {
+		issueErr = current.IssueCredentials(token)
+	})
+	if err == nil {
+		err = issueErr
+	}
+	if err != nil {
+		return false, fmt.Errorf("unable to issue credentials to agent [%s]: %w", agentID, err)
+	}
+	return true, nil
+}
+
+// VerifyAgentCertificate checks to see if the client certificate of the agent identifies the agent when
+// VerifyAgentCertificates is enabled. The subject common name or one of the subject alternative names of the certificate
+// must match the value of the AgentCertificateLabel of the agent, or the agent ID if the agent doesn't have that label.
+// Rejected certificates are recorded in the audit log. The fingerprint of accepted certificates is recorded on the
+// agent.
+func (m *manager) VerifyAgentCertificate(ctx context.Context, agentID string, certificate *x509.Certificate) (bool, error) {
+	ctx, span := tracer.Start(ctx, "manager/VerifyAgentCertificate")
+	defer span.End()
+
+	if agentID == "" || (certificate == nil && !m.config.VerifyAgentCertificates) {
+		return !m.config.VerifyAgentCertificates, nil
+	}
+
+	agent, err := m.store.Agent(ctx, agentID)
+	if err != nil {
+		return false, err
+	}
+
+	if m.config.VerifyAgentCertificates {
+		identity := agentID
+		if label := m.config.AgentCertificateLabel; label != "" && agent != nil && agent.Labels.Has(label) {
+			identity = agent.Labels.Get(label)
+		}
+		if certificate == nil || !slices.Contains(model.CertificateIdentities(certificate), identity) {
+			m.rejectAgentCertificate(ctx, agentID, identity, certificate)
+			return false, nil
+		}
+	}
+
+	fingerprint := model.CertificateFingerprint(certificate)
+	if agent == nil || agent.CertificateFingerprint != fingerprint {
+		_, err = m.store.UpsertAgent(ctx, agentID, func(current *model.Agent) {
+			current.CertificateFingerprint = fingerprint
+		})
+		if err != nil {
+			return false, fmt.Errorf("unable to record the certificate fingerprint of agent [%s]: %w", agentID, err)
+		}
+	}
+	return true, nil
+}
+
+// rejectAgentCertificate records the rejected certificate in the audit log
+func (m *manager) rejectAgentCertificate(ctx context.Context, agentID string, identity string, certificate *x509.Certificate) {
+	m.logger.Info("rejecting agent certificate", zap.String("agentID", agentID), zap.String("identity", identity))
+
+	rejected := map[string]any{"identity": identity}
+	if certificate != nil {
+		rejected["certificateFingerprint"] = model.CertificateFingerprint(certificate)
+		rejected["certificateIdentities"] = model.CertificateIdentities(certificate)
+	}
+	entry, err := model.NewAuditEntry("", model.AuditActionReject, model.KindAgent, agentID, nil, rejected)
+	if err != nil {
+		m.logger.Error("failed to create an audit entry", zap.String("agentID", agentID), zap.Error(err))
+		return
+	}
+	if err := m.store.AddAuditEntries(ctx, []*model.AuditEntry{entry}); err != nil {
+		m.logger.Error("failed to store audit entries", zap.Error(err))
+	}
 }
 
 // ResourceStore provides access to the store to render configurations
@@ -355,22 +635,108 @@ func (m *manager) AgentVersion(ctx context.Context, version string) (*model.Agen
 
 // ----------------------------------------------------------------------
 
-// handleAgentCleanup removes disconnected agents from the store.
-func (m *manager) handleAgentCleanup() {
-	ctx, cancel := context.WithCancel(context.Background())
-	defer cancel()
-
-	_, span := tracer.Start(ctx, "manager/handleAgentCleanup")
+// handleAgentCleanup removes agents that have been disconnected for longer than the AgentCleanupTTL from the store or
+// marks them stale if MarkStaleAgents is enabled. With multiple servers, only the server holding the agent cleanup lease
+// cleans up agents.
+func (m *manager) handleAgentCleanup(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "manager/handleAgentCleanup")
 	defer span.End()
 
-	now := time.Now()
-	// TODO: in a cluster, move this to a job
-	err := m.store.CleanupDisconnectedAgents(ctx, now.Add(-AgentCleanupTTL))
+	acquired, err := m.store.AcquireLease(ctx, AgentCleanupLease, m.nodeID, AgentCleanupLeaseTTL)
+	if err != nil {
+		m.logger.Error("unable to acquire the agent cleanup lease", zap.Error(err))
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	since := time.Now().Add(-m.config.AgentCleanupTTL)
+	if m.config.MarkStaleAgents {
+		err = m.markStaleAgents(ctx, since)
+	} else {
+		err = m.store.CleanupDisconnectedAgents(ctx, since)
+	}
 	if err != nil {
 		m.logger.Error("error cleaning up disconnected agents", zap.Error(err))
 	}
 }
 
+// handleAuditCleanup removes entries older than the AuditRetention and the oldest entries beyond AuditMaxEntries from
+// the audit log. With multiple servers, only the server holding the audit cleanup lease cleans up the audit log.
+func (m *manager) handleAuditCleanup(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "manager/handleAuditCleanup")
+	defer span.End()
+
+	acquired, err := m.store.AcquireLease(ctx, AuditCleanupLease, m.nodeID, AuditCleanupLeaseTTL)
+	if err != nil {
+		m.logger.Error("unable to acquire the audit cleanup lease", zap.Error(err))
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	var before time.Time
+	if m.config.AuditRetention > 0 {
+		before = time.Now().Add(-m.config.AuditRetention)
+	}
+	if err := m.store.CleanupAuditEntries(ctx, before, m.config.AuditMaxEntries); err != nil {
+		m.logger.Error("error cleaning up the audit log", zap.Error(err))
+	}
+}
+
+// handleAgentHistoryCleanup removes status changes older than the AgentHistoryRetention and the oldest changes beyond
+// AgentHistoryMaxChanges from the history of each agent. With multiple servers, only the server holding the agent
+// history cleanup lease cleans up the history.
+func (m *manager) handleAgentHistoryCleanup(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "manager/handleAgentHistoryCleanup")
+	defer span.End()
+
+	acquired, err := m.store.AcquireLease(ctx, AgentHistoryCleanupLease, m.nodeID, AgentHistoryCleanupLeaseTTL)
+	if err != nil {
+		m.logger.Error("unable to acquire the agent history cleanup lease", zap.Error(err))
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	var before time.Time
+	if m.config.AgentHistoryRetention > 0 {
+		before = time.Now().Add(-m.config.AgentHistoryRetention)
+	}
+	if err := m.store.CleanupAgentHistory(ctx, before, m.config.AgentHistoryMaxChanges); err != nil {
+		m.logger.Error("error cleaning up the agent history", zap.Error(err))
+	}
+}
+
+// markStaleAgents sets the status of agents that have been disconnected since the specified time to Stale
+func (m *manager) markStaleAgents(ctx context.Context, since time.Time) error {
+	agents, err := m.store.Agents(ctx)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, agent := range agents {
+		if agent.Status != model.Stale && agent.DisconnectedSince(since) {
+			ids = append(ids, agent.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = m.store.UpsertAgents(ctx, ids, func(current *model.Agent) {
+		// the agent may have reconnected since it was listed
+		if current.DisconnectedSince(since) {
+			current.Status = model.Stale
+		}
+	})
+	return err
+}
+
 func (m *manager) handleAgentHeartbeat(ctx context.Context) {
 	ctx, span := tracer.Start(ctx, "manager/handleAgentHeartbeat")
 	defer span.End()
@@ -378,13 +744,13 @@ func (m *manager) handleAgentHeartbeat(ctx context.Context) {
 	for _, p := range m.protocols {
 		ids, err := p.ConnectedAgentIDs(ctx)
 		if err != nil {
-			m.logger.Error("unable to get connected agents", zap.String("protocol", p.Name()))
+			m.logger.Error("unable to get connected agents", zap.String("protocol", p.Name()), zap.Error(err))
 			continue
 		}
 		for _, id := range ids {
 			err = p.SendHeartbeat(id)
 			if err != nil {
-				m.logger.Error("unable to get send agent heartbeat", zap.String("protocol", p.Name()), zap.String("agentID", id))
+				m.logger.Error("unable to send agent heartbeat", zap.String("protocol", p.Name()), zap.String("agentID", id), zap.Error(err))
 				continue
 			}
 		}
@@ -412,6 +778,19 @@ func (m *manager) connected(agentID string) bool {
 	return false
 }
 
+// agentConnected returns true if the stored status of the agent shows that it is connected to any server. Unlike
+// connected, it includes agents connected to other servers sharing the store.
+func agentConnected(agent *model.Agent) bool {
+	if agent == nil {
+		return false
+	}
+	switch agent.Status {
+	case model.Disconnected, model.Stale, model.Deleted:
+		return false
+	}
+	return true
+}
+
 // connectedAgentIDs returns the list of agents connected using any protocol
 func (m *manager) connectedAgentIDs(ctx context.Context) []string {
 	ids := []string{}
@@ -426,6 +805,15 @@ func (m *manager) connectedAgentIDs(ctx context.Context) []string {
 	return ids
 }
 
+func (m *manager) restartAgent(ctx context.Context, agent *model.Agent) {
+	for _, p := range m.protocols {
+		err := p.RestartAgent(ctx, agent)
+		if err != nil {
+			m.logger.Error("unable to restart agent", zap.String("agentID", agent.ID), zap.Error(err))
+		}
+	}
+}
+
 func (m *manager) updateAgent(ctx context.Context, agent *model.Agent, updates *AgentUpdates) {
 	for _, p := range m.protocols {
 		err := p.UpdateAgent(ctx, agent, updates)
@@ -16,8 +16,15 @@ package server
 
 import (
 	"context"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"fmt"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
 
+	"github.com/observiq/bindplane-op/common"
 	"github.com/observiq/bindplane-op/internal/server/report"
 	"github.com/observiq/bindplane-op/internal/store"
 	"github.com/observiq/bindplane-op/model"
@@ -166,6 +173,53 @@ func TestHandleUpdatesAgentLabels(t *testing.T) {
 	testProtocol.AssertExpectations(t)
 }
 
+func TestHandleUpdatesAgentRestart(t *testing.T) {
+	managerTestReset()
+	testAgentA, err := testMapstore.UpsertAgent(context.TODO(), "A", func(agent *model.Agent) {
+		agent.Status = model.Connected
+		agent.RequestRestart()
+	})
+	require.NoError(t, err)
+	testAgentB, err := testMapstore.UpsertAgent(context.TODO(), "B", func(agent *model.Agent) {
+		agent.Status = model.Connected
+		agent.RequestRestart()
+		agent.RestartStarted()
+	})
+	require.NoError(t, err)
+
+	updates := store.NewUpdates()
+	updates.IncludeAgent(testAgentA, store.EventTypeUpdate)
+	updates.IncludeAgent(testAgentB, store.EventTypeUpdate)
+
+	// only the agent with a pending restart is restarted
+	testProtocol.
+		On("Connected", testAgentA.ID).Return(true).
+		On("RestartAgent", mock.Anything, testAgentA).Return(nil)
+
+	testManager.handleUpdates(context.TODO(), updates)
+
+	testProtocol.AssertExpectations(t)
+}
+
+func TestHandleUpdatesAgentRevoked(t *testing.T) {
+	managerTestReset()
+	testAgentA, err := testMapstore.UpsertAgent(context.TODO(), "A", func(agent *model.Agent) {
+		require.NoError(t, agent.IssueCredentials(nil))
+		agent.RevokeCredentials()
+	})
+	require.NoError(t, err)
+
+	updates := store.NewUpdates()
+	updates.IncludeAgent(testAgentA, store.EventTypeUpdate)
+
+	testProtocol.
+		On("Disconnect", testAgentA.ID).Return(true)
+
+	testManager.handleUpdates(context.TODO(), updates)
+
+	testProtocol.AssertExpectations(t)
+}
+
 func TestHandleUpdatesNewConfiguration(t *testing.T) {
 	managerTestReset()
 	testAgentA := makeTestAgentWithLabels("A", "configuration=test")
@@ -286,6 +340,417 @@ func TestManagerVerifySecretKey(t *testing.T) {
 	}
 }
 
+func TestManagerAuthenticateAgent(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, secretKey string) *manager {
+		s := store.NewMapStore(ctx, store.Options{
+			SessionsSecret:   "super-secret-key",
+			MaxEventsToMerge: 1,
+		}, logger)
+		expired := model.NewEnrollmentToken("expired", model.EnrollmentTokenSpec{ExpiresAt: "2020-01-01T00:00:00Z"})
+		limited := model.NewEnrollmentToken("limited", model.EnrollmentTokenSpec{MaxUses: 1})
+		production := model.NewEnrollmentToken("production", model.EnrollmentTokenSpec{Labels: map[string]string{"env": "production"}})
+		_, err := s.ApplyResources(ctx, []model.Resource{expired, limited, production})
+		require.NoError(t, err)
+		return &manager{
+			store:     s,
+			logger:    logger,
+			secretKey: secretKey,
+		}
+	}
+
+	token := func(t *testing.T, m *manager, name string) string {
+		token, err := m.store.EnrollmentToken(ctx, name)
+		require.NoError(t, err)
+		require.NotNil(t, token)
+		return token.Token()
+	}
+
+	authenticate := func(t *testing.T, m *manager, agentID, secretKey string) bool {
+		ok, err := m.AuthenticateAgent(ctx, agentID, secretKey)
+		require.NoError(t, err)
+		return ok
+	}
+
+	agent := func(t *testing.T, m *manager, agentID string) *model.Agent {
+		agent, err := m.store.Agent(ctx, agentID)
+		require.NoError(t, err)
+		require.NotNil(t, agent)
+		return agent
+	}
+
+	t.Run("secret key issues credentials", func(t *testing.T) {
+		m := setup(t, "secret")
+		require.False(t, authenticate(t, m, "1", "wrong"))
+		require.True(t, authenticate(t, m, "1", "secret"))
+
+		credentials := agent(t, m, "1").Credentials
+		require.NotNil(t, credentials)
+		require.True(t, credentials.Pending())
+		require.Empty(t, credentials.EnrollmentToken)
+
+		// the agent can use either key until it confirms the issued key
+		require.True(t, authenticate(t, m, "1", "secret"))
+		require.True(t, authenticate(t, m, "1", credentials.PendingSecretKey))
+	})
+
+	t.Run("enrollment token issues credentials with labels", func(t *testing.T) {
+		m := setup(t, "secret")
+		require.True(t, authenticate(t, m, "1", token(t, m, "production")))
+
+		a := agent(t, m, "1")
+		require.NotNil(t, a.Credentials)
+		require.Equal(t, "production", a.Credentials.EnrollmentToken)
+		require.Equal(t, "production", a.Labels.Set["env"])
+	})
+
+	t.Run("expired enrollment token", func(t *testing.T) {
+		m := setup(t, "secret")
+		require.False(t, authenticate(t, m, "1", token(t, m, "expired")))
+	})
+
+	t.Run("enrollment token max uses", func(t *testing.T) {
+		m := setup(t, "secret")
+		require.True(t, authenticate(t, m, "1", token(t, m, "limited")))
+		require.True(t, authenticate(t, m, "1", token(t, m, "limited")))
+		require.False(t, authenticate(t, m, "2", token(t, m, "limited")))
+	})
+
+	t.Run("enrollment token max uses with concurrent agents", func(t *testing.T) {
+		m := setup(t, "secret")
+		limited := token(t, m, "limited")
+
+		var accepted int32
+		var wg sync.WaitGroup
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(agentID string) {
+				defer wg.Done()
+				ok, err := m.AuthenticateAgent(ctx, agentID, limited)
+				require.NoError(t, err)
+				if ok {
+					atomic.AddInt32(&accepted, 1)
+				}
+			}(fmt.Sprintf("agent-%d", i))
+		}
+		wg.Wait()
+		require.Equal(t, int32(1), accepted)
+	})
+
+	t.Run("confirmed credentials", func(t *testing.T) {
+		m := setup(t, "secret")
+		require.True(t, authenticate(t, m, "1", "secret"))
+		pendingKey := agent(t, m, "1").Credentials.PendingSecretKey
+
+		_, err := m.store.UpsertAgent(ctx, "1", func(current *model.Agent) {
+			require.True(t, current.ConfirmCredentials(pendingKey))
+		})
+		require.NoError(t, err)
+
+		require.False(t, authenticate(t, m, "1", "secret"))
+		require.True(t, authenticate(t, m, "1", pendingKey))
+	})
+
+	t.Run("revoked credentials", func(t *testing.T) {
+		m := setup(t, "secret")
+		require.True(t, authenticate(t, m, "1", "secret"))
+		pendingKey := agent(t, m, "1").Credentials.PendingSecretKey
+
+		_, err := m.store.UpsertAgent(ctx, "1", func(current *model.Agent) {
+			current.RevokeCredentials()
+		})
+		require.NoError(t, err)
+
+		require.False(t, authenticate(t, m, "1", "secret"))
+		require.False(t, authenticate(t, m, "1", pendingKey))
+	})
+
+	t.Run("revoked credentials after the agent is deleted", func(t *testing.T) {
+		m := setup(t, "secret")
+		require.True(t, authenticate(t, m, "1", "secret"))
+
+		require.NoError(t, m.store.RevokeAgent(ctx, "1"))
+		_, err := m.store.DeleteAgents(ctx, []string{"1"})
+		require.NoError(t, err)
+
+		require.False(t, authenticate(t, m, "1", "secret"))
+		require.False(t, authenticate(t, m, "1", token(t, m, "production")))
+		require.True(t, authenticate(t, m, "2", "secret"))
+	})
+
+	t.Run("secret key enrollment disabled", func(t *testing.T) {
+		m := setup(t, "secret")
+		require.True(t, authenticate(t, m, "1", "secret"))
+		m.config = &common.Server{DisableSecretKeyEnrollment: true}
+
+		// pending credentials issued for the secret key are not accepted either
+		require.False(t, authenticate(t, m, "1", "secret"))
+		require.True(t, authenticate(t, m, "1", agent(t, m, "1").Credentials.PendingSecretKey))
+
+		require.False(t, authenticate(t, m, "2", "secret"))
+		require.False(t, authenticate(t, m, "", "secret"))
+		require.True(t, authenticate(t, m, "3", token(t, m, "production")))
+	})
+
+	t.Run("no agent id", func(t *testing.T) {
+		m := setup(t, "secret")
+		require.True(t, authenticate(t, m, "", "secret"))
+		require.False(t, authenticate(t, m, "", token(t, m, "production")))
+	})
+}
+
+func TestManagerVerifyAgentCertificate(t *testing.T) {
+	ctx := context.Background()
+
+	certificate := func(commonName string, dnsNames ...string) *x509.Certificate {
+		return &x509.Certificate{
+			Raw:      []byte(commonName),
+			Subject:  pkix.Name{CommonName: commonName},
+			DNSNames: dnsNames,
+		}
+	}
+
+	setup := func(t *testing.T, config *common.Server) *manager {
+		s := store.NewMapStore(ctx, store.Options{
+			SessionsSecret:   "super-secret-key",
+			MaxEventsToMerge: 1,
+		}, logger)
+		_, err := s.UpsertAgent(ctx, "labeled", func(current *model.Agent) {
+			current.Labels = model.LabelsFromValidatedMap(map[string]string{"host": "web-1"})
+		})
+		require.NoError(t, err)
+		return &manager{
+			config: config,
+			store:  s,
+			logger: logger,
+		}
+	}
+
+	verify := func(t *testing.T, m *manager, agentID string, certificate *x509.Certificate) bool {
+		ok, err := m.VerifyAgentCertificate(ctx, agentID, certificate)
+		require.NoError(t, err)
+		return ok
+	}
+
+	rejected := func(t *testing.T, m *manager) []*model.AuditEntry {
+		entries, err := m.store.AuditEntries(ctx, model.AuditFilter{})
+		require.NoError(t, err)
+		return entries
+	}
+
+	t.Run("disabled records the fingerprint", func(t *testing.T) {
+		m := setup(t, &common.Server{})
+		require.True(t, verify(t, m, "1", nil))
+		require.True(t, verify(t, m, "1", certificate("other")))
+
+		agent, err := m.store.Agent(ctx, "1")
+		require.NoError(t, err)
+		require.Equal(t, model.CertificateFingerprint(certificate("other")), agent.CertificateFingerprint)
+	})
+
+	t.Run("certificate must match the agent id", func(t *testing.T) {
+		m := setup(t, &common.Server{VerifyAgentCertificates: true})
+		require.False(t, verify(t, m, "1", nil))
+		require.False(t, verify(t, m, "1", certificate("2")))
+		require.True(t, verify(t, m, "1", certificate("1")))
+		require.True(t, verify(t, m, "2", certificate("agents", "2")))
+
+		agent, err := m.store.Agent(ctx, "1")
+		require.NoError(t, err)
+		require.Equal(t, model.CertificateFingerprint(certificate("1")), agent.CertificateFingerprint)
+
+		entries := rejected(t, m)
+		require.Len(t, entries, 2)
+		for _, entry := range entries {
+			require.Equal(t, model.AuditActionReject, entry.Action)
+			require.Equal(t, model.KindAgent, entry.Kind)
+			require.Equal(t, "1", entry.Name)
+		}
+	})
+
+	t.Run("certificate must match the label", func(t *testing.T) {
+		m := setup(t, &common.Server{VerifyAgentCertificates: true, AgentCertificateLabel: "host"})
+		require.False(t, verify(t, m, "labeled", certificate("labeled")))
+		require.True(t, verify(t, m, "labeled", certificate("web-1")))
+
+		// agents without the label must match the agent id
+		require.True(t, verify(t, m, "1", certificate("1")))
+	})
+}
+
+func TestHandleAgentCleanup(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, config *common.Server) *manager {
+		s := store.NewMapStore(ctx, store.Options{SessionsSecret: "super-secret-key"}, logger)
+
+		longAgo := time.Now().Add(-time.Hour)
+		recently := time.Now().Add(-time.Minute)
+		for _, agent := range []*model.Agent{
+			{ID: "connected", Status: model.Connected},
+			{ID: "recent", Status: model.Disconnected, DisconnectedAt: &recently},
+			{ID: "old", Status: model.Disconnected, DisconnectedAt: &longAgo},
+		} {
+			a := agent
+			_, err := s.UpsertAgent(ctx, a.ID, func(current *model.Agent) { *current = *a })
+			require.NoError(t, err)
+		}
+
+		return &manager{config: config, store: s, logger: logger, nodeID: "node-1"}
+	}
+
+	getAgent := func(t *testing.T, m *manager, id string) *model.Agent {
+		agent, err := m.store.Agent(ctx, id)
+		require.NoError(t, err)
+		return agent
+	}
+
+	t.Run("removes agents disconnected longer than the ttl", func(t *testing.T) {
+		m := setup(t, &common.Server{AgentCleanupTTL: 15 * time.Minute})
+		m.handleAgentCleanup(ctx)
+
+		require.Nil(t, getAgent(t, m, "old"))
+		require.NotNil(t, getAgent(t, m, "recent"))
+		require.NotNil(t, getAgent(t, m, "connected"))
+	})
+
+	t.Run("marks agents stale", func(t *testing.T) {
+		m := setup(t, &common.Server{AgentCleanupTTL: 15 * time.Minute, MarkStaleAgents: true})
+		m.handleAgentCleanup(ctx)
+
+		require.Equal(t, model.Stale, getAgent(t, m, "old").Status)
+		require.Equal(t, model.Disconnected, getAgent(t, m, "recent").Status)
+		require.Equal(t, model.Connected, getAgent(t, m, "connected").Status)
+	})
+
+	t.Run("only the server with the lease cleans up", func(t *testing.T) {
+		m := setup(t, &common.Server{AgentCleanupTTL: 15 * time.Minute})
+		acquired, err := m.store.AcquireLease(ctx, AgentCleanupLease, "node-2", AgentCleanupLeaseTTL)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		m.handleAgentCleanup(ctx)
+		require.NotNil(t, getAgent(t, m, "old"))
+	})
+}
+
+func TestHandleAuditCleanup(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, config *common.Server) *manager {
+		s := store.NewMapStore(ctx, store.Options{SessionsSecret: "super-secret-key"}, logger)
+
+		var entries []*model.AuditEntry
+		for _, age := range []time.Duration{3 * time.Hour, 2 * time.Hour, time.Minute} {
+			entries = append(entries, &model.AuditEntry{
+				Action:    model.AuditActionUpdate,
+				Kind:      model.KindConfiguration,
+				Name:      age.String(),
+				Timestamp: time.Now().Add(-age),
+			})
+		}
+		require.NoError(t, s.AddAuditEntries(ctx, entries))
+
+		return &manager{config: config, store: s, logger: logger, nodeID: "node-1"}
+	}
+
+	names := func(t *testing.T, m *manager) []string {
+		entries, err := m.store.AuditEntries(ctx, model.AuditFilter{})
+		require.NoError(t, err)
+		var names []string
+		for _, entry := range entries {
+			names = append(names, entry.Name)
+		}
+		return names
+	}
+
+	t.Run("removes entries older than the retention", func(t *testing.T) {
+		m := setup(t, &common.Server{AuditRetention: time.Hour})
+		m.handleAuditCleanup(ctx)
+		require.Equal(t, []string{"1m0s"}, names(t, m))
+	})
+
+	t.Run("removes entries over the maximum", func(t *testing.T) {
+		m := setup(t, &common.Server{AuditMaxEntries: 2})
+		m.handleAuditCleanup(ctx)
+		require.Equal(t, []string{"1m0s", "2h0m0s"}, names(t, m))
+	})
+
+	t.Run("only the server with the lease cleans up", func(t *testing.T) {
+		m := setup(t, &common.Server{AuditRetention: time.Hour})
+		acquired, err := m.store.AcquireLease(ctx, AuditCleanupLease, "node-2", AuditCleanupLeaseTTL)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		m.handleAuditCleanup(ctx)
+		require.Len(t, names(t, m), 3)
+	})
+}
+
+func TestHandleAgentHistoryCleanup(t *testing.T) {
+	ctx := context.Background()
+
+	setup := func(t *testing.T, config *common.Server) *manager {
+		s := store.NewMapStore(ctx, store.Options{SessionsSecret: "super-secret-key"}, logger)
+
+		for _, status := range []model.AgentStatus{model.Connected, model.Disconnected, model.Connected} {
+			_, err := s.UpsertAgent(ctx, "1", func(current *model.Agent) {
+				current.Status = status
+			})
+			require.NoError(t, err)
+		}
+
+		return &manager{config: config, store: s, logger: logger, nodeID: "node-1"}
+	}
+
+	statuses := func(t *testing.T, m *manager) []model.AgentStatus {
+		changes, err := m.store.AgentHistory(ctx, "1")
+		require.NoError(t, err)
+		var statuses []model.AgentStatus
+		for _, change := range changes {
+			statuses = append(statuses, change.Status)
+		}
+		return statuses
+	}
+
+	t.Run("removes changes older than the retention except the most recent", func(t *testing.T) {
+		m := setup(t, &common.Server{AgentHistoryRetention: time.Nanosecond})
+		time.Sleep(time.Millisecond)
+		m.handleAgentHistoryCleanup(ctx)
+		require.Equal(t, []model.AgentStatus{model.Connected}, statuses(t, m))
+	})
+
+	t.Run("removes changes over the maximum", func(t *testing.T) {
+		m := setup(t, &common.Server{AgentHistoryMaxChanges: 2})
+		m.handleAgentHistoryCleanup(ctx)
+		require.Equal(t, []model.AgentStatus{model.Disconnected, model.Connected}, statuses(t, m))
+	})
+
+	t.Run("only the server with the lease cleans up", func(t *testing.T) {
+		m := setup(t, &common.Server{AgentHistoryMaxChanges: 1})
+		acquired, err := m.store.AcquireLease(ctx, AgentHistoryCleanupLease, "node-2", AgentHistoryCleanupLeaseTTL)
+		require.NoError(t, err)
+		require.True(t, acquired)
+
+		m.handleAgentHistoryCleanup(ctx)
+		require.Len(t, statuses(t, m), 3)
+	})
+}
+
+func TestHandleAgentHeartbeat(t *testing.T) {
+	protocol := &mockProtocol{}
+	protocol.
+		On("ConnectedAgentIDs", mock.Anything).Return([]string{"1", "2"}, nil).
+		On("SendHeartbeat", "1").Return(nil).
+		On("SendHeartbeat", "2").Return(nil)
+
+	m := &manager{store: testMapstore, logger: logger, protocols: []Protocol{protocol}}
+	m.handleAgentHeartbeat(context.Background())
+
+	protocol.AssertExpectations(t)
+}
+
 // -------------------------
 // Protocol is an autogenerated mock type for the Protocol type
 type mockProtocol struct {
@@ -306,6 +771,20 @@ func (_m *mockProtocol) RequestReport(ctx context.Context, agentID string, confi
 	return r0
 }
 
+// RestartAgent provides a mock function with given fields: ctx, agent
+func (_m *mockProtocol) RestartAgent(ctx context.Context, agent *model.Agent) error {
+	ret := _m.Called(ctx, agent)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Agent) error); ok {
+		r0 = rf(ctx, agent)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Connected provides a mock function with given fields: agentID
 func (_m *mockProtocol) Disconnect(agentID string) bool {
 	ret := _m.Called(agentID)
@@ -68,6 +68,22 @@ func (_m *BindPlane) Manager() server.Manager {
 	return r0
 }
 
+// Packages provides a mock function with given fields:
+func (_m *BindPlane) Packages() agent.Packages {
+	ret := _m.Called()
+
+	var r0 agent.Packages
+	if rf, ok := ret.Get(0).(func() agent.Packages); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(agent.Packages)
+		}
+	}
+
+	return r0
+}
+
 // Relayers provides a mock function with given fields:
 func (_m *BindPlane) Relayers() *server.Relayers {
 	ret := _m.Called()
@@ -1,21 +1,20 @@
-// Code generated by mockery v0.0.0-dev. DO NOT EDIT.
+// Code generated by mockery v2.14.0. DO NOT EDIT.
 
 package mocks
 
 import (
 	context "context"
 
-	report "github.com/observiq/bindplane-op/internal/server/report"
-
+	model "github.com/observiq/bindplane-op/model"
 	mock "github.com/stretchr/testify/mock"
 
-	model "github.com/observiq/bindplane-op/model"
+	report "github.com/observiq/bindplane-op/internal/server/report"
 
 	server "github.com/observiq/bindplane-op/internal/server"
 
 	store "github.com/observiq/bindplane-op/internal/store"
 
-	testing "testing"
+	x509 "crypto/x509"
 )
 
 // Manager is an autogenerated mock type for the Manager type
@@ -23,6 +22,29 @@ type Manager struct {
 	mock.Mock
 }
 
+// AbortRollout provides a mock function with given fields: ctx, name
+func (_m *Manager) AbortRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Rollout
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Rollout); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Rollout)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Agent provides a mock function with given fields: ctx, agentID
 func (_m *Manager) Agent(ctx context.Context, agentID string) (*model.Agent, error) {
 	ret := _m.Called(ctx, agentID)
@@ -46,13 +68,13 @@ func (_m *Manager) Agent(ctx context.Context, agentID string) (*model.Agent, err
 	return r0, r1
 }
 
-// AgentUpdates provides a mock function with given fields: ctx, _a1
-func (_m *Manager) AgentUpdates(ctx context.Context, _a1 *model.Agent) (*server.AgentUpdates, error) {
-	ret := _m.Called(ctx, _a1)
+// AgentUpdates provides a mock function with given fields: ctx, agent
+func (_m *Manager) AgentUpdates(ctx context.Context, agent *model.Agent) (*server.AgentUpdates, error) {
+	ret := _m.Called(ctx, agent)
 
 	var r0 *server.AgentUpdates
 	if rf, ok := ret.Get(0).(func(context.Context, *model.Agent) *server.AgentUpdates); ok {
-		r0 = rf(ctx, _a1)
+		r0 = rf(ctx, agent)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).(*server.AgentUpdates)
@@ -61,7 +83,7 @@ func (_m *Manager) AgentUpdates(ctx context.Context, _a1 *model.Agent) (*server.
 
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context, *model.Agent) error); ok {
-		r1 = rf(ctx, _a1)
+		r1 = rf(ctx, agent)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -92,7 +114,125 @@ func (_m *Manager) AgentVersion(ctx context.Context, version string) (*model.Age
 	return r0, r1
 }
 
-// RequestReport sends report configuration to the specified agent
+// AuthenticateAgent provides a mock function with given fields: ctx, agentID, secretKey
+func (_m *Manager) AuthenticateAgent(ctx context.Context, agentID string, secretKey string) (bool, error) {
+	ret := _m.Called(ctx, agentID, secretKey)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string) bool); ok {
+		r0 = rf(ctx, agentID, secretKey)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string) error); ok {
+		r1 = rf(ctx, agentID, secretKey)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// BindPlaneConfiguration provides a mock function with given fields:
+func (_m *Manager) BindPlaneConfiguration() model.BindPlaneConfiguration {
+	ret := _m.Called()
+
+	var r0 model.BindPlaneConfiguration
+	if rf, ok := ret.Get(0).(func() model.BindPlaneConfiguration); ok {
+		r0 = rf()
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(model.BindPlaneConfiguration)
+		}
+	}
+
+	return r0
+}
+
+// CreateUpgradeCampaign provides a mock function with given fields: ctx, campaign, options
+func (_m *Manager) CreateUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign, options ...store.QueryOption) (*model.UpgradeCampaign, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, campaign)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 *model.UpgradeCampaign
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpgradeCampaign, ...store.QueryOption) *model.UpgradeCampaign); ok {
+		r0 = rf(ctx, campaign, options...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UpgradeCampaign)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, *model.UpgradeCampaign, ...store.QueryOption) error); ok {
+		r1 = rf(ctx, campaign, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnableProtocol provides a mock function with given fields: _a0
+func (_m *Manager) EnableProtocol(_a0 server.Protocol) {
+	_m.Called(_a0)
+}
+
+// PauseRollout provides a mock function with given fields: ctx, name
+func (_m *Manager) PauseRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Rollout
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Rollout); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Rollout)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// PauseUpgradeCampaign provides a mock function with given fields: ctx, name
+func (_m *Manager) PauseUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.UpgradeCampaign
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UpgradeCampaign); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UpgradeCampaign)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RequestReport provides a mock function with given fields: ctx, agentID, configuration
 func (_m *Manager) RequestReport(ctx context.Context, agentID string, configuration report.Configuration) error {
 	ret := _m.Called(ctx, agentID, configuration)
 
@@ -106,11 +246,6 @@ func (_m *Manager) RequestReport(ctx context.Context, agentID string, configurat
 	return r0
 }
 
-// EnableProtocol provides a mock function with given fields: _a0
-func (_m *Manager) EnableProtocol(_a0 server.Protocol) {
-	_m.Called(_a0)
-}
-
 // ResourceStore provides a mock function with given fields:
 func (_m *Manager) ResourceStore() model.ResourceStore {
 	ret := _m.Called()
@@ -127,20 +262,50 @@ func (_m *Manager) ResourceStore() model.ResourceStore {
 	return r0
 }
 
-// BindPlaneConfiguration provides a mock function with given fields:
-func (_m *Manager) BindPlaneConfiguration() model.BindPlaneConfiguration {
-	ret := _m.Called()
+// ResumeRollout provides a mock function with given fields: ctx, name
+func (_m *Manager) ResumeRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	ret := _m.Called(ctx, name)
 
-	var r0 model.BindPlaneConfiguration
-	if rf, ok := ret.Get(0).(func() model.BindPlaneConfiguration); ok {
-		r0 = rf()
+	var r0 *model.Rollout
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Rollout); ok {
+		r0 = rf(ctx, name)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).(model.BindPlaneConfiguration)
+			r0 = ret.Get(0).(*model.Rollout)
 		}
 	}
 
-	return r0
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResumeUpgradeCampaign provides a mock function with given fields: ctx, name
+func (_m *Manager) ResumeUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.UpgradeCampaign
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UpgradeCampaign); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UpgradeCampaign)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
 }
 
 // Start provides a mock function with given fields: ctx
@@ -171,6 +336,27 @@ func (_m *Manager) UpsertAgent(ctx context.Context, agentID string, updater stor
 	return r0, r1
 }
 
+// VerifyAgentCertificate provides a mock function with given fields: ctx, agentID, certificate
+func (_m *Manager) VerifyAgentCertificate(ctx context.Context, agentID string, certificate *x509.Certificate) (bool, error) {
+	ret := _m.Called(ctx, agentID, certificate)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, *x509.Certificate) bool); ok {
+		r0 = rf(ctx, agentID, certificate)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, *x509.Certificate) error); ok {
+		r1 = rf(ctx, agentID, certificate)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // VerifySecretKey provides a mock function with given fields: ctx, secretKey
 func (_m *Manager) VerifySecretKey(ctx context.Context, secretKey string) bool {
 	ret := _m.Called(ctx, secretKey)
@@ -185,8 +371,13 @@ func (_m *Manager) VerifySecretKey(ctx context.Context, secretKey string) bool {
 	return r0
 }
 
-// NewManager creates a new instance of Manager. It also registers the testing.TB interface on the mock and a cleanup function to assert the mocks expectations.
-func NewManager(t testing.TB) *Manager {
+type mockConstructorTestingTNewManager interface {
+	mock.TestingT
+	Cleanup(func())
+}
+
+// NewManager creates a new instance of Manager. It also registers a testing interface on the mock and a cleanup function to assert the mocks expectations.
+func NewManager(t mockConstructorTestingTNewManager) *Manager {
 	mock := &Manager{}
 	mock.Mock.Test(t)
 
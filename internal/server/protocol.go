@@ -56,6 +56,10 @@ type Protocol interface {
 
 	// RequestReport sends report configuration to the specified agent
 	RequestReport(ctx context.Context, agentID string, configuration report.Configuration) error
+
+	// RestartAgent sends a message to the specified agent to restart it. It does nothing if the agent is not connected
+	// using this protocol.
+	RestartAgent(context.Context, *model.Agent) error
 }
 
 // Empty returns true if the updates are empty because no changes need to be made to the agent
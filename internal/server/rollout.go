@@ -0,0 +1,477 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/model"
+)
+
+const (
+	// RolloutCheckInterval is the interval at which running rollouts check the status of the agents in the current wave
+	RolloutCheckInterval = 15 * time.Second
+	// RolloutLease is the name of the lease held by the server that starts and advances rollouts.
+	RolloutLease = "rollouts"
+	// RolloutLeaseTTL is how long the rollout lease is held without being renewed.
+	RolloutLeaseTTL = 3 * RolloutCheckInterval
+)
+
+var (
+	// ErrRolloutInactive is returned when pausing, resuming, or aborting a rollout that has completed, was aborted, or
+	// was superseded
+	ErrRolloutInactive = errors.New("rollout is not active")
+)
+
+// rolloutWave identifies the wave of a rollout that was sent to the agents connected to this server
+type rolloutWave struct {
+	revision  int
+	wave      int
+	completed bool
+}
+
+// rolloutLeader returns true if this server holds the rollout lease. With multiple servers, only the server holding the
+// lease stores new rollouts and advances them. Every server sends the configuration to the agents connected to it.
+func (m *manager) rolloutLeader(ctx context.Context) bool {
+	acquired, err := m.store.AcquireLease(ctx, RolloutLease, m.nodeID, RolloutLeaseTTL)
+	if err != nil {
+		m.logger.Error("unable to acquire the rollout lease", zap.Error(err))
+		return false
+	}
+	return acquired
+}
+
+// startRollout returns the active rollout of the configuration, starting a new rollout with the first wave of agents if
+// the configuration specifies rollout options and this revision has not been rolled out yet. It returns nil if the
+// configuration should be sent to all of its agents. Every server receives the configuration change and determines the
+// same rollout from the store, but only the server holding the rollout lease stores it.
+func (m *manager) startRollout(ctx context.Context, configuration *model.Configuration) (*model.Rollout, error) {
+	m.rolloutMtx.Lock()
+	defer m.rolloutMtx.Unlock()
+
+	leader := m.rolloutLeader(ctx)
+
+	existing, err := m.store.Rollout(ctx, configuration.Name())
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	previousRevision := 0
+	if existing != nil {
+		switch {
+		case existing.Revision == configuration.Revision():
+			// the configuration or one of its components changed without a new revision
+			if existing.Active() {
+				m.markRolloutSent(existing)
+				return existing, nil
+			}
+			return nil, nil
+
+		case existing.RollbackRevision != 0 && existing.RollbackRevision == configuration.Revision():
+			// the rollback of an aborted rollout is sent to all agents
+			return nil, nil
+
+		case existing.Active():
+			// agents that have not been updated continue to use the revision that was replaced by the superseded rollout
+			previousRevision = existing.PreviousRevision
+			existing.Status = model.RolloutSuperseded
+			existing.Message = fmt.Sprintf("superseded by revision %d", configuration.Revision())
+			existing.UpdatedAt = now
+			if !leader {
+				break
+			}
+			if err := m.store.UpsertRollout(ctx, existing); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	if configuration.Spec.Rollout == nil {
+		return nil, nil
+	}
+
+	if previousRevision == 0 {
+		revisions, err := m.store.ResourceRevisions(ctx, model.KindConfiguration, configuration.Name())
+		if err != nil {
+			return nil, err
+		}
+		for _, r := range revisions {
+			if r.Revision() < configuration.Revision() {
+				previousRevision = r.Revision()
+			}
+		}
+		if previousRevision == 0 {
+			// a new configuration has no previous revision for agents to use
+			return nil, nil
+		}
+	}
+
+	matching, err := m.store.AgentsIDsMatchingConfiguration(ctx, configuration)
+	if err != nil {
+		return nil, err
+	}
+
+	rollout := model.NewRollout(configuration, previousRevision, now)
+	if _, err := m.startWave(ctx, rollout, matching, now); err != nil {
+		return nil, err
+	}
+	// handleUpdates sends the first wave to the agents connected to this server
+	m.markRolloutSent(rollout)
+	if !leader {
+		return rollout, nil
+	}
+
+	m.logger.Info("starting rollout",
+		zap.String("configuration.name", rollout.Name),
+		zap.Int("revision", rollout.Revision),
+		zap.Int("agents", len(rollout.Agents)),
+	)
+	if err := m.store.UpsertRollout(ctx, rollout); err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
+
+// startWave starts the next wave of the rollout with the agents that have not been updated and are connected to any
+// server according to their stored status or completes the rollout if there are no such agents. It returns the IDs of
+// the agents in the new wave.
+func (m *manager) startWave(ctx context.Context, rollout *model.Rollout, matching []string, now time.Time) ([]string, error) {
+	remaining := []string{}
+	for _, id := range matching {
+		if rollout.Includes(id) {
+			continue
+		}
+		agent, err := m.store.Agent(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if agentConnected(agent) {
+			remaining = append(remaining, id)
+		}
+	}
+	if len(remaining) == 0 {
+		rollout.Status = model.RolloutCompleted
+		rollout.UpdatedAt = now
+		return nil, nil
+	}
+	sort.Strings(remaining)
+
+	size := rollout.Options.WaveSize(len(matching))
+	if size < len(remaining) {
+		remaining = remaining[:size]
+	}
+	rollout.StartWave(remaining, now)
+	return remaining, nil
+}
+
+// rolloutConfiguration returns the configuration that should be applied to the agent while the configuration is rolled
+// out. Agents that have not been updated by an active rollout use the previous revision.
+func (m *manager) rolloutConfiguration(ctx context.Context, agentID string, configuration *model.Configuration) (*model.Configuration, error) {
+	if configuration == nil {
+		return nil, nil
+	}
+	rollout, err := m.store.Rollout(ctx, configuration.Name())
+	if err != nil {
+		return nil, err
+	}
+	if rollout == nil || !rollout.Active() || rollout.Revision != configuration.Revision() || rollout.Includes(agentID) {
+		return configuration, nil
+	}
+	return m.previousConfiguration(ctx, rollout)
+}
+
+// previousConfiguration returns the revision of the configuration used by agents that have not been updated
+func (m *manager) previousConfiguration(ctx context.Context, rollout *model.Rollout) (*model.Configuration, error) {
+	r, err := store.ResourceRevision(ctx, m.store, model.KindConfiguration, rollout.Name, rollout.PreviousRevision)
+	if err != nil {
+		return nil, err
+	}
+	configuration, ok := r.(*model.Configuration)
+	if !ok {
+		return nil, fmt.Errorf("configuration %s revision %d: unexpected resource %T", rollout.Name, rollout.PreviousRevision, r)
+	}
+	return configuration, nil
+}
+
+// handleRollouts checks the status of the agents in the current wave of each running rollout and starts the next wave
+// when every agent has applied the configuration or reported an error. Rollouts that exceed the MaxErrorPercent are
+// paused or aborted. With multiple servers, only the server holding the rollout lease advances rollouts and every
+// server sends the configuration to the agents of new waves that are connected to it.
+func (m *manager) handleRollouts(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "manager/handleRollouts")
+	defer span.End()
+
+	m.rolloutMtx.Lock()
+	defer m.rolloutMtx.Unlock()
+
+	leader := m.rolloutLeader(ctx)
+
+	rollouts, err := m.store.Rollouts(ctx)
+	if err != nil {
+		m.logger.Error("unable to get rollouts", zap.Error(err))
+		return
+	}
+
+	for _, rollout := range rollouts {
+		if leader && rollout.Status == model.RolloutRunning {
+			if err := m.advanceRollout(ctx, rollout); err != nil {
+				m.logger.Error("unable to advance rollout", zap.String("configuration.name", rollout.Name), zap.Error(err))
+				continue
+			}
+		}
+		if err := m.sendRollout(ctx, rollout); err != nil {
+			m.logger.Error("unable to send rollout", zap.String("configuration.name", rollout.Name), zap.Error(err))
+		}
+	}
+}
+
+// advanceRollout updates the status of the agents in the current wave and starts the next wave if the current wave
+// is complete. It must be called with the rolloutMtx held.
+func (m *manager) advanceRollout(ctx context.Context, rollout *model.Rollout) error {
+	now := time.Now()
+	for _, id := range rollout.WaveAgentIDs(model.RolloutAgentPending) {
+		agent, err := m.store.Agent(ctx, id)
+		if err != nil {
+			return err
+		}
+		if !agentConnected(agent) {
+			// the agent will receive the configuration that applies when it reconnects
+			delete(rollout.Agents, id)
+			continue
+		}
+		// the agent is applied or failed when it reports the status of the remote configuration sent for the revision
+		rollout.UpdateAgentStatus(agent)
+	}
+	rollout.UpdatedAt = now
+
+	if len(rollout.WaveAgentIDs(model.RolloutAgentPending)) > 0 {
+		return m.store.UpsertRollout(ctx, rollout)
+	}
+
+	if rollout.WaveFailed() {
+		message := fmt.Sprintf("%.0f%% of the agents in wave %d failed to apply the configuration", rollout.WaveErrorPercent(), rollout.Wave)
+		m.logger.Info("stopping rollout", zap.String("configuration.name", rollout.Name), zap.String("reason", message))
+		if rollout.Options.OnError == model.RolloutActionRollback {
+			return m.abortRollout(ctx, rollout, message)
+		}
+		rollout.Status = model.RolloutPaused
+		rollout.Message = message
+		return m.store.UpsertRollout(ctx, rollout)
+	}
+
+	return m.nextWave(ctx, rollout)
+}
+
+// nextWave starts the next wave of the rollout and sends the configuration to the agents in the wave that are connected
+// to this server. When the rollout completes, the configuration is sent to every agent. It must be called with the
+// rolloutMtx held.
+func (m *manager) nextWave(ctx context.Context, rollout *model.Rollout) error {
+	now := time.Now()
+	configuration, err := m.store.Configuration(ctx, rollout.Name)
+	if err != nil {
+		return err
+	}
+	if configuration == nil || configuration.Revision() != rollout.Revision {
+		rollout.Status = model.RolloutSuperseded
+		rollout.Message = "the configuration was changed or deleted"
+		rollout.UpdatedAt = now
+		return m.store.UpsertRollout(ctx, rollout)
+	}
+
+	matching, err := m.store.AgentsIDsMatchingConfiguration(ctx, configuration)
+	if err != nil {
+		return err
+	}
+
+	if _, err := m.startWave(ctx, rollout, matching, now); err != nil {
+		return err
+	}
+	if rollout.Status == model.RolloutCompleted {
+		m.logger.Info("completed rollout", zap.String("configuration.name", rollout.Name), zap.Int("revision", rollout.Revision))
+	}
+	if err := m.store.UpsertRollout(ctx, rollout); err != nil {
+		return err
+	}
+
+	m.sendConfiguration(ctx, configuration, m.rolloutAgentIDs(rollout, matching))
+	m.markRolloutSent(rollout)
+	return nil
+}
+
+// sendRollout sends the revision of the rollout to the agents connected to this server if the current wave or the
+// completion of the rollout has not been sent by this server. Waves may be started by another server, so each server
+// sends the configuration to the agents connected to it. It must be called with the rolloutMtx held.
+func (m *manager) sendRollout(ctx context.Context, rollout *model.Rollout) error {
+	if !rollout.Active() && rollout.Status != model.RolloutCompleted {
+		return nil
+	}
+	if m.rolloutWaves[rollout.Name] == newRolloutWave(rollout) {
+		return nil
+	}
+
+	configuration, err := m.store.Configuration(ctx, rollout.Name)
+	if err != nil {
+		return err
+	}
+	if configuration == nil || configuration.Revision() != rollout.Revision {
+		// the configuration was changed or deleted and its new revision is sent by handleUpdates
+		m.markRolloutSent(rollout)
+		return nil
+	}
+
+	var matching []string
+	if rollout.Status == model.RolloutCompleted {
+		if matching, err = m.store.AgentsIDsMatchingConfiguration(ctx, configuration); err != nil {
+			return err
+		}
+	}
+
+	m.sendConfiguration(ctx, configuration, m.rolloutAgentIDs(rollout, matching))
+	m.markRolloutSent(rollout)
+	return nil
+}
+
+// rolloutAgentIDs returns the IDs of the agents that use the revision of the rollout, which is every matching agent
+// once the rollout completes
+func (m *manager) rolloutAgentIDs(rollout *model.Rollout, matching []string) []string {
+	if rollout.Status == model.RolloutCompleted {
+		return matching
+	}
+	ids := make([]string, 0, len(rollout.Agents))
+	for id := range rollout.Agents {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// markRolloutSent records that the current wave of the rollout was sent to the agents connected to this server. It
+// must be called with the rolloutMtx held.
+func (m *manager) markRolloutSent(rollout *model.Rollout) {
+	if m.rolloutWaves == nil {
+		m.rolloutWaves = map[string]rolloutWave{}
+	}
+	m.rolloutWaves[rollout.Name] = newRolloutWave(rollout)
+}
+
+func newRolloutWave(rollout *model.Rollout) rolloutWave {
+	return rolloutWave{
+		revision:  rollout.Revision,
+		wave:      rollout.Wave,
+		completed: rollout.Status == model.RolloutCompleted,
+	}
+}
+
+// abortRollout stops the rollout and restores the previous revision of the configuration. The restored revision is
+// sent to every agent by handleUpdates. It must be called with the rolloutMtx held.
+func (m *manager) abortRollout(ctx context.Context, rollout *model.Rollout, message string) error {
+	status, err := store.RollbackResource(ctx, m.store, model.KindConfiguration, rollout.Name, rollout.PreviousRevision)
+	if err != nil {
+		return err
+	}
+	rollout.RollbackRevision = status.Resource.Revision()
+	rollout.Status = model.RolloutAborted
+	rollout.Message = message
+	rollout.UpdatedAt = time.Now()
+	return m.store.UpsertRollout(ctx, rollout)
+}
+
+// sendConfiguration sends the configuration to the agents with the specified IDs that are connected to this server
+func (m *manager) sendConfiguration(ctx context.Context, configuration *model.Configuration, agentIDs []string) {
+	pending := pendingAgentUpdates{}
+	for _, id := range agentIDs {
+		if !m.connected(id) {
+			continue
+		}
+		agent, err := m.store.Agent(ctx, id)
+		if err != nil || agent == nil {
+			m.logger.Error("unable to apply configuration to agent", zap.String("agentID", id), zap.String("configuration.name", configuration.Name()), zap.Error(err))
+			continue
+		}
+		pending.agent(agent).updates.Configuration = configuration
+	}
+	pending.apply(ctx, m)
+}
+
+// PauseRollout pauses the running rollout of the configuration with the specified name. Agents that have not been
+// updated continue to use the previous revision until the rollout is resumed or aborted.
+func (m *manager) PauseRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return m.updateRollout(ctx, name, func(rollout *model.Rollout) error {
+		if rollout.Status == model.RolloutRunning {
+			rollout.Status = model.RolloutPaused
+			rollout.Message = "paused"
+			rollout.UpdatedAt = time.Now()
+		}
+		return nil
+	})
+}
+
+// ResumeRollout resumes the paused rollout of the configuration with the specified name. If every agent in the current
+// wave has applied the configuration or failed, the next wave is started.
+func (m *manager) ResumeRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return m.updateRollout(ctx, name, func(rollout *model.Rollout) error {
+		if rollout.Status != model.RolloutPaused {
+			return nil
+		}
+		rollout.Status = model.RolloutRunning
+		rollout.Message = ""
+		rollout.UpdatedAt = time.Now()
+		if len(rollout.WaveAgentIDs(model.RolloutAgentPending)) > 0 {
+			return nil
+		}
+		return m.nextWave(ctx, rollout)
+	})
+}
+
+// AbortRollout stops the rollout of the configuration with the specified name and restores the previous revision of
+// the configuration on every agent.
+func (m *manager) AbortRollout(ctx context.Context, name string) (*model.Rollout, error) {
+	return m.updateRollout(ctx, name, func(rollout *model.Rollout) error {
+		return m.abortRollout(ctx, rollout, "aborted")
+	})
+}
+
+// updateRollout calls the updater with the active rollout of the configuration with the specified name and stores the
+// result
+func (m *manager) updateRollout(ctx context.Context, name string, updater func(rollout *model.Rollout) error) (*model.Rollout, error) {
+	m.rolloutMtx.Lock()
+	defer m.rolloutMtx.Unlock()
+
+	rollout, err := m.store.Rollout(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if rollout == nil {
+		return nil, fmt.Errorf("rollout %s: %w", name, store.ErrResourceMissing)
+	}
+	if !rollout.Active() {
+		return nil, fmt.Errorf("rollout %s is %s: %w", name, rollout.Status, ErrRolloutInactive)
+	}
+	if err := updater(rollout); err != nil {
+		return nil, err
+	}
+	if err := m.store.UpsertRollout(ctx, rollout); err != nil {
+		return nil, err
+	}
+	return rollout, nil
+}
@@ -0,0 +1,333 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// rolloutTestManager returns a manager with four connected agents using revision 1 of the test configuration and a
+// function that returns the revision of the configuration most recently sent to each agent
+func rolloutTestManager(t *testing.T) (*manager, func() map[string]int) {
+	s := store.NewMapStore(context.Background(), store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, logger)
+
+	var mtx sync.Mutex
+	sent := map[string]int{}
+	protocol := &mockProtocol{}
+	protocol.On("Connected", mock.Anything).Return(true)
+	protocol.On("UpdateAgent", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		mtx.Lock()
+		defer mtx.Unlock()
+		sent[args.Get(1).(*model.Agent).ID] = args.Get(2).(*AgentUpdates).Configuration.Revision()
+	}).Return(nil)
+
+	m := &manager{
+		store:     s,
+		logger:    logger,
+		protocols: []Protocol{protocol},
+	}
+
+	for _, id := range []string{"1", "2", "3", "4"} {
+		_, err := s.UpsertAgent(context.Background(), id, func(agent *model.Agent) {
+			agent.Labels = model.LabelsFromValidatedMap(map[string]string{"configuration": "test"})
+			agent.Status = model.Connected
+		})
+		require.NoError(t, err)
+	}
+
+	_, err := s.ApplyResources(context.Background(), []model.Resource{makeTestConfiguration(t, "test", "configuration=test", "raw: 1")})
+	require.NoError(t, err)
+
+	return m, func() map[string]int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		result := map[string]int{}
+		for id, revision := range sent {
+			result[id] = revision
+		}
+		return result
+	}
+}
+
+// applyRolloutTestConfiguration applies a new revision of the test configuration and sends it to handleUpdates
+func applyRolloutTestConfiguration(t *testing.T, m *manager, raw string, options *model.RolloutOptions) *model.Configuration {
+	ctx := context.Background()
+	configuration := makeTestConfiguration(t, "test", "configuration=test", raw)
+	configuration.Spec.Rollout = options
+	_, err := m.store.ApplyResources(ctx, []model.Resource{configuration})
+	require.NoError(t, err)
+
+	configuration, err = m.store.Configuration(ctx, "test")
+	require.NoError(t, err)
+
+	updates := store.NewUpdates()
+	updates.Configurations.Include(configuration, store.EventTypeUpdate)
+	m.handleUpdates(ctx, updates)
+	return configuration
+}
+
+// reportRolloutTestConfiguration records that the current revision of the test configuration was sent to the agents
+// with the specified hash and that the agents reported the status for the hash
+func reportRolloutTestConfiguration(t *testing.T, m *manager, hash string, status model.RemoteConfigStatus, ids ...string) {
+	ctx := context.Background()
+	configuration, err := m.store.Configuration(ctx, "test")
+	require.NoError(t, err)
+	_, err = m.store.UpsertAgents(ctx, ids, func(agent *model.Agent) {
+		agent.ConfigurationSent(configuration, hash)
+		agent.ConfigurationReported(hash, status)
+	})
+	require.NoError(t, err)
+}
+
+func TestManagerRollout(t *testing.T) {
+	ctx := context.Background()
+	m, sent := rolloutTestManager(t)
+
+	configuration := applyRolloutTestConfiguration(t, m, "raw: 2", &model.RolloutOptions{BatchPercent: 50})
+	require.Equal(t, 2, configuration.Revision())
+	require.Equal(t, map[string]int{"1": 2, "2": 2, "3": 1, "4": 1}, sent())
+
+	rollout, err := m.store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, model.RolloutRunning, rollout.Status)
+	require.Equal(t, 1, rollout.Wave)
+	require.Equal(t, 1, rollout.PreviousRevision)
+	require.Equal(t, []string{"1", "2"}, rollout.WaveAgentIDs(model.RolloutAgentPending))
+
+	// agents that have not been updated by the rollout use the previous revision
+	for id, revision := range map[string]int{"1": 2, "3": 1} {
+		agent, err := m.store.Agent(ctx, id)
+		require.NoError(t, err)
+		updates, err := m.AgentUpdates(ctx, agent)
+		require.NoError(t, err)
+		require.Equal(t, revision, updates.Configuration.Revision())
+	}
+
+	// the wave is not complete until the agents report that they applied the configuration sent for the revision
+	m.handleRollouts(ctx)
+	rollout, err = m.store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, 1, rollout.Wave)
+
+	reportRolloutTestConfiguration(t, m, "a1", model.RemoteConfigApplying, "1", "2")
+	m.handleRollouts(ctx)
+	rollout, err = m.store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, 1, rollout.Wave)
+	require.Equal(t, "a1", rollout.Agents["1"].ConfigHash)
+
+	// a status reported for a different remote configuration is ignored
+	_, err = m.store.UpsertAgents(ctx, []string{"1", "2"}, func(agent *model.Agent) {
+		agent.ConfigurationReported("b2", model.RemoteConfigApplied)
+	})
+	require.NoError(t, err)
+	m.handleRollouts(ctx)
+	rollout, err = m.store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, 1, rollout.Wave)
+
+	reportRolloutTestConfiguration(t, m, "a1", model.RemoteConfigApplied, "1", "2")
+	m.handleRollouts(ctx)
+	rollout, err = m.store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, 2, rollout.Wave)
+	require.Equal(t, 2, rollout.AgentCount(model.RolloutAgentApplied))
+	require.Equal(t, []string{"3", "4"}, rollout.WaveAgentIDs(model.RolloutAgentPending))
+	require.Equal(t, map[string]int{"1": 2, "2": 2, "3": 2, "4": 2}, sent())
+
+	// a failed agent exceeds the default maxErrorPercent of 0 and pauses the rollout
+	reportRolloutTestConfiguration(t, m, "a1", model.RemoteConfigFailed, "3")
+	reportRolloutTestConfiguration(t, m, "a1", model.RemoteConfigApplied, "4")
+	m.handleRollouts(ctx)
+	rollout, err = m.store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, model.RolloutPaused, rollout.Status)
+	require.Equal(t, "50% of the agents in wave 2 failed to apply the configuration", rollout.Message)
+	require.Equal(t, 1, rollout.AgentCount(model.RolloutAgentFailed))
+
+	// paused rollouts are not advanced
+	m.handleRollouts(ctx)
+	rollout, err = m.store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, model.RolloutPaused, rollout.Status)
+
+	rollout, err = m.ResumeRollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, model.RolloutCompleted, rollout.Status)
+
+	_, err = m.PauseRollout(ctx, "test")
+	require.ErrorIs(t, err, ErrRolloutInactive)
+}
+
+func TestManagerRolloutRollback(t *testing.T) {
+	ctx := context.Background()
+	m, sent := rolloutTestManager(t)
+
+	applyRolloutTestConfiguration(t, m, "raw: 2", &model.RolloutOptions{BatchSize: 1, MaxErrorPercent: 50, OnError: model.RolloutActionRollback})
+	require.Equal(t, map[string]int{"1": 2, "2": 1, "3": 1, "4": 1}, sent())
+
+	reportRolloutTestConfiguration(t, m, "a1", model.RemoteConfigFailed, "1")
+	m.handleRollouts(ctx)
+
+	rollout, err := m.store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, model.RolloutAborted, rollout.Status)
+	require.Equal(t, 3, rollout.RollbackRevision)
+
+	// the rollback restores the previous revision and is sent to every agent
+	configuration, err := m.store.Configuration(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, 3, configuration.Revision())
+	require.Equal(t, "raw: 1", configuration.Spec.Raw)
+
+	updates := store.NewUpdates()
+	updates.Configurations.Include(configuration, store.EventTypeUpdate)
+	m.handleUpdates(ctx, updates)
+	require.Equal(t, map[string]int{"1": 3, "2": 3, "3": 3, "4": 3}, sent())
+}
+
+func TestManagerRolloutControls(t *testing.T) {
+	ctx := context.Background()
+	m, sent := rolloutTestManager(t)
+
+	_, err := m.PauseRollout(ctx, "test")
+	require.ErrorIs(t, err, store.ErrResourceMissing)
+
+	applyRolloutTestConfiguration(t, m, "raw: 2", &model.RolloutOptions{BatchSize: 3})
+	require.Equal(t, map[string]int{"1": 2, "2": 2, "3": 2, "4": 1}, sent())
+
+	rollout, err := m.PauseRollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, model.RolloutPaused, rollout.Status)
+
+	// resuming before the wave is complete continues to wait for the agents
+	rollout, err = m.ResumeRollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, model.RolloutRunning, rollout.Status)
+	require.Equal(t, 1, rollout.Wave)
+
+	// a new revision supersedes the rollout and agents that were not updated continue to use revision 1
+	applyRolloutTestConfiguration(t, m, "raw: 3", &model.RolloutOptions{BatchSize: 3})
+	rollout, err = m.store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, 3, rollout.Revision)
+	require.Equal(t, 1, rollout.PreviousRevision)
+	require.Equal(t, map[string]int{"1": 3, "2": 3, "3": 3, "4": 1}, sent())
+
+	rollout, err = m.AbortRollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, model.RolloutAborted, rollout.Status)
+	require.Equal(t, 4, rollout.RollbackRevision)
+
+	_, err = m.AbortRollout(ctx, "test")
+	require.ErrorIs(t, err, ErrRolloutInactive)
+}
+
+func TestManagerRolloutMultipleServers(t *testing.T) {
+	ctx := context.Background()
+	s := store.NewMapStore(ctx, store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, logger)
+
+	var mtx sync.Mutex
+	sent := map[string]int{}
+	newServer := func(nodeID string, agentIDs ...string) *manager {
+		protocol := &mockProtocol{}
+		for _, id := range agentIDs {
+			protocol.On("Connected", id).Return(true)
+		}
+		protocol.On("Connected", mock.Anything).Return(false)
+		protocol.On("UpdateAgent", mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			mtx.Lock()
+			defer mtx.Unlock()
+			sent[args.Get(1).(*model.Agent).ID] = args.Get(2).(*AgentUpdates).Configuration.Revision()
+		}).Return(nil)
+		return &manager{
+			store:     s,
+			logger:    logger,
+			protocols: []Protocol{protocol},
+			nodeID:    nodeID,
+		}
+	}
+	sentRevisions := func() map[string]int {
+		mtx.Lock()
+		defer mtx.Unlock()
+		result := map[string]int{}
+		for id, revision := range sent {
+			result[id] = revision
+		}
+		return result
+	}
+
+	// agents 1 and 3 are connected to the first server and agents 2 and 4 to the second
+	m1 := newServer("node-1", "1", "3")
+	m2 := newServer("node-2", "2", "4")
+	for _, id := range []string{"1", "2", "3", "4"} {
+		_, err := s.UpsertAgent(ctx, id, func(agent *model.Agent) {
+			agent.Labels = model.LabelsFromValidatedMap(map[string]string{"configuration": "test"})
+			agent.Status = model.Connected
+		})
+		require.NoError(t, err)
+	}
+	_, err := s.ApplyResources(ctx, []model.Resource{makeTestConfiguration(t, "test", "configuration=test", "raw: 1")})
+	require.NoError(t, err)
+
+	// both servers receive the new revision and the first wave is chosen from the stored status of the agents
+	configuration := applyRolloutTestConfiguration(t, m1, "raw: 2", &model.RolloutOptions{BatchPercent: 50})
+	updates := store.NewUpdates()
+	updates.Configurations.Include(configuration, store.EventTypeUpdate)
+	m2.handleUpdates(ctx, updates)
+	require.Equal(t, map[string]int{"1": 2, "2": 2, "3": 1, "4": 1}, sentRevisions())
+
+	rollout, err := s.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2"}, rollout.WaveAgentIDs(model.RolloutAgentPending))
+
+	// only the server holding the rollout lease advances the rollout
+	reportRolloutTestConfiguration(t, m1, "a1", model.RemoteConfigApplied, "1", "2")
+	m2.handleRollouts(ctx)
+	rollout, err = s.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, 1, rollout.Wave)
+
+	m1.handleRollouts(ctx)
+	rollout, err = s.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, 2, rollout.Wave)
+	require.Equal(t, []string{"3", "4"}, rollout.WaveAgentIDs(model.RolloutAgentPending))
+	require.Equal(t, map[string]int{"1": 2, "2": 2, "3": 2, "4": 1}, sentRevisions())
+
+	// the other server sends the new wave to the agents connected to it
+	m2.handleRollouts(ctx)
+	require.Equal(t, map[string]int{"1": 2, "2": 2, "3": 2, "4": 2}, sentRevisions())
+
+	reportRolloutTestConfiguration(t, m1, "a1", model.RemoteConfigApplied, "3", "4")
+	m1.handleRollouts(ctx)
+	rollout, err = s.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Equal(t, model.RolloutCompleted, rollout.Status)
+}
@@ -46,6 +46,7 @@ func login(ctx *gin.Context, bindplane server.BindPlane) {
 
 	// Set user as authenticated
 	session.Values["authenticated"] = true
+	session.Values["user"] = username
 
 	bindplane.Logger().Info("logging in user.", zap.String("user", username))
 
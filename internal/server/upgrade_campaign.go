@@ -0,0 +1,303 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/model"
+)
+
+const (
+	// UpgradeCampaignCheckInterval is the interval at which running upgrade campaigns check the status of the agents
+	// being upgraded
+	UpgradeCampaignCheckInterval = 15 * time.Second
+	// UpgradeCampaignLease is the name of the lease held by the server that advances upgrade campaigns.
+	UpgradeCampaignLease = "upgrade-campaigns"
+	// UpgradeCampaignLeaseTTL is how long the upgrade campaign lease is held without being renewed.
+	UpgradeCampaignLeaseTTL = 3 * UpgradeCampaignCheckInterval
+)
+
+var (
+	// ErrUpgradeCampaignExists is returned when creating an upgrade campaign with the same name as a campaign that has
+	// not completed
+	ErrUpgradeCampaignExists = errors.New("upgrade campaign already exists")
+
+	// ErrUpgradeCampaignCompleted is returned when pausing or resuming an upgrade campaign that has completed
+	ErrUpgradeCampaignCompleted = errors.New("upgrade campaign is completed")
+)
+
+// CreateUpgradeCampaign starts upgrading the agents that match the options to the version of the campaign. Agents that
+// do not support upgrade or already have the version are not included. The latest version is used if the campaign does
+// not specify a version.
+func (m *manager) CreateUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign, options ...store.QueryOption) (*model.UpgradeCampaign, error) {
+	ctx, span := tracer.Start(ctx, "manager/CreateUpgradeCampaign")
+	defer span.End()
+
+	if err := campaign.Validate(); err != nil {
+		return nil, err
+	}
+
+	m.campaignMtx.Lock()
+	defer m.campaignMtx.Unlock()
+
+	existing, err := m.store.UpgradeCampaign(ctx, campaign.Name)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil && existing.Status != model.UpgradeCampaignCompleted {
+		return nil, fmt.Errorf("upgrade campaign %s is %s: %w", campaign.Name, existing.Status, ErrUpgradeCampaignExists)
+	}
+
+	if campaign.Version == "" || campaign.Version == "latest" {
+		campaign.Version = m.versions.LatestVersionString(ctx)
+		if campaign.Version == "" {
+			return nil, errors.New("unable to determine the latest agent version")
+		}
+	}
+
+	agents, err := m.store.Agents(ctx, options...)
+	if err != nil {
+		return nil, err
+	}
+	agentIDs := []string{}
+	for _, agent := range agents {
+		if agent.SupportsUpgrade() && agent.Version != campaign.Version {
+			agentIDs = append(agentIDs, agent.ID)
+		}
+	}
+
+	now := time.Now()
+	campaign.Start(agentIDs, now)
+	m.logger.Info("starting upgrade campaign",
+		zap.String("campaign.name", campaign.Name),
+		zap.String("version", campaign.Version),
+		zap.Int("agents", len(agentIDs)),
+	)
+	if err := m.nextUpgradeBatch(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
+
+// handleUpgradeCampaigns checks the status of the agents being upgraded by each running upgrade campaign, starts
+// upgrades up to the concurrency of the campaign, and starts the next batch when the current batch is complete. With
+// multiple servers, only the server holding the upgrade campaign lease advances upgrade campaigns.
+func (m *manager) handleUpgradeCampaigns(ctx context.Context) {
+	ctx, span := tracer.Start(ctx, "manager/handleUpgradeCampaigns")
+	defer span.End()
+
+	acquired, err := m.store.AcquireLease(ctx, UpgradeCampaignLease, m.nodeID, UpgradeCampaignLeaseTTL)
+	if err != nil {
+		m.logger.Error("unable to acquire the upgrade campaign lease", zap.Error(err))
+		return
+	}
+	if !acquired {
+		return
+	}
+
+	m.campaignMtx.Lock()
+	defer m.campaignMtx.Unlock()
+
+	campaigns, err := m.store.UpgradeCampaigns(ctx)
+	if err != nil {
+		m.logger.Error("unable to get upgrade campaigns", zap.Error(err))
+		return
+	}
+
+	for _, campaign := range campaigns {
+		if campaign.Status != model.UpgradeCampaignRunning {
+			continue
+		}
+		if err := m.advanceUpgradeCampaign(ctx, campaign); err != nil {
+			m.logger.Error("unable to advance upgrade campaign", zap.String("campaign.name", campaign.Name), zap.Error(err))
+		}
+	}
+}
+
+// advanceUpgradeCampaign updates the status of the agents being upgraded and starts more upgrades or the next batch.
+// Agents that do not complete the upgrade within the timeout of the campaign have failed. It must be called with the
+// campaignMtx held.
+func (m *manager) advanceUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) error {
+	now := time.Now()
+	timeout := campaign.UpgradeTimeout()
+	for _, id := range campaign.AgentIDs(campaign.Batch, model.UpgradeCampaignAgentUpgrading) {
+		agent, err := m.store.Agent(ctx, id)
+		if err != nil {
+			return err
+		}
+		status := campaign.Agents[id]
+		switch {
+		case agent == nil:
+			status.Status = model.UpgradeCampaignAgentSkipped
+			status.Error = "agent was deleted"
+		case agent.Upgrade == nil:
+			status.Status = model.UpgradeCampaignAgentUpgraded
+		case agent.Upgrade.Status == model.UpgradeFailed:
+			status.Status = model.UpgradeCampaignAgentFailed
+			status.Error = agent.Upgrade.Error
+		case now.Sub(status.StartedAt) > timeout:
+			status.Status = model.UpgradeCampaignAgentFailed
+			status.Error = fmt.Sprintf("upgrade did not complete within %s", timeout)
+		}
+	}
+	campaign.UpdatedAt = now
+
+	if !campaign.BatchComplete() {
+		return m.startUpgrades(ctx, campaign)
+	}
+	return m.nextUpgradeBatch(ctx, campaign)
+}
+
+// pauseOnFailures pauses the campaign and returns true if more than MaxFailurePercent of the completed upgrades have
+// failed
+func (m *manager) pauseOnFailures(campaign *model.UpgradeCampaign) bool {
+	if !campaign.FailureThresholdExceeded() {
+		return false
+	}
+	campaign.Status = model.UpgradeCampaignPaused
+	campaign.Message = fmt.Sprintf("%.0f%% of the agent upgrades failed", campaign.FailurePercent())
+	m.logger.Info("pausing upgrade campaign", zap.String("campaign.name", campaign.Name), zap.String("reason", campaign.Message))
+	return true
+}
+
+// nextUpgradeBatch starts the next batch of the campaign or completes the campaign if every agent has been assigned to
+// a batch. The campaign is paused instead if the failures exceed the threshold. It must be called with the campaignMtx
+// held.
+func (m *manager) nextUpgradeBatch(ctx context.Context, campaign *model.UpgradeCampaign) error {
+	if m.pauseOnFailures(campaign) {
+		return m.store.UpsertUpgradeCampaign(ctx, campaign)
+	}
+	if !campaign.StartBatch(time.Now()) {
+		campaign.Status = model.UpgradeCampaignCompleted
+		m.logger.Info("completed upgrade campaign", zap.String("campaign.name", campaign.Name), zap.String("version", campaign.Version))
+		return m.store.UpsertUpgradeCampaign(ctx, campaign)
+	}
+	return m.startUpgrades(ctx, campaign)
+}
+
+// startUpgrades starts upgrading the pending agents in the current batch until Concurrency agents are upgrading. No
+// upgrades are started and the campaign is paused if the failures exceed the threshold. Agents that are deleted or not
+// connected to any server are skipped. The campaign is stored before the agents are updated so that the campaign
+// tracks every upgrade that it starts. It must be called with the campaignMtx held.
+func (m *manager) startUpgrades(ctx context.Context, campaign *model.UpgradeCampaign) error {
+	pending := campaign.AgentIDs(campaign.Batch, model.UpgradeCampaignAgentPending)
+	upgrading := len(campaign.AgentIDs(campaign.Batch, model.UpgradeCampaignAgentUpgrading))
+
+	now := time.Now()
+	start := []string{}
+	for _, id := range pending {
+		if campaign.Concurrency > 0 && upgrading >= campaign.Concurrency {
+			break
+		}
+		if m.pauseOnFailures(campaign) {
+			break
+		}
+		agent, err := m.store.Agent(ctx, id)
+		if err != nil {
+			return err
+		}
+		status := campaign.Agents[id]
+		switch {
+		case agent == nil:
+			status.Status = model.UpgradeCampaignAgentSkipped
+			status.Error = "agent was deleted"
+			continue
+		case !agentConnected(agent):
+			status.Status = model.UpgradeCampaignAgentSkipped
+			status.Error = "agent was not connected"
+			continue
+		}
+		status.Status = model.UpgradeCampaignAgentUpgrading
+		status.StartedAt = now
+		start = append(start, id)
+		upgrading++
+	}
+
+	if err := m.store.UpsertUpgradeCampaign(ctx, campaign); err != nil {
+		return err
+	}
+	if len(start) == 0 {
+		return nil
+	}
+
+	sort.Strings(start)
+	_, err := m.store.UpsertAgents(ctx, start, func(current *model.Agent) {
+		current.UpgradeTo(campaign.Version)
+	})
+	return err
+}
+
+// PauseUpgradeCampaign pauses the upgrade campaign with the specified name. Agents that are already upgrading continue
+// to upgrade, but no more upgrades are started until the campaign is resumed.
+func (m *manager) PauseUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	return m.updateUpgradeCampaign(ctx, name, func(campaign *model.UpgradeCampaign) error {
+		if campaign.Status == model.UpgradeCampaignRunning {
+			campaign.Status = model.UpgradeCampaignPaused
+			campaign.Message = "paused"
+			campaign.UpdatedAt = time.Now()
+		}
+		return nil
+	})
+}
+
+// ResumeUpgradeCampaign resumes the paused upgrade campaign with the specified name. The failures before the campaign
+// was resumed are accepted and do not pause it again. If the current batch is complete, the next batch is started.
+func (m *manager) ResumeUpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	return m.updateUpgradeCampaign(ctx, name, func(campaign *model.UpgradeCampaign) error {
+		if campaign.Status != model.UpgradeCampaignPaused {
+			return nil
+		}
+		campaign.Status = model.UpgradeCampaignRunning
+		campaign.Message = ""
+		campaign.AcceptedFailures = campaign.AgentCount(model.UpgradeCampaignAgentFailed)
+		campaign.UpdatedAt = time.Now()
+		if !campaign.BatchComplete() {
+			return m.startUpgrades(ctx, campaign)
+		}
+		return m.nextUpgradeBatch(ctx, campaign)
+	})
+}
+
+// updateUpgradeCampaign calls the updater with the upgrade campaign with the specified name and stores the result
+func (m *manager) updateUpgradeCampaign(ctx context.Context, name string, updater func(campaign *model.UpgradeCampaign) error) (*model.UpgradeCampaign, error) {
+	m.campaignMtx.Lock()
+	defer m.campaignMtx.Unlock()
+
+	campaign, err := m.store.UpgradeCampaign(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if campaign == nil {
+		return nil, fmt.Errorf("upgrade campaign %s: %w", name, store.ErrResourceMissing)
+	}
+	if campaign.Status == model.UpgradeCampaignCompleted {
+		return nil, fmt.Errorf("upgrade campaign %s: %w", name, ErrUpgradeCampaignCompleted)
+	}
+	if err := updater(campaign); err != nil {
+		return nil, err
+	}
+	if err := m.store.UpsertUpgradeCampaign(ctx, campaign); err != nil {
+		return nil, err
+	}
+	return campaign, nil
+}
@@ -0,0 +1,239 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package server
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+
+	"github.com/observiq/bindplane-op/internal/agent/mocks"
+	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// upgradeCampaignTestManager returns a manager with connected agents 1 through 5 that can be upgraded, agent 6 that
+// already has the latest version, and agent 7 that does not support upgrade
+func upgradeCampaignTestManager(t *testing.T) *manager {
+	s := store.NewMapStore(context.Background(), store.Options{
+		SessionsSecret:   "super-secret-key",
+		MaxEventsToMerge: 1,
+	}, logger)
+
+	protocol := &mockProtocol{}
+	protocol.On("Connected", mock.Anything).Return(true)
+
+	versions := mocks.NewVersions(t)
+	versions.On("LatestVersionString", mock.Anything).Return("v1.9.0").Maybe()
+
+	m := &manager{
+		store:     s,
+		versions:  versions,
+		logger:    logger,
+		protocols: []Protocol{protocol},
+	}
+
+	agentVersions := map[string]string{"1": "v1.8.0", "2": "v1.8.0", "3": "v1.8.0", "4": "v1.8.0", "5": "v1.8.0", "6": "v1.9.0", "7": "v1.5.0"}
+	for id, version := range agentVersions {
+		version := version
+		_, err := s.UpsertAgent(context.Background(), id, func(agent *model.Agent) {
+			agent.Version = version
+			agent.Labels = model.LabelsFromValidatedMap(map[string]string{"env": "prod"})
+			agent.Status = model.Connected
+		})
+		require.NoError(t, err)
+	}
+
+	return m
+}
+
+// completeTestUpgrade completes the upgrade of the agent with the specified error message
+func completeTestUpgrade(t *testing.T, m *manager, id string, errorMessage string) {
+	_, err := m.store.UpsertAgent(context.Background(), id, func(agent *model.Agent) {
+		agent.UpgradeComplete("", errorMessage)
+	})
+	require.NoError(t, err)
+}
+
+func TestCreateUpgradeCampaign(t *testing.T) {
+	ctx := context.Background()
+	m := upgradeCampaignTestManager(t)
+
+	selector, err := model.SelectorFromString("env=prod")
+	require.NoError(t, err)
+
+	campaign, err := m.CreateUpgradeCampaign(ctx, &model.UpgradeCampaign{Name: "prod", Selector: "env=prod", BatchSize: 2, Concurrency: 1}, store.WithSelector(selector))
+	require.NoError(t, err)
+	require.Equal(t, "v1.9.0", campaign.Version)
+	require.Equal(t, model.UpgradeCampaignRunning, campaign.Status)
+	require.Len(t, campaign.Agents, 5, "agents with the version or without upgrade support are not included")
+	require.Equal(t, []string{"1"}, campaign.AgentIDs(1, model.UpgradeCampaignAgentUpgrading))
+	require.Equal(t, []string{"2"}, campaign.AgentIDs(1, model.UpgradeCampaignAgentPending))
+
+	agent, err := m.store.Agent(ctx, "1")
+	require.NoError(t, err)
+	require.Equal(t, model.Upgrading, agent.Status)
+	require.Equal(t, "v1.9.0", agent.Upgrade.Version)
+
+	_, err = m.CreateUpgradeCampaign(ctx, &model.UpgradeCampaign{Name: "prod"})
+	require.ErrorIs(t, err, ErrUpgradeCampaignExists)
+
+	_, err = m.CreateUpgradeCampaign(ctx, &model.UpgradeCampaign{Name: "invalid", MaxFailurePercent: 200})
+	require.Error(t, err)
+}
+
+func TestUpgradeCampaignBatches(t *testing.T) {
+	ctx := context.Background()
+	m := upgradeCampaignTestManager(t)
+
+	_, err := m.CreateUpgradeCampaign(ctx, &model.UpgradeCampaign{Name: "prod", Version: "v1.9.0", BatchSize: 2, Concurrency: 1, MaxFailurePercent: 25})
+	require.NoError(t, err)
+
+	campaign := func() *model.UpgradeCampaign {
+		campaign, err := m.store.UpgradeCampaign(ctx, "prod")
+		require.NoError(t, err)
+		return campaign
+	}
+
+	// nothing changes while the agent is upgrading
+	m.handleUpgradeCampaigns(ctx)
+	require.Equal(t, []string{"1"}, campaign().AgentIDs(1, model.UpgradeCampaignAgentUpgrading))
+
+	// the next agent in the batch starts when the first completes
+	completeTestUpgrade(t, m, "1", "")
+	m.handleUpgradeCampaigns(ctx)
+	require.Equal(t, []string{"1"}, campaign().AgentIDs(1, model.UpgradeCampaignAgentUpgraded))
+	require.Equal(t, []string{"2"}, campaign().AgentIDs(1, model.UpgradeCampaignAgentUpgrading))
+
+	// the campaign is paused when the failures exceed the threshold
+	completeTestUpgrade(t, m, "2", "download failed")
+	m.handleUpgradeCampaigns(ctx)
+	paused := campaign()
+	require.Equal(t, model.UpgradeCampaignPaused, paused.Status)
+	require.Equal(t, "50% of the agent upgrades failed", paused.Message)
+	require.Equal(t, "download failed", paused.Agents["2"].Error)
+	require.Equal(t, 1, paused.Batch)
+
+	// resuming starts the next batch
+	resumed, err := m.ResumeUpgradeCampaign(ctx, "prod")
+	require.NoError(t, err)
+	require.Equal(t, model.UpgradeCampaignRunning, resumed.Status)
+	require.Equal(t, 2, resumed.Batch)
+	require.Equal(t, []string{"3"}, resumed.AgentIDs(2, model.UpgradeCampaignAgentUpgrading))
+
+	// pausing stops new upgrades from starting
+	_, err = m.PauseUpgradeCampaign(ctx, "prod")
+	require.NoError(t, err)
+	completeTestUpgrade(t, m, "3", "")
+	m.handleUpgradeCampaigns(ctx)
+	require.Equal(t, []string{"3"}, campaign().AgentIDs(2, model.UpgradeCampaignAgentUpgrading))
+
+	_, err = m.ResumeUpgradeCampaign(ctx, "prod")
+	require.NoError(t, err)
+	for _, id := range []string{"3", "4", "5"} {
+		m.handleUpgradeCampaigns(ctx)
+		completeTestUpgrade(t, m, id, "")
+	}
+	m.handleUpgradeCampaigns(ctx)
+	m.handleUpgradeCampaigns(ctx)
+
+	completed := campaign()
+	require.Equal(t, model.UpgradeCampaignCompleted, completed.Status)
+	require.Equal(t, 4, completed.AgentCount(model.UpgradeCampaignAgentUpgraded))
+	require.Equal(t, 1, completed.AgentCount(model.UpgradeCampaignAgentFailed))
+
+	_, err = m.PauseUpgradeCampaign(ctx, "prod")
+	require.ErrorIs(t, err, ErrUpgradeCampaignCompleted)
+	_, err = m.ResumeUpgradeCampaign(ctx, "missing")
+	require.ErrorIs(t, err, store.ErrResourceMissing)
+
+	// a completed campaign can be replaced
+	_, err = m.CreateUpgradeCampaign(ctx, &model.UpgradeCampaign{Name: "prod", Version: "v1.9.0"})
+	require.NoError(t, err)
+}
+
+func TestUpgradeCampaignFailures(t *testing.T) {
+	ctx := context.Background()
+	m := upgradeCampaignTestManager(t)
+
+	campaign := func() *model.UpgradeCampaign {
+		campaign, err := m.store.UpgradeCampaign(ctx, "prod")
+		require.NoError(t, err)
+		return campaign
+	}
+
+	_, err := m.CreateUpgradeCampaign(ctx, &model.UpgradeCampaign{Name: "prod", Version: "v1.9.0", Concurrency: 1, Timeout: time.Minute})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, campaign().AgentIDs(1, model.UpgradeCampaignAgentUpgrading))
+
+	// the threshold is checked before each upgrade starts, not only when the batch is complete
+	completeTestUpgrade(t, m, "1", "download failed")
+	m.handleUpgradeCampaigns(ctx)
+	paused := campaign()
+	require.Equal(t, model.UpgradeCampaignPaused, paused.Status)
+	require.Equal(t, "100% of the agent upgrades failed", paused.Message)
+	require.Empty(t, paused.AgentIDs(1, model.UpgradeCampaignAgentUpgrading))
+	require.Len(t, paused.AgentIDs(1, model.UpgradeCampaignAgentPending), 4)
+
+	// resuming accepts the failure and continues with the next agent
+	resumed, err := m.ResumeUpgradeCampaign(ctx, "prod")
+	require.NoError(t, err)
+	require.Equal(t, model.UpgradeCampaignRunning, resumed.Status)
+	require.Equal(t, []string{"2"}, resumed.AgentIDs(1, model.UpgradeCampaignAgentUpgrading))
+
+	// an agent that does not complete the upgrade within the timeout has failed
+	resumed.Agents["2"].StartedAt = time.Now().Add(-2 * time.Minute)
+	require.NoError(t, m.store.UpsertUpgradeCampaign(ctx, resumed))
+	m.handleUpgradeCampaigns(ctx)
+	timedOut := campaign()
+	require.Equal(t, model.UpgradeCampaignAgentFailed, timedOut.Agents["2"].Status)
+	require.Equal(t, "upgrade did not complete within 1m0s", timedOut.Agents["2"].Error)
+	require.Equal(t, model.UpgradeCampaignPaused, timedOut.Status)
+}
+
+func TestUpgradeCampaignMultipleServers(t *testing.T) {
+	ctx := context.Background()
+	m := upgradeCampaignTestManager(t)
+	m.nodeID = "node-1"
+
+	// agents connected to other servers are upgraded based on their stored status
+	protocol := &mockProtocol{}
+	protocol.On("Connected", mock.Anything).Return(false)
+	m.protocols = []Protocol{protocol}
+
+	_, err := m.store.UpsertAgent(ctx, "2", func(agent *model.Agent) {
+		agent.Status = model.Disconnected
+	})
+	require.NoError(t, err)
+
+	created, err := m.CreateUpgradeCampaign(ctx, &model.UpgradeCampaign{Name: "prod", Version: "v1.9.0", Concurrency: 2})
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "3"}, created.AgentIDs(1, model.UpgradeCampaignAgentUpgrading))
+	require.Equal(t, "agent was not connected", created.Agents["2"].Error)
+
+	// only the server holding the lease advances the campaign
+	acquired, err := m.store.AcquireLease(ctx, UpgradeCampaignLease, "node-2", UpgradeCampaignLeaseTTL)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	completeTestUpgrade(t, m, "1", "")
+	m.handleUpgradeCampaigns(ctx)
+	campaign, err := m.store.UpgradeCampaign(ctx, "prod")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "3"}, campaign.AgentIDs(1, model.UpgradeCampaignAgentUpgrading))
+}
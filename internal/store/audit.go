@@ -0,0 +1,447 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/model"
+)
+
+// userContextKey is the context key for the authenticated user making a request
+type userContextKey struct{}
+
+// ContextWithUser returns a copy of the context with the authenticated user. Changes made by the Store with this context
+// are attributed to the user in the audit log.
+func ContextWithUser(ctx context.Context, user string) context.Context {
+	return context.WithValue(ctx, userContextKey{}, user)
+}
+
+// UserFromContext returns the authenticated user added with ContextWithUser and true if there is one
+func UserFromContext(ctx context.Context) (string, bool) {
+	user, ok := ctx.Value(userContextKey{}).(string)
+	return user, ok
+}
+
+// prepareAuditEntry assigns the ID and the Timestamp to an audit entry that is about to be stored if they have not
+// already been assigned
+func prepareAuditEntry(entry *model.AuditEntry, id string) {
+	if entry.ID == "" {
+		entry.ID = id
+	}
+	if entry.Timestamp.IsZero() {
+		entry.Timestamp = time.Now().UTC()
+	}
+}
+
+// pageAuditEntries applies the Offset and Limit of the filter to entries that have already been filtered
+func pageAuditEntries(entries []*model.AuditEntry, filter model.AuditFilter) []*model.AuditEntry {
+	if filter.Offset > 0 {
+		if filter.Offset >= len(entries) {
+			return nil
+		}
+		entries = entries[filter.Offset:]
+	}
+	if filter.Limit > 0 && filter.Limit < len(entries) {
+		entries = entries[:filter.Limit]
+	}
+	return entries
+}
+
+// ----------------------------------------------------------------------
+
+// auditStore is a Store that records an audit entry for every change made to resources and agents. Changes to agents are
+// only recorded when they are made by an authenticated user because agents report their own status continuously.
+type auditStore struct {
+	Store
+	logger *zap.Logger
+
+	// log receives each entry as a line of JSON and is optional
+	log    io.Writer
+	logMtx sync.Mutex
+}
+
+var _ Store = (*auditStore)(nil)
+
+// NewAuditStore returns a Store that records changes made to the specified Store in its audit log. If log is not nil,
+// each entry is also written to it as a line of JSON.
+func NewAuditStore(s Store, log io.Writer, logger *zap.Logger) Store {
+	return &auditStore{
+		Store:  s,
+		logger: logger,
+		log:    log,
+	}
+}
+
+// ApplyResources applies the resources and records an entry for each resource that is created or configured
+func (s *auditStore) ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+	before := s.currentResources(ctx, resources)
+
+	statuses, err := s.Store.ApplyResources(ctx, resources)
+
+	var entries []*model.AuditEntry
+	for _, status := range statuses {
+		var action model.AuditAction
+		switch status.Status {
+		case model.StatusCreated:
+			action = model.AuditActionCreate
+		case model.StatusConfigured:
+			action = model.AuditActionUpdate
+		default:
+			continue
+		}
+		r := status.Resource
+		entries = s.appendEntry(ctx, entries, action, r.GetKind(), r.Name(), before[string(resourceKey(r.GetKind(), r.Name()))], r)
+	}
+	s.record(ctx, entries)
+
+	return statuses, err
+}
+
+// DeleteResources deletes the resources and records an entry for each resource that is deleted
+func (s *auditStore) DeleteResources(ctx context.Context, resources []model.Resource, options ...DeleteOption) ([]model.ResourceStatus, error) {
+	if opts := makeDeleteOptions(options); opts.planned() {
+		// plan the delete with this store so that the resources applied and deleted by the plan are recorded
+		return deleteResourcesWithOptions(ctx, s, resources, opts)
+	}
+
+	before := s.currentResources(ctx, resources)
+
+	statuses, err := s.Store.DeleteResources(ctx, resources)
+
+	var entries []*model.AuditEntry
+	for _, status := range statuses {
+		if status.Status != model.StatusDeleted {
+			continue
+		}
+		r := status.Resource
+		deleted := before[string(resourceKey(r.GetKind(), r.Name()))]
+		if deleted == nil {
+			deleted = r
+		}
+		entries = s.appendEntry(ctx, entries, model.AuditActionDelete, r.GetKind(), r.Name(), deleted, nil)
+	}
+	s.record(ctx, entries)
+
+	return statuses, err
+}
+
+// DeleteAgentVersion deletes the agent version and records an entry if it is deleted
+func (s *auditStore) DeleteAgentVersion(ctx context.Context, name string) (*model.AgentVersion, error) {
+	deleted, err := s.Store.DeleteAgentVersion(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteConfiguration deletes the configuration and records an entry if it is deleted
+func (s *auditStore) DeleteConfiguration(ctx context.Context, name string) (*model.Configuration, error) {
+	deleted, err := s.Store.DeleteConfiguration(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteSource deletes the source and records an entry if it is deleted
+func (s *auditStore) DeleteSource(ctx context.Context, name string) (*model.Source, error) {
+	deleted, err := s.Store.DeleteSource(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteSourceType deletes the source type and records an entry if it is deleted
+func (s *auditStore) DeleteSourceType(ctx context.Context, name string) (*model.SourceType, error) {
+	deleted, err := s.Store.DeleteSourceType(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteProcessor deletes the processor and records an entry if it is deleted
+func (s *auditStore) DeleteProcessor(ctx context.Context, name string) (*model.Processor, error) {
+	deleted, err := s.Store.DeleteProcessor(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteProcessorType deletes the processor type and records an entry if it is deleted
+func (s *auditStore) DeleteProcessorType(ctx context.Context, name string) (*model.ProcessorType, error) {
+	deleted, err := s.Store.DeleteProcessorType(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteDestination deletes the destination and records an entry if it is deleted
+func (s *auditStore) DeleteDestination(ctx context.Context, name string) (*model.Destination, error) {
+	deleted, err := s.Store.DeleteDestination(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteDestinationType deletes the destination type and records an entry if it is deleted
+func (s *auditStore) DeleteDestinationType(ctx context.Context, name string) (*model.DestinationType, error) {
+	deleted, err := s.Store.DeleteDestinationType(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteExtensionType deletes the extension type and records an entry if it is deleted
+func (s *auditStore) DeleteExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	deleted, err := s.Store.DeleteExtensionType(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteEnrollmentToken deletes the enrollment token and records an entry if it is deleted
+func (s *auditStore) DeleteEnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	deleted, err := s.Store.DeleteEnrollmentToken(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteSecret deletes the secret and records an entry if it is deleted
+func (s *auditStore) DeleteSecret(ctx context.Context, name string) (*model.Secret, error) {
+	deleted, err := s.Store.DeleteSecret(ctx, name)
+	if err == nil && deleted != nil {
+		s.recordDelete(ctx, deleted)
+	}
+	return deleted, err
+}
+
+// DeleteAgents deletes the agents and records an entry for each agent that is deleted
+func (s *auditStore) DeleteAgents(ctx context.Context, agentIDs []string) ([]*model.Agent, error) {
+	deleted, err := s.Store.DeleteAgents(ctx, agentIDs)
+
+	var entries []*model.AuditEntry
+	for _, agent := range deleted {
+		entries = s.appendEntry(ctx, entries, model.AuditActionDelete, model.KindAgent, agent.ID, agent, nil)
+	}
+	s.record(ctx, entries)
+
+	return deleted, err
+}
+
+// UpsertAgent updates the agent and records an entry if the change is made by an authenticated user
+func (s *auditStore) UpsertAgent(ctx context.Context, agentID string, updater AgentUpdater) (*model.Agent, error) {
+	if _, ok := UserFromContext(ctx); !ok {
+		return s.Store.UpsertAgent(ctx, agentID, updater)
+	}
+
+	changes := newAgentChanges()
+	agent, err := s.Store.UpsertAgent(ctx, agentID, changes.updater(updater))
+	s.record(ctx, changes.entries(ctx, s))
+	return agent, err
+}
+
+// UpsertAgents updates the agents and records an entry for each agent if the change is made by an authenticated user
+func (s *auditStore) UpsertAgents(ctx context.Context, agentIDs []string, updater AgentUpdater) ([]*model.Agent, error) {
+	if _, ok := UserFromContext(ctx); !ok {
+		return s.Store.UpsertAgents(ctx, agentIDs, updater)
+	}
+
+	changes := newAgentChanges()
+	agents, err := s.Store.UpsertAgents(ctx, agentIDs, changes.updater(updater))
+	s.record(ctx, changes.entries(ctx, s))
+	return agents, err
+}
+
+// currentResources returns the stored version of each of the resources that exist, keyed by resourceKey
+func (s *auditStore) currentResources(ctx context.Context, resources []model.Resource) map[string]model.Resource {
+	current := map[string]model.Resource{}
+	for _, r := range resources {
+		existing, err := GetResource(ctx, s.Store, r.GetKind(), r.Name())
+		if err != nil {
+			s.logger.Error("failed to get the resource for the audit log", zap.String("kind", string(r.GetKind())), zap.String("name", r.Name()), zap.Error(err))
+			continue
+		}
+		if existing != nil {
+			current[string(resourceKey(r.GetKind(), r.Name()))] = existing
+		}
+	}
+	return current
+}
+
+func (s *auditStore) recordDelete(ctx context.Context, r model.Resource) {
+	s.record(ctx, s.appendEntry(ctx, nil, model.AuditActionDelete, r.GetKind(), r.Name(), r, nil))
+}
+
+// appendEntry appends a new entry for the change to the entries. If the entry cannot be created, the error is logged
+// and the entries are returned unchanged.
+func (s *auditStore) appendEntry(ctx context.Context, entries []*model.AuditEntry, action model.AuditAction, kind model.Kind, name string, before, after any) []*model.AuditEntry {
+	user, _ := UserFromContext(ctx)
+	entry, err := model.NewAuditEntry(user, action, kind, name, redactSecret(before), redactSecret(after))
+	if err != nil {
+		s.logger.Error("failed to create an audit entry", zap.String("kind", string(kind)), zap.String("name", name), zap.Error(err))
+		return entries
+	}
+	return append(entries, entry)
+}
+
+// redactSecret returns the Secret without its value so that the audit log never contains the values of secrets. Other
+// resources are returned unchanged.
+func redactSecret(r any) any {
+	if secret, ok := r.(*model.Secret); ok && secret != nil {
+		return secret.Redacted()
+	}
+	return r
+}
+
+// AddAuditEntries stores the entries and writes them to the log
+func (s *auditStore) AddAuditEntries(ctx context.Context, entries []*model.AuditEntry) error {
+	err := s.Store.AddAuditEntries(ctx, entries)
+	s.writeLog(entries)
+	return err
+}
+
+// record stores the entries and writes them to the log. Failures are logged and do not fail the change that was made.
+func (s *auditStore) record(ctx context.Context, entries []*model.AuditEntry) {
+	if len(entries) == 0 {
+		return
+	}
+	if err := s.AddAuditEntries(ctx, entries); err != nil {
+		s.logger.Error("failed to store audit entries", zap.Error(err))
+	}
+}
+
+// writeLog writes the entries to the log as lines of JSON if there is a log
+func (s *auditStore) writeLog(entries []*model.AuditEntry) {
+	if s.log == nil {
+		return
+	}
+
+	s.logMtx.Lock()
+	defer s.logMtx.Unlock()
+
+	encoder := json.NewEncoder(s.log)
+	for _, entry := range entries {
+		if err := encoder.Encode(entry); err != nil {
+			s.logger.Error("failed to write to the audit log", zap.Error(err))
+			return
+		}
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// agentChanges captures each agent before and after it is modified by an AgentUpdater
+type agentChanges struct {
+	before map[string]*model.Agent
+	after  map[string]*model.Agent
+	ids    []string
+	mtx    sync.Mutex
+}
+
+func newAgentChanges() *agentChanges {
+	return &agentChanges{
+		before: map[string]*model.Agent{},
+		after:  map[string]*model.Agent{},
+	}
+}
+
+// updater returns an AgentUpdater that calls the specified updater and captures the agent before and after
+func (c *agentChanges) updater(updater AgentUpdater) AgentUpdater {
+	return func(current *model.Agent) {
+		before := cloneAgent(current)
+		updater(current)
+		after := cloneAgent(current)
+
+		c.mtx.Lock()
+		defer c.mtx.Unlock()
+		if _, ok := c.before[current.ID]; !ok {
+			c.before[current.ID] = before
+			c.ids = append(c.ids, current.ID)
+		}
+		c.after[current.ID] = after
+	}
+}
+
+// entries returns an entry for each agent that was changed. Labels changes are recorded as label, upgrades are recorded
+// as upgrade, and anything else is recorded as update.
+func (c *agentChanges) entries(ctx context.Context, s *auditStore) []*model.AuditEntry {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	var entries []*model.AuditEntry
+	for _, id := range c.ids {
+		before, after := c.before[id], c.after[id]
+		if before == nil || after == nil {
+			continue
+		}
+
+		action := model.AuditActionUpdate
+		switch {
+		case before.Labels.String() != after.Labels.String():
+			action = model.AuditActionLabel
+		case !agentUpgradesEqual(before.Upgrade, after.Upgrade):
+			action = model.AuditActionUpgrade
+		}
+
+		user, _ := UserFromContext(ctx)
+		entry, err := model.NewAuditEntry(user, action, model.KindAgent, id, before.RedactCredentials(), after.RedactCredentials())
+		if err != nil {
+			s.logger.Error("failed to create an audit entry", zap.String("kind", string(model.KindAgent)), zap.String("name", id), zap.Error(err))
+			continue
+		}
+		if len(entry.Changes) == 0 {
+			// the updater did not change anything
+			continue
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// cloneAgent returns a deep copy of the agent so that it is not modified by the updater
+func cloneAgent(agent *model.Agent) *model.Agent {
+	data, err := json.Marshal(agent)
+	if err != nil {
+		return nil
+	}
+	clone := &model.Agent{}
+	if err := json.Unmarshal(data, clone); err != nil {
+		return nil
+	}
+	return clone
+}
+
+func agentUpgradesEqual(a, b *model.AgentUpgrade) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Status == b.Status && a.Version == b.Version && a.Error == b.Error
+}
@@ -0,0 +1,470 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package backup exports the contents of a store.Store to a portable archive and restores an archive into a
+// store.Store. Because it only uses the store.Store interface, a backup of one type of store can be restored into
+// another.
+package backup
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/internal/store/stats"
+	"github.com/observiq/bindplane-op/internal/version"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// FormatVersion is the version of the archive format written by Backup. Restore will not read archives with a newer
+// format.
+const FormatVersion = 1
+
+// names of the files in the archive
+const (
+	manifestFile     = "manifest.json"
+	resourcesFile    = "resources.json"
+	agentsFile       = "agents.json"
+	measurementsFile = "measurements.json"
+)
+
+// ErrUnsupportedFormat is returned by Restore if the archive was written with a newer format
+var ErrUnsupportedFormat = errors.New("unsupported backup format")
+
+// ErrBackupSecretsKey is returned by Restore if the Secrets in the archive cannot be decrypted with the secrets key of
+// the server that created the backup
+var ErrBackupSecretsKey = errors.New("the secrets in the backup cannot be decrypted with the backup secrets key")
+
+// Options configure the contents of a backup
+type Options struct {
+	// IncludeMeasurements will include the measurements of the store in the backup
+	IncludeMeasurements bool
+}
+
+// RestoreOptions configure how a backup is restored
+type RestoreOptions struct {
+	// SecretsKey is the secrets key of the server that the backup is restored to. Secrets are encrypted with it.
+	SecretsKey string
+
+	// BackupSecretsKey is the secrets key of the server that created the backup. Secrets in the backup are encrypted
+	// with it and are decrypted to be encrypted again with SecretsKey. If empty, SecretsKey is used.
+	BackupSecretsKey string
+}
+
+// Manifest describes the contents of a backup and is the first file in the archive
+type Manifest struct {
+	FormatVersion    int       `json:"formatVersion"`
+	BindPlaneVersion string    `json:"bindplaneVersion"`
+	Created          time.Time `json:"created"`
+	Resources        int       `json:"resources"`
+	Agents           int       `json:"agents"`
+	Measurements     int       `json:"measurements"`
+}
+
+// restoreOrder is the order that resources are restored so that resources are restored before the resources that
+// depend on them
+var restoreOrder = []model.Kind{
+	model.KindAgentVersion,
+	model.KindEnrollmentToken,
+	model.KindSecret,
+	model.KindSourceType,
+	model.KindProcessorType,
+	model.KindDestinationType,
+	model.KindExtensionType,
+	model.KindSource,
+	model.KindProcessor,
+	model.KindDestination,
+	model.KindConfiguration,
+}
+
+// Backup writes every resource, agent, and optionally measurement in the store to w as a gzipped tar archive of json
+// files. Secrets are written with the values encrypted by the secrets key of the server, so the key is required to
+// restore them.
+func Backup(ctx context.Context, s store.Store, w io.Writer, options Options) (*Manifest, error) {
+	resources, err := allResources(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resources: %w", err)
+	}
+
+	agents, err := s.Agents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read agents: %w", err)
+	}
+
+	var measurements stats.MetricData
+	if options.IncludeMeasurements {
+		if m := s.Measurements(); m != nil {
+			measurements, err = m.ExportMeasurements(ctx)
+			if err != nil {
+				return nil, fmt.Errorf("failed to read measurements: %w", err)
+			}
+		}
+	}
+
+	manifest := &Manifest{
+		FormatVersion:    FormatVersion,
+		BindPlaneVersion: version.NewVersion().String(),
+		Created:          time.Now().UTC(),
+		Resources:        len(resources),
+		Agents:           len(agents),
+		Measurements:     len(measurements),
+	}
+
+	resourcesData, err := json.Marshal(resources)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resources: %w", err)
+	}
+	manifestData, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal manifest: %w", err)
+	}
+	agentsData, err := json.Marshal(agents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal agents: %w", err)
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	if err := writeFile(tw, manifestFile, manifestData, manifest.Created); err != nil {
+		return nil, err
+	}
+	if err := writeFile(tw, resourcesFile, resourcesData, manifest.Created); err != nil {
+		return nil, err
+	}
+	if err := writeFile(tw, agentsFile, agentsData, manifest.Created); err != nil {
+		return nil, err
+	}
+	if options.IncludeMeasurements {
+		measurementsData, err := json.Marshal(measurements)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal measurements: %w", err)
+		}
+		if err := writeFile(tw, measurementsFile, measurementsData, manifest.Created); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write backup: %w", err)
+	}
+
+	return manifest, nil
+}
+
+// Restore reads an archive written by Backup and applies its contents to the store. Resources are applied with
+// ApplyResources so existing resources with the same name are updated and a new revision is created. Secrets are
+// encrypted again with the secrets key of the store and ErrBackupSecretsKey is returned before anything is restored if
+// they cannot be decrypted. Agents that were connected when the backup was created are restored as disconnected. The
+// statuses of the applied resources are returned.
+func Restore(ctx context.Context, s store.Store, r io.Reader, options RestoreOptions) (*Manifest, []model.ResourceStatus, error) {
+	files, err := readArchive(r)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	manifestData, ok := files[manifestFile]
+	if !ok {
+		return nil, nil, fmt.Errorf("backup is missing %s", manifestFile)
+	}
+	manifest := &Manifest{}
+	if err := json.Unmarshal(manifestData, manifest); err != nil {
+		return nil, nil, fmt.Errorf("failed to unmarshal %s: %w", manifestFile, err)
+	}
+	if manifest.FormatVersion > FormatVersion {
+		return manifest, nil, fmt.Errorf("%w: %d", ErrUnsupportedFormat, manifest.FormatVersion)
+	}
+
+	// resources
+	var statuses []model.ResourceStatus
+	if data, ok := files[resourcesFile]; ok {
+		statuses, err = restoreResources(ctx, s, data, options)
+		if err != nil {
+			return manifest, statuses, err
+		}
+	}
+
+	// agents
+	if data, ok := files[agentsFile]; ok {
+		if err := restoreAgents(ctx, s, data); err != nil {
+			return manifest, statuses, err
+		}
+	}
+
+	// measurements
+	if data, ok := files[measurementsFile]; ok {
+		if err := restoreMeasurements(ctx, s, data); err != nil {
+			return manifest, statuses, err
+		}
+	}
+
+	return manifest, statuses, nil
+}
+
+// ----------------------------------------------------------------------
+
+func allResources(ctx context.Context, s store.Store) ([]model.Resource, error) {
+	var resources []model.Resource
+
+	agentVersions, err := s.AgentVersions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, agentVersions)
+
+	enrollmentTokens, err := s.EnrollmentTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, enrollmentTokens)
+
+	secrets, err := s.Secrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, secrets)
+
+	sourceTypes, err := s.SourceTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, sourceTypes)
+
+	processorTypes, err := s.ProcessorTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, processorTypes)
+
+	destinationTypes, err := s.DestinationTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, destinationTypes)
+	extensionTypes, err := s.ExtensionTypes(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, extensionTypes)
+
+	sources, err := s.Sources(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, sources)
+
+	processors, err := s.Processors(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, processors)
+
+	destinations, err := s.Destinations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, destinations)
+
+	configurations, err := s.Configurations(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resources = appendResources(resources, configurations)
+
+	return resources, nil
+}
+
+func appendResources[R model.Resource](resources []model.Resource, items []R) []model.Resource {
+	for _, item := range items {
+		resources = append(resources, item)
+	}
+	return resources
+}
+
+func writeFile(tw *tar.Writer, name string, data []byte, modTime time.Time) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0600,
+		Size:    int64(len(data)),
+		ModTime: modTime,
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	if _, err := tw.Write(data); err != nil {
+		return fmt.Errorf("failed to write %s: %w", name, err)
+	}
+	return nil
+}
+
+func readArchive(r io.Reader) (map[string][]byte, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read backup: %w", err)
+	}
+	defer gz.Close()
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read backup: %w", err)
+		}
+		data, err := io.ReadAll(tr)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read %s: %w", header.Name, err)
+		}
+		files[header.Name] = data
+	}
+	return files, nil
+}
+
+func restoreResources(ctx context.Context, s store.Store, data []byte, options RestoreOptions) ([]model.ResourceStatus, error) {
+	var rawResources []json.RawMessage
+	if err := json.Unmarshal(data, &rawResources); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal %s: %w", resourcesFile, err)
+	}
+
+	resources := make([]model.Resource, 0, len(rawResources))
+	for _, raw := range rawResources {
+		resource, err := unmarshalResource(raw)
+		if err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", resourcesFile, err)
+		}
+		resources = append(resources, resource)
+	}
+
+	// the resourceVersion in the backup has no meaning in the store being restored
+	for _, resource := range resources {
+		resource.SetResourceVersion("")
+	}
+
+	// secrets are checked before anything is restored so that a backup with the wrong key is not partially restored
+	for _, resource := range resources {
+		if secret, ok := resource.(*model.Secret); ok {
+			if err := reencryptSecret(secret, options); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	order := map[model.Kind]int{}
+	for i, kind := range restoreOrder {
+		order[kind] = i
+	}
+	sort.SliceStable(resources, func(i, j int) bool {
+		return order[resources[i].GetKind()] < order[resources[j].GetKind()]
+	})
+
+	statuses, err := s.ApplyResources(ctx, resources)
+	if err != nil {
+		return statuses, fmt.Errorf("failed to restore resources: %w", err)
+	}
+	return statuses, nil
+}
+
+// reencryptSecret decrypts the secret with the secrets key of the backup and encrypts it with the secrets key of the
+// store being restored. The secret is unchanged if the keys are the same.
+func reencryptSecret(secret *model.Secret, options RestoreOptions) error {
+	if secret.Spec.EncryptedValue == "" {
+		return nil
+	}
+	backupKey := options.BackupSecretsKey
+	if backupKey == "" {
+		backupKey = options.SecretsKey
+	}
+	value, err := secret.Decrypt(backupKey)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrBackupSecretsKey, err)
+	}
+	if backupKey == options.SecretsKey {
+		return nil
+	}
+	secret.Spec.Value = value
+	secret.Spec.EncryptedValue = ""
+	if err := secret.Encrypt(options.SecretsKey); err != nil {
+		return fmt.Errorf("failed to encrypt secret %s: %w", secret.Name(), err)
+	}
+	return nil
+}
+
+// unmarshalResource uses the kind of the resource to unmarshal it into the correct type
+func unmarshalResource(data []byte) (model.Resource, error) {
+	var meta model.ResourceMeta
+	if err := json.Unmarshal(data, &meta); err != nil {
+		return nil, err
+	}
+	resource, err := model.NewEmptyResource(meta.Kind)
+	if err != nil {
+		return nil, err
+	}
+	return resource, json.Unmarshal(data, resource)
+}
+
+func restoreAgents(ctx context.Context, s store.Store, data []byte) error {
+	var agents []*model.Agent
+	if err := json.Unmarshal(data, &agents); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", agentsFile, err)
+	}
+	if len(agents) == 0 {
+		return nil
+	}
+
+	ids := make([]string, 0, len(agents))
+	agentsByID := make(map[string]*model.Agent, len(agents))
+	for _, agent := range agents {
+		ids = append(ids, agent.ID)
+		agentsByID[agent.ID] = agent
+	}
+
+	_, err := s.UpsertAgents(ctx, ids, func(current *model.Agent) {
+		*current = *agentsByID[current.ID]
+		if current.Status != model.Disconnected {
+			current.Disconnect()
+		}
+	})
+	if err != nil {
+		return fmt.Errorf("failed to restore agents: %w", err)
+	}
+	return nil
+}
+
+func restoreMeasurements(ctx context.Context, s store.Store, data []byte) error {
+	var measurements stats.MetricData
+	if err := json.Unmarshal(data, &measurements); err != nil {
+		return fmt.Errorf("failed to unmarshal %s: %w", measurementsFile, err)
+	}
+	m := s.Measurements()
+	if len(measurements) == 0 || m == nil {
+		return nil
+	}
+	if err := m.SaveAgentMetrics(ctx, measurements); err != nil {
+		return fmt.Errorf("failed to restore measurements: %w", err)
+	}
+	return nil
+}
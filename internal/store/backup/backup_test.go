@@ -0,0 +1,277 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package backup
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/internal/otlp/record"
+	"github.com/observiq/bindplane-op/internal/store"
+	"github.com/observiq/bindplane-op/internal/store/stats"
+	"github.com/observiq/bindplane-op/model"
+)
+
+var testOptions = store.Options{
+	SessionsSecret:   "super-secret-key",
+	MaxEventsToMerge: 1,
+}
+
+func newTestBoltStore(t *testing.T) store.Store {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	db, err := store.InitDB(filepath.Join(t.TempDir(), "storage"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+
+	return store.NewBoltStore(ctx, db, testOptions, zap.NewNop())
+}
+
+func newTestMapStore(t *testing.T) store.Store {
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	return store.NewMapStore(ctx, testOptions, zap.NewNop())
+}
+
+// populate adds resource types, a destination, a configuration, two agents, and measurements to the store
+func populate(t *testing.T, s store.Store) {
+	ctx := context.Background()
+	require.NoError(t, store.Seed(ctx, s, zap.NewNop()))
+
+	destination := model.NewDestination("otlp", "otlp_grpc", []model.Parameter{
+		{Name: "hostname", Value: "localhost"},
+	})
+	configuration := model.NewConfigurationWithSpec("config", model.ConfigurationSpec{
+		Destinations: []model.ResourceConfiguration{{Name: "otlp"}},
+	})
+	statuses, err := s.ApplyResources(ctx, []model.Resource{destination, configuration})
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.Equal(t, model.StatusCreated, status.Status, status.Reason)
+	}
+
+	_, err = s.UpsertAgent(ctx, "1", func(current *model.Agent) {
+		current.Name = "connected"
+		current.Labels = model.LabelsFromValidatedMap(map[string]string{"configuration": "config"})
+		current.Status = model.Connected
+	})
+	require.NoError(t, err)
+	_, err = s.UpsertAgent(ctx, "2", func(current *model.Agent) {
+		current.Name = "disconnected"
+		current.Disconnect()
+	})
+	require.NoError(t, err)
+
+	now := time.Now().UTC().Truncate(10 * time.Second)
+	metrics := stats.MetricData{}
+	for i := 0; i < 3; i++ {
+		metrics = append(metrics, &record.Metric{
+			Name:      stats.LogDataSizeMetricName,
+			Timestamp: now.Add(time.Duration(-i*10) * time.Second),
+			Value:     float64(100 * (3 - i)),
+			Type:      "Sum",
+			Attributes: map[string]interface{}{
+				stats.AgentAttributeName:         "1",
+				stats.ConfigurationAttributeName: "config",
+				stats.ProcessorAttributeName:     "throughputmeasurement/_d1_logs_otlp",
+			},
+		})
+	}
+	require.NoError(t, s.Measurements().SaveAgentMetrics(ctx, metrics))
+}
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+
+	source := newTestBoltStore(t)
+	populate(t, source)
+
+	sourceResources, err := allResources(ctx, source)
+	require.NoError(t, err)
+
+	tests := []struct {
+		name                string
+		target              store.Store
+		includeMeasurements bool
+		expectMeasurements  int
+	}{
+		{
+			name:                "bbolt to bbolt with measurements",
+			target:              newTestBoltStore(t),
+			includeMeasurements: true,
+			expectMeasurements:  3,
+		},
+		{
+			name:                "bbolt to bbolt without measurements",
+			target:              newTestBoltStore(t),
+			includeMeasurements: false,
+			expectMeasurements:  0,
+		},
+		{
+			name:                "bbolt to map",
+			target:              newTestMapStore(t),
+			includeMeasurements: true,
+			expectMeasurements:  0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			manifest, err := Backup(ctx, source, &buffer, Options{IncludeMeasurements: test.includeMeasurements})
+			require.NoError(t, err)
+			require.Equal(t, FormatVersion, manifest.FormatVersion)
+			require.Equal(t, len(sourceResources), manifest.Resources)
+			require.Equal(t, 2, manifest.Agents)
+			if test.includeMeasurements {
+				require.Equal(t, 3, manifest.Measurements)
+			}
+
+			restored, statuses, err := Restore(ctx, test.target, &buffer, RestoreOptions{})
+			require.NoError(t, err)
+			require.Equal(t, manifest.Resources, restored.Resources)
+			require.Len(t, statuses, len(sourceResources))
+			for _, status := range statuses {
+				require.Equal(t, model.StatusCreated, status.Status, status.Reason)
+			}
+
+			// resources
+			targetResources, err := allResources(ctx, test.target)
+			require.NoError(t, err)
+			require.Len(t, targetResources, len(sourceResources))
+
+			configuration, err := test.target.Configuration(ctx, "config")
+			require.NoError(t, err)
+			require.NotNil(t, configuration)
+			sourceConfiguration, err := source.Configuration(ctx, "config")
+			require.NoError(t, err)
+			require.Equal(t, sourceConfiguration.ID(), configuration.ID())
+			require.Equal(t, sourceConfiguration.Spec, configuration.Spec)
+
+			// agents
+			agents, err := test.target.Agents(ctx)
+			require.NoError(t, err)
+			require.Len(t, agents, 2)
+			for _, agent := range agents {
+				require.Equal(t, model.Disconnected, agent.Status, agent.Name)
+			}
+			agentConfiguration, err := test.target.AgentConfiguration(ctx, "1")
+			require.NoError(t, err)
+			require.NotNil(t, agentConfiguration)
+			require.Equal(t, "config", agentConfiguration.Name())
+
+			// measurements
+			measurements, err := test.target.Measurements().ExportMeasurements(ctx)
+			require.NoError(t, err)
+			require.Len(t, measurements, test.expectMeasurements)
+		})
+	}
+}
+
+func TestRestoreExisting(t *testing.T) {
+	ctx := context.Background()
+
+	source := newTestBoltStore(t)
+	populate(t, source)
+
+	var buffer bytes.Buffer
+	_, err := Backup(ctx, source, &buffer, Options{})
+	require.NoError(t, err)
+
+	// restoring into the same store leaves everything unchanged
+	_, statuses, err := Restore(ctx, source, &buffer, RestoreOptions{})
+	require.NoError(t, err)
+	for _, status := range statuses {
+		require.Equal(t, model.StatusUnchanged, status.Status, status.Reason)
+	}
+}
+
+func TestRestoreUnsupportedFormat(t *testing.T) {
+	var buffer bytes.Buffer
+	gz := gzip.NewWriter(&buffer)
+	tw := tar.NewWriter(gz)
+	data, err := json.Marshal(Manifest{FormatVersion: FormatVersion + 1})
+	require.NoError(t, err)
+	require.NoError(t, writeFile(tw, manifestFile, data, time.Now()))
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	_, _, err = Restore(context.Background(), newTestMapStore(t), &buffer, RestoreOptions{})
+	require.ErrorIs(t, err, ErrUnsupportedFormat)
+}
+
+func TestRestoreInvalidArchive(t *testing.T) {
+	_, _, err := Restore(context.Background(), newTestMapStore(t), bytes.NewBufferString("not a backup"), RestoreOptions{})
+	require.Error(t, err)
+}
+
+func TestRestoreSecrets(t *testing.T) {
+	ctx := context.Background()
+
+	source := newTestMapStore(t)
+	secret := model.NewSecret("token", "s3cr3t")
+	require.NoError(t, secret.Encrypt("source-key"))
+	_, err := source.ApplyResources(ctx, []model.Resource{secret})
+	require.NoError(t, err)
+
+	var archive bytes.Buffer
+	_, err = Backup(ctx, source, &archive, Options{})
+	require.NoError(t, err)
+
+	restoreSecret := func(t *testing.T, options RestoreOptions) (*model.Secret, error) {
+		target := newTestMapStore(t)
+		_, _, err := Restore(ctx, target, bytes.NewReader(archive.Bytes()), options)
+		if err != nil {
+			return nil, err
+		}
+		return target.Secret(ctx, "token")
+	}
+
+	t.Run("secrets are restored with the same key", func(t *testing.T) {
+		restored, err := restoreSecret(t, RestoreOptions{SecretsKey: "source-key"})
+		require.NoError(t, err)
+		require.Equal(t, secret.Spec.EncryptedValue, restored.Spec.EncryptedValue)
+	})
+
+	t.Run("secrets are encrypted again with the key of the server", func(t *testing.T) {
+		restored, err := restoreSecret(t, RestoreOptions{SecretsKey: "target-key", BackupSecretsKey: "source-key"})
+		require.NoError(t, err)
+		require.Empty(t, restored.Spec.Value)
+		value, err := restored.Decrypt("target-key")
+		require.NoError(t, err)
+		require.Equal(t, "s3cr3t", value)
+	})
+
+	t.Run("nothing is restored with the wrong backup key", func(t *testing.T) {
+		target := newTestMapStore(t)
+		_, _, err := Restore(ctx, target, bytes.NewReader(archive.Bytes()), RestoreOptions{SecretsKey: "target-key"})
+		require.ErrorIs(t, err, ErrBackupSecretsKey)
+
+		secrets, err := target.Secrets(ctx)
+		require.NoError(t, err)
+		require.Empty(t, secrets)
+	})
+}
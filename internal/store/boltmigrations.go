@@ -0,0 +1,325 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/internal/store/search"
+	"github.com/observiq/bindplane-op/model"
+)
+
+const (
+	bucketMeta = "Meta"
+
+	metaKeySchemaVersion = "schemaVersion"
+)
+
+// BoltMigration is a change to the data stored in bbolt. Migrations are applied in order of Version and the schema
+// version of the database is updated in the same transaction as each migration.
+type BoltMigration struct {
+	// Version is the schema version of the database after the migration is applied
+	Version int
+	// Description is a short description of the migration displayed by bindplane migrate
+	Description string
+
+	migrate func(tx *bbolt.Tx) error
+}
+
+// boltMigrations are all of the migrations in order. New migrations must be added to the end with the next version.
+var boltMigrations = []BoltMigration{
+	{
+		Version:     1,
+		Description: "restore case sensitive resource keys",
+		migrate:     migrateBackToCaseSensitive,
+	},
+	{
+		Version:     2,
+		Description: "add revision history for existing resources",
+		migrate:     migrateRevisionHistory,
+	},
+}
+
+// LatestBoltSchemaVersion is the schema version of the database after all migrations are applied
+func LatestBoltSchemaVersion() int {
+	return boltMigrations[len(boltMigrations)-1].Version
+}
+
+// BoltSchemaVersion returns the schema version of the database. A database that has never been migrated has version 0.
+func BoltSchemaVersion(db *bbolt.DB) (int, error) {
+	version := 0
+	err := db.View(func(tx *bbolt.Tx) error {
+		version = schemaVersion(tx)
+		return nil
+	})
+	return version, err
+}
+
+// PendingBoltMigrations returns the migrations that have not been applied to the database
+func PendingBoltMigrations(db *bbolt.DB) ([]BoltMigration, error) {
+	version, err := BoltSchemaVersion(db)
+	if err != nil {
+		return nil, err
+	}
+	return pendingBoltMigrations(version)
+}
+
+func pendingBoltMigrations(version int) ([]BoltMigration, error) {
+	if latest := LatestBoltSchemaVersion(); version > latest {
+		return nil, fmt.Errorf("storage schema version %d is newer than the latest supported version %d", version, latest)
+	}
+
+	var pending []BoltMigration
+	for _, migration := range boltMigrations {
+		if migration.Version > version {
+			pending = append(pending, migration)
+		}
+	}
+	return pending, nil
+}
+
+// MigrateBolt applies any pending migrations to the database and returns the migrations that were applied. If the
+// database contains resources or agents, a copy of the database is saved next to the storage file before any
+// migrations are applied and the path of the copy is returned.
+func MigrateBolt(db *bbolt.DB, logger *zap.Logger) (applied []BoltMigration, backupPath string, err error) {
+	version, err := BoltSchemaVersion(db)
+	if err != nil {
+		return nil, "", err
+	}
+	pending, err := pendingBoltMigrations(version)
+	if err != nil || len(pending) == 0 {
+		return nil, "", err
+	}
+
+	backupPath, err = backupBolt(db, version)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to backup storage before migrating: %w", err)
+	}
+	if backupPath != "" {
+		logger.Info("Saved a backup of storage before migrating", zap.String("path", backupPath))
+	}
+
+	for _, migration := range pending {
+		err := db.Update(func(tx *bbolt.Tx) error {
+			if err := migration.migrate(tx); err != nil {
+				return err
+			}
+			return setSchemaVersion(tx, migration.Version)
+		})
+		if err != nil {
+			return applied, backupPath, fmt.Errorf("migration %d (%s) failed: %w", migration.Version, migration.Description, err)
+		}
+		logger.Info("Migrated storage", zap.Int("version", migration.Version), zap.String("description", migration.Description))
+		applied = append(applied, migration)
+	}
+
+	// migrations may change resources without updating the persisted search indexes, so remove them to be rebuilt
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if tx.Bucket([]byte(search.BoltIndexBucket)) == nil {
+			return nil
+		}
+		return tx.DeleteBucket([]byte(search.BoltIndexBucket))
+	})
+	if err != nil {
+		return applied, backupPath, fmt.Errorf("failed to remove search indexes after migrating: %w", err)
+	}
+
+	return applied, backupPath, nil
+}
+
+// backupBolt copies the database to a file next to the storage file. Empty databases are not copied and an empty path
+// is returned.
+func backupBolt(db *bbolt.DB, version int) (string, error) {
+	path := ""
+	err := db.View(func(tx *bbolt.Tx) error {
+		if isEmptyBucket(resourcesBucket(tx)) && isEmptyBucket(agentBucket(tx)) {
+			return nil
+		}
+		path = fmt.Sprintf("%s.v%d-%s.backup", db.Path(), version, time.Now().UTC().Format("20060102150405"))
+		return tx.CopyFile(path, 0600)
+	})
+	return path, err
+}
+
+func isEmptyBucket(b *bbolt.Bucket) bool {
+	if b == nil {
+		return true
+	}
+	k, _ := b.Cursor().First()
+	return k == nil
+}
+
+func schemaVersion(tx *bbolt.Tx) int {
+	b := tx.Bucket([]byte(bucketMeta))
+	if b == nil {
+		return 0
+	}
+	data := b.Get([]byte(metaKeySchemaVersion))
+	if len(data) != 8 {
+		return 0
+	}
+	return int(binary.BigEndian.Uint64(data))
+}
+
+func setSchemaVersion(tx *bbolt.Tx, version int) error {
+	b, err := tx.CreateBucketIfNotExists([]byte(bucketMeta))
+	if err != nil {
+		return err
+	}
+	data := make([]byte, 8)
+	binary.BigEndian.PutUint64(data, uint64(version))
+	return b.Put([]byte(metaKeySchemaVersion), data)
+}
+
+// ----------------------------------------------------------------------
+// migrations
+
+func getNameFromResource[R model.Resource](v []byte) (string, error) {
+	var resource R
+	if err := json.Unmarshal(v, &resource); err != nil {
+		return "", err
+	}
+	return resource.Name(), nil
+}
+
+// migrateBackToCaseSensitive fixes keys that were forcefully lowercased
+func migrateBackToCaseSensitive(tx *bbolt.Tx) error {
+	bucket := resourcesBucket(tx)
+	cursor := bucket.Cursor()
+
+	for k, v := cursor.Seek(nil); k != nil; k, v = cursor.Next() {
+		oldKey := string(k)
+		kind, _, found := strings.Cut(oldKey, "|")
+		if !found {
+			continue
+		}
+		foundKind := model.ParseKind(string(kind))
+		if foundKind == model.KindUnknown {
+			continue
+		}
+
+		var foundName string
+		var err error
+		switch foundKind {
+		case model.KindAgentVersion:
+			foundName, err = getNameFromResource[*model.AgentVersion](v)
+		case model.KindConfiguration:
+			foundName, err = getNameFromResource[*model.Configuration](v)
+		case model.KindSource:
+			foundName, err = getNameFromResource[*model.Source](v)
+		case model.KindSourceType:
+			foundName, err = getNameFromResource[*model.SourceType](v)
+		case model.KindProcessor:
+			foundName, err = getNameFromResource[*model.Processor](v)
+		case model.KindProcessorType:
+			foundName, err = getNameFromResource[*model.ProcessorType](v)
+		case model.KindDestination:
+			foundName, err = getNameFromResource[*model.Destination](v)
+		case model.KindDestinationType:
+			foundName, err = getNameFromResource[*model.DestinationType](v)
+		case model.KindExtensionType:
+			foundName, err = getNameFromResource[*model.ExtensionType](v)
+		case model.KindEnrollmentToken:
+			foundName, err = getNameFromResource[*model.EnrollmentToken](v)
+		case model.KindSecret:
+			foundName, err = getNameFromResource[*model.Secret](v)
+		}
+
+		if err != nil {
+			continue
+		}
+
+		newKey := fmt.Sprintf("%s|%s", foundKind, foundName)
+		if newKey != oldKey {
+			err := cursor.Delete()
+			if err != nil {
+				return err
+			}
+			err = bucket.Put([]byte(newKey), v)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// migrateRevisionHistory stores the current version of resources created before revision history was added as their
+// first revision
+func migrateRevisionHistory(tx *bbolt.Tx) error {
+	bucket := resourcesBucket(tx)
+
+	type update struct {
+		key  []byte
+		data []byte
+	}
+	var updates []update
+
+	err := bucket.ForEach(func(k, v []byte) error {
+		var resource model.AnyResource
+		if err := json.Unmarshal(v, &resource); err != nil {
+			// skip resources that cannot be read, they will be rewritten when they are next applied
+			return nil
+		}
+		if latestRevision(tx, resource.GetKind(), resource.Name()) > 0 {
+			return nil
+		}
+
+		revision := resource.Revision()
+		if revision == 0 {
+			revision = 1
+		}
+
+		data := v
+		if resource.Revision() == 0 {
+			// set the revision without changing the rest of the resource
+			var fields map[string]any
+			if err := json.Unmarshal(v, &fields); err != nil {
+				return nil
+			}
+			metadata, _ := fields["metadata"].(map[string]any)
+			if metadata == nil {
+				return nil
+			}
+			metadata["revision"] = revision
+			var err error
+			if data, err = json.Marshal(fields); err != nil {
+				return err
+			}
+		}
+
+		updates = append(updates, update{key: append([]byte{}, k...), data: data})
+		return revisionsBucket(tx).Put(revisionKey(resource.GetKind(), resource.Name(), revision), data)
+	})
+	if err != nil {
+		return err
+	}
+
+	// resources cannot be modified while iterating with ForEach
+	for _, u := range updates {
+		if err := bucket.Put(u.key, u.data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
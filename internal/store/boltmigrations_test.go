@@ -0,0 +1,191 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/internal/store/search"
+	"github.com/observiq/bindplane-op/model"
+)
+
+func migrationVersions(migrations []BoltMigration) []int {
+	var versions []int
+	for _, migration := range migrations {
+		versions = append(versions, migration.Version)
+	}
+	return versions
+}
+
+func initMigrationTestDB(t *testing.T) *bbolt.DB {
+	db, err := InitDB(filepath.Join(t.TempDir(), "storage"))
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func TestMigrateBoltEmpty(t *testing.T) {
+	db := initMigrationTestDB(t)
+
+	pending, err := PendingBoltMigrations(db)
+	require.NoError(t, err)
+	require.Equal(t, migrationVersions(boltMigrations), migrationVersions(pending))
+
+	applied, backupPath, err := MigrateBolt(db, zap.NewNop())
+	require.NoError(t, err)
+	require.Len(t, applied, len(boltMigrations))
+	require.Empty(t, backupPath, "empty databases should not be backed up")
+
+	version, err := BoltSchemaVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, LatestBoltSchemaVersion(), version)
+
+	// nothing left to do
+	pending, err = PendingBoltMigrations(db)
+	require.NoError(t, err)
+	require.Empty(t, pending)
+
+	applied, _, err = MigrateBolt(db, zap.NewNop())
+	require.NoError(t, err)
+	require.Empty(t, applied)
+}
+
+func TestMigrateBoltLegacy(t *testing.T) {
+	db := initMigrationTestDB(t)
+
+	// a configuration stored with a lowercase key and no revision by an old version
+	configuration := model.NewRawConfiguration("MyConfig", "receivers:")
+	configuration.SetID("1")
+	data, err := json.Marshal(configuration)
+	require.NoError(t, err)
+	require.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		return resourcesBucket(tx).Put([]byte("configuration|myconfig"), data)
+	}))
+
+	// a persisted search index that does not contain the configuration
+	_, err = search.NewBoltIndex(db, "configuration")
+	require.NoError(t, err)
+
+	pending, err := PendingBoltMigrations(db)
+	require.NoError(t, err)
+	require.Len(t, pending, 2)
+
+	applied, backupPath, err := MigrateBolt(db, zap.NewNop())
+	require.NoError(t, err)
+	require.Equal(t, migrationVersions(pending), migrationVersions(applied))
+
+	// the search indexes are removed to be rebuilt
+	require.NoError(t, db.View(func(tx *bbolt.Tx) error {
+		require.Nil(t, tx.Bucket([]byte(search.BoltIndexBucket)))
+		return nil
+	}))
+
+	// the backup contains the database before the migration
+	require.NotEmpty(t, backupPath)
+	backup, err := bbolt.Open(backupPath, 0600, &bbolt.Options{ReadOnly: true})
+	require.NoError(t, err)
+	defer backup.Close()
+	version, err := BoltSchemaVersion(backup)
+	require.NoError(t, err)
+	require.Equal(t, 0, version)
+	require.NoError(t, backup.View(func(tx *bbolt.Tx) error {
+		require.NotNil(t, resourcesBucket(tx).Get([]byte("configuration|myconfig")))
+		return nil
+	}))
+	require.NoError(t, os.Remove(backupPath))
+
+	version, err = BoltSchemaVersion(db)
+	require.NoError(t, err)
+	require.Equal(t, 2, version)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+
+	// the key is case sensitive and the resource has a revision
+	migrated, err := store.Configuration(ctx, "MyConfig")
+	require.NoError(t, err)
+	require.NotNil(t, migrated)
+	require.Equal(t, 1, migrated.Revision())
+	require.Equal(t, "1", migrated.ID())
+
+	revisions, err := store.ResourceRevisions(ctx, model.KindConfiguration, "MyConfig")
+	require.NoError(t, err)
+	require.Len(t, revisions, 1)
+	require.Equal(t, 1, revisions[0].Revision())
+
+	// a change to the migrated resourceVersion is applied
+	update := model.NewRawConfiguration("MyConfig", "receivers: {}")
+	update.SetResourceVersion(model.RevisionResourceVersion(migrated.Revision()))
+	statuses, err := store.ApplyResources(ctx, []model.Resource{update})
+	require.NoError(t, err)
+	require.Equal(t, model.StatusConfigured, statuses[0].Status)
+	require.Equal(t, 2, statuses[0].Resource.Revision())
+}
+
+func TestMigrateBoltNewerVersion(t *testing.T) {
+	db := initMigrationTestDB(t)
+	require.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		return setSchemaVersion(tx, LatestBoltSchemaVersion()+1)
+	}))
+
+	_, err := PendingBoltMigrations(db)
+	require.Error(t, err)
+
+	_, _, err = MigrateBolt(db, zap.NewNop())
+	require.Error(t, err)
+}
+
+func TestNewStoreRefusesNewerVersion(t *testing.T) {
+	storageFilePath := filepath.Join(t.TempDir(), "storage")
+	db, err := InitDB(storageFilePath)
+	require.NoError(t, err)
+	require.NoError(t, db.Update(func(tx *bbolt.Tx) error {
+		return setSchemaVersion(tx, LatestBoltSchemaVersion()+1)
+	}))
+	require.NoError(t, db.Close())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	config := &common.Server{
+		StoreType:       common.StoreTypeBbolt,
+		StorageFilePath: storageFilePath,
+	}
+	_, err = NewStore(ctx, config, zap.NewNop())
+	require.ErrorContains(t, err, "newer than the latest supported version")
+
+	// the storage file is closed so that it is not locked after startup fails
+	db, err = bbolt.Open(storageFilePath, 0600, &bbolt.Options{Timeout: time.Second})
+	require.NoError(t, err)
+	require.NoError(t, db.Close())
+}
+
+func TestBoltMigrationVersions(t *testing.T) {
+	for i, migration := range boltMigrations {
+		require.Equal(t, i+1, migration.Version, "migrations must be in order with no gaps")
+		require.NotEmpty(t, migration.Description)
+		require.NotNil(t, migration.migrate)
+	}
+}
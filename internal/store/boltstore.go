@@ -21,6 +21,7 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -43,6 +44,13 @@ const (
 	bucketTasks        = "Tasks"
 	bucketAgents       = "Agents"
 	bucketMeasurements = "Measurements"
+	bucketRevisions    = "Revisions"
+	bucketAudit        = "Audit"
+	bucketAgentHistory = "AgentHistory"
+	bucketRollouts     = "Rollouts"
+	bucketCampaigns    = "UpgradeCampaigns"
+	bucketTokenUses    = "EnrollmentTokenUses"
+	bucketRevoked      = "RevokedAgents"
 )
 
 type boltstore struct {
@@ -50,21 +58,30 @@ type boltstore struct {
 	updates            *storeUpdates
 	agentIndex         search.Index
 	configurationIndex search.Index
+	resourceIndexes    resourceIndexes
 	logger             *zap.Logger
+	leases             *localLeases
 	sync.RWMutex
 	sessionStorage sessions.Store
 }
 
 var _ Store = (*boltstore)(nil)
 
-// NewBoltStore returns a new store boltstore struct that implements the store.Store interface.
+// NewBoltStore returns a new store boltstore struct that implements the store.Store interface. Migrations must be
+// applied to the database with MigrateBolt before it is used.
 func NewBoltStore(ctx context.Context, db *bbolt.DB, options Options, logger *zap.Logger) Store {
+	newIndex := func(name string) search.Index {
+		return newBoltIndex(db, name, logger)
+	}
+
 	store := &boltstore{
 		db:                 db,
 		updates:            newStoreUpdates(ctx, options.MaxEventsToMerge),
-		agentIndex:         search.NewInMemoryIndex("agent"),
-		configurationIndex: search.NewInMemoryIndex("configuration"),
+		agentIndex:         newIndex("agent"),
+		configurationIndex: newIndex("configuration"),
+		resourceIndexes:    newResourceIndexes(newIndex),
 		logger:             logger,
+		leases:             newLocalLeases(),
 
 		sessionStorage: newBPCookieStore(options.SessionsSecret),
 	}
@@ -75,19 +92,34 @@ func NewBoltStore(ctx context.Context, db *bbolt.DB, options Options, logger *za
 	// start the timer that runs cleanup on measurements
 	store.startMeasurements(ctx)
 
-	// run the migration that fixes keys that were forcefully lowercased
-	err := store.migrateBackToCaseSensitive(context.Background())
+	return store
+}
+
+// newBoltIndex returns a search index persisted in the database. If the persisted index cannot be used, an in-memory
+// index is returned and will be seeded when the server starts.
+func newBoltIndex(db *bbolt.DB, name string, logger *zap.Logger) search.Index {
+	index, err := search.NewBoltIndex(db, name)
 	if err != nil {
-		logger.Error("failed to migrateBackToCaseSensitive", zap.Error(err))
+		logger.Error("failed to load the persisted search index, using an in-memory index", zap.String("index", name), zap.Error(err))
+		return search.NewInMemoryIndex(name)
 	}
-
-	return store
+	if !index.Loaded() {
+		logger.Info("Search index will be rebuilt", zap.String("index", name))
+	}
+	return index
 }
 
+// boltOpenTimeout is how long InitDB waits for the lock on the storage file, which is held by any other process that
+// has it open
+var boltOpenTimeout = 5 * time.Second
+
 // InitDB takes in the full path to a storage file and returns an opened bbolt database.
-// It will return an error if the file cannot be opened.
+// It will return an error if the file cannot be opened or is locked by another process.
 func InitDB(storageFilePath string) (*bbolt.DB, error) {
-	var db, err = bbolt.Open(storageFilePath, 0640, nil)
+	var db, err = bbolt.Open(storageFilePath, 0640, &bbolt.Options{Timeout: boltOpenTimeout})
+	if errors.Is(err, bbolt.ErrTimeout) {
+		return nil, fmt.Errorf("bbolt storage file %s is locked by another process, stop the BindPlane server using it and try again: %w", storageFilePath, err)
+	}
 	if err != nil {
 		return nil, fmt.Errorf("error while opening bbolt storage file: %s, %w", storageFilePath, err)
 	}
@@ -97,6 +129,14 @@ func InitDB(storageFilePath string) (*bbolt.DB, error) {
 		bucketTasks,
 		bucketAgents,
 		bucketMeasurements,
+		bucketRevisions,
+		bucketAudit,
+		bucketAgentHistory,
+		bucketRollouts,
+		bucketCampaigns,
+		bucketTokenUses,
+		bucketRevoked,
+		bucketMeta,
 	}
 
 	err = db.Update(func(tx *bbolt.Tx) error {
@@ -181,7 +221,11 @@ func (s *boltstore) Updates() eventbus.Source[*Updates] {
 // DeleteResources iterates threw a slice of resources, and removes them from storage by name.
 // Sends any successful pipeline deletes to the pipelineDeletes channel, to be handled by the manager.
 // Exporter and receiver deletes are sent to the manager via notifyUpdates.
-func (s *boltstore) DeleteResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+func (s *boltstore) DeleteResources(ctx context.Context, resources []model.Resource, options ...DeleteOption) ([]model.ResourceStatus, error) {
+	if opts := makeDeleteOptions(options); opts.planned() {
+		return deleteResourcesWithOptions(ctx, s, resources, opts)
+	}
+
 	updates := NewUpdates()
 
 	// track deleteStatuses to return
@@ -194,7 +238,11 @@ func (s *boltstore) DeleteResources(ctx context.Context, resources []model.Resou
 			continue
 		}
 
-		deleted, exists, err := deleteResource(ctx, s, r.GetKind(), r.Name(), empty)
+		deleted, exists, err := deleteResource(ctx, s, r.GetKind(), r.Name(), r.ResourceVersion(), empty)
+		if errors.Is(err, ErrResourceConflict) {
+			deleteStatuses = append(deleteStatuses, *model.NewResourceStatusWithReason(r, model.StatusConflict, err.Error()))
+			continue
+		}
 
 		switch err.(type) {
 		case *DependencyError:
@@ -226,6 +274,11 @@ func (s *boltstore) DeleteResources(ctx context.Context, resources []model.Resou
 // Apply resources iterates through a slice of resources, then adds them to storage,
 // and calls notify updates on the updated resources.
 func (s *boltstore) ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+	// copies are applied so that the resourceVersion of the resources of the caller is unchanged
+	copies := copyResources(resources)
+	defer assignResources(resources, copies)
+	resources = copies
+
 	updates := NewUpdates()
 
 	// resourceStatuses to return for the applied resources
@@ -243,41 +296,478 @@ func (s *boltstore) ApplyResources(ctx context.Context, resources []model.Resour
 			continue
 		}
 
+		// the status and update are only recorded once the transaction is committed because it is rolled back if the
+		// index cannot be updated
+		var status model.UpdateStatus
 		err = s.db.Update(func(tx *bbolt.Tx) error {
 			// update the resource in the database
-			status, err := upsertResource(tx, resource, resource.GetKind())
+			var err error
+			status, err = upsertResource(tx, resource, resource.GetKind())
+			if err != nil {
+				return err
+			}
+
+			// update the index in the same transaction so that a persisted index cannot diverge from the resources
+			return s.upsertIndexTx(tx, s.ResourceIndex(ctx, resource.GetKind()), resource)
+		})
+		switch {
+		case errors.Is(err, ErrResourceConflict):
+			resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, model.StatusConflict, err.Error()))
+			continue
+		case err != nil:
+			resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, model.StatusError, err.Error()))
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, status, warn))
+
+		switch status {
+		case model.StatusCreated:
+			updates.IncludeResource(resource, EventTypeInsert)
+		case model.StatusConfigured:
+			updates.IncludeResource(resource, EventTypeUpdate)
+		}
+	}
+
+	s.notify(ctx, updates)
+
+	return resourceStatuses, errs
+}
+
+// ResourceRevisions returns every revision of the resource with the specified kind and name, oldest first.
+func (s *boltstore) ResourceRevisions(ctx context.Context, kind model.Kind, name string) ([]model.Resource, error) {
+	var revisions []model.Resource
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := revisionsBucket(tx)
+		if bucket == nil {
+			return nil
+		}
+
+		prefix := revisionsPrefix(kind, name)
+		cursor := bucket.Cursor()
+
+		for k, v := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = cursor.Next() {
+			revision, err := model.NewEmptyResource(kind)
 			if err != nil {
-				resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, model.StatusError, err.Error()))
 				return err
 			}
-			resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, status, warn))
+			if err := json.Unmarshal(v, revision); err != nil {
+				return fmt.Errorf("failed to unmarshal revision %s: %w", string(k), err)
+			}
+			revisions = append(revisions, revision)
+		}
+		return nil
+	})
+
+	return revisions, err
+}
+
+// AddAuditEntries stores the audit entries, assigning each an ID and a Timestamp if it does not have one
+func (s *boltstore) AddAuditEntries(ctx context.Context, entries []*model.AuditEntry) error {
+	_, span := tracer.Start(ctx, "store/AddAuditEntries")
+	defer span.End()
 
-			switch status {
-			case model.StatusCreated:
-				updates.IncludeResource(resource, EventTypeInsert)
-			case model.StatusConfigured:
-				updates.IncludeResource(resource, EventTypeUpdate)
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(bucketAudit))
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			sequence, err := bucket.NextSequence()
+			if err != nil {
+				return err
 			}
+			prepareAuditEntry(entry, strconv.FormatUint(sequence, 10))
 
-			// some resources need special treatment
-			switch r := resource.(type) {
-			case *model.Configuration:
-				// update the index
-				err = s.configurationIndex.Upsert(r)
-				if err != nil {
-					s.logger.Error("failed to update the search index", zap.String("configuration", r.Name()))
+			data, err := json.Marshal(entry)
+			if err != nil {
+				return err
+			}
+			if err := bucket.Put(sequenceKey(sequence), data); err != nil {
+				return fmt.Errorf("add audit entry: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// AuditEntries returns the audit entries matching the filter, newest first
+func (s *boltstore) AuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error) {
+	_, span := tracer.Start(ctx, "store/AuditEntries")
+	defer span.End()
+
+	var entries []*model.AuditEntry
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketAudit))
+		if bucket == nil {
+			return nil
+		}
+
+		skipped := 0
+		cursor := bucket.Cursor()
+		for k, v := cursor.Last(); k != nil; k, v = cursor.Prev() {
+			entry := &model.AuditEntry{}
+			if err := json.Unmarshal(v, entry); err != nil {
+				return fmt.Errorf("failed to unmarshal audit entry %s: %w", string(k), err)
+			}
+			if !filter.Matches(entry) {
+				continue
+			}
+			if skipped < filter.Offset {
+				skipped++
+				continue
+			}
+			entries = append(entries, entry)
+			if filter.Limit > 0 && len(entries) >= filter.Limit {
+				break
+			}
+		}
+		return nil
+	})
+
+	return entries, err
+}
+
+// CleanupAuditEntries removes the audit entries recorded before the specified time and the oldest entries beyond
+// maxEntries
+func (s *boltstore) CleanupAuditEntries(ctx context.Context, before time.Time, maxEntries int) error {
+	_, span := tracer.Start(ctx, "store/CleanupAuditEntries")
+	defer span.End()
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketAudit))
+		if bucket == nil {
+			return nil
+		}
+
+		excess := 0
+		if maxEntries > 0 {
+			excess = bucket.Stats().KeyN - maxEntries
+		}
+
+		// entries are stored oldest first, so stop at the first entry that is kept
+		var keys [][]byte
+		cursor := bucket.Cursor()
+		for k, v := cursor.First(); k != nil; k, v = cursor.Next() {
+			if len(keys) >= excess {
+				if before.IsZero() {
+					break
 				}
+				entry := &model.AuditEntry{}
+				if err := json.Unmarshal(v, entry); err != nil {
+					return fmt.Errorf("failed to unmarshal audit entry %s: %w", string(k), err)
+				}
+				if !entry.Timestamp.Before(before) {
+					break
+				}
+			}
+			keys = append(keys, append([]byte(nil), k...))
+		}
+
+		for _, key := range keys {
+			if err := bucket.Delete(key); err != nil {
+				return fmt.Errorf("cleanup audit entries: %w", err)
+			}
+		}
+		return nil
+	})
+}
+
+// AgentHistory returns the status changes of the agent, oldest first
+func (s *boltstore) AgentHistory(ctx context.Context, agentID string) ([]*model.AgentStatusChange, error) {
+	_, span := tracer.Start(ctx, "store/AgentHistory")
+	defer span.End()
+
+	changes := []*model.AgentStatusChange{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		history := tx.Bucket([]byte(bucketAgentHistory))
+		if history == nil {
+			return nil
+		}
+		bucket := history.Bucket([]byte(agentID))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			change := &model.AgentStatusChange{}
+			if err := json.Unmarshal(v, change); err != nil {
+				return fmt.Errorf("failed to unmarshal agent status change %s: %w", string(k), err)
+			}
+			changes = append(changes, change)
+			return nil
+		})
+	})
+
+	return changes, err
+}
+
+// CleanupAgentHistory removes the status changes recorded before the specified time and the oldest changes beyond
+// maxChanges of each agent, keeping the most recent change
+func (s *boltstore) CleanupAgentHistory(ctx context.Context, before time.Time, maxChanges int) error {
+	_, span := tracer.Start(ctx, "store/CleanupAgentHistory")
+	defer span.End()
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		history := tx.Bucket([]byte(bucketAgentHistory))
+		if history == nil {
+			return nil
+		}
+
+		// the history of each agent is a nested bucket, collected first to avoid modifying them during ForEach
+		var agentIDs [][]byte
+		err := history.ForEach(func(k, v []byte) error {
+			if v == nil {
+				agentIDs = append(agentIDs, append([]byte(nil), k...))
 			}
 			return nil
 		})
 		if err != nil {
-			errs = multierror.Append(errs, err)
+			return err
+		}
+
+		for _, agentID := range agentIDs {
+			bucket := history.Bucket(agentID)
+			count := bucket.Stats().KeyN
+			excess := 0
+			if maxChanges > 0 {
+				excess = count - maxChanges
+			}
+
+			// changes are stored oldest first, so stop at the first change that is kept
+			var keys [][]byte
+			cursor := bucket.Cursor()
+			for k, v := cursor.First(); k != nil && len(keys) < count-1; k, v = cursor.Next() {
+				if len(keys) >= excess {
+					if before.IsZero() {
+						break
+					}
+					change := &model.AgentStatusChange{}
+					if err := json.Unmarshal(v, change); err != nil {
+						return fmt.Errorf("failed to unmarshal agent status change %s: %w", string(k), err)
+					}
+					if !change.Timestamp.Before(before) {
+						break
+					}
+				}
+				keys = append(keys, append([]byte(nil), k...))
+			}
+
+			for _, key := range keys {
+				if err := bucket.Delete(key); err != nil {
+					return fmt.Errorf("cleanup agent history: %w", err)
+				}
+			}
 		}
+		return nil
+	})
+}
+
+// Rollout returns the most recent rollout of the configuration
+func (s *boltstore) Rollout(ctx context.Context, name string) (*model.Rollout, error) {
+	_, span := tracer.Start(ctx, "store/Rollout")
+	defer span.End()
+
+	var rollout *model.Rollout
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketRollouts))
+		if bucket == nil {
+			return nil
+		}
+		data := bucket.Get([]byte(name))
+		if data == nil {
+			return nil
+		}
+		rollout = &model.Rollout{}
+		if err := json.Unmarshal(data, rollout); err != nil {
+			return fmt.Errorf("failed to unmarshal rollout %s: %w", name, err)
+		}
+		return nil
+	})
+
+	return rollout, err
+}
+
+// Rollouts returns the most recent rollout of each configuration, sorted by name
+func (s *boltstore) Rollouts(ctx context.Context) ([]*model.Rollout, error) {
+	_, span := tracer.Start(ctx, "store/Rollouts")
+	defer span.End()
+
+	rollouts := []*model.Rollout{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketRollouts))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			rollout := &model.Rollout{}
+			if err := json.Unmarshal(v, rollout); err != nil {
+				return fmt.Errorf("failed to unmarshal rollout %s: %w", string(k), err)
+			}
+			rollouts = append(rollouts, rollout)
+			return nil
+		})
+	})
+
+	return rollouts, err
+}
+
+// UpsertRollout stores the rollout, replacing any previous rollout of the same configuration
+func (s *boltstore) UpsertRollout(ctx context.Context, rollout *model.Rollout) error {
+	_, span := tracer.Start(ctx, "store/UpsertRollout")
+	defer span.End()
+
+	data, err := json.Marshal(rollout)
+	if err != nil {
+		return err
 	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(bucketRollouts))
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put([]byte(rollout.Name), data); err != nil {
+			return fmt.Errorf("upsert rollout: %w", err)
+		}
+		return nil
+	})
+}
 
-	s.notify(ctx, updates)
+// UpgradeCampaign returns the upgrade campaign with the specified name
+func (s *boltstore) UpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	_, span := tracer.Start(ctx, "store/UpgradeCampaign")
+	defer span.End()
 
-	return resourceStatuses, errs
+	var campaign *model.UpgradeCampaign
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketCampaigns))
+		if bucket == nil {
+			return nil
+		}
+		data := bucket.Get([]byte(name))
+		if data == nil {
+			return nil
+		}
+		campaign = &model.UpgradeCampaign{}
+		if err := json.Unmarshal(data, campaign); err != nil {
+			return fmt.Errorf("failed to unmarshal upgrade campaign %s: %w", name, err)
+		}
+		return nil
+	})
+
+	return campaign, err
+}
+
+// UpgradeCampaigns returns all of the upgrade campaigns, sorted by name
+func (s *boltstore) UpgradeCampaigns(ctx context.Context) ([]*model.UpgradeCampaign, error) {
+	_, span := tracer.Start(ctx, "store/UpgradeCampaigns")
+	defer span.End()
+
+	campaigns := []*model.UpgradeCampaign{}
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		bucket := tx.Bucket([]byte(bucketCampaigns))
+		if bucket == nil {
+			return nil
+		}
+		return bucket.ForEach(func(k, v []byte) error {
+			campaign := &model.UpgradeCampaign{}
+			if err := json.Unmarshal(v, campaign); err != nil {
+				return fmt.Errorf("failed to unmarshal upgrade campaign %s: %w", string(k), err)
+			}
+			campaigns = append(campaigns, campaign)
+			return nil
+		})
+	})
+
+	return campaigns, err
+}
+
+// UpsertUpgradeCampaign stores the upgrade campaign, replacing any previous upgrade campaign with the same name
+func (s *boltstore) UpsertUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) error {
+	_, span := tracer.Start(ctx, "store/UpsertUpgradeCampaign")
+	defer span.End()
+
+	data, err := json.Marshal(campaign)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(bucketCampaigns))
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put([]byte(campaign.Name), data); err != nil {
+			return fmt.Errorf("upsert upgrade campaign: %w", err)
+		}
+		return nil
+	})
+}
+
+// AcquireLease acquires or renews the lease with the specified name for the holder until the ttl expires. The bbolt
+// storage file can only be used by a single server so the lease is held in memory.
+func (s *boltstore) AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	return s.leases.acquire(name, holder, ttl, time.Now()), nil
+}
+
+// UseEnrollmentToken counts a use of the enrollment token with the specified ID if it was used fewer than maxUses times
+func (s *boltstore) UseEnrollmentToken(ctx context.Context, id string, maxUses int) (bool, error) {
+	_, span := tracer.Start(ctx, "store/UseEnrollmentToken")
+	defer span.End()
+
+	used := false
+	err := s.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(bucketTokenUses))
+		if err != nil {
+			return err
+		}
+		uses := 0
+		if data := bucket.Get([]byte(id)); data != nil {
+			if uses, err = strconv.Atoi(string(data)); err != nil {
+				return err
+			}
+		}
+		if maxUses > 0 && uses >= maxUses {
+			return nil
+		}
+		used = true
+		return bucket.Put([]byte(id), []byte(strconv.Itoa(uses+1)))
+	})
+	if err != nil {
+		return false, fmt.Errorf("use enrollment token: %w", err)
+	}
+	return used, nil
+}
+
+// RevokeAgent records that the credentials of the agent with the specified ID were revoked
+func (s *boltstore) RevokeAgent(ctx context.Context, agentID string) error {
+	_, span := tracer.Start(ctx, "store/RevokeAgent")
+	defer span.End()
+
+	return s.db.Update(func(tx *bbolt.Tx) error {
+		bucket, err := tx.CreateBucketIfNotExists([]byte(bucketRevoked))
+		if err != nil {
+			return err
+		}
+		if err := bucket.Put([]byte(agentID), []byte(time.Now().UTC().Format(time.RFC3339))); err != nil {
+			return fmt.Errorf("revoke agent: %w", err)
+		}
+		return nil
+	})
+}
+
+// AgentRevoked returns true if the credentials of the agent with the specified ID were revoked
+func (s *boltstore) AgentRevoked(ctx context.Context, agentID string) (bool, error) {
+	_, span := tracer.Start(ctx, "store/AgentRevoked")
+	defer span.End()
+
+	revoked := false
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		if bucket := tx.Bucket([]byte(bucketRevoked)); bucket != nil {
+			revoked = bucket.Get([]byte(agentID)) != nil
+		}
+		return nil
+	})
+	return revoked, err
 }
 
 // ----------------------------------------------------------------------
@@ -306,12 +796,24 @@ func (s *boltstore) Clear() {
 		_ = tx.DeleteBucket([]byte(bucketTasks))
 		_ = tx.DeleteBucket([]byte(bucketAgents))
 		_ = tx.DeleteBucket([]byte(bucketMeasurements))
+		_ = tx.DeleteBucket([]byte(bucketRevisions))
+		_ = tx.DeleteBucket([]byte(bucketAudit))
+		_ = tx.DeleteBucket([]byte(bucketAgentHistory))
+		_ = tx.DeleteBucket([]byte(bucketRollouts))
+		_ = tx.DeleteBucket([]byte(bucketCampaigns))
+		_ = tx.DeleteBucket([]byte(bucketTokenUses))
+		_ = tx.DeleteBucket([]byte(bucketRevoked))
 
 		// create them again
 		// Disregarding errors because bucket names are valid.
 		_, _ = tx.CreateBucketIfNotExists([]byte(bucketResources))
 		_, _ = tx.CreateBucketIfNotExists([]byte(bucketTasks))
 		_, _ = tx.CreateBucketIfNotExists([]byte(bucketAgents))
+		_, _ = tx.CreateBucketIfNotExists([]byte(bucketRevisions))
+		_, _ = tx.CreateBucketIfNotExists([]byte(bucketAudit))
+		_, _ = tx.CreateBucketIfNotExists([]byte(bucketAgentHistory))
+		_, _ = tx.CreateBucketIfNotExists([]byte(bucketRollouts))
+		_, _ = tx.CreateBucketIfNotExists([]byte(bucketCampaigns))
 		b, _ := tx.CreateBucketIfNotExists([]byte(bucketMeasurements))
 
 		for _, metric := range stats.SupportedMetricNames {
@@ -319,6 +821,7 @@ func (s *boltstore) Clear() {
 		}
 		return nil
 	})
+	s.leases.clear()
 }
 
 func (s *boltstore) UpsertAgents(ctx context.Context, agentIDs []string, updater AgentUpdater) ([]*model.Agent, error) {
@@ -334,6 +837,9 @@ func (s *boltstore) UpsertAgents(ctx context.Context, agentIDs []string, updater
 			if err != nil {
 				return err
 			}
+			if err := s.upsertIndexTx(tx, s.agentIndex, agent); err != nil {
+				return err
+			}
 
 			agents = append(agents, agent)
 		}
@@ -344,13 +850,6 @@ func (s *boltstore) UpsertAgents(ctx context.Context, agentIDs []string, updater
 		return nil, err
 	}
 
-	// update the search index with changes
-	for _, a := range agents {
-		if err := s.agentIndex.Upsert(a); err != nil {
-			s.logger.Error("failed to update the search index", zap.String("agentID", a.ID))
-		}
-	}
-
 	// notify results
 	s.notify(ctx, updates)
 	return agents, nil
@@ -370,19 +869,13 @@ func (s *boltstore) UpsertAgent(ctx context.Context, id string, updater AgentUpd
 		if err != nil {
 			return err
 		}
-		return nil
+		return s.upsertIndexTx(tx, s.agentIndex, agent)
 	})
 
 	if err != nil {
 		return nil, err
 	}
 
-	// update the index
-	err = s.agentIndex.Upsert(updatedAgent)
-	if err != nil {
-		s.logger.Error("failed to update the search index", zap.String("agentID", updatedAgent.ID))
-	}
-
 	s.notify(ctx, updates)
 
 	return updatedAgent, nil
@@ -465,6 +958,12 @@ func (s *boltstore) DeleteAgents(ctx context.Context, agentIDs []string) ([]*mod
 				if err != nil {
 					return err
 				}
+				if err := deleteAgentHistoryTx(tx, id); err != nil {
+					return err
+				}
+				if err := s.removeIndexTx(tx, s.agentIndex, agent); err != nil {
+					return err
+				}
 
 				// include it in updates
 				updates.IncludeAgent(agent, EventTypeRemove)
@@ -478,13 +977,6 @@ func (s *boltstore) DeleteAgents(ctx context.Context, agentIDs []string) ([]*mod
 		return deleted, err
 	}
 
-	// remove deleted agents from the index
-	for _, agent := range deleted {
-		if err := s.agentIndex.Remove(agent); err != nil {
-			s.logger.Error("failed to remove from the search index", zap.String("agentID", agent.ID))
-		}
-	}
-
 	// notify updates
 	s.notify(ctx, updates)
 
@@ -516,6 +1008,16 @@ func (s *boltstore) agentsByID(ids []string, opts queryOptions) ([]*model.Agent,
 }
 
 func (s *boltstore) AgentsCount(ctx context.Context, options ...QueryOption) (int, error) {
+	if len(options) == 0 {
+		// avoid reading every agent when counting all of them
+		count := 0
+		err := s.db.View(func(tx *bbolt.Tx) error {
+			count = agentBucket(tx).Stats().KeyN
+			return nil
+		})
+		return count, err
+	}
+
 	agents, err := s.Agents(ctx, options...)
 	if err != nil {
 		return -1, err
@@ -597,8 +1099,12 @@ func (s *boltstore) Source(ctx context.Context, name string) (*model.Source, err
 	}
 	return item, err
 }
-func (s *boltstore) Sources(ctx context.Context) ([]*model.Source, error) {
-	return resources[*model.Source](s, model.KindSource)
+func (s *boltstore) Sources(ctx context.Context, options ...QueryOption) ([]*model.Source, error) {
+	items, err := resources[*model.Source](s, model.KindSource)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindSource], makeQueryOptions(options))
 }
 func (s *boltstore) DeleteSource(ctx context.Context, name string) (*model.Source, error) {
 	item, exists, err := deleteResourceAndNotify(ctx, s, model.KindSource, name, &model.Source{})
@@ -615,8 +1121,12 @@ func (s *boltstore) SourceType(ctx context.Context, name string) (*model.SourceT
 	}
 	return item, err
 }
-func (s *boltstore) SourceTypes(ctx context.Context) ([]*model.SourceType, error) {
-	return resources[*model.SourceType](s, model.KindSourceType)
+func (s *boltstore) SourceTypes(ctx context.Context, options ...QueryOption) ([]*model.SourceType, error) {
+	items, err := resources[*model.SourceType](s, model.KindSourceType)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindSourceType], makeQueryOptions(options))
 }
 func (s *boltstore) DeleteSourceType(ctx context.Context, name string) (*model.SourceType, error) {
 	item, exists, err := deleteResourceAndNotify(ctx, s, model.KindSourceType, name, &model.SourceType{})
@@ -633,8 +1143,12 @@ func (s *boltstore) Processor(ctx context.Context, name string) (*model.Processo
 	}
 	return item, err
 }
-func (s *boltstore) Processors(ctx context.Context) ([]*model.Processor, error) {
-	return resources[*model.Processor](s, model.KindProcessor)
+func (s *boltstore) Processors(ctx context.Context, options ...QueryOption) ([]*model.Processor, error) {
+	items, err := resources[*model.Processor](s, model.KindProcessor)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindProcessor], makeQueryOptions(options))
 }
 func (s *boltstore) DeleteProcessor(ctx context.Context, name string) (*model.Processor, error) {
 	item, exists, err := deleteResourceAndNotify(ctx, s, model.KindProcessor, name, &model.Processor{})
@@ -651,8 +1165,12 @@ func (s *boltstore) ProcessorType(ctx context.Context, name string) (*model.Proc
 	}
 	return item, err
 }
-func (s *boltstore) ProcessorTypes(ctx context.Context) ([]*model.ProcessorType, error) {
-	return resources[*model.ProcessorType](s, model.KindProcessorType)
+func (s *boltstore) ProcessorTypes(ctx context.Context, options ...QueryOption) ([]*model.ProcessorType, error) {
+	items, err := resources[*model.ProcessorType](s, model.KindProcessorType)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindProcessorType], makeQueryOptions(options))
 }
 func (s *boltstore) DeleteProcessorType(ctx context.Context, name string) (*model.ProcessorType, error) {
 	item, exists, err := deleteResourceAndNotify(ctx, s, model.KindProcessorType, name, &model.ProcessorType{})
@@ -669,8 +1187,12 @@ func (s *boltstore) Destination(ctx context.Context, name string) (*model.Destin
 	}
 	return item, err
 }
-func (s *boltstore) Destinations(ctx context.Context) ([]*model.Destination, error) {
-	return resources[*model.Destination](s, model.KindDestination)
+func (s *boltstore) Destinations(ctx context.Context, options ...QueryOption) ([]*model.Destination, error) {
+	items, err := resources[*model.Destination](s, model.KindDestination)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindDestination], makeQueryOptions(options))
 }
 func (s *boltstore) DeleteDestination(ctx context.Context, name string) (*model.Destination, error) {
 	item, exists, err := deleteResourceAndNotify(ctx, s, model.KindDestination, name, &model.Destination{})
@@ -687,8 +1209,12 @@ func (s *boltstore) DestinationType(ctx context.Context, name string) (*model.De
 	}
 	return item, err
 }
-func (s *boltstore) DestinationTypes(ctx context.Context) ([]*model.DestinationType, error) {
-	return resources[*model.DestinationType](s, model.KindDestinationType)
+func (s *boltstore) DestinationTypes(ctx context.Context, options ...QueryOption) ([]*model.DestinationType, error) {
+	items, err := resources[*model.DestinationType](s, model.KindDestinationType)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindDestinationType], makeQueryOptions(options))
 }
 func (s *boltstore) DeleteDestinationType(ctx context.Context, name string) (*model.DestinationType, error) {
 	item, exists, err := deleteResourceAndNotify(ctx, s, model.KindDestinationType, name, &model.DestinationType{})
@@ -698,6 +1224,64 @@ func (s *boltstore) DeleteDestinationType(ctx context.Context, name string) (*mo
 	return item, err
 }
 
+func (s *boltstore) ExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	item, exists, err := resource[*model.ExtensionType](s, model.KindExtensionType, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *boltstore) ExtensionTypes(ctx context.Context, options ...QueryOption) ([]*model.ExtensionType, error) {
+	items, err := resources[*model.ExtensionType](s, model.KindExtensionType)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindExtensionType], makeQueryOptions(options))
+}
+func (s *boltstore) DeleteExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	item, exists, err := deleteResourceAndNotify(ctx, s, model.KindExtensionType, name, &model.ExtensionType{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *boltstore) EnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	item, exists, err := resource[*model.EnrollmentToken](s, model.KindEnrollmentToken, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *boltstore) EnrollmentTokens(ctx context.Context) ([]*model.EnrollmentToken, error) {
+	return resources[*model.EnrollmentToken](s, model.KindEnrollmentToken)
+}
+func (s *boltstore) DeleteEnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	item, exists, err := deleteResourceAndNotify(ctx, s, model.KindEnrollmentToken, name, &model.EnrollmentToken{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *boltstore) Secret(ctx context.Context, name string) (*model.Secret, error) {
+	item, exists, err := resource[*model.Secret](s, model.KindSecret, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *boltstore) Secrets(ctx context.Context) ([]*model.Secret, error) {
+	return resources[*model.Secret](s, model.KindSecret)
+}
+func (s *boltstore) DeleteSecret(ctx context.Context, name string) (*model.Secret, error) {
+	item, exists, err := deleteResourceAndNotify(ctx, s, model.KindSecret, name, &model.Secret{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
 // CleanupDisconnectedAgents removes agents that have disconnected before the specified time
 func (s *boltstore) CleanupDisconnectedAgents(ctx context.Context, since time.Time) error {
 	agents, err := s.Agents(ctx)
@@ -709,17 +1293,18 @@ func (s *boltstore) CleanupDisconnectedAgents(ctx context.Context, since time.Ti
 	for _, agent := range agents {
 		if agent.DisconnectedSince(since) {
 			err := s.db.Update(func(tx *bbolt.Tx) error {
-				return agentBucket(tx).Delete(agentKey(agent.ID))
+				if err := agentBucket(tx).Delete(agentKey(agent.ID)); err != nil {
+					return err
+				}
+				if err := deleteAgentHistoryTx(tx, agent.ID); err != nil {
+					return err
+				}
+				return s.removeIndexTx(tx, s.agentIndex, agent)
 			})
 			if err != nil {
 				return err
 			}
 			changes.IncludeAgent(agent, EventTypeRemove)
-
-			// update the index
-			if err := s.agentIndex.Remove(agent); err != nil {
-				s.logger.Error("failed to remove from the search index", zap.String("agentID", agent.ID))
-			}
 		}
 	}
 
@@ -737,6 +1322,50 @@ func (s *boltstore) ConfigurationIndex(ctx context.Context) search.Index {
 	return s.configurationIndex
 }
 
+// ResourceIndex provides access to the search Index for the specified kind of resource
+func (s *boltstore) ResourceIndex(ctx context.Context, kind model.Kind) search.Index {
+	if kind == model.KindConfiguration {
+		return s.configurationIndex
+	}
+	return s.resourceIndexes[kind]
+}
+
+// upsertIndexTx updates the index in the transaction that updates the indexed resource so that a persisted index cannot
+// diverge from the database. Indexes that are not persisted are updated when the transaction is committed.
+func (s *boltstore) upsertIndexTx(tx *bbolt.Tx, index search.Index, indexed search.Indexed) error {
+	switch index := index.(type) {
+	case nil:
+		return nil
+	case search.BoltIndex:
+		return index.UpsertTx(tx, indexed)
+	default:
+		tx.OnCommit(func() {
+			if err := index.Upsert(indexed); err != nil {
+				s.logger.Error("failed to update the search index", zap.String("id", indexed.IndexID()), zap.Error(err))
+			}
+		})
+		return nil
+	}
+}
+
+// removeIndexTx removes the indexed resource from the index in the transaction that removes the resource. See
+// upsertIndexTx.
+func (s *boltstore) removeIndexTx(tx *bbolt.Tx, index search.Index, indexed search.Indexed) error {
+	switch index := index.(type) {
+	case nil:
+		return nil
+	case search.BoltIndex:
+		return index.RemoveTx(tx, indexed)
+	default:
+		tx.OnCommit(func() {
+			if err := index.Remove(indexed); err != nil {
+				s.logger.Error("failed to remove from the search index", zap.String("id", indexed.IndexID()), zap.Error(err))
+			}
+		})
+		return nil
+	}
+}
+
 func (s *boltstore) UserSessions() sessions.Store {
 	return s.sessionStorage
 }
@@ -773,6 +1402,20 @@ func resourceKey(kind model.Kind, name string) []byte {
 	return []byte(fmt.Sprintf("%s|%s", kind, name))
 }
 
+func revisionsPrefix(kind model.Kind, name string) []byte {
+	return []byte(fmt.Sprintf("%s|%s|", kind, name))
+}
+
+// revisionKey zero pads the revision so that revisions are ordered by key
+func revisionKey(kind model.Kind, name string, revision int) []byte {
+	return []byte(fmt.Sprintf("%s|%s|%010d", kind, name, revision))
+}
+
+// sequenceKey zero pads the sequence so that audit entries and agent status changes are ordered by key
+func sequenceKey(sequence uint64) []byte {
+	return []byte(fmt.Sprintf("%020d", sequence))
+}
+
 func agentKey(id string) []byte {
 	return []byte(fmt.Sprintf("%s|%s", "Agent", id))
 }
@@ -794,6 +1437,10 @@ func resourcesBucket(tx *bbolt.Tx) *bbolt.Bucket {
 	return tx.Bucket([]byte(bucketResources))
 }
 
+func revisionsBucket(tx *bbolt.Tx) *bbolt.Bucket {
+	return tx.Bucket([]byte(bucketRevisions))
+}
+
 func measurementsBucket(tx *bbolt.Tx, metric string) *bbolt.Bucket {
 	b := tx.Bucket([]byte(bucketMeasurements))
 	if b != nil {
@@ -807,12 +1454,15 @@ func upsertResource(tx *bbolt.Tx, r model.Resource, kind model.Kind) (model.Upda
 	bucket := resourcesBucket(tx)
 	existing := bucket.Get(key)
 
-	// preserve the id (if possible)
-	if len(existing) > 0 {
-		var cur model.AnyResource
-		if err := json.Unmarshal(existing, &cur); err == nil {
-			r.SetID(cur.ID())
-		}
+	// preserve the id and revision (if possible)
+	var cur model.AnyResource
+	exists := len(existing) > 0 && json.Unmarshal(existing, &cur) == nil
+	if err := checkResourceVersion(r, cur.Revision(), exists); err != nil {
+		return model.StatusConflict, err
+	}
+	if exists {
+		r.SetID(cur.ID())
+		r.SetRevision(cur.Revision())
 	}
 
 	data, err := json.Marshal(r)
@@ -824,10 +1474,22 @@ func upsertResource(tx *bbolt.Tx, r model.Resource, kind model.Kind) (model.Upda
 		return model.StatusUnchanged, nil
 	}
 
+	// the resource changed, store it as a new revision
+	r.SetRevision(latestRevision(tx, kind, r.Name()) + 1)
+	data, err = json.Marshal(r)
+	if err != nil {
+		// error, status unchanged
+		return model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
+	}
+
 	if err = bucket.Put(key, data); err != nil {
 		// error, status unchanged
 		return model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
 	}
+	if err = revisionsBucket(tx).Put(revisionKey(kind, r.Name(), r.Revision()), data); err != nil {
+		// error, status unchanged
+		return model.StatusUnchanged, fmt.Errorf("upsert resource revision: %w", err)
+	}
 
 	if len(existing) == 0 {
 		return model.StatusCreated, nil
@@ -835,6 +1497,22 @@ func upsertResource(tx *bbolt.Tx, r model.Resource, kind model.Kind) (model.Upda
 	return model.StatusConfigured, nil
 }
 
+// latestRevision returns the most recent revision stored for the resource with the specified kind and name or 0 if
+// there are no revisions
+func latestRevision(tx *bbolt.Tx, kind model.Kind, name string) int {
+	prefix := revisionsPrefix(kind, name)
+	cursor := revisionsBucket(tx).Cursor()
+
+	latest := 0
+	for k, _ := cursor.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, _ = cursor.Next() {
+		var revision int
+		if _, err := fmt.Sscanf(string(k[len(prefix):]), "%d", &revision); err == nil && revision > latest {
+			latest = revision
+		}
+	}
+	return latest
+}
+
 // upsertAgentTx is a transaction helper that updates the given agent,
 // puts it into the agent bucket  and includes it in the passed updates.
 // it does *not* update the search index or notify any subscribers of updates.
@@ -853,9 +1531,11 @@ func upsertAgentTx(tx *bbolt.Tx, agentID string, updater AgentUpdater, updates *
 		}
 		agentEventType = EventTypeUpdate
 	}
+	exists := agentEventType == EventTypeUpdate
 
 	// compare labels before/after and notify if they change
 	labelsBefore := agent.Labels.String()
+	statusBefore, errorBefore := agent.Status, agent.ErrorMessage
 
 	// update the agent
 	updater(agent)
@@ -878,10 +1558,50 @@ func upsertAgentTx(tx *bbolt.Tx, agentID string, updater AgentUpdater, updates *
 		return agent, err
 	}
 
+	if change := agentStatusChange(agent, exists, statusBefore, errorBefore); change != nil {
+		if err := addAgentStatusChangeTx(tx, change); err != nil {
+			return agent, err
+		}
+	}
+
 	updates.IncludeAgent(agent, agentEventType)
 	return agent, nil
 }
 
+// addAgentStatusChangeTx adds the change to the history of the agent, which is a bucket in the AgentHistory bucket
+func addAgentStatusChangeTx(tx *bbolt.Tx, change *model.AgentStatusChange) error {
+	history, err := tx.CreateBucketIfNotExists([]byte(bucketAgentHistory))
+	if err != nil {
+		return err
+	}
+	bucket, err := history.CreateBucketIfNotExists([]byte(change.AgentID))
+	if err != nil {
+		return err
+	}
+	sequence, err := bucket.NextSequence()
+	if err != nil {
+		return err
+	}
+	data, err := json.Marshal(change)
+	if err != nil {
+		return err
+	}
+	return bucket.Put(sequenceKey(sequence), data)
+}
+
+// deleteAgentHistoryTx removes the history of the agent
+func deleteAgentHistoryTx(tx *bbolt.Tx, agentID string) error {
+	history := tx.Bucket([]byte(bucketAgentHistory))
+	if history == nil {
+		return nil
+	}
+	err := history.DeleteBucket([]byte(agentID))
+	if errors.Is(err, bbolt.ErrBucketNotFound) {
+		return nil
+	}
+	return err
+}
+
 // ----------------------------------------------------------------------
 // generic resource accessors
 
@@ -945,7 +1665,7 @@ func resourcesByName[R model.Resource](s *boltstore, kind model.Kind, names []st
 }
 
 func deleteResourceAndNotify[R model.Resource](ctx context.Context, s *boltstore, kind model.Kind, name string, emptyResource R) (resource R, exists bool, err error) {
-	deleted, exists, err := deleteResource(ctx, s, kind, name, emptyResource)
+	deleted, exists, err := deleteResource(ctx, s, kind, name, "", emptyResource)
 
 	if err == nil && exists {
 		updates := NewUpdates()
@@ -957,10 +1677,11 @@ func deleteResourceAndNotify[R model.Resource](ctx context.Context, s *boltstore
 }
 
 // deleteResource removes the resource with the given kind and name. Returns ResourceMissingError if the resource wasn't
-// found. Returns DependencyError if the resource is referenced by another.
+// found. Returns DependencyError if the resource is referenced by another. If version is not empty, returns
+// ErrResourceConflict if it does not match the resourceVersion of the resource.
 // emptyResource will be populated with the deleted resource. For convenience, if the delete is successful, the
 // populated resource will also be returned. If there was an error, nil will be returned for the resource.
-func deleteResource[R model.Resource](ctx context.Context, s *boltstore, kind model.Kind, name string, emptyResource R) (resource R, exists bool, err error) {
+func deleteResource[R model.Resource](ctx context.Context, s *boltstore, kind model.Kind, name, version string, emptyResource R) (resource R, exists bool, err error) {
 	var dependencies DependentResources
 
 	err = s.db.Update(func(tx *bbolt.Tx) error {
@@ -978,6 +1699,10 @@ func deleteResource[R model.Resource](ctx context.Context, s *boltstore, kind mo
 
 			exists = true
 
+			if err := checkDeleteVersion(emptyResource, version); err != nil {
+				return err
+			}
+
 			// Check if the resources is referenced by another
 			dependencies, err = FindDependentResources(ctx, s, emptyResource)
 			if !dependencies.empty() {
@@ -985,9 +1710,15 @@ func deleteResource[R model.Resource](ctx context.Context, s *boltstore, kind mo
 			}
 
 			// Delete the key from the store
-			return c.Delete()
+			if err := c.Delete(); err != nil {
+				return err
+			}
+			return s.removeIndexTx(tx, s.ResourceIndex(ctx, kind), emptyResource)
 		}
 
+		if err := checkMissingVersion(kind, name, version); err != nil {
+			return err
+		}
 		return ErrResourceMissing
 	})
 
@@ -1000,12 +1731,6 @@ func deleteResource[R model.Resource](ctx context.Context, s *boltstore, kind mo
 		return resource, exists, err
 	}
 
-	if emptyResource.GetKind() == model.KindConfiguration {
-		if err := s.configurationIndex.Remove(emptyResource); err != nil {
-			s.logger.Error("failed to remove configuration from the search index", zap.String("name", emptyResource.Name()))
-		}
-	}
-
 	return emptyResource, exists, nil
 }
 
@@ -1068,32 +1793,7 @@ func (s *boltstore) ConfigurationMetrics(ctx context.Context, name string, optio
 		return nil, err
 	}
 
-	groupedMetrics := map[string]stats.MetricData{}
-	for _, m := range baseMetrics {
-		// since multiple configurations may be returned for the overview page, group by configuration and processor
-		key := fmt.Sprintf("%s|%s", stats.Configuration(m), stats.Processor(m))
-		groupedMetrics[key] = append(groupedMetrics[key], m)
-	}
-
-	finalMetrics := stats.MetricData{}
-	for _, metrics := range groupedMetrics {
-		sum := 0.0
-		for _, m := range metrics {
-			val, _ := stats.Value(m)
-			sum += val
-		}
-
-		attributes := map[string]interface{}{
-			stats.ConfigurationAttributeName: metrics[0].Attributes[stats.ConfigurationAttributeName],
-			stats.ProcessorAttributeName:     metrics[0].Attributes[stats.ProcessorAttributeName],
-		}
-
-		m := generateRecord(metrics[0], sum, attributes)
-
-		finalMetrics = append(finalMetrics, m)
-	}
-
-	return finalMetrics, nil
+	return sumConfigurationMetrics(baseMetrics), nil
 }
 
 // OverviewMetrics provides all metrics needed for the overview page. This page shows configs and destinations.
@@ -1184,7 +1884,7 @@ func (s *boltstore) retrieveMetrics(ctx context.Context, metricNames []string, o
 				// Only calculate rates if we found a start point to match the end points desired
 				for key, first := range startMetrics {
 					if last, ok := endMetrics[key]; ok {
-						if metric := s.calculateRateMetric(first, last); metric != nil {
+						if metric := calculateRateMetric(first, last); metric != nil {
 							result = append(result, metric)
 						}
 					}
@@ -1321,6 +2021,29 @@ func (s *boltstore) ProcessMetrics(ctx context.Context) error {
 	return errs
 }
 
+// ExportMeasurements returns all of the saved measurements. Each measurement is stored with an agent key and a
+// configuration key so only the agent keys are used.
+func (s *boltstore) ExportMeasurements(ctx context.Context) (stats.MetricData, error) {
+	result := stats.MetricData{}
+	prefix := []byte(fmt.Sprintf("%s|", model.KindAgent))
+
+	err := s.db.View(func(tx *bbolt.Tx) error {
+		for _, metricName := range stats.SupportedMetricNames {
+			c := measurementsBucket(tx, metricName).Cursor()
+			for k, v := c.Seek(prefix); k != nil && bytes.HasPrefix(k, prefix); k, v = c.Next() {
+				m := &record.Metric{}
+				if err := json.Unmarshal(v, m); err != nil {
+					return fmt.Errorf("failed to unmarshal measurement: %w", err)
+				}
+				result = append(result, m)
+			}
+		}
+		return nil
+	})
+
+	return result, err
+}
+
 func (s *boltstore) startMeasurements(ctx context.Context) {
 	// start the measurements timer for writing & rolling up
 	go func() {
@@ -1374,27 +2097,12 @@ func (s *boltstore) cleanupMeasurements(ctx context.Context, metricName string)
 		// Capture now to re-use for all the date math
 		now := getCurrentTime()
 
-		// Only look at data points older than 100 seconds, and we keep all the latest 10s intervals
-		endCleanupDate := now.Add(-100 * time.Second)
-
 		// Iterate through all points in the bucket, due to ordering we can't just scan by date
 		for k, _ := c.First(); k != nil; k, _ = c.Next() {
 			if ts, err := time.Parse(measurementsDateFormat, keyTimestamp(k)); err != nil {
 				errs = multierror.Append(errs, err)
 			} else {
-				// Assume anything older than the cutoff is being deleted, unless the
-				// normalized timestamp matches one of the rollup times
-				keep := ts.Sub(endCleanupDate) > 0
-				// For last 10 minutes keep the minute data
-				keep = keep || ts.Truncate(time.Minute*1).Equal(ts) && now.Sub(ts) <= 10*time.Minute
-				// for the last 6 hours keep the 5 minute data
-				keep = keep || ts.Truncate(time.Minute*5).Equal(ts) && now.Sub(ts) <= 6*time.Hour
-				// for the last 24 hours keep the hourly data
-				keep = keep || ts.Truncate(time.Hour*1).Equal(ts) && now.Sub(ts) <= 24*time.Hour
-				// for the last 31 days keep the daily data
-				keep = keep || ts.Truncate(time.Hour*24).Equal(ts) && now.Sub(ts) <= 24*31*time.Hour
-
-				if !keep {
+				if !keepMeasurement(ts, now) {
 					if err := c.Delete(); err != nil {
 						errs = multierror.Append(errs, err)
 					}
@@ -1406,7 +2114,59 @@ func (s *boltstore) cleanupMeasurements(ctx context.Context, metricName string)
 	})
 }
 
-func (s *boltstore) calculateRateMetric(first, last *record.Metric) *record.Metric {
+// keepMeasurement returns true if a measurement with the specified normalized timestamp should be kept when cleaning up
+// measurements at the specified time.
+func keepMeasurement(ts, now time.Time) bool {
+	// Only look at data points older than 100 seconds, and we keep all the latest 10s intervals
+	endCleanupDate := now.Add(-100 * time.Second)
+
+	// Assume anything older than the cutoff is being deleted, unless the
+	// normalized timestamp matches one of the rollup times
+	keep := ts.Sub(endCleanupDate) > 0
+	// For last 10 minutes keep the minute data
+	keep = keep || ts.Truncate(time.Minute*1).Equal(ts) && now.Sub(ts) <= 10*time.Minute
+	// for the last 6 hours keep the 5 minute data
+	keep = keep || ts.Truncate(time.Minute*5).Equal(ts) && now.Sub(ts) <= 6*time.Hour
+	// for the last 24 hours keep the hourly data
+	keep = keep || ts.Truncate(time.Hour*1).Equal(ts) && now.Sub(ts) <= 24*time.Hour
+	// for the last 31 days keep the daily data
+	keep = keep || ts.Truncate(time.Hour*24).Equal(ts) && now.Sub(ts) <= 24*31*time.Hour
+
+	return keep
+}
+
+// sumConfigurationMetrics sums the rates of configuration metrics from all agents, grouped by configuration and
+// processor
+func sumConfigurationMetrics(baseMetrics stats.MetricData) stats.MetricData {
+	groupedMetrics := map[string]stats.MetricData{}
+	for _, m := range baseMetrics {
+		// since multiple configurations may be returned for the overview page, group by configuration and processor
+		key := fmt.Sprintf("%s|%s", stats.Configuration(m), stats.Processor(m))
+		groupedMetrics[key] = append(groupedMetrics[key], m)
+	}
+
+	finalMetrics := stats.MetricData{}
+	for _, metrics := range groupedMetrics {
+		sum := 0.0
+		for _, m := range metrics {
+			val, _ := stats.Value(m)
+			sum += val
+		}
+
+		attributes := map[string]interface{}{
+			stats.ConfigurationAttributeName: metrics[0].Attributes[stats.ConfigurationAttributeName],
+			stats.ProcessorAttributeName:     metrics[0].Attributes[stats.ProcessorAttributeName],
+		}
+
+		m := generateRecord(metrics[0], sum, attributes)
+
+		finalMetrics = append(finalMetrics, m)
+	}
+
+	return finalMetrics
+}
+
+func calculateRateMetric(first, last *record.Metric) *record.Metric {
 	// If we ended up with start & end being the same moment, or somehow
 	// start is later than end, we don't want to provide a 0 or negative rate
 	if first.Timestamp.Sub(last.Timestamp) >= 0 {
@@ -1486,71 +2246,3 @@ func metricsKeys(m *record.Metric) [][]byte {
 func sanitizeKey(key string) string {
 	return strings.ReplaceAll(key, "|", "")
 }
-
-// ----------------------------------------------------------------------
-
-func getNameFromResource[R model.Resource](ctx context.Context, v []byte) (string, error) {
-	var resource R
-	if err := json.Unmarshal(v, &resource); err != nil {
-		return "", err
-	}
-	return resource.Name(), nil
-}
-
-func (s *boltstore) migrateBackToCaseSensitive(ctx context.Context) error {
-	return s.db.Update(func(tx *bbolt.Tx) error {
-		bucket := resourcesBucket(tx)
-		cursor := bucket.Cursor()
-
-		for k, v := cursor.Seek(nil); k != nil; k, v = cursor.Next() {
-			oldKey := string(k)
-			kind, _, found := strings.Cut(oldKey, "|")
-			if !found {
-				continue
-			}
-			foundKind := model.ParseKind(string(kind))
-			if foundKind == model.KindUnknown {
-				continue
-			}
-
-			var foundName string
-			var err error
-			switch foundKind {
-			case model.KindAgentVersion:
-				foundName, err = getNameFromResource[*model.AgentVersion](ctx, v)
-			case model.KindConfiguration:
-				foundName, err = getNameFromResource[*model.Configuration](ctx, v)
-			case model.KindSource:
-				foundName, err = getNameFromResource[*model.Source](ctx, v)
-			case model.KindSourceType:
-				foundName, err = getNameFromResource[*model.SourceType](ctx, v)
-			case model.KindProcessor:
-				foundName, err = getNameFromResource[*model.Processor](ctx, v)
-			case model.KindProcessorType:
-				foundName, err = getNameFromResource[*model.ProcessorType](ctx, v)
-			case model.KindDestination:
-				foundName, err = getNameFromResource[*model.Destination](ctx, v)
-			case model.KindDestinationType:
-				foundName, err = getNameFromResource[*model.DestinationType](ctx, v)
-			}
-
-			if err != nil {
-				continue
-			}
-
-			newKey := fmt.Sprintf("%s|%s", foundKind, foundName)
-			if newKey != oldKey {
-				err := cursor.Delete()
-				if err != nil {
-					return err
-				}
-				err = bucket.Put([]byte(newKey), v)
-				if err != nil {
-					return err
-				}
-			}
-		}
-
-		return nil
-	})
-}
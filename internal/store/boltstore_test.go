@@ -18,18 +18,21 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"os"
 	"path"
 	"path/filepath"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"go.etcd.io/bbolt"
 	"go.uber.org/zap"
 
+	"github.com/observiq/bindplane-op/internal/eventbus"
 	"github.com/observiq/bindplane-op/internal/store/search"
 	"github.com/observiq/bindplane-op/model"
 )
@@ -103,6 +106,10 @@ type mockUnknownResource struct{}
 func (x mockUnknownResource) ID() string                                { return "" }
 func (x mockUnknownResource) SetID(string)                              {}
 func (x mockUnknownResource) EnsureID()                                 {}
+func (x mockUnknownResource) Revision() int                             { return 0 }
+func (x mockUnknownResource) SetRevision(int)                           {}
+func (x mockUnknownResource) ResourceVersion() string                   { return "" }
+func (x mockUnknownResource) SetResourceVersion(string)                 {}
 func (x mockUnknownResource) GetKind() model.Kind                       { return model.KindUnknown }
 func (x mockUnknownResource) Name() string                              { return "" }
 func (x mockUnknownResource) Description() string                       { return "" }
@@ -383,13 +390,13 @@ func TestInitDB(t *testing.T) {
 			require.NoError(t, db.Close())
 
 			// cursor count increases by 2 for every empty bucket accessed, including sub-buckets
-			// a count of 16 means we accessed 8 buckets.
-			bucketCount := 8
+			// a count of 32 means we accessed 16 buckets.
+			bucketCount := 16
 			require.Equal(t, bucketCount*2, db.Stats().TxStats.CursorCount)
 
-			// InitDB creates buckets: Resources, Tasks, Agents, Measurements, and sub-buckets in measurements for each metric
+			// InitDB creates buckets: Resources, Tasks, Agents, Measurements, Revisions, Audit, AgentHistory, Rollouts, UpgradeCampaigns, EnrollmentTokenUses, RevokedAgents, Meta, and sub-buckets in measurements for each metric
 			_ = db.Update(func(tx *bbolt.Tx) error {
-				for _, bucket := range []string{bucketResources, bucketTasks, bucketAgents, bucketMeasurements} {
+				for _, bucket := range []string{bucketResources, bucketTasks, bucketAgents, bucketMeasurements, bucketRevisions, bucketAudit, bucketAgentHistory, bucketRollouts, bucketCampaigns, bucketTokenUses, bucketRevoked, bucketMeta} {
 					// Deleting the bucket
 					err := tx.DeleteBucket([]byte(bucket))
 					require.NoError(t, err, "expected bucket %s to exist", bucket)
@@ -400,6 +407,20 @@ func TestInitDB(t *testing.T) {
 	}
 }
 
+func TestInitDBLocked(t *testing.T) {
+	defer func(timeout time.Duration) { boltOpenTimeout = timeout }(boltOpenTimeout)
+	boltOpenTimeout = 100 * time.Millisecond
+
+	path := filepath.Join(t.TempDir(), "bindplane.db")
+	db, err := InitDB(path)
+	require.NoError(t, err)
+	defer db.Close()
+
+	_, err = InitDB(path)
+	require.ErrorIs(t, err, bbolt.ErrTimeout)
+	require.ErrorContains(t, err, "is locked by another process, stop the BindPlane server using it")
+}
+
 func TestNewBoltStore(t *testing.T) {
 	cases := []struct {
 		name string
@@ -447,6 +468,17 @@ func TestBoltstoreDependentResources(t *testing.T) {
 	runDependentResourcesTests(t, store)
 }
 
+func TestBoltstoreCascadeDelete(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runCascadeDeleteTests(t, store)
+}
+
 func TestBoltstoreIndividualDelete(t *testing.T) {
 	db, err := initTestDB(t)
 	require.NoError(t, err)
@@ -491,6 +523,137 @@ func TestBoltstoreUpsertAgents(t *testing.T) {
 	runTestUpsertAgents(t, store)
 }
 
+func TestBoltstoreResourceRevisions(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runResourceRevisionsTests(t, store)
+}
+
+func TestBoltstoreResourceVersion(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runResourceVersionTests(t, store)
+}
+
+func TestBoltstoreResourceQuery(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runResourceQueryTests(t, store)
+}
+
+func TestBoltstorePersistentSearchIndex(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+
+	_, err = store.ApplyResources(ctx, []model.Resource{macosSourceType, macosSource, cabinDestinationType, cabinDestination1, testConfiguration})
+	require.NoError(t, err)
+	_, err = store.UpsertAgent(ctx, "1", func(current *model.Agent) {
+		current.Name = "persisted"
+	})
+	require.NoError(t, err)
+
+	// a new store using the same database can search without seeding the indexes
+	restarted := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+
+	agentIndex, ok := restarted.AgentIndex(ctx).(search.PersistentIndex)
+	require.True(t, ok)
+	require.True(t, agentIndex.Loaded())
+	ids, err := search.Field(ctx, agentIndex, "name", "persisted")
+	require.NoError(t, err)
+	require.Equal(t, []string{"1"}, ids)
+
+	configurations, err := restarted.Configurations(ctx, WithQuery(search.ParseQuery("source:macos-1")))
+	require.NoError(t, err)
+	require.Len(t, configurations, 1)
+
+	sources, err := restarted.Sources(ctx, WithQuery(search.ParseQuery("type:macos")))
+	require.NoError(t, err)
+	require.Len(t, sources, 1)
+
+	// deleted resources are removed from the persisted index
+	_, err = restarted.DeleteConfiguration(ctx, testConfiguration.Name())
+	require.NoError(t, err)
+	restarted = NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	configurations, err = restarted.Configurations(ctx, WithQuery(search.ParseQuery("source:macos-1")))
+	require.NoError(t, err)
+	require.Len(t, configurations, 0)
+
+	// deleted agents are removed from the persisted index
+	_, err = restarted.DeleteAgents(ctx, []string{"1"})
+	require.NoError(t, err)
+	restarted = NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	agentIndex, ok = restarted.AgentIndex(ctx).(search.PersistentIndex)
+	require.True(t, ok)
+	require.True(t, agentIndex.Loaded())
+	require.Equal(t, 0, agentIndex.Size())
+}
+
+// failingIndex is a BoltIndex that fails to store documents
+type failingIndex struct {
+	search.BoltIndex
+}
+
+func (i *failingIndex) UpsertTx(tx *bbolt.Tx, indexed search.Indexed) error {
+	return errors.New("index unavailable")
+}
+
+func TestBoltstoreApplyResourcesIndexError(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	bs := store.(*boltstore)
+	bs.configurationIndex = &failingIndex{BoltIndex: bs.configurationIndex.(search.BoltIndex)}
+
+	updates, unsubscribe := eventbus.Subscribe(store.Updates())
+	defer unsubscribe()
+
+	statuses, err := store.ApplyResources(ctx, []model.Resource{macosSourceType, macosSource, cabinDestinationType, cabinDestination1, testConfiguration})
+	require.ErrorContains(t, err, "index unavailable")
+
+	// the configuration is rolled back and only reported as an error
+	require.Len(t, statuses, 5)
+	for _, status := range statuses[:4] {
+		require.Equal(t, model.StatusCreated, status.Status)
+	}
+	require.Equal(t, model.StatusError, statuses[4].Status)
+
+	configuration, err := store.Configuration(ctx, testConfiguration.Name())
+	require.NoError(t, err)
+	require.Nil(t, configuration)
+
+	select {
+	case update := <-updates:
+		require.True(t, update.Sources.Contains(macosSource.Name(), EventTypeInsert))
+		require.True(t, update.Configurations.Empty())
+	case <-time.After(time.Second):
+		require.Fail(t, "no updates received")
+	}
+}
+
 func TestBoltstoreMeasurements(t *testing.T) {
 	db, err := initTestDB(t)
 	require.NoError(t, err)
@@ -516,6 +679,8 @@ func initTestDB(t *testing.T) (*bbolt.DB, error) {
 		require.NoError(t, err, "error while initializing test database, %w", err)
 		_, err = tx.CreateBucketIfNotExists([]byte(bucketAgents))
 		require.NoError(t, err, "error while initializing test database, %w", err)
+		_, err = tx.CreateBucketIfNotExists([]byte(bucketRevisions))
+		require.NoError(t, err, "error while initializing test database, %w", err)
 
 		return nil
 	})
@@ -545,3 +710,102 @@ func storedAgentIDs(t *testing.T, store Store) []string {
 	}
 	return result
 }
+
+func TestBoltstoreAudit(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runAuditTests(t, store)
+}
+
+func TestBoltstoreAgentHistory(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runAgentHistoryTests(t, store)
+}
+
+func TestBoltstoreRequestAgentRestarts(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runRequestAgentRestartsTests(t, store)
+}
+
+func TestBoltstoreEnrollmentTokens(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runEnrollmentTokenTests(t, store)
+}
+
+func TestBoltstoreSecrets(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runSecretTests(t, store)
+}
+
+func TestBoltstoreRollouts(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runRolloutTests(t, store)
+}
+
+func TestBoltstoreUpgradeCampaigns(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runUpgradeCampaignTests(t, store)
+}
+
+func TestBoltstoreLeases(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runLeaseTests(t, store)
+}
+
+func TestBoltstoreCleanupDisconnectedAgents(t *testing.T) {
+	db, err := initTestDB(t)
+	require.NoError(t, err)
+	defer cleanupTestDB(t)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewBoltStore(ctx, db, testOptions, zap.NewNop())
+	runCleanupDisconnectedAgentsTests(t, store)
+}
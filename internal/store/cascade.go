@@ -0,0 +1,336 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+
+	"github.com/observiq/bindplane-op/internal/store/search"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// deleteOptions represents the set of options available for Store.DeleteResources
+type deleteOptions struct {
+	cascade model.CascadeMode
+	dryRun  bool
+}
+
+func makeDeleteOptions(options []DeleteOption) deleteOptions {
+	opts := deleteOptions{}
+	for _, opt := range options {
+		opt(&opts)
+	}
+	return opts
+}
+
+// planned returns true if the delete must be planned with deleteResourcesWithOptions instead of deleting the resources
+// directly
+func (o deleteOptions) planned() bool {
+	return o.cascade != model.CascadeNone || o.dryRun
+}
+
+// DeleteOption is an option used with Store.DeleteResources
+type DeleteOption func(*deleteOptions)
+
+// WithCascade determines what happens to the resources that depend on the deleted resources. By default, resources with
+// dependents are not deleted and have the status model.StatusInUse.
+func WithCascade(mode model.CascadeMode) DeleteOption {
+	return func(opts *deleteOptions) {
+		opts.cascade = mode
+	}
+}
+
+// WithDryRun returns the statuses that the delete would return without changing any resources
+func WithDryRun(dryRun bool) DeleteOption {
+	return func(opts *deleteOptions) {
+		opts.dryRun = dryRun
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// deleteResourcesWithOptions plans the delete of the resources and their dependents and then applies the resources with
+// references removed and deletes the resources, dependents first. For a dry run, the statuses of the planned changes are
+// returned instead. Stores call this from DeleteResources when the options require a plan, so it must be called without
+// holding any locks.
+func deleteResourcesWithOptions(ctx context.Context, s Store, resources []model.Resource, opts deleteOptions) ([]model.ResourceStatus, error) {
+	plan := newDeletePlan(s, opts.cascade)
+	for _, r := range resources {
+		if err := plan.delete(ctx, r.GetKind(), r.Name(), r.ResourceVersion()); err != nil {
+			return nil, err
+		}
+	}
+
+	if opts.dryRun {
+		return plan.statuses(), nil
+	}
+
+	statuses := plan.skipped
+	if updates := plan.pendingUpdates(); len(updates) > 0 {
+		updated, err := s.ApplyResources(ctx, updates)
+		statuses = append(statuses, updated...)
+		if err != nil {
+			return statuses, err
+		}
+	}
+	if len(plan.deletes) > 0 {
+		deleted, err := s.DeleteResources(ctx, plan.deletes)
+		statuses = append(statuses, deleted...)
+		if err != nil {
+			return statuses, err
+		}
+	}
+	return statuses, nil
+}
+
+// deletePlan contains the changes required to delete a set of resources
+type deletePlan struct {
+	store   Store
+	cascade model.CascadeMode
+
+	// deletes are the resources to delete, ordered so that each resource is deleted before the resources it depends on
+	deletes []model.Resource
+
+	// updates are copies of the resources that depend on deleted resources with the references removed. Resources that
+	// are deleted after their references are removed are skipped by pendingUpdates.
+	updates []model.Resource
+
+	// skipped contains the statuses of the resources that cannot be deleted because they have dependents or because the
+	// specified resourceVersion does not match
+	skipped []model.ResourceStatus
+
+	deleted map[string]bool
+	updated map[string]model.Resource
+}
+
+func newDeletePlan(s Store, cascade model.CascadeMode) *deletePlan {
+	return &deletePlan{
+		store:   s,
+		cascade: cascade,
+		deleted: map[string]bool{},
+		updated: map[string]model.Resource{},
+	}
+}
+
+// delete adds the resource with the specified kind and name to the plan along with the changes required to its
+// dependents. Resources that do not exist are ignored unless a version is specified. If version is not empty, the
+// resource is only deleted if its resourceVersion matches.
+func (p *deletePlan) delete(ctx context.Context, kind model.Kind, name, version string) error {
+	key := string(resourceKey(kind, name))
+	if p.deleted[key] {
+		return nil
+	}
+
+	r, err := GetResource(ctx, p.store, kind, name)
+	if err != nil {
+		return err
+	}
+	if r == nil {
+		if err := checkMissingVersion(kind, name, version); err != nil {
+			missing, parseErr := model.ParseResource(&model.AnyResource{
+				ResourceMeta: model.ResourceMeta{
+					Kind:     kind,
+					Metadata: model.Metadata{Name: name, ResourceVersion: version},
+				},
+			})
+			if parseErr != nil {
+				return parseErr
+			}
+			p.skipped = append(p.skipped, *model.NewResourceStatusWithReason(missing, model.StatusConflict, err.Error()))
+		}
+		return nil
+	}
+	if err := checkDeleteVersion(r, version); err != nil {
+		p.skipped = append(p.skipped, *model.NewResourceStatusWithReason(r, model.StatusConflict, err.Error()))
+		return nil
+	}
+
+	if p.cascade == model.CascadeNone {
+		dependencies, err := FindDependentResources(ctx, p.store, r)
+		if err != nil {
+			return err
+		}
+		if !p.allDeleted(dependencies) {
+			p.skipped = append(p.skipped, *model.NewResourceStatusWithReason(r, model.StatusInUse, dependencies.message()))
+			return nil
+		}
+		p.deleted[key] = true
+		p.deletes = append(p.deletes, r)
+		return nil
+	}
+
+	// mark the resource as deleted before visiting the dependents to avoid visiting it again
+	p.deleted[key] = true
+
+	for _, dependent := range dependentFields[kind] {
+		index := p.store.ResourceIndex(ctx, dependent.kind)
+		if index == nil {
+			continue
+		}
+		names, err := search.Field(ctx, index, dependent.field, name)
+		if err != nil {
+			return err
+		}
+		sort.Strings(names)
+
+		for _, dependentName := range names {
+			// a resource cannot exist without its type, so it is deleted even if only references are removed
+			if p.cascade == model.CascadeDelete || dependent.field == "type" {
+				err = p.delete(ctx, dependent.kind, dependentName, "")
+			} else {
+				err = p.removeReferences(ctx, dependent.kind, dependentName, kind, name)
+			}
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	delete(p.updated, key)
+	p.deletes = append(p.deletes, r)
+	return nil
+}
+
+// allDeleted returns true if every dependency is already deleted by the plan
+func (p *deletePlan) allDeleted(dependencies DependentResources) bool {
+	for _, d := range dependencies {
+		if !p.deleted[string(resourceKey(d.kind, d.name))] {
+			return false
+		}
+	}
+	return true
+}
+
+// referenceRemover is implemented by resources that can refer to other resources
+type referenceRemover interface {
+	RemoveReferences(kind model.Kind, name string) bool
+}
+
+// removeReferences removes the references to the resource with the specified kind and name from a copy of the dependent
+// resource. The copy is included in the updates of the plan unless the dependent resource is also deleted.
+func (p *deletePlan) removeReferences(ctx context.Context, dependentKind model.Kind, dependentName string, kind model.Kind, name string) error {
+	key := string(resourceKey(dependentKind, dependentName))
+	if p.deleted[key] {
+		return nil
+	}
+
+	r, ok := p.updated[key]
+	if !ok {
+		current, err := GetResource(ctx, p.store, dependentKind, dependentName)
+		if err != nil {
+			return err
+		}
+		if current == nil {
+			return nil
+		}
+		// copy the resource because some stores return the stored resource
+		r, err = copyResource(current)
+		if err != nil {
+			return err
+		}
+	}
+
+	remover, ok := r.(referenceRemover)
+	if !ok || !remover.RemoveReferences(kind, name) {
+		return nil
+	}
+	if _, ok := p.updated[key]; !ok {
+		p.updated[key] = r
+		p.updates = append(p.updates, r)
+	}
+	return nil
+}
+
+// pendingUpdates returns the updates of resources that are not deleted
+func (p *deletePlan) pendingUpdates() []model.Resource {
+	var updates []model.Resource
+	for _, r := range p.updates {
+		if _, ok := p.updated[string(resourceKey(r.GetKind(), r.Name()))]; ok {
+			updates = append(updates, r)
+		}
+	}
+	return updates
+}
+
+// statuses returns the statuses of the planned changes for a dry run
+func (p *deletePlan) statuses() []model.ResourceStatus {
+	statuses := append([]model.ResourceStatus{}, p.skipped...)
+	for _, r := range p.pendingUpdates() {
+		statuses = append(statuses, *model.NewResourceStatus(r, model.StatusConfigured))
+	}
+	for _, r := range p.deletes {
+		statuses = append(statuses, *model.NewResourceStatus(r, model.StatusDeleted))
+	}
+	return statuses
+}
+
+// copyResource returns a deep copy of a resource of any kind. cloneResource can be used when the type is known.
+func copyResource(r model.Resource) (model.Resource, error) {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return nil, err
+	}
+	clone, err := model.NewEmptyResource(r.GetKind())
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, clone); err != nil {
+		return nil, err
+	}
+	return clone, nil
+}
+
+// ----------------------------------------------------------------------
+
+// AffectedAgentIDs returns the IDs of the agents whose configuration is changed by the resources with the statuses
+// returned by ApplyResources or DeleteResources, including changes to the Configurations that use a changed Source or
+// Destination. The IDs are sorted.
+func AffectedAgentIDs(ctx context.Context, s Store, statuses []model.ResourceStatus) ([]string, error) {
+	updates := NewUpdates()
+	for _, status := range statuses {
+		switch status.Status {
+		case model.StatusCreated, model.StatusConfigured:
+			updates.IncludeResource(status.Resource, EventTypeUpdate)
+		case model.StatusDeleted:
+			updates.IncludeResource(status.Resource, EventTypeRemove)
+		}
+	}
+	if updates.Empty() {
+		return nil, nil
+	}
+	if err := updates.addTransitiveUpdates(ctx, s); err != nil {
+		return nil, err
+	}
+
+	agentIDs := map[string]bool{}
+	for _, event := range updates.Configurations {
+		ids, err := s.AgentsIDsMatchingConfiguration(ctx, event.Item)
+		if err != nil {
+			return nil, err
+		}
+		for _, id := range ids {
+			agentIDs[id] = true
+		}
+	}
+
+	result := make([]string, 0, len(agentIDs))
+	for id := range agentIDs {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+	return result, nil
+}
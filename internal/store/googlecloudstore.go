@@ -15,11 +15,13 @@
 package store
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"strconv"
 	"time"
 
 	"cloud.google.com/go/datastore"
@@ -47,6 +49,7 @@ type googleCloudStore struct {
 	updates            eventbus.Source[*Updates]
 	agentIndex         search.Index
 	configurationIndex search.Index
+	resourceIndexes    resourceIndexes
 	logger             *zap.Logger
 
 	sessionStore sessions.Store
@@ -72,6 +75,7 @@ func NewGoogleCloudStore(ctx context.Context, cfg *common.Server, logger *zap.Lo
 		updates:            eventbus.NewSource[*Updates](),
 		agentIndex:         search.NewInMemoryIndex("agent"),
 		configurationIndex: search.NewInMemoryIndex("configuration"),
+		resourceIndexes:    newResourceIndexes(search.NewInMemoryIndex),
 		logger:             logger,
 
 		sessionStore: newBPCookieStore(cfg.SessionsSecret),
@@ -123,24 +127,26 @@ func (s *googleCloudStore) AgentsCount(ctx context.Context, options ...QueryOpti
 }
 
 // getAndUpdateAgent gets the agent from the data store and calls the updater on it.
-// It appends the passed updates with the appropriate agent and status.
+// It appends the passed updates with the appropriate agent and status and returns the
+// change to add to the agent history, if any.
 // It does *not* PUT to update the agents in the store, notify subscribers of updates,
 // or update the search index.
-func (s *googleCloudStore) getAndUpdateAgent(ctx context.Context, agentID string, updater AgentUpdater, updates *Updates) (agent *model.Agent, err error) {
+func (s *googleCloudStore) getAndUpdateAgent(ctx context.Context, agentID string, updater AgentUpdater, updates *Updates) (agent *model.Agent, change *model.AgentStatusChange, err error) {
 	agentEventType := EventTypeUpdate
 
 	agent, exists, err := getDatastoreResource[*model.Agent](ctx, s, model.KindAgent, agentID)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if !exists {
-		agentEventType = EventTypeUpdate
+		agentEventType = EventTypeInsert
 		agent = &model.Agent{ID: agentID}
 	}
 
 	// compare labels before/after and notify if they change
 	labelsBefore := agent.Labels.String()
+	statusBefore, errorBefore := agent.Status, agent.ErrorMessage
 
 	// update the agent
 	updater(agent)
@@ -151,7 +157,9 @@ func (s *googleCloudStore) getAndUpdateAgent(ctx context.Context, agentID string
 	if labelsAfter != labelsBefore && agentEventType == EventTypeUpdate {
 		agentEventType = EventTypeLabel
 	}
-	return agent, nil
+
+	updates.IncludeAgent(agent, agentEventType)
+	return agent, agentStatusChange(agent, exists, statusBefore, errorBefore), nil
 }
 
 func (s *googleCloudStore) UpsertAgents(ctx context.Context, agentIDs []string, updater AgentUpdater) ([]*model.Agent, error) {
@@ -160,15 +168,19 @@ func (s *googleCloudStore) UpsertAgents(ctx context.Context, agentIDs []string,
 
 	updates := NewUpdates()
 	agents := make([]*model.Agent, 0, len(agentIDs))
+	var changes []*model.AgentStatusChange
 
 	// TODO (dsvanlani) This can be optimized to use GetMulti instead of individual gets.
 	for _, id := range agentIDs {
-		agent, err := s.getAndUpdateAgent(ctx, id, updater, updates)
+		agent, change, err := s.getAndUpdateAgent(ctx, id, updater, updates)
 		if err != nil {
 			return nil, err
 		}
 
 		agents = append(agents, agent)
+		if change != nil {
+			changes = append(changes, change)
+		}
 	}
 
 	// make data store resources and update the store
@@ -189,6 +201,10 @@ func (s *googleCloudStore) UpsertAgents(ctx context.Context, agentIDs []string,
 		return nil, err
 	}
 
+	if err := addDatastoreAgentStatusChanges(ctx, s, changes); err != nil {
+		return nil, err
+	}
+
 	// Update the search index
 	for _, agent := range agents {
 		err = s.agentIndex.Upsert(agent)
@@ -200,7 +216,7 @@ func (s *googleCloudStore) UpsertAgents(ctx context.Context, agentIDs []string,
 	// notify updates
 	s.notify(ctx, updates)
 
-	return nil, nil
+	return agents, nil
 }
 
 // UpsertAgent adds a new Agent to the Store or updates an existing one
@@ -210,7 +226,7 @@ func (s *googleCloudStore) UpsertAgent(ctx context.Context, agentID string, upda
 
 	updates := NewUpdates()
 
-	agent, err := s.getAndUpdateAgent(ctx, agentID, updater, updates)
+	agent, change, err := s.getAndUpdateAgent(ctx, agentID, updater, updates)
 	if err != nil {
 		return nil, err
 	}
@@ -220,6 +236,11 @@ func (s *googleCloudStore) UpsertAgent(ctx context.Context, agentID string, upda
 	if err != nil {
 		return nil, err
 	}
+	if change != nil {
+		if err := addDatastoreAgentStatusChanges(ctx, s, []*model.AgentStatusChange{change}); err != nil {
+			return nil, err
+		}
+	}
 
 	// update the index
 	err = s.agentIndex.Upsert(agent)
@@ -283,8 +304,12 @@ func (s *googleCloudStore) Source(ctx context.Context, name string) (*model.Sour
 	}
 	return item, err
 }
-func (s *googleCloudStore) Sources(ctx context.Context) ([]*model.Source, error) {
-	return getDatastoreResources[*model.Source](ctx, s, model.KindSource, nil)
+func (s *googleCloudStore) Sources(ctx context.Context, options ...QueryOption) ([]*model.Source, error) {
+	items, err := getDatastoreResources[*model.Source](ctx, s, model.KindSource, nil)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindSource], makeQueryOptions(options))
 }
 func (s *googleCloudStore) DeleteSource(ctx context.Context, name string) (*model.Source, error) {
 	item, exists, err := deleteDatastoreResourceAndNotify[*model.Source](ctx, s, model.KindSource, name)
@@ -301,8 +326,12 @@ func (s *googleCloudStore) SourceType(ctx context.Context, name string) (*model.
 	}
 	return item, err
 }
-func (s *googleCloudStore) SourceTypes(ctx context.Context) ([]*model.SourceType, error) {
-	return getDatastoreResources[*model.SourceType](ctx, s, model.KindSourceType, nil)
+func (s *googleCloudStore) SourceTypes(ctx context.Context, options ...QueryOption) ([]*model.SourceType, error) {
+	items, err := getDatastoreResources[*model.SourceType](ctx, s, model.KindSourceType, nil)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindSourceType], makeQueryOptions(options))
 }
 func (s *googleCloudStore) DeleteSourceType(ctx context.Context, name string) (*model.SourceType, error) {
 	item, exists, err := deleteDatastoreResourceAndNotify[*model.SourceType](ctx, s, model.KindSourceType, name)
@@ -319,8 +348,12 @@ func (s *googleCloudStore) Processor(ctx context.Context, name string) (*model.P
 	}
 	return item, err
 }
-func (s *googleCloudStore) Processors(ctx context.Context) ([]*model.Processor, error) {
-	return getDatastoreResources[*model.Processor](ctx, s, model.KindProcessor, nil)
+func (s *googleCloudStore) Processors(ctx context.Context, options ...QueryOption) ([]*model.Processor, error) {
+	items, err := getDatastoreResources[*model.Processor](ctx, s, model.KindProcessor, nil)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindProcessor], makeQueryOptions(options))
 }
 func (s *googleCloudStore) DeleteProcessor(ctx context.Context, name string) (*model.Processor, error) {
 	item, exists, err := deleteDatastoreResourceAndNotify[*model.Processor](ctx, s, model.KindProcessor, name)
@@ -337,8 +370,12 @@ func (s *googleCloudStore) ProcessorType(ctx context.Context, name string) (*mod
 	}
 	return item, err
 }
-func (s *googleCloudStore) ProcessorTypes(ctx context.Context) ([]*model.ProcessorType, error) {
-	return getDatastoreResources[*model.ProcessorType](ctx, s, model.KindProcessorType, nil)
+func (s *googleCloudStore) ProcessorTypes(ctx context.Context, options ...QueryOption) ([]*model.ProcessorType, error) {
+	items, err := getDatastoreResources[*model.ProcessorType](ctx, s, model.KindProcessorType, nil)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindProcessorType], makeQueryOptions(options))
 }
 func (s *googleCloudStore) DeleteProcessorType(ctx context.Context, name string) (*model.ProcessorType, error) {
 	item, exists, err := deleteDatastoreResourceAndNotify[*model.ProcessorType](ctx, s, model.KindProcessorType, name)
@@ -355,8 +392,12 @@ func (s *googleCloudStore) Destination(ctx context.Context, name string) (*model
 	}
 	return item, err
 }
-func (s *googleCloudStore) Destinations(ctx context.Context) ([]*model.Destination, error) {
-	return getDatastoreResources[*model.Destination](ctx, s, model.KindDestination, nil)
+func (s *googleCloudStore) Destinations(ctx context.Context, options ...QueryOption) ([]*model.Destination, error) {
+	items, err := getDatastoreResources[*model.Destination](ctx, s, model.KindDestination, nil)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindDestination], makeQueryOptions(options))
 }
 func (s *googleCloudStore) DeleteDestination(ctx context.Context, name string) (*model.Destination, error) {
 	item, exists, err := deleteDatastoreResourceAndNotify[*model.Destination](ctx, s, model.KindDestination, name)
@@ -373,8 +414,12 @@ func (s *googleCloudStore) DestinationType(ctx context.Context, name string) (*m
 	}
 	return item, err
 }
-func (s *googleCloudStore) DestinationTypes(ctx context.Context) ([]*model.DestinationType, error) {
-	return getDatastoreResources[*model.DestinationType](ctx, s, model.KindDestinationType, nil)
+func (s *googleCloudStore) DestinationTypes(ctx context.Context, options ...QueryOption) ([]*model.DestinationType, error) {
+	items, err := getDatastoreResources[*model.DestinationType](ctx, s, model.KindDestinationType, nil)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindDestinationType], makeQueryOptions(options))
 }
 func (s *googleCloudStore) DeleteDestinationType(ctx context.Context, name string) (*model.DestinationType, error) {
 	item, exists, err := deleteDatastoreResourceAndNotify[*model.DestinationType](ctx, s, model.KindDestinationType, name)
@@ -384,9 +429,72 @@ func (s *googleCloudStore) DeleteDestinationType(ctx context.Context, name strin
 	return item, err
 }
 
+func (s *googleCloudStore) ExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	item, exists, err := getDatastoreResource[*model.ExtensionType](ctx, s, model.KindExtensionType, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *googleCloudStore) ExtensionTypes(ctx context.Context, options ...QueryOption) ([]*model.ExtensionType, error) {
+	items, err := getDatastoreResources[*model.ExtensionType](ctx, s, model.KindExtensionType, nil)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindExtensionType], makeQueryOptions(options))
+}
+func (s *googleCloudStore) DeleteExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	item, exists, err := deleteDatastoreResourceAndNotify[*model.ExtensionType](ctx, s, model.KindExtensionType, name)
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *googleCloudStore) EnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	item, exists, err := getDatastoreResource[*model.EnrollmentToken](ctx, s, model.KindEnrollmentToken, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *googleCloudStore) EnrollmentTokens(ctx context.Context) ([]*model.EnrollmentToken, error) {
+	return getDatastoreResources[*model.EnrollmentToken](ctx, s, model.KindEnrollmentToken, nil)
+}
+func (s *googleCloudStore) DeleteEnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	item, exists, err := deleteDatastoreResourceAndNotify[*model.EnrollmentToken](ctx, s, model.KindEnrollmentToken, name)
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *googleCloudStore) Secret(ctx context.Context, name string) (*model.Secret, error) {
+	item, exists, err := getDatastoreResource[*model.Secret](ctx, s, model.KindSecret, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *googleCloudStore) Secrets(ctx context.Context) ([]*model.Secret, error) {
+	return getDatastoreResources[*model.Secret](ctx, s, model.KindSecret, nil)
+}
+func (s *googleCloudStore) DeleteSecret(ctx context.Context, name string) (*model.Secret, error) {
+	item, exists, err := deleteDatastoreResourceAndNotify[*model.Secret](ctx, s, model.KindSecret, name)
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
 // ----------------------------------------------------------------------
 
 func (s *googleCloudStore) ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+	// copies are applied so that the resourceVersion of the resources of the caller is unchanged
+	copies := copyResources(resources)
+	defer assignResources(resources, copies)
+	resources = copies
+
 	updates := NewUpdates()
 
 	// resourceStatuses to return for the applied resources
@@ -405,6 +513,10 @@ func (s *googleCloudStore) ApplyResources(ctx context.Context, resources []model
 		}
 
 		status, err := upsertAnyDatastoreResource(ctx, s, resource)
+		if errors.Is(err, ErrResourceConflict) {
+			resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, model.StatusConflict, err.Error()))
+			continue
+		}
 		if err != nil {
 			resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, model.StatusError, err.Error()))
 			errs = multierror.Append(errs, err)
@@ -427,8 +539,351 @@ func (s *googleCloudStore) ApplyResources(ctx context.Context, resources []model
 	return resourceStatuses, errs
 }
 
+// ResourceRevisions returns every revision of the resource with the specified kind and name, oldest first.
+func (s *googleCloudStore) ResourceRevisions(ctx context.Context, kind model.Kind, name string) ([]model.Resource, error) {
+	// revisions are children of the resource key and ancestor queries are ordered by key
+	query := datastore.NewQuery(datastoreRevisionKind(kind)).Ancestor(datastoreKey(kind, name))
+	var list []datastoreResource
+	if _, err := s.client.GetAll(ctx, query, &list); err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+
+	results := make([]model.Resource, 0, len(list))
+	for _, dsr := range list {
+		revision, err := model.NewEmptyResource(kind)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(dsr.Body, revision); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the revision: %w", err)
+		}
+		results = append(results, revision)
+	}
+
+	return results, nil
+}
+
+// AddAuditEntries stores the audit entries, assigning each an ID and a Timestamp if it does not have one
+func (s *googleCloudStore) AddAuditEntries(ctx context.Context, entries []*model.AuditEntry) error {
+	if len(entries) == 0 {
+		return nil
+	}
+
+	keys := make([]*datastore.Key, len(entries))
+	for i := range entries {
+		keys[i] = datastore.IncompleteKey(datastoreAuditKind, nil)
+	}
+	keys, err := s.client.AllocateIDs(ctx, keys)
+	if err != nil {
+		return fmt.Errorf("add audit entries: %w", err)
+	}
+
+	list := make([]*datastoreAuditEntry, 0, len(entries))
+	for i, entry := range entries {
+		prepareAuditEntry(entry, strconv.FormatInt(keys[i].ID, 10))
+		body, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		list = append(list, &datastoreAuditEntry{
+			Timestamp: entry.Timestamp,
+			Body:      body,
+		})
+	}
+
+	if _, err := s.client.PutMulti(ctx, keys, list); err != nil {
+		return fmt.Errorf("add audit entries: %w", err)
+	}
+	return nil
+}
+
+// AuditEntries returns the audit entries matching the filter, newest first
+func (s *googleCloudStore) AuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error) {
+	// entries are filtered after they are read to avoid requiring composite indexes
+	query := datastore.NewQuery(datastoreAuditKind).Order("-timestamp")
+	var list []datastoreAuditEntry
+	if _, err := s.client.GetAll(ctx, query, &list); err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+
+	var entries []*model.AuditEntry
+	for _, dse := range list {
+		entry := &model.AuditEntry{}
+		if err := json.Unmarshal(dse.Body, entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the audit entry: %w", err)
+		}
+		if filter.Matches(entry) {
+			entries = append(entries, entry)
+		}
+	}
+
+	return pageAuditEntries(entries, filter), nil
+}
+
+// CleanupAuditEntries removes the audit entries recorded before the specified time and the oldest entries beyond
+// maxEntries
+func (s *googleCloudStore) CleanupAuditEntries(ctx context.Context, before time.Time, maxEntries int) error {
+	var queries []*datastore.Query
+	if !before.IsZero() {
+		queries = append(queries, datastore.NewQuery(datastoreAuditKind).Filter("timestamp <", before).KeysOnly())
+	}
+	if maxEntries > 0 {
+		queries = append(queries, datastore.NewQuery(datastoreAuditKind).Order("-timestamp").Offset(maxEntries).KeysOnly())
+	}
+
+	for _, query := range queries {
+		keys, err := s.client.GetAll(ctx, query, nil)
+		if err != nil {
+			return fmt.Errorf("failed to get audit entries: %w", err)
+		}
+		if err := deleteDatastoreKeys(ctx, s, keys); err != nil {
+			return fmt.Errorf("cleanup audit entries: %w", err)
+		}
+	}
+	return nil
+}
+
+// AgentHistory returns the status changes of the agent, oldest first
+func (s *googleCloudStore) AgentHistory(ctx context.Context, agentID string) ([]*model.AgentStatusChange, error) {
+	query := datastore.NewQuery(datastoreAgentHistoryKind).Ancestor(datastoreKey(model.KindAgent, agentID)).Order("timestamp")
+	var list []datastoreAgentStatusChange
+	if _, err := s.client.GetAll(ctx, query, &list); err != nil {
+		return nil, fmt.Errorf("failed to get agent history: %w", err)
+	}
+
+	changes := make([]*model.AgentStatusChange, 0, len(list))
+	for _, dsc := range list {
+		change := &model.AgentStatusChange{}
+		if err := json.Unmarshal(dsc.Body, change); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the agent status change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, nil
+}
+
+// CleanupAgentHistory removes the status changes recorded before the specified time and the oldest changes beyond
+// maxChanges of each agent, keeping the most recent change
+func (s *googleCloudStore) CleanupAgentHistory(ctx context.Context, before time.Time, maxChanges int) error {
+	query := datastore.NewQuery(datastoreAgentHistoryKind).Project("timestamp").Order("-timestamp")
+	var list []datastoreAgentStatusChange
+	keys, err := s.client.GetAll(ctx, query, &list)
+	if err != nil {
+		return fmt.Errorf("failed to get agent history: %w", err)
+	}
+
+	// changes are newest first, so the changes of each agent are counted from its most recent change
+	var remove []*datastore.Key
+	counts := map[string]int{}
+	for i, key := range keys {
+		agentID := key.Parent.Name
+		counts[agentID]++
+		count := counts[agentID]
+		if count == 1 {
+			continue
+		}
+		if (maxChanges > 0 && count > maxChanges) || (!before.IsZero() && list[i].Timestamp.Before(before)) {
+			remove = append(remove, key)
+		}
+	}
+
+	if err := deleteDatastoreKeys(ctx, s, remove); err != nil {
+		return fmt.Errorf("cleanup agent history: %w", err)
+	}
+	return nil
+}
+
+// Rollout returns the most recent rollout of the configuration
+func (s *googleCloudStore) Rollout(ctx context.Context, name string) (*model.Rollout, error) {
+	var dsr datastoreRollout
+	err := s.client.Get(ctx, datastore.NameKey(datastoreRolloutKind, name, nil), &dsr)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rollout: %w", err)
+	}
+	rollout := &model.Rollout{}
+	if err := json.Unmarshal(dsr.Body, rollout); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the rollout: %w", err)
+	}
+	return rollout, nil
+}
+
+// Rollouts returns the most recent rollout of each configuration, sorted by name
+func (s *googleCloudStore) Rollouts(ctx context.Context) ([]*model.Rollout, error) {
+	query := datastore.NewQuery(datastoreRolloutKind).Order("name")
+	var list []datastoreRollout
+	if _, err := s.client.GetAll(ctx, query, &list); err != nil {
+		return nil, fmt.Errorf("failed to get rollouts: %w", err)
+	}
+
+	rollouts := make([]*model.Rollout, 0, len(list))
+	for _, dsr := range list {
+		rollout := &model.Rollout{}
+		if err := json.Unmarshal(dsr.Body, rollout); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the rollout: %w", err)
+		}
+		rollouts = append(rollouts, rollout)
+	}
+
+	return rollouts, nil
+}
+
+// UpsertRollout stores the rollout, replacing any previous rollout of the same configuration
+func (s *googleCloudStore) UpsertRollout(ctx context.Context, rollout *model.Rollout) error {
+	body, err := json.Marshal(rollout)
+	if err != nil {
+		return err
+	}
+	dsr := &datastoreRollout{
+		Name: rollout.Name,
+		Body: body,
+	}
+	if _, err := s.client.Put(ctx, datastore.NameKey(datastoreRolloutKind, rollout.Name, nil), dsr); err != nil {
+		return fmt.Errorf("upsert rollout: %w", err)
+	}
+	return nil
+}
+
+// UpgradeCampaign returns the upgrade campaign with the specified name
+func (s *googleCloudStore) UpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	var dsc datastoreUpgradeCampaign
+	err := s.client.Get(ctx, datastore.NameKey(datastoreUpgradeCampaignKind, name, nil), &dsc)
+	if errors.Is(err, datastore.ErrNoSuchEntity) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade campaign: %w", err)
+	}
+	campaign := &model.UpgradeCampaign{}
+	if err := json.Unmarshal(dsc.Body, campaign); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the upgrade campaign: %w", err)
+	}
+	return campaign, nil
+}
+
+// UpgradeCampaigns returns all of the upgrade campaigns, sorted by name
+func (s *googleCloudStore) UpgradeCampaigns(ctx context.Context) ([]*model.UpgradeCampaign, error) {
+	query := datastore.NewQuery(datastoreUpgradeCampaignKind).Order("name")
+	var list []datastoreUpgradeCampaign
+	if _, err := s.client.GetAll(ctx, query, &list); err != nil {
+		return nil, fmt.Errorf("failed to get upgrade campaigns: %w", err)
+	}
+
+	campaigns := make([]*model.UpgradeCampaign, 0, len(list))
+	for _, dsc := range list {
+		campaign := &model.UpgradeCampaign{}
+		if err := json.Unmarshal(dsc.Body, campaign); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the upgrade campaign: %w", err)
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, nil
+}
+
+// UpsertUpgradeCampaign stores the upgrade campaign, replacing any previous upgrade campaign with the same name
+func (s *googleCloudStore) UpsertUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) error {
+	body, err := json.Marshal(campaign)
+	if err != nil {
+		return err
+	}
+	dsc := &datastoreUpgradeCampaign{
+		Name: campaign.Name,
+		Body: body,
+	}
+	if _, err := s.client.Put(ctx, datastore.NameKey(datastoreUpgradeCampaignKind, campaign.Name, nil), dsc); err != nil {
+		return fmt.Errorf("upsert upgrade campaign: %w", err)
+	}
+	return nil
+}
+
+// AcquireLease acquires or renews the lease with the specified name for the holder until the ttl expires
+func (s *googleCloudStore) AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	key := datastore.NameKey(datastoreLeaseKind, name, nil)
+	acquired := false
+	_, err := s.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		now := time.Now()
+		var current datastoreLease
+		err := tx.Get(key, &current)
+		switch {
+		case errors.Is(err, datastore.ErrNoSuchEntity):
+		case err != nil:
+			return err
+		case current.Holder != holder && now.Before(current.Expires):
+			acquired = false
+			return nil
+		}
+		if _, err := tx.Put(key, &datastoreLease{Holder: holder, Expires: now.Add(ttl)}); err != nil {
+			return err
+		}
+		acquired = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("acquire lease: %w", err)
+	}
+	return acquired, nil
+}
+
+// UseEnrollmentToken counts a use of the enrollment token with the specified ID if it was used fewer than maxUses times
+func (s *googleCloudStore) UseEnrollmentToken(ctx context.Context, id string, maxUses int) (bool, error) {
+	key := datastore.NameKey(datastoreEnrollmentTokenUsesKind, id, nil)
+	used := false
+	_, err := s.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		used = false
+		var current datastoreEnrollmentTokenUses
+		err := tx.Get(key, &current)
+		switch {
+		case errors.Is(err, datastore.ErrNoSuchEntity):
+		case err != nil:
+			return err
+		case maxUses > 0 && current.Uses >= maxUses:
+			return nil
+		}
+		current.Uses++
+		if _, err := tx.Put(key, &current); err != nil {
+			return err
+		}
+		used = true
+		return nil
+	})
+	if err != nil {
+		return false, fmt.Errorf("use enrollment token: %w", err)
+	}
+	return used, nil
+}
+
+// RevokeAgent records that the credentials of the agent with the specified ID were revoked
+func (s *googleCloudStore) RevokeAgent(ctx context.Context, agentID string) error {
+	key := datastore.NameKey(datastoreRevokedAgentKind, agentID, nil)
+	if _, err := s.client.Put(ctx, key, &datastoreRevokedAgent{Revoked: time.Now()}); err != nil {
+		return fmt.Errorf("revoke agent: %w", err)
+	}
+	return nil
+}
+
+// AgentRevoked returns true if the credentials of the agent with the specified ID were revoked
+func (s *googleCloudStore) AgentRevoked(ctx context.Context, agentID string) (bool, error) {
+	var revoked datastoreRevokedAgent
+	err := s.client.Get(ctx, datastore.NameKey(datastoreRevokedAgentKind, agentID, nil), &revoked)
+	switch {
+	case errors.Is(err, datastore.ErrNoSuchEntity):
+		return false, nil
+	case err != nil:
+		return false, fmt.Errorf("agent revoked: %w", err)
+	}
+	return true, nil
+}
+
 // Batch delete of a slice of resources, returns the successfully deleted resources or an error.
-func (s *googleCloudStore) DeleteResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+func (s *googleCloudStore) DeleteResources(ctx context.Context, resources []model.Resource, options ...DeleteOption) ([]model.ResourceStatus, error) {
+	if opts := makeDeleteOptions(options); opts.planned() {
+		return deleteResourcesWithOptions(ctx, s, resources, opts)
+	}
+
 	updates := NewUpdates()
 
 	// track deleteStatuses to return
@@ -436,6 +891,10 @@ func (s *googleCloudStore) DeleteResources(ctx context.Context, resources []mode
 
 	for _, r := range resources {
 		deleted, exists, err := deleteAnyDatastoreResource(ctx, s, r)
+		if errors.Is(err, ErrResourceConflict) {
+			deleteStatuses = append(deleteStatuses, *model.NewResourceStatusWithReason(r, model.StatusConflict, err.Error()))
+			continue
+		}
 
 		switch err.(type) {
 		case *DependencyError:
@@ -505,8 +964,23 @@ func (s *googleCloudStore) AgentsIDsMatchingConfiguration(ctx context.Context, c
 
 // CleanupDisconnectedAgents removes agents that have disconnected before the specified time
 func (s *googleCloudStore) CleanupDisconnectedAgents(ctx context.Context, since time.Time) error {
-	// TODO: find agents where status=disconnected and disconnectedAt < since
-	return nil
+	agents, err := s.Agents(ctx)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, agent := range agents {
+		if agent.DisconnectedSince(since) {
+			ids = append(ids, agent.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = s.DeleteAgents(ctx, ids)
+	return err
 }
 
 // Updates will receive pipelines and configurations that have been updated or deleted, either because the
@@ -526,6 +1000,14 @@ func (s *googleCloudStore) ConfigurationIndex(ctx context.Context) search.Index
 	return s.configurationIndex
 }
 
+// ResourceIndex provides access to the search Index for the specified kind of resource
+func (s *googleCloudStore) ResourceIndex(ctx context.Context, kind model.Kind) search.Index {
+	if kind == model.KindConfiguration {
+		return s.configurationIndex
+	}
+	return s.resourceIndexes[kind]
+}
+
 // Measurements stores stats for agents and configurations
 func (s *googleCloudStore) Measurements() stats.Measurements {
 	return nil
@@ -584,11 +1066,12 @@ func (s *googleCloudStore) receivePubsubMessage(ctx context.Context, msg *pubsub
 
 	// update indexes which must be in sync across servers
 	for _, event := range updates.Configurations {
-		updateIndex(s, s.configurationIndex, event)
+		updateIndex(s.logger, s.configurationIndex, event)
 	}
 	for _, event := range updates.Agents {
-		updateIndex(s, s.agentIndex, event)
+		updateIndex(s.logger, s.agentIndex, event)
 	}
+	s.resourceIndexes.update(s.logger, &updates)
 
 	s.updates.Send(&updates)
 }
@@ -598,7 +1081,7 @@ type indexed interface {
 	search.Indexed
 }
 
-func updateIndex[T indexed](s *googleCloudStore, index search.Index, event Event[T]) {
+func updateIndex[T indexed](logger *zap.Logger, index search.Index, event Event[T]) {
 	var err error
 	switch event.Type {
 	case EventTypeRemove:
@@ -607,7 +1090,7 @@ func updateIndex[T indexed](s *googleCloudStore, index search.Index, event Event
 		err = index.Upsert(event.Item)
 	}
 	if err != nil {
-		s.logger.Error("failed to update the search index", zap.String("ID", event.Item.IndexID()), zap.Error(err))
+		logger.Error("failed to update the search index", zap.String("ID", event.Item.IndexID()), zap.Error(err))
 	}
 }
 
@@ -618,6 +1101,42 @@ func datastoreKey(kind model.Kind, uniqueKey string) *datastore.Key {
 	return datastore.NameKey(string(kind), uniqueKey, nil)
 }
 
+// datastoreRevisionKind is the datastore kind used for revisions of resources of the specified kind
+func datastoreRevisionKind(kind model.Kind) string {
+	return fmt.Sprintf("%sRevision", kind)
+}
+
+// datastoreRevisionKey is the key of a revision, which is a child of the resource key
+func datastoreRevisionKey(kind model.Kind, name string, revision int) *datastore.Key {
+	return datastore.IDKey(datastoreRevisionKind(kind), int64(revision), datastoreKey(kind, name))
+}
+
+// datastoreAuditKind is the datastore kind used for audit entries
+const datastoreAuditKind = "Audit"
+
+// datastoreMaxBatchSize is the maximum number of entities that can be written or deleted in a single call
+const datastoreMaxBatchSize = 500
+
+// datastoreAgentHistoryKind is the datastore kind used for agent status changes, which are children of the agent key
+const datastoreAgentHistoryKind = "AgentHistory"
+
+// datastoreRolloutKind is the datastore kind used for rollouts, which are keyed by the name of the configuration
+const datastoreRolloutKind = "Rollout"
+
+// datastoreUpgradeCampaignKind is the datastore kind used for upgrade campaigns, which are keyed by name
+const datastoreUpgradeCampaignKind = "UpgradeCampaign"
+
+// datastoreLeaseKind is the datastore kind used for leases, which are keyed by name
+const datastoreLeaseKind = "Lease"
+
+// datastoreEnrollmentTokenUsesKind is the datastore kind used for the uses of enrollment tokens, which are keyed by the
+// ID of the token
+const datastoreEnrollmentTokenUsesKind = "EnrollmentTokenUses"
+
+// datastoreRevokedAgentKind is the datastore kind used for agents whose credentials were revoked, which are keyed by the
+// agent ID
+const datastoreRevokedAgentKind = "RevokedAgent"
+
 func datastoreQuery(kind model.Kind, opts *queryOptions) *datastore.Query {
 	query := datastore.NewQuery(string(kind))
 	if opts != nil {
@@ -649,6 +1168,46 @@ func datastoreQuery(kind model.Kind, opts *queryOptions) *datastore.Query {
 	return query
 }
 
+// datastoreAuditEntry is the value stored in the datastore for an audit entry
+type datastoreAuditEntry struct {
+	Timestamp time.Time `datastore:"timestamp"`
+	Body      []byte    `datastore:"body,noindex"`
+}
+
+// datastoreAgentStatusChange is the value stored in the datastore for a change in the agent history
+type datastoreAgentStatusChange struct {
+	Timestamp time.Time `datastore:"timestamp"`
+	Body      []byte    `datastore:"body,noindex"`
+}
+
+// datastoreRollout is the value stored in the datastore for a rollout
+type datastoreRollout struct {
+	Name string `datastore:"name"`
+	Body []byte `datastore:"body,noindex"`
+}
+
+// datastoreUpgradeCampaign is the value stored in the datastore for an upgrade campaign
+type datastoreUpgradeCampaign struct {
+	Name string `datastore:"name"`
+	Body []byte `datastore:"body,noindex"`
+}
+
+// datastoreLease is the value stored in the datastore for a lease
+type datastoreLease struct {
+	Holder  string    `datastore:"holder"`
+	Expires time.Time `datastore:"expires"`
+}
+
+// datastoreEnrollmentTokenUses is the value stored in the datastore for the uses of an enrollment token
+type datastoreEnrollmentTokenUses struct {
+	Uses int `datastore:"uses,noindex"`
+}
+
+// datastoreRevokedAgent is the value stored in the datastore for an agent whose credentials were revoked
+type datastoreRevokedAgent struct {
+	Revoked time.Time `datastore:"revoked"`
+}
+
 // datastoreResource is the value stored in the datastore. It is common to all datastore types.
 type datastoreResource struct {
 	Key    *datastore.Key `datastore:"__key__"`
@@ -721,6 +1280,12 @@ func upsertAnyDatastoreResource(ctx context.Context, s *googleCloudStore, r mode
 		return upsertDatastoreResource(ctx, s, r.(*model.Destination))
 	case model.KindDestinationType:
 		return upsertDatastoreResource(ctx, s, r.(*model.DestinationType))
+	case model.KindExtensionType:
+		return upsertDatastoreResource(ctx, s, r.(*model.ExtensionType))
+	case model.KindEnrollmentToken:
+		return upsertDatastoreResource(ctx, s, r.(*model.EnrollmentToken))
+	case model.KindSecret:
+		return upsertDatastoreResource(ctx, s, r.(*model.Secret))
 	default:
 		return model.StatusError, fmt.Errorf("unable to use ApplyResource with %s", string(r.GetKind()))
 	}
@@ -741,28 +1306,96 @@ func upsertDatastoreAgent(ctx context.Context, s *googleCloudStore, agent *model
 }
 
 func upsertDatastoreResource[R model.Resource](ctx context.Context, s *googleCloudStore, r R) (model.UpdateStatus, error) {
-	successStatus := model.StatusCreated
-	existing, exists, err := getDatastoreResource[R](ctx, s, r.GetKind(), r.Name())
-	if err != nil {
+	key := datastoreKey(r.GetKind(), r.Name())
+
+	// the resource is read and its revision is assigned in the same transaction that writes it so that concurrent
+	// changes cannot write the same revision
+	var status model.UpdateStatus
+	_, err := s.client.RunInTransaction(ctx, func(tx *datastore.Transaction) error {
+		status = model.StatusCreated
+
+		var existing datastoreResource
+		err := tx.Get(key, &existing)
+		switch {
+		case errors.Is(err, datastore.ErrNoSuchEntity):
+			if err := checkResourceVersion(r, 0, false); err != nil {
+				status = model.StatusConflict
+				return err
+			}
+		case err != nil:
+			return fmt.Errorf("failed to get the resource: %w", err)
+		default:
+			var current R
+			if err := decodeDatastoreResource(&existing, &current); err != nil {
+				return fmt.Errorf("failed to unmarshal the resource: %w", err)
+			}
+			if err := checkResourceVersion(r, current.Revision(), true); err != nil {
+				status = model.StatusConflict
+				return err
+			}
+			status = model.StatusConfigured
+			// preserve the id and revision (if possible)
+			r.SetID(current.ID())
+			r.SetRevision(current.Revision())
+
+			currentData, err := json.Marshal(current)
+			if err != nil {
+				return fmt.Errorf("failed to marshal the existing resource: %w", err)
+			}
+			data, err := json.Marshal(r)
+			if err != nil {
+				return fmt.Errorf("failed to marshal the resource: %w", err)
+			}
+			if bytes.Equal(currentData, data) {
+				status = model.StatusUnchanged
+				return nil
+			}
+		}
+
+		// the resource changed, store it as a new revision
+		latest, err := latestDatastoreRevision(ctx, s, tx, r.GetKind(), r.Name())
+		if err != nil {
+			return err
+		}
+		r.SetRevision(latest + 1)
+
+		dsr, err := newDatastoreResource(r)
+		if err != nil {
+			return fmt.Errorf("failed to marshal the resource: %w", err)
+		}
+		revision := *dsr
+		revision.Key = datastoreRevisionKey(r.GetKind(), r.Name(), r.Revision())
+
+		if _, err := tx.PutMulti([]*datastore.Key{dsr.Key, revision.Key}, []*datastoreResource{dsr, &revision}); err != nil {
+			return fmt.Errorf("failed to put the resource: %w", err)
+		}
+		return nil
+	})
+	switch {
+	case status == model.StatusConflict:
+		return status, err
+	case err != nil:
 		return model.StatusError, err
-	} else if exists {
-		successStatus = model.StatusConfigured
-		// preserve the id (if possible)
-		r.SetID(existing.ID())
 	}
+	return status, nil
+}
 
-	dsr, err := newDatastoreResource(r)
+// latestDatastoreRevision returns the most recent revision stored for the resource with the specified kind and name or 0
+// if there are no revisions. The revisions are read in the specified transaction.
+func latestDatastoreRevision(ctx context.Context, s *googleCloudStore, tx *datastore.Transaction, kind model.Kind, name string) (int, error) {
+	query := datastore.NewQuery(datastoreRevisionKind(kind)).Ancestor(datastoreKey(kind, name)).KeysOnly().Transaction(tx)
+	keys, err := s.client.GetAll(ctx, query, nil)
 	if err != nil {
-		return model.StatusUnchanged, fmt.Errorf("failed to marshal the resource: %w", err)
+		return 0, fmt.Errorf("failed to get revisions: %w", err)
 	}
 
-	_, err = s.client.Put(ctx, dsr.Key, dsr)
-	if err != nil {
-		return model.StatusUnchanged, fmt.Errorf("failed to put the resource: %w", err)
+	latest := 0
+	for _, key := range keys {
+		if int(key.ID) > latest {
+			latest = int(key.ID)
+		}
 	}
-
-	// note that we don't know if the item existed before
-	return successStatus, err
+	return latest, nil
 }
 
 func getDatastoreResource[R any](ctx context.Context, s *googleCloudStore, kind model.Kind, name string) (resource R, exists bool, err error) {
@@ -784,7 +1417,7 @@ func getDatastoreResource[R any](ctx context.Context, s *googleCloudStore, kind
 }
 
 func deleteDatastoreResourceAndNotify[R model.Resource](ctx context.Context, s *googleCloudStore, kind model.Kind, name string) (resource R, exists bool, err error) {
-	deleted, exists, err := deleteDatastoreResource[R](ctx, s, kind, name)
+	deleted, exists, err := deleteDatastoreResource[R](ctx, s, kind, name, "")
 
 	if err == nil && exists {
 		updates := NewUpdates()
@@ -799,42 +1432,54 @@ func deleteAnyDatastoreResource(ctx context.Context, s *googleCloudStore, r mode
 	// TODO if resource type and kind get out of sync, this will cause issues
 	switch r.GetKind() {
 	case model.KindAgentVersion:
-		return deleteDatastoreResource[*model.AgentVersion](ctx, s, r.GetKind(), r.Name())
+		return deleteDatastoreResource[*model.AgentVersion](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
 	case model.KindConfiguration:
-		return deleteDatastoreResource[*model.Configuration](ctx, s, r.GetKind(), r.Name())
+		return deleteDatastoreResource[*model.Configuration](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
 	case model.KindSource:
-		return deleteDatastoreResource[*model.Source](ctx, s, r.GetKind(), r.Name())
+		return deleteDatastoreResource[*model.Source](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
 	case model.KindSourceType:
-		return deleteDatastoreResource[*model.SourceType](ctx, s, r.GetKind(), r.Name())
+		return deleteDatastoreResource[*model.SourceType](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
 	case model.KindProcessor:
-		return deleteDatastoreResource[*model.Processor](ctx, s, r.GetKind(), r.Name())
+		return deleteDatastoreResource[*model.Processor](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
 	case model.KindProcessorType:
-		return deleteDatastoreResource[*model.ProcessorType](ctx, s, r.GetKind(), r.Name())
+		return deleteDatastoreResource[*model.ProcessorType](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
 	case model.KindDestination:
-		return deleteDatastoreResource[*model.Destination](ctx, s, r.GetKind(), r.Name())
+		return deleteDatastoreResource[*model.Destination](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
 	case model.KindDestinationType:
-		return deleteDatastoreResource[*model.DestinationType](ctx, s, r.GetKind(), r.Name())
+		return deleteDatastoreResource[*model.DestinationType](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
+	case model.KindExtensionType:
+		return deleteDatastoreResource[*model.ExtensionType](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
+	case model.KindEnrollmentToken:
+		return deleteDatastoreResource[*model.EnrollmentToken](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
+	case model.KindSecret:
+		return deleteDatastoreResource[*model.Secret](ctx, s, r.GetKind(), r.Name(), r.ResourceVersion())
 	default:
 		return nil, false, fmt.Errorf("unable to use DeleteResources with %s", string(r.GetKind()))
 	}
 }
 
-func deleteDatastoreResource[R model.Resource](ctx context.Context, s *googleCloudStore, kind model.Kind, name string) (resource R, exists bool, err error) {
+func deleteDatastoreResource[R model.Resource](ctx context.Context, s *googleCloudStore, kind model.Kind, name, version string) (resource R, exists bool, err error) {
 	var dsr datastoreResource
 	if err = s.client.Get(ctx, datastoreKey(kind, name), &dsr); err != nil {
 		if errors.Is(err, datastore.ErrNoSuchEntity) {
-			return resource, false, nil
+			return resource, false, checkMissingVersion(kind, name, version)
 		}
 		return resource, true, fmt.Errorf("failed to check if resource exists: %w", err)
 	}
 	if err = decodeDatastoreResource(&dsr, &resource); err != nil {
 		return resource, true, fmt.Errorf("failed to unmarshal existing resource: %w", err)
 	}
+	if err = checkDeleteVersion(resource, version); err != nil {
+		return resource, true, err
+	}
 
 	// Check if the resources is referenced by another
 	dependencies, err := FindDependentResources(ctx, s, resource)
+	if err != nil {
+		return resource, true, err
+	}
 	if !dependencies.empty() {
-		return resource, true, ErrResourceInUse
+		return resource, true, newDependencyError(dependencies)
 	}
 
 	if err = s.client.Delete(ctx, datastoreKey(kind, name)); err != nil {
@@ -915,6 +1560,48 @@ func getDatastoreResourcesByID[R any](ctx context.Context, s *googleCloudStore,
 	return results, nil
 }
 
+// deleteDatastoreKeys deletes the entities with the keys in batches because the datastore limits the number of entities
+// deleted at once
+func deleteDatastoreKeys(ctx context.Context, s *googleCloudStore, keys []*datastore.Key) error {
+	for len(keys) > 0 {
+		batch := keys
+		if len(batch) > datastoreMaxBatchSize {
+			batch = batch[:datastoreMaxBatchSize]
+		}
+		if err := s.client.DeleteMulti(ctx, batch); err != nil {
+			return err
+		}
+		keys = keys[len(batch):]
+	}
+	return nil
+}
+
+// addDatastoreAgentStatusChanges stores the changes in the history of each agent
+func addDatastoreAgentStatusChanges(ctx context.Context, s *googleCloudStore, changes []*model.AgentStatusChange) error {
+	if len(changes) == 0 {
+		return nil
+	}
+
+	keys := make([]*datastore.Key, 0, len(changes))
+	list := make([]*datastoreAgentStatusChange, 0, len(changes))
+	for _, change := range changes {
+		body, err := json.Marshal(change)
+		if err != nil {
+			return err
+		}
+		keys = append(keys, datastore.IncompleteKey(datastoreAgentHistoryKind, datastoreKey(model.KindAgent, change.AgentID)))
+		list = append(list, &datastoreAgentStatusChange{
+			Timestamp: change.Timestamp,
+			Body:      body,
+		})
+	}
+
+	if _, err := s.client.PutMulti(ctx, keys, list); err != nil {
+		return fmt.Errorf("add agent status changes: %w", err)
+	}
+	return nil
+}
+
 func deleteDatastoreAgents(ctx context.Context, s *googleCloudStore, ids []string) ([]*model.Agent, error) {
 	ctx, span := tracer.Start(ctx, "store/deleteDatastoreAgents")
 	defer span.End()
@@ -934,6 +1621,18 @@ func deleteDatastoreAgents(ctx context.Context, s *googleCloudStore, ids []strin
 		return nil, err
 	}
 
+	// delete the history of each agent
+	for _, key := range keys {
+		query := datastore.NewQuery(datastoreAgentHistoryKind).Ancestor(key).KeysOnly()
+		historyKeys, err := s.client.GetAll(ctx, query, nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get agent history: %w", err)
+		}
+		if err := s.client.DeleteMulti(ctx, historyKeys); err != nil {
+			return nil, fmt.Errorf("failed to delete agent history: %w", err)
+		}
+	}
+
 	// set deleted status on the agents that have been deleted
 	for _, agent := range agents {
 		agent.Status = model.Deleted
@@ -0,0 +1,61 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"sync"
+	"time"
+)
+
+// localLeases holds leases in memory for stores that can only be used by a single server. Leases still expire so
+// that they behave the same as leases stored in a shared database.
+type localLeases struct {
+	leases map[string]localLease
+	mtx    sync.Mutex
+}
+
+type localLease struct {
+	holder  string
+	expires time.Time
+}
+
+func newLocalLeases() *localLeases {
+	return &localLeases{
+		leases: map[string]localLease{},
+	}
+}
+
+// acquire acquires or renews the lease with the specified name for the holder and returns true if the holder has the
+// lease
+func (l *localLeases) acquire(name string, holder string, ttl time.Duration, now time.Time) bool {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	if current, ok := l.leases[name]; ok && current.holder != holder && now.Before(current.expires) {
+		return false
+	}
+	l.leases[name] = localLease{
+		holder:  holder,
+		expires: now.Add(ttl),
+	}
+	return true
+}
+
+func (l *localLeases) clear() {
+	l.mtx.Lock()
+	defer l.mtx.Unlock()
+
+	l.leases = map[string]localLease{}
+}
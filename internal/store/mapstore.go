@@ -16,8 +16,11 @@ package store
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"reflect"
+	"sort"
+	"strconv"
 	"sync"
 	"time"
 
@@ -45,10 +48,35 @@ type mapStore struct {
 	processorTypes   resourceStore[*model.ProcessorType]
 	destinations     resourceStore[*model.Destination]
 	destinationTypes resourceStore[*model.DestinationType]
+	extensionTypes   resourceStore[*model.ExtensionType]
+	enrollmentTokens resourceStore[*model.EnrollmentToken]
+	secrets          resourceStore[*model.Secret]
+
+	// auditEntries are stored oldest first
+	auditEntries  []*model.AuditEntry
+	auditSequence int
+
+	// agentHistory contains the status changes of each agent, oldest first
+	agentHistory map[string][]*model.AgentStatusChange
+
+	// rollouts contains the most recent rollout of each configuration
+	rollouts map[string]*model.Rollout
+
+	// upgradeCampaigns contains the upgrade campaigns by name
+	upgradeCampaigns map[string]*model.UpgradeCampaign
+
+	// enrollmentTokenUses contains the number of uses of each enrollment token by ID
+	enrollmentTokenUses map[string]int
+
+	// revokedAgents contains the IDs of agents whose credentials were revoked
+	revokedAgents map[string]bool
+
+	leases *localLeases
 
 	updates            *storeUpdates
 	agentIndex         search.Index
 	configurationIndex search.Index
+	resourceIndexes    resourceIndexes
 	logger             *zap.Logger
 	sync.RWMutex
 
@@ -60,19 +88,30 @@ var _ Store = (*mapStore)(nil)
 // NewMapStore returns an in memory Store
 func NewMapStore(ctx context.Context, options Options, logger *zap.Logger) Store {
 	return &mapStore{
-		agents:             make(map[string]*model.Agent),
-		configurations:     newResourceStore[*model.Configuration](),
-		sources:            newResourceStore[*model.Source](),
-		sourceTypes:        newResourceStore[*model.SourceType](),
-		processors:         newResourceStore[*model.Processor](),
-		processorTypes:     newResourceStore[*model.ProcessorType](),
-		destinations:       newResourceStore[*model.Destination](),
-		destinationTypes:   newResourceStore[*model.DestinationType](),
-		updates:            newStoreUpdates(ctx, options.MaxEventsToMerge),
-		agentIndex:         search.NewInMemoryIndex("agent"),
-		configurationIndex: search.NewInMemoryIndex("configuration"),
-		logger:             logger,
-		sessionStore:       newBPCookieStore(options.SessionsSecret),
+		agents:              make(map[string]*model.Agent),
+		agentHistory:        make(map[string][]*model.AgentStatusChange),
+		rollouts:            make(map[string]*model.Rollout),
+		upgradeCampaigns:    make(map[string]*model.UpgradeCampaign),
+		enrollmentTokenUses: make(map[string]int),
+		revokedAgents:       make(map[string]bool),
+		leases:              newLocalLeases(),
+		agentVersions:       newResourceStore[*model.AgentVersion](),
+		configurations:      newResourceStore[*model.Configuration](),
+		sources:             newResourceStore[*model.Source](),
+		sourceTypes:         newResourceStore[*model.SourceType](),
+		processors:          newResourceStore[*model.Processor](),
+		processorTypes:      newResourceStore[*model.ProcessorType](),
+		destinations:        newResourceStore[*model.Destination](),
+		destinationTypes:    newResourceStore[*model.DestinationType](),
+		extensionTypes:      newResourceStore[*model.ExtensionType](),
+		enrollmentTokens:    newResourceStore[*model.EnrollmentToken](),
+		secrets:             newResourceStore[*model.Secret](),
+		updates:             newStoreUpdates(ctx, options.MaxEventsToMerge),
+		agentIndex:          search.NewInMemoryIndex("agent"),
+		configurationIndex:  search.NewInMemoryIndex("configuration"),
+		resourceIndexes:     newResourceIndexes(search.NewInMemoryIndex),
+		logger:              logger,
+		sessionStore:        newBPCookieStore(options.SessionsSecret),
 	}
 }
 
@@ -81,12 +120,15 @@ func NewMapStore(ctx context.Context, options Options, logger *zap.Logger) Store
 // resourceStore stores a single type of resource and has its own lock
 type resourceStore[T model.Resource] struct {
 	store map[string]T
-	mtx   sync.RWMutex
+	// history contains copies of every revision of each resource, oldest first
+	history map[string][]T
+	mtx     sync.RWMutex
 }
 
 func newResourceStore[T model.Resource]() resourceStore[T] {
 	return resourceStore[T]{
-		store: map[string]T{},
+		store:   map[string]T{},
+		history: map[string][]T{},
 	}
 }
 
@@ -105,11 +147,19 @@ func (r *resourceStore[T]) add(resource T) *model.ResourceStatus {
 		resource.SetID(uuid.NewString())
 	}
 	existing, ok := r.store[resource.Name()]
+	var existingRevision int
+	if ok {
+		existingRevision = existing.Revision()
+	}
+	if err := checkResourceVersion(resource, existingRevision, ok); err != nil {
+		return model.NewResourceStatusWithReason(resource, model.StatusConflict, err.Error())
+	}
 	if ok && existing.ID() != "" {
 		resource.SetID(existing.ID())
 	}
-
-	r.store[resource.Name()] = resource
+	if ok {
+		resource.SetRevision(existing.Revision())
+	}
 
 	var status model.UpdateStatus
 	switch {
@@ -121,9 +171,43 @@ func (r *resourceStore[T]) add(resource T) *model.ResourceStatus {
 		status = model.StatusUnchanged
 	}
 
+	if status != model.StatusUnchanged {
+		history := r.history[resource.Name()]
+		revision := 1
+		if len(history) > 0 {
+			revision = history[len(history)-1].Revision() + 1
+		}
+		resource.SetRevision(revision)
+
+		revisionCopy, err := cloneResource(resource)
+		if err != nil {
+			return model.NewResourceStatusWithReason(resource, model.StatusError, err.Error())
+		}
+		r.history[resource.Name()] = append(history, revisionCopy)
+	}
+
+	r.store[resource.Name()] = resource
+
 	return model.NewResourceStatus(resource, status)
 }
 
+// revisions returns copies of every revision of the resource with the specified name, oldest first
+func (r *resourceStore[T]) revisions(name string) ([]model.Resource, error) {
+	r.mtx.RLock()
+	defer r.mtx.RUnlock()
+
+	history := r.history[name]
+	results := make([]model.Resource, 0, len(history))
+	for _, revision := range history {
+		revisionCopy, err := cloneResource(revision)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, revisionCopy)
+	}
+	return results, nil
+}
+
 func (r *resourceStore[T]) remove(name string) (item T, exists bool) {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
@@ -154,6 +238,8 @@ func (r *resourceStore[T]) removeAndNotify(ctx context.Context, name string, sto
 	r.mtx.Unlock()
 
 	if ok {
+		store.removeFromIndex(existing)
+
 		updates := NewUpdates()
 		updates.IncludeResource(existing, EventTypeRemove)
 		store.notify(ctx, updates)
@@ -172,6 +258,7 @@ func (r *resourceStore[T]) clear() {
 	r.mtx.Lock()
 	defer r.mtx.Unlock()
 	r.store = map[string]T{}
+	r.history = map[string][]T{}
 }
 
 // ----------------------------------------------------------------------
@@ -187,6 +274,17 @@ func (mapstore *mapStore) Clear() {
 	mapstore.sourceTypes.clear()
 	mapstore.destinations.clear()
 	mapstore.destinationTypes.clear()
+	mapstore.extensionTypes.clear()
+	mapstore.enrollmentTokens.clear()
+	mapstore.secrets.clear()
+
+	mapstore.auditEntries = nil
+	mapstore.agentHistory = make(map[string][]*model.AgentStatusChange)
+	mapstore.rollouts = make(map[string]*model.Rollout)
+	mapstore.upgradeCampaigns = make(map[string]*model.UpgradeCampaign)
+	mapstore.enrollmentTokenUses = make(map[string]int)
+	mapstore.revokedAgents = make(map[string]bool)
+	mapstore.leases.clear()
 }
 
 func (mapstore *mapStore) UpsertAgents(ctx context.Context, agentIDs []string, updater AgentUpdater) ([]*model.Agent, error) {
@@ -225,9 +323,13 @@ func (mapstore *mapStore) Agents(ctx context.Context, options ...QueryOption) ([
 	result := make([]*model.Agent, 0, len(mapstore.agents))
 
 	for _, value := range mapstore.agents {
-		if opts.selector.Matches(value.Labels) {
-			result = append(result, value)
+		if !opts.selector.Matches(value.Labels) {
+			continue
 		}
+		if opts.query != nil && !mapstore.agentIndex.Matches(opts.query, value.ID) {
+			continue
+		}
+		result = append(result, value)
 	}
 
 	if opts.sort == "" {
@@ -272,8 +374,9 @@ func (mapstore *mapStore) DeleteAgents(ctx context.Context, agentIDs []string) (
 			// save the agent to return
 			deleted = append(deleted, agent)
 
-			// delete the agent
+			// delete the agent and its history
 			delete(mapstore.agents, id)
+			delete(mapstore.agentHistory, id)
 
 			// include in the agent updates
 			updates.Agents.Include(agent, EventTypeRemove)
@@ -336,8 +439,8 @@ func (mapstore *mapStore) DeleteConfiguration(ctx context.Context, name string)
 func (mapstore *mapStore) Source(ctx context.Context, name string) (*model.Source, error) {
 	return mapstore.sources.get(name), nil
 }
-func (mapstore *mapStore) Sources(ctx context.Context) ([]*model.Source, error) {
-	return mapstore.sources.list(), nil
+func (mapstore *mapStore) Sources(ctx context.Context, options ...QueryOption) ([]*model.Source, error) {
+	return queryResources(ctx, mapstore.sources.list(), mapstore.resourceIndexes[model.KindSource], makeQueryOptions(options))
 }
 func (mapstore *mapStore) DeleteSource(ctx context.Context, name string) (*model.Source, error) {
 	item, exists, err := mapstore.sources.removeAndNotify(ctx, name, mapstore)
@@ -354,8 +457,8 @@ func (mapstore *mapStore) DeleteSource(ctx context.Context, name string) (*model
 func (mapstore *mapStore) SourceType(ctx context.Context, name string) (*model.SourceType, error) {
 	return mapstore.sourceTypes.get(name), nil
 }
-func (mapstore *mapStore) SourceTypes(ctx context.Context) ([]*model.SourceType, error) {
-	return mapstore.sourceTypes.list(), nil
+func (mapstore *mapStore) SourceTypes(ctx context.Context, options ...QueryOption) ([]*model.SourceType, error) {
+	return queryResources(ctx, mapstore.sourceTypes.list(), mapstore.resourceIndexes[model.KindSourceType], makeQueryOptions(options))
 }
 func (mapstore *mapStore) DeleteSourceType(ctx context.Context, name string) (*model.SourceType, error) {
 	item, exists, err := mapstore.sourceTypes.removeAndNotify(ctx, name, mapstore)
@@ -372,8 +475,8 @@ func (mapstore *mapStore) DeleteSourceType(ctx context.Context, name string) (*m
 func (mapstore *mapStore) Processor(ctx context.Context, name string) (*model.Processor, error) {
 	return mapstore.processors.get(name), nil
 }
-func (mapstore *mapStore) Processors(ctx context.Context) ([]*model.Processor, error) {
-	return mapstore.processors.list(), nil
+func (mapstore *mapStore) Processors(ctx context.Context, options ...QueryOption) ([]*model.Processor, error) {
+	return queryResources(ctx, mapstore.processors.list(), mapstore.resourceIndexes[model.KindProcessor], makeQueryOptions(options))
 }
 func (mapstore *mapStore) DeleteProcessor(ctx context.Context, name string) (*model.Processor, error) {
 	item, exists, err := mapstore.processors.removeAndNotify(ctx, name, mapstore)
@@ -390,8 +493,8 @@ func (mapstore *mapStore) DeleteProcessor(ctx context.Context, name string) (*mo
 func (mapstore *mapStore) ProcessorType(ctx context.Context, name string) (*model.ProcessorType, error) {
 	return mapstore.processorTypes.get(name), nil
 }
-func (mapstore *mapStore) ProcessorTypes(ctx context.Context) ([]*model.ProcessorType, error) {
-	return mapstore.processorTypes.list(), nil
+func (mapstore *mapStore) ProcessorTypes(ctx context.Context, options ...QueryOption) ([]*model.ProcessorType, error) {
+	return queryResources(ctx, mapstore.processorTypes.list(), mapstore.resourceIndexes[model.KindProcessorType], makeQueryOptions(options))
 }
 func (mapstore *mapStore) DeleteProcessorType(ctx context.Context, name string) (*model.ProcessorType, error) {
 	item, exists, err := mapstore.processorTypes.removeAndNotify(ctx, name, mapstore)
@@ -408,8 +511,8 @@ func (mapstore *mapStore) DeleteProcessorType(ctx context.Context, name string)
 func (mapstore *mapStore) Destination(ctx context.Context, name string) (*model.Destination, error) {
 	return mapstore.destinations.get(name), nil
 }
-func (mapstore *mapStore) Destinations(ctx context.Context) ([]*model.Destination, error) {
-	return mapstore.destinations.list(), nil
+func (mapstore *mapStore) Destinations(ctx context.Context, options ...QueryOption) ([]*model.Destination, error) {
+	return queryResources(ctx, mapstore.destinations.list(), mapstore.resourceIndexes[model.KindDestination], makeQueryOptions(options))
 }
 func (mapstore *mapStore) DeleteDestination(ctx context.Context, name string) (*model.Destination, error) {
 	item, exists, err := mapstore.destinations.removeAndNotify(ctx, name, mapstore)
@@ -426,8 +529,8 @@ func (mapstore *mapStore) DeleteDestination(ctx context.Context, name string) (*
 func (mapstore *mapStore) DestinationType(ctx context.Context, name string) (*model.DestinationType, error) {
 	return mapstore.destinationTypes.get(name), nil
 }
-func (mapstore *mapStore) DestinationTypes(ctx context.Context) ([]*model.DestinationType, error) {
-	return mapstore.destinationTypes.list(), nil
+func (mapstore *mapStore) DestinationTypes(ctx context.Context, options ...QueryOption) ([]*model.DestinationType, error) {
+	return queryResources(ctx, mapstore.destinationTypes.list(), mapstore.resourceIndexes[model.KindDestinationType], makeQueryOptions(options))
 }
 func (mapstore *mapStore) DeleteDestinationType(ctx context.Context, name string) (*model.DestinationType, error) {
 	item, exists, err := mapstore.destinationTypes.removeAndNotify(ctx, name, mapstore)
@@ -441,7 +544,64 @@ func (mapstore *mapStore) DeleteDestinationType(ctx context.Context, name string
 	return item, nil
 }
 
+func (mapstore *mapStore) ExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	return mapstore.extensionTypes.get(name), nil
+}
+func (mapstore *mapStore) ExtensionTypes(ctx context.Context, options ...QueryOption) ([]*model.ExtensionType, error) {
+	return queryResources(ctx, mapstore.extensionTypes.list(), mapstore.resourceIndexes[model.KindExtensionType], makeQueryOptions(options))
+}
+func (mapstore *mapStore) DeleteExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	item, exists, err := mapstore.extensionTypes.removeAndNotify(ctx, name, mapstore)
+	if err != nil {
+		return item, err
+	}
+
+	if !exists {
+		return nil, nil
+	}
+	return item, nil
+}
+
+func (mapstore *mapStore) EnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	return mapstore.enrollmentTokens.get(name), nil
+}
+func (mapstore *mapStore) EnrollmentTokens(ctx context.Context) ([]*model.EnrollmentToken, error) {
+	return mapstore.enrollmentTokens.list(), nil
+}
+func (mapstore *mapStore) DeleteEnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	item, exists, err := mapstore.enrollmentTokens.removeAndNotify(ctx, name, mapstore)
+	if err != nil {
+		return item, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	return item, nil
+}
+
+func (mapstore *mapStore) Secret(ctx context.Context, name string) (*model.Secret, error) {
+	return mapstore.secrets.get(name), nil
+}
+func (mapstore *mapStore) Secrets(ctx context.Context) ([]*model.Secret, error) {
+	return mapstore.secrets.list(), nil
+}
+func (mapstore *mapStore) DeleteSecret(ctx context.Context, name string) (*model.Secret, error) {
+	item, exists, err := mapstore.secrets.removeAndNotify(ctx, name, mapstore)
+	if err != nil {
+		return item, err
+	}
+	if !exists {
+		return nil, nil
+	}
+	return item, nil
+}
+
 func (mapstore *mapStore) ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+	// copies are applied so that the resourceVersion of the resources of the caller is unchanged
+	copies := copyResources(resources)
+	defer assignResources(resources, copies)
+	resources = copies
+
 	mapstore.Lock()
 	defer mapstore.Unlock()
 	var result error
@@ -462,8 +622,11 @@ func (mapstore *mapStore) ApplyResources(ctx context.Context, resources []model.
 			resourceStatus = mapstore.agentVersions.add(r)
 		case *model.Configuration:
 			resourceStatus = mapstore.configurations.add(r)
-			if err := mapstore.configurationIndex.Upsert(resourceStatus.Resource); err != nil {
-				mapstore.logger.Error("error updating configuration in the search index", zap.Error(err))
+			switch resourceStatus.Status {
+			case model.StatusCreated, model.StatusConfigured:
+				if err := mapstore.configurationIndex.Upsert(resourceStatus.Resource); err != nil {
+					mapstore.logger.Error("error updating configuration in the search index", zap.Error(err))
+				}
 			}
 		case *model.Source:
 			resourceStatus = mapstore.sources.add(r)
@@ -477,6 +640,12 @@ func (mapstore *mapStore) ApplyResources(ctx context.Context, resources []model.
 			resourceStatus = mapstore.destinations.add(r)
 		case *model.DestinationType:
 			resourceStatus = mapstore.destinationTypes.add(r)
+		case *model.ExtensionType:
+			resourceStatus = mapstore.extensionTypes.add(r)
+		case *model.EnrollmentToken:
+			resourceStatus = mapstore.enrollmentTokens.add(r)
+		case *model.Secret:
+			resourceStatus = mapstore.secrets.add(r)
 		default:
 			resourceStatus = model.NewResourceStatusWithReason(resource, model.StatusInvalid, fmt.Sprintf("unknown resource type in apply: %s", r.Name()))
 		}
@@ -490,6 +659,13 @@ func (mapstore *mapStore) ApplyResources(ctx context.Context, resources []model.
 			case model.StatusConfigured:
 				updates.IncludeResource(resource, EventTypeUpdate)
 			}
+
+			switch resourceStatus.Status {
+			case model.StatusCreated, model.StatusConfigured:
+				if err := mapstore.resourceIndexes.upsert(resourceStatus.Resource); err != nil {
+					mapstore.logger.Error("error updating resource in the search index", zap.Error(err))
+				}
+			}
 		}
 	}
 
@@ -497,7 +673,11 @@ func (mapstore *mapStore) ApplyResources(ctx context.Context, resources []model.
 	return resourceStatuses, result
 }
 
-func (mapstore *mapStore) DeleteResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+func (mapstore *mapStore) DeleteResources(ctx context.Context, resources []model.Resource, options ...DeleteOption) ([]model.ResourceStatus, error) {
+	if opts := makeDeleteOptions(options); opts.planned() {
+		return deleteResourcesWithOptions(ctx, mapstore, resources, opts)
+	}
+
 	mapstore.Lock()
 	defer mapstore.Unlock()
 
@@ -507,6 +687,22 @@ func (mapstore *mapStore) DeleteResources(ctx context.Context, resources []model
 	resourceStatuses := make([]model.ResourceStatus, 0)
 
 	for _, r := range resources {
+		if r.ResourceVersion() != "" {
+			existing, err := GetResource(ctx, mapstore, r.GetKind(), r.Name())
+			if err != nil {
+				mapstore.logger.Error("failed to get the resource", zap.Error(err))
+				continue
+			}
+			err = checkMissingVersion(r.GetKind(), r.Name(), r.ResourceVersion())
+			if existing != nil {
+				err = checkDeleteVersion(existing, r.ResourceVersion())
+			}
+			if err != nil {
+				resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(r, model.StatusConflict, err.Error()))
+				continue
+			}
+		}
+
 		dependencies, err := FindDependentResources(ctx, mapstore, r)
 		if err != nil {
 			mapstore.logger.Error("failed to get dependent resources", zap.Error(err))
@@ -531,8 +727,10 @@ func (mapstore *mapStore) DeleteResources(ctx context.Context, resources []model
 
 		case *model.Configuration:
 			c, e := mapstore.configurations.remove(r.Name())
-			if err := mapstore.configurationIndex.Remove(c); err != nil {
-				mapstore.logger.Error("error removing configuration from the search index", zap.Error(err))
+			if e {
+				if err := mapstore.configurationIndex.Remove(c); err != nil {
+					mapstore.logger.Error("error removing configuration from the search index", zap.Error(err))
+				}
 			}
 			exists = e
 
@@ -554,12 +752,25 @@ func (mapstore *mapStore) DeleteResources(ctx context.Context, resources []model
 		case *model.DestinationType:
 			_, exists = mapstore.destinationTypes.remove(r.Name())
 
+		case *model.ExtensionType:
+			_, exists = mapstore.extensionTypes.remove(r.Name())
+
+		case *model.EnrollmentToken:
+			_, exists = mapstore.enrollmentTokens.remove(r.Name())
+
+		case *model.Secret:
+			_, exists = mapstore.secrets.remove(r.Name())
+
 		default:
 			continue
 		}
 		if exists {
 			resourceStatuses = append(resourceStatuses, *model.NewResourceStatus(r, model.StatusDeleted))
 			updates.IncludeResource(r, EventTypeRemove)
+
+			if err := mapstore.resourceIndexes.remove(r); err != nil {
+				mapstore.logger.Error("error removing resource from the search index", zap.Error(err))
+			}
 		}
 	}
 
@@ -567,6 +778,242 @@ func (mapstore *mapStore) DeleteResources(ctx context.Context, resources []model
 	return resourceStatuses, nil
 }
 
+// ResourceRevisions returns every revision of the resource with the specified kind and name, oldest first.
+func (mapstore *mapStore) ResourceRevisions(ctx context.Context, kind model.Kind, name string) ([]model.Resource, error) {
+	switch kind {
+	case model.KindAgentVersion:
+		return mapstore.agentVersions.revisions(name)
+	case model.KindConfiguration:
+		return mapstore.configurations.revisions(name)
+	case model.KindSource:
+		return mapstore.sources.revisions(name)
+	case model.KindSourceType:
+		return mapstore.sourceTypes.revisions(name)
+	case model.KindProcessor:
+		return mapstore.processors.revisions(name)
+	case model.KindProcessorType:
+		return mapstore.processorTypes.revisions(name)
+	case model.KindDestination:
+		return mapstore.destinations.revisions(name)
+	case model.KindDestinationType:
+		return mapstore.destinationTypes.revisions(name)
+	case model.KindExtensionType:
+		return mapstore.extensionTypes.revisions(name)
+	case model.KindEnrollmentToken:
+		return mapstore.enrollmentTokens.revisions(name)
+	case model.KindSecret:
+		return mapstore.secrets.revisions(name)
+	default:
+		return nil, fmt.Errorf("unable to get revisions of %s", kind)
+	}
+}
+
+// AddAuditEntries stores the audit entries, assigning each an ID and a Timestamp if it does not have one
+func (mapstore *mapStore) AddAuditEntries(ctx context.Context, entries []*model.AuditEntry) error {
+	mapstore.Lock()
+	defer mapstore.Unlock()
+
+	for _, entry := range entries {
+		mapstore.auditSequence++
+		prepareAuditEntry(entry, strconv.Itoa(mapstore.auditSequence))
+		mapstore.auditEntries = append(mapstore.auditEntries, entry)
+	}
+	return nil
+}
+
+// CleanupAuditEntries removes the audit entries recorded before the specified time and the oldest entries beyond
+// maxEntries
+func (mapstore *mapStore) CleanupAuditEntries(ctx context.Context, before time.Time, maxEntries int) error {
+	mapstore.Lock()
+	defer mapstore.Unlock()
+
+	// entries are stored oldest first
+	remove := 0
+	if maxEntries > 0 && len(mapstore.auditEntries) > maxEntries {
+		remove = len(mapstore.auditEntries) - maxEntries
+	}
+	for !before.IsZero() && remove < len(mapstore.auditEntries) && mapstore.auditEntries[remove].Timestamp.Before(before) {
+		remove++
+	}
+	mapstore.auditEntries = append([]*model.AuditEntry(nil), mapstore.auditEntries[remove:]...)
+	return nil
+}
+
+// AuditEntries returns the audit entries matching the filter, newest first
+func (mapstore *mapStore) AuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error) {
+	mapstore.RLock()
+	defer mapstore.RUnlock()
+
+	var entries []*model.AuditEntry
+	for i := len(mapstore.auditEntries) - 1; i >= 0; i-- {
+		if filter.Matches(mapstore.auditEntries[i]) {
+			entries = append(entries, mapstore.auditEntries[i])
+		}
+	}
+	return pageAuditEntries(entries, filter), nil
+}
+
+// AgentHistory returns the status changes of the agent, oldest first
+func (mapstore *mapStore) AgentHistory(ctx context.Context, agentID string) ([]*model.AgentStatusChange, error) {
+	mapstore.RLock()
+	defer mapstore.RUnlock()
+
+	changes := make([]*model.AgentStatusChange, len(mapstore.agentHistory[agentID]))
+	copy(changes, mapstore.agentHistory[agentID])
+	return changes, nil
+}
+
+// CleanupAgentHistory removes the status changes recorded before the specified time and the oldest changes beyond
+// maxChanges of each agent, keeping the most recent change
+func (mapstore *mapStore) CleanupAgentHistory(ctx context.Context, before time.Time, maxChanges int) error {
+	mapstore.Lock()
+	defer mapstore.Unlock()
+
+	for agentID, changes := range mapstore.agentHistory {
+		// changes are stored oldest first
+		remove := 0
+		if maxChanges > 0 && len(changes) > maxChanges {
+			remove = len(changes) - maxChanges
+		}
+		for !before.IsZero() && remove < len(changes)-1 && changes[remove].Timestamp.Before(before) {
+			remove++
+		}
+		if remove > 0 {
+			mapstore.agentHistory[agentID] = append([]*model.AgentStatusChange(nil), changes[remove:]...)
+		}
+	}
+	return nil
+}
+
+// Rollout returns the most recent rollout of the configuration
+func (mapstore *mapStore) Rollout(ctx context.Context, name string) (*model.Rollout, error) {
+	mapstore.RLock()
+	defer mapstore.RUnlock()
+
+	rollout, ok := mapstore.rollouts[name]
+	if !ok {
+		return nil, nil
+	}
+	return copyRollout(rollout), nil
+}
+
+// Rollouts returns the most recent rollout of each configuration, sorted by name
+func (mapstore *mapStore) Rollouts(ctx context.Context) ([]*model.Rollout, error) {
+	mapstore.RLock()
+	defer mapstore.RUnlock()
+
+	rollouts := make([]*model.Rollout, 0, len(mapstore.rollouts))
+	for _, rollout := range mapstore.rollouts {
+		rollouts = append(rollouts, copyRollout(rollout))
+	}
+	sort.Slice(rollouts, func(i, j int) bool {
+		return rollouts[i].Name < rollouts[j].Name
+	})
+	return rollouts, nil
+}
+
+// UpsertRollout stores the rollout, replacing any previous rollout of the same configuration
+func (mapstore *mapStore) UpsertRollout(ctx context.Context, rollout *model.Rollout) error {
+	mapstore.Lock()
+	defer mapstore.Unlock()
+
+	mapstore.rollouts[rollout.Name] = copyRollout(rollout)
+	return nil
+}
+
+// copyRollout copies the rollout so that callers cannot modify the stored rollout
+func copyRollout(rollout *model.Rollout) *model.Rollout {
+	result := *rollout
+	result.Agents = make(map[string]*model.RolloutAgent, len(rollout.Agents))
+	for id, agent := range rollout.Agents {
+		a := *agent
+		result.Agents[id] = &a
+	}
+	return &result
+}
+
+// UpgradeCampaign returns the upgrade campaign with the specified name
+func (mapstore *mapStore) UpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	mapstore.RLock()
+	defer mapstore.RUnlock()
+
+	campaign, ok := mapstore.upgradeCampaigns[name]
+	if !ok {
+		return nil, nil
+	}
+	return copyUpgradeCampaign(campaign), nil
+}
+
+// UpgradeCampaigns returns all of the upgrade campaigns, sorted by name
+func (mapstore *mapStore) UpgradeCampaigns(ctx context.Context) ([]*model.UpgradeCampaign, error) {
+	mapstore.RLock()
+	defer mapstore.RUnlock()
+
+	campaigns := make([]*model.UpgradeCampaign, 0, len(mapstore.upgradeCampaigns))
+	for _, campaign := range mapstore.upgradeCampaigns {
+		campaigns = append(campaigns, copyUpgradeCampaign(campaign))
+	}
+	sort.Slice(campaigns, func(i, j int) bool {
+		return campaigns[i].Name < campaigns[j].Name
+	})
+	return campaigns, nil
+}
+
+// UpsertUpgradeCampaign stores the upgrade campaign, replacing any previous upgrade campaign with the same name
+func (mapstore *mapStore) UpsertUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) error {
+	mapstore.Lock()
+	defer mapstore.Unlock()
+
+	mapstore.upgradeCampaigns[campaign.Name] = copyUpgradeCampaign(campaign)
+	return nil
+}
+
+// AcquireLease acquires or renews the lease with the specified name for the holder until the ttl expires. The mapstore
+// is only used by a single server so the lease is held in memory.
+func (mapstore *mapStore) AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	return mapstore.leases.acquire(name, holder, ttl, time.Now()), nil
+}
+
+// UseEnrollmentToken counts a use of the enrollment token with the specified ID if it was used fewer than maxUses times
+func (mapstore *mapStore) UseEnrollmentToken(ctx context.Context, id string, maxUses int) (bool, error) {
+	mapstore.Lock()
+	defer mapstore.Unlock()
+
+	if maxUses > 0 && mapstore.enrollmentTokenUses[id] >= maxUses {
+		return false, nil
+	}
+	mapstore.enrollmentTokenUses[id]++
+	return true, nil
+}
+
+// RevokeAgent records that the credentials of the agent with the specified ID were revoked
+func (mapstore *mapStore) RevokeAgent(ctx context.Context, agentID string) error {
+	mapstore.Lock()
+	defer mapstore.Unlock()
+
+	mapstore.revokedAgents[agentID] = true
+	return nil
+}
+
+// AgentRevoked returns true if the credentials of the agent with the specified ID were revoked
+func (mapstore *mapStore) AgentRevoked(ctx context.Context, agentID string) (bool, error) {
+	mapstore.RLock()
+	defer mapstore.RUnlock()
+
+	return mapstore.revokedAgents[agentID], nil
+}
+
+// copyUpgradeCampaign copies the upgrade campaign so that callers cannot modify the stored upgrade campaign
+func copyUpgradeCampaign(campaign *model.UpgradeCampaign) *model.UpgradeCampaign {
+	result := *campaign
+	result.Agents = make(map[string]*model.UpgradeCampaignAgent, len(campaign.Agents))
+	for id, agent := range campaign.Agents {
+		a := *agent
+		result.Agents[id] = &a
+	}
+	return &result
+}
+
 // AgentConfiguration returns the configuration that should be applied to an agent.
 func (mapstore *mapStore) AgentConfiguration(ctx context.Context, agentID string) (*model.Configuration, error) {
 	mapstore.RLock()
@@ -610,6 +1057,7 @@ func (mapstore *mapStore) CleanupDisconnectedAgents(ctx context.Context, since t
 	for _, agent := range mapstore.agents {
 		if agent.DisconnectedSince(since) {
 			delete(mapstore.agents, agent.ID)
+			delete(mapstore.agentHistory, agent.ID)
 			updates.IncludeAgent(agent, EventTypeRemove)
 		}
 	}
@@ -629,6 +1077,28 @@ func (mapstore *mapStore) ConfigurationIndex(ctx context.Context) search.Index {
 	return mapstore.configurationIndex
 }
 
+// ResourceIndex provides access to the search Index for the specified kind of resource
+func (mapstore *mapStore) ResourceIndex(ctx context.Context, kind model.Kind) search.Index {
+	if kind == model.KindConfiguration {
+		return mapstore.configurationIndex
+	}
+	return mapstore.resourceIndexes[kind]
+}
+
+// removeFromIndex removes a deleted resource from the search index for its kind
+func (mapstore *mapStore) removeFromIndex(r model.Resource) {
+	index := mapstore.configurationIndex
+	if r.GetKind() != model.KindConfiguration {
+		index = mapstore.resourceIndexes[r.GetKind()]
+	}
+	if index == nil {
+		return
+	}
+	if err := index.Remove(r); err != nil {
+		mapstore.logger.Error("error removing resource from the search index", zap.Error(err))
+	}
+}
+
 func (mapstore *mapStore) UserSessions() sessions.Store {
 	return mapstore.sessionStore
 }
@@ -654,12 +1124,19 @@ func (mapstore *mapStore) upsertAgent(agentID string, updater AgentUpdater, upda
 		agentEventType = EventTypeUpdate
 	}
 
+	exists := agentEventType == EventTypeUpdate
+
 	// compare labels before/after and notify if they change
 	labelsBefore := agent.Labels.String()
+	statusBefore, errorBefore := agent.Status, agent.ErrorMessage
 
 	updater(agent)
 	mapstore.agents[agentID] = agent
 
+	if change := agentStatusChange(agent, exists, statusBefore, errorBefore); change != nil {
+		mapstore.agentHistory[agentID] = append(mapstore.agentHistory[agentID], change)
+	}
+
 	// update the index
 	err := mapstore.agentIndex.Upsert(agent)
 	if err != nil {
@@ -686,6 +1163,20 @@ func (mapstore *mapStore) notify(ctx context.Context, updates *Updates) {
 	}
 }
 
+// cloneResource returns a deep copy of the resource so that stored revisions are not modified by changes to the
+// resource that was applied
+func cloneResource[T model.Resource](resource T) (T, error) {
+	var clone T
+	data, err := json.Marshal(resource)
+	if err != nil {
+		return clone, fmt.Errorf("copy resource: %w", err)
+	}
+	if err := json.Unmarshal(data, &clone); err != nil {
+		return clone, fmt.Errorf("copy resource: %w", err)
+	}
+	return clone, nil
+}
+
 func resourcesEqual(r1 model.Resource, r2 model.Resource) bool {
 	r1Any := &model.AnyResource{}
 	r2Any := &model.AnyResource{}
@@ -743,3 +1234,8 @@ func (mapstore *mapStore) MeasurementsSize() (int, error) {
 func (mapstore *mapStore) ProcessMetrics(ctx context.Context) error {
 	return nil
 }
+
+// ExportMeasurements returns all of the saved measurements
+func (mapstore *mapStore) ExportMeasurements(ctx context.Context) (stats.MetricData, error) {
+	return nil, nil
+}
@@ -145,6 +145,13 @@ func TestMapstoreDependentResources(t *testing.T) {
 	runDependentResourcesTests(t, store)
 }
 
+func TestMapstoreCascadeDelete(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runCascadeDeleteTests(t, store)
+}
+
 func TestMapstoreIndividualDelete(t *testing.T) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
@@ -179,3 +186,87 @@ func TestMapstoreUpsertAgents(t *testing.T) {
 // 	store := NewMapStore(ctx, testOptions, zap.NewNop())
 // 	runTestMeasurements(t, store)
 // }
+
+func TestMapstoreResourceRevisions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runResourceRevisionsTests(t, store)
+}
+
+func TestMapstoreResourceVersion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runResourceVersionTests(t, store)
+}
+
+func TestMapstoreResourceQuery(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runResourceQueryTests(t, store)
+}
+
+func TestMapstoreAudit(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runAuditTests(t, store)
+}
+
+func TestMapstoreAgentHistory(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runAgentHistoryTests(t, store)
+}
+
+func TestMapstoreRequestAgentRestarts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runRequestAgentRestartsTests(t, store)
+}
+
+func TestMapstoreEnrollmentTokens(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runEnrollmentTokenTests(t, store)
+}
+
+func TestMapstoreSecrets(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runSecretTests(t, store)
+}
+
+func TestMapstoreRollouts(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runRolloutTests(t, store)
+}
+
+func TestMapstoreUpgradeCampaigns(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runUpgradeCampaignTests(t, store)
+}
+
+func TestMapstoreLeases(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runLeaseTests(t, store)
+}
+
+func TestMapstoreCleanupDisconnectedAgents(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	store := NewMapStore(ctx, testOptions, zap.NewNop())
+	runCleanupDisconnectedAgentsTests(t, store)
+}
@@ -26,6 +26,41 @@ type Store struct {
 	mock.Mock
 }
 
+// AcquireLease provides a mock function with given fields: ctx, name, holder, ttl
+func (_m *Store) AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	ret := _m.Called(ctx, name, holder, ttl)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, string, time.Duration) bool); ok {
+		r0 = rf(ctx, name, holder, ttl)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, string, time.Duration) error); ok {
+		r1 = rf(ctx, name, holder, ttl)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// AddAuditEntries provides a mock function with given fields: ctx, entries
+func (_m *Store) AddAuditEntries(ctx context.Context, entries []*model.AuditEntry) error {
+	ret := _m.Called(ctx, entries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, []*model.AuditEntry) error); ok {
+		r0 = rf(ctx, entries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // Agent provides a mock function with given fields: ctx, name
 func (_m *Store) Agent(ctx context.Context, name string) (*model.Agent, error) {
 	ret := _m.Called(ctx, name)
@@ -72,6 +107,29 @@ func (_m *Store) AgentConfiguration(ctx context.Context, agentID string) (*model
 	return r0, r1
 }
 
+// AgentHistory provides a mock function with given fields: ctx, agentID
+func (_m *Store) AgentHistory(ctx context.Context, agentID string) ([]*model.AgentStatusChange, error) {
+	ret := _m.Called(ctx, agentID)
+
+	var r0 []*model.AgentStatusChange
+	if rf, ok := ret.Get(0).(func(context.Context, string) []*model.AgentStatusChange); ok {
+		r0 = rf(ctx, agentID)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AgentStatusChange)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, agentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AgentIndex provides a mock function with given fields: ctx
 func (_m *Store) AgentIndex(ctx context.Context) search.Index {
 	ret := _m.Called(ctx)
@@ -88,6 +146,27 @@ func (_m *Store) AgentIndex(ctx context.Context) search.Index {
 	return r0
 }
 
+// AgentRevoked provides a mock function with given fields: ctx, agentID
+func (_m *Store) AgentRevoked(ctx context.Context, agentID string) (bool, error) {
+	ret := _m.Called(ctx, agentID)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string) bool); ok {
+		r0 = rf(ctx, agentID)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, agentID)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // AgentVersion provides a mock function with given fields: ctx, name
 func (_m *Store) AgentVersion(ctx context.Context, name string) (*model.AgentVersion, error) {
 	ret := _m.Called(ctx, name)
@@ -238,6 +317,57 @@ func (_m *Store) ApplyResources(ctx context.Context, resources []model.Resource)
 	return r0, r1
 }
 
+// AuditEntries provides a mock function with given fields: ctx, filter
+func (_m *Store) AuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error) {
+	ret := _m.Called(ctx, filter)
+
+	var r0 []*model.AuditEntry
+	if rf, ok := ret.Get(0).(func(context.Context, model.AuditFilter) []*model.AuditEntry); ok {
+		r0 = rf(ctx, filter)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.AuditEntry)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.AuditFilter) error); ok {
+		r1 = rf(ctx, filter)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// CleanupAgentHistory provides a mock function with given fields: ctx, before, maxChanges
+func (_m *Store) CleanupAgentHistory(ctx context.Context, before time.Time, maxChanges int) error {
+	ret := _m.Called(ctx, before, maxChanges)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) error); ok {
+		r0 = rf(ctx, before, maxChanges)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// CleanupAuditEntries provides a mock function with given fields: ctx, before, maxEntries
+func (_m *Store) CleanupAuditEntries(ctx context.Context, before time.Time, maxEntries int) error {
+	ret := _m.Called(ctx, before, maxEntries)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, time.Time, int) error); ok {
+		r0 = rf(ctx, before, maxEntries)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
 // CleanupDisconnectedAgents provides a mock function with given fields: ctx, since
 func (_m *Store) CleanupDisconnectedAgents(ctx context.Context, since time.Time) error {
 	ret := _m.Called(ctx, since)
@@ -441,6 +571,52 @@ func (_m *Store) DeleteDestinationType(ctx context.Context, name string) (*model
 	return r0, r1
 }
 
+// DeleteEnrollmentToken provides a mock function with given fields: ctx, name
+func (_m *Store) DeleteEnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.EnrollmentToken
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.EnrollmentToken); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EnrollmentToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteExtensionType provides a mock function with given fields: ctx, name
+func (_m *Store) DeleteExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.ExtensionType
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ExtensionType); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExtensionType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // DeleteProcessor provides a mock function with given fields: ctx, name
 func (_m *Store) DeleteProcessor(ctx context.Context, name string) (*model.Processor, error) {
 	ret := _m.Called(ctx, name)
@@ -487,13 +663,20 @@ func (_m *Store) DeleteProcessorType(ctx context.Context, name string) (*model.P
 	return r0, r1
 }
 
-// DeleteResources provides a mock function with given fields: ctx, resources
-func (_m *Store) DeleteResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
-	ret := _m.Called(ctx, resources)
+// DeleteResources provides a mock function with given fields: ctx, resources, options
+func (_m *Store) DeleteResources(ctx context.Context, resources []model.Resource, options ...store.DeleteOption) ([]model.ResourceStatus, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx, resources)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []model.ResourceStatus
-	if rf, ok := ret.Get(0).(func(context.Context, []model.Resource) []model.ResourceStatus); ok {
-		r0 = rf(ctx, resources)
+	if rf, ok := ret.Get(0).(func(context.Context, []model.Resource, ...store.DeleteOption) []model.ResourceStatus); ok {
+		r0 = rf(ctx, resources, options...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]model.ResourceStatus)
@@ -501,8 +684,31 @@ func (_m *Store) DeleteResources(ctx context.Context, resources []model.Resource
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context, []model.Resource) error); ok {
-		r1 = rf(ctx, resources)
+	if rf, ok := ret.Get(1).(func(context.Context, []model.Resource, ...store.DeleteOption) error); ok {
+		r1 = rf(ctx, resources, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// DeleteSecret provides a mock function with given fields: ctx, name
+func (_m *Store) DeleteSecret(ctx context.Context, name string) (*model.Secret, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Secret
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Secret); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Secret)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -602,13 +808,20 @@ func (_m *Store) DestinationType(ctx context.Context, name string) (*model.Desti
 	return r0, r1
 }
 
-// DestinationTypes provides a mock function with given fields: ctx
-func (_m *Store) DestinationTypes(ctx context.Context) ([]*model.DestinationType, error) {
-	ret := _m.Called(ctx)
+// DestinationTypes provides a mock function with given fields: ctx, options
+func (_m *Store) DestinationTypes(ctx context.Context, options ...store.QueryOption) ([]*model.DestinationType, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*model.DestinationType
-	if rf, ok := ret.Get(0).(func(context.Context) []*model.DestinationType); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []*model.DestinationType); ok {
+		r0 = rf(ctx, options...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.DestinationType)
@@ -616,8 +829,8 @@ func (_m *Store) DestinationTypes(ctx context.Context) ([]*model.DestinationType
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) error); ok {
+		r1 = rf(ctx, options...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -625,19 +838,72 @@ func (_m *Store) DestinationTypes(ctx context.Context) ([]*model.DestinationType
 	return r0, r1
 }
 
-// Destinations provides a mock function with given fields: ctx
-func (_m *Store) Destinations(ctx context.Context) ([]*model.Destination, error) {
-	ret := _m.Called(ctx)
+// Destinations provides a mock function with given fields: ctx, options
+func (_m *Store) Destinations(ctx context.Context, options ...store.QueryOption) ([]*model.Destination, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*model.Destination
-	if rf, ok := ret.Get(0).(func(context.Context) []*model.Destination); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []*model.Destination); ok {
+		r0 = rf(ctx, options...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Destination)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) error); ok {
+		r1 = rf(ctx, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnrollmentToken provides a mock function with given fields: ctx, name
+func (_m *Store) EnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.EnrollmentToken
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.EnrollmentToken); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.EnrollmentToken)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// EnrollmentTokens provides a mock function with given fields: ctx
+func (_m *Store) EnrollmentTokens(ctx context.Context) ([]*model.EnrollmentToken, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.EnrollmentToken
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.EnrollmentToken); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.EnrollmentToken)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
 		r1 = rf(ctx)
@@ -648,6 +914,59 @@ func (_m *Store) Destinations(ctx context.Context) ([]*model.Destination, error)
 	return r0, r1
 }
 
+// ExtensionType provides a mock function with given fields: ctx, name
+func (_m *Store) ExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.ExtensionType
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.ExtensionType); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.ExtensionType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ExtensionTypes provides a mock function with given fields: ctx, options
+func (_m *Store) ExtensionTypes(ctx context.Context, options ...store.QueryOption) ([]*model.ExtensionType, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []*model.ExtensionType
+	if rf, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []*model.ExtensionType); ok {
+		r0 = rf(ctx, options...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.ExtensionType)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) error); ok {
+		r1 = rf(ctx, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // Measurements provides a mock function with given fields:
 func (_m *Store) Measurements() stats.Measurements {
 	ret := _m.Called()
@@ -710,19 +1029,155 @@ func (_m *Store) ProcessorType(ctx context.Context, name string) (*model.Process
 	return r0, r1
 }
 
-// ProcessorTypes provides a mock function with given fields: ctx
-func (_m *Store) ProcessorTypes(ctx context.Context) ([]*model.ProcessorType, error) {
-	ret := _m.Called(ctx)
+// ProcessorTypes provides a mock function with given fields: ctx, options
+func (_m *Store) ProcessorTypes(ctx context.Context, options ...store.QueryOption) ([]*model.ProcessorType, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*model.ProcessorType
-	if rf, ok := ret.Get(0).(func(context.Context) []*model.ProcessorType); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []*model.ProcessorType); ok {
+		r0 = rf(ctx, options...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.ProcessorType)
 		}
 	}
 
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) error); ok {
+		r1 = rf(ctx, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Processors provides a mock function with given fields: ctx, options
+func (_m *Store) Processors(ctx context.Context, options ...store.QueryOption) ([]*model.Processor, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
+
+	var r0 []*model.Processor
+	if rf, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []*model.Processor); ok {
+		r0 = rf(ctx, options...)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Processor)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) error); ok {
+		r1 = rf(ctx, options...)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// ResourceIndex provides a mock function with given fields: ctx, kind
+func (_m *Store) ResourceIndex(ctx context.Context, kind model.Kind) search.Index {
+	ret := _m.Called(ctx, kind)
+
+	var r0 search.Index
+	if rf, ok := ret.Get(0).(func(context.Context, model.Kind) search.Index); ok {
+		r0 = rf(ctx, kind)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(search.Index)
+		}
+	}
+
+	return r0
+}
+
+// ResourceRevisions provides a mock function with given fields: ctx, kind, name
+func (_m *Store) ResourceRevisions(ctx context.Context, kind model.Kind, name string) ([]model.Resource, error) {
+	ret := _m.Called(ctx, kind, name)
+
+	var r0 []model.Resource
+	if rf, ok := ret.Get(0).(func(context.Context, model.Kind, string) []model.Resource); ok {
+		r0 = rf(ctx, kind, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]model.Resource)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, model.Kind, string) error); ok {
+		r1 = rf(ctx, kind, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// RevokeAgent provides a mock function with given fields: ctx, agentID
+func (_m *Store) RevokeAgent(ctx context.Context, agentID string) error {
+	ret := _m.Called(ctx, agentID)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, string) error); ok {
+		r0 = rf(ctx, agentID)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// Rollout provides a mock function with given fields: ctx, name
+func (_m *Store) Rollout(ctx context.Context, name string) (*model.Rollout, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Rollout
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Rollout); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Rollout)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Rollouts provides a mock function with given fields: ctx
+func (_m *Store) Rollouts(ctx context.Context) ([]*model.Rollout, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.Rollout
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Rollout); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.Rollout)
+		}
+	}
+
 	var r1 error
 	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
 		r1 = rf(ctx)
@@ -733,16 +1188,39 @@ func (_m *Store) ProcessorTypes(ctx context.Context) ([]*model.ProcessorType, er
 	return r0, r1
 }
 
-// Processors provides a mock function with given fields: ctx
-func (_m *Store) Processors(ctx context.Context) ([]*model.Processor, error) {
+// Secret provides a mock function with given fields: ctx, name
+func (_m *Store) Secret(ctx context.Context, name string) (*model.Secret, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.Secret
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.Secret); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.Secret)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// Secrets provides a mock function with given fields: ctx
+func (_m *Store) Secrets(ctx context.Context) ([]*model.Secret, error) {
 	ret := _m.Called(ctx)
 
-	var r0 []*model.Processor
-	if rf, ok := ret.Get(0).(func(context.Context) []*model.Processor); ok {
+	var r0 []*model.Secret
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.Secret); ok {
 		r0 = rf(ctx)
 	} else {
 		if ret.Get(0) != nil {
-			r0 = ret.Get(0).([]*model.Processor)
+			r0 = ret.Get(0).([]*model.Secret)
 		}
 	}
 
@@ -802,13 +1280,20 @@ func (_m *Store) SourceType(ctx context.Context, name string) (*model.SourceType
 	return r0, r1
 }
 
-// SourceTypes provides a mock function with given fields: ctx
-func (_m *Store) SourceTypes(ctx context.Context) ([]*model.SourceType, error) {
-	ret := _m.Called(ctx)
+// SourceTypes provides a mock function with given fields: ctx, options
+func (_m *Store) SourceTypes(ctx context.Context, options ...store.QueryOption) ([]*model.SourceType, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*model.SourceType
-	if rf, ok := ret.Get(0).(func(context.Context) []*model.SourceType); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []*model.SourceType); ok {
+		r0 = rf(ctx, options...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.SourceType)
@@ -816,8 +1301,8 @@ func (_m *Store) SourceTypes(ctx context.Context) ([]*model.SourceType, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) error); ok {
+		r1 = rf(ctx, options...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -825,13 +1310,20 @@ func (_m *Store) SourceTypes(ctx context.Context) ([]*model.SourceType, error) {
 	return r0, r1
 }
 
-// Sources provides a mock function with given fields: ctx
-func (_m *Store) Sources(ctx context.Context) ([]*model.Source, error) {
-	ret := _m.Called(ctx)
+// Sources provides a mock function with given fields: ctx, options
+func (_m *Store) Sources(ctx context.Context, options ...store.QueryOption) ([]*model.Source, error) {
+	_va := make([]interface{}, len(options))
+	for _i := range options {
+		_va[_i] = options[_i]
+	}
+	var _ca []interface{}
+	_ca = append(_ca, ctx)
+	_ca = append(_ca, _va...)
+	ret := _m.Called(_ca...)
 
 	var r0 []*model.Source
-	if rf, ok := ret.Get(0).(func(context.Context) []*model.Source); ok {
-		r0 = rf(ctx)
+	if rf, ok := ret.Get(0).(func(context.Context, ...store.QueryOption) []*model.Source); ok {
+		r0 = rf(ctx, options...)
 	} else {
 		if ret.Get(0) != nil {
 			r0 = ret.Get(0).([]*model.Source)
@@ -839,8 +1331,8 @@ func (_m *Store) Sources(ctx context.Context) ([]*model.Source, error) {
 	}
 
 	var r1 error
-	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
-		r1 = rf(ctx)
+	if rf, ok := ret.Get(1).(func(context.Context, ...store.QueryOption) error); ok {
+		r1 = rf(ctx, options...)
 	} else {
 		r1 = ret.Error(1)
 	}
@@ -864,6 +1356,52 @@ func (_m *Store) Updates() eventbus.Source[*store.Updates] {
 	return r0
 }
 
+// UpgradeCampaign provides a mock function with given fields: ctx, name
+func (_m *Store) UpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	ret := _m.Called(ctx, name)
+
+	var r0 *model.UpgradeCampaign
+	if rf, ok := ret.Get(0).(func(context.Context, string) *model.UpgradeCampaign); ok {
+		r0 = rf(ctx, name)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(*model.UpgradeCampaign)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string) error); ok {
+		r1 = rf(ctx, name)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
+// UpgradeCampaigns provides a mock function with given fields: ctx
+func (_m *Store) UpgradeCampaigns(ctx context.Context) ([]*model.UpgradeCampaign, error) {
+	ret := _m.Called(ctx)
+
+	var r0 []*model.UpgradeCampaign
+	if rf, ok := ret.Get(0).(func(context.Context) []*model.UpgradeCampaign); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).([]*model.UpgradeCampaign)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UpsertAgent provides a mock function with given fields: ctx, agentID, updater
 func (_m *Store) UpsertAgent(ctx context.Context, agentID string, updater store.AgentUpdater) (*model.Agent, error) {
 	ret := _m.Called(ctx, agentID, updater)
@@ -910,6 +1448,55 @@ func (_m *Store) UpsertAgents(ctx context.Context, agentIDs []string, updater st
 	return r0, r1
 }
 
+// UpsertRollout provides a mock function with given fields: ctx, rollout
+func (_m *Store) UpsertRollout(ctx context.Context, rollout *model.Rollout) error {
+	ret := _m.Called(ctx, rollout)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.Rollout) error); ok {
+		r0 = rf(ctx, rollout)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UpsertUpgradeCampaign provides a mock function with given fields: ctx, campaign
+func (_m *Store) UpsertUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) error {
+	ret := _m.Called(ctx, campaign)
+
+	var r0 error
+	if rf, ok := ret.Get(0).(func(context.Context, *model.UpgradeCampaign) error); ok {
+		r0 = rf(ctx, campaign)
+	} else {
+		r0 = ret.Error(0)
+	}
+
+	return r0
+}
+
+// UseEnrollmentToken provides a mock function with given fields: ctx, id, maxUses
+func (_m *Store) UseEnrollmentToken(ctx context.Context, id string, maxUses int) (bool, error) {
+	ret := _m.Called(ctx, id, maxUses)
+
+	var r0 bool
+	if rf, ok := ret.Get(0).(func(context.Context, string, int) bool); ok {
+		r0 = rf(ctx, id, maxUses)
+	} else {
+		r0 = ret.Get(0).(bool)
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context, string, int) error); ok {
+		r1 = rf(ctx, id, maxUses)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // UserSessions provides a mock function with given fields:
 func (_m *Store) UserSessions() sessions.Store {
 	ret := _m.Called()
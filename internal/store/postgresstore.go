@@ -0,0 +1,1988 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/sessions"
+	"github.com/hashicorp/go-multierror"
+	"github.com/lib/pq"
+	"go.opentelemetry.io/otel/attribute"
+	"go.uber.org/zap"
+
+	"github.com/observiq/bindplane-op/common"
+	"github.com/observiq/bindplane-op/internal/eventbus"
+	"github.com/observiq/bindplane-op/internal/otlp/record"
+	"github.com/observiq/bindplane-op/internal/store/search"
+	"github.com/observiq/bindplane-op/internal/store/stats"
+	"github.com/observiq/bindplane-op/model"
+)
+
+// postgresUpdatesChannel is the LISTEN/NOTIFY channel used to send Updates to every server using the database
+const postgresUpdatesChannel = "bindplane_updates"
+
+// postgresUpdatesRetention is how long Updates are kept in the updates table so that they can be read by listeners
+const postgresUpdatesRetention = 5 * time.Minute
+
+// postgresSchema is applied when the store is created. Resources, revisions, and agents are stored as json documents
+// keyed the same way as the bbolt store. Each measurement is stored twice, once to be looked up by agent and once to be
+// looked up by configuration.
+var postgresSchema = []string{
+	`CREATE TABLE IF NOT EXISTS resources (
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		data JSONB NOT NULL,
+		PRIMARY KEY (kind, name)
+	)`,
+	`CREATE TABLE IF NOT EXISTS revisions (
+		kind TEXT NOT NULL,
+		name TEXT NOT NULL,
+		revision INTEGER NOT NULL,
+		data JSONB NOT NULL,
+		PRIMARY KEY (kind, name, revision)
+	)`,
+	`CREATE TABLE IF NOT EXISTS agents (
+		id TEXT NOT NULL PRIMARY KEY,
+		data JSONB NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS measurements (
+		metric TEXT NOT NULL,
+		object_type TEXT NOT NULL,
+		object_id TEXT NOT NULL,
+		timestamp TIMESTAMPTZ NOT NULL,
+		series TEXT NOT NULL,
+		data JSONB NOT NULL,
+		PRIMARY KEY (metric, object_type, object_id, timestamp, series)
+	)`,
+	`CREATE TABLE IF NOT EXISTS audit (
+		id BIGSERIAL PRIMARY KEY,
+		data JSONB NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS agent_history (
+		id BIGSERIAL PRIMARY KEY,
+		agent_id TEXT NOT NULL,
+		data JSONB NOT NULL
+	)`,
+	`CREATE INDEX IF NOT EXISTS agent_history_agent_id ON agent_history (agent_id)`,
+	`CREATE TABLE IF NOT EXISTS rollouts (
+		name TEXT NOT NULL PRIMARY KEY,
+		data JSONB NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS upgrade_campaigns (
+		name TEXT NOT NULL PRIMARY KEY,
+		data JSONB NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS leases (
+		name TEXT NOT NULL PRIMARY KEY,
+		holder TEXT NOT NULL,
+		expires TIMESTAMPTZ NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS enrollment_token_uses (
+		id TEXT NOT NULL PRIMARY KEY,
+		uses INTEGER NOT NULL
+	)`,
+	`CREATE TABLE IF NOT EXISTS revoked_agents (
+		id TEXT NOT NULL PRIMARY KEY,
+		revoked TIMESTAMPTZ NOT NULL DEFAULT NOW()
+	)`,
+	`CREATE TABLE IF NOT EXISTS updates (
+		id BIGSERIAL PRIMARY KEY,
+		created TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+		data JSONB NOT NULL
+	)`,
+}
+
+type postgresStore struct {
+	db                 *sql.DB
+	updates            *storeUpdates
+	agentIndex         search.Index
+	configurationIndex search.Index
+	resourceIndexes    resourceIndexes
+	logger             *zap.Logger
+
+	sessionStorage sessions.Store
+}
+
+var _ Store = (*postgresStore)(nil)
+
+// NewPostgresStore creates a new store that uses PostgreSQL for storage and LISTEN/NOTIFY for events. Multiple servers
+// can share the same database and each will receive all Updates.
+func NewPostgresStore(ctx context.Context, cfg *common.Postgres, options Options, logger *zap.Logger) (Store, error) {
+	connectionString := postgresConnectionString(cfg)
+
+	db, err := sql.Open("postgres", connectionString)
+	if err != nil {
+		return nil, fmt.Errorf("open postgres: %w", err)
+	}
+	if cfg != nil && cfg.MaxConnections > 0 {
+		db.SetMaxOpenConns(cfg.MaxConnections)
+	}
+	if err := db.PingContext(ctx); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("connect to postgres: %w", err)
+	}
+	if err := initPostgresSchema(ctx, db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	s := &postgresStore{
+		db:                 db,
+		updates:            newStoreUpdates(ctx, options.MaxEventsToMerge),
+		agentIndex:         search.NewInMemoryIndex("agent"),
+		configurationIndex: search.NewInMemoryIndex("configuration"),
+		resourceIndexes:    newResourceIndexes(search.NewInMemoryIndex),
+		logger:             logger,
+
+		sessionStorage: newBPCookieStore(options.SessionsSecret),
+	}
+
+	// the indexes are kept in memory and must be populated from the database
+	if err := s.loadIndexes(ctx); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	listener := pq.NewListener(connectionString, time.Second, time.Minute, func(event pq.ListenerEventType, err error) {
+		if err != nil {
+			logger.Error("postgres listener error", zap.Error(err))
+		}
+	})
+	if err := listener.Listen(postgresUpdatesChannel); err != nil {
+		listener.Close()
+		db.Close()
+		return nil, fmt.Errorf("listen for postgres notifications: %w", err)
+	}
+
+	// start listening for events
+	go s.receiveNotifications(ctx, listener)
+
+	// start the timer that runs cleanup on measurements and updates
+	s.startMaintenance(ctx)
+
+	return s, nil
+}
+
+// postgresConnectionString returns a connection url for the configuration, using defaults for any missing values
+func postgresConnectionString(cfg *common.Postgres) string {
+	if cfg == nil {
+		cfg = &common.Postgres{}
+	}
+
+	host := cfg.Host
+	if host == "" {
+		host = "localhost"
+	}
+	port := cfg.Port
+	if port == "" {
+		port = "5432"
+	}
+	database := cfg.Database
+	if database == "" {
+		database = "bindplane"
+	}
+	sslMode := cfg.SSLMode
+	if sslMode == "" {
+		sslMode = "require"
+	}
+
+	u := url.URL{
+		Scheme:   "postgres",
+		Host:     net.JoinHostPort(host, port),
+		Path:     "/" + database,
+		RawQuery: url.Values{"sslmode": []string{sslMode}}.Encode(),
+	}
+	switch {
+	case cfg.Username != "" && cfg.Password != "":
+		u.User = url.UserPassword(cfg.Username, cfg.Password)
+	case cfg.Username != "":
+		u.User = url.User(cfg.Username)
+	}
+	return u.String()
+}
+
+func initPostgresSchema(ctx context.Context, db *sql.DB) error {
+	for _, statement := range postgresSchema {
+		if _, err := db.ExecContext(ctx, statement); err != nil {
+			return fmt.Errorf("create postgres schema: %w", err)
+		}
+	}
+	return nil
+}
+
+// loadIndexes adds all agents and configurations to the search indexes
+func (s *postgresStore) loadIndexes(ctx context.Context) error {
+	agents, err := s.Agents(ctx)
+	if err != nil {
+		return fmt.Errorf("load agent index: %w", err)
+	}
+	for _, agent := range agents {
+		if err := s.agentIndex.Upsert(agent); err != nil {
+			s.logger.Error("failed to update the search index", zap.String("agentID", agent.ID))
+		}
+	}
+
+	configurations, err := s.Configurations(ctx)
+	if err != nil {
+		return fmt.Errorf("load configuration index: %w", err)
+	}
+	for _, configuration := range configurations {
+		if err := s.configurationIndex.Upsert(configuration); err != nil {
+			s.logger.Error("failed to update the search index", zap.String("configuration", configuration.Name()))
+		}
+	}
+
+	loaders := map[model.Kind]func(context.Context, *postgresStore, model.Kind) error{
+		model.KindSource:          loadPostgresResourceIndex[*model.Source],
+		model.KindSourceType:      loadPostgresResourceIndex[*model.SourceType],
+		model.KindProcessor:       loadPostgresResourceIndex[*model.Processor],
+		model.KindProcessorType:   loadPostgresResourceIndex[*model.ProcessorType],
+		model.KindDestination:     loadPostgresResourceIndex[*model.Destination],
+		model.KindDestinationType: loadPostgresResourceIndex[*model.DestinationType],
+		model.KindExtensionType:   loadPostgresResourceIndex[*model.ExtensionType],
+	}
+	for kind, load := range loaders {
+		if err := load(ctx, s, kind); err != nil {
+			return fmt.Errorf("load %s index: %w", kind, err)
+		}
+	}
+	return nil
+}
+
+// loadPostgresResourceIndex populates the search index for the specified kind of resource
+func loadPostgresResourceIndex[R model.Resource](ctx context.Context, s *postgresStore, kind model.Kind) error {
+	items, err := postgresResources[R](ctx, s, kind)
+	if err != nil {
+		return err
+	}
+	for _, item := range items {
+		if err := s.resourceIndexes.upsert(item); err != nil {
+			s.logger.Error("failed to update the search index", zap.String("kind", string(kind)), zap.String("name", item.Name()))
+		}
+	}
+	return nil
+}
+
+// Clear clears the database of resources, revisions, agents, agent history, measurements, audit entries, and updates. Mostly used for
+// testing.
+func (s *postgresStore) Clear() {
+	_, err := s.db.Exec("TRUNCATE resources, revisions, agents, agent_history, rollouts, upgrade_campaigns, leases, enrollment_token_uses, revoked_agents, measurements, audit, updates")
+	if err != nil {
+		s.logger.Error("failed to clear the store", zap.Error(err))
+	}
+}
+
+// ----------------------------------------------------------------------
+// agents
+
+func (s *postgresStore) Agent(ctx context.Context, id string) (*model.Agent, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, "SELECT data FROM agents WHERE id = $1", id).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	agent := &model.Agent{}
+	return agent, json.Unmarshal(data, agent)
+}
+
+func (s *postgresStore) Agents(ctx context.Context, options ...QueryOption) ([]*model.Agent, error) {
+	opts := makeQueryOptions(options)
+
+	// search is implemented using the search index
+	if opts.query != nil {
+		ids, err := s.agentIndex.Search(ctx, opts.query)
+		if err != nil {
+			return nil, err
+		}
+		return s.agentsByID(ctx, ids, opts)
+	}
+
+	agents, err := queryPostgresAgents(ctx, s.db, "SELECT data FROM agents ORDER BY id")
+	if err != nil {
+		return nil, err
+	}
+
+	filtered := []*model.Agent{}
+	for _, agent := range agents {
+		if opts.selector.Matches(agent.Labels) {
+			filtered = append(filtered, agent)
+		}
+	}
+
+	if opts.sort == "" {
+		opts.sort = "name"
+	}
+	return applySortOffsetAndLimit(filtered, opts, func(field string, item *model.Agent) string {
+		switch field {
+		case "id":
+			return item.ID
+		default:
+			return item.Name
+		}
+	}), nil
+}
+
+func (s *postgresStore) agentsByID(ctx context.Context, ids []string, opts queryOptions) ([]*model.Agent, error) {
+	agents, err := queryPostgresAgents(ctx, s.db, "SELECT data FROM agents WHERE id = ANY($1) ORDER BY id", pq.Array(ids))
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*model.Agent
+	for _, agent := range agents {
+		if opts.selector.Matches(agent.Labels) {
+			results = append(results, agent)
+		}
+	}
+	return results, nil
+}
+
+func (s *postgresStore) AgentsCount(ctx context.Context, options ...QueryOption) (int, error) {
+	agents, err := s.Agents(ctx, options...)
+	if err != nil {
+		return -1, err
+	}
+	return len(agents), nil
+}
+
+// UpsertAgent creates or updates the given agent and calls the updater method on it.
+func (s *postgresStore) UpsertAgent(ctx context.Context, id string, updater AgentUpdater) (*model.Agent, error) {
+	agents, err := s.UpsertAgents(ctx, []string{id}, updater)
+	if err != nil {
+		return nil, err
+	}
+	return agents[0], nil
+}
+
+func (s *postgresStore) UpsertAgents(ctx context.Context, agentIDs []string, updater AgentUpdater) ([]*model.Agent, error) {
+	ctx, span := tracer.Start(ctx, "store/UpsertAgents")
+	defer span.End()
+
+	agents := make([]*model.Agent, 0, len(agentIDs))
+	updates := NewUpdates()
+
+	err := s.transaction(ctx, func(tx *sql.Tx) error {
+		for _, agentID := range agentIDs {
+			agent, err := upsertPostgresAgent(ctx, tx, agentID, updater, updates)
+			if err != nil {
+				return err
+			}
+
+			agents = append(agents, agent)
+		}
+		return nil
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	// update the search index with changes
+	for _, a := range agents {
+		if err := s.agentIndex.Upsert(a); err != nil {
+			s.logger.Error("failed to update the search index", zap.String("agentID", a.ID))
+		}
+	}
+
+	// notify results
+	s.notify(ctx, updates)
+	return agents, nil
+}
+
+func (s *postgresStore) DeleteAgents(ctx context.Context, agentIDs []string) ([]*model.Agent, error) {
+	updates := NewUpdates()
+
+	deleted, err := queryPostgresAgents(ctx, s.db, "DELETE FROM agents WHERE id = ANY($1) RETURNING data", pq.Array(agentIDs))
+	if err != nil {
+		return nil, err
+	}
+	if _, err := s.db.ExecContext(ctx, "DELETE FROM agent_history WHERE agent_id = ANY($1)", pq.Array(agentIDs)); err != nil {
+		return nil, fmt.Errorf("failed to delete agent history: %w", err)
+	}
+
+	for _, agent := range deleted {
+		agent.Status = model.Deleted
+		updates.IncludeAgent(agent, EventTypeRemove)
+
+		// remove deleted agents from the index
+		if err := s.agentIndex.Remove(agent); err != nil {
+			s.logger.Error("failed to remove from the search index", zap.String("agentID", agent.ID))
+		}
+	}
+
+	// notify updates
+	s.notify(ctx, updates)
+
+	return deleted, nil
+}
+
+// CleanupDisconnectedAgents removes agents that have disconnected before the specified time
+func (s *postgresStore) CleanupDisconnectedAgents(ctx context.Context, since time.Time) error {
+	agents, err := s.Agents(ctx)
+	if err != nil {
+		return err
+	}
+
+	var ids []string
+	for _, agent := range agents {
+		if agent.DisconnectedSince(since) {
+			ids = append(ids, agent.ID)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	_, err = s.DeleteAgents(ctx, ids)
+	return err
+}
+
+// AgentConfiguration returns the configuration that should be applied to an agent.
+func (s *postgresStore) AgentConfiguration(ctx context.Context, agentID string) (*model.Configuration, error) {
+	if agentID == "" {
+		return nil, fmt.Errorf("cannot return configuration for empty agentID")
+	}
+
+	agent, err := s.Agent(ctx, agentID)
+	if agent == nil {
+		return nil, err
+	}
+
+	// check for configuration= label and use that
+	if configurationName, ok := agent.Labels.Set["configuration"]; ok {
+		// if there is a configuration label, this takes precedence and we don't need to look any further
+		return s.Configuration(ctx, configurationName)
+	}
+
+	// iterate over all configurations and look for matches
+	configurations, err := s.Configurations(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("unable to retrieve agent configuration: %w", err)
+	}
+	for _, configuration := range configurations {
+		if configuration.IsForAgent(agent) {
+			return configuration, nil
+		}
+	}
+
+	return nil, nil
+}
+
+// AgentsIDsMatchingConfiguration returns the list of agent IDs that are using the specified configuration
+func (s *postgresStore) AgentsIDsMatchingConfiguration(ctx context.Context, configuration *model.Configuration) ([]string, error) {
+	ids := s.AgentIndex(ctx).Select(configuration.Spec.Selector.MatchLabels)
+	return ids, nil
+}
+
+// ----------------------------------------------------------------------
+// resources
+
+func (s *postgresStore) AgentVersion(ctx context.Context, name string) (*model.AgentVersion, error) {
+	item, exists, err := postgresResource[*model.AgentVersion](ctx, s, model.KindAgentVersion, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) AgentVersions(ctx context.Context) ([]*model.AgentVersion, error) {
+	result, err := postgresResources[*model.AgentVersion](ctx, s, model.KindAgentVersion)
+	if err == nil {
+		model.SortAgentVersionsLatestFirst(result)
+	}
+	return result, err
+}
+func (s *postgresStore) DeleteAgentVersion(ctx context.Context, name string) (*model.AgentVersion, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindAgentVersion, name, &model.AgentVersion{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) Configurations(ctx context.Context, options ...QueryOption) ([]*model.Configuration, error) {
+	opts := makeQueryOptions(options)
+	// search is implemented using the search index
+	if opts.query != nil {
+		names, err := s.configurationIndex.Search(ctx, opts.query)
+		if err != nil {
+			return nil, err
+		}
+		return postgresResourcesByName[*model.Configuration](ctx, s, model.KindConfiguration, names, opts)
+	}
+
+	configurations, err := postgresResources[*model.Configuration](ctx, s, model.KindConfiguration)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []*model.Configuration
+	for _, c := range configurations {
+		if opts.selector.Matches(c.GetLabels()) {
+			results = append(results, c)
+		}
+	}
+	return results, nil
+}
+func (s *postgresStore) Configuration(ctx context.Context, name string) (*model.Configuration, error) {
+	item, exists, err := postgresResource[*model.Configuration](ctx, s, model.KindConfiguration, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) DeleteConfiguration(ctx context.Context, name string) (*model.Configuration, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindConfiguration, name, &model.Configuration{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) Source(ctx context.Context, name string) (*model.Source, error) {
+	item, exists, err := postgresResource[*model.Source](ctx, s, model.KindSource, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) Sources(ctx context.Context, options ...QueryOption) ([]*model.Source, error) {
+	items, err := postgresResources[*model.Source](ctx, s, model.KindSource)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindSource], makeQueryOptions(options))
+}
+func (s *postgresStore) DeleteSource(ctx context.Context, name string) (*model.Source, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindSource, name, &model.Source{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) SourceType(ctx context.Context, name string) (*model.SourceType, error) {
+	item, exists, err := postgresResource[*model.SourceType](ctx, s, model.KindSourceType, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) SourceTypes(ctx context.Context, options ...QueryOption) ([]*model.SourceType, error) {
+	items, err := postgresResources[*model.SourceType](ctx, s, model.KindSourceType)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindSourceType], makeQueryOptions(options))
+}
+func (s *postgresStore) DeleteSourceType(ctx context.Context, name string) (*model.SourceType, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindSourceType, name, &model.SourceType{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) Processor(ctx context.Context, name string) (*model.Processor, error) {
+	item, exists, err := postgresResource[*model.Processor](ctx, s, model.KindProcessor, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) Processors(ctx context.Context, options ...QueryOption) ([]*model.Processor, error) {
+	items, err := postgresResources[*model.Processor](ctx, s, model.KindProcessor)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindProcessor], makeQueryOptions(options))
+}
+func (s *postgresStore) DeleteProcessor(ctx context.Context, name string) (*model.Processor, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindProcessor, name, &model.Processor{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) ProcessorType(ctx context.Context, name string) (*model.ProcessorType, error) {
+	item, exists, err := postgresResource[*model.ProcessorType](ctx, s, model.KindProcessorType, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) ProcessorTypes(ctx context.Context, options ...QueryOption) ([]*model.ProcessorType, error) {
+	items, err := postgresResources[*model.ProcessorType](ctx, s, model.KindProcessorType)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindProcessorType], makeQueryOptions(options))
+}
+func (s *postgresStore) DeleteProcessorType(ctx context.Context, name string) (*model.ProcessorType, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindProcessorType, name, &model.ProcessorType{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) Destination(ctx context.Context, name string) (*model.Destination, error) {
+	item, exists, err := postgresResource[*model.Destination](ctx, s, model.KindDestination, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) Destinations(ctx context.Context, options ...QueryOption) ([]*model.Destination, error) {
+	items, err := postgresResources[*model.Destination](ctx, s, model.KindDestination)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindDestination], makeQueryOptions(options))
+}
+func (s *postgresStore) DeleteDestination(ctx context.Context, name string) (*model.Destination, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindDestination, name, &model.Destination{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) DestinationType(ctx context.Context, name string) (*model.DestinationType, error) {
+	item, exists, err := postgresResource[*model.DestinationType](ctx, s, model.KindDestinationType, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) DestinationTypes(ctx context.Context, options ...QueryOption) ([]*model.DestinationType, error) {
+	items, err := postgresResources[*model.DestinationType](ctx, s, model.KindDestinationType)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindDestinationType], makeQueryOptions(options))
+}
+func (s *postgresStore) DeleteDestinationType(ctx context.Context, name string) (*model.DestinationType, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindDestinationType, name, &model.DestinationType{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) ExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	item, exists, err := postgresResource[*model.ExtensionType](ctx, s, model.KindExtensionType, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) ExtensionTypes(ctx context.Context, options ...QueryOption) ([]*model.ExtensionType, error) {
+	items, err := postgresResources[*model.ExtensionType](ctx, s, model.KindExtensionType)
+	if err != nil {
+		return nil, err
+	}
+	return queryResources(ctx, items, s.resourceIndexes[model.KindExtensionType], makeQueryOptions(options))
+}
+func (s *postgresStore) DeleteExtensionType(ctx context.Context, name string) (*model.ExtensionType, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindExtensionType, name, &model.ExtensionType{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) EnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	item, exists, err := postgresResource[*model.EnrollmentToken](ctx, s, model.KindEnrollmentToken, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) EnrollmentTokens(ctx context.Context) ([]*model.EnrollmentToken, error) {
+	return postgresResources[*model.EnrollmentToken](ctx, s, model.KindEnrollmentToken)
+}
+func (s *postgresStore) DeleteEnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindEnrollmentToken, name, &model.EnrollmentToken{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+func (s *postgresStore) Secret(ctx context.Context, name string) (*model.Secret, error) {
+	item, exists, err := postgresResource[*model.Secret](ctx, s, model.KindSecret, name)
+	if !exists {
+		item = nil
+	}
+	return item, err
+}
+func (s *postgresStore) Secrets(ctx context.Context) ([]*model.Secret, error) {
+	return postgresResources[*model.Secret](ctx, s, model.KindSecret)
+}
+func (s *postgresStore) DeleteSecret(ctx context.Context, name string) (*model.Secret, error) {
+	item, exists, err := deletePostgresResourceAndNotify(ctx, s, model.KindSecret, name, &model.Secret{})
+	if !exists {
+		return nil, err
+	}
+	return item, err
+}
+
+// ApplyResources iterates through a slice of resources, then adds them to storage,
+// and calls notify updates on the updated resources.
+func (s *postgresStore) ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+	// copies are applied so that the resourceVersion of the resources of the caller is unchanged
+	copies := copyResources(resources)
+	defer assignResources(resources, copies)
+	resources = copies
+
+	updates := NewUpdates()
+
+	// resourceStatuses to return for the applied resources
+	resourceStatuses := make([]model.ResourceStatus, 0, len(resources))
+
+	var errs error
+	for _, resource := range resources {
+		// Set the resource's initial ID, which wil be overwritten if
+		// the resource already exists (using the existing resource ID)
+		resource.EnsureID()
+
+		warn, err := resource.ValidateWithStore(ctx, s)
+		if err != nil {
+			resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, model.StatusInvalid, err.Error()))
+			continue
+		}
+
+		var status model.UpdateStatus
+		err = s.transaction(ctx, func(tx *sql.Tx) error {
+			status, err = upsertPostgresResource(ctx, tx, resource)
+			return err
+		})
+		if errors.Is(err, ErrResourceConflict) {
+			resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, model.StatusConflict, err.Error()))
+			continue
+		}
+		if err != nil {
+			resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, model.StatusError, err.Error()))
+			errs = multierror.Append(errs, err)
+			continue
+		}
+		resourceStatuses = append(resourceStatuses, *model.NewResourceStatusWithReason(resource, status, warn))
+
+		switch status {
+		case model.StatusCreated:
+			updates.IncludeResource(resource, EventTypeInsert)
+		case model.StatusConfigured:
+			updates.IncludeResource(resource, EventTypeUpdate)
+		default:
+			continue
+		}
+
+		// some resources need special treatment
+		switch r := resource.(type) {
+		case *model.Configuration:
+			// update the index
+			if err := s.configurationIndex.Upsert(r); err != nil {
+				s.logger.Error("failed to update the search index", zap.String("configuration", r.Name()))
+			}
+		default:
+			if err := s.resourceIndexes.upsert(r); err != nil {
+				s.logger.Error("failed to update the search index", zap.String("kind", string(r.GetKind())), zap.String("name", r.Name()))
+			}
+		}
+	}
+
+	s.notify(ctx, updates)
+
+	return resourceStatuses, errs
+}
+
+// DeleteResources iterates threw a slice of resources, and removes them from storage by name.
+func (s *postgresStore) DeleteResources(ctx context.Context, resources []model.Resource, options ...DeleteOption) ([]model.ResourceStatus, error) {
+	if opts := makeDeleteOptions(options); opts.planned() {
+		return deleteResourcesWithOptions(ctx, s, resources, opts)
+	}
+
+	updates := NewUpdates()
+
+	// track deleteStatuses to return
+	deleteStatuses := make([]model.ResourceStatus, 0)
+
+	for _, r := range resources {
+		empty, err := model.NewEmptyResource(r.GetKind())
+		if err != nil {
+			deleteStatuses = append(deleteStatuses, *model.NewResourceStatusWithReason(r, model.StatusError, err.Error()))
+			continue
+		}
+
+		deleted, exists, err := deletePostgresResource(ctx, s, r.GetKind(), r.Name(), r.ResourceVersion(), empty)
+		if errors.Is(err, ErrResourceConflict) {
+			deleteStatuses = append(deleteStatuses, *model.NewResourceStatusWithReason(r, model.StatusConflict, err.Error()))
+			continue
+		}
+
+		switch err.(type) {
+		case *DependencyError:
+			deleteStatuses = append(
+				deleteStatuses,
+				*model.NewResourceStatusWithReason(r, model.StatusInUse, err.Error()))
+			continue
+
+		case nil:
+			break
+
+		default:
+			deleteStatuses = append(deleteStatuses, *model.NewResourceStatusWithReason(r, model.StatusError, err.Error()))
+			continue
+		}
+
+		if !exists {
+			continue
+		}
+
+		deleteStatuses = append(deleteStatuses, *model.NewResourceStatus(r, model.StatusDeleted))
+		updates.IncludeResource(deleted, EventTypeRemove)
+	}
+
+	s.notify(ctx, updates)
+	return deleteStatuses, nil
+}
+
+// ResourceRevisions returns every revision of the resource with the specified kind and name, oldest first.
+func (s *postgresStore) ResourceRevisions(ctx context.Context, kind model.Kind, name string) ([]model.Resource, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT data FROM revisions WHERE kind = $1 AND name = $2 ORDER BY revision", string(kind), name)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get revisions: %w", err)
+	}
+	defer rows.Close()
+
+	var revisions []model.Resource
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		revision, err := model.NewEmptyResource(kind)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, revision); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the revision: %w", err)
+		}
+		revisions = append(revisions, revision)
+	}
+
+	return revisions, rows.Err()
+}
+
+// AddAuditEntries stores the audit entries, assigning each an ID and a Timestamp if it does not have one
+func (s *postgresStore) AddAuditEntries(ctx context.Context, entries []*model.AuditEntry) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, entry := range entries {
+		var id int64
+		if err := tx.QueryRowContext(ctx, "SELECT nextval(pg_get_serial_sequence('audit', 'id'))").Scan(&id); err != nil {
+			return fmt.Errorf("add audit entry: %w", err)
+		}
+		prepareAuditEntry(entry, strconv.FormatInt(id, 10))
+
+		data, err := json.Marshal(entry)
+		if err != nil {
+			return err
+		}
+		if _, err := tx.ExecContext(ctx, "INSERT INTO audit (id, data) VALUES ($1, $2)", id, data); err != nil {
+			return fmt.Errorf("add audit entry: %w", err)
+		}
+	}
+
+	return tx.Commit()
+}
+
+// AuditEntries returns the audit entries matching the filter, newest first
+func (s *postgresStore) AuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error) {
+	query := "SELECT data FROM audit WHERE TRUE"
+	var args []any
+	if filter.Kind != "" {
+		args = append(args, string(filter.Kind))
+		query += fmt.Sprintf(" AND lower(data->>'kind') = lower($%d)", len(args))
+	}
+	if filter.Name != "" {
+		args = append(args, filter.Name)
+		query += fmt.Sprintf(" AND data->>'name' = $%d", len(args))
+	}
+	if filter.User != "" {
+		args = append(args, filter.User)
+		query += fmt.Sprintf(" AND data->>'user' = $%d", len(args))
+	}
+	query += " ORDER BY id DESC"
+	if filter.Limit > 0 {
+		args = append(args, filter.Limit)
+		query += fmt.Sprintf(" LIMIT $%d", len(args))
+	}
+	if filter.Offset > 0 {
+		args = append(args, filter.Offset)
+		query += fmt.Sprintf(" OFFSET $%d", len(args))
+	}
+
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*model.AuditEntry
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		entry := &model.AuditEntry{}
+		if err := json.Unmarshal(data, entry); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the audit entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	return entries, rows.Err()
+}
+
+// CleanupAuditEntries removes the audit entries recorded before the specified time and the oldest entries beyond
+// maxEntries
+func (s *postgresStore) CleanupAuditEntries(ctx context.Context, before time.Time, maxEntries int) error {
+	if !before.IsZero() {
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM audit WHERE (data->>'timestamp')::timestamptz < $1", before); err != nil {
+			return fmt.Errorf("cleanup audit entries: %w", err)
+		}
+	}
+	if maxEntries > 0 {
+		_, err := s.db.ExecContext(ctx, "DELETE FROM audit WHERE id <= (SELECT id FROM audit ORDER BY id DESC OFFSET $1 LIMIT 1)", maxEntries)
+		if err != nil {
+			return fmt.Errorf("cleanup audit entries: %w", err)
+		}
+	}
+	return nil
+}
+
+// AgentHistory returns the status changes of the agent, oldest first
+func (s *postgresStore) AgentHistory(ctx context.Context, agentID string) ([]*model.AgentStatusChange, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT data FROM agent_history WHERE agent_id = $1 ORDER BY id", agentID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get agent history: %w", err)
+	}
+	defer rows.Close()
+
+	changes := []*model.AgentStatusChange{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		change := &model.AgentStatusChange{}
+		if err := json.Unmarshal(data, change); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the agent status change: %w", err)
+		}
+		changes = append(changes, change)
+	}
+
+	return changes, rows.Err()
+}
+
+// CleanupAgentHistory removes the status changes recorded before the specified time and the oldest changes beyond
+// maxChanges of each agent, keeping the most recent change
+func (s *postgresStore) CleanupAgentHistory(ctx context.Context, before time.Time, maxChanges int) error {
+	if !before.IsZero() {
+		_, err := s.db.ExecContext(ctx, `DELETE FROM agent_history h WHERE (data->>'timestamp')::timestamptz < $1
+			AND id < (SELECT MAX(id) FROM agent_history WHERE agent_id = h.agent_id)`, before)
+		if err != nil {
+			return fmt.Errorf("cleanup agent history: %w", err)
+		}
+	}
+	if maxChanges > 0 {
+		_, err := s.db.ExecContext(ctx, `DELETE FROM agent_history WHERE id IN (SELECT id FROM
+			(SELECT id, ROW_NUMBER() OVER (PARTITION BY agent_id ORDER BY id DESC) AS n FROM agent_history) ranked
+			WHERE n > $1)`, maxChanges)
+		if err != nil {
+			return fmt.Errorf("cleanup agent history: %w", err)
+		}
+	}
+	return nil
+}
+
+// Rollout returns the most recent rollout of the configuration
+func (s *postgresStore) Rollout(ctx context.Context, name string) (*model.Rollout, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, "SELECT data FROM rollouts WHERE name = $1", name).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rollout: %w", err)
+	}
+	rollout := &model.Rollout{}
+	if err := json.Unmarshal(data, rollout); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the rollout: %w", err)
+	}
+	return rollout, nil
+}
+
+// Rollouts returns the most recent rollout of each configuration, sorted by name
+func (s *postgresStore) Rollouts(ctx context.Context) ([]*model.Rollout, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT data FROM rollouts ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get rollouts: %w", err)
+	}
+	defer rows.Close()
+
+	rollouts := []*model.Rollout{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		rollout := &model.Rollout{}
+		if err := json.Unmarshal(data, rollout); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the rollout: %w", err)
+		}
+		rollouts = append(rollouts, rollout)
+	}
+
+	return rollouts, rows.Err()
+}
+
+// UpsertRollout stores the rollout, replacing any previous rollout of the same configuration
+func (s *postgresStore) UpsertRollout(ctx context.Context, rollout *model.Rollout) error {
+	data, err := json.Marshal(rollout)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		"INSERT INTO rollouts (name, data) VALUES ($1, $2) ON CONFLICT (name) DO UPDATE SET data = EXCLUDED.data",
+		rollout.Name, string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("upsert rollout: %w", err)
+	}
+	return nil
+}
+
+// UpgradeCampaign returns the upgrade campaign with the specified name
+func (s *postgresStore) UpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error) {
+	var data []byte
+	err := s.db.QueryRowContext(ctx, "SELECT data FROM upgrade_campaigns WHERE name = $1", name).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade campaign: %w", err)
+	}
+	campaign := &model.UpgradeCampaign{}
+	if err := json.Unmarshal(data, campaign); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the upgrade campaign: %w", err)
+	}
+	return campaign, nil
+}
+
+// UpgradeCampaigns returns all of the upgrade campaigns, sorted by name
+func (s *postgresStore) UpgradeCampaigns(ctx context.Context) ([]*model.UpgradeCampaign, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT data FROM upgrade_campaigns ORDER BY name")
+	if err != nil {
+		return nil, fmt.Errorf("failed to get upgrade campaigns: %w", err)
+	}
+	defer rows.Close()
+
+	campaigns := []*model.UpgradeCampaign{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		campaign := &model.UpgradeCampaign{}
+		if err := json.Unmarshal(data, campaign); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal the upgrade campaign: %w", err)
+		}
+		campaigns = append(campaigns, campaign)
+	}
+
+	return campaigns, rows.Err()
+}
+
+// UpsertUpgradeCampaign stores the upgrade campaign, replacing any previous upgrade campaign with the same name
+func (s *postgresStore) UpsertUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) error {
+	data, err := json.Marshal(campaign)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.ExecContext(ctx,
+		"INSERT INTO upgrade_campaigns (name, data) VALUES ($1, $2) ON CONFLICT (name) DO UPDATE SET data = EXCLUDED.data",
+		campaign.Name, string(data),
+	)
+	if err != nil {
+		return fmt.Errorf("upsert upgrade campaign: %w", err)
+	}
+	return nil
+}
+
+// AcquireLease acquires or renews the lease with the specified name for the holder until the ttl expires. The clock of
+// the database is used so that servers with different clocks agree on when the lease expires.
+func (s *postgresStore) AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error) {
+	result, err := s.db.ExecContext(ctx,
+		`INSERT INTO leases (name, holder, expires) VALUES ($1, $2, NOW() + $3 * INTERVAL '1 microsecond')
+		ON CONFLICT (name) DO UPDATE SET holder = EXCLUDED.holder, expires = EXCLUDED.expires
+		WHERE leases.holder = EXCLUDED.holder OR leases.expires < NOW()`,
+		name, holder, ttl.Microseconds(),
+	)
+	if err != nil {
+		return false, fmt.Errorf("acquire lease: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("acquire lease: %w", err)
+	}
+	return rows == 1, nil
+}
+
+// UseEnrollmentToken counts a use of the enrollment token with the specified ID if it was used fewer than maxUses times.
+// The count is checked and incremented in a single statement so that servers sharing the database cannot exceed
+// maxUses.
+func (s *postgresStore) UseEnrollmentToken(ctx context.Context, id string, maxUses int) (bool, error) {
+	result, err := s.db.ExecContext(ctx,
+		`INSERT INTO enrollment_token_uses (id, uses) VALUES ($1, 1)
+		ON CONFLICT (id) DO UPDATE SET uses = enrollment_token_uses.uses + 1
+		WHERE $2 = 0 OR enrollment_token_uses.uses < $2`,
+		id, maxUses,
+	)
+	if err != nil {
+		return false, fmt.Errorf("use enrollment token: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("use enrollment token: %w", err)
+	}
+	return rows == 1, nil
+}
+
+// RevokeAgent records that the credentials of the agent with the specified ID were revoked
+func (s *postgresStore) RevokeAgent(ctx context.Context, agentID string) error {
+	_, err := s.db.ExecContext(ctx, "INSERT INTO revoked_agents (id) VALUES ($1) ON CONFLICT (id) DO NOTHING", agentID)
+	if err != nil {
+		return fmt.Errorf("revoke agent: %w", err)
+	}
+	return nil
+}
+
+// AgentRevoked returns true if the credentials of the agent with the specified ID were revoked
+func (s *postgresStore) AgentRevoked(ctx context.Context, agentID string) (bool, error) {
+	var revoked bool
+	err := s.db.QueryRowContext(ctx, "SELECT EXISTS (SELECT 1 FROM revoked_agents WHERE id = $1)", agentID).Scan(&revoked)
+	if err != nil {
+		return false, fmt.Errorf("agent revoked: %w", err)
+	}
+	return revoked, nil
+}
+
+// ----------------------------------------------------------------------
+
+// Updates will receive pipelines and configurations that have been updated or deleted, either because the
+// configuration changed or a component in them was updated. Agents inserted/updated from UpsertAgent and agents
+// removed from CleanupDisconnectedAgents are also sent with Updates.
+func (s *postgresStore) Updates() eventbus.Source[*Updates] {
+	return s.updates.Updates()
+}
+
+// AgentIndex provides access to the search Index implementation managed by the Store
+func (s *postgresStore) AgentIndex(ctx context.Context) search.Index {
+	return s.agentIndex
+}
+
+// ConfigurationIndex provides access to the search Index for Configurations
+func (s *postgresStore) ConfigurationIndex(ctx context.Context) search.Index {
+	return s.configurationIndex
+}
+
+// ResourceIndex provides access to the search Index for the specified kind of resource
+func (s *postgresStore) ResourceIndex(ctx context.Context, kind model.Kind) search.Index {
+	if kind == model.KindConfiguration {
+		return s.configurationIndex
+	}
+	return s.resourceIndexes[kind]
+}
+
+func (s *postgresStore) UserSessions() sessions.Store {
+	return s.sessionStorage
+}
+
+// Measurements stores stats for agents and configurations
+func (s *postgresStore) Measurements() stats.Measurements {
+	return s
+}
+
+// ----------------------------------------------------------------------
+// events
+
+// notify publishes the updates with NOTIFY. Updates can be larger than the maximum NOTIFY payload so they are stored in
+// the updates table and the id of the row is sent as the payload. Eventually the notification will return to this
+// server and the updates will be sent to subscribers.
+func (s *postgresStore) notify(ctx context.Context, updates *Updates) {
+	err := updates.addTransitiveUpdates(ctx, s)
+	if err != nil {
+		// TODO: if we can't notify about all updates, what do we do?
+		s.logger.Error("unable to add transitive updates", zap.Any("updates", updates), zap.Error(err))
+	}
+	if updates.Empty() {
+		return
+	}
+
+	data, err := json.Marshal(updates)
+	if err != nil {
+		s.logger.Error("failed to marshal Updates", zap.Error(err))
+		return
+	}
+
+	_, err = s.db.ExecContext(ctx,
+		"WITH inserted AS (INSERT INTO updates (data) VALUES ($1) RETURNING id) SELECT pg_notify($2, id::text) FROM inserted",
+		string(data), postgresUpdatesChannel,
+	)
+	if err != nil {
+		s.logger.Error("failed to notify Updates", zap.Error(err))
+	}
+}
+
+// receiveNotifications receives notifications from this server or other servers and forwards the updates to subscribers
+// of this server
+func (s *postgresStore) receiveNotifications(ctx context.Context, listener *pq.Listener) {
+	defer listener.Close()
+
+	pingTicker := time.NewTicker(time.Minute)
+	defer pingTicker.Stop()
+
+	for {
+		select {
+		case notification := <-listener.Notify:
+			if notification == nil {
+				// the connection was lost and re-established and notifications may have been missed
+				s.logger.Warn("postgres listener reconnected, some updates may have been missed")
+				continue
+			}
+			s.receiveUpdates(ctx, notification.Extra)
+
+		case <-pingTicker.C:
+			// detect broken connections that would otherwise go unnoticed
+			go func() {
+				if err := listener.Ping(); err != nil {
+					s.logger.Error("postgres listener ping failed", zap.Error(err))
+				}
+			}()
+
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+func (s *postgresStore) receiveUpdates(ctx context.Context, payload string) {
+	ctx, span := tracer.Start(ctx, "store/receiveUpdates")
+	defer span.End()
+
+	id, err := strconv.ParseInt(payload, 10, 64)
+	if err != nil {
+		s.logger.Warn("unexpected postgres notification payload", zap.String("payload", payload))
+		return
+	}
+
+	var data []byte
+	if err := s.db.QueryRowContext(ctx, "SELECT data FROM updates WHERE id = $1", id).Scan(&data); err != nil {
+		s.logger.Warn("failed to read Updates", zap.Int64("id", id), zap.Error(err))
+		return
+	}
+
+	var updates Updates
+	if err := json.Unmarshal(data, &updates); err != nil {
+		s.logger.Warn("failed to unmarshal Updates", zap.Int64("id", id), zap.Error(err))
+		return
+	}
+
+	span.SetAttributes(
+		attribute.Int("configurations", len(updates.Configurations)),
+		attribute.Int("agents", len(updates.Agents)),
+	)
+
+	// update indexes which must be in sync across servers
+	for _, event := range updates.Configurations {
+		updateIndex(s.logger, s.configurationIndex, event)
+	}
+	for _, event := range updates.Agents {
+		updateIndex(s.logger, s.agentIndex, event)
+	}
+	s.resourceIndexes.update(s.logger, &updates)
+
+	s.updates.Send(&updates)
+}
+
+// cleanupUpdates removes updates that have already been received by all listeners
+func (s *postgresStore) cleanupUpdates(ctx context.Context) error {
+	_, err := s.db.ExecContext(ctx, "DELETE FROM updates WHERE created < $1", getCurrentTime().Add(-postgresUpdatesRetention))
+	return err
+}
+
+func (s *postgresStore) startMaintenance(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(time.Minute)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				// periodically clean up old measurements
+				if err := s.ProcessMetrics(ctx); err != nil {
+					s.logger.Error("error cleaning up measurements", zap.Error(err))
+				}
+				if err := s.cleanupUpdates(ctx); err != nil {
+					s.logger.Error("error cleaning up updates", zap.Error(err))
+				}
+			case <-ctx.Done():
+				s.db.Close()
+				return
+			}
+		}
+	}()
+}
+
+// ----------------------------------------------------------------------
+// transaction helpers
+
+// transaction runs the function in a transaction, committing if it returns nil and rolling back otherwise
+func (s *postgresStore) transaction(ctx context.Context, f func(tx *sql.Tx) error) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	if err := f(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// queryer is implemented by *sql.DB and *sql.Tx
+type queryer interface {
+	QueryContext(ctx context.Context, query string, args ...any) (*sql.Rows, error)
+}
+
+func queryPostgresAgents(ctx context.Context, q queryer, query string, args ...any) ([]*model.Agent, error) {
+	rows, err := q.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("agents: %w", err)
+	}
+	defer rows.Close()
+
+	var agents []*model.Agent
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, fmt.Errorf("agents: %w", err)
+		}
+		agent := &model.Agent{}
+		if err := json.Unmarshal(data, agent); err != nil {
+			return nil, fmt.Errorf("agents: %w", err)
+		}
+		agents = append(agents, agent)
+	}
+	return agents, rows.Err()
+}
+
+// upsertPostgresAgent is a transaction helper that updates the given agent, stores it, and includes it in the passed
+// updates. it does *not* update the search index or notify any subscribers of updates.
+func upsertPostgresAgent(ctx context.Context, tx *sql.Tx, agentID string, updater AgentUpdater, updates *Updates) (*model.Agent, error) {
+	agentEventType := EventTypeInsert
+	agent := &model.Agent{ID: agentID}
+
+	// load the existing agent or create it
+	var data []byte
+	err := tx.QueryRowContext(ctx, "SELECT data FROM agents WHERE id = $1 FOR UPDATE", agentID).Scan(&data)
+	switch {
+	case errors.Is(err, sql.ErrNoRows):
+	case err != nil:
+		return agent, err
+	default:
+		// existing agent, unmarshal
+		if err := json.Unmarshal(data, agent); err != nil {
+			return agent, err
+		}
+		agentEventType = EventTypeUpdate
+	}
+
+	exists := agentEventType == EventTypeUpdate
+
+	// compare labels before/after and notify if they change
+	labelsBefore := agent.Labels.String()
+	statusBefore, errorBefore := agent.Status, agent.ErrorMessage
+
+	// update the agent
+	updater(agent)
+
+	labelsAfter := agent.Labels.String()
+
+	// if the labels changes is this is just an update, use EventTypeLabel
+	if labelsAfter != labelsBefore && agentEventType == EventTypeUpdate {
+		agentEventType = EventTypeLabel
+	}
+
+	data, err = json.Marshal(agent)
+	if err != nil {
+		return agent, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO agents (id, data) VALUES ($1, $2) ON CONFLICT (id) DO UPDATE SET data = EXCLUDED.data",
+		agentID, string(data),
+	)
+	if err != nil {
+		return agent, err
+	}
+
+	if change := agentStatusChange(agent, exists, statusBefore, errorBefore); change != nil {
+		data, err = json.Marshal(change)
+		if err != nil {
+			return agent, err
+		}
+		_, err = tx.ExecContext(ctx, "INSERT INTO agent_history (agent_id, data) VALUES ($1, $2)", agentID, string(data))
+		if err != nil {
+			return agent, fmt.Errorf("add agent status change: %w", err)
+		}
+	}
+
+	updates.IncludeAgent(agent, agentEventType)
+	return agent, nil
+}
+
+func upsertPostgresResource(ctx context.Context, tx *sql.Tx, r model.Resource) (model.UpdateStatus, error) {
+	kind := r.GetKind()
+
+	existing, err := model.NewEmptyResource(kind)
+	if err != nil {
+		return model.StatusError, err
+	}
+
+	var existingData []byte
+	err = tx.QueryRowContext(ctx, "SELECT data FROM resources WHERE kind = $1 AND name = $2 FOR UPDATE", string(kind), r.Name()).Scan(&existingData)
+	exists := err == nil
+	if err != nil && !errors.Is(err, sql.ErrNoRows) {
+		return model.StatusError, fmt.Errorf("upsert resource: %w", err)
+	}
+
+	if !exists {
+		if err := checkResourceVersion(r, 0, exists); err != nil {
+			return model.StatusConflict, err
+		}
+	}
+
+	if exists {
+		if err := json.Unmarshal(existingData, existing); err != nil {
+			return model.StatusError, fmt.Errorf("failed to unmarshal the existing resource: %w", err)
+		}
+		if err := checkResourceVersion(r, existing.Revision(), exists); err != nil {
+			return model.StatusConflict, err
+		}
+		// preserve the id and revision (if possible)
+		r.SetID(existing.ID())
+		r.SetRevision(existing.Revision())
+
+		// jsonb does not preserve the original bytes so both are marshaled to compare them
+		existingData, err = json.Marshal(existing)
+		if err != nil {
+			return model.StatusUnchanged, fmt.Errorf("failed to marshal the existing resource: %w", err)
+		}
+		data, err := json.Marshal(r)
+		if err != nil {
+			return model.StatusUnchanged, fmt.Errorf("failed to marshal the resource: %w", err)
+		}
+		if bytes.Equal(existingData, data) {
+			return model.StatusUnchanged, nil
+		}
+	}
+
+	// the resource changed, store it as a new revision
+	var latest int
+	err = tx.QueryRowContext(ctx, "SELECT COALESCE(MAX(revision), 0) FROM revisions WHERE kind = $1 AND name = $2", string(kind), r.Name()).Scan(&latest)
+	if err != nil {
+		return model.StatusUnchanged, fmt.Errorf("failed to get revisions: %w", err)
+	}
+	r.SetRevision(latest + 1)
+
+	data, err := json.Marshal(r)
+	if err != nil {
+		return model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
+	}
+
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO resources (kind, name, data) VALUES ($1, $2, $3) ON CONFLICT (kind, name) DO UPDATE SET data = EXCLUDED.data",
+		string(kind), r.Name(), string(data),
+	)
+	if err != nil {
+		return model.StatusUnchanged, fmt.Errorf("upsert resource: %w", err)
+	}
+	_, err = tx.ExecContext(ctx,
+		"INSERT INTO revisions (kind, name, revision, data) VALUES ($1, $2, $3, $4)",
+		string(kind), r.Name(), r.Revision(), string(data),
+	)
+	if err != nil {
+		return model.StatusUnchanged, fmt.Errorf("upsert resource revision: %w", err)
+	}
+
+	if !exists {
+		return model.StatusCreated, nil
+	}
+	return model.StatusConfigured, nil
+}
+
+// ----------------------------------------------------------------------
+// generic resource accessors
+
+func postgresResource[R model.Resource](ctx context.Context, s *postgresStore, kind model.Kind, name string) (resource R, exists bool, err error) {
+	var data []byte
+	err = s.db.QueryRowContext(ctx, "SELECT data FROM resources WHERE kind = $1 AND name = $2", string(kind), name).Scan(&data)
+	if errors.Is(err, sql.ErrNoRows) {
+		return resource, false, nil
+	}
+	if err != nil {
+		return resource, false, err
+	}
+	return resource, true, json.Unmarshal(data, &resource)
+}
+
+func postgresResources[R model.Resource](ctx context.Context, s *postgresStore, kind model.Kind) ([]R, error) {
+	rows, err := s.db.QueryContext(ctx, "SELECT name, data FROM resources WHERE kind = $1 ORDER BY name", string(kind))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var resources []R
+	for rows.Next() {
+		var name string
+		var data []byte
+		if err := rows.Scan(&name, &data); err != nil {
+			return nil, err
+		}
+		var resource R
+		if err := json.Unmarshal(data, &resource); err != nil {
+			s.logger.Error("failed to unmarshal resource", zap.String("name", name), zap.String("kind", string(kind)), zap.Error(err))
+			continue
+		}
+		resources = append(resources, resource)
+	}
+
+	return resources, rows.Err()
+}
+
+// postgresResourcesByName returns the resources of the specified name with the specified names. If requesting some
+// resources results in an error, the errors will be accumulated and return with the list of resources successfully
+// retrieved.
+func postgresResourcesByName[R model.Resource](ctx context.Context, s *postgresStore, kind model.Kind, names []string, opts queryOptions) ([]R, error) {
+	var errs error
+	var results []R
+
+	for _, name := range names {
+		if result, exists, err := postgresResource[R](ctx, s, kind, name); err != nil {
+			errs = multierror.Append(errs, err)
+		} else {
+			if exists && opts.selector.Matches(result.GetLabels()) {
+				results = append(results, result)
+			}
+		}
+	}
+	return results, errs
+}
+
+func deletePostgresResourceAndNotify[R model.Resource](ctx context.Context, s *postgresStore, kind model.Kind, name string, emptyResource R) (resource R, exists bool, err error) {
+	deleted, exists, err := deletePostgresResource(ctx, s, kind, name, "", emptyResource)
+
+	if err == nil && exists {
+		updates := NewUpdates()
+		updates.IncludeResource(deleted, EventTypeRemove)
+		s.notify(ctx, updates)
+	}
+
+	return deleted, exists, err
+}
+
+// deletePostgresResource removes the resource with the given kind and name. Returns DependencyError if the resource is
+// referenced by another. If version is not empty, returns ErrResourceConflict if it does not match the resourceVersion of
+// the resource. emptyResource will be populated with the deleted resource. For convenience, if the delete is
+// successful, the populated resource will also be returned. If there was an error, nil will be returned for the
+// resource.
+func deletePostgresResource[R model.Resource](ctx context.Context, s *postgresStore, kind model.Kind, name, version string, emptyResource R) (resource R, exists bool, err error) {
+	var dependencies DependentResources
+
+	err = s.transaction(ctx, func(tx *sql.Tx) error {
+		var data []byte
+		err := tx.QueryRowContext(ctx, "SELECT data FROM resources WHERE kind = $1 AND name = $2 FOR UPDATE", string(kind), name).Scan(&data)
+		if errors.Is(err, sql.ErrNoRows) {
+			if err := checkMissingVersion(kind, name, version); err != nil {
+				return err
+			}
+			return ErrResourceMissing
+		}
+		if err != nil {
+			return err
+		}
+
+		// populate the emptyResource with the data before deleting
+		if err := json.Unmarshal(data, emptyResource); err != nil {
+			return err
+		}
+
+		exists = true
+
+		if err := checkDeleteVersion(emptyResource, version); err != nil {
+			return err
+		}
+
+		// Check if the resources is referenced by another
+		dependencies, err = FindDependentResources(ctx, s, emptyResource)
+		if !dependencies.empty() {
+			return ErrResourceInUse
+		}
+
+		_, err = tx.ExecContext(ctx, "DELETE FROM resources WHERE kind = $1 AND name = $2", string(kind), name)
+		return err
+	})
+
+	switch {
+	case errors.Is(err, ErrResourceMissing):
+		return resource, exists, nil
+	case errors.Is(err, ErrResourceInUse):
+		return emptyResource, exists, newDependencyError(dependencies)
+	case err != nil:
+		return resource, exists, err
+	}
+
+	if emptyResource.GetKind() == model.KindConfiguration {
+		if err := s.configurationIndex.Remove(emptyResource); err != nil {
+			s.logger.Error("failed to remove configuration from the search index", zap.String("name", emptyResource.Name()))
+		}
+	} else if err := s.resourceIndexes.remove(emptyResource); err != nil {
+		s.logger.Error("failed to remove resource from the search index", zap.String("kind", string(emptyResource.GetKind())), zap.String("name", emptyResource.Name()))
+	}
+
+	return emptyResource, exists, nil
+}
+
+// ----------------------------------------------------------------------
+// Measurements implementation
+
+// AgentMetrics provides metrics for an individual agents. They are essentially configuration metrics filtered to a
+// list of agents.
+//
+// Note: While the same record.Metric struct is used to return the metrics, these are not the same metrics provided to
+// Store. They will be aggregated and counter metrics will be converted into rates.
+func (s *postgresStore) AgentMetrics(ctx context.Context, ids []string, options ...stats.QueryOption) (stats.MetricData, error) {
+	// Empty string single key or empty array of ids is a request for all Agents
+	if len(ids) == 0 || (len(ids) == 1 && ids[0] == "") {
+		var err error
+		ids, err = s.queryStrings(ctx, "SELECT id FROM agents ORDER BY id")
+		if err != nil {
+			return nil, err
+		}
+	}
+	return s.retrieveMetrics(ctx, stats.SupportedMetricNames, string(model.KindAgent), ids, options...)
+}
+
+// ConfigurationMetrics provides all metrics associated with a configuration aggregated from all agents using the
+// configuration.
+//
+// Note: While the same record.Metric struct is used to return the metrics, these are not the same metrics provided to
+// Store. They will be aggregated and counter metrics will be converted into rates.
+func (s *postgresStore) ConfigurationMetrics(ctx context.Context, name string, options ...stats.QueryOption) (stats.MetricData, error) {
+	names := []string{name}
+	var err error
+	// Empty name is a request for all configurations
+	if name == "" {
+		names, err = s.queryStrings(ctx, "SELECT name FROM resources WHERE kind = $1 ORDER BY name", string(model.KindConfiguration))
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	baseMetrics, err := s.retrieveMetrics(ctx, stats.SupportedMetricNames, string(model.KindConfiguration), names, options...)
+	if err != nil {
+		return nil, err
+	}
+
+	return sumConfigurationMetrics(baseMetrics), nil
+}
+
+// OverviewMetrics provides all metrics needed for the overview page. This page shows configs and destinations.
+func (s *postgresStore) OverviewMetrics(ctx context.Context, options ...stats.QueryOption) (stats.MetricData, error) {
+	return s.ConfigurationMetrics(ctx, "", options...)
+}
+
+// MeasurementsSize returns the count of rows in the measurements table, and is used only for testing
+func (s *postgresStore) MeasurementsSize() (int, error) {
+	var count int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM measurements").Scan(&count)
+	return count, err
+}
+
+// SaveAgentMetrics saves new metrics. These metrics will be aggregated to determine metrics associated with agents and configurations.
+func (s *postgresStore) SaveAgentMetrics(ctx context.Context, metrics []*record.Metric) error {
+	var supported []*record.Metric
+	for _, m := range metrics {
+		for _, name := range stats.SupportedMetricNames {
+			if m.Name == name {
+				supported = append(supported, m)
+				break
+			}
+		}
+	}
+	if len(supported) == 0 {
+		return nil
+	}
+
+	return s.transaction(ctx, func(tx *sql.Tx) error {
+		var errs error
+		for _, m := range supported {
+			data, err := json.Marshal(m)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+			timestamp := m.Timestamp.UTC().Truncate(10 * time.Second)
+			agent, configuration, processor := stats.Agent(m), stats.Configuration(m), stats.Processor(m)
+
+			rows := []struct {
+				objectType string
+				objectID   string
+				series     string
+			}{
+				{string(model.KindAgent), agent, measurementSeries(configuration, processor)},
+				{string(model.KindConfiguration), configuration, measurementSeries(agent, processor)},
+			}
+			for _, row := range rows {
+				_, err = tx.ExecContext(ctx,
+					`INSERT INTO measurements (metric, object_type, object_id, timestamp, series, data) VALUES ($1, $2, $3, $4, $5, $6)
+					ON CONFLICT (metric, object_type, object_id, timestamp, series) DO UPDATE SET data = EXCLUDED.data`,
+					m.Name, row.objectType, row.objectID, timestamp, row.series, string(data),
+				)
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return errs
+	})
+}
+
+// ProcessMetrics is called in the background at regular intervals and performs metric roll-up and removes old data
+func (s *postgresStore) ProcessMetrics(ctx context.Context) error {
+	// Capture now to re-use for all the date math
+	now := getCurrentTime()
+
+	// Only look at data points older than 100 seconds, and we keep all the latest 10s intervals
+	rows, err := s.db.QueryContext(ctx, "SELECT DISTINCT timestamp FROM measurements WHERE timestamp <= $1", now.Add(-100*time.Second))
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+
+	var remove []time.Time
+	for rows.Next() {
+		var ts time.Time
+		if err := rows.Scan(&ts); err != nil {
+			return err
+		}
+		if !keepMeasurement(ts.UTC(), now) {
+			remove = append(remove, ts)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	var errs error
+	for _, ts := range remove {
+		if _, err := s.db.ExecContext(ctx, "DELETE FROM measurements WHERE timestamp = $1", ts); err != nil {
+			errs = multierror.Append(errs, err)
+		}
+	}
+	return errs
+}
+
+// ExportMeasurements returns all of the saved measurements. Each measurement is stored with an agent row and a
+// configuration row so only the agent rows are used.
+func (s *postgresStore) ExportMeasurements(ctx context.Context) (stats.MetricData, error) {
+	return s.queryMeasurementData(ctx,
+		"SELECT data FROM measurements WHERE object_type = $1 ORDER BY metric, object_id, timestamp, series",
+		string(model.KindAgent),
+	)
+}
+
+func (s *postgresStore) retrieveMetrics(ctx context.Context, metricNames []string, objectType string, ids []string, options ...stats.QueryOption) (stats.MetricData, error) {
+	result := stats.MetricData{}
+	opts := stats.MakeQueryOptions(options)
+	rollup := getRollupFromPeriod(opts)
+
+	endDate := getCurrentTime().Add(-10 * time.Second).Truncate(10 * time.Second)
+	startDate := endDate.Add(-1 * opts.Period).Truncate(rollup)
+
+	var errs error
+	for metricIndex, metricName := range metricNames {
+		for idIndex, id := range ids {
+			endMetrics, err := s.queryMeasurements(ctx,
+				`SELECT series, data FROM measurements
+				WHERE metric = $1 AND object_type = $2 AND object_id = $3 AND timestamp = $4`,
+				metricName, objectType, id, endDate,
+			)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+
+			// On the first metric & agent, check if a previous time would offer more complete data
+			if metricIndex == 0 && idIndex == 0 {
+				prevDate := endDate.Add(-10 * time.Second)
+				// Ignore this error and just act on endMetrics if an error occured
+				prevMetrics, _ := s.queryMeasurements(ctx,
+					`SELECT series, data FROM measurements
+					WHERE metric = $1 AND object_type = $2 AND object_id = $3 AND timestamp = $4`,
+					metricName, objectType, id, prevDate,
+				)
+
+				// If there were _more_ metrics in the latest bucket, assume that the current bucket
+				// has not been filled yet. See boltstore.retrieveMetrics.
+				if len(endMetrics) < len(prevMetrics) {
+					endMetrics = prevMetrics
+					endDate = prevDate
+					startDate = endDate.Add(-1 * opts.Period).Truncate(rollup)
+				}
+			}
+
+			if len(endMetrics) == 0 {
+				continue
+			}
+
+			// the earliest measurement of each series in the period is the start point
+			startMetrics, err := s.queryMeasurements(ctx,
+				`SELECT DISTINCT ON (series) series, data FROM measurements
+				WHERE metric = $1 AND object_type = $2 AND object_id = $3 AND timestamp >= $4 AND timestamp < $5
+				ORDER BY series, timestamp`,
+				metricName, objectType, id, startDate, endDate,
+			)
+			if err != nil {
+				errs = multierror.Append(errs, err)
+				continue
+			}
+
+			// Only calculate rates if we found a start point to match the end points desired
+			for series, first := range startMetrics {
+				if last, ok := endMetrics[series]; ok {
+					if metric := calculateRateMetric(first, last); metric != nil {
+						result = append(result, metric)
+					}
+				}
+			}
+		}
+	}
+
+	return result, errs
+}
+
+// queryMeasurements returns the measurements returned by the query keyed by series
+func (s *postgresStore) queryMeasurements(ctx context.Context, query string, args ...any) (map[string]*record.Metric, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	metrics := map[string]*record.Metric{}
+	for rows.Next() {
+		var series string
+		var data []byte
+		if err := rows.Scan(&series, &data); err != nil {
+			return nil, err
+		}
+		m := &record.Metric{}
+		if err := json.Unmarshal(data, m); err != nil {
+			return nil, err
+		}
+		metrics[series] = m
+	}
+	return metrics, rows.Err()
+}
+
+// queryMeasurementData returns the measurements returned by the query
+func (s *postgresStore) queryMeasurementData(ctx context.Context, query string, args ...any) (stats.MetricData, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	metrics := stats.MetricData{}
+	for rows.Next() {
+		var data []byte
+		if err := rows.Scan(&data); err != nil {
+			return nil, err
+		}
+		m := &record.Metric{}
+		if err := json.Unmarshal(data, m); err != nil {
+			return nil, err
+		}
+		metrics = append(metrics, m)
+	}
+	return metrics, rows.Err()
+}
+
+func (s *postgresStore) queryStrings(ctx context.Context, query string, args ...any) ([]string, error) {
+	rows, err := s.db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	results := []string{}
+	for rows.Next() {
+		var result string
+		if err := rows.Scan(&result); err != nil {
+			return nil, err
+		}
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// measurementSeries identifies a series of measurements for an agent or configuration
+func measurementSeries(id, processor string) string {
+	return fmt.Sprintf("%s|%s", id, processor)
+}
@@ -0,0 +1,340 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+
+	"go.etcd.io/bbolt"
+)
+
+// BoltIndexBucket is the name of the bbolt bucket that contains a nested bucket for each persistent index
+const BoltIndexBucket = "SearchIndex"
+
+// boltIndexVersion is stored with each persistent index. It must be incremented when the format of stored documents or
+// the fields indexed by resources change so that existing indexes are rebuilt.
+const boltIndexVersion = 4
+
+var (
+	boltIndexKeyVersion = []byte("version")
+	boltIndexDocuments  = []byte("documents")
+)
+
+var errCorruptIndex = errors.New("corrupt search index")
+
+// PersistentIndex is an Index that persists its documents so that it does not need to be rebuilt every time the
+// server starts.
+type PersistentIndex interface {
+	Index
+
+	// Loaded returns true if the documents of the index were loaded from storage. It returns false if the index is new
+	// or was reset because it was corrupt or stored with a different version. In that case the index must be rebuilt by
+	// upserting every document.
+	Loaded() bool
+
+	// Size returns the number of documents in the index
+	Size() int
+
+	// Rebuild replaces all documents in the index with the specified documents
+	Rebuild(indexed []Indexed) error
+}
+
+// BoltIndex is a PersistentIndex stored in a bbolt database. Its stored documents can be updated in the same transaction
+// that updates the indexed resources so that the stored index never diverges from them.
+type BoltIndex interface {
+	PersistentIndex
+
+	// UpsertTx stores the document for the indexed resource using the transaction. The in-memory index is updated when
+	// the transaction is committed.
+	UpsertTx(tx *bbolt.Tx, indexed Indexed) error
+
+	// RemoveTx removes the stored document for the indexed resource using the transaction. The in-memory index is
+	// updated when the transaction is committed.
+	RemoveTx(tx *bbolt.Tx, indexed Indexed) error
+}
+
+type boltIndex struct {
+	*index
+	db     *bbolt.DB
+	loaded bool
+}
+
+var _ BoltIndex = (*boltIndex)(nil)
+
+// NewBoltIndex returns a BoltIndex that stores its documents in a nested bucket of BoltIndexBucket and keeps
+// them in memory for searching. Stored documents are loaded when the index is created. If they cannot be read or were
+// stored with a different version, they are removed and Loaded will return false.
+func NewBoltIndex(db *bbolt.DB, name string) (BoltIndex, error) {
+	i := &boltIndex{
+		index: newIndex(name),
+		db:    db,
+	}
+
+	err := db.Update(func(tx *bbolt.Tx) error {
+		err := i.load(tx)
+		if err == nil {
+			i.loaded = true
+			return nil
+		}
+		if !errors.Is(err, errCorruptIndex) {
+			return err
+		}
+		i.index.clear()
+		return i.create(tx)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to load %s search index: %w", name, err)
+	}
+	return i, nil
+}
+
+// Loaded returns true if the documents of the index were loaded from storage
+func (i *boltIndex) Loaded() bool {
+	return i.loaded
+}
+
+// Size returns the number of documents in the index
+func (i *boltIndex) Size() int {
+	return i.index.size()
+}
+
+// Rebuild replaces all documents in the index with the specified documents using a single transaction
+func (i *boltIndex) Rebuild(indexed []Indexed) error {
+	docs := make([]*storedDocument, 0, len(indexed))
+	err := i.db.Update(func(tx *bbolt.Tx) error {
+		if err := i.create(tx); err != nil {
+			return err
+		}
+		b, err := i.documentsBucket(tx)
+		if err != nil {
+			return err
+		}
+		for _, item := range indexed {
+			doc := newStoredDocument(item)
+			data, err := json.Marshal(doc)
+			if err != nil {
+				return err
+			}
+			if err := b.Put([]byte(doc.ID), data); err != nil {
+				return err
+			}
+			docs = append(docs, doc)
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+
+	i.index.clear()
+	for _, doc := range docs {
+		if err := i.index.Upsert(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (i *boltIndex) Upsert(indexed Indexed) error {
+	// agents are upserted frequently without changes to their indexed fields, so avoid a write transaction for
+	// unchanged documents
+	doc := newStoredDocument(indexed)
+	if data, err := json.Marshal(doc); err == nil && !i.changed(doc.ID, data) {
+		return i.index.Upsert(doc)
+	}
+	return i.db.Update(func(tx *bbolt.Tx) error {
+		return i.UpsertTx(tx, indexed)
+	})
+}
+
+func (i *boltIndex) Remove(indexed Indexed) error {
+	return i.db.Update(func(tx *bbolt.Tx) error {
+		return i.RemoveTx(tx, indexed)
+	})
+}
+
+// UpsertTx stores the document for the indexed resource using the transaction and updates the in-memory index when the
+// transaction is committed
+func (i *boltIndex) UpsertTx(tx *bbolt.Tx, indexed Indexed) error {
+	doc := newStoredDocument(indexed)
+	data, err := json.Marshal(doc)
+	if err != nil {
+		return err
+	}
+	b, err := i.documentsBucket(tx)
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(b.Get([]byte(doc.ID)), data) {
+		if err := b.Put([]byte(doc.ID), data); err != nil {
+			return err
+		}
+	}
+	tx.OnCommit(func() {
+		_ = i.index.Upsert(doc)
+	})
+	return nil
+}
+
+// RemoveTx removes the stored document for the indexed resource using the transaction and updates the in-memory index
+// when the transaction is committed
+func (i *boltIndex) RemoveTx(tx *bbolt.Tx, indexed Indexed) error {
+	b, err := i.documentsBucket(tx)
+	if err != nil {
+		return err
+	}
+	if err := b.Delete([]byte(indexed.IndexID())); err != nil {
+		return err
+	}
+	tx.OnCommit(func() {
+		_ = i.index.Remove(indexed)
+	})
+	return nil
+}
+
+// changed returns true if the stored document with the specified id is different from data
+func (i *boltIndex) changed(id string, data []byte) bool {
+	changed := true
+	_ = i.db.View(func(tx *bbolt.Tx) error {
+		if b := tx.Bucket([]byte(BoltIndexBucket)); b != nil {
+			if ib := b.Bucket([]byte(i.name)); ib != nil {
+				if docs := ib.Bucket(boltIndexDocuments); docs != nil {
+					changed = !bytes.Equal(docs.Get([]byte(id)), data)
+				}
+			}
+		}
+		return nil
+	})
+	return changed
+}
+
+// load adds the stored documents to the in-memory index. It returns errCorruptIndex if the index does not exist, has a
+// different version, or contains documents that cannot be read.
+func (i *boltIndex) load(tx *bbolt.Tx) error {
+	b := tx.Bucket([]byte(BoltIndexBucket))
+	if b == nil {
+		return errCorruptIndex
+	}
+	ib := b.Bucket([]byte(i.name))
+	if ib == nil {
+		return errCorruptIndex
+	}
+	if string(ib.Get(boltIndexKeyVersion)) != strconv.Itoa(boltIndexVersion) {
+		return errCorruptIndex
+	}
+	docs := ib.Bucket(boltIndexDocuments)
+	if docs == nil {
+		return errCorruptIndex
+	}
+	return docs.ForEach(func(k, v []byte) error {
+		doc := &storedDocument{}
+		if err := json.Unmarshal(v, doc); err != nil || doc.ID != string(k) {
+			return errCorruptIndex
+		}
+		return i.index.Upsert(doc)
+	})
+}
+
+// create replaces any existing bucket for this index with an empty bucket using the current version
+func (i *boltIndex) create(tx *bbolt.Tx) error {
+	b, err := tx.CreateBucketIfNotExists([]byte(BoltIndexBucket))
+	if err != nil {
+		return err
+	}
+	if b.Bucket([]byte(i.name)) != nil {
+		if err := b.DeleteBucket([]byte(i.name)); err != nil {
+			return err
+		}
+	}
+	ib, err := b.CreateBucket([]byte(i.name))
+	if err != nil {
+		return err
+	}
+	if err := ib.Put(boltIndexKeyVersion, []byte(strconv.Itoa(boltIndexVersion))); err != nil {
+		return err
+	}
+	_, err = ib.CreateBucket(boltIndexDocuments)
+	return err
+}
+
+// documentsBucket returns the bucket containing the documents of this index, creating it if necessary
+func (i *boltIndex) documentsBucket(tx *bbolt.Tx) (*bbolt.Bucket, error) {
+	b := tx.Bucket([]byte(BoltIndexBucket))
+	if b != nil {
+		if ib := b.Bucket([]byte(i.name)); ib != nil {
+			if docs := ib.Bucket(boltIndexDocuments); docs != nil {
+				return docs, nil
+			}
+		}
+	}
+	if err := i.create(tx); err != nil {
+		return nil, err
+	}
+	return tx.Bucket([]byte(BoltIndexBucket)).Bucket([]byte(i.name)).Bucket(boltIndexDocuments), nil
+}
+
+// ----------------------------------------------------------------------
+
+// storedDocument is the form of an Indexed resource stored by a boltIndex. It contains the fields and labels indexed by
+// the resource and implements Indexed so that it can be added to the in-memory index when it is loaded.
+type storedDocument struct {
+	ID     string      `json:"id"`
+	Fields [][2]string `json:"fields,omitempty"`
+	Labels [][2]string `json:"labels,omitempty"`
+}
+
+var _ Indexed = (*storedDocument)(nil)
+
+func newStoredDocument(indexed Indexed) *storedDocument {
+	doc := &storedDocument{
+		ID: indexed.IndexID(),
+	}
+	indexed.IndexFields(func(name, value string) {
+		doc.Fields = append(doc.Fields, [2]string{name, value})
+	})
+	indexed.IndexLabels(func(name, value string) {
+		doc.Labels = append(doc.Labels, [2]string{name, value})
+	})
+
+	// labels are often indexed from a map, so sort them to store the same document for the same labels
+	sort.Slice(doc.Labels, func(a, b int) bool {
+		return doc.Labels[a][0] < doc.Labels[b][0]
+	})
+	return doc
+}
+
+// IndexID returns an ID used to identify the resource that is indexed
+func (d *storedDocument) IndexID() string {
+	return d.ID
+}
+
+// IndexFields indexes the stored fields
+func (d *storedDocument) IndexFields(index Indexer) {
+	for _, field := range d.Fields {
+		index(field[0], field[1])
+	}
+}
+
+// IndexLabels indexes the stored labels
+func (d *storedDocument) IndexLabels(index Indexer) {
+	for _, label := range d.Labels {
+		index(label[0], label[1])
+	}
+}
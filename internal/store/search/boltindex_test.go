@@ -0,0 +1,209 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package search
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"go.etcd.io/bbolt"
+)
+
+func testBoltDB(t *testing.T) *bbolt.DB {
+	db, err := bbolt.Open(filepath.Join(t.TempDir(), "index.db"), 0600, nil)
+	require.NoError(t, err)
+	t.Cleanup(func() { db.Close() })
+	return db
+}
+
+func testBoltIndexDocuments() (*document, *document) {
+	doc1 := emptyDocument("1")
+	doc1.addField("arch", "arm64")
+	doc1.addField("os", "macOS 12.3")
+	doc1.labels["env"] = "prod"
+
+	doc2 := emptyDocument("2")
+	doc2.addField("arch", "amd64")
+	doc2.addField("os", "Ubuntu")
+	doc2.labels["env"] = "dev"
+	return doc1, doc2
+}
+
+func searchIndex(t *testing.T, index Index, query string) []string {
+	results, err := index.Search(context.TODO(), ParseQuery(query))
+	require.NoError(t, err)
+	return results
+}
+
+func TestBoltIndexPersistsDocuments(t *testing.T) {
+	db := testBoltDB(t)
+	doc1, doc2 := testBoltIndexDocuments()
+
+	index, err := NewBoltIndex(db, "test")
+	require.NoError(t, err)
+	require.False(t, index.Loaded())
+
+	require.NoError(t, index.Upsert(doc1))
+	require.NoError(t, index.Upsert(doc2))
+	require.ElementsMatch(t, []string{"1"}, searchIndex(t, index, "arch:arm64"))
+
+	// a new index using the same database loads the documents
+	loaded, err := NewBoltIndex(db, "test")
+	require.NoError(t, err)
+	require.True(t, loaded.Loaded())
+	require.Equal(t, 2, loaded.Size())
+	require.ElementsMatch(t, []string{"1"}, searchIndex(t, loaded, "arch:arm64"))
+	require.ElementsMatch(t, []string{"2"}, searchIndex(t, loaded, "env:dev"))
+	require.ElementsMatch(t, []string{"2"}, loaded.Select(map[string]string{"env": "dev"}))
+
+	suggestions, err := loaded.Suggestions(ParseQuery("ar"))
+	require.NoError(t, err)
+	require.Len(t, suggestions, 1)
+	require.Equal(t, "arch:", suggestions[0].Label)
+
+	// removed documents are not loaded
+	require.NoError(t, loaded.Remove(doc1))
+	loaded, err = NewBoltIndex(db, "test")
+	require.NoError(t, err)
+	require.True(t, loaded.Loaded())
+	require.Equal(t, 1, loaded.Size())
+	require.ElementsMatch(t, []string{}, searchIndex(t, loaded, "arch:arm64"))
+
+	// other indexes are separate
+	other, err := NewBoltIndex(db, "other")
+	require.NoError(t, err)
+	require.False(t, other.Loaded())
+	require.Equal(t, 0, other.Size())
+}
+
+func TestBoltIndexRebuild(t *testing.T) {
+	db := testBoltDB(t)
+	doc1, doc2 := testBoltIndexDocuments()
+
+	index, err := NewBoltIndex(db, "test")
+	require.NoError(t, err)
+	require.NoError(t, index.Upsert(doc1))
+
+	require.NoError(t, index.Rebuild([]Indexed{doc2}))
+	require.Equal(t, 1, index.Size())
+	require.ElementsMatch(t, []string{"2"}, searchIndex(t, index, "arch:"))
+
+	loaded, err := NewBoltIndex(db, "test")
+	require.NoError(t, err)
+	require.True(t, loaded.Loaded())
+	require.ElementsMatch(t, []string{"2"}, searchIndex(t, loaded, "arch:"))
+}
+
+func TestBoltIndexUpdatesInTransaction(t *testing.T) {
+	db := testBoltDB(t)
+	doc1, doc2 := testBoltIndexDocuments()
+
+	index, err := NewBoltIndex(db, "test")
+	require.NoError(t, err)
+	require.NoError(t, index.Upsert(doc1))
+
+	// documents are not stored or indexed if the transaction is rolled back
+	errRollback := errors.New("rollback")
+	err = db.Update(func(tx *bbolt.Tx) error {
+		require.NoError(t, index.UpsertTx(tx, doc2))
+		require.NoError(t, index.RemoveTx(tx, doc1))
+		return errRollback
+	})
+	require.ErrorIs(t, err, errRollback)
+	require.ElementsMatch(t, []string{"1"}, searchIndex(t, index, "arch:"))
+
+	loaded, err := NewBoltIndex(db, "test")
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"1"}, searchIndex(t, loaded, "arch:"))
+
+	// documents are stored and indexed when the transaction is committed
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if err := index.UpsertTx(tx, doc2); err != nil {
+			return err
+		}
+		return index.RemoveTx(tx, doc1)
+	})
+	require.NoError(t, err)
+	require.ElementsMatch(t, []string{"2"}, searchIndex(t, index, "arch:"))
+
+	loaded, err = NewBoltIndex(db, "test")
+	require.NoError(t, err)
+	require.True(t, loaded.Loaded())
+	require.ElementsMatch(t, []string{"2"}, searchIndex(t, loaded, "arch:"))
+}
+
+func TestBoltIndexResetsWhenInvalid(t *testing.T) {
+	tests := []struct {
+		name   string
+		modify func(b *bbolt.Bucket) error
+	}{
+		{
+			name: "version mismatch",
+			modify: func(b *bbolt.Bucket) error {
+				return b.Put(boltIndexKeyVersion, []byte("0"))
+			},
+		},
+		{
+			name: "corrupt document",
+			modify: func(b *bbolt.Bucket) error {
+				return b.Bucket(boltIndexDocuments).Put([]byte("1"), []byte("{not json"))
+			},
+		},
+		{
+			name: "mismatched document id",
+			modify: func(b *bbolt.Bucket) error {
+				return b.Bucket(boltIndexDocuments).Put([]byte("3"), []byte(`{"id":"4"}`))
+			},
+		},
+		{
+			name: "missing documents",
+			modify: func(b *bbolt.Bucket) error {
+				return b.DeleteBucket(boltIndexDocuments)
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := testBoltDB(t)
+			doc1, doc2 := testBoltIndexDocuments()
+
+			index, err := NewBoltIndex(db, "test")
+			require.NoError(t, err)
+			require.NoError(t, index.Upsert(doc1))
+			require.NoError(t, index.Upsert(doc2))
+
+			err = db.Update(func(tx *bbolt.Tx) error {
+				return test.modify(tx.Bucket([]byte(BoltIndexBucket)).Bucket([]byte("test")))
+			})
+			require.NoError(t, err)
+
+			loaded, err := NewBoltIndex(db, "test")
+			require.NoError(t, err)
+			require.False(t, loaded.Loaded())
+			require.Equal(t, 0, loaded.Size())
+
+			// the index is usable after being reset
+			require.NoError(t, loaded.Upsert(doc1))
+			reloaded, err := NewBoltIndex(db, "test")
+			require.NoError(t, err)
+			require.True(t, reloaded.Loaded())
+			require.Equal(t, 1, reloaded.Size())
+		})
+	}
+}
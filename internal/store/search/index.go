@@ -73,6 +73,10 @@ type index struct {
 
 // NewInMemoryIndex returns a new implementation of the the search Index interface that stores the index in memory
 func NewInMemoryIndex(name string) Index {
+	return newIndex(name)
+}
+
+func newIndex(name string) *index {
 	return &index{
 		name:      name,
 		documents: map[string]*document{},
@@ -105,6 +109,21 @@ func (i *index) Remove(indexed Indexed) error {
 	return nil
 }
 
+// size returns the number of documents in the index
+func (i *index) size() int {
+	i.mtx.RLock()
+	defer i.mtx.RUnlock()
+	return len(i.documents)
+}
+
+// clear removes all documents from the index
+func (i *index) clear() {
+	i.mtx.Lock()
+	defer i.mtx.Unlock()
+	i.documents = map[string]*document{}
+	i.facets = newFacets()
+}
+
 func (i *index) Search(ctx context.Context, query *Query) ([]string, error) {
 	ctx, span := tracer.Start(ctx, "index/Search")
 	defer span.End()
@@ -0,0 +1,98 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package store
+
+import (
+	"context"
+
+	"github.com/observiq/bindplane-op/model"
+)
+
+// secretStore is a Store that encrypts the values of Secrets before they are stored so that they are never stored in
+// plain text
+type secretStore struct {
+	Store
+	key string
+}
+
+var _ Store = (*secretStore)(nil)
+
+// NewSecretStore returns a Store that encrypts the values of Secrets applied to the specified Store using the key.
+// Secrets with values cannot be applied if the key is empty.
+func NewSecretStore(s Store, key string) Store {
+	return &secretStore{
+		Store: s,
+		key:   key,
+	}
+}
+
+// ApplyResources encrypts the values of any Secrets and applies the resources. Secrets that cannot be encrypted are
+// not applied and are returned with StatusInvalid.
+func (s *secretStore) ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error) {
+	var invalid []model.ResourceStatus
+	apply := make([]model.Resource, 0, len(resources))
+	for _, r := range resources {
+		if secret, ok := r.(*model.Secret); ok {
+			// the copy is encrypted so that the secret of the caller is unchanged
+			encrypted := *secret
+			if err := s.encrypt(ctx, &encrypted); err != nil {
+				invalid = append(invalid, *model.NewResourceStatusWithReason(r, model.StatusInvalid, err.Error()))
+				continue
+			}
+			r = &encrypted
+		}
+		apply = append(apply, r)
+	}
+
+	statuses, err := s.Store.ApplyResources(ctx, apply)
+	return append(invalid, statuses...), err
+}
+
+// encrypt replaces the value of the secret with an encrypted value. If the value is unchanged, the current encrypted
+// value is kept so that the secret is unchanged.
+func (s *secretStore) encrypt(ctx context.Context, secret *model.Secret) error {
+	if secret.Spec.Value == "" {
+		return nil
+	}
+	current, err := s.Store.Secret(ctx, secret.Name())
+	if err == nil && current != nil {
+		if value, err := current.Decrypt(s.key); err == nil && value == secret.Spec.Value {
+			secret.Spec.EncryptedValue = current.Spec.EncryptedValue
+			secret.Spec.Value = ""
+			return nil
+		}
+	}
+	return secret.Encrypt(s.key)
+}
+
+// SecretValues returns the values of all of the Secrets decrypted with the key so that they can be redacted from
+// configurations reported by agents. Secrets that cannot be decrypted are skipped and nil is returned if there is no
+// key.
+func SecretValues(ctx context.Context, s Store, key string) ([]string, error) {
+	if key == "" {
+		return nil, nil
+	}
+	secrets, err := s.Secrets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var values []string
+	for _, secret := range secrets {
+		if value, err := secret.Decrypt(key); err == nil {
+			values = append(values, value)
+		}
+	}
+	return values, nil
+}
@@ -85,6 +85,10 @@ type Measurements interface {
 
 	// ProcessMetrics is called in the background at regular intervals and performs metric roll-up and removes old data
 	ProcessMetrics(ctx context.Context) error
+
+	// ExportMeasurements returns all of the metrics saved with SaveAgentMetrics that have not been removed by
+	// ProcessMetrics. It is used to include measurements in a backup.
+	ExportMeasurements(ctx context.Context) (MetricData, error)
 }
 
 // ----------------------------------------------------------------------
@@ -81,6 +81,29 @@ func (_m *Measurements) ConfigurationMetrics(ctx context.Context, name string, o
 	return r0, r1
 }
 
+// ExportMeasurements provides a mock function with given fields: ctx
+func (_m *Measurements) ExportMeasurements(ctx context.Context) (stats.MetricData, error) {
+	ret := _m.Called(ctx)
+
+	var r0 stats.MetricData
+	if rf, ok := ret.Get(0).(func(context.Context) stats.MetricData); ok {
+		r0 = rf(ctx)
+	} else {
+		if ret.Get(0) != nil {
+			r0 = ret.Get(0).(stats.MetricData)
+		}
+	}
+
+	var r1 error
+	if rf, ok := ret.Get(1).(func(context.Context) error); ok {
+		r1 = rf(ctx)
+	} else {
+		r1 = ret.Error(1)
+	}
+
+	return r0, r1
+}
+
 // MeasurementsSize provides a mock function with given fields:
 func (_m *Measurements) MeasurementsSize() (int, error) {
 	ret := _m.Called()
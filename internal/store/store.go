@@ -16,15 +16,21 @@ package store
 
 import (
 	"context"
+	"crypto/subtle"
 	"errors"
 	"fmt"
+	"io"
 	"io/fs"
 	"net/http"
+	"os"
+	"reflect"
 	"sort"
+	"strings"
 	"time"
 
 	"github.com/gorilla/sessions"
 	"github.com/hashicorp/go-multierror"
+	"github.com/observiq/bindplane-op/common"
 	"github.com/observiq/bindplane-op/internal/eventbus"
 	"github.com/observiq/bindplane-op/internal/store/search"
 	"github.com/observiq/bindplane-op/internal/store/stats"
@@ -42,6 +48,67 @@ type Options struct {
 	MaxEventsToMerge int
 }
 
+// NewStore creates the Store specified by the StoreType of the server configuration. bbolt is used if no StoreType is
+// specified. Changes made to the Store are recorded in its audit log.
+func NewStore(ctx context.Context, config *common.Server, logger *zap.Logger) (Store, error) {
+	s, err := newBackendStore(ctx, config, logger)
+	if err != nil {
+		return nil, err
+	}
+
+	var auditLog io.Writer
+	if config.AuditLogFile != "" {
+		file, err := os.OpenFile(config.AuditLogFile, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open the audit log file: %w", err)
+		}
+		logger.Info("Writing the audit log", zap.String("auditLogFile", config.AuditLogFile))
+		auditLog = file
+	}
+
+	return NewAuditStore(NewSecretStore(s, config.SecretsKey), auditLog, logger), nil
+}
+
+func newBackendStore(ctx context.Context, config *common.Server, logger *zap.Logger) (Store, error) {
+	options := Options{
+		SessionsSecret:   config.SessionsSecret,
+		MaxEventsToMerge: 100,
+	}
+
+	switch config.StoreType {
+	case common.StoreTypeMap:
+		return NewMapStore(ctx, options, logger), nil
+
+	case common.StoreTypeGoogleCloud:
+		logger.Info("Using Google Cloud Datastore and Pub/Sub")
+		return NewGoogleCloudStore(ctx, config, logger)
+
+	case common.StoreTypePostgres:
+		logger.Info("Using PostgreSQL")
+		return NewPostgresStore(ctx, config.Postgres, options, logger)
+
+	default:
+		// case common.StoreTypeBbolt:
+		storageFilePath := config.BoltDatabasePath()
+
+		db, err := InitDB(storageFilePath)
+		// Exit if DB creation is unsuccessful.
+		if err != nil {
+			return nil, fmt.Errorf("BBolt storage file failed to open: %w", err)
+		}
+
+		// apply any migrations before using the database. the server must not use storage it cannot migrate or that was
+		// written by a newer version.
+		if _, _, err := MigrateBolt(db, logger); err != nil {
+			db.Close()
+			return nil, fmt.Errorf("failed to migrate bbolt storage: %w", err)
+		}
+
+		logger.Info("Using BBolt Storage", zap.String("storageFilePath", storageFilePath))
+		return NewBoltStore(ctx, db, options, logger), nil
+	}
+}
+
 // Store handles interacting with a storage backend,
 type Store interface {
 	Clear()
@@ -63,32 +130,97 @@ type Store interface {
 	DeleteConfiguration(ctx context.Context, name string) (*model.Configuration, error)
 
 	Source(ctx context.Context, name string) (*model.Source, error)
-	Sources(ctx context.Context) ([]*model.Source, error)
+	Sources(ctx context.Context, options ...QueryOption) ([]*model.Source, error)
 	DeleteSource(ctx context.Context, name string) (*model.Source, error)
 
 	SourceType(ctx context.Context, name string) (*model.SourceType, error)
-	SourceTypes(ctx context.Context) ([]*model.SourceType, error)
+	SourceTypes(ctx context.Context, options ...QueryOption) ([]*model.SourceType, error)
 	DeleteSourceType(ctx context.Context, name string) (*model.SourceType, error)
 
 	Processor(ctx context.Context, name string) (*model.Processor, error)
-	Processors(ctx context.Context) ([]*model.Processor, error)
+	Processors(ctx context.Context, options ...QueryOption) ([]*model.Processor, error)
 	DeleteProcessor(ctx context.Context, name string) (*model.Processor, error)
 
 	ProcessorType(ctx context.Context, name string) (*model.ProcessorType, error)
-	ProcessorTypes(ctx context.Context) ([]*model.ProcessorType, error)
+	ProcessorTypes(ctx context.Context, options ...QueryOption) ([]*model.ProcessorType, error)
 	DeleteProcessorType(ctx context.Context, name string) (*model.ProcessorType, error)
 
 	Destination(ctx context.Context, name string) (*model.Destination, error)
-	Destinations(ctx context.Context) ([]*model.Destination, error)
+	Destinations(ctx context.Context, options ...QueryOption) ([]*model.Destination, error)
 	DeleteDestination(ctx context.Context, name string) (*model.Destination, error)
 
 	DestinationType(ctx context.Context, name string) (*model.DestinationType, error)
-	DestinationTypes(ctx context.Context) ([]*model.DestinationType, error)
+	DestinationTypes(ctx context.Context, options ...QueryOption) ([]*model.DestinationType, error)
 	DeleteDestinationType(ctx context.Context, name string) (*model.DestinationType, error)
 
+	ExtensionType(ctx context.Context, name string) (*model.ExtensionType, error)
+	ExtensionTypes(ctx context.Context, options ...QueryOption) ([]*model.ExtensionType, error)
+	DeleteExtensionType(ctx context.Context, name string) (*model.ExtensionType, error)
+
+	EnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error)
+	EnrollmentTokens(ctx context.Context) ([]*model.EnrollmentToken, error)
+	DeleteEnrollmentToken(ctx context.Context, name string) (*model.EnrollmentToken, error)
+
+	Secret(ctx context.Context, name string) (*model.Secret, error)
+	Secrets(ctx context.Context) ([]*model.Secret, error)
+	DeleteSecret(ctx context.Context, name string) (*model.Secret, error)
+
 	ApplyResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error)
-	// Batch delete of a slice of resources, returns the successfully deleted resources or an error.
-	DeleteResources(ctx context.Context, resources []model.Resource) ([]model.ResourceStatus, error)
+	// Batch delete of a slice of resources, returns the successfully deleted resources or an error. WithCascade can be
+	// used to remove the references to the resources or delete their dependents and WithDryRun to only return the
+	// statuses of the changes that would be made.
+	DeleteResources(ctx context.Context, resources []model.Resource, options ...DeleteOption) ([]model.ResourceStatus, error)
+
+	// ResourceRevisions returns every revision of the resource with the specified kind and name, ordered from oldest to
+	// newest. Revisions are retained after the resource is deleted.
+	ResourceRevisions(ctx context.Context, kind model.Kind, name string) ([]model.Resource, error)
+
+	// AddAuditEntries stores entries in the audit log, assigning each an ID and a Timestamp if it does not have one.
+	AddAuditEntries(ctx context.Context, entries []*model.AuditEntry) error
+	// AuditEntries returns the entries in the audit log that match the filter, ordered from newest to oldest.
+	AuditEntries(ctx context.Context, filter model.AuditFilter) ([]*model.AuditEntry, error)
+	// CleanupAuditEntries removes the entries in the audit log recorded before the specified time and the oldest entries
+	// beyond maxEntries. A zero time or maxEntries of 0 disables the respective limit.
+	CleanupAuditEntries(ctx context.Context, before time.Time, maxEntries int) error
+
+	// AgentHistory returns the changes to the Status and ErrorMessage of the agent with the specified ID, ordered from
+	// oldest to newest. A change is recorded by UpsertAgent and UpsertAgents when an agent is created or its Status or
+	// ErrorMessage changes, and the history is removed when the agent is deleted.
+	AgentHistory(ctx context.Context, agentID string) ([]*model.AgentStatusChange, error)
+	// CleanupAgentHistory removes the status changes of each agent recorded before the specified time and the oldest
+	// changes of each agent beyond maxChanges. The most recent change of each agent is always kept. A zero time or
+	// maxChanges of 0 disables the respective limit.
+	CleanupAgentHistory(ctx context.Context, before time.Time, maxChanges int) error
+
+	// Rollout returns the most recent rollout of the Configuration with the specified name or nil if the Configuration
+	// has never been rolled out in waves.
+	Rollout(ctx context.Context, name string) (*model.Rollout, error)
+	// Rollouts returns the most recent rollout of each Configuration, sorted by name.
+	Rollouts(ctx context.Context) ([]*model.Rollout, error)
+	// UpsertRollout stores the rollout, replacing any previous rollout of the same Configuration.
+	UpsertRollout(ctx context.Context, rollout *model.Rollout) error
+
+	// UpgradeCampaign returns the UpgradeCampaign with the specified name or nil if it does not exist.
+	UpgradeCampaign(ctx context.Context, name string) (*model.UpgradeCampaign, error)
+	// UpgradeCampaigns returns all of the UpgradeCampaigns, sorted by name.
+	UpgradeCampaigns(ctx context.Context) ([]*model.UpgradeCampaign, error)
+	// UpsertUpgradeCampaign stores the UpgradeCampaign, replacing any previous UpgradeCampaign with the same name.
+	UpsertUpgradeCampaign(ctx context.Context, campaign *model.UpgradeCampaign) error
+
+	// AcquireLease acquires or renews the lease with the specified name for the holder until the ttl expires and returns
+	// true if the holder has the lease. It is used to run a task on only one server when multiple servers share a Store.
+	AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (bool, error)
+
+	// UseEnrollmentToken counts a use of the EnrollmentToken with the specified ID, which is the token presented by
+	// agents, and returns true if it was used fewer than maxUses times before. The use is not counted otherwise. The count
+	// is checked and incremented atomically so that servers sharing a Store cannot exceed maxUses, which is unlimited if
+	// 0. Uses are not returned when agents are deleted.
+	UseEnrollmentToken(ctx context.Context, id string, maxUses int) (bool, error)
+	// RevokeAgent records that the credentials of the agent with the specified ID were revoked. The revocation is kept
+	// after the agent is deleted so that the agent cannot enroll again with the same ID.
+	RevokeAgent(ctx context.Context, agentID string) error
+	// AgentRevoked returns true if the credentials of the agent with the specified ID were revoked
+	AgentRevoked(ctx context.Context, agentID string) (bool, error)
 
 	// AgentConfiguration returns the configuration that should be applied to an agent.
 	AgentConfiguration(ctx context.Context, agentID string) (*model.Configuration, error)
@@ -110,6 +242,10 @@ type Store interface {
 	// ConfigurationIndex provides access to the search Index for Configurations
 	ConfigurationIndex(ctx context.Context) search.Index
 
+	// ResourceIndex provides access to the search Index for Sources, Processors, Destinations, their types, and
+	// Configurations. It returns nil for other kinds.
+	ResourceIndex(ctx context.Context, kind model.Kind) search.Index
+
 	// UserSessions must implement the gorilla sessions.Store interface
 	UserSessions() sessions.Store
 
@@ -122,6 +258,15 @@ type Store interface {
 // Store implementation.
 type AgentUpdater func(current *model.Agent)
 
+// agentStatusChange returns the change to add to the history of the agent after it is updated or nil if the agent
+// already existed and its Status and ErrorMessage did not change
+func agentStatusChange(agent *model.Agent, exists bool, statusBefore model.AgentStatus, errorBefore string) *model.AgentStatusChange {
+	if exists && agent.Status == statusBefore && agent.ErrorMessage == errorBefore {
+		return nil
+	}
+	return model.NewAgentStatusChange(agent, time.Now().UTC())
+}
+
 // ErrResourceMissing is used in delete functions to indicate the delete
 // could not be performed because no such resource exists
 var ErrResourceMissing = errors.New("resource not found")
@@ -131,6 +276,69 @@ var ErrResourceMissing = errors.New("resource not found")
 // i.e. the Source that is being deleted is being referenced in a Configuration.
 var ErrResourceInUse = errors.New("resource in use")
 
+// ErrResourceConflict is used in apply and delete functions to indicate the change could not be performed because the
+// resourceVersion of the resource does not match the resourceVersion of the stored resource.
+var ErrResourceConflict = errors.New("resource version conflict")
+
+// checkResourceVersion returns ErrResourceConflict if the resource specifies a resourceVersion that does not match the
+// resourceVersion of the existing resource or the resource does not exist. Resources without a resourceVersion are
+// always accepted. The resourceVersion is cleared once it has been checked because it is not stored.
+func checkResourceVersion(r model.Resource, existingRevision int, exists bool) error {
+	version := r.ResourceVersion()
+	if !exists {
+		if err := checkMissingVersion(r.GetKind(), r.Name(), version); err != nil {
+			return err
+		}
+	} else if existingVersion := model.RevisionResourceVersion(existingRevision); version != "" && version != existingVersion {
+		return fmt.Errorf("%w: %s %s has resourceVersion %q but %q was applied", ErrResourceConflict, r.GetKind(), r.Name(), existingVersion, version)
+	}
+	r.SetResourceVersion("")
+	return nil
+}
+
+// checkMissingVersion returns ErrResourceConflict if a resourceVersion was specified when applying or deleting a
+// resource that does not exist, because no version of the resource can match it
+func checkMissingVersion(kind model.Kind, name, version string) error {
+	if version == "" {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s does not exist but resourceVersion %q was specified", ErrResourceConflict, kind, name, version)
+}
+
+// checkDeleteVersion returns ErrResourceConflict if a resourceVersion was specified when deleting the existing resource
+// and it does not match the resourceVersion of the existing resource.
+func checkDeleteVersion(existing model.Resource, version string) error {
+	existingVersion := model.RevisionResourceVersion(existing.Revision())
+	if version == "" || version == existingVersion {
+		return nil
+	}
+	return fmt.Errorf("%w: %s %s has resourceVersion %q but %q was deleted", ErrResourceConflict, existing.GetKind(), existing.Name(), existingVersion, version)
+}
+
+// copyResources returns copies of the resources being applied so that the store does not change the resources of the
+// caller. Use assignResources to copy the ID and revision assigned by the store back to the resources of the caller.
+// Resources of unknown kinds cannot be copied and are returned unchanged to be rejected when they are applied.
+func copyResources(resources []model.Resource) []model.Resource {
+	copies := make([]model.Resource, 0, len(resources))
+	for _, r := range resources {
+		c, err := copyResource(r)
+		if err != nil {
+			c = r
+		}
+		copies = append(copies, c)
+	}
+	return copies
+}
+
+// assignResources sets the ID and revision of the resources of the caller to those assigned by the store to the copies
+// returned by copyResources
+func assignResources(resources, copies []model.Resource) {
+	for i, r := range resources {
+		r.SetID(copies[i].ID())
+		r.SetRevision(copies[i].Revision())
+	}
+}
+
 // ----------------------------------------------------------------------
 
 // queryOptions represents the set of options available for a store query
@@ -194,6 +402,155 @@ func WithSort(field string) QueryOption {
 	}
 }
 
+// ----------------------------------------------------------------------
+
+// GetResource returns the stored resource with the specified kind and name or nil if it does not exist. Kinds that are
+// not resources, like Agent, always return nil.
+func GetResource(ctx context.Context, s Store, kind model.Kind, name string) (model.Resource, error) {
+	switch kind {
+	case model.KindAgentVersion:
+		return nilIfMissing(s.AgentVersion(ctx, name))
+	case model.KindConfiguration:
+		return nilIfMissing(s.Configuration(ctx, name))
+	case model.KindSource:
+		return nilIfMissing(s.Source(ctx, name))
+	case model.KindSourceType:
+		return nilIfMissing(s.SourceType(ctx, name))
+	case model.KindProcessor:
+		return nilIfMissing(s.Processor(ctx, name))
+	case model.KindProcessorType:
+		return nilIfMissing(s.ProcessorType(ctx, name))
+	case model.KindDestination:
+		return nilIfMissing(s.Destination(ctx, name))
+	case model.KindDestinationType:
+		return nilIfMissing(s.DestinationType(ctx, name))
+	case model.KindExtensionType:
+		return nilIfMissing(s.ExtensionType(ctx, name))
+	case model.KindEnrollmentToken:
+		return nilIfMissing(s.EnrollmentToken(ctx, name))
+	case model.KindSecret:
+		return nilIfMissing(s.Secret(ctx, name))
+	default:
+		return nil, nil
+	}
+}
+
+// nilIfMissing converts a typed nil resource into a nil model.Resource
+func nilIfMissing[R model.Resource](r R, err error) (model.Resource, error) {
+	if err != nil {
+		return nil, err
+	}
+	if reflect.ValueOf(r).IsNil() {
+		return nil, nil
+	}
+	return r, nil
+}
+
+// ----------------------------------------------------------------------
+// revisions
+
+// ResourceRevision returns the specified revision of the resource with the specified kind and name. ErrResourceMissing
+// is returned if the revision does not exist.
+func ResourceRevision(ctx context.Context, store Store, kind model.Kind, name string, revision int) (model.Resource, error) {
+	revisions, err := store.ResourceRevisions(ctx, kind, name)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, r := range revisions {
+		if r.Revision() == revision {
+			return r, nil
+		}
+	}
+
+	return nil, fmt.Errorf("%s %s revision %d: %w", kind, name, revision, ErrResourceMissing)
+}
+
+// RollbackResource restores the resource with the specified kind and name to the specified revision. The restored
+// resource is applied with ApplyResources, so it becomes the newest revision and is sent with Updates like any other
+// change. ErrResourceMissing is returned if the revision does not exist.
+func RollbackResource(ctx context.Context, store Store, kind model.Kind, name string, revision int) (*model.ResourceStatus, error) {
+	r, err := ResourceRevision(ctx, store, kind, name, revision)
+	if err != nil {
+		return nil, err
+	}
+
+	// the revision replaces whatever is current, so it must not conflict with the current resourceVersion
+	r.SetResourceVersion("")
+	statuses, err := store.ApplyResources(ctx, []model.Resource{r})
+	if err != nil {
+		return nil, err
+	}
+	if len(statuses) == 0 {
+		return nil, fmt.Errorf("rollback %s %s: no status returned", kind, name)
+	}
+	return &statuses[0], nil
+}
+
+// ----------------------------------------------------------------------
+// agent restarts
+
+// RequestAgentRestarts requests a restart of the agents with the specified IDs and, if any options are specified, the
+// agents matching the options. Disconnected agents cannot be restarted and are skipped. The restart itself is sent to
+// each agent by the Manager when it receives the update. It returns the agents that will be restarted.
+func RequestAgentRestarts(ctx context.Context, store Store, ids []string, options ...QueryOption) ([]*model.Agent, error) {
+	var candidates []*model.Agent
+	for _, id := range ids {
+		agent, err := store.Agent(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		if agent != nil {
+			candidates = append(candidates, agent)
+		}
+	}
+	if len(options) > 0 {
+		agents, err := store.Agents(ctx, options...)
+		if err != nil {
+			return nil, err
+		}
+		candidates = append(candidates, agents...)
+	}
+
+	restartIDs := []string{}
+	included := map[string]bool{}
+	for _, agent := range candidates {
+		if included[agent.ID] || agent.Status == model.Disconnected {
+			continue
+		}
+		included[agent.ID] = true
+		restartIDs = append(restartIDs, agent.ID)
+	}
+	if len(restartIDs) == 0 {
+		return []*model.Agent{}, nil
+	}
+
+	return store.UpsertAgents(ctx, restartIDs, func(current *model.Agent) {
+		current.RequestRestart()
+	})
+}
+
+// ----------------------------------------------------------------------
+// enrollment tokens
+
+// FindEnrollmentToken returns the EnrollmentToken with the specified token, which is the ID of the EnrollmentToken, or
+// nil if there is no such EnrollmentToken.
+func FindEnrollmentToken(ctx context.Context, store Store, token string) (*model.EnrollmentToken, error) {
+	if token == "" {
+		return nil, nil
+	}
+	tokens, err := store.EnrollmentTokens(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, t := range tokens {
+		if subtle.ConstantTimeCompare([]byte(t.Token()), []byte(token)) == 1 {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+
 // ----------------------------------------------------------------------
 // seeding resources
 
@@ -259,6 +616,8 @@ func seedDir(ctx context.Context, dir string, store Store, logger *zap.Logger) e
 type dependency struct {
 	name string
 	kind model.Kind
+	// dependents are the resources that depend on this dependency and would also be affected by the delete
+	dependents DependentResources
 }
 
 // DependentResources is the return type of store.dependentResources
@@ -271,17 +630,40 @@ func (r *DependentResources) empty() bool {
 }
 
 func (r *DependentResources) message() string {
-	msg := "Dependent resources:\n"
+	var sb strings.Builder
+	sb.WriteString("Dependent resources:\n")
+	r.writeMessage(&sb, "")
+	return sb.String()
+}
+
+// writeMessage writes each dependency on its own line, indenting the dependents of each dependency below it
+func (r *DependentResources) writeMessage(sb *strings.Builder, indent string) {
 	for _, item := range *r {
-		msg += fmt.Sprintf("%s %s\n", item.kind, item.name)
+		sb.WriteString(fmt.Sprintf("%s%s %s\n", indent, item.kind, item.name))
+		item.dependents.writeMessage(sb, indent+"  ")
 	}
-	return msg
 }
 
 func (r *DependentResources) add(d dependency) {
 	*r = append(*r, d)
 }
 
+// Dependents returns the dependencies as a tree of model.Dependent
+func (r DependentResources) Dependents() []*model.Dependent {
+	dependents := make([]*model.Dependent, 0, len(r))
+	for _, item := range r {
+		dependent := &model.Dependent{
+			Kind: item.kind,
+			Name: item.name,
+		}
+		if !item.dependents.empty() {
+			dependent.Dependents = item.dependents.Dependents()
+		}
+		dependents = append(dependents, dependent)
+	}
+	return dependents
+}
+
 // DependencyError is returned when trying to delete a resource
 // that is being referenced by other resources.
 type DependencyError struct {
@@ -292,6 +674,11 @@ func (de *DependencyError) Error() string {
 	return de.dependencies.message()
 }
 
+// Dependencies returns the resources that depend on the resource that could not be deleted
+func (de *DependencyError) Dependencies() DependentResources {
+	return de.dependencies
+}
+
 func newDependencyError(d DependentResources) error {
 	return &DependencyError{
 		dependencies: d,
@@ -300,31 +687,180 @@ func newDependencyError(d DependentResources) error {
 
 // ----------------------------------------------------------------------
 
-// FindDependentResources finds the dependent resources using the ConfigurationIndex provided by the Store.
+// dependentField is a field in the search index of a kind of resource that refers to another resource by name
+type dependentField struct {
+	kind  model.Kind
+	field string
+}
+
+// dependentFields contains the fields that refer to a resource of each kind. Fields ending in Type refer to resource
+// types used by inline resources in a Configuration, Source, or Destination.
+var dependentFields = map[model.Kind][]dependentField{
+	model.KindSource: {
+		{kind: model.KindConfiguration, field: "source"},
+	},
+	model.KindSourceType: {
+		{kind: model.KindSource, field: "type"},
+		{kind: model.KindConfiguration, field: "sourceType"},
+	},
+	model.KindProcessor: {
+		{kind: model.KindSource, field: "processor"},
+		{kind: model.KindDestination, field: "processor"},
+		{kind: model.KindConfiguration, field: "processor"},
+	},
+	model.KindProcessorType: {
+		{kind: model.KindProcessor, field: "type"},
+		{kind: model.KindSource, field: "processorType"},
+		{kind: model.KindDestination, field: "processorType"},
+		{kind: model.KindConfiguration, field: "processorType"},
+	},
+	model.KindDestination: {
+		{kind: model.KindConfiguration, field: "destination"},
+	},
+	model.KindDestinationType: {
+		{kind: model.KindDestination, field: "type"},
+		{kind: model.KindConfiguration, field: "destinationType"},
+	},
+	model.KindExtensionType: {
+		{kind: model.KindConfiguration, field: "extensionType"},
+	},
+	model.KindSecret: {
+		{kind: model.KindSource, field: "secret"},
+		{kind: model.KindProcessor, field: "secret"},
+		{kind: model.KindDestination, field: "secret"},
+		{kind: model.KindConfiguration, field: "secret"},
+	},
+}
+
+// FindDependentResources finds the dependent resources using the search indexes provided by the Store. Each dependency
+// includes the resources that depend on it, e.g. the Configurations using a Source that depends on a SourceType.
 func FindDependentResources(ctx context.Context, s Store, r model.Resource) (DependentResources, error) {
+	return findDependentResources(ctx, s, r.GetKind(), r.Name())
+}
+
+func findDependentResources(ctx context.Context, s Store, kind model.Kind, name string) (DependentResources, error) {
 	var dependencies DependentResources
 
-	switch r.GetKind() {
-	case model.KindSource:
-		ids, err := search.Field(ctx, s.ConfigurationIndex(ctx), "source", r.Name())
+	for _, dependent := range dependentFields[kind] {
+		index := s.ResourceIndex(ctx, dependent.kind)
+		if index == nil {
+			continue
+		}
+		names, err := search.Field(ctx, index, dependent.field, name)
 		if err != nil {
 			return nil, err
 		}
-		for _, id := range ids {
-			dependencies.add(dependency{name: id, kind: model.KindConfiguration})
+		sort.Strings(names)
+
+		for _, dependentName := range names {
+			dependents, err := findDependentResources(ctx, s, dependent.kind, dependentName)
+			if err != nil {
+				return nil, err
+			}
+			dependencies.add(dependency{name: dependentName, kind: dependent.kind, dependents: dependents})
 		}
+	}
 
-	case model.KindDestination:
-		ids, err := search.Field(ctx, s.ConfigurationIndex(ctx), "destination", r.Name())
+	return dependencies, nil
+}
+
+// ----------------------------------------------------------------------
+// search indexes for resources
+
+// resourceIndexes contains a search index for each of the kinds of resources that support search queries, other than
+// Configurations which have their own index
+type resourceIndexes map[model.Kind]search.Index
+
+func newResourceIndexes(newIndex func(name string) search.Index) resourceIndexes {
+	return resourceIndexes{
+		model.KindSource:          newIndex("source"),
+		model.KindSourceType:      newIndex("sourceType"),
+		model.KindProcessor:       newIndex("processor"),
+		model.KindProcessorType:   newIndex("processorType"),
+		model.KindDestination:     newIndex("destination"),
+		model.KindDestinationType: newIndex("destinationType"),
+		model.KindExtensionType:   newIndex("extensionType"),
+	}
+}
+
+// upsert adds the resource to the index for its kind if there is one
+func (i resourceIndexes) upsert(r model.Resource) error {
+	if index, ok := i[r.GetKind()]; ok {
+		return index.Upsert(r)
+	}
+	return nil
+}
+
+// remove removes the resource from the index for its kind if there is one
+func (i resourceIndexes) remove(r model.Resource) error {
+	if index, ok := i[r.GetKind()]; ok {
+		return index.Remove(r)
+	}
+	return nil
+}
+
+// update applies the events in the updates to the indexes. It is used by stores that receive updates from other
+// servers.
+func (i resourceIndexes) update(logger *zap.Logger, updates *Updates) {
+	for _, event := range updates.Sources {
+		updateIndex(logger, i[model.KindSource], event)
+	}
+	for _, event := range updates.SourceTypes {
+		updateIndex(logger, i[model.KindSourceType], event)
+	}
+	for _, event := range updates.Processors {
+		updateIndex(logger, i[model.KindProcessor], event)
+	}
+	for _, event := range updates.ProcessorTypes {
+		updateIndex(logger, i[model.KindProcessorType], event)
+	}
+	for _, event := range updates.Destinations {
+		updateIndex(logger, i[model.KindDestination], event)
+	}
+	for _, event := range updates.DestinationTypes {
+		updateIndex(logger, i[model.KindDestinationType], event)
+	}
+	for _, event := range updates.ExtensionTypes {
+		updateIndex(logger, i[model.KindExtensionType], event)
+	}
+}
+
+// queryResources applies the selector, search query, sort, offset, and limit of the query options to the resources.
+// The index is used for the search query.
+func queryResources[R model.Resource](ctx context.Context, resources []R, index search.Index, opts queryOptions) ([]R, error) {
+	var matches map[string]bool
+	if opts.query != nil && index != nil {
+		ids, err := index.Search(ctx, opts.query)
 		if err != nil {
 			return nil, err
 		}
+		matches = make(map[string]bool, len(ids))
 		for _, id := range ids {
-			dependencies.add(dependency{name: id, kind: model.KindConfiguration})
+			matches[id] = true
 		}
 	}
 
-	return dependencies, nil
+	filtered := []R{}
+	for _, r := range resources {
+		if matches != nil && !matches[r.IndexID()] {
+			continue
+		}
+		if !opts.selector.Matches(r.GetLabels()) {
+			continue
+		}
+		filtered = append(filtered, r)
+	}
+
+	return applySortOffsetAndLimit(filtered, opts, func(field string, item R) string {
+		switch field {
+		case "id":
+			return item.ID()
+		case "description":
+			return item.Description()
+		default:
+			return item.Name()
+		}
+	}), nil
 }
 
 // ----------------------------------------------------------------------
@@ -17,13 +17,18 @@ package store
 // This file contains shared tests for mapstore and boltstore
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
 	"fmt"
+	"sort"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
 
 	"github.com/observiq/bindplane-op/internal/eventbus"
 	"github.com/observiq/bindplane-op/internal/otlp/record"
@@ -827,6 +832,42 @@ func runDependentResourcesTests(t *testing.T, s Store) {
 	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
 
+	batchProcessorType := model.NewProcessorType("batch", []model.ParameterDefinition{})
+	batchProcessor := model.NewProcessor("batch-1", "batch", []model.Parameter{})
+	processedSource := model.NewSourceWithSpec("macos-processed", model.ParameterizedSpec{
+		Type:       "macos",
+		Processors: []model.ResourceConfiguration{{Name: batchProcessor.Name()}},
+	})
+	inlineConfiguration := model.NewConfigurationWithSpec("configuration-inline", model.ConfigurationSpec{
+		Sources: []model.ResourceConfiguration{
+			{
+				ParameterizedSpec: model.ParameterizedSpec{
+					Type:       "macos",
+					Processors: []model.ResourceConfiguration{{ParameterizedSpec: model.ParameterizedSpec{Type: "batch"}}},
+				},
+			},
+			{
+				Name: processedSource.Name(),
+			},
+		},
+		Destinations: []model.ResourceConfiguration{
+			{
+				ParameterizedSpec: model.ParameterizedSpec{Type: "cabin"},
+			},
+		},
+	})
+	allResources := []model.Resource{
+		macosSourceType,
+		macosSource,
+		cabinDestinationType,
+		cabinDestination1,
+		testConfiguration,
+		batchProcessorType,
+		batchProcessor,
+		processedSource,
+		inlineConfiguration,
+	}
+
 	tests := []struct {
 		description      string
 		initialResources []model.Resource
@@ -867,16 +908,292 @@ func runDependentResourcesTests(t *testing.T, s Store) {
 				},
 			},
 		},
+		{
+			description:      "macos source type has source and inline configuration dependencies",
+			initialResources: allResources,
+			testResource:     macosSourceType,
+			expect: DependentResources{
+				{
+					name: macosSource.Name(),
+					kind: model.KindSource,
+					dependents: DependentResources{
+						{name: testConfiguration.Name(), kind: model.KindConfiguration},
+					},
+				},
+				{
+					name: processedSource.Name(),
+					kind: model.KindSource,
+					dependents: DependentResources{
+						{name: inlineConfiguration.Name(), kind: model.KindConfiguration},
+					},
+				},
+				{
+					name: inlineConfiguration.Name(),
+					kind: model.KindConfiguration,
+				},
+			},
+		},
+		{
+			description:      "batch processor has source dependency",
+			initialResources: allResources,
+			testResource:     batchProcessor,
+			expect: DependentResources{
+				{
+					name: processedSource.Name(),
+					kind: model.KindSource,
+					dependents: DependentResources{
+						{name: inlineConfiguration.Name(), kind: model.KindConfiguration},
+					},
+				},
+			},
+		},
+		{
+			description:      "batch processor type has processor and inline configuration dependencies",
+			initialResources: allResources,
+			testResource:     batchProcessorType,
+			expect: DependentResources{
+				{
+					name: batchProcessor.Name(),
+					kind: model.KindProcessor,
+					dependents: DependentResources{
+						{
+							name: processedSource.Name(),
+							kind: model.KindSource,
+							dependents: DependentResources{
+								{name: inlineConfiguration.Name(), kind: model.KindConfiguration},
+							},
+						},
+					},
+				},
+				{
+					name: inlineConfiguration.Name(),
+					kind: model.KindConfiguration,
+				},
+			},
+		},
+		{
+			description:      "cabin destination type has destination and inline configuration dependencies",
+			initialResources: allResources,
+			testResource:     cabinDestinationType,
+			expect: DependentResources{
+				{
+					name: cabinDestination1.Name(),
+					kind: model.KindDestination,
+					dependents: DependentResources{
+						{name: testConfiguration.Name(), kind: model.KindConfiguration},
+					},
+				},
+				{
+					name: inlineConfiguration.Name(),
+					kind: model.KindConfiguration,
+				},
+			},
+		},
+		{
+			description:      "configuration has no dependencies",
+			initialResources: allResources,
+			testResource:     inlineConfiguration,
+			expect:           nil,
+		},
 	}
 
 	for _, test := range tests {
+		s.Clear()
 		updates, err := s.ApplyResources(ctx, test.initialResources)
 		fmt.Println("UPDATES: ", updates)
 
+		for _, update := range updates {
+			require.NotEqual(t, model.StatusInvalid, update.Status, update.Reason)
+		}
+
 		dependencies, err := FindDependentResources(context.TODO(), s, test.testResource)
 		require.NoError(t, err)
-		assert.Equal(t, test.expect, dependencies)
+		assert.Equal(t, test.expect, dependencies, test.description)
 	}
+
+	t.Run("DependencyError includes the tree of dependencies", func(t *testing.T) {
+		s.Clear()
+		_, err := s.ApplyResources(ctx, allResources)
+		require.NoError(t, err)
+
+		_, err = s.DeleteProcessorType(ctx, batchProcessorType.Name())
+		var dependencyError *DependencyError
+		require.ErrorAs(t, err, &dependencyError)
+		require.Equal(t, "Dependent resources:\nProcessor batch-1\n  Source macos-processed\n    Configuration configuration-inline\nConfiguration configuration-inline\n", err.Error())
+
+		processorType, err := s.ProcessorType(ctx, batchProcessorType.Name())
+		require.NoError(t, err)
+		require.NotNil(t, processorType, "the processor type should not be deleted")
+
+		statuses, err := s.DeleteResources(ctx, []model.Resource{macosSourceType})
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		require.Equal(t, model.StatusInUse, statuses[0].Status)
+	})
+}
+
+func runCascadeDeleteTests(t *testing.T, s Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	batchProcessorType := model.NewProcessorType("batch", []model.ParameterDefinition{})
+	batchProcessor := model.NewProcessor("batch-1", "batch", []model.Parameter{})
+	processedSource := model.NewSourceWithSpec("macos-processed", model.ParameterizedSpec{
+		Type:       "macos",
+		Processors: []model.ResourceConfiguration{{Name: batchProcessor.Name()}},
+	})
+	configuration1 := model.NewConfigurationWithSpec("configuration-1", model.ConfigurationSpec{
+		Sources:      []model.ResourceConfiguration{{Name: macosSource.Name()}},
+		Destinations: []model.ResourceConfiguration{{Name: cabinDestination1.Name()}},
+		Selector:     model.AgentSelector{MatchLabels: model.MatchLabels{"configuration": "configuration-1"}},
+	})
+	configuration2 := model.NewConfigurationWithSpec("configuration-2", model.ConfigurationSpec{
+		Sources:      []model.ResourceConfiguration{{Name: processedSource.Name()}},
+		Destinations: []model.ResourceConfiguration{{Name: cabinDestination1.Name()}, {Name: cabinDestination2.Name()}},
+		Selector:     model.AgentSelector{MatchLabels: model.MatchLabels{"configuration": "configuration-2"}},
+	})
+
+	setup := func(t *testing.T) {
+		s.Clear()
+		statuses, err := s.ApplyResources(ctx, []model.Resource{
+			macosSourceType,
+			macosSource,
+			cabinDestinationType,
+			cabinDestination1,
+			cabinDestination2,
+			batchProcessorType,
+			batchProcessor,
+			processedSource,
+			configuration1,
+			configuration2,
+		})
+		require.NoError(t, err)
+		for _, status := range statuses {
+			require.NotEqual(t, model.StatusInvalid, status.Status, status.Reason)
+		}
+		for id, configuration := range map[string]string{"1": "configuration-1", "2": "configuration-2", "3": "other"} {
+			_, err := s.UpsertAgent(ctx, id, func(current *model.Agent) {
+				current.Labels = labels(map[string]string{"configuration": configuration})
+			})
+			require.NoError(t, err)
+		}
+	}
+
+	type change struct {
+		kind   model.Kind
+		name   string
+		status model.UpdateStatus
+	}
+	changes := func(statuses []model.ResourceStatus) []change {
+		result := []change{}
+		for _, status := range statuses {
+			result = append(result, change{status.Resource.GetKind(), status.Resource.Name(), status.Status})
+		}
+		return result
+	}
+
+	t.Run("dry run without cascade reports the resources in use", func(t *testing.T) {
+		setup(t)
+		statuses, err := s.DeleteResources(ctx, []model.Resource{cabinDestination1, configuration1}, WithDryRun(true))
+		require.NoError(t, err)
+		require.Equal(t, []change{
+			{model.KindDestination, "cabin-1", model.StatusInUse},
+			{model.KindConfiguration, "configuration-1", model.StatusDeleted},
+		}, changes(statuses))
+
+		configuration, err := s.Configuration(ctx, "configuration-1")
+		require.NoError(t, err)
+		require.NotNil(t, configuration)
+	})
+
+	t.Run("dry run with cascade references returns the changes without making them", func(t *testing.T) {
+		setup(t)
+		statuses, err := s.DeleteResources(ctx, []model.Resource{cabinDestination1}, WithCascade(model.CascadeReferences), WithDryRun(true))
+		require.NoError(t, err)
+		require.Equal(t, []change{
+			{model.KindConfiguration, "configuration-1", model.StatusConfigured},
+			{model.KindConfiguration, "configuration-2", model.StatusConfigured},
+			{model.KindDestination, "cabin-1", model.StatusDeleted},
+		}, changes(statuses))
+
+		agentIDs, err := AffectedAgentIDs(ctx, s, statuses)
+		require.NoError(t, err)
+		require.Equal(t, []string{"1", "2"}, agentIDs)
+
+		destination, err := s.Destination(ctx, "cabin-1")
+		require.NoError(t, err)
+		require.NotNil(t, destination)
+		configuration, err := s.Configuration(ctx, "configuration-2")
+		require.NoError(t, err)
+		require.Len(t, configuration.Spec.Destinations, 2)
+	})
+
+	t.Run("cascade references removes the references and deletes the resource", func(t *testing.T) {
+		setup(t)
+		statuses, err := s.DeleteResources(ctx, []model.Resource{cabinDestination1}, WithCascade(model.CascadeReferences))
+		require.NoError(t, err)
+		require.Equal(t, []change{
+			{model.KindConfiguration, "configuration-1", model.StatusConfigured},
+			{model.KindConfiguration, "configuration-2", model.StatusConfigured},
+			{model.KindDestination, "cabin-1", model.StatusDeleted},
+		}, changes(statuses))
+
+		destination, err := s.Destination(ctx, "cabin-1")
+		require.NoError(t, err)
+		require.Nil(t, destination)
+		configuration, err := s.Configuration(ctx, "configuration-1")
+		require.NoError(t, err)
+		require.Empty(t, configuration.Spec.Destinations)
+		configuration, err = s.Configuration(ctx, "configuration-2")
+		require.NoError(t, err)
+		require.Equal(t, []model.ResourceConfiguration{{Name: cabinDestination2.Name()}}, configuration.Spec.Destinations)
+	})
+
+	t.Run("cascade references deletes the resources of a deleted type", func(t *testing.T) {
+		setup(t)
+		statuses, err := s.DeleteResources(ctx, []model.Resource{batchProcessorType}, WithCascade(model.CascadeReferences))
+		require.NoError(t, err)
+		require.Equal(t, []change{
+			{model.KindSource, "macos-processed", model.StatusConfigured},
+			{model.KindProcessor, "batch-1", model.StatusDeleted},
+			{model.KindProcessorType, "batch", model.StatusDeleted},
+		}, changes(statuses))
+
+		source, err := s.Source(ctx, "macos-processed")
+		require.NoError(t, err)
+		require.Empty(t, source.Spec.Processors)
+		configuration, err := s.Configuration(ctx, "configuration-2")
+		require.NoError(t, err)
+		require.NotNil(t, configuration)
+	})
+
+	t.Run("cascade delete deletes the dependents first", func(t *testing.T) {
+		setup(t)
+		statuses, err := s.DeleteResources(ctx, []model.Resource{macosSourceType}, WithCascade(model.CascadeDelete), WithDryRun(true))
+		require.NoError(t, err)
+		expect := []change{
+			{model.KindConfiguration, "configuration-1", model.StatusDeleted},
+			{model.KindSource, "macos-1", model.StatusDeleted},
+			{model.KindConfiguration, "configuration-2", model.StatusDeleted},
+			{model.KindSource, "macos-processed", model.StatusDeleted},
+			{model.KindSourceType, "macos", model.StatusDeleted},
+		}
+		require.Equal(t, expect, changes(statuses))
+
+		statuses, err = s.DeleteResources(ctx, []model.Resource{macosSourceType}, WithCascade(model.CascadeDelete))
+		require.NoError(t, err)
+		require.Equal(t, expect, changes(statuses))
+
+		configurations, err := s.Configurations(ctx)
+		require.NoError(t, err)
+		require.Empty(t, configurations)
+		sources, err := s.Sources(ctx)
+		require.NoError(t, err)
+		require.Empty(t, sources)
+		processor, err := s.Processor(ctx, "batch-1")
+		require.NoError(t, err)
+		require.NotNil(t, processor)
+	})
 }
 
 func runIndividualDeleteTests(t *testing.T, store Store) {
@@ -1667,3 +1984,899 @@ func generateTestMetrics(t *testing.T, name string, timestamp, startTime time.Ti
 	}
 	return results
 }
+
+func runResourceRevisionsTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+	updates, _ := eventbus.Subscribe(store.Updates())
+
+	apply := func(raw string) model.ResourceStatus {
+		status, err := store.ApplyResources(ctx, []model.Resource{model.NewRawConfiguration("revisions", raw)})
+		require.NoError(t, err)
+		require.Len(t, status, 1)
+		return status[0]
+	}
+
+	// these tests are dependent on each other and are expected to run in order.
+
+	t.Run("new resource is revision 1", func(t *testing.T) {
+		status := apply("raw: 1")
+		require.Equal(t, model.StatusCreated, status.Status)
+		require.Equal(t, 1, status.Resource.Revision())
+	})
+
+	t.Run("unchanged resource keeps its revision", func(t *testing.T) {
+		status := apply("raw: 1")
+		require.Equal(t, model.StatusUnchanged, status.Status)
+		require.Equal(t, 1, status.Resource.Revision())
+	})
+
+	t.Run("changed resource is a new revision", func(t *testing.T) {
+		status := apply("raw: 2")
+		require.Equal(t, model.StatusConfigured, status.Status)
+		require.Equal(t, 2, status.Resource.Revision())
+
+		revisions, err := store.ResourceRevisions(ctx, model.KindConfiguration, "revisions")
+		require.NoError(t, err)
+		require.Len(t, revisions, 2)
+		for i, raw := range []string{"raw: 1", "raw: 2"} {
+			require.Equal(t, i+1, revisions[i].Revision())
+			require.Equal(t, raw, revisions[i].(*model.Configuration).Spec.Raw)
+		}
+	})
+
+	t.Run("rollback applies the revision as a new revision", func(t *testing.T) {
+		status, err := RollbackResource(ctx, store, model.KindConfiguration, "revisions", 1)
+		require.NoError(t, err)
+		require.Equal(t, model.StatusConfigured, status.Status)
+		require.Equal(t, 3, status.Resource.Revision())
+
+		config, err := store.Configuration(ctx, "revisions")
+		require.NoError(t, err)
+		require.Equal(t, "raw: 1", config.Spec.Raw)
+		require.Equal(t, 3, config.Revision())
+
+		revisions, err := store.ResourceRevisions(ctx, model.KindConfiguration, "revisions")
+		require.NoError(t, err)
+		require.Len(t, revisions, 3)
+
+		// the rollback is sent with Updates
+		timeout := time.After(5 * time.Second)
+		for found := false; !found; {
+			select {
+			case <-timeout:
+				require.Fail(t, "timed out waiting for the rollback update")
+			case u := <-updates:
+				for _, event := range u.Configurations {
+					if event.Type == EventTypeUpdate && event.Item.Revision() == 3 {
+						found = true
+					}
+				}
+			}
+		}
+	})
+
+	t.Run("rollback to a missing revision", func(t *testing.T) {
+		_, err := RollbackResource(ctx, store, model.KindConfiguration, "revisions", 10)
+		require.ErrorIs(t, err, ErrResourceMissing)
+	})
+
+	t.Run("revisions are kept after delete", func(t *testing.T) {
+		_, err := store.DeleteConfiguration(ctx, "revisions")
+		require.NoError(t, err)
+
+		revisions, err := store.ResourceRevisions(ctx, model.KindConfiguration, "revisions")
+		require.NoError(t, err)
+		require.Len(t, revisions, 3)
+
+		status := apply("raw: 4")
+		require.Equal(t, model.StatusCreated, status.Status)
+		require.Equal(t, 4, status.Resource.Revision())
+	})
+}
+
+func runResourceVersionTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+
+	apply := func(raw, resourceVersion string) model.ResourceStatus {
+		configuration := model.NewRawConfiguration("versioned", raw)
+		configuration.SetResourceVersion(resourceVersion)
+		status, err := store.ApplyResources(ctx, []model.Resource{configuration})
+		require.NoError(t, err)
+		require.Len(t, status, 1)
+		return status[0]
+	}
+
+	// these tests are dependent on each other and are expected to run in order.
+
+	t.Run("new resource with a resourceVersion conflicts", func(t *testing.T) {
+		status := apply("raw: 1", "1")
+		require.Equal(t, model.StatusConflict, status.Status)
+		require.Contains(t, status.Reason, ErrResourceConflict.Error())
+
+		config, err := store.Configuration(ctx, "versioned")
+		require.NoError(t, err)
+		require.Nil(t, config)
+	})
+
+	t.Run("new resource is assigned a resourceVersion", func(t *testing.T) {
+		status := apply("raw: 1", "")
+		require.Equal(t, model.StatusCreated, status.Status)
+		require.Equal(t, "1", model.RevisionResourceVersion(status.Resource.Revision()))
+	})
+
+	t.Run("matching resourceVersion is applied", func(t *testing.T) {
+		configuration := model.NewRawConfiguration("versioned", "raw: 2")
+		configuration.SetResourceVersion("1")
+		statuses, err := store.ApplyResources(ctx, []model.Resource{configuration})
+		require.NoError(t, err)
+		require.Len(t, statuses, 1)
+		require.Equal(t, model.StatusConfigured, statuses[0].Status)
+		require.Equal(t, "2", model.RevisionResourceVersion(statuses[0].Resource.Revision()))
+		require.Empty(t, statuses[0].Resource.ResourceVersion(), "the resourceVersion is not stored")
+
+		// the resourceVersion of the applied resource is unchanged
+		require.Equal(t, "1", configuration.ResourceVersion())
+		require.Equal(t, 2, configuration.Revision())
+	})
+
+	t.Run("stale resourceVersion conflicts", func(t *testing.T) {
+		status := apply("raw: 3", "1")
+		require.Equal(t, model.StatusConflict, status.Status)
+		require.Contains(t, status.Reason, ErrResourceConflict.Error())
+
+		config, err := store.Configuration(ctx, "versioned")
+		require.NoError(t, err)
+		require.Equal(t, "raw: 2", config.Spec.Raw)
+		require.Equal(t, 2, config.Revision())
+	})
+
+	t.Run("missing resourceVersion is applied", func(t *testing.T) {
+		status := apply("raw: 4", "")
+		require.Equal(t, model.StatusConfigured, status.Status)
+		require.Equal(t, 3, status.Resource.Revision())
+	})
+
+	t.Run("rollback does not conflict", func(t *testing.T) {
+		status, err := RollbackResource(ctx, store, model.KindConfiguration, "versioned", 1)
+		require.NoError(t, err)
+		require.Equal(t, model.StatusConfigured, status.Status)
+		require.Equal(t, 4, status.Resource.Revision())
+	})
+
+	remove := func(resourceVersion string, options ...DeleteOption) []model.ResourceStatus {
+		configuration := model.NewRawConfiguration("versioned", "")
+		configuration.SetResourceVersion(resourceVersion)
+		statuses, err := store.DeleteResources(ctx, []model.Resource{configuration}, options...)
+		require.NoError(t, err)
+		return statuses
+	}
+
+	t.Run("stale resourceVersion is not deleted", func(t *testing.T) {
+		statuses := remove("3")
+		require.Len(t, statuses, 1)
+		require.Equal(t, model.StatusConflict, statuses[0].Status)
+		require.Contains(t, statuses[0].Reason, ErrResourceConflict.Error())
+
+		statuses = remove("3", WithDryRun(true))
+		require.Len(t, statuses, 1)
+		require.Equal(t, model.StatusConflict, statuses[0].Status)
+
+		config, err := store.Configuration(ctx, "versioned")
+		require.NoError(t, err)
+		require.NotNil(t, config)
+	})
+
+	t.Run("matching resourceVersion is deleted", func(t *testing.T) {
+		statuses := remove("4")
+		require.Len(t, statuses, 1)
+		require.Equal(t, model.StatusDeleted, statuses[0].Status)
+
+		config, err := store.Configuration(ctx, "versioned")
+		require.NoError(t, err)
+		require.Nil(t, config)
+	})
+
+	t.Run("missing resource with a resourceVersion is not deleted", func(t *testing.T) {
+		statuses := remove("4")
+		require.Len(t, statuses, 1)
+		require.Equal(t, model.StatusConflict, statuses[0].Status)
+		require.Contains(t, statuses[0].Reason, ErrResourceConflict.Error())
+	})
+}
+
+func runResourceQueryTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+
+	newSource := func(name, sourceType string, l map[string]string) *model.Source {
+		source := model.NewSource(name, sourceType, []model.Parameter{})
+		source.Metadata.Labels = labels(l)
+		return source
+	}
+
+	_, err := store.ApplyResources(ctx, []model.Resource{
+		macosSourceType,
+		nginxSourceType,
+		cabinDestinationType,
+		newSource("macos-prod", "macos", map[string]string{"env": "prod"}),
+		newSource("macos-dev", "macos", map[string]string{"env": "dev"}),
+		newSource("nginx-prod", "nginx", map[string]string{"env": "prod"}),
+		cabinDestination1,
+		cabinDestination2,
+	})
+	require.NoError(t, err)
+
+	sourceNames := func(t *testing.T, options ...QueryOption) []string {
+		sources, err := store.Sources(ctx, options...)
+		require.NoError(t, err)
+		names := []string{}
+		for _, source := range sources {
+			names = append(names, source.Name())
+		}
+		return names
+	}
+
+	t.Run("all sources", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"macos-prod", "macos-dev", "nginx-prod"}, sourceNames(t))
+	})
+
+	t.Run("sources matching query", func(t *testing.T) {
+		require.ElementsMatch(t, []string{"macos-prod", "macos-dev"}, sourceNames(t, WithQuery(search.ParseQuery("type:macos"))))
+		require.ElementsMatch(t, []string{"macos-prod", "nginx-prod"}, sourceNames(t, WithQuery(search.ParseQuery("env:prod"))))
+	})
+
+	t.Run("sources matching selector", func(t *testing.T) {
+		selector, err := model.SelectorFromString("env=dev")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"macos-dev"}, sourceNames(t, WithSelector(selector)))
+	})
+
+	t.Run("sources matching query and selector", func(t *testing.T) {
+		selector, err := model.SelectorFromString("env=prod")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"macos-prod"}, sourceNames(t, WithSelector(selector), WithQuery(search.ParseQuery("type:macos"))))
+	})
+
+	t.Run("sources sorted and paged", func(t *testing.T) {
+		require.Equal(t, []string{"macos-dev", "macos-prod", "nginx-prod"}, sourceNames(t, WithSort("name")))
+		require.Equal(t, []string{"macos-prod"}, sourceNames(t, WithSort("name"), WithOffset(1), WithLimit(1)))
+	})
+
+	t.Run("deleted sources are removed from the index", func(t *testing.T) {
+		_, err := store.DeleteSource(ctx, "macos-dev")
+		require.NoError(t, err)
+		require.ElementsMatch(t, []string{"macos-prod"}, sourceNames(t, WithQuery(search.ParseQuery("type:macos"))))
+	})
+
+	t.Run("source types matching query", func(t *testing.T) {
+		sourceTypes, err := store.SourceTypes(ctx, WithQuery(search.ParseQuery("name:nginx")))
+		require.NoError(t, err)
+		require.Len(t, sourceTypes, 1)
+		require.Equal(t, "nginx", sourceTypes[0].Name())
+	})
+
+	t.Run("destinations matching query", func(t *testing.T) {
+		destinations, err := store.Destinations(ctx, WithQuery(search.ParseQuery("type:cabin")), WithLimit(1))
+		require.NoError(t, err)
+		require.Len(t, destinations, 1)
+	})
+
+	t.Run("resource indexes", func(t *testing.T) {
+		require.NotNil(t, store.ResourceIndex(ctx, model.KindSource))
+		require.Equal(t, store.ConfigurationIndex(ctx), store.ResourceIndex(ctx, model.KindConfiguration))
+		require.Nil(t, store.ResourceIndex(ctx, model.KindAgentVersion))
+	})
+}
+
+func runAuditTests(t *testing.T, s Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Clear()
+
+	log := &bytes.Buffer{}
+	store := NewAuditStore(s, log, zap.NewNop())
+	userCtx := ContextWithUser(ctx, "alice")
+
+	// resources
+	_, err := store.ApplyResources(userCtx, []model.Resource{model.NewRawConfiguration("audited", "receivers:")})
+	require.NoError(t, err)
+	_, err = store.ApplyResources(userCtx, []model.Resource{model.NewRawConfiguration("audited", "receivers: {}")})
+	require.NoError(t, err)
+	// unchanged resources are not recorded
+	_, err = store.ApplyResources(userCtx, []model.Resource{model.NewRawConfiguration("audited", "receivers: {}")})
+	require.NoError(t, err)
+	_, err = store.DeleteResources(userCtx, []model.Resource{model.NewRawConfiguration("audited", "")})
+	require.NoError(t, err)
+
+	// agents are only recorded when changed by a user
+	require.NoError(t, addAgent(store, &model.Agent{ID: "1", Name: "agent-1", Labels: labels(map[string]string{"env": "dev"})}))
+	_, err = store.UpsertAgent(userCtx, "1", func(current *model.Agent) {
+		current.Labels = model.LabelsFromMerge(current.Labels, labels(map[string]string{"env": "prod"}))
+	})
+	require.NoError(t, err)
+	_, err = store.UpsertAgents(userCtx, []string{"1"}, func(current *model.Agent) {
+		current.Name = "renamed"
+	})
+	require.NoError(t, err)
+	_, err = store.DeleteAgents(ctx, []string{"1"})
+	require.NoError(t, err)
+
+	t.Run("records every change newest first", func(t *testing.T) {
+		entries, err := store.AuditEntries(ctx, model.AuditFilter{})
+		require.NoError(t, err)
+		require.Len(t, entries, 6)
+
+		actions := []model.AuditAction{}
+		for _, entry := range entries {
+			require.NotEmpty(t, entry.ID)
+			require.False(t, entry.Timestamp.IsZero())
+			actions = append(actions, entry.Action)
+		}
+		require.Equal(t, []model.AuditAction{
+			model.AuditActionDelete,
+			model.AuditActionUpdate,
+			model.AuditActionLabel,
+			model.AuditActionDelete,
+			model.AuditActionUpdate,
+			model.AuditActionCreate,
+		}, actions)
+
+		require.Equal(t, "", entries[0].User, "the agent was deleted without a user")
+		require.Equal(t, "alice", entries[1].User)
+		require.Contains(t, entries[1].Changes, "name: agent-1 -> renamed")
+		require.Contains(t, entries[2].Changes, "labels.env: dev -> prod")
+		require.Contains(t, entries[4].Changes, "spec.raw: receivers: -> receivers: {}")
+		require.Nil(t, entries[5].Before)
+		require.NotNil(t, entries[5].After)
+		require.NotNil(t, entries[3].Before)
+		require.Nil(t, entries[3].After)
+	})
+
+	t.Run("filters entries", func(t *testing.T) {
+		entries, err := store.AuditEntries(ctx, model.AuditFilter{Kind: model.KindConfiguration, Name: "audited"})
+		require.NoError(t, err)
+		require.Len(t, entries, 3)
+
+		entries, err = store.AuditEntries(ctx, model.AuditFilter{Kind: "agent", User: "alice"})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+
+		entries, err = store.AuditEntries(ctx, model.AuditFilter{User: "bob"})
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("pages entries", func(t *testing.T) {
+		entries, err := store.AuditEntries(ctx, model.AuditFilter{Offset: 1, Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, model.AuditActionUpdate, entries[0].Action)
+		require.Equal(t, model.AuditActionLabel, entries[1].Action)
+
+		entries, err = store.AuditEntries(ctx, model.AuditFilter{Offset: 10})
+		require.NoError(t, err)
+		require.Empty(t, entries)
+	})
+
+	t.Run("writes entries to the log", func(t *testing.T) {
+		lines := strings.Split(strings.TrimSpace(log.String()), "\n")
+		require.Len(t, lines, 6)
+
+		entry := &model.AuditEntry{}
+		require.NoError(t, json.Unmarshal([]byte(lines[0]), entry))
+		require.Equal(t, model.AuditActionCreate, entry.Action)
+		require.Equal(t, "audited", entry.Name)
+	})
+
+	t.Run("records the changes made by a cascading delete", func(t *testing.T) {
+		configuration := model.NewConfigurationWithSpec("cascaded", model.ConfigurationSpec{
+			Sources: []model.ResourceConfiguration{{Name: macosSource.Name()}},
+		})
+		_, err := store.ApplyResources(userCtx, []model.Resource{macosSourceType, macosSource, configuration})
+		require.NoError(t, err)
+
+		// a dry run changes nothing
+		_, err = store.DeleteResources(userCtx, []model.Resource{macosSource}, WithCascade(model.CascadeReferences), WithDryRun(true))
+		require.NoError(t, err)
+		entries, err := store.AuditEntries(ctx, model.AuditFilter{Kind: model.KindConfiguration, Name: "cascaded"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+
+		_, err = store.DeleteResources(userCtx, []model.Resource{macosSource}, WithCascade(model.CascadeReferences))
+		require.NoError(t, err)
+		entries, err = store.AuditEntries(ctx, model.AuditFilter{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, entries, 2)
+		require.Equal(t, model.AuditActionDelete, entries[0].Action)
+		require.Equal(t, macosSource.Name(), entries[0].Name)
+		require.Equal(t, model.AuditActionUpdate, entries[1].Action)
+		require.Equal(t, "cascaded", entries[1].Name)
+		require.Equal(t, "alice", entries[1].User)
+	})
+
+	t.Run("does not record the secret keys issued to agents", func(t *testing.T) {
+		var secretKey string
+		_, err := store.UpsertAgent(userCtx, "credentials", func(current *model.Agent) {
+			require.NoError(t, current.IssueCredentials(nil))
+			secretKey = current.Credentials.PendingSecretKey
+		})
+		require.NoError(t, err)
+
+		entries, err := store.AuditEntries(ctx, model.AuditFilter{Kind: model.KindAgent, Name: "credentials"})
+		require.NoError(t, err)
+		require.Len(t, entries, 1)
+		data, err := json.Marshal(entries[0])
+		require.NoError(t, err)
+		require.NotContains(t, string(data), secretKey)
+		require.NotContains(t, string(data), "secretKeyHash")
+		require.Contains(t, log.String(), `"credentials"`)
+		require.NotContains(t, log.String(), secretKey)
+	})
+
+	t.Run("cleanup removes old and excess entries", func(t *testing.T) {
+		entries, err := store.AuditEntries(ctx, model.AuditFilter{})
+		require.NoError(t, err)
+		require.Greater(t, len(entries), 2)
+
+		// the newest entries are kept
+		require.NoError(t, store.CleanupAuditEntries(ctx, time.Time{}, 2))
+		kept, err := store.AuditEntries(ctx, model.AuditFilter{})
+		require.NoError(t, err)
+		require.Len(t, kept, 2)
+		require.Equal(t, entries[0].ID, kept[0].ID)
+		require.Equal(t, entries[1].ID, kept[1].ID)
+
+		// entries recorded before the time are removed
+		future := &model.AuditEntry{Action: model.AuditActionUpdate, Kind: model.KindConfiguration, Name: "future", Timestamp: time.Now().Add(time.Hour)}
+		require.NoError(t, store.AddAuditEntries(ctx, []*model.AuditEntry{future}))
+		require.NoError(t, store.CleanupAuditEntries(ctx, time.Now(), 0))
+		kept, err = store.AuditEntries(ctx, model.AuditFilter{})
+		require.NoError(t, err)
+		require.Len(t, kept, 1)
+		require.Equal(t, "future", kept[0].Name)
+
+		// new entries do not reuse the IDs of removed entries
+		require.NoError(t, store.AddAuditEntries(ctx, []*model.AuditEntry{{Action: model.AuditActionUpdate, Kind: model.KindConfiguration, Name: "next"}}))
+		kept, err = store.AuditEntries(ctx, model.AuditFilter{})
+		require.NoError(t, err)
+		require.Len(t, kept, 2)
+		require.NotEqual(t, kept[0].ID, kept[1].ID)
+	})
+}
+
+func runAgentHistoryTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+
+	setStatus := func(id string, status model.AgentStatus, errorMessage string) {
+		_, err := store.UpsertAgents(ctx, []string{id}, func(current *model.Agent) {
+			current.Status = status
+			current.ErrorMessage = errorMessage
+		})
+		require.NoError(t, err)
+	}
+
+	require.NoError(t, addAgent(store, &model.Agent{ID: "1", Status: model.Connected}))
+	require.NoError(t, addAgent(store, &model.Agent{ID: "2", Status: model.Connected}))
+
+	// changes that do not modify the status or error are not recorded
+	_, err := store.UpsertAgent(ctx, "1", func(current *model.Agent) {
+		current.Name = "renamed"
+	})
+	require.NoError(t, err)
+
+	setStatus("1", model.Error, "failed to start")
+	setStatus("1", model.Error, "failed to start again")
+	setStatus("1", model.Disconnected, "")
+
+	t.Run("records status changes oldest first", func(t *testing.T) {
+		changes, err := store.AgentHistory(ctx, "1")
+		require.NoError(t, err)
+
+		statuses := []model.AgentStatus{}
+		errorMessages := []string{}
+		for _, change := range changes {
+			require.Equal(t, "1", change.AgentID)
+			require.False(t, change.Timestamp.IsZero())
+			statuses = append(statuses, change.Status)
+			errorMessages = append(errorMessages, change.ErrorMessage)
+		}
+		require.Equal(t, []model.AgentStatus{model.Connected, model.Error, model.Error, model.Disconnected}, statuses)
+		require.Equal(t, []string{"", "failed to start", "failed to start again", ""}, errorMessages)
+
+		changes, err = store.AgentHistory(ctx, "2")
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+	})
+
+	t.Run("returns an empty history for an unknown agent", func(t *testing.T) {
+		changes, err := store.AgentHistory(ctx, "unknown")
+		require.NoError(t, err)
+		require.Empty(t, changes)
+	})
+
+	t.Run("cleanup removes old and excess changes", func(t *testing.T) {
+		// the newest changes of each agent are kept
+		require.NoError(t, store.CleanupAgentHistory(ctx, time.Time{}, 2))
+		changes, err := store.AgentHistory(ctx, "1")
+		require.NoError(t, err)
+		require.Len(t, changes, 2)
+		require.Equal(t, "failed to start again", changes[0].ErrorMessage)
+		require.Equal(t, model.Disconnected, changes[1].Status)
+
+		// changes recorded before the time are removed except the most recent change of each agent
+		require.NoError(t, store.CleanupAgentHistory(ctx, time.Now().Add(time.Hour), 0))
+		changes, err = store.AgentHistory(ctx, "1")
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+		require.Equal(t, model.Disconnected, changes[0].Status)
+
+		changes, err = store.AgentHistory(ctx, "2")
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+	})
+
+	t.Run("removes the history of deleted agents", func(t *testing.T) {
+		_, err := store.DeleteAgents(ctx, []string{"1"})
+		require.NoError(t, err)
+
+		changes, err := store.AgentHistory(ctx, "1")
+		require.NoError(t, err)
+		require.Empty(t, changes)
+
+		changes, err = store.AgentHistory(ctx, "2")
+		require.NoError(t, err)
+		require.Len(t, changes, 1)
+	})
+}
+
+func runRequestAgentRestartsTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+
+	require.NoError(t, addAgent(store, &model.Agent{ID: "1", Status: model.Connected, Labels: labels(map[string]string{"env": "dev"})}))
+	require.NoError(t, addAgent(store, &model.Agent{ID: "2", Status: model.Connected, Labels: labels(map[string]string{"env": "prod"})}))
+	require.NoError(t, addAgent(store, &model.Agent{ID: "3", Status: model.Disconnected, Labels: labels(map[string]string{"env": "prod"})}))
+	require.NoError(t, addAgent(store, &model.Agent{ID: "4", Status: model.Error, Labels: labels(map[string]string{"env": "test"})}))
+
+	agentIDs := func(agents []*model.Agent) []string {
+		ids := []string{}
+		for _, agent := range agents {
+			ids = append(ids, agent.ID)
+		}
+		sort.Strings(ids)
+		return ids
+	}
+
+	selector, err := model.SelectorFromString("env=prod")
+	require.NoError(t, err)
+
+	restarted, err := RequestAgentRestarts(ctx, store, []string{"1", "missing"}, WithSelector(selector))
+	require.NoError(t, err)
+	require.Equal(t, []string{"1", "2"}, agentIDs(restarted), "disconnected and missing agents are skipped")
+
+	for _, id := range []string{"1", "2"} {
+		agent, err := store.Agent(ctx, id)
+		require.NoError(t, err)
+		require.Equal(t, model.Restarting, agent.Status)
+		require.NotNil(t, agent.Restart)
+		require.Equal(t, model.RestartPending, agent.Restart.Status)
+	}
+	for _, id := range []string{"3", "4"} {
+		agent, err := store.Agent(ctx, id)
+		require.NoError(t, err)
+		require.Nil(t, agent.Restart)
+	}
+
+	restarted, err = RequestAgentRestarts(ctx, store, nil)
+	require.NoError(t, err)
+	require.Empty(t, restarted, "no agents are restarted without ids or options")
+}
+
+func runEnrollmentTokenTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+
+	token := model.NewEnrollmentToken("production", model.EnrollmentTokenSpec{MaxUses: 2})
+	statuses, err := store.ApplyResources(ctx, []model.Resource{token})
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, model.StatusCreated, statuses[0].Status)
+
+	stored, err := store.EnrollmentToken(ctx, "production")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.NotEmpty(t, stored.Token())
+	require.Equal(t, 2, stored.Spec.MaxUses)
+
+	tokens, err := store.EnrollmentTokens(ctx)
+	require.NoError(t, err)
+	require.Len(t, tokens, 1)
+
+	found, err := FindEnrollmentToken(ctx, store, stored.Token())
+	require.NoError(t, err)
+	require.NotNil(t, found)
+	require.Equal(t, "production", found.Name())
+
+	found, err = FindEnrollmentToken(ctx, store, "unknown")
+	require.NoError(t, err)
+	require.Nil(t, found)
+
+	for i, expect := range []bool{true, true, false} {
+		used, err := store.UseEnrollmentToken(ctx, stored.Token(), stored.Spec.MaxUses)
+		require.NoError(t, err)
+		require.Equal(t, expect, used, "use %d", i+1)
+	}
+	used, err := store.UseEnrollmentToken(ctx, "unlimited", 0)
+	require.NoError(t, err)
+	require.True(t, used)
+
+	for _, id := range []string{"1", "2"} {
+		_, err := store.UpsertAgent(ctx, id, func(agent *model.Agent) {
+			require.NoError(t, agent.IssueCredentials(stored))
+		})
+		require.NoError(t, err)
+	}
+	_, err = store.UpsertAgent(ctx, "3", func(agent *model.Agent) {
+		require.NoError(t, agent.IssueCredentials(nil))
+	})
+	require.NoError(t, err)
+
+	agent, err := store.Agent(ctx, "1")
+	require.NoError(t, err)
+	require.True(t, agent.Credentials.Pending())
+	require.True(t, agent.Credentials.Verify(agent.Credentials.PendingSecretKey))
+
+	// revocations are kept after the agent is deleted
+	require.NoError(t, store.RevokeAgent(ctx, "1"))
+	_, err = store.DeleteAgents(ctx, []string{"1"})
+	require.NoError(t, err)
+	revoked, err := store.AgentRevoked(ctx, "1")
+	require.NoError(t, err)
+	require.True(t, revoked)
+	revoked, err = store.AgentRevoked(ctx, "2")
+	require.NoError(t, err)
+	require.False(t, revoked)
+
+	deleted, err := store.DeleteEnrollmentToken(ctx, "production")
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+
+	stored, err = store.EnrollmentToken(ctx, "production")
+	require.NoError(t, err)
+	require.Nil(t, stored)
+}
+
+func runSecretTests(t *testing.T, s Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s.Clear()
+	store := NewSecretStore(s, "secrets-key")
+
+	secret := model.NewSecret("splunk-token", "s3cr3t")
+	statuses, err := store.ApplyResources(ctx, []model.Resource{secret})
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, model.StatusCreated, statuses[0].Status)
+
+	stored, err := store.Secret(ctx, "splunk-token")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.Empty(t, stored.Spec.Value, "the plain text value should not be stored")
+	require.NotEmpty(t, stored.Spec.EncryptedValue)
+	value, err := stored.Decrypt("secrets-key")
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3t", value)
+
+	// applying the same value leaves the secret unchanged
+	statuses, err = store.ApplyResources(ctx, []model.Resource{model.NewSecret("splunk-token", "s3cr3t")})
+	require.NoError(t, err)
+	require.Equal(t, model.StatusUnchanged, statuses[0].Status)
+
+	values, err := SecretValues(ctx, store, "secrets-key")
+	require.NoError(t, err)
+	require.Equal(t, []string{"s3cr3t"}, values)
+
+	// secrets cannot be applied without a key
+	statuses, err = NewSecretStore(s, "").ApplyResources(ctx, []model.Resource{model.NewSecret("other", "value")})
+	require.NoError(t, err)
+	require.Len(t, statuses, 1)
+	require.Equal(t, model.StatusInvalid, statuses[0].Status)
+	require.Equal(t, model.ErrSecretsKeyRequired.Error(), statuses[0].Reason)
+
+	// secrets cannot be deleted while they are referenced
+	destinationType := model.NewDestinationType("splunk", []model.ParameterDefinition{{Name: "token", Type: "string"}})
+	destination := model.NewDestination("splunk", "splunk", []model.Parameter{{Name: "token", Value: model.SecretReference("splunk-token")}})
+	_, err = store.ApplyResources(ctx, []model.Resource{destinationType, destination})
+	require.NoError(t, err)
+
+	_, err = store.DeleteSecret(ctx, "splunk-token")
+	var dependencyError *DependencyError
+	require.ErrorAs(t, err, &dependencyError)
+	require.Equal(t, "Dependent resources:\nDestination splunk\n", err.Error())
+
+	_, err = store.DeleteDestination(ctx, "splunk")
+	require.NoError(t, err)
+	deleted, err := store.DeleteSecret(ctx, "splunk-token")
+	require.NoError(t, err)
+	require.NotNil(t, deleted)
+
+	secrets, err := store.Secrets(ctx)
+	require.NoError(t, err)
+	require.Empty(t, secrets)
+}
+
+func runRolloutTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+
+	rollout, err := store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.Nil(t, rollout)
+
+	rollouts, err := store.Rollouts(ctx)
+	require.NoError(t, err)
+	require.Empty(t, rollouts)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	configuration := model.NewConfiguration("test")
+	configuration.Spec.Rollout = &model.RolloutOptions{BatchSize: 1}
+	rollout = model.NewRollout(configuration, 1, now)
+	rollout.Revision = 2
+	rollout.StartWave([]string{"1"}, now)
+	require.NoError(t, store.UpsertRollout(ctx, rollout))
+	require.NoError(t, store.UpsertRollout(ctx, model.NewRollout(model.NewConfiguration("another"), 3, now)))
+
+	stored, err := store.Rollout(ctx, "test")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.Equal(t, 2, stored.Revision)
+	require.Equal(t, 1, stored.PreviousRevision)
+	require.Equal(t, 1, stored.Options.BatchSize)
+	require.Equal(t, 1, stored.Wave)
+	require.True(t, stored.Includes("1"))
+	require.False(t, stored.Includes("2"))
+
+	// changes are stored by UpsertRollout
+	stored.Agents["1"].Status = model.RolloutAgentApplied
+	stored.Status = model.RolloutCompleted
+	require.NoError(t, store.UpsertRollout(ctx, stored))
+
+	rollouts, err = store.Rollouts(ctx)
+	require.NoError(t, err)
+	require.Len(t, rollouts, 2)
+	require.Equal(t, "another", rollouts[0].Name)
+	require.Equal(t, "test", rollouts[1].Name)
+	require.Equal(t, model.RolloutCompleted, rollouts[1].Status)
+	require.Equal(t, model.RolloutAgentApplied, rollouts[1].Agents["1"].Status)
+}
+
+func runUpgradeCampaignTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+
+	campaign, err := store.UpgradeCampaign(ctx, "prod")
+	require.NoError(t, err)
+	require.Nil(t, campaign)
+
+	campaigns, err := store.UpgradeCampaigns(ctx)
+	require.NoError(t, err)
+	require.Empty(t, campaigns)
+
+	now := time.Now().UTC().Truncate(time.Second)
+	campaign = &model.UpgradeCampaign{Name: "prod", Version: "v1.2.0", BatchSize: 1, MaxFailurePercent: 10}
+	campaign.Start([]string{"1", "2"}, now)
+	campaign.StartBatch(now)
+	require.NoError(t, store.UpsertUpgradeCampaign(ctx, campaign))
+
+	another := &model.UpgradeCampaign{Name: "dev", Version: "v1.2.0"}
+	another.Start(nil, now)
+	require.NoError(t, store.UpsertUpgradeCampaign(ctx, another))
+
+	stored, err := store.UpgradeCampaign(ctx, "prod")
+	require.NoError(t, err)
+	require.NotNil(t, stored)
+	require.Equal(t, "v1.2.0", stored.Version)
+	require.Equal(t, 1, stored.BatchSize)
+	require.Equal(t, 10, stored.MaxFailurePercent)
+	require.Equal(t, 1, stored.Batch)
+	require.Equal(t, []string{"1"}, stored.AgentIDs(1, model.UpgradeCampaignAgentPending))
+	require.Equal(t, []string{"2"}, stored.AgentIDs(0, model.UpgradeCampaignAgentPending))
+
+	// changes are stored by UpsertUpgradeCampaign
+	stored.Agents["1"].Status = model.UpgradeCampaignAgentFailed
+	stored.Agents["1"].Error = "download failed"
+	stored.Status = model.UpgradeCampaignPaused
+	require.NoError(t, store.UpsertUpgradeCampaign(ctx, stored))
+
+	campaigns, err = store.UpgradeCampaigns(ctx)
+	require.NoError(t, err)
+	require.Len(t, campaigns, 2)
+	require.Equal(t, "dev", campaigns[0].Name)
+	require.Equal(t, "prod", campaigns[1].Name)
+	require.Equal(t, model.UpgradeCampaignPaused, campaigns[1].Status)
+	require.Equal(t, model.UpgradeCampaignAgentFailed, campaigns[1].Agents["1"].Status)
+	require.Equal(t, "download failed", campaigns[1].Agents["1"].Error)
+}
+
+func runLeaseTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+
+	acquired, err := store.AcquireLease(ctx, "cleanup", "a", time.Hour)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// another holder cannot acquire the lease until it expires
+	acquired, err = store.AcquireLease(ctx, "cleanup", "b", time.Hour)
+	require.NoError(t, err)
+	require.False(t, acquired)
+
+	// the holder can renew the lease
+	acquired, err = store.AcquireLease(ctx, "cleanup", "a", time.Hour)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// leases with different names are independent
+	acquired, err = store.AcquireLease(ctx, "other", "b", 10*time.Millisecond)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	// an expired lease can be acquired by another holder
+	time.Sleep(50 * time.Millisecond)
+	acquired, err = store.AcquireLease(ctx, "other", "a", time.Hour)
+	require.NoError(t, err)
+	require.True(t, acquired)
+
+	acquired, err = store.AcquireLease(ctx, "other", "b", time.Hour)
+	require.NoError(t, err)
+	require.False(t, acquired)
+}
+
+func runCleanupDisconnectedAgentsTests(t *testing.T, store Store) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	store.Clear()
+
+	now := time.Now()
+	longAgo := now.Add(-time.Hour)
+	recently := now.Add(-time.Minute)
+
+	require.NoError(t, addAgent(store, &model.Agent{ID: "connected", Status: model.Connected}))
+	require.NoError(t, addAgent(store, &model.Agent{ID: "recent", Status: model.Disconnected, DisconnectedAt: &recently}))
+	require.NoError(t, addAgent(store, &model.Agent{ID: "old", Status: model.Disconnected, DisconnectedAt: &longAgo}))
+	require.NoError(t, addAgent(store, &model.Agent{ID: "stale", Status: model.Stale, DisconnectedAt: &longAgo}))
+
+	require.NoError(t, store.CleanupDisconnectedAgents(ctx, now.Add(-15*time.Minute)))
+
+	agents, err := store.Agents(ctx)
+	require.NoError(t, err)
+	ids := make([]string, 0, len(agents))
+	for _, agent := range agents {
+		ids = append(ids, agent.ID)
+	}
+	require.ElementsMatch(t, []string{"connected", "recent"}, ids)
+}
@@ -33,6 +33,8 @@ type Updates struct {
 	ProcessorTypes   Events[*model.ProcessorType]
 	Destinations     Events[*model.Destination]
 	DestinationTypes Events[*model.DestinationType]
+	ExtensionTypes   Events[*model.ExtensionType]
+	Secrets          Events[*model.Secret]
 	Configurations   Events[*model.Configuration]
 }
 
@@ -48,6 +50,8 @@ func NewUpdates() *Updates {
 		ProcessorTypes:   NewEvents[*model.ProcessorType](),
 		Destinations:     NewEvents[*model.Destination](),
 		DestinationTypes: NewEvents[*model.DestinationType](),
+		ExtensionTypes:   NewEvents[*model.ExtensionType](),
+		Secrets:          NewEvents[*model.Secret](),
 		Configurations:   NewEvents[*model.Configuration](),
 	}
 }
@@ -76,6 +80,10 @@ func (updates *Updates) IncludeResource(r model.Resource, eventType EventType) {
 		updates.Destinations.Include(r, eventType)
 	case *model.DestinationType:
 		updates.DestinationTypes.Include(r, eventType)
+	case *model.ExtensionType:
+		updates.ExtensionTypes.Include(r, eventType)
+	case *model.Secret:
+		updates.Secrets.Include(r, eventType)
 	case *model.Configuration:
 		updates.Configurations.Include(r, eventType)
 	}
@@ -96,6 +104,8 @@ func (updates *Updates) Size() int {
 		len(updates.ProcessorTypes) +
 		len(updates.Destinations) +
 		len(updates.DestinationTypes) +
+		len(updates.ExtensionTypes) +
+		len(updates.Secrets) +
 		len(updates.Configurations)
 }
 
@@ -113,10 +123,17 @@ func (updates *Updates) addTransitiveUpdates(ctx context.Context, s Store) error
 	// for sources and sourceTypes, add configurations
 	// for processors and processorTypes, add configurations
 	// for destinations and destinationTypes, add configurations
+	// for extensionTypes, add configurations
+	// for secrets, add sources, processors, destinations, and configurations that refer to them
 
 	var errs error
 
-	err := updates.addProcessorUpdates(ctx, s)
+	err := updates.addSecretUpdates(ctx, s)
+	if err != nil {
+		errs = multierror.Append(errs, err)
+	}
+
+	err = updates.addProcessorUpdates(ctx, s)
 	if err != nil {
 		errs = multierror.Append(errs, err)
 	}
@@ -139,6 +156,56 @@ func (updates *Updates) addTransitiveUpdates(ctx context.Context, s Store) error
 	return errs
 }
 
+func (updates *Updates) addSecretUpdates(ctx context.Context, s Store) error {
+	if updates.Secrets.Empty() {
+		return nil
+	}
+
+	// updates to a Secret will trigger updates of all of the Sources, Processors, and Destinations that refer to it.
+	// Configurations that refer to it are updated with the other configuration updates.
+	sources, err := s.Sources(ctx)
+	if err != nil {
+		return err
+	}
+	for _, source := range sources {
+		if updates.usesUpdatedSecret(source.Spec.SecretNames()) {
+			updates.Sources.Include(source, EventTypeUpdate)
+		}
+	}
+
+	processors, err := s.Processors(ctx)
+	if err != nil {
+		return err
+	}
+	for _, processor := range processors {
+		if updates.usesUpdatedSecret(processor.Spec.SecretNames()) {
+			updates.Processors.Include(processor, EventTypeUpdate)
+		}
+	}
+
+	destinations, err := s.Destinations(ctx)
+	if err != nil {
+		return err
+	}
+	for _, destination := range destinations {
+		if updates.usesUpdatedSecret(destination.Spec.SecretNames()) {
+			updates.Destinations.Include(destination, EventTypeUpdate)
+		}
+	}
+
+	return nil
+}
+
+// usesUpdatedSecret returns true if any of the secret names are included in the updates
+func (updates *Updates) usesUpdatedSecret(secretNames []string) bool {
+	for _, name := range secretNames {
+		if _, ok := updates.Secrets[name]; ok {
+			return true
+		}
+	}
+	return false
+}
+
 func (updates *Updates) addSourceUpdates(ctx context.Context, s Store) error {
 	if updates.SourceTypes.Empty() && updates.Processors.Empty() && updates.ProcessorTypes.Empty() {
 		return nil
@@ -278,6 +345,15 @@ func (updates *Updates) addConfigurationUpdatesFromComponents(configuration *mod
 			return
 		}
 	}
+	for _, extension := range configuration.Spec.Extensions {
+		if _, ok := updates.ExtensionTypes[extension.Type]; ok {
+			updates.Configurations.Include(configuration, EventTypeUpdate)
+			return
+		}
+	}
+	if updates.usesUpdatedSecret(configuration.SecretNames()) {
+		updates.Configurations.Include(configuration, EventTypeUpdate)
+	}
 }
 
 // ----------------------------------------------------------------------
@@ -293,6 +369,8 @@ func mergeUpdates(into, single *Updates) bool {
 		into.ProcessorTypes.CanSafelyMerge(single.ProcessorTypes) &&
 		into.Destinations.CanSafelyMerge(single.Destinations) &&
 		into.DestinationTypes.CanSafelyMerge(single.DestinationTypes) &&
+		into.ExtensionTypes.CanSafelyMerge(single.ExtensionTypes) &&
+		into.Secrets.CanSafelyMerge(single.Secrets) &&
 		into.Configurations.CanSafelyMerge(single.Configurations)
 
 	if !safe {
@@ -308,6 +386,8 @@ func mergeUpdates(into, single *Updates) bool {
 	into.ProcessorTypes.Merge(single.ProcessorTypes)
 	into.Destinations.Merge(single.Destinations)
 	into.DestinationTypes.Merge(single.DestinationTypes)
+	into.ExtensionTypes.Merge(single.ExtensionTypes)
+	into.Secrets.Merge(single.Secrets)
 	into.Configurations.Merge(single.Configurations)
 
 	return true
@@ -15,9 +15,17 @@
 package model
 
 import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/hex"
 	"sort"
+	"strconv"
 	"time"
 
+	"github.com/pmezard/go-difflib/difflib"
+
 	"github.com/observiq/bindplane-op/internal/store/search"
 	"github.com/observiq/bindplane-op/internal/util/semver"
 )
@@ -58,6 +66,14 @@ const (
 	// Upgrading is set on an Agent when it has been sent a new package that is being applied. After Upgrading, it will
 	// transition back to Connected or Error unless it already has the Configuring status.
 	Upgrading AgentStatus = 7
+
+	// Restarting is set on an Agent when it has been asked to restart. After the agent reports its status following the
+	// restart, it will transition back to Connected or Error.
+	Restarting AgentStatus = 8
+
+	// Stale is set on an Agent that has been Disconnected for longer than the agent cleanup TTL when stale agents are
+	// kept instead of deleted. It will transition back to Connected when the agent reconnects.
+	Stale AgentStatus = 9
 )
 
 // AgentUpgradeStatus is the status of the AgentUpgrade
@@ -89,6 +105,120 @@ type AgentUpgrade struct {
 	Error string `json:"error,omitempty" yaml:"error,omitempty"`
 }
 
+// AgentRestartStatus is the status of the AgentRestart
+type AgentRestartStatus uint8
+
+const (
+	// RestartPending is set when the restart is requested
+	RestartPending AgentRestartStatus = 0
+	// RestartStarted is set when the restart has been sent to the agent
+	RestartStarted AgentRestartStatus = 1
+	// RestartFailed is set when the restart could not be sent to the agent. If the restart is successful, the Agent
+	// Restart field will be set to nil and there is no corresponding status.
+	RestartFailed AgentRestartStatus = 2
+)
+
+// AgentRestart stores information on an Agent about a requested restart.
+type AgentRestart struct {
+	// Status indicates the progress of the agent restart
+	Status AgentRestartStatus `json:"status" yaml:"status"`
+
+	// RequestedAt is the time that the restart was requested
+	RequestedAt time.Time `json:"requestedAt" yaml:"requestedAt"`
+
+	// Error is set if the restart could not be sent to the agent
+	Error string `json:"error,omitempty" yaml:"error,omitempty"`
+}
+
+// RemoteConfigStatus is the status reported by an agent for a remote configuration sent to it
+type RemoteConfigStatus string
+
+const (
+	// RemoteConfigApplying is reported while the agent is applying the remote configuration
+	RemoteConfigApplying RemoteConfigStatus = "applying"
+	// RemoteConfigApplied is reported when the agent applied the remote configuration
+	RemoteConfigApplied RemoteConfigStatus = "applied"
+	// RemoteConfigFailed is reported when the agent failed to apply the remote configuration
+	RemoteConfigFailed RemoteConfigStatus = "failed"
+)
+
+// AgentConfigurationStatus tracks the revision of the Configuration most recently sent to an Agent and the status
+// reported by the agent. The sent and reported hashes are recorded separately because the agent may report its status
+// before the sent configuration is stored.
+type AgentConfigurationStatus struct {
+	// Configuration is the name of the Configuration sent to the agent
+	Configuration string `json:"configuration" yaml:"configuration"`
+
+	// Revision is the revision of the Configuration sent to the agent
+	Revision int `json:"revision" yaml:"revision"`
+
+	// Hash is the hex-encoded hash of the remote configuration sent to the agent. It is empty if the agent was already
+	// using the configuration and nothing was sent.
+	Hash string `json:"hash,omitempty" yaml:"hash,omitempty"`
+
+	// ReportedHash is the hex-encoded hash of the last remote configuration reported by the agent
+	ReportedHash string `json:"reportedHash,omitempty" yaml:"reportedHash,omitempty"`
+
+	// ReportedStatus is the status reported by the agent for the remote configuration with ReportedHash
+	ReportedStatus RemoteConfigStatus `json:"reportedStatus,omitempty" yaml:"reportedStatus,omitempty"`
+}
+
+// Sent returns true if the revision of the Configuration was sent to the agent as the remote configuration with the
+// specified hash
+func (s *AgentConfigurationStatus) Sent(configuration *Configuration, hash string) bool {
+	return s != nil && s.Configuration == configuration.Name() && s.Revision == configuration.Revision() && s.Hash == hash
+}
+
+// Status returns the status of the remote configuration with the specified hash. An empty hash means that nothing was
+// sent because the agent was already using the configuration and it is considered applied. It returns an empty status
+// if the agent has not reported a status for the hash.
+func (s *AgentConfigurationStatus) Status(hash string) RemoteConfigStatus {
+	switch {
+	case hash == "":
+		return RemoteConfigApplied
+	case s.ReportedHash != hash:
+		return ""
+	default:
+		return s.ReportedStatus
+	}
+}
+
+// AgentDrift describes the difference between the collector configuration reported by an Agent and the configuration
+// rendered for it by BindPlane.
+type AgentDrift struct {
+	// Configuration is the name of the Configuration rendered for the agent
+	Configuration string `json:"configuration" yaml:"configuration"`
+
+	// Diff is a unified diff from the rendered configuration to the configuration reported by the agent
+	Diff string `json:"diff" yaml:"diff"`
+
+	// DetectedAt is the time that the difference was first detected
+	DetectedAt time.Time `json:"detectedAt" yaml:"detectedAt"`
+}
+
+// AgentCredentials are issued to an Agent when it first connects. Once the agent is using its own secret key, it must
+// present that key to connect.
+type AgentCredentials struct {
+	// SecretKeyHash is the hex encoded sha256 hash of the secret key issued to the agent
+	SecretKeyHash string `json:"secretKeyHash,omitempty" yaml:"-"`
+
+	// PendingSecretKey is the secret key issued to the agent. It is stored until the agent reports that it is using the
+	// secret key so that it can be sent to the agent.
+	PendingSecretKey string `json:"pendingSecretKey,omitempty" yaml:"-"`
+
+	// EnrollmentToken is the name of the EnrollmentToken used by the agent to enroll, if any
+	EnrollmentToken string `json:"enrollmentToken,omitempty" yaml:"enrollmentToken,omitempty"`
+
+	// Labels are the labels of the EnrollmentToken which are added to the agent until it is using its secret key
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty"`
+
+	// IssuedAt is the time that the secret key was issued
+	IssuedAt time.Time `json:"issuedAt" yaml:"issuedAt"`
+
+	// RevokedAt is the time that the credentials were revoked. Agents with revoked credentials cannot connect.
+	RevokedAt *time.Time `json:"revokedAt,omitempty" yaml:"revokedAt,omitempty"`
+}
+
 // AgentFeatures is a bitmask of features supported by the Agent, usually based on its version.
 type AgentFeatures uint32
 
@@ -130,15 +260,29 @@ type Agent struct {
 	// Upgrade stores information about an agent upgrade
 	Upgrade *AgentUpgrade `json:"upgrade,omitempty" yaml:"upgrade,omitempty"`
 
+	// Restart stores information about a requested restart
+	Restart *AgentRestart `json:"restart,omitempty" yaml:"restart,omitempty"`
+
+	// Credentials are issued to the agent when it first connects
+	Credentials *AgentCredentials `json:"credentials,omitempty" yaml:"credentials,omitempty"`
+
+	// CertificateFingerprint is the SHA-256 fingerprint of the client certificate used by the agent to connect with
+	// mutual TLS
+	CertificateFingerprint string `json:"certificateFingerprint,omitempty" yaml:"certificateFingerprint,omitempty"`
+
 	// reported by Status messages
 	Status       AgentStatus `json:"status"`
 	ErrorMessage string      `json:"errorMessage,omitempty" yaml:"errorMessage,omitempty"`
 
 	// tracked by BindPlane
 	Configuration  interface{} `json:"configuration,omitempty" yaml:"configuration,omitempty"`
+	Drift          *AgentDrift `json:"drift,omitempty" yaml:"drift,omitempty"`
 	ConnectedAt    *time.Time  `json:"connectedAt,omitempty" yaml:"connectedAt,omitempty"`
 	DisconnectedAt *time.Time  `json:"disconnectedAt,omitempty" yaml:"disconnectedAt,omitempty"`
 
+	// ConfigurationStatus is the status of the revision of the Configuration most recently sent to the agent
+	ConfigurationStatus *AgentConfigurationStatus `json:"configurationStatus,omitempty" yaml:"configurationStatus,omitempty"`
+
 	// used by the agent management protocol
 	Protocol string      `json:"protocol,omitempty" yaml:"protocol,omitempty"`
 	State    interface{} `json:"state,omitempty" yaml:"state,omitempty"`
@@ -155,7 +299,12 @@ func (a *Agent) UniqueKey() string {
 
 // StatusDisplayText returns the string representation of the agent's status.
 func (a *Agent) StatusDisplayText() string {
-	switch a.Status {
+	return a.Status.DisplayText()
+}
+
+// DisplayText returns the string representation of the status
+func (s AgentStatus) DisplayText() string {
+	switch s {
 	case Disconnected:
 		return "Disconnected"
 	case Connected:
@@ -170,6 +319,10 @@ func (a *Agent) StatusDisplayText() string {
 		return "Configuring"
 	case Upgrading:
 		return "Upgrading"
+	case Restarting:
+		return "Restarting"
+	case Stale:
+		return "Stale"
 	default:
 		return "Unknown"
 	}
@@ -182,7 +335,7 @@ func (a *Agent) GetLabels() Labels {
 
 // ConnectedDurationDisplayText TODO(doc)
 func (a *Agent) ConnectedDurationDisplayText() string {
-	if a.Status == Disconnected {
+	if a.Status == Disconnected || a.Status == Stale {
 		return "-"
 	}
 	return durationDisplay(a.ConnectedAt)
@@ -200,7 +353,7 @@ func (a *Agent) MatchesSelector(selector Selector) bool {
 
 // DisconnectedSince returns true if the agent has been disconnected since a given time.
 func (a *Agent) DisconnectedSince(since time.Time) bool {
-	return a.DisconnectedAt != nil || a.DisconnectedAt.Before(since)
+	return a.DisconnectedAt != nil && a.DisconnectedAt.Before(since)
 }
 
 // Connect updates the ConnectedAt and DisconnectedAt fields of the agent and should be called when the
@@ -333,6 +486,296 @@ func (a *Agent) UpgradeComplete(version, errorMessage string) {
 	}
 }
 
+// ----------------------------------------------------------------------
+// restarting
+
+// RequestRestart begins a restart by setting the status to Restarting and setting the Restart field. Disconnected agents
+// cannot be restarted and are not modified.
+func (a *Agent) RequestRestart() {
+	if a.Status == Disconnected {
+		return
+	}
+	a.Restart = &AgentRestart{
+		Status:      RestartPending,
+		RequestedAt: time.Now().UTC(),
+	}
+	a.Status = Restarting
+}
+
+// RestartStarted is set when the restart instructions have actually been sent to the Agent.
+func (a *Agent) RestartStarted() {
+	if a.Restart == nil {
+		a.Restart = &AgentRestart{RequestedAt: time.Now().UTC()}
+	}
+	a.Restart.Status = RestartStarted
+	a.Status = Restarting
+}
+
+// RestartComplete completes a restart by setting the status back to either Connected or Error (depending on
+// ErrorMessage) and either removing the AgentRestart field or setting the Error on it if the specified errorMessage is
+// not empty.
+func (a *Agent) RestartComplete(errorMessage string) {
+	if errorMessage != "" {
+		if a.Restart == nil {
+			a.Restart = &AgentRestart{RequestedAt: time.Now().UTC()}
+		}
+		a.Restart.Status = RestartFailed
+		a.Restart.Error = errorMessage
+	} else {
+		a.Restart = nil
+	}
+	if a.Status == Restarting {
+		if a.ErrorMessage != "" {
+			a.Status = Error
+		} else {
+			a.Status = Connected
+		}
+	}
+}
+
+// ----------------------------------------------------------------------
+// credentials
+
+// IssueCredentials issues a new secret key to the agent, replacing any existing credentials. If the agent enrolled with
+// an EnrollmentToken, the name and labels of the token are stored with the credentials and the labels are added to the
+// agent.
+func (a *Agent) IssueCredentials(token *EnrollmentToken) error {
+	secretKey, err := newSecretKey()
+	if err != nil {
+		return err
+	}
+	a.Credentials = &AgentCredentials{
+		SecretKeyHash:    hashSecretKey(secretKey),
+		PendingSecretKey: secretKey,
+		IssuedAt:         time.Now().UTC(),
+	}
+	if token != nil {
+		labels := token.AgentLabels()
+		a.Credentials.EnrollmentToken = token.Name()
+		a.Credentials.Labels = labels.AsMap()
+		a.ApplyEnrollmentLabels()
+	}
+	return nil
+}
+
+// ApplyEnrollmentLabels adds the labels of the EnrollmentToken to the labels of the agent until the agent is using its
+// secret key. Labels specified by the agent take precedence.
+func (a *Agent) ApplyEnrollmentLabels() {
+	if !a.Credentials.Pending() || len(a.Credentials.Labels) == 0 {
+		return
+	}
+	a.Labels = LabelsFromMerge(LabelsFromValidatedMap(a.Credentials.Labels), a.Labels)
+}
+
+// ConfirmCredentials is called when the agent reports that it is using the specified secret key. If it is the pending
+// secret key, the pending secret key is removed and the agent must present it to connect.
+func (a *Agent) ConfirmCredentials(secretKey string) bool {
+	if !a.Credentials.Pending() || a.Credentials.PendingSecretKey != secretKey {
+		return false
+	}
+	a.Credentials.PendingSecretKey = ""
+	a.Credentials.Labels = nil
+	return true
+}
+
+// RevokeCredentials revokes the credentials of the agent. Agents without credentials are not modified.
+func (a *Agent) RevokeCredentials() {
+	if a.Credentials == nil || a.Credentials.Revoked() {
+		return
+	}
+	now := time.Now().UTC()
+	a.Credentials.RevokedAt = &now
+	a.Credentials.PendingSecretKey = ""
+}
+
+// RedactCredentials returns a copy of the agent without the secret key issued to it or the hash of that key so that
+// they are never returned by the API or recorded in the audit log. The agent is returned unchanged if it has no
+// credentials.
+func (a *Agent) RedactCredentials() *Agent {
+	if a == nil || a.Credentials == nil {
+		return a
+	}
+	credentials := *a.Credentials
+	credentials.SecretKeyHash = ""
+	credentials.PendingSecretKey = ""
+
+	redacted := *a
+	redacted.Credentials = &credentials
+	return &redacted
+}
+
+// Verify returns true if the specified secret key is the secret key issued to the agent and the credentials have not
+// been revoked.
+func (c *AgentCredentials) Verify(secretKey string) bool {
+	if c == nil || c.Revoked() || secretKey == "" {
+		return false
+	}
+	return subtle.ConstantTimeCompare([]byte(hashSecretKey(secretKey)), []byte(c.SecretKeyHash)) == 1
+}
+
+// Pending returns true if the agent has not yet reported that it is using the secret key issued to it
+func (c *AgentCredentials) Pending() bool {
+	return c != nil && c.PendingSecretKey != ""
+}
+
+// Revoked returns true if the credentials have been revoked
+func (c *AgentCredentials) Revoked() bool {
+	return c != nil && c.RevokedAt != nil
+}
+
+func newSecretKey() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(bytes), nil
+}
+
+func hashSecretKey(secretKey string) string {
+	hash := sha256.Sum256([]byte(secretKey))
+	return hex.EncodeToString(hash[:])
+}
+
+// ----------------------------------------------------------------------
+// certificates
+
+// CertificateFingerprint returns the hex encoded SHA-256 fingerprint of the certificate
+func CertificateFingerprint(certificate *x509.Certificate) string {
+	fingerprint := sha256.Sum256(certificate.Raw)
+	return hex.EncodeToString(fingerprint[:])
+}
+
+// CertificateIdentities returns the subject common name and the DNS, URI, and email subject alternative names of the
+// certificate which can be used to identify an agent
+func CertificateIdentities(certificate *x509.Certificate) []string {
+	var identities []string
+	if certificate.Subject.CommonName != "" {
+		identities = append(identities, certificate.Subject.CommonName)
+	}
+	identities = append(identities, certificate.DNSNames...)
+	for _, uri := range certificate.URIs {
+		identities = append(identities, uri.String())
+	}
+	identities = append(identities, certificate.EmailAddresses...)
+	return identities
+}
+
+// ----------------------------------------------------------------------
+// drift
+
+// Drifted returns true if the collector configuration reported by the agent differs from the configuration rendered
+// for it
+func (a *Agent) Drifted() bool {
+	return a.Drift != nil
+}
+
+// ConfigurationSent records the revision of the Configuration and the hex-encoded hash of the remote configuration sent
+// to the agent. The hash is empty if the agent was already using the configuration. It returns true if the
+// ConfigurationStatus was changed.
+func (a *Agent) ConfigurationSent(configuration *Configuration, hash string) bool {
+	if a.ConfigurationStatus.Sent(configuration, hash) {
+		return false
+	}
+	if a.ConfigurationStatus == nil {
+		a.ConfigurationStatus = &AgentConfigurationStatus{}
+	}
+	a.ConfigurationStatus.Configuration = configuration.Name()
+	a.ConfigurationStatus.Revision = configuration.Revision()
+	a.ConfigurationStatus.Hash = hash
+	return true
+}
+
+// ConfigurationReported records the status reported by the agent for the remote configuration with the hex-encoded hash
+func (a *Agent) ConfigurationReported(hash string, status RemoteConfigStatus) {
+	if a.ConfigurationStatus == nil {
+		a.ConfigurationStatus = &AgentConfigurationStatus{}
+	}
+	a.ConfigurationStatus.ReportedHash = hash
+	a.ConfigurationStatus.ReportedStatus = status
+}
+
+// UpdateDrift compares the collector configuration rendered for the agent from the Configuration with the specified
+// name to the collector configuration reported by the agent. Drift is set with a diff if they differ and cleared if
+// they are the same. The secret values are redacted from the diff because the rendered configuration contains the
+// values of the secrets it references. It returns true if Drift was changed. The time that drift was detected is
+// preserved while the diff is unchanged.
+func (a *Agent) UpdateDrift(configuration string, rendered string, reported string, secrets []string, now time.Time) bool {
+	if rendered == reported {
+		changed := a.Drift != nil
+		a.Drift = nil
+		return changed
+	}
+
+	diff, err := difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+		A:        difflib.SplitLines(rendered),
+		B:        difflib.SplitLines(reported),
+		FromFile: configuration,
+		ToFile:   a.ID,
+		Context:  3,
+	})
+	if err != nil {
+		diff = err.Error()
+	}
+	diff = RedactSecretValues(diff, secrets)
+	if a.Drift != nil && a.Drift.Configuration == configuration && a.Drift.Diff == diff {
+		return false
+	}
+	a.Drift = &AgentDrift{
+		Configuration: configuration,
+		Diff:          diff,
+		DetectedAt:    now,
+	}
+	return true
+}
+
+// RedactSecrets returns a copy of the agent with the secret values redacted from the configuration reported by the
+// agent and from the diff of its drift. The diff is also redacted when it is stored, so it is only redacted here in
+// case it was stored by an earlier version. The agent is returned unchanged if there are no secret values.
+func (a *Agent) RedactSecrets(values []string) *Agent {
+	if len(values) == 0 {
+		return a
+	}
+	redacted := *a
+	redacted.Configuration = redactSecretValues(a.Configuration, values)
+	if a.Drift != nil {
+		drift := *a.Drift
+		drift.Diff = RedactSecretValues(drift.Diff, values)
+		redacted.Drift = &drift
+	}
+	return &redacted
+}
+
+// AgentTemplateValues are the values of an Agent available to ResourceType templates and parameter values as .Agent,
+// e.g. {{ .Agent.Labels.datacenter }}
+type AgentTemplateValues struct {
+	ID              string
+	Name            string
+	Hostname        string
+	Platform        string
+	OperatingSystem string
+	Architecture    string
+	Version         string
+	Labels          map[string]string
+}
+
+// TemplateValues returns the values of the agent available to templates. All of the values are empty if the agent is
+// nil.
+func (a *Agent) TemplateValues() AgentTemplateValues {
+	if a == nil {
+		return AgentTemplateValues{}
+	}
+	return AgentTemplateValues{
+		ID:              a.ID,
+		Name:            a.Name,
+		Hostname:        a.HostName,
+		Platform:        a.Platform,
+		OperatingSystem: a.OperatingSystem,
+		Architecture:    a.Architecture,
+		Version:         a.Version,
+		Labels:          a.Labels.AsMap(),
+	}
+}
+
 // ----------------------------------------------------------------------
 // sorting
 
@@ -374,6 +817,10 @@ func (a *Agent) IndexFields(index search.Indexer) {
 	index("macAddress", a.MacAddress)
 	index("type", a.Type)
 	index("status", a.StatusDisplayText())
+	index("drift", strconv.FormatBool(a.Drifted()))
+	if a.Credentials != nil && a.Credentials.EnrollmentToken != "" {
+		index("enrollmentToken", a.Credentials.EnrollmentToken)
+	}
 }
 
 // IndexLabels returns a map of label name to label value to be stored in the index
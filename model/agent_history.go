@@ -0,0 +1,185 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// AgentStatusChange records a change to the Status or ErrorMessage of an agent. The changes of an agent form its
+// connection history.
+type AgentStatusChange struct {
+	AgentID      string      `json:"agentId" yaml:"agentId" mapstructure:"agentId"`
+	Timestamp    time.Time   `json:"timestamp" yaml:"timestamp" mapstructure:"timestamp"`
+	Status       AgentStatus `json:"status" yaml:"status" mapstructure:"status"`
+	ErrorMessage string      `json:"errorMessage,omitempty" yaml:"errorMessage,omitempty" mapstructure:"errorMessage"`
+}
+
+// NewAgentStatusChange returns an AgentStatusChange with the current Status and ErrorMessage of the agent
+func NewAgentStatusChange(agent *Agent, timestamp time.Time) *AgentStatusChange {
+	return &AgentStatusChange{
+		AgentID:      agent.ID,
+		Timestamp:    timestamp,
+		Status:       agent.Status,
+		ErrorMessage: agent.ErrorMessage,
+	}
+}
+
+// Up returns true if the agent is connected and running normally with this status, which is the case for Connected,
+// Configuring, Upgrading, and Restarting
+func (c *AgentStatusChange) Up() bool {
+	switch c.Status {
+	case Connected, Configuring, Upgrading, Restarting:
+		return true
+	default:
+		return false
+	}
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableKindSingular returns the singular form of the Kind, e.g. "Agent Status Change"
+func (c *AgentStatusChange) PrintableKindSingular() string {
+	return "Agent Status Change"
+}
+
+// PrintableKindPlural returns the plural form of the Kind, e.g. "Agent Status Changes"
+func (c *AgentStatusChange) PrintableKindPlural() string {
+	return "Agent Status Changes"
+}
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (c *AgentStatusChange) PrintableFieldTitles() []string {
+	return []string{"Timestamp", "Status", "Error"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (c *AgentStatusChange) PrintableFieldValue(title string) string {
+	switch title {
+	case "Timestamp":
+		return c.Timestamp.Format(time.RFC3339)
+	case "Status":
+		return c.Status.DisplayText()
+	case "Error":
+		if c.ErrorMessage == "" {
+			return "-"
+		}
+		return c.ErrorMessage
+	default:
+		return "-"
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// AgentHistory contains the status changes of an agent during a window of time and the percentage of the window that the
+// agent was up
+type AgentHistory struct {
+	Start time.Time `json:"start" yaml:"start" mapstructure:"start"`
+	End   time.Time `json:"end" yaml:"end" mapstructure:"end"`
+
+	// Changes are the status changes during the window, oldest first
+	Changes []*AgentStatusChange `json:"changes" yaml:"changes" mapstructure:"changes"`
+
+	// Uptime is the percentage of the window, from 0 to 100, that the agent was up. Time in the window before the first
+	// recorded change is not included because the agent was not known to the server.
+	Uptime float64 `json:"uptime" yaml:"uptime" mapstructure:"uptime"`
+}
+
+// NewAgentHistory returns the AgentHistory for the window from start to end using the changes of the agent, which must be
+// ordered from oldest to newest and include changes before start. If start is zero, the window starts with the first
+// change. If end is zero, the window ends now.
+func NewAgentHistory(changes []*AgentStatusChange, start, end time.Time) *AgentHistory {
+	if end.IsZero() {
+		end = time.Now().UTC()
+	}
+	if start.IsZero() && len(changes) > 0 {
+		start = changes[0].Timestamp
+	}
+
+	history := &AgentHistory{
+		Start:   start,
+		End:     end,
+		Changes: []*AgentStatusChange{},
+	}
+
+	var total, up time.Duration
+	for i, change := range changes {
+		if !change.Timestamp.Before(start) && !change.Timestamp.After(end) {
+			history.Changes = append(history.Changes, change)
+		}
+
+		// each status lasts until the next change or the end of the window
+		from, until := change.Timestamp, end
+		if i+1 < len(changes) {
+			until = changes[i+1].Timestamp
+		}
+		if from.Before(start) {
+			from = start
+		}
+		if until.After(end) {
+			until = end
+		}
+		if !until.After(from) {
+			continue
+		}
+		total += until.Sub(from)
+		if change.Up() {
+			up += until.Sub(from)
+		}
+	}
+
+	if total > 0 {
+		history.Uptime = float64(up) / float64(total) * 100
+	}
+	return history
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableKindSingular returns the singular form of the Kind, e.g. "Agent History"
+func (h *AgentHistory) PrintableKindSingular() string {
+	return "Agent History"
+}
+
+// PrintableKindPlural returns the plural form of the Kind, e.g. "Agent Histories"
+func (h *AgentHistory) PrintableKindPlural() string {
+	return "Agent Histories"
+}
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (h *AgentHistory) PrintableFieldTitles() []string {
+	return []string{"Start", "End", "Changes", "Uptime"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (h *AgentHistory) PrintableFieldValue(title string) string {
+	switch title {
+	case "Start":
+		return h.Start.Format(time.RFC3339)
+	case "End":
+		return h.End.Format(time.RFC3339)
+	case "Changes":
+		return strconv.Itoa(len(h.Changes))
+	case "Uptime":
+		return fmt.Sprintf("%.2f%%", h.Uptime)
+	default:
+		return "-"
+	}
+}
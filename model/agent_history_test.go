@@ -0,0 +1,106 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewAgentHistory(t *testing.T) {
+	base := time.Date(2022, 10, 1, 12, 0, 0, 0, time.UTC)
+	at := func(minutes int) time.Time {
+		return base.Add(time.Duration(minutes) * time.Minute)
+	}
+	changes := []*AgentStatusChange{
+		{AgentID: "1", Timestamp: at(0), Status: Connected},
+		{AgentID: "1", Timestamp: at(30), Status: Error, ErrorMessage: "failed"},
+		{AgentID: "1", Timestamp: at(40), Status: Configuring},
+		{AgentID: "1", Timestamp: at(50), Status: Disconnected},
+		{AgentID: "1", Timestamp: at(60), Status: Connected},
+	}
+
+	tests := []struct {
+		name          string
+		changes       []*AgentStatusChange
+		start         time.Time
+		end           time.Time
+		expectStart   time.Time
+		expectChanges int
+		expectUptime  float64
+	}{
+		{
+			name:          "entire history",
+			changes:       changes,
+			end:           at(100),
+			expectStart:   at(0),
+			expectChanges: 5,
+			expectUptime:  80,
+		},
+		{
+			name:          "window starting between changes",
+			changes:       changes,
+			start:         at(35),
+			end:           at(55),
+			expectStart:   at(35),
+			expectChanges: 2,
+			expectUptime:  50,
+		},
+		{
+			name:          "window before the first change",
+			changes:       changes,
+			start:         at(-60),
+			end:           at(60),
+			expectStart:   at(-60),
+			expectChanges: 5,
+			expectUptime:  float64(40) / float64(60) * 100,
+		},
+		{
+			name:          "no changes",
+			changes:       nil,
+			start:         at(0),
+			end:           at(60),
+			expectStart:   at(0),
+			expectChanges: 0,
+			expectUptime:  0,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			history := NewAgentHistory(test.changes, test.start, test.end)
+			require.Equal(t, test.expectStart, history.Start)
+			require.Equal(t, test.end, history.End)
+			require.Len(t, history.Changes, test.expectChanges)
+			require.InDelta(t, test.expectUptime, history.Uptime, 0.0001)
+		})
+	}
+}
+
+func TestAgentStatusChangeUp(t *testing.T) {
+	for status, expect := range map[AgentStatus]bool{
+		Disconnected: false,
+		Connected:    true,
+		Error:        false,
+		Configuring:  true,
+		Upgrading:    true,
+		Restarting:   true,
+		Deleted:      false,
+	} {
+		require.Equal(t, expect, (&AgentStatusChange{Status: status}).Up(), status.DisplayText())
+	}
+}
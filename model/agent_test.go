@@ -16,6 +16,7 @@ package model
 
 import (
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 )
@@ -212,6 +213,90 @@ func TestAgentUpgradeComplete(t *testing.T) {
 	}
 }
 
+func TestAgentCredentials(t *testing.T) {
+	token := NewEnrollmentToken("test", EnrollmentTokenSpec{Labels: map[string]string{"env": "test", "app": "token"}})
+	labels, err := LabelsFromMap(map[string]string{"app": "agent"})
+	require.NoError(t, err)
+
+	agent := &Agent{ID: "1", Labels: labels}
+	require.False(t, agent.Credentials.Verify("anything"))
+
+	require.NoError(t, agent.IssueCredentials(token))
+	require.True(t, agent.Credentials.Pending())
+	require.Equal(t, "test", agent.Credentials.EnrollmentToken)
+	require.Equal(t, map[string]string{"env": "test", "app": "agent"}, agent.Labels.AsMap())
+
+	secretKey := agent.Credentials.PendingSecretKey
+	require.Len(t, secretKey, 64)
+	require.NotEqual(t, secretKey, agent.Credentials.SecretKeyHash)
+	require.True(t, agent.Credentials.Verify(secretKey))
+	require.False(t, agent.Credentials.Verify("wrong"))
+	require.False(t, agent.Credentials.Verify(""))
+
+	require.False(t, agent.ConfirmCredentials("wrong"))
+	require.True(t, agent.ConfirmCredentials(secretKey))
+	require.False(t, agent.Credentials.Pending())
+	require.True(t, agent.Credentials.Verify(secretKey))
+
+	agent.RevokeCredentials()
+	require.True(t, agent.Credentials.Revoked())
+	require.False(t, agent.Credentials.Verify(secretKey))
+}
+
+func TestAgentRedactCredentials(t *testing.T) {
+	agent := &Agent{ID: "1"}
+	require.Same(t, agent, agent.RedactCredentials())
+
+	require.NoError(t, agent.IssueCredentials(nil))
+	redacted := agent.RedactCredentials()
+	require.Empty(t, redacted.Credentials.PendingSecretKey)
+	require.Empty(t, redacted.Credentials.SecretKeyHash)
+	require.Equal(t, agent.Credentials.IssuedAt, redacted.Credentials.IssuedAt)
+	require.True(t, agent.Credentials.Pending(), "redacting should not modify the original")
+}
+
+func TestAgentUpdateDrift(t *testing.T) {
+	agent := &Agent{ID: "1"}
+	detected := time.Now()
+
+	require.False(t, agent.UpdateDrift("test", "a: 1\n", "a: 1\n", nil, detected))
+	require.False(t, agent.Drifted())
+
+	require.True(t, agent.UpdateDrift("test", "a: 1\nb: 2\n", "a: 1\nb: 3\n", nil, detected))
+	require.True(t, agent.Drifted())
+	require.Equal(t, "test", agent.Drift.Configuration)
+	require.Equal(t, "--- test\n+++ 1\n@@ -1,3 +1,3 @@\n a: 1\n-b: 2\n+b: 3\n \n", agent.Drift.Diff)
+
+	// the same drift keeps the time it was detected
+	require.False(t, agent.UpdateDrift("test", "a: 1\nb: 2\n", "a: 1\nb: 3\n", nil, detected.Add(time.Minute)))
+	require.Equal(t, detected, agent.Drift.DetectedAt)
+
+	require.True(t, agent.UpdateDrift("test", "a: 1\nb: 2\n", "a: 1\nb: 2\n", nil, detected))
+	require.False(t, agent.Drifted())
+}
+
+func TestAgentUpdateDriftRedactsSecrets(t *testing.T) {
+	agent := &Agent{ID: "1"}
+	require.True(t, agent.UpdateDrift("test", "token: s3cr3t\n", "token: other\n", []string{"s3cr3t"}, time.Now()))
+	require.Equal(t, "--- test\n+++ 1\n@@ -1,2 +1,2 @@\n-token: (redacted)\n+token: other\n \n", agent.Drift.Diff)
+}
+
+func TestAgentDisconnectedSince(t *testing.T) {
+	now := time.Now()
+
+	agent := &Agent{ID: "1", Status: Connected}
+	require.False(t, agent.DisconnectedSince(now))
+
+	disconnectedAt := now.Add(-time.Hour)
+	agent.Status = Disconnected
+	agent.DisconnectedAt = &disconnectedAt
+	require.True(t, agent.DisconnectedSince(now.Add(-time.Minute)))
+	require.False(t, agent.DisconnectedSince(now.Add(-2*time.Hour)))
+
+	agent.Connect("")
+	require.False(t, agent.DisconnectedSince(now))
+}
+
 func TestFeatures(t *testing.T) {
 	tests := []struct {
 		version        string
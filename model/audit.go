@@ -0,0 +1,268 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// AuditAction is the type of change recorded by an AuditEntry
+type AuditAction string
+
+const (
+	// AuditActionCreate indicates that a resource was created
+	AuditActionCreate AuditAction = "create"
+
+	// AuditActionUpdate indicates that a resource or agent was modified
+	AuditActionUpdate AuditAction = "update"
+
+	// AuditActionDelete indicates that a resource or agent was deleted
+	AuditActionDelete AuditAction = "delete"
+
+	// AuditActionLabel indicates that the labels of an agent were modified
+	AuditActionLabel AuditAction = "label"
+
+	// AuditActionUpgrade indicates that an agent was asked to upgrade
+	AuditActionUpgrade AuditAction = "upgrade"
+
+	// AuditActionReject indicates that the connection of an agent was rejected
+	AuditActionReject AuditAction = "reject"
+)
+
+// AuditEntry records a single change to a resource or agent, including the user that made the change and the state of
+// the resource or agent before and after the change.
+type AuditEntry struct {
+	ID        string      `json:"id" yaml:"id" mapstructure:"id"`
+	Timestamp time.Time   `json:"timestamp" yaml:"timestamp" mapstructure:"timestamp"`
+	User      string      `json:"user" yaml:"user" mapstructure:"user"`
+	Action    AuditAction `json:"action" yaml:"action" mapstructure:"action"`
+	Kind      Kind        `json:"kind" yaml:"kind" mapstructure:"kind"`
+	// Name is the name of the resource or the ID of the agent
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Before is the resource or agent before the change and is empty if it was created
+	Before map[string]any `json:"before,omitempty" yaml:"before,omitempty" mapstructure:"before"`
+	// After is the resource or agent after the change and is empty if it was deleted
+	After map[string]any `json:"after,omitempty" yaml:"after,omitempty" mapstructure:"after"`
+	// Changes describes each field that is different between Before and After, e.g. "spec.raw: a -> b"
+	Changes []string `json:"changes,omitempty" yaml:"changes,omitempty" mapstructure:"changes"`
+}
+
+// NewAuditEntry returns a new AuditEntry for the change of a resource or agent from before to after. Either before or
+// after can be nil if the resource was created or deleted. The ID and Timestamp are assigned by the Store.
+func NewAuditEntry(user string, action AuditAction, kind Kind, name string, before, after any) (*AuditEntry, error) {
+	beforeMap, err := auditMap(before)
+	if err != nil {
+		return nil, err
+	}
+	afterMap, err := auditMap(after)
+	if err != nil {
+		return nil, err
+	}
+	return &AuditEntry{
+		User:    user,
+		Action:  action,
+		Kind:    kind,
+		Name:    name,
+		Before:  beforeMap,
+		After:   afterMap,
+		Changes: AuditChanges(beforeMap, afterMap),
+	}, nil
+}
+
+// auditMap converts a resource or agent to the generic map stored with an AuditEntry
+func auditMap(v any) (map[string]any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := json.Unmarshal(data, &m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// AuditChanges returns a sorted description of each field that is different between before and after. Nested fields
+// are described using their path, e.g. "metadata.labels.env: dev -> prod".
+func AuditChanges(before, after map[string]any) []string {
+	beforeFields := map[string]string{}
+	flattenAuditFields("", before, beforeFields)
+	afterFields := map[string]string{}
+	flattenAuditFields("", after, afterFields)
+
+	var changes []string
+	for path, b := range beforeFields {
+		a, ok := afterFields[path]
+		switch {
+		case !ok:
+			changes = append(changes, fmt.Sprintf("%s: %s -> (removed)", path, b))
+		case a != b:
+			changes = append(changes, fmt.Sprintf("%s: %s -> %s", path, b, a))
+		}
+	}
+	for path, a := range afterFields {
+		if _, ok := beforeFields[path]; !ok {
+			changes = append(changes, fmt.Sprintf("%s: (added) -> %s", path, a))
+		}
+	}
+	sort.Strings(changes)
+	return changes
+}
+
+func flattenAuditFields(path string, value any, fields map[string]string) {
+	switch v := value.(type) {
+	case map[string]any:
+		for key, child := range v {
+			if path == "" {
+				flattenAuditFields(key, child, fields)
+			} else {
+				flattenAuditFields(path+"."+key, child, fields)
+			}
+		}
+	case []any:
+		for i, child := range v {
+			flattenAuditFields(fmt.Sprintf("%s[%d]", path, i), child, fields)
+		}
+	case nil:
+		// missing and null fields are treated the same
+	default:
+		fields[path] = fmt.Sprintf("%v", v)
+	}
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableKindSingular returns the singular form of the Kind, e.g. "Audit Entry"
+func (e *AuditEntry) PrintableKindSingular() string {
+	return "Audit Entry"
+}
+
+// PrintableKindPlural returns the plural form of the Kind, e.g. "Audit Entries"
+func (e *AuditEntry) PrintableKindPlural() string {
+	return "Audit Entries"
+}
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (e *AuditEntry) PrintableFieldTitles() []string {
+	return []string{"Timestamp", "User", "Action", "Kind", "Name", "Changes"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (e *AuditEntry) PrintableFieldValue(title string) string {
+	switch title {
+	case "ID":
+		return e.ID
+	case "Timestamp":
+		return e.Timestamp.Format(time.RFC3339)
+	case "User":
+		if e.User == "" {
+			return "-"
+		}
+		return e.User
+	case "Action":
+		return string(e.Action)
+	case "Kind":
+		return string(e.Kind)
+	case "Name":
+		return e.Name
+	case "Changes":
+		return fmt.Sprintf("%d", len(e.Changes))
+	default:
+		return "-"
+	}
+}
+
+// auditCredentialFields are the fields of the credentials of an agent that are removed from audit entries
+var auditCredentialFields = []string{"secretKeyHash", "pendingSecretKey"}
+
+// RedactSecrets returns a copy of the entry with the secret values redacted from the resource or agent before and after
+// the change and from the description of the changes. The secret keys issued to agents are also removed from the
+// entries of agents.
+func (e *AuditEntry) RedactSecrets(values []string) *AuditEntry {
+	redacted := *e
+	redacted.Before = redactAuditMap(e.Before, e.Kind, values)
+	redacted.After = redactAuditMap(e.After, e.Kind, values)
+	redacted.Changes = make([]string, 0, len(e.Changes))
+	for _, change := range e.Changes {
+		if e.Kind == KindAgent && isAuditCredentialChange(change) {
+			continue
+		}
+		redacted.Changes = append(redacted.Changes, RedactSecretValues(change, values))
+	}
+	return &redacted
+}
+
+func redactAuditMap(m map[string]any, kind Kind, values []string) map[string]any {
+	if m == nil {
+		return nil
+	}
+	redacted, _ := redactSecretValues(m, values).(map[string]any)
+	if kind != KindAgent {
+		return redacted
+	}
+	if credentials, ok := redacted["credentials"].(map[string]any); ok {
+		for _, field := range auditCredentialFields {
+			delete(credentials, field)
+		}
+	}
+	return redacted
+}
+
+func isAuditCredentialChange(change string) bool {
+	for _, field := range auditCredentialFields {
+		if strings.HasPrefix(change, fmt.Sprintf("credentials.%s:", field)) {
+			return true
+		}
+	}
+	return false
+}
+
+// ----------------------------------------------------------------------
+
+// AuditFilter selects audit entries. Empty fields match every entry.
+type AuditFilter struct {
+	Kind Kind
+	Name string
+	User string
+
+	// Offset is the number of matching entries to skip, starting with the newest
+	Offset int
+	// Limit is the maximum number of entries to return. Zero means no limit.
+	Limit int
+}
+
+// Matches returns true if the entry matches the Kind, Name, and User of the filter. Kind is matched without regard to
+// case.
+func (f AuditFilter) Matches(entry *AuditEntry) bool {
+	if f.Kind != "" && !strings.EqualFold(string(f.Kind), string(entry.Kind)) {
+		return false
+	}
+	if f.Name != "" && f.Name != entry.Name {
+		return false
+	}
+	if f.User != "" && f.User != entry.User {
+		return false
+	}
+	return true
+}
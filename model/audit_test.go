@@ -0,0 +1,88 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestAuditChanges(t *testing.T) {
+	tests := []struct {
+		name   string
+		before map[string]any
+		after  map[string]any
+		expect []string
+	}{
+		{
+			name:   "no changes",
+			before: map[string]any{"kind": "Source", "spec": map[string]any{"type": "nginx"}},
+			after:  map[string]any{"kind": "Source", "spec": map[string]any{"type": "nginx"}},
+			expect: nil,
+		},
+		{
+			name:   "created",
+			before: nil,
+			after:  map[string]any{"kind": "Source", "spec": map[string]any{"type": "nginx"}},
+			expect: []string{"kind: (added) -> Source", "spec.type: (added) -> nginx"},
+		},
+		{
+			name:   "nested and list fields",
+			before: map[string]any{"metadata": map[string]any{"labels": map[string]any{"env": "dev"}}, "spec": map[string]any{"sources": []any{"a", "b"}}},
+			after:  map[string]any{"metadata": map[string]any{"labels": map[string]any{"env": "prod"}}, "spec": map[string]any{"sources": []any{"a"}}},
+			expect: []string{"metadata.labels.env: dev -> prod", "spec.sources[1]: b -> (removed)"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			require.Equal(t, test.expect, AuditChanges(test.before, test.after))
+		})
+	}
+}
+
+func TestAuditFilterMatches(t *testing.T) {
+	entry := &AuditEntry{User: "admin", Action: AuditActionDelete, Kind: KindSource, Name: "nginx"}
+
+	require.True(t, AuditFilter{}.Matches(entry))
+	require.True(t, AuditFilter{Kind: "source", Name: "nginx", User: "admin"}.Matches(entry))
+	require.False(t, AuditFilter{Kind: KindDestination}.Matches(entry))
+	require.False(t, AuditFilter{Name: "macos"}.Matches(entry))
+	require.False(t, AuditFilter{User: "someone"}.Matches(entry))
+}
+
+func TestAuditEntryRedactSecrets(t *testing.T) {
+	entry, err := NewAuditEntry("alice", AuditActionUpdate, KindAgent, "1",
+		map[string]any{"configuration": "token: old"},
+		map[string]any{
+			"configuration": "token: s3cr3t",
+			"credentials":   map[string]any{"secretKeyHash": "hash", "pendingSecretKey": "key", "enrollmentToken": "production"},
+		},
+	)
+	require.NoError(t, err)
+
+	redacted := entry.RedactSecrets([]string{"s3cr3t"})
+	require.Equal(t, "token: (redacted)", redacted.After["configuration"])
+	require.Equal(t, map[string]any{"enrollmentToken": "production"}, redacted.After["credentials"])
+	require.Equal(t, []string{
+		"configuration: token: old -> token: (redacted)",
+		"credentials.enrollmentToken: (added) -> production",
+	}, redacted.Changes)
+
+	// the original entry is not modified
+	require.Equal(t, "token: s3cr3t", entry.After["configuration"])
+	require.Len(t, entry.Changes, 4)
+}
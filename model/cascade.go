@@ -0,0 +1,42 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import "fmt"
+
+// CascadeMode determines what happens to the resources that depend on a resource when it is deleted
+type CascadeMode string
+
+const (
+	// CascadeNone prevents a resource from being deleted while other resources depend on it
+	CascadeNone CascadeMode = ""
+
+	// CascadeReferences removes the references to the deleted resource from the Configurations, Sources, and
+	// Destinations that depend on it. Resources of a deleted resource type are also deleted because they cannot exist
+	// without their type.
+	CascadeReferences CascadeMode = "references"
+
+	// CascadeDelete deletes every resource that depends on the deleted resource
+	CascadeDelete CascadeMode = "delete"
+)
+
+// ParseCascadeMode returns the CascadeMode with the specified name or an error if it is not valid
+func ParseCascadeMode(mode string) (CascadeMode, error) {
+	switch CascadeMode(mode) {
+	case CascadeNone, CascadeReferences, CascadeDelete:
+		return CascadeMode(mode), nil
+	}
+	return CascadeNone, fmt.Errorf("invalid cascade mode %q, expected %q or %q", mode, CascadeReferences, CascadeDelete)
+}
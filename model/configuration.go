@@ -47,6 +47,9 @@ const (
 
 	// builtinRouteReceiverName is the name of the route receiver builtin to configurations
 	builtinRouteReceiverName string = "builtin"
+
+	// builtinRouteSourceName is the name of the source used for the builtin route receiver in pipeline names
+	builtinRouteSourceName string = "route"
 )
 
 // Configuration is the resource for the entire agent configuration
@@ -98,6 +101,22 @@ type ConfigurationSpec struct {
 	Sources      []ResourceConfiguration `json:"sources,omitempty" yaml:"sources,omitempty" mapstructure:"sources"`
 	Destinations []ResourceConfiguration `json:"destinations,omitempty" yaml:"destinations,omitempty" mapstructure:"destinations"`
 	Selector     AgentSelector           `json:"selector" yaml:"selector" mapstructure:"selector"`
+
+	// Rollout rolls out each new revision of the configuration to its agents in waves. New revisions are sent to every
+	// agent at once if it is not specified.
+	Rollout *RolloutOptions `json:"rollout,omitempty" yaml:"rollout,omitempty" mapstructure:"rollout"`
+
+	// Routes restrict the sources sent to each destination and the types of telemetry sent. Every source is sent to
+	// every destination if it is not specified.
+	Routes []ConfigurationRoute `json:"routes,omitempty" yaml:"routes,omitempty" mapstructure:"routes"`
+
+	// Extensions are rendered by ExtensionTypes and added to service.extensions of the configuration. They are shared by
+	// all of the pipelines.
+	Extensions []ResourceConfiguration `json:"extensions,omitempty" yaml:"extensions,omitempty" mapstructure:"extensions"`
+
+	// ServiceTelemetry is rendered as service.telemetry of the configuration to configure the telemetry of the agent
+	// itself, e.g. its logs level.
+	ServiceTelemetry map[string]any `json:"serviceTelemetry,omitempty" yaml:"serviceTelemetry,omitempty" mapstructure:"serviceTelemetry"`
 }
 
 // ResourceConfiguration represents a source or destination configuration
@@ -158,15 +177,21 @@ type ResourceStore interface {
 	ProcessorType(ctx context.Context, name string) (*ProcessorType, error)
 	Destination(ctx context.Context, name string) (*Destination, error)
 	DestinationType(ctx context.Context, name string) (*DestinationType, error)
+	ExtensionType(ctx context.Context, name string) (*ExtensionType, error)
+	Secret(ctx context.Context, name string) (*Secret, error)
 }
 
 // BindPlaneConfiguration includes configuration information needed to render configurations
 type BindPlaneConfiguration interface {
 	BindPlaneURL() string
+	// SecretsEncryptionKey returns the key used to decrypt the values of Secrets
+	SecretsEncryptionKey() string
 }
 
 // Render converts the Configuration model to a configuration yaml that can be sent to an agent. The specified Agent can
-// be nil if this configuration is not being rendered for a specific agent.
+// be nil if this configuration is not being rendered for a specific agent. The values of the Agent are available to
+// templates as .Agent. References to Secrets are only replaced by their values when the configuration is rendered for
+// an agent.
 func (c *Configuration) Render(ctx context.Context, agent *Agent, config BindPlaneConfiguration, store ResourceStore) (string, error) {
 	ctx, span := tracer.Start(ctx, "model/Configuration/Render")
 	defer span.End()
@@ -175,11 +200,24 @@ func (c *Configuration) Render(ctx context.Context, agent *Agent, config BindPla
 		// we always prefer raw
 		return c.Spec.Raw, nil
 	}
-	return c.renderComponents(ctx, agent, config, store)
+	return c.renderComponents(ctx, agent, agent != nil, config, store)
 }
 
-func (c *Configuration) renderComponents(ctx context.Context, agent *Agent, config BindPlaneConfiguration, store ResourceStore) (string, error) {
-	configuration, err := c.otelConfiguration(ctx, agent, config, store)
+// Preview renders the Configuration with the values of the specified Agent available to templates as .Agent. Unlike
+// Render, references to Secrets are never replaced by their values so that the result can be displayed. The specified
+// Agent can be nil.
+func (c *Configuration) Preview(ctx context.Context, agent *Agent, config BindPlaneConfiguration, store ResourceStore) (string, error) {
+	ctx, span := tracer.Start(ctx, "model/Configuration/Preview")
+	defer span.End()
+
+	if c.Spec.Raw != "" {
+		return c.Spec.Raw, nil
+	}
+	return c.renderComponents(ctx, agent, false, config, store)
+}
+
+func (c *Configuration) renderComponents(ctx context.Context, agent *Agent, resolveSecrets bool, config BindPlaneConfiguration, store ResourceStore) (string, error) {
+	configuration, err := c.otelConfiguration(ctx, agent, resolveSecrets, config, store)
 	if err != nil {
 		return "", err
 	}
@@ -189,9 +227,15 @@ func (c *Configuration) renderComponents(ctx context.Context, agent *Agent, conf
 type renderContext struct {
 	*otel.RenderContext
 	pipelineTypeUsage *PipelineTypeUsage
+
+	// secrets resolves references to secrets and is nil if they should not be resolved
+	secrets *secretResolver
+
+	// agent contains the values of the agent available to templates
+	agent AgentTemplateValues
 }
 
-func (c *Configuration) otelConfiguration(ctx context.Context, agent *Agent, config BindPlaneConfiguration, store ResourceStore) (*otel.Configuration, error) {
+func (c *Configuration) otelConfiguration(ctx context.Context, agent *Agent, resolveSecrets bool, config BindPlaneConfiguration, store ResourceStore) (*otel.Configuration, error) {
 	if len(c.Spec.Sources) == 0 || len(c.Spec.Destinations) == 0 {
 		return nil, nil
 	}
@@ -206,10 +250,14 @@ func (c *Configuration) otelConfiguration(ctx context.Context, agent *Agent, con
 	rc := &renderContext{
 		RenderContext:     otel.NewRenderContext(agentID, c.Name(), config.BindPlaneURL()),
 		pipelineTypeUsage: newPipelineTypeUsage(),
+		agent:             agent.TemplateValues(),
 	}
 	rc.IncludeSnapshotProcessor = agentFeatures.Has(AgentSupportsSnapshots)
 	rc.IncludeMeasurements = agentFeatures.Has(AgentSupportsMeasurements)
 	rc.IncludeRouteReceiver = agentFeatures.Has(AgentSupportsLogBasedMetrics)
+	if resolveSecrets {
+		rc.secrets = newSecretResolver(config.SecretsEncryptionKey(), store)
+	}
 
 	return c.otelConfigurationWithRenderContext(ctx, rc, store)
 }
@@ -217,7 +265,7 @@ func (c *Configuration) otelConfiguration(ctx context.Context, agent *Agent, con
 func (c *Configuration) otelConfigurationWithRenderContext(ctx context.Context, rc *renderContext, store ResourceStore) (*otel.Configuration, error) {
 	configuration := otel.NewConfiguration()
 
-	// match each source with each destination to produce a pipeline
+	// match each source with each routed destination to produce a pipeline
 	sources, destinations, err := c.evalComponents(ctx, store, rc)
 	if err != nil {
 		return nil, err
@@ -235,17 +283,46 @@ func (c *Configuration) otelConfigurationWithRenderContext(ctx context.Context,
 			destination := destinations[destinationName]
 
 			name := fmt.Sprintf("%s__%s", sourceName, destinationName)
-			configuration.AddPipeline(name, otel.Logs, sourceName, source, destinationName, destination, rc.RenderContext)
-			configuration.AddPipeline(name, otel.Metrics, sourceName, source, destinationName, destination, rc.RenderContext)
-			configuration.AddPipeline(name, otel.Traces, sourceName, source, destinationName, destination, rc.RenderContext)
+			for _, pipelineType := range []otel.PipelineType{otel.Logs, otel.Metrics, otel.Traces} {
+				if !c.Spec.Routed(sourceName, destinationName, pipelineType) {
+					continue
+				}
+				configuration.AddPipeline(name, pipelineType, sourceName, source, destinationName, destination, rc.RenderContext)
+			}
 		}
 	}
 
 	configuration.AddAgentMetricsPipeline(rc.RenderContext)
 
+	// extensions and service telemetry are shared by all of the pipelines
+	if err := c.evalExtensions(ctx, configuration, store, rc); err != nil {
+		return nil, err
+	}
+	configuration.Service.Telemetry = c.Spec.ServiceTelemetry
+	if rc.secrets != nil {
+		if configuration.Service.Telemetry, err = rc.secrets.resolveTelemetry(ctx, c.Spec.ServiceTelemetry); err != nil {
+			return nil, err
+		}
+	}
+
 	return configuration, nil
 }
 
+// resolveSecrets returns the resource with the references to secrets in its parameters replaced by their values if the
+// configuration is being rendered for an agent. Only parameters are resolved, and they are resolved before templates
+// are evaluated, so that values reported by the agent cannot reference secrets.
+func (rc *renderContext) resolveSecrets(ctx context.Context, resource parameterizedResource, errorHandler TemplateErrorHandler) parameterizedResource {
+	if rc.secrets == nil {
+		return resource
+	}
+	parameters, err := rc.secrets.resolveParameters(ctx, resource.ResourceParameters())
+	if err != nil {
+		errorHandler(err)
+		return resource
+	}
+	return &resolvedResource{parameterizedResource: resource, parameters: parameters}
+}
+
 func (c *Configuration) evalComponents(ctx context.Context, store ResourceStore, rc *renderContext) (sources map[string]otel.Partials, destinations map[string]otel.Partials, err error) {
 	errorHandler := func(e error) {
 		if e != nil {
@@ -265,17 +342,13 @@ func (c *Configuration) evalComponents(ctx context.Context, store ResourceStore,
 
 		// If the route receiver is supported, check if any source is using the `count_logs` processor
 		if rc.IncludeRouteReceiver && !pipelineNeedsRouteReceiver {
-			for _, p := range source.Processors {
-				if processorNeedsRouteReceiver(p) {
-					pipelineNeedsRouteReceiver = true
-				}
-			}
+			pipelineNeedsRouteReceiver = source.needsRouteReceiver()
 		}
 	}
 
 	if pipelineNeedsRouteReceiver && rc.IncludeRouteReceiver {
 		if routeReceiver, routeParts := builtinRouteReceiver(ctx, store, errorHandler); routeReceiver != "" {
-			sources["route"] = routeParts
+			sources[builtinRouteSourceName] = routeParts
 		}
 	}
 
@@ -299,7 +372,7 @@ func evalSource(ctx context.Context, source *ResourceConfiguration, defaultName
 	if src.Spec.Disabled {
 		return srcName, otel.NewPartials()
 	}
-	partials := srcType.eval(src, errorHandler)
+	partials := srcType.eval(rc.resolveSecrets(ctx, src, errorHandler), rc.agent, errorHandler)
 
 	if rc.pipelineTypeUsage != nil {
 		rc.pipelineTypeUsage.sources.setSupported(srcName, partials)
@@ -333,7 +406,7 @@ func evalProcessor(ctx context.Context, processor *ResourceConfiguration, defaul
 		return prc.Name(), otel.NewPartials()
 	}
 
-	return prc.Name(), prcType.eval(prc, errorHandler)
+	return prc.Name(), prcType.eval(rc.resolveSecrets(ctx, prc, errorHandler), rc.agent, errorHandler)
 }
 
 func evalDestination(ctx context.Context, destination *ResourceConfiguration, defaultName string, store ResourceStore, rc *renderContext, errorHandler TemplateErrorHandler) (string, otel.Partials) {
@@ -347,7 +420,7 @@ func evalDestination(ctx context.Context, destination *ResourceConfiguration, de
 	if dest.Spec.Disabled || destination.Disabled {
 		return destName, otel.NewPartials()
 	}
-	partials := destType.eval(dest, errorHandler)
+	partials := destType.eval(rc.resolveSecrets(ctx, dest, errorHandler), rc.agent, errorHandler)
 
 	if rc.pipelineTypeUsage != nil {
 		rc.pipelineTypeUsage.sources.setSupported(destName, partials)
@@ -404,7 +477,7 @@ func builtinRouteReceiver(ctx context.Context, store ResourceStore, errorHandler
 	}
 
 	src := NewSource(builtinRouteReceiverName, srcType.Name(), []Parameter{})
-	return src.Name(), srcType.eval(src, errorHandler)
+	return src.Name(), srcType.eval(src, AgentTemplateValues{}, errorHandler)
 }
 
 func findSourceAndType(ctx context.Context, source *ResourceConfiguration, defaultName string, store ResourceStore) (*Source, *SourceType, error) {
@@ -488,17 +561,30 @@ func processorNeedsRouteReceiver(processor ResourceConfiguration) bool {
 		processor.Type == "extract_metric"
 }
 
+// needsRouteReceiver returns true if any processor of the source needs the route receiver
+func (rc *ResourceConfiguration) needsRouteReceiver() bool {
+	for _, p := range rc.Processors {
+		if processorNeedsRouteReceiver(p) {
+			return true
+		}
+	}
+	return false
+}
+
 // ----------------------------------------------------------------------
 
 func (cs *ConfigurationSpec) validate(errors validation.Errors) {
 	cs.validateSpecFields(errors)
 	cs.validateRaw(errors)
 	cs.Selector.validate(errors)
+	if cs.Rollout != nil {
+		cs.Rollout.validate(errors)
+	}
 }
 
 func (cs *ConfigurationSpec) validateSpecFields(errors validation.Errors) {
 	if cs.Raw != "" {
-		if len(cs.Destinations) > 0 || len(cs.Sources) > 0 {
+		if len(cs.Destinations) > 0 || len(cs.Sources) > 0 || len(cs.Routes) > 0 || len(cs.Extensions) > 0 || len(cs.ServiceTelemetry) > 0 {
 			errors.Add(fmt.Errorf("configuration must specify raw or sources and destinations"))
 		}
 	}
@@ -522,6 +608,8 @@ func (cs *ConfigurationSpec) validateSourcesAndDestinations(ctx context.Context,
 	for _, destination := range cs.Destinations {
 		destination.validate(ctx, KindDestination, errors, store)
 	}
+	cs.validateRoutes(errors)
+	cs.validateExtensions(ctx, errors, store)
 }
 
 func (rc *ResourceConfiguration) localName(kind Kind, index int) string {
@@ -559,6 +647,11 @@ func (rc *ResourceConfiguration) validateParameters(ctx context.Context, resourc
 		errors.Add(err)
 		return
 	}
+	rc.validateParameterValues(resourceType, errors)
+}
+
+// validateParameterValues validates the values of the parameters using the parameter definitions of the resource type
+func (rc *ResourceConfiguration) validateParameterValues(resourceType *ResourceType, errors validation.Errors) {
 	// ensure parameters are valid
 	for _, parameter := range rc.Parameters {
 		if parameter.Name == "" {
@@ -612,16 +705,26 @@ func (c *Configuration) IndexFields(index search.Indexer) {
 	// add the type of configuration
 	index("type", string(c.Type()))
 
-	// add source, sourceType fields
+	// add source, sourceType, processor, processorType fields
 	for _, source := range c.Spec.Sources {
 		source.indexFields("source", "sourceType", index)
 	}
 
-	// add destination, destinationType fields
+	// add destination, destinationType, processor, processorType fields
 	for _, destination := range c.Spec.Destinations {
 		destination.indexFields("destination", "destinationType", index)
 	}
 
+	// add extensionType fields
+	for _, extension := range c.Spec.Extensions {
+		index("extensionType", extension.Type)
+	}
+
+	// add secret fields
+	for _, name := range c.SecretNames() {
+		index("secret", name)
+	}
+
 	// add pipeline fields
 	//
 	// TODO(andy): I was going to add pipeline:traces, pipeline:logs, and pipeline:metrics because I thought it would be a
@@ -634,17 +737,75 @@ func (c *Configuration) IndexFields(index search.Indexer) {
 func (rc *ResourceConfiguration) indexFields(resourceName string, resourceTypeName string, index search.Indexer) {
 	index(resourceName, rc.Name)
 	index(resourceTypeName, rc.Type)
+	rc.indexProcessors(index)
+}
+
+// RemoveReferences removes the sources, destinations, and processors that refer to the resource with the specified kind
+// and name and returns true if any were removed. It is used to remove the references to a resource before it is
+// deleted.
+func (c *Configuration) RemoveReferences(kind Kind, name string) bool {
+	var removed bool
+	switch kind {
+	case KindSource:
+		removed = c.removeSources(func(rc ResourceConfiguration) bool { return rc.Name == name })
+	case KindSourceType:
+		removed = c.removeSources(func(rc ResourceConfiguration) bool { return rc.Type == name })
+	case KindDestination:
+		removed = c.removeDestinations(func(rc ResourceConfiguration) bool { return rc.Name == name })
+	case KindDestinationType:
+		removed = c.removeDestinations(func(rc ResourceConfiguration) bool { return rc.Type == name })
+	case KindExtensionType:
+		c.Spec.Extensions, removed = removeResourceConfigurations(c.Spec.Extensions, func(rc ResourceConfiguration) bool { return rc.Type == name })
+	case KindProcessor, KindProcessorType:
+		for i := range c.Spec.Sources {
+			if c.Spec.Sources[i].removeProcessors(kind, name) {
+				removed = true
+			}
+		}
+		for i := range c.Spec.Destinations {
+			if c.Spec.Destinations[i].removeProcessors(kind, name) {
+				removed = true
+			}
+		}
+	}
+	return removed
+}
+
+// removeSources removes the sources that match and updates the routes to use the new names of the remaining sources
+func (c *Configuration) removeSources(match func(rc ResourceConfiguration) bool) bool {
+	names := localNamesAfterRemove(KindSource, c.Spec.Sources, match)
+	var removed bool
+	c.Spec.Sources, removed = removeResourceConfigurations(c.Spec.Sources, match)
+	if removed {
+		c.Spec.Routes = renameRoutes(c.Spec.Routes, names, nil)
+	}
+	return removed
+}
+
+// removeDestinations removes the destinations that match and updates the routes to use the new names of the remaining
+// destinations
+func (c *Configuration) removeDestinations(match func(rc ResourceConfiguration) bool) bool {
+	names := localNamesAfterRemove(KindDestination, c.Spec.Destinations, match)
+	var removed bool
+	c.Spec.Destinations, removed = removeResourceConfigurations(c.Spec.Destinations, match)
+	if removed {
+		c.Spec.Routes = renameRoutes(c.Spec.Routes, nil, names)
+	}
+	return removed
 }
 
 // Duplicate copies the value of the current configuration and returns
 // a duplicate with the new name.  It should be identical except for the
-// Metadata.Name, Metadata.ID, and Spec.Selector fields.
+// Metadata.Name, Metadata.ID, Metadata.Revision, Metadata.ResourceVersion,
+// and Spec.Selector fields.
 func (c *Configuration) Duplicate(name string) *Configuration {
 	copy := *c
 
 	// Change the metadata values
 	copy.Metadata.Name = name
 	copy.Metadata.ID = uuid.NewString()
+	copy.Metadata.Revision = 0
+	copy.Metadata.ResourceVersion = ""
 
 	// replace the configuration matchLabel
 	matchLabels := copy.Spec.Selector.MatchLabels
@@ -661,8 +822,8 @@ func (c *Configuration) Duplicate(name string) *Configuration {
 func (c *Configuration) Graph(ctx context.Context, store ResourceStore) (*graph.Graph, error) {
 	g := graph.NewGraph()
 
-	// lastNodes is a list of the last node for each source that will be connected to the destinations
-	lastNodes := make([]*graph.Node, 0, len(c.Spec.Sources))
+	// lastNodes is the last node for each source, keyed by source name, that will be connected to the destinations
+	lastNodes := make(map[string]*graph.Node, len(c.Spec.Sources))
 
 	pipelineUsage := c.determinePipelineTypeUsage(ctx, store)
 	g.Attributes["activeTypeFlags"] = pipelineUsage.ActiveFlags()
@@ -696,7 +857,7 @@ func (c *Configuration) Graph(ctx context.Context, store ResourceStore) (*graph.
 		g.AddIntermediate(p)
 		g.Connect(s, p)
 
-		lastNodes = append(lastNodes, p)
+		lastNodes[sourceName] = p
 	}
 
 	for i, destination := range c.Spec.Destinations {
@@ -715,8 +876,11 @@ func (c *Configuration) Graph(ctx context.Context, store ResourceStore) (*graph.
 			},
 		}
 		g.AddIntermediate(p)
-		for _, l := range lastNodes {
-			g.Connect(l, p)
+		for j, source := range c.Spec.Sources {
+			sourceName := source.localName(KindSource, j)
+			if c.Spec.Routed(sourceName, destinationName, "") {
+				g.Connect(lastNodes[sourceName], p)
+			}
 		}
 
 		attributes := graph.MakeAttributes(string(KindDestination), destination.Name)
@@ -0,0 +1,181 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"fmt"
+	"sort"
+
+	"golang.org/x/exp/slices"
+
+	"github.com/observiq/bindplane-op/model/otel"
+	"github.com/observiq/bindplane-op/model/validation"
+)
+
+// ConfigurationRoute connects sources of a Configuration to destinations. When a Configuration has routes, pipelines
+// are only created for the sources and destinations of the same route instead of connecting every source to every
+// destination.
+type ConfigurationRoute struct {
+	// Sources are the names of the sources sent by the route. Sources without a name are named by their position, e.g.
+	// source0 for the first source.
+	Sources []string `json:"sources" yaml:"sources" mapstructure:"sources"`
+
+	// Destinations are the names of the destinations that receive the sources. Destinations without a name are named by
+	// their position, e.g. destination0 for the first destination.
+	Destinations []string `json:"destinations" yaml:"destinations" mapstructure:"destinations"`
+
+	// Types are the types of telemetry sent by the route: logs, metrics, or traces. All types are sent if it is empty.
+	Types []otel.PipelineType `json:"types,omitempty" yaml:"types,omitempty" mapstructure:"types"`
+}
+
+// Includes returns true if the route sends telemetry of the pipeline type from the source to the destination. The
+// pipeline type is ignored if it is empty.
+func (r *ConfigurationRoute) Includes(sourceName, destinationName string, pipelineType otel.PipelineType) bool {
+	if !slices.Contains(r.Sources, sourceName) || !slices.Contains(r.Destinations, destinationName) {
+		return false
+	}
+	return pipelineType == "" || len(r.Types) == 0 || slices.Contains(r.Types, pipelineType)
+}
+
+// Routed returns true if telemetry of the pipeline type should be sent from the source to the destination. The
+// pipeline type is ignored if it is empty. Every source is sent to every destination if there are no Routes.
+func (cs *ConfigurationSpec) Routed(sourceName, destinationName string, pipelineType otel.PipelineType) bool {
+	if len(cs.Routes) == 0 {
+		return true
+	}
+
+	// metrics from the builtin route receiver are extracted from the logs of sources and follow those sources
+	if sourceName == builtinRouteSourceName {
+		for i, source := range cs.Sources {
+			if source.needsRouteReceiver() && cs.Routed(source.localName(KindSource, i), destinationName, pipelineType) {
+				return true
+			}
+		}
+		return false
+	}
+
+	for _, route := range cs.Routes {
+		if route.Includes(sourceName, destinationName, pipelineType) {
+			return true
+		}
+	}
+	return false
+}
+
+func (cs *ConfigurationSpec) validateRoutes(errors validation.Errors) {
+	if len(cs.Routes) == 0 {
+		return
+	}
+
+	// track which sources and destinations are used by a route
+	sources := map[string]bool{}
+	for i, source := range cs.Sources {
+		sources[source.localName(KindSource, i)] = false
+	}
+	destinations := map[string]bool{}
+	for i, destination := range cs.Destinations {
+		destinations[destination.localName(KindDestination, i)] = false
+	}
+
+	for i, route := range cs.Routes {
+		if len(route.Sources) == 0 || len(route.Destinations) == 0 {
+			errors.Warn(fmt.Errorf("route %d does not have any sources or destinations and will not send telemetry", i))
+		}
+		for _, name := range route.Sources {
+			if _, ok := sources[name]; !ok {
+				errors.Add(fmt.Errorf("route %d source %s is not a source of the configuration", i, name))
+				continue
+			}
+			sources[name] = true
+		}
+		for _, name := range route.Destinations {
+			if _, ok := destinations[name]; !ok {
+				errors.Add(fmt.Errorf("route %d destination %s is not a destination of the configuration", i, name))
+				continue
+			}
+			destinations[name] = true
+		}
+		for _, pipelineType := range route.Types {
+			switch pipelineType {
+			case otel.Logs, otel.Metrics, otel.Traces:
+			default:
+				errors.Add(fmt.Errorf("route %d type must be logs, metrics, or traces: %s", i, pipelineType))
+			}
+		}
+	}
+
+	warnUnrouted(KindSource, sources, errors)
+	warnUnrouted(KindDestination, destinations, errors)
+}
+
+// warnUnrouted warns about the sources or destinations that are not used by any route
+func warnUnrouted(kind Kind, routed map[string]bool, errors validation.Errors) {
+	var names []string
+	for name, ok := range routed {
+		if !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		errors.Warn(fmt.Errorf("%s %s is not in any route and will not be used", kind, name))
+	}
+}
+
+// localNamesAfterRemove returns the local name of each resource that is not removed, keyed by its local name before the
+// resources that match are removed. Resources without a name are named by their position, so their names change when
+// resources before them are removed.
+func localNamesAfterRemove(kind Kind, list []ResourceConfiguration, match func(rc ResourceConfiguration) bool) map[string]string {
+	names := map[string]string{}
+	index := 0
+	for i, rc := range list {
+		if match(rc) {
+			continue
+		}
+		names[rc.localName(kind, i)] = rc.localName(kind, index)
+		index++
+	}
+	return names
+}
+
+// renameRoutes replaces the names of the sources and destinations in the routes using the specified maps of old names
+// to new names and removes the names that are not in the maps. Names are unchanged if the map is nil. Routes are kept
+// even if they no longer have any sources or destinations so that the remaining sources are not sent to every
+// destination.
+func renameRoutes(routes []ConfigurationRoute, sourceNames, destinationNames map[string]string) []ConfigurationRoute {
+	if len(routes) == 0 {
+		return routes
+	}
+	result := make([]ConfigurationRoute, 0, len(routes))
+	for _, route := range routes {
+		route.Sources = renameRouteNames(route.Sources, sourceNames)
+		route.Destinations = renameRouteNames(route.Destinations, destinationNames)
+		result = append(result, route)
+	}
+	return result
+}
+
+func renameRouteNames(names []string, renamed map[string]string) []string {
+	if renamed == nil {
+		return names
+	}
+	result := make([]string, 0, len(names))
+	for _, name := range names {
+		if newName, ok := renamed[name]; ok {
+			result = append(result, newName)
+		}
+	}
+	return result
+}
@@ -0,0 +1,189 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"sort"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/observiq/bindplane-op/model/otel"
+	"github.com/observiq/bindplane-op/model/validation"
+)
+
+func TestConfigurationSpecRouted(t *testing.T) {
+	spec := ConfigurationSpec{
+		Sources: []ResourceConfiguration{
+			{Name: "firewall"},
+			{ParameterizedSpec: ParameterizedSpec{Type: "hostmetrics"}},
+			{Name: "nginx", ParameterizedSpec: ParameterizedSpec{Processors: []ResourceConfiguration{{ParameterizedSpec: ParameterizedSpec{Type: "count_logs"}}}}},
+		},
+		Destinations: []ResourceConfiguration{
+			{Name: "siem"},
+			{Name: "prometheus"},
+		},
+	}
+
+	// every source is sent to every destination without routes
+	require.True(t, spec.Routed("firewall", "prometheus", otel.Metrics))
+
+	spec.Routes = []ConfigurationRoute{
+		{Sources: []string{"firewall"}, Destinations: []string{"siem"}},
+		{Sources: []string{"source1", "nginx"}, Destinations: []string{"prometheus"}, Types: []otel.PipelineType{otel.Metrics}},
+	}
+
+	tests := []struct {
+		source       string
+		destination  string
+		pipelineType otel.PipelineType
+		expect       bool
+	}{
+		{"firewall", "siem", otel.Logs, true},
+		{"firewall", "siem", otel.Traces, true},
+		{"firewall", "prometheus", otel.Logs, false},
+		{"source1", "prometheus", otel.Metrics, true},
+		{"source1", "prometheus", otel.Logs, false},
+		{"source1", "prometheus", "", true},
+		{"source1", "siem", "", false},
+		{builtinRouteSourceName, "prometheus", otel.Metrics, true},
+		{builtinRouteSourceName, "siem", otel.Metrics, false},
+	}
+	for _, test := range tests {
+		require.Equal(t, test.expect, spec.Routed(test.source, test.destination, test.pipelineType), "%s => %s %s", test.source, test.destination, test.pipelineType)
+	}
+}
+
+func TestConfigurationSpecValidateRoutes(t *testing.T) {
+	spec := ConfigurationSpec{
+		Sources: []ResourceConfiguration{
+			{Name: "firewall"},
+			{ParameterizedSpec: ParameterizedSpec{Type: "hostmetrics"}},
+		},
+		Destinations: []ResourceConfiguration{
+			{Name: "siem"},
+			{Name: "prometheus"},
+		},
+		Routes: []ConfigurationRoute{
+			{Sources: []string{"firewall"}, Destinations: []string{"siem"}},
+			{Sources: []string{"source1"}, Destinations: []string{"prometheus"}, Types: []otel.PipelineType{otel.Metrics}},
+		},
+	}
+
+	errs := validation.NewErrors()
+	spec.validateRoutes(errs)
+	require.NoError(t, errs.Result())
+	require.Empty(t, errs.Warnings())
+
+	spec.Routes = []ConfigurationRoute{
+		{Sources: []string{"firewall", "source2"}, Destinations: []string{"splunk"}, Types: []otel.PipelineType{"events"}},
+		{Sources: []string{"firewall"}},
+	}
+	errs = validation.NewErrors()
+	spec.validateRoutes(errs)
+	require.Error(t, errs.Result())
+	require.Contains(t, errs.Result().Error(), "route 0 source source2 is not a source of the configuration")
+	require.Contains(t, errs.Result().Error(), "route 0 destination splunk is not a destination of the configuration")
+	require.Contains(t, errs.Result().Error(), "route 0 type must be logs, metrics, or traces: events")
+	require.Contains(t, errs.Warnings(), "route 1 does not have any sources or destinations")
+	require.Contains(t, errs.Warnings(), "Source source1 is not in any route")
+	require.Contains(t, errs.Warnings(), "Destination prometheus is not in any route")
+}
+
+func TestConfigurationRenderRoutes(t *testing.T) {
+	store := newTestResourceStore()
+	config := newTestConfiguration()
+
+	macos := testResource[*SourceType](t, "sourcetype-macos.yaml")
+	store.sourceTypes[macos.Name()] = macos
+	googleCloudType := testResource[*DestinationType](t, "destinationtype-googlecloud.yaml")
+	store.destinationTypes[googleCloudType.Name()] = googleCloudType
+	cabinType := testResource[*DestinationType](t, "destinationtype-cabin.yaml")
+	store.destinationTypes[cabinType.Name()] = cabinType
+	googleCloud := testResource[*Destination](t, "destination-googlecloud.yaml")
+	store.destinations[googleCloud.Name()] = googleCloud
+	cabin := testResource[*Destination](t, "destination-cabin.yaml")
+	store.destinations[cabin.Name()] = cabin
+	transposerType := testResource[*ProcessorType](t, "processortype-resourceattributetransposer.yaml")
+	store.processorTypes[transposerType.Name()] = transposerType
+
+	configuration := testResource[*Configuration](t, "configuration-macos-multi-destination.yaml")
+	configuration.Spec.Routes = []ConfigurationRoute{
+		{Sources: []string{"source0"}, Destinations: []string{"googlecloud"}, Types: []otel.PipelineType{otel.Metrics}},
+	}
+
+	pipelineNames := func(t *testing.T) []string {
+		otelConfiguration, err := configuration.otelConfiguration(context.TODO(), nil, false, config, store)
+		require.NoError(t, err)
+		names := []string{}
+		for name := range otelConfiguration.Service.Pipelines {
+			names = append(names, string(name))
+		}
+		sort.Strings(names)
+		return names
+	}
+	require.Equal(t, []string{"metrics/source0__googlecloud"}, pipelineNames(t))
+
+	configuration.Spec.Routes[0].Types = nil
+	require.Equal(t, []string{"logs/source0__googlecloud", "metrics/source0__googlecloud"}, pipelineNames(t))
+
+	// the graph only connects routed sources and destinations
+	g, err := configuration.Graph(context.TODO(), store)
+	require.NoError(t, err)
+	edges := []string{}
+	for _, edge := range g.Edges {
+		edges = append(edges, edge.ID)
+	}
+	require.ElementsMatch(t, []string{
+		"source/source0|source/source0/processors",
+		"source/source0/processors|destination/googlecloud/processors",
+		"destination/googlecloud/processors|destination/googlecloud",
+		"destination/cabin-production-logs/processors|destination/cabin-production-logs",
+	}, edges)
+}
+
+func TestConfigurationRemoveReferencesRoutes(t *testing.T) {
+	c := NewConfigurationWithSpec("routes", ConfigurationSpec{
+		Sources: []ResourceConfiguration{
+			{Name: "firewall"},
+			{ParameterizedSpec: ParameterizedSpec{Type: "macos"}},
+			{ParameterizedSpec: ParameterizedSpec{Type: "hostmetrics"}},
+		},
+		Destinations: []ResourceConfiguration{
+			{Name: "siem"},
+			{Name: "prometheus"},
+		},
+		Routes: []ConfigurationRoute{
+			{Sources: []string{"firewall", "source1"}, Destinations: []string{"siem"}},
+			{Sources: []string{"source2"}, Destinations: []string{"prometheus"}},
+		},
+	})
+
+	// unnamed sources after the removed source are renamed for their new position
+	require.True(t, c.RemoveReferences(KindSource, "firewall"))
+	require.Equal(t, []ConfigurationRoute{
+		{Sources: []string{"source0"}, Destinations: []string{"siem"}},
+		{Sources: []string{"source1"}, Destinations: []string{"prometheus"}},
+	}, c.Spec.Routes)
+
+	// routes are kept without destinations so that their sources are not sent to every destination
+	require.True(t, c.RemoveReferences(KindDestination, "siem"))
+	require.Equal(t, []ConfigurationRoute{
+		{Sources: []string{"source0"}, Destinations: []string{}},
+		{Sources: []string{"source1"}, Destinations: []string{"prometheus"}},
+	}, c.Spec.Routes)
+	require.False(t, c.Spec.Routed("source0", "prometheus", ""))
+}
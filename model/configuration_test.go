@@ -16,6 +16,7 @@ package model
 
 import (
 	"context"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
@@ -46,6 +47,7 @@ func fileResource[T Resource](t *testing.T, path string) T {
 
 type testConfiguration struct {
 	bindplaneURL string
+	secretsKey   string
 }
 
 func newTestConfiguration() BindPlaneConfiguration {
@@ -56,6 +58,10 @@ func (c *testConfiguration) BindPlaneURL() string {
 	return c.bindplaneURL
 }
 
+func (c *testConfiguration) SecretsEncryptionKey() string {
+	return c.secretsKey
+}
+
 var _ BindPlaneConfiguration = (*testConfiguration)(nil)
 
 type testResourceStore struct {
@@ -65,6 +71,8 @@ type testResourceStore struct {
 	processorTypes   map[string]*ProcessorType
 	destinations     map[string]*Destination
 	destinationTypes map[string]*DestinationType
+	extensionTypes   map[string]*ExtensionType
+	secrets          map[string]*Secret
 }
 
 func newTestResourceStore() *testResourceStore {
@@ -75,6 +83,8 @@ func newTestResourceStore() *testResourceStore {
 		processorTypes:   map[string]*ProcessorType{},
 		destinations:     map[string]*Destination{},
 		destinationTypes: map[string]*DestinationType{},
+		extensionTypes:   map[string]*ExtensionType{},
+		secrets:          map[string]*Secret{},
 	}
 }
 
@@ -98,6 +108,12 @@ func (s *testResourceStore) Destination(ctx context.Context, name string) (*Dest
 func (s *testResourceStore) DestinationType(ctx context.Context, name string) (*DestinationType, error) {
 	return s.destinationTypes[name], nil
 }
+func (s *testResourceStore) ExtensionType(ctx context.Context, name string) (*ExtensionType, error) {
+	return s.extensionTypes[name], nil
+}
+func (s *testResourceStore) Secret(ctx context.Context, name string) (*Secret, error) {
+	return s.secrets[name], nil
+}
 
 func TestParseConfiguration(t *testing.T) {
 	path := filepath.Join("testfiles", "configuration-raw.yaml")
@@ -345,6 +361,72 @@ service:
 	require.Equal(t, expect, result)
 }
 
+func TestEvalConfigurationConnectors(t *testing.T) {
+	store := newTestResourceStore()
+	config := newTestConfiguration()
+
+	otlp := testResource[*SourceType](t, "sourcetype-otlp.yaml")
+	store.sourceTypes[otlp.Name()] = otlp
+
+	otlpDestinationType := testResource[*DestinationType](t, "destinationtype-otlp.yaml")
+	store.destinationTypes[otlpDestinationType.Name()] = otlpDestinationType
+
+	spanmetrics := testResource[*ProcessorType](t, "processortype-spanmetrics.yaml")
+	store.processorTypes[spanmetrics.Name()] = spanmetrics
+
+	configuration := testResource[*Configuration](t, "configuration-otlp-spanmetrics.yaml")
+	result, err := configuration.Render(context.TODO(), nil, config, store)
+	require.NoError(t, err)
+
+	expect := strings.TrimLeft(`
+receivers:
+    otlp/source0:
+        protocols:
+            grpc: null
+            http: null
+processors:
+    batch/destination0: null
+exporters:
+    otlp/destination0:
+        endpoint: otelcol:4317
+connectors:
+    spanmetrics/source0__processor0:
+        namespace: traces.spanmetrics
+service:
+    pipelines:
+        logs/source0__destination0:
+            receivers:
+                - otlp/source0
+            processors:
+                - batch/destination0
+            exporters:
+                - otlp/destination0
+        metrics/source0__destination0:
+            receivers:
+                - otlp/source0
+                - spanmetrics/source0__processor0
+            processors:
+                - batch/destination0
+            exporters:
+                - otlp/destination0
+        traces/source0___connectors:
+            receivers:
+                - otlp/source0
+            processors: []
+            exporters:
+                - spanmetrics/source0__processor0
+        traces/source0__destination0:
+            receivers:
+                - otlp/source0
+            processors:
+                - batch/destination0
+            exporters:
+                - otlp/destination0
+`, "\n")
+
+	require.Equal(t, expect, result)
+}
+
 func TestEvalConfiguration4(t *testing.T) {
 	store := newTestResourceStore()
 	config := newTestConfiguration()
@@ -1691,6 +1773,73 @@ func TestDuplicate(t *testing.T) {
 	})
 }
 
+func TestConfigurationRemoveReferences(t *testing.T) {
+	newConfiguration := func() *Configuration {
+		return NewConfigurationWithSpec("configuration", ConfigurationSpec{
+			Sources: []ResourceConfiguration{
+				{Name: "macos-1"},
+				{
+					ParameterizedSpec: ParameterizedSpec{
+						Type: "macos",
+						Processors: []ResourceConfiguration{
+							{Name: "batch-1"},
+							{ParameterizedSpec: ParameterizedSpec{Type: "batch"}},
+						},
+					},
+				},
+			},
+			Destinations: []ResourceConfiguration{
+				{Name: "cabin-1"},
+				{ParameterizedSpec: ParameterizedSpec{Type: "cabin", Processors: []ResourceConfiguration{{Name: "batch-1"}}}},
+			},
+		})
+	}
+
+	tests := []struct {
+		kind         Kind
+		name         string
+		expectSource []string
+		expectDest   []string
+		expectRemove bool
+	}{
+		{kind: KindSource, name: "macos-1", expectSource: []string{"macos"}, expectDest: []string{"cabin-1", "cabin"}, expectRemove: true},
+		{kind: KindSourceType, name: "macos", expectSource: []string{"macos-1"}, expectDest: []string{"cabin-1", "cabin"}, expectRemove: true},
+		{kind: KindDestination, name: "cabin-1", expectSource: []string{"macos-1", "macos"}, expectDest: []string{"cabin"}, expectRemove: true},
+		{kind: KindDestinationType, name: "cabin", expectSource: []string{"macos-1", "macos"}, expectDest: []string{"cabin-1"}, expectRemove: true},
+		{kind: KindSource, name: "missing", expectSource: []string{"macos-1", "macos"}, expectDest: []string{"cabin-1", "cabin"}, expectRemove: false},
+	}
+	names := func(list []ResourceConfiguration) []string {
+		result := []string{}
+		for _, rc := range list {
+			if rc.Name != "" {
+				result = append(result, rc.Name)
+			} else {
+				result = append(result, rc.Type)
+			}
+		}
+		return result
+	}
+	for _, test := range tests {
+		t.Run(fmt.Sprintf("%s %s", test.kind, test.name), func(t *testing.T) {
+			c := newConfiguration()
+			require.Equal(t, test.expectRemove, c.RemoveReferences(test.kind, test.name))
+			require.Equal(t, test.expectSource, names(c.Spec.Sources))
+			require.Equal(t, test.expectDest, names(c.Spec.Destinations))
+		})
+	}
+
+	t.Run("processors are removed from sources and destinations", func(t *testing.T) {
+		c := newConfiguration()
+		require.True(t, c.RemoveReferences(KindProcessor, "batch-1"))
+		require.Equal(t, []string{"batch"}, names(c.Spec.Sources[1].Processors))
+		require.Empty(t, c.Spec.Destinations[1].Processors)
+
+		require.True(t, c.RemoveReferences(KindProcessorType, "batch"))
+		require.Empty(t, c.Spec.Sources[1].Processors)
+		require.False(t, c.RemoveReferences(KindProcessorType, "batch"))
+	})
+}
+
 func TestConfigurationType(t *testing.T) {
 	t.Run("raw configuration", func(t *testing.T) {
 		c := NewConfigurationWithSpec("raw-config", ConfigurationSpec{
@@ -1799,3 +1948,69 @@ service:
 
 	require.Equal(t, expect, result)
 }
+
+func TestEvalConfigurationAgentTemplateValues(t *testing.T) {
+	store := newTestResourceStore()
+	config := newTestConfiguration()
+
+	sourceType := NewSourceTypeWithSpec("otlp", ResourceTypeSpec{
+		Parameters: []ParameterDefinition{
+			{Name: "service", Type: "string", Default: "default"},
+		},
+		Logs: ResourceTypeOutput{
+			Receivers: `
+- otlp:
+    endpoint: {{ .Agent.Hostname }}:4317
+    platform: {{ .Agent.Platform }}
+    datacenter: {{ .Agent.Labels.datacenter }}
+    service: "{{ .service }}"
+`,
+		},
+	})
+	store.sourceTypes[sourceType.Name()] = sourceType
+	destinationType := NewDestinationTypeWithSpec("logging", ResourceTypeSpec{
+		Logs: ResourceTypeOutput{
+			Exporters: "- logging:\n",
+		},
+	})
+	store.destinationTypes[destinationType.Name()] = destinationType
+
+	configuration := NewConfigurationWithSpec("per-agent", ConfigurationSpec{
+		Sources: []ResourceConfiguration{
+			{ParameterizedSpec: ParameterizedSpec{Type: "otlp", Parameters: []Parameter{{Name: "service", Value: "{{ .Agent.Name }}-{{ .Agent.Labels.env }}"}}}},
+		},
+		Destinations: []ResourceConfiguration{{ParameterizedSpec: ParameterizedSpec{Type: "logging"}}},
+	})
+
+	agent := &Agent{
+		ID:       "01ARZ3NDEKTSV4RRFFQ69G5FAV",
+		Name:     "agent-1",
+		HostName: "host-1",
+		Platform: "linux",
+		Labels:   MakeLabels(),
+	}
+	agent.Labels.Set["datacenter"] = "east"
+	agent.Labels.Set["env"] = "prod"
+
+	result, err := configuration.Render(context.TODO(), agent, config, store)
+	require.NoError(t, err)
+	require.Contains(t, result, strings.TrimLeft(`
+    otlp/source0:
+        datacenter: east
+        endpoint: host-1:4317
+        platform: linux
+        service: agent-1-prod
+`, "\n"))
+
+	// labels that the agent doesn't have are empty
+	delete(agent.Labels.Set, "datacenter")
+	result, err = configuration.Preview(context.TODO(), agent, config, store)
+	require.NoError(t, err)
+	require.Contains(t, result, "datacenter: null\n")
+
+	// values are empty when rendered without an agent
+	result, err = configuration.Render(context.TODO(), nil, config, store)
+	require.NoError(t, err)
+	require.Contains(t, result, "endpoint: :4317")
+	require.Contains(t, result, "service: '-'")
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/observiq/bindplane-op/internal/store/search"
 	"github.com/observiq/bindplane-op/model/otel"
 	"github.com/observiq/bindplane-op/model/validation"
 )
@@ -125,3 +126,26 @@ func (d *Destination) PrintableFieldValue(title string) string {
 		return "-"
 	}
 }
+
+// RemoveReferences removes the processors that refer to the Processor or ProcessorType with the specified kind and
+// name and returns true if any were removed. It is used to remove the references to a resource before it is deleted.
+func (d *Destination) RemoveReferences(kind Kind, name string) bool {
+	return d.Spec.removeProcessors(kind, name)
+}
+
+// ----------------------------------------------------------------------
+// Indexed
+
+// IndexFields returns a map of field name to field value to be stored in the index
+func (d *Destination) IndexFields(index search.Indexer) {
+	d.ResourceMeta.IndexFields(index)
+
+	// add the type of destination
+	index("type", d.ResourceTypeName())
+
+	// add processor, processorType fields
+	d.Spec.indexProcessors(index)
+
+	// add secret fields
+	d.Spec.indexSecrets(index)
+}
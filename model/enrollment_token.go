@@ -0,0 +1,172 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/observiq/bindplane-op/model/validation"
+)
+
+// EnrollmentToken is the resource used by agents to enroll with BindPlane. Agents present the token, which is the ID of
+// the resource, as their secret key when they first connect and are issued their own secret key.
+type EnrollmentToken struct {
+	ResourceMeta `yaml:",inline" json:",inline" mapstructure:",squash"`
+	Spec         EnrollmentTokenSpec `json:"spec" yaml:"spec" mapstructure:"spec"`
+}
+
+// EnrollmentTokenSpec is the spec for an EnrollmentToken
+type EnrollmentTokenSpec struct {
+	// ExpiresAt is an RFC3339 encoded time after which the token can no longer be used. Tokens without ExpiresAt do not
+	// expire.
+	ExpiresAt string `json:"expiresAt,omitempty" yaml:"expiresAt,omitempty" mapstructure:"expiresAt"`
+
+	// MaxUses is the maximum number of agents that can enroll with the token. Tokens without MaxUses can be used by any
+	// number of agents.
+	MaxUses int `json:"maxUses,omitempty" yaml:"maxUses,omitempty" mapstructure:"maxUses"`
+
+	// Labels are added to the labels of the agents that enroll with the token. Labels specified by the agent take
+	// precedence.
+	Labels map[string]string `json:"labels,omitempty" yaml:"labels,omitempty" mapstructure:"labels"`
+}
+
+var _ Resource = (*EnrollmentToken)(nil)
+
+// NewEnrollmentToken creates a new EnrollmentToken with the specified name and spec
+func NewEnrollmentToken(name string, spec EnrollmentTokenSpec) *EnrollmentToken {
+	return &EnrollmentToken{
+		ResourceMeta: ResourceMeta{
+			APIVersion: V1,
+			Kind:       KindEnrollmentToken,
+			Metadata: Metadata{
+				Name: name,
+			},
+		},
+		Spec: spec,
+	}
+}
+
+// GetKind returns "EnrollmentToken"
+func (t *EnrollmentToken) GetKind() Kind {
+	return KindEnrollmentToken
+}
+
+// Token returns the value presented by agents to enroll with this EnrollmentToken
+func (t *EnrollmentToken) Token() string {
+	return t.ID()
+}
+
+// ExpiresAt returns the time when the token expires or nil if it does not expire or the time is invalid
+func (t *EnrollmentToken) ExpiresAt() *time.Time {
+	if t.Spec.ExpiresAt == "" {
+		return nil
+	}
+	expiresAt, err := time.Parse(time.RFC3339, t.Spec.ExpiresAt)
+	if err != nil {
+		return nil
+	}
+	return &expiresAt
+}
+
+// Expired returns true if the token has expired at the specified time
+func (t *EnrollmentToken) Expired(now time.Time) bool {
+	expiresAt := t.ExpiresAt()
+	return expiresAt != nil && !now.Before(*expiresAt)
+}
+
+// Available returns an error if an agent cannot enroll with the token at the specified time because it has expired or
+// has already been used by MaxUses agents.
+func (t *EnrollmentToken) Available(now time.Time, uses int) error {
+	if t.Expired(now) {
+		return fmt.Errorf("enrollment token %s expired at %s", t.Name(), t.Spec.ExpiresAt)
+	}
+	if t.Spec.MaxUses > 0 && uses >= t.Spec.MaxUses {
+		return fmt.Errorf("enrollment token %s has been used by the maximum of %d agents", t.Name(), t.Spec.MaxUses)
+	}
+	return nil
+}
+
+// AgentLabels returns the labels added to agents that enroll with the token. Invalid labels are ignored.
+func (t *EnrollmentToken) AgentLabels() Labels {
+	labels, _ := LabelsFromMap(t.Spec.Labels)
+	return labels
+}
+
+// ----------------------------------------------------------------------
+// validation
+
+// Validate ensures that the ExpiresAt, MaxUses, and Labels of the EnrollmentToken are valid
+func (t *EnrollmentToken) Validate() (warnings string, errors error) {
+	errs := validation.NewErrors()
+	t.validate(errs)
+	return errs.Warnings(), errs.Result()
+}
+
+// ValidateWithStore validates the EnrollmentToken. No additional validation requires the store.
+func (t *EnrollmentToken) ValidateWithStore(ctx context.Context, store ResourceStore) (warnings string, errors error) {
+	return t.Validate()
+}
+
+func (t *EnrollmentToken) validate(errs validation.Errors) {
+	t.ResourceMeta.validate(errs)
+	t.Spec.validate(errs)
+}
+
+func (s *EnrollmentTokenSpec) validate(errs validation.Errors) {
+	if s.ExpiresAt != "" {
+		if _, err := time.Parse(time.RFC3339, s.ExpiresAt); err != nil {
+			errs.Add(fmt.Errorf("enrollment-token .spec.expiresAt must be an RFC3339 time: %w", err))
+		}
+	}
+	if s.MaxUses < 0 {
+		errs.Add(errors.New("enrollment-token .spec.maxUses cannot be negative"))
+	}
+	if _, err := LabelsFromMap(s.Labels); err != nil {
+		errs.Add(fmt.Errorf("enrollment-token .spec.labels are invalid: %w", err))
+	}
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (t *EnrollmentToken) PrintableFieldTitles() []string {
+	return []string{"Name", "Expires", "Max Uses", "Labels"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (t *EnrollmentToken) PrintableFieldValue(title string) string {
+	switch title {
+	case "Name":
+		return t.Name()
+	case "Expires":
+		if t.Spec.ExpiresAt == "" {
+			return "never"
+		}
+		return t.Spec.ExpiresAt
+	case "Max Uses":
+		if t.Spec.MaxUses == 0 {
+			return "unlimited"
+		}
+		return fmt.Sprintf("%d", t.Spec.MaxUses)
+	case "Labels":
+		return t.AgentLabels().String()
+	default:
+		return "-"
+	}
+}
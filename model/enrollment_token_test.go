@@ -0,0 +1,116 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestEnrollmentTokenParse(t *testing.T) {
+	token := testResource[*EnrollmentToken](t, "enrollmenttoken-production.yaml")
+	require.Equal(t, KindEnrollmentToken, token.GetKind())
+	require.Equal(t, "production", token.Name())
+	require.Equal(t, 10, token.Spec.MaxUses)
+	require.Equal(t, "env=production", token.AgentLabels().String())
+	require.Equal(t, time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC), token.ExpiresAt().UTC())
+}
+
+func TestEnrollmentTokenAvailable(t *testing.T) {
+	now := time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name      string
+		spec      EnrollmentTokenSpec
+		uses      int
+		expectErr string
+	}{
+		{
+			name: "no limits",
+			spec: EnrollmentTokenSpec{},
+			uses: 1000,
+		},
+		{
+			name: "not expired",
+			spec: EnrollmentTokenSpec{ExpiresAt: "2023-01-01T00:00:01Z"},
+		},
+		{
+			name:      "expired",
+			spec:      EnrollmentTokenSpec{ExpiresAt: "2023-01-01T00:00:00Z"},
+			expectErr: "enrollment token test expired at 2023-01-01T00:00:00Z",
+		},
+		{
+			name: "uses remaining",
+			spec: EnrollmentTokenSpec{MaxUses: 2},
+			uses: 1,
+		},
+		{
+			name:      "no uses remaining",
+			spec:      EnrollmentTokenSpec{MaxUses: 2},
+			uses:      2,
+			expectErr: "enrollment token test has been used by the maximum of 2 agents",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			token := NewEnrollmentToken("test", test.spec)
+			err := token.Available(now, test.uses)
+			if test.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.expectErr)
+		})
+	}
+}
+
+func TestEnrollmentTokenValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		spec      EnrollmentTokenSpec
+		expectErr bool
+	}{
+		{
+			name: "valid",
+			spec: EnrollmentTokenSpec{ExpiresAt: "2030-01-01T00:00:00Z", MaxUses: 1, Labels: map[string]string{"env": "test"}},
+		},
+		{
+			name:      "invalid expiresAt",
+			spec:      EnrollmentTokenSpec{ExpiresAt: "tomorrow"},
+			expectErr: true,
+		},
+		{
+			name:      "negative maxUses",
+			spec:      EnrollmentTokenSpec{MaxUses: -1},
+			expectErr: true,
+		},
+		{
+			name:      "invalid labels",
+			spec:      EnrollmentTokenSpec{Labels: map[string]string{"env": "not valid"}},
+			expectErr: true,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewEnrollmentToken("test", test.spec).Validate()
+			if test.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/go-multierror"
+	"github.com/observiq/bindplane-op/model/otel"
+	"github.com/observiq/bindplane-op/model/validation"
+)
+
+// ExtensionType is a ResourceType used to define extensions that are added to a Configuration
+type ExtensionType struct {
+	ResourceType `yaml:",inline" json:",inline" mapstructure:",squash"`
+}
+
+// NewExtensionType creates a new extension-type with the specified name,
+func NewExtensionType(name string, parameters []ParameterDefinition) *ExtensionType {
+	return NewExtensionTypeWithSpec(name, ResourceTypeSpec{
+		Parameters: parameters,
+	})
+}
+
+// NewExtensionTypeWithSpec creates a new extension-type with the specified name and spec.
+func NewExtensionTypeWithSpec(name string, spec ResourceTypeSpec) *ExtensionType {
+	return &ExtensionType{
+		ResourceType: ResourceType{
+			ResourceMeta: ResourceMeta{
+				APIVersion: V1,
+				Kind:       KindExtensionType,
+				Metadata: Metadata{
+					Name: name,
+				},
+			},
+			Spec: spec,
+		},
+	}
+}
+
+// GetKind returns "ExtensionType"
+func (s *ExtensionType) GetKind() Kind {
+	return KindExtensionType
+}
+
+// ----------------------------------------------------------------------
+
+// extension is an extension of a Configuration. There is no Extension resource, so extensions are always defined
+// inline and the name is used to make the ids of the extensions unique.
+type extension struct {
+	name string
+	spec ParameterizedSpec
+}
+
+var _ parameterizedResource = (*extension)(nil)
+
+func newExtension(rc *ResourceConfiguration, index int) *extension {
+	return &extension{
+		name: extensionName(rc, index),
+		spec: rc.ParameterizedSpec,
+	}
+}
+
+// extensionName returns the name of the extension or extension0, extension1, etc. based on its position in the
+// Configuration if it doesn't have a name.
+func extensionName(rc *ResourceConfiguration, index int) string {
+	if rc.Name != "" {
+		return rc.Name
+	}
+	return fmt.Sprintf("extension%d", index)
+}
+
+// Name returns the name of the extension in the Configuration, e.g. extension0
+func (e *extension) Name() string {
+	return e.name
+}
+
+// ResourceTypeName returns the name of the ExtensionType
+func (e *extension) ResourceTypeName() string {
+	return e.spec.Type
+}
+
+// ResourceParameters returns the parameters of the extension
+func (e *extension) ResourceParameters() []Parameter {
+	return e.spec.Parameters
+}
+
+// ComponentID provides a unique component id for the specified component name
+func (e *extension) ComponentID(name string) otel.ComponentID {
+	return otel.UniqueComponentID(name, e.spec.Type, e.name)
+}
+
+// evalExtensions evaluates the extensions of the configuration with the values of the agent and adds them to the otel
+// configuration
+func (c *Configuration) evalExtensions(ctx context.Context, configuration *otel.Configuration, store ResourceStore, rc *renderContext) (err error) {
+	errorHandler := func(e error) {
+		if e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+
+	for i, ext := range c.Spec.Extensions {
+		if ext.Disabled {
+			continue
+		}
+		extensionType, err := findExtensionType(ctx, &ext, store)
+		if err != nil {
+			errorHandler(err)
+			continue
+		}
+		ext := ext
+		extension := rc.resolveSecrets(ctx, newExtension(&ext, i), errorHandler)
+		configuration.AddExtensions(extensionType.evalExtensions(extension, rc.agent, errorHandler))
+	}
+	return err
+}
+
+func findExtensionType(ctx context.Context, rc *ResourceConfiguration, store ResourceStore) (*ExtensionType, error) {
+	extensionType, err := store.ExtensionType(ctx, rc.Type)
+	if err == nil && extensionType == nil {
+		err = fmt.Errorf("unknown %s: %s", KindExtensionType, rc.Type)
+	}
+	return extensionType, err
+}
+
+// validateExtensions validates the extensions of the configuration
+func (cs *ConfigurationSpec) validateExtensions(ctx context.Context, errors validation.Errors, store ResourceStore) {
+	names := map[string]bool{}
+	for i, rc := range cs.Extensions {
+		name := extensionName(&rc, i)
+		if names[name] {
+			errors.Add(fmt.Errorf("extension name %s is not unique", name))
+		}
+		names[name] = true
+
+		if rc.Type == "" {
+			errors.Add(fmt.Errorf("all extensions must have a type"))
+			continue
+		}
+		if len(rc.Processors) > 0 {
+			errors.Add(fmt.Errorf("extension %s cannot have processors", name))
+		}
+		for _, parameter := range rc.Parameters {
+			if parameter.Name == "" {
+				errors.Add(fmt.Errorf("all extension parameters must have a name"))
+			}
+		}
+		extensionType, err := findExtensionType(ctx, &rc, store)
+		if err != nil {
+			errors.Add(err)
+			continue
+		}
+		rc.validateParameterValues(&extensionType.ResourceType, errors)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testExtensionType() *ExtensionType {
+	return NewExtensionTypeWithSpec("file_storage", ResourceTypeSpec{
+		Parameters: []ParameterDefinition{
+			{Name: "directory", Type: "string", Default: "/var/lib/storage"},
+		},
+		Extensions: `
+- file_storage:
+    directory: {{ .directory }}
+`,
+	})
+}
+
+func TestEvalConfigurationExtensions(t *testing.T) {
+	store := newTestResourceStore()
+	config := newTestConfiguration()
+
+	otlp := testResource[*SourceType](t, "sourcetype-otlp.yaml")
+	store.sourceTypes[otlp.Name()] = otlp
+	otlpDestinationType := testResource[*DestinationType](t, "destinationtype-otlp.yaml")
+	store.destinationTypes[otlpDestinationType.Name()] = otlpDestinationType
+	fileStorage := testExtensionType()
+	store.extensionTypes[fileStorage.Name()] = fileStorage
+
+	configuration := NewConfigurationWithSpec("otlp", ConfigurationSpec{
+		Sources:      []ResourceConfiguration{{ParameterizedSpec: ParameterizedSpec{Type: "otlp"}}},
+		Destinations: []ResourceConfiguration{{ParameterizedSpec: ParameterizedSpec{Type: "otlp"}}},
+		Extensions: []ResourceConfiguration{
+			{ParameterizedSpec: ParameterizedSpec{Type: "file_storage"}},
+			{Name: "queue", ParameterizedSpec: ParameterizedSpec{Type: "file_storage", Parameters: []Parameter{{Name: "directory", Value: "/tmp/queue"}}}},
+			{Name: "disabled", ParameterizedSpec: ParameterizedSpec{Type: "file_storage", Disabled: true}},
+		},
+		ServiceTelemetry: map[string]any{
+			"logs": map[string]any{"level": "debug"},
+		},
+	})
+	result, err := configuration.Render(context.TODO(), nil, config, store)
+	require.NoError(t, err)
+
+	expect := strings.TrimLeft(`
+extensions:
+    file_storage/extension0:
+        directory: /var/lib/storage
+    file_storage/queue:
+        directory: /tmp/queue
+service:
+    extensions:
+        - file_storage/extension0
+        - file_storage/queue
+`, "\n")
+	require.Contains(t, result, expect)
+	require.Contains(t, result, strings.TrimLeft(`
+    telemetry:
+        logs:
+            level: debug
+`, "\n"))
+
+	// unknown extension types are reported when rendering
+	configuration.Spec.Extensions[0].Type = "pprof"
+	_, err = configuration.Render(context.TODO(), nil, config, store)
+	require.ErrorContains(t, err, "unknown ExtensionType: pprof")
+}
+
+func TestConfigurationValidateExtensions(t *testing.T) {
+	store := newTestResourceStore()
+	fileStorage := testExtensionType()
+	store.extensionTypes[fileStorage.Name()] = fileStorage
+
+	tests := []struct {
+		name       string
+		extensions []ResourceConfiguration
+		expectErr  string
+	}{
+		{
+			name: "valid",
+			extensions: []ResourceConfiguration{
+				{ParameterizedSpec: ParameterizedSpec{Type: "file_storage"}},
+				{Name: "queue", ParameterizedSpec: ParameterizedSpec{Type: "file_storage"}},
+			},
+		},
+		{
+			name:       "missing type",
+			extensions: []ResourceConfiguration{{Name: "queue"}},
+			expectErr:  "all extensions must have a type",
+		},
+		{
+			name:       "unknown type",
+			extensions: []ResourceConfiguration{{ParameterizedSpec: ParameterizedSpec{Type: "pprof"}}},
+			expectErr:  "unknown ExtensionType: pprof",
+		},
+		{
+			name: "duplicate names",
+			extensions: []ResourceConfiguration{
+				{Name: "extension1", ParameterizedSpec: ParameterizedSpec{Type: "file_storage"}},
+				{ParameterizedSpec: ParameterizedSpec{Type: "file_storage"}},
+			},
+			expectErr: "extension name extension1 is not unique",
+		},
+		{
+			name: "processors",
+			extensions: []ResourceConfiguration{
+				{ParameterizedSpec: ParameterizedSpec{Type: "file_storage", Processors: []ResourceConfiguration{{ParameterizedSpec: ParameterizedSpec{Type: "batch"}}}}},
+			},
+			expectErr: "extension extension0 cannot have processors",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			configuration := NewConfigurationWithSpec("test", ConfigurationSpec{
+				Extensions: test.extensions,
+				Selector:   AgentSelector{MatchLabels: MatchLabels{"configuration": "test"}},
+			})
+			_, err := configuration.ValidateWithStore(context.TODO(), store)
+			if test.expectErr == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, test.expectErr)
+		})
+	}
+}
+
+func TestExtensionTypeValidate(t *testing.T) {
+	extensionType := testExtensionType()
+	_, err := extensionType.Validate()
+	require.NoError(t, err)
+
+	extensionType.Spec.Logs.Receivers = "- otlp:"
+	_, err = extensionType.Validate()
+	require.ErrorContains(t, err, "ExtensionType can only specify extensions")
+
+	sourceType := NewSourceTypeWithSpec("otlp", ResourceTypeSpec{Extensions: "- pprof:"})
+	_, err = sourceType.Validate()
+	require.ErrorContains(t, err, "extensions can only be specified for ExtensionType")
+}
+
+func TestConfigurationRemoveReferencesExtensionType(t *testing.T) {
+	configuration := NewConfigurationWithSpec("test", ConfigurationSpec{
+		Extensions: []ResourceConfiguration{
+			{ParameterizedSpec: ParameterizedSpec{Type: "file_storage"}},
+			{ParameterizedSpec: ParameterizedSpec{Type: "pprof"}},
+		},
+	})
+	require.False(t, configuration.RemoveReferences(KindExtensionType, "health_check"))
+	require.True(t, configuration.RemoveReferences(KindExtensionType, "file_storage"))
+	require.Equal(t, []ResourceConfiguration{{ParameterizedSpec: ParameterizedSpec{Type: "pprof"}}}, configuration.Spec.Extensions)
+}
@@ -188,6 +188,32 @@ func (c *AgentConfiguration) ReplaceLabels(labels string) {
 	}
 }
 
+// HasSecretKey returns true if the existing configuration has a manager.yaml with the specified secret key. An empty
+// secret key is always considered present because the server has no opinion about the secret key.
+func (c *AgentConfiguration) HasSecretKey(secretKey string) bool {
+	if secretKey == "" {
+		return true
+	}
+	return c.Manager != nil && c.Manager.SecretKey == secretKey
+}
+
+// ReplaceSecretKey replaces the secret key in the manager.yaml. If manager.yaml doesn't exist an empty one will be
+// created.
+func (c *AgentConfiguration) ReplaceSecretKey(secretKey string) {
+	if c.HasSecretKey(secretKey) {
+		return
+	}
+	if c.Manager == nil {
+		c.Manager = &ManagerConfig{
+			SecretKey: secretKey,
+		}
+	} else {
+		copy := *c.Manager
+		copy.SecretKey = secretKey
+		c.Manager = &copy
+	}
+}
+
 // Empty returns true if the configuration has empty collector, logging, and manager configs.
 func (c AgentConfiguration) Empty() bool {
 	return c.Collector == "" && c.Logging == "" && c.Manager == nil
@@ -204,7 +230,8 @@ func ComputeConfigurationUpdates(server *AgentConfiguration, agent *AgentConfigu
 		diff.Collector = server.Collector
 	}
 
-	// manager.yaml -- only requires that the labels be equal because this is currently the only managed portion of that configuration
+	// manager.yaml -- only requires that the labels and secret key be equal because these are currently the only managed
+	// portions of that configuration. An empty secret key means that the server has no opinion about the secret key.
 
 	if server.Manager == nil {
 		// no server manager configuration so no opinion about labels
@@ -212,19 +239,23 @@ func ComputeConfigurationUpdates(server *AgentConfiguration, agent *AgentConfigu
 	}
 
 	if agent.Manager == nil {
-		// no agent manager configuration to compare so just send a config with labels
-		if server.Manager.Labels != "" {
+		// no agent manager configuration to compare so just send a config with labels and secret key
+		if server.Manager.Labels != "" || server.Manager.SecretKey != "" {
 			diff.Manager = &ManagerConfig{
-				Labels: server.Manager.Labels,
+				Labels:    server.Manager.Labels,
+				SecretKey: server.Manager.SecretKey,
 			}
 		}
 		return diff
 	}
 
-	if !agent.HasLabels(server.Manager.Labels) {
+	if !agent.HasLabels(server.Manager.Labels) || !agent.HasSecretKey(server.Manager.SecretKey) {
 		// start with a copy of the agent manager configuration since we want to preserve the rest of the agent config
 		copy := *agent.Manager
 		copy.Labels = server.Manager.Labels
+		if server.Manager.SecretKey != "" {
+			copy.SecretKey = server.Manager.SecretKey
+		}
 		diff.Manager = &copy
 	}
 
@@ -346,6 +346,66 @@ func TestAgentConfigurationComputeConfigurationUpdates(t *testing.T) {
 			},
 			expectEmpty: false,
 		},
+		{
+			name: "secret key change, same labels",
+			server: AgentConfiguration{
+				Manager: &ManagerConfig{
+					Labels:    "foo=bar",
+					SecretKey: "issued",
+				},
+			},
+			agent: AgentConfiguration{
+				Manager: &ManagerConfig{
+					Endpoint:  "ws://localhost:3001/v1/opamp",
+					Labels:    "foo=bar",
+					SecretKey: "enrollment",
+				},
+			},
+			expect: AgentConfiguration{
+				Manager: &ManagerConfig{
+					Endpoint:  "ws://localhost:3001/v1/opamp",
+					Labels:    "foo=bar",
+					SecretKey: "issued",
+				},
+			},
+			expectEmpty: false,
+		},
+		{
+			name: "label change, secret key preserved",
+			server: AgentConfiguration{
+				Manager: &ManagerConfig{
+					Labels: "foo=bar",
+				},
+			},
+			agent: AgentConfiguration{
+				Manager: &ManagerConfig{
+					Labels:    "foo=baz",
+					SecretKey: "issued",
+				},
+			},
+			expect: AgentConfiguration{
+				Manager: &ManagerConfig{
+					Labels:    "foo=bar",
+					SecretKey: "issued",
+				},
+			},
+			expectEmpty: false,
+		},
+		{
+			name: "secret key change, no manager",
+			server: AgentConfiguration{
+				Manager: &ManagerConfig{
+					SecretKey: "issued",
+				},
+			},
+			agent: AgentConfiguration{},
+			expect: AgentConfiguration{
+				Manager: &ManagerConfig{
+					SecretKey: "issued",
+				},
+			},
+			expectEmpty: false,
+		},
 	}
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
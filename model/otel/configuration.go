@@ -70,7 +70,7 @@ func (p *PipelineTypeFlags) Set(flags PipelineTypeFlags) {
 	*p = *p | flags
 }
 
-// ComponentID is a the name of an individual receiver, processor, exporter, or extension.
+// ComponentID is a the name of an individual receiver, processor, exporter, connector, or extension.
 type ComponentID string
 
 // ComponentMap is a map of individual receivers, processors, etc.
@@ -143,6 +143,7 @@ type Configuration struct {
 	Receivers  ComponentMap `yaml:"receivers,omitempty"`
 	Processors ComponentMap `yaml:"processors,omitempty"`
 	Exporters  ComponentMap `yaml:"exporters,omitempty"`
+	Connectors ComponentMap `yaml:"connectors,omitempty"`
 	Extensions ComponentMap `yaml:"extensions,omitempty"`
 	Service    Service      `yaml:"service"`
 }
@@ -153,6 +154,7 @@ func NewConfiguration() *Configuration {
 		Receivers:  ComponentMap{},
 		Processors: ComponentMap{},
 		Exporters:  ComponentMap{},
+		Connectors: ComponentMap{},
 		Extensions: ComponentMap{},
 		Service: Service{
 			Pipelines: Pipelines{},
@@ -169,6 +171,10 @@ const SnapshotProcessorName ComponentID = "snapshotprocessor"
 // throughput
 const MeasureProcessorName ComponentID = "throughputmeasurement"
 
+// ConnectorsPipelineName is used in place of a destination name for the pipelines that export the telemetry of a source
+// to its connectors, e.g. logs/source0___connectors
+const ConnectorsPipelineName = "_connectors"
+
 // YAML marshals the configuration to yaml
 func (c *Configuration) YAML() (string, error) {
 	if c == nil || !c.HasPipelines() {
@@ -186,8 +192,9 @@ func (c *Configuration) HasPipelines() bool {
 // Service is the part of the configuration that defines the pipelines which consist of references to the components in
 // the Configuration.
 type Service struct {
-	Extensions []ComponentID `yaml:"extensions,omitempty"`
-	Pipelines  Pipelines     `yaml:"pipelines"`
+	Extensions []ComponentID  `yaml:"extensions,omitempty"`
+	Pipelines  Pipelines      `yaml:"pipelines"`
+	Telemetry  map[string]any `yaml:"telemetry,omitempty"`
 }
 
 // AddPipeline adds a pipeline to the map of pipelines for the service
@@ -250,12 +257,13 @@ type Partial struct {
 	Receivers  ComponentList
 	Processors ComponentList
 	Exporters  ComponentList
+	Connectors ComponentList
 	Extensions ComponentList
 }
 
 // Size returns the number of components in the partial configuration
 func (p *Partial) Size() int {
-	return len(p.Receivers) + len(p.Processors) + len(p.Exporters) + len(p.Extensions)
+	return len(p.Receivers) + len(p.Processors) + len(p.Exporters) + len(p.Connectors) + len(p.Extensions)
 }
 
 // HasNoReceiversOrExporters returns true if this Partial doesn't have any receivers or exporters
@@ -268,6 +276,7 @@ func (p *Partial) Append(o *Partial) {
 	p.Receivers = append(p.Receivers, o.Receivers...)
 	p.Processors = append(p.Processors, o.Processors...)
 	p.Exporters = append(p.Exporters, o.Exporters...)
+	p.Connectors = append(p.Connectors, o.Connectors...)
 	p.Extensions = append(p.Extensions, o.Extensions...)
 }
 
@@ -283,6 +292,7 @@ func (p *Partial) Prepend(o *Partial) {
 	p.Receivers = prepend(p.Receivers, o.Receivers...)
 	p.Processors = prepend(p.Processors, o.Processors...)
 	p.Exporters = prepend(p.Exporters, o.Exporters...)
+	p.Connectors = prepend(p.Connectors, o.Connectors...)
 	p.Extensions = prepend(p.Extensions, o.Extensions...)
 }
 
@@ -427,23 +437,46 @@ func (c *Configuration) AddAgentMetricsPipeline(rc *RenderContext) {
 	c.Service.AddPipeline(p)
 }
 
-// AddPipeline adds a pipeline and all of the corresponding components to the configuration
+// AddPipeline adds a pipeline and all of the corresponding components to the configuration. Connectors of the source
+// that produce telemetry of the specified pipelineType are added as receivers of the pipeline and the source pipelines
+// that export to those connectors are added as well. If the telemetry of the specified pipelineType goes through a
+// connector, the pipeline only receives it from the connector.
 func (c *Configuration) AddPipeline(name string, pipelineType PipelineType, sourceName string, source Partials, destinationName string, destination Partials, rc *RenderContext) {
 	s := source[pipelineType]
 	d := destination[pipelineType]
-	if s.HasNoReceiversOrExporters() || d.HasNoReceiversOrExporters() {
+	connectors := source.connectorsProducing(pipelineType)
+	if (s.HasNoReceiversOrExporters() && len(connectors) == 0) || d.HasNoReceiversOrExporters() {
 		// not all pipelineType will have components, ignore these
 		return
 	}
 
+	// telemetry of this type that goes through a connector of the source is received from the connector instead of the
+	// receivers of the source. Otherwise the destination would receive it twice and a routing connector couldn't filter.
+	throughConnector := false
+	for _, connector := range connectors {
+		if connector.pipelineType == pipelineType {
+			throughConnector = true
+		}
+	}
+
 	p := NewPipeline(pipelineType, name, sourceName, destinationName)
 
 	// add any receivers specified
-	p.AddReceivers(rc, c.Receivers.addComponents(s.Receivers))
+	if !throughConnector {
+		p.AddReceivers(rc, c.Receivers.addComponents(s.Receivers))
+	}
 	p.AddReceivers(rc, c.Receivers.addComponents(d.Receivers))
 
-	// add any processors specified
-	p.AddProcessors(rc, c.Processors.addComponents(s.Processors))
+	// add any connectors of the source that produce this type of telemetry as receivers
+	for _, connector := range connectors {
+		p.AddReceivers(rc, connector.ids)
+	}
+
+	// add any processors specified. the processors of the source are in the connector pipeline if it goes through a
+	// connector.
+	if !throughConnector {
+		p.AddProcessors(rc, c.Processors.addComponents(s.Processors))
+	}
 	p.AddProcessors(rc, c.Processors.addComponents(d.Processors))
 
 	if rc.IncludeSnapshotProcessor {
@@ -465,6 +498,91 @@ func (c *Configuration) AddPipeline(name string, pipelineType PipelineType, sour
 	c.AddExtensions(d.Extensions)
 
 	c.Service.AddPipeline(p)
+
+	// connectors are exporters in a separate pipeline for the telemetry they consume
+	for _, connector := range connectors {
+		c.addConnectorPipeline(sourceName, connector.pipelineType, source[connector.pipelineType], connector.components, rc)
+	}
+}
+
+// addConnectorPipeline adds a pipeline of the specified type that exports the telemetry of the source to the specified
+// connectors. If the pipeline already exists, any new connectors are added to its exporters.
+func (c *Configuration) addConnectorPipeline(sourceName string, pipelineType PipelineType, s *Partial, connectors ComponentList, rc *RenderContext) {
+	ids := c.Connectors.addComponents(connectors)
+
+	id := NewComponentID(string(pipelineType), fmt.Sprintf("%s__%s", sourceName, ConnectorsPipelineName))
+	if p, ok := c.Service.Pipelines[id]; ok {
+		for _, connectorID := range ids {
+			if !slices.Contains(p.Exporters, connectorID) {
+				p.AddExporters(rc, []ComponentID{connectorID})
+			}
+		}
+		c.Service.AddPipeline(p)
+		return
+	}
+
+	p := NewPipeline(pipelineType, fmt.Sprintf("%s__%s", sourceName, ConnectorsPipelineName), sourceName, ConnectorsPipelineName)
+	p.AddReceivers(rc, c.Receivers.addComponents(s.Receivers))
+	p.AddProcessors(rc, c.Processors.addComponents(s.Processors))
+	p.AddExporters(rc, ids)
+
+	c.AddExtensions(s.Extensions)
+
+	c.Service.AddPipeline(p)
+}
+
+// connectorReceiverTypes contains the types of connectors that produce a different type of telemetry than they
+// consume and the pipeline types in which they can be used as receivers. All other connectors, e.g. forward and
+// routing, produce the same type of telemetry that they consume.
+var connectorReceiverTypes = map[string][]PipelineType{
+	"count":        {Metrics},
+	"exceptions":   {Metrics, Logs},
+	"servicegraph": {Metrics},
+	"spanmetrics":  {Metrics},
+	"sum":          {Metrics},
+}
+
+// ConnectorReceiverTypes returns the pipeline types in which the connector with the specified id can be used as a
+// receiver when it is used as an exporter in a pipeline of the specified type.
+func ConnectorReceiverTypes(id ComponentID, pipelineType PipelineType) []PipelineType {
+	connectorType, _ := ParseComponentID(id)
+	if types, ok := connectorReceiverTypes[connectorType]; ok {
+		return types
+	}
+	return []PipelineType{pipelineType}
+}
+
+// partialConnectors are the connectors of a Partial that consume telemetry of the specified pipelineType
+type partialConnectors struct {
+	pipelineType PipelineType
+	components   ComponentList
+	ids          []ComponentID
+}
+
+// connectorsProducing returns the connectors of each pipeline type that produce telemetry of the specified pipeline
+// type. Connectors are ignored for pipeline types without receivers because nothing would be exported to them.
+func (p Partials) connectorsProducing(pipelineType PipelineType) []partialConnectors {
+	var result []partialConnectors
+	for _, consumedType := range []PipelineType{Logs, Metrics, Traces} {
+		partial := p[consumedType]
+		if partial == nil || len(partial.Receivers) == 0 {
+			continue
+		}
+		connectors := partialConnectors{pipelineType: consumedType}
+		for _, components := range partial.Connectors {
+			for id, component := range components {
+				if !slices.Contains(ConnectorReceiverTypes(id, consumedType), pipelineType) {
+					continue
+				}
+				connectors.components = append(connectors.components, map[ComponentID]any{id: component})
+				connectors.ids = append(connectors.ids, id)
+			}
+		}
+		if len(connectors.ids) > 0 {
+			result = append(result, connectors)
+		}
+	}
+	return result
 }
 
 // hasComponent returns true if the ComponentMap already has a component with the specified name
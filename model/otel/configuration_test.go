@@ -123,3 +123,67 @@ func TestPipelineTypeFlags_Set(t *testing.T) {
 		})
 	}
 }
+
+func TestConnectorReceiverTypes(t *testing.T) {
+	tests := []struct {
+		id           ComponentID
+		pipelineType PipelineType
+		expect       []PipelineType
+	}{
+		{id: "spanmetrics", pipelineType: Traces, expect: []PipelineType{Metrics}},
+		{id: "count/source0", pipelineType: Logs, expect: []PipelineType{Metrics}},
+		{id: "exceptions/source0", pipelineType: Traces, expect: []PipelineType{Metrics, Logs}},
+		{id: "routing/source0", pipelineType: Logs, expect: []PipelineType{Logs}},
+		{id: "forward", pipelineType: Traces, expect: []PipelineType{Traces}},
+	}
+	for _, test := range tests {
+		t.Run(string(test.id), func(t *testing.T) {
+			require.Equal(t, test.expect, ConnectorReceiverTypes(test.id, test.pipelineType))
+		})
+	}
+}
+
+func TestAddPipelineWithConnectors(t *testing.T) {
+	rc := NewRenderContext("", "test", "")
+
+	source := NewPartials()
+	source[Traces].Receivers = ComponentList{{"otlp/source0": nil}}
+	source[Traces].Processors = ComponentList{{"batch/source0": nil}}
+	source[Traces].Connectors = ComponentList{
+		{"spanmetrics/source0": map[string]any{"namespace": "spans"}},
+		{"forward/source0": nil},
+	}
+	source[Logs].Connectors = ComponentList{{"count/source0": nil}}
+
+	destination := NewPartials()
+	destination[Metrics].Exporters = ComponentList{{"otlp/destination0": nil}}
+	destination[Traces].Exporters = ComponentList{{"otlp/destination0": nil}}
+
+	c := NewConfiguration()
+	for _, pipelineType := range []PipelineType{Logs, Metrics, Traces} {
+		c.AddPipeline("source0__destination0", pipelineType, "source0", source, "destination0", destination, rc)
+	}
+
+	// count is ignored because there are no logs receivers
+	require.Equal(t, ComponentMap{
+		"spanmetrics/source0": map[string]any{"namespace": "spans"},
+		"forward/source0":     nil,
+	}, c.Connectors)
+
+	require.Len(t, c.Service.Pipelines, 3)
+
+	metrics := c.Service.Pipelines["metrics/source0__destination0"]
+	require.Equal(t, []ComponentID{"spanmetrics/source0"}, metrics.Receivers)
+	require.Equal(t, []ComponentID{"otlp/destination0"}, metrics.Exporters)
+
+	traces := c.Service.Pipelines["traces/source0__destination0"]
+	require.Equal(t, []ComponentID{"forward/source0"}, traces.Receivers)
+	require.Empty(t, traces.Processors)
+	require.Equal(t, []ComponentID{"otlp/destination0"}, traces.Exporters)
+
+	connectors := c.Service.Pipelines["traces/source0___connectors"]
+	require.Equal(t, []ComponentID{"otlp/source0"}, connectors.Receivers)
+	require.Equal(t, []ComponentID{"batch/source0"}, connectors.Processors)
+	require.Equal(t, []ComponentID{"spanmetrics/source0", "forward/source0"}, connectors.Exporters)
+	require.Equal(t, ConnectorsPipelineName, connectors.DestinationName())
+}
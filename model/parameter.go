@@ -239,6 +239,12 @@ func (p ParameterDefinition) validateName() error {
 			"ensure that the name is a valid go identifier that can be used in go templates",
 		)
 	}
+	if p.Name == agentTemplateKey {
+		return errors.NewError(
+			fmt.Sprintf("invalid name '%s' for parameter", p.Name),
+			"the name is reserved for the values of the agent available to templates",
+		)
+	}
 	return nil
 }
 
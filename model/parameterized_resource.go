@@ -17,6 +17,7 @@ package model
 import (
 	"context"
 
+	"github.com/observiq/bindplane-op/internal/store/search"
 	"github.com/observiq/bindplane-op/model/otel"
 	"github.com/observiq/bindplane-op/model/validation"
 )
@@ -64,6 +65,43 @@ func (s ParameterizedSpec) overrideParameters(parameters []Parameter) Parameteri
 	return ParameterizedSpec{Type: s.Type, Parameters: result, Processors: s.Processors}
 }
 
+// indexProcessors adds the processor and processorType fields for each processor in the spec. A processor has a name if
+// it refers to a Processor resource and only a type if it is defined inline.
+func (s *ParameterizedSpec) indexProcessors(index search.Indexer) {
+	for _, processor := range s.Processors {
+		index("processor", processor.Name)
+		index("processorType", processor.Type)
+	}
+}
+
+// removeProcessors removes the processors that refer to the Processor or ProcessorType with the specified kind and name
+// and returns true if any were removed
+func (s *ParameterizedSpec) removeProcessors(kind Kind, name string) bool {
+	var removed bool
+	switch kind {
+	case KindProcessor:
+		s.Processors, removed = removeResourceConfigurations(s.Processors, func(rc ResourceConfiguration) bool { return rc.Name == name })
+	case KindProcessorType:
+		s.Processors, removed = removeResourceConfigurations(s.Processors, func(rc ResourceConfiguration) bool { return rc.Type == name })
+	}
+	return removed
+}
+
+// removeResourceConfigurations returns a new slice without the resource configurations that match and true if any were
+// removed
+func removeResourceConfigurations(list []ResourceConfiguration, match func(rc ResourceConfiguration) bool) ([]ResourceConfiguration, bool) {
+	var result []ResourceConfiguration
+	removed := false
+	for _, rc := range list {
+		if match(rc) {
+			removed = true
+			continue
+		}
+		result = append(result, rc)
+	}
+	return result, removed
+}
+
 // validateTypeAndParameters is used by Source and Destination validation and uses methods created for Configuration
 // validation.
 func (s *ParameterizedSpec) validateTypeAndParameters(ctx context.Context, kind Kind, errors validation.Errors, store ResourceStore) {
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/observiq/bindplane-op/internal/store/search"
 	"github.com/observiq/bindplane-op/model/otel"
 	"github.com/observiq/bindplane-op/model/validation"
 )
@@ -125,3 +126,17 @@ func (s *Processor) PrintableFieldValue(title string) string {
 		return "-"
 	}
 }
+
+// ----------------------------------------------------------------------
+// Indexed
+
+// IndexFields returns a map of field name to field value to be stored in the index
+func (s *Processor) IndexFields(index search.Indexer) {
+	s.ResourceMeta.IndexFields(index)
+
+	// add the type of processor
+	index("type", s.ResourceTypeName())
+
+	// add secret fields
+	s.Spec.indexSecrets(index)
+}
@@ -23,6 +23,7 @@ import (
 	"path"
 	"path/filepath"
 	"runtime"
+	"strconv"
 	"strings"
 
 	"github.com/google/uuid"
@@ -60,6 +61,9 @@ const (
 	KindSourceType      Kind = "SourceType"
 	KindProcessorType   Kind = "ProcessorType"
 	KindDestinationType Kind = "DestinationType"
+	KindExtensionType   Kind = "ExtensionType"
+	KindEnrollmentToken Kind = "EnrollmentToken"
+	KindSecret          Kind = "Secret"
 	KindUnknown         Kind = "Unknown"
 )
 
@@ -79,6 +83,9 @@ func createKindLookup() map[string]Kind {
 		KindSourceType,
 		KindProcessorType,
 		KindDestinationType,
+		KindExtensionType,
+		KindEnrollmentToken,
+		KindSecret,
 	} {
 		key := strings.ToLower(string(kind))
 		plural := fmt.Sprintf("%ss", key)
@@ -110,6 +117,18 @@ type Resource interface {
 	// EnsureID generates a new uuid for a resource if none exists
 	EnsureID()
 
+	// Revision returns the revision of this resource assigned by the store
+	Revision() int
+
+	// SetRevision replaces the revision for this resource
+	SetRevision(revision int)
+
+	// ResourceVersion returns the resourceVersion specified when applying or deleting this resource
+	ResourceVersion() string
+
+	// SetResourceVersion replaces the resourceVersion for this resource
+	SetResourceVersion(version string)
+
 	// Name returns the name for this resource
 	Name() string
 
@@ -149,6 +168,11 @@ type Metadata struct {
 	Description string `yaml:"description,omitempty" json:"description,omitempty" mapstructure:"description"`
 	Icon        string `yaml:"icon,omitempty" json:"icon,omitempty" mapstructure:"icon"`
 	Labels      Labels `yaml:"labels,omitempty" json:"labels" mapstructure:"labels"`
+	// Revision is assigned by the store and incremented each time the resource is changed
+	Revision int `yaml:"revision,omitempty" json:"revision,omitempty" mapstructure:"revision"`
+	// ResourceVersion is specified when applying or deleting a resource to only make the change if it matches the
+	// resourceVersion of the stored resource, which is derived from its revision. It is not stored.
+	ResourceVersion string `yaml:"resourceVersion,omitempty" json:"resourceVersion,omitempty" mapstructure:"resourceVersion"`
 }
 
 // Parameter TODO(doc)
@@ -176,6 +200,35 @@ func (r *ResourceMeta) SetID(id string) {
 	r.Metadata.ID = id
 }
 
+// Revision returns the revision
+func (r *ResourceMeta) Revision() int {
+	return r.Metadata.Revision
+}
+
+// SetRevision replaces the revision for this resource
+func (r *ResourceMeta) SetRevision(revision int) {
+	r.Metadata.Revision = revision
+}
+
+// ResourceVersion returns the resourceVersion
+func (r *ResourceMeta) ResourceVersion() string {
+	return r.Metadata.ResourceVersion
+}
+
+// SetResourceVersion replaces the resourceVersion for this resource
+func (r *ResourceMeta) SetResourceVersion(version string) {
+	r.Metadata.ResourceVersion = version
+}
+
+// RevisionResourceVersion returns the resourceVersion of a stored resource with the specified revision. Resources
+// without a revision have no resourceVersion.
+func RevisionResourceVersion(revision int) string {
+	if revision == 0 {
+		return ""
+	}
+	return strconv.Itoa(revision)
+}
+
 // GetKind returns the Kind of this resource.
 func (r *ResourceMeta) GetKind() Kind {
 	return r.Kind
@@ -303,8 +356,14 @@ func ParseResource(r *AnyResource) (Resource, error) {
 		return parseResource(r, &Destination{})
 	case KindDestinationType:
 		return parseResource(r, &DestinationType{})
+	case KindExtensionType:
+		return parseResource(r, &ExtensionType{})
 	case KindAgentVersion:
 		return parseResource(r, &AgentVersion{})
+	case KindEnrollmentToken:
+		return parseResource(r, &EnrollmentToken{})
+	case KindSecret:
+		return parseResource(r, &Secret{})
 	}
 
 	return nil, fmt.Errorf("unknown resource kind: %s", r.Kind)
@@ -458,6 +517,12 @@ func NewEmptyResource(kind Kind) (Resource, error) {
 		return &ProcessorType{}, nil
 	case KindDestinationType:
 		return &DestinationType{}, nil
+	case KindExtensionType:
+		return &ExtensionType{}, nil
+	case KindEnrollmentToken:
+		return &EnrollmentToken{}, nil
+	case KindSecret:
+		return &Secret{}, nil
 	default:
 		return nil, fmt.Errorf("cannot make empty resource for unexpected kind: %s", kind)
 	}
@@ -84,6 +84,10 @@ const (
 
 	// StatusInUse is used when attempting to delete a resource that is being referenced by another
 	StatusInUse UpdateStatus = "in-use"
+
+	// StatusConflict is used when the resourceVersion of an applied resource does not match the current resourceVersion
+	// of the resource in the store
+	StatusConflict UpdateStatus = "conflict"
 )
 
 // PrintResourceUpdates TODO(doc)
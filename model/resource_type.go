@@ -19,6 +19,8 @@ import (
 	"context"
 	"fmt"
 	"io"
+	"regexp"
+	"strings"
 	"text/template"
 
 	"github.com/Masterminds/sprig/v3"
@@ -58,6 +60,9 @@ type ResourceTypeSpec struct {
 
 	// all three (alphabetical order)
 	LogsMetricsTraces ResourceTypeOutput `json:"logs+metrics+traces,omitempty" yaml:"logs+metrics+traces,omitempty" mapstructure:"logs+metrics+traces"`
+
+	// Extensions are not specific to any type of telemetry and are only used by ExtensionTypes
+	Extensions ResourceTypeTemplate `json:"extensions,omitempty" yaml:"extensions,omitempty" mapstructure:"extensions"`
 }
 
 // ResourceTypeOutput describes the output of the resource type
@@ -65,12 +70,13 @@ type ResourceTypeOutput struct {
 	Receivers  ResourceTypeTemplate `json:"receivers,omitempty"  yaml:"receivers,omitempty"  mapstructure:"receivers"`
 	Processors ResourceTypeTemplate `json:"processors,omitempty" yaml:"processors,omitempty" mapstructure:"processors"`
 	Exporters  ResourceTypeTemplate `json:"exporters,omitempty"  yaml:"exporters,omitempty"  mapstructure:"exporters"`
+	Connectors ResourceTypeTemplate `json:"connectors,omitempty" yaml:"connectors,omitempty" mapstructure:"connectors"`
 	Extensions ResourceTypeTemplate `json:"extensions,omitempty" yaml:"extensions,omitempty" mapstructure:"extensions"`
 }
 
-// Empty returns true if Receivers, Processors, Exporters, and Extensions are the zero value ""
+// Empty returns true if Receivers, Processors, Exporters, Connectors, and Extensions are the zero value ""
 func (s ResourceTypeOutput) Empty() bool {
-	return s.Receivers == "" && s.Processors == "" && s.Exporters == "" && s.Extensions == ""
+	return s.Receivers == "" && s.Processors == "" && s.Exporters == "" && s.Connectors == "" && s.Extensions == ""
 }
 
 // ResourceTypeTemplate is a go-template that evaluates to an array of OpenTelemetry resources
@@ -93,28 +99,28 @@ func (s *ResourceTypeSpec) ParameterDefinition(name string) *ParameterDefinition
 // ----------------------------------------------------------------------
 
 // eval executes all of the templates associated with this resource type, returning a partial configuration for each
-// telemetry type.
-func (rt *ResourceType) eval(resource parameterizedResource, errorHandler TemplateErrorHandler) otel.Partials {
+// telemetry type. The values of the agent are available to the templates and parameter values as .Agent.
+func (rt *ResourceType) eval(resource parameterizedResource, agent AgentTemplateValues, errorHandler TemplateErrorHandler) otel.Partials {
 	result := otel.Partials{
-		otel.Logs:    rt.evalOutput(&rt.Spec.Logs, resource, errorHandler),
-		otel.Metrics: rt.evalOutput(&rt.Spec.Metrics, resource, errorHandler),
-		otel.Traces:  rt.evalOutput(&rt.Spec.Traces, resource, errorHandler),
+		otel.Logs:    rt.evalOutput(&rt.Spec.Logs, resource, agent, errorHandler),
+		otel.Metrics: rt.evalOutput(&rt.Spec.Metrics, resource, agent, errorHandler),
+		otel.Traces:  rt.evalOutput(&rt.Spec.Traces, resource, agent, errorHandler),
 	}
 
 	// add multi-pipelines components
-	logsMetrics := rt.evalOutput(&rt.Spec.LogsMetrics, resource, errorHandler)
+	logsMetrics := rt.evalOutput(&rt.Spec.LogsMetrics, resource, agent, errorHandler)
 	result[otel.Logs].Append(logsMetrics)
 	result[otel.Metrics].Append(logsMetrics)
 
-	logsTraces := rt.evalOutput(&rt.Spec.LogsTraces, resource, errorHandler)
+	logsTraces := rt.evalOutput(&rt.Spec.LogsTraces, resource, agent, errorHandler)
 	result[otel.Logs].Append(logsTraces)
 	result[otel.Traces].Append(logsTraces)
 
-	metricsTraces := rt.evalOutput(&rt.Spec.MetricsTraces, resource, errorHandler)
+	metricsTraces := rt.evalOutput(&rt.Spec.MetricsTraces, resource, agent, errorHandler)
 	result[otel.Metrics].Append(metricsTraces)
 	result[otel.Traces].Append(metricsTraces)
 
-	logsMetricsTraces := rt.evalOutput(&rt.Spec.LogsMetricsTraces, resource, errorHandler)
+	logsMetricsTraces := rt.evalOutput(&rt.Spec.LogsMetricsTraces, resource, agent, errorHandler)
 	result[otel.Logs].Append(logsMetricsTraces)
 	result[otel.Metrics].Append(logsMetricsTraces)
 	result[otel.Traces].Append(logsMetricsTraces)
@@ -122,8 +128,27 @@ func (rt *ResourceType) eval(resource parameterizedResource, errorHandler Templa
 	return result
 }
 
+// evalExtensions executes the extensions template of an ExtensionType using the specified resource and errorHandler.
+func (rt *ExtensionType) evalExtensions(resource parameterizedResource, agent AgentTemplateValues, errorHandler TemplateErrorHandler) otel.ComponentList {
+	return rt.evalTemplate(rt.Spec.Extensions, resource, rt.parameterValues(resource, agent, errorHandler), agent, errorHandler)
+}
+
 // evalOutput executes the templates associated with the specified output using the specified resource and errorHandler.
-func (rt *ResourceType) evalOutput(output *ResourceTypeOutput, resource parameterizedResource, errorHandler TemplateErrorHandler) *otel.Partial {
+func (rt *ResourceType) evalOutput(output *ResourceTypeOutput, resource parameterizedResource, agent AgentTemplateValues, errorHandler TemplateErrorHandler) *otel.Partial {
+	params := rt.parameterValues(resource, agent, errorHandler)
+	// eval all of the components
+	return &otel.Partial{
+		Receivers:  rt.evalTemplate(output.Receivers, resource, params, agent, errorHandler),
+		Processors: rt.evalTemplate(output.Processors, resource, params, agent, errorHandler),
+		Exporters:  rt.evalTemplate(output.Exporters, resource, params, agent, errorHandler),
+		Connectors: rt.evalTemplate(output.Connectors, resource, params, agent, errorHandler),
+		Extensions: rt.evalTemplate(output.Extensions, resource, params, agent, errorHandler),
+	}
+}
+
+// parameterValues returns the default parameter values of the resource type overridden by the parameters of the
+// resource. Parameter values that reference .Agent are evaluated as templates with the values of the agent.
+func (rt *ResourceType) parameterValues(resource parameterizedResource, agent AgentTemplateValues, errorHandler TemplateErrorHandler) map[string]any {
 	params := map[string]any{}
 	// start with default parameters
 	for _, p := range rt.Spec.Parameters {
@@ -133,15 +158,110 @@ func (rt *ResourceType) evalOutput(output *ResourceTypeOutput, resource paramete
 	}
 	// resource can overrides the parameters
 	for _, p := range resource.ResourceParameters() {
-		params[p.Name] = p.Value
+		value, err := evalParameterValue(p.Value, agent)
+		if err != nil {
+			errorHandler(fmt.Errorf("parameter %s: %w", p.Name, err))
+		}
+		params[p.Name] = value
 	}
-	// eval all of the components
-	return &otel.Partial{
-		Receivers:  rt.evalTemplate(output.Receivers, resource, params, errorHandler),
-		Processors: rt.evalTemplate(output.Processors, resource, params, errorHandler),
-		Exporters:  rt.evalTemplate(output.Exporters, resource, params, errorHandler),
-		Extensions: rt.evalTemplate(output.Extensions, resource, params, errorHandler),
+	return params
+}
+
+// evalParameterValue evaluates the strings of the parameter value that reference .Agent as templates with the values of
+// the agent. Other strings are left unchanged because they may contain templates that are evaluated by the agent.
+func evalParameterValue(value any, agent AgentTemplateValues) (any, error) {
+	switch value := value.(type) {
+	case string:
+		if !strings.Contains(value, "{{") || !strings.Contains(value, agentTemplateKey) {
+			return value, nil
+		}
+		t, err := template.New("parameter").
+			Option("missingkey=error").
+			Funcs(template.FuncMap(sprig.FuncMap())).
+			Parse(value)
+		if err != nil {
+			return value, err
+		}
+		var writer bytes.Buffer
+		if err := t.Execute(&writer, templateData(nil, agent, value)); err != nil {
+			return value, err
+		}
+		return writer.String(), nil
+
+	case []any:
+		result := make([]any, len(value))
+		for i, v := range value {
+			evaluated, err := evalParameterValue(v, agent)
+			if err != nil {
+				return value, err
+			}
+			result[i] = evaluated
+		}
+		return result, nil
+
+	case []string:
+		result := make([]string, len(value))
+		for i, v := range value {
+			evaluated, err := evalParameterValue(v, agent)
+			if err != nil {
+				return value, err
+			}
+			result[i] = evaluated.(string)
+		}
+		return result, nil
+
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for k, v := range value {
+			evaluated, err := evalParameterValue(v, agent)
+			if err != nil {
+				return value, err
+			}
+			result[k] = evaluated
+		}
+		return result, nil
+
+	case map[string]string:
+		result := make(map[string]string, len(value))
+		for k, v := range value {
+			evaluated, err := evalParameterValue(v, agent)
+			if err != nil {
+				return value, err
+			}
+			result[k] = evaluated.(string)
+		}
+		return result, nil
 	}
+	return value, nil
+}
+
+// agentTemplateKey is the key of the values of the agent in the data of templates. It cannot be used as the name of a
+// parameter.
+const agentTemplateKey = "Agent"
+
+var agentLabelReferencePattern = regexp.MustCompile(`\.Agent\.Labels\.([A-Za-z_][A-Za-z0-9_]*)`)
+
+// templateData returns a copy of the parameter values with the values of the agent added as .Agent. Templates are
+// executed with missingkey=error, so each label referenced by the template text that the agent doesn't have is added
+// with an empty value. This allows a template to reference labels that only some of the agents have.
+func templateData(params map[string]any, agent AgentTemplateValues, text string) map[string]any {
+	labels := make(map[string]string, len(agent.Labels))
+	for k, v := range agent.Labels {
+		labels[k] = v
+	}
+	for _, match := range agentLabelReferencePattern.FindAllStringSubmatch(text, -1) {
+		if _, ok := labels[match[1]]; !ok {
+			labels[match[1]] = ""
+		}
+	}
+	agent.Labels = labels
+
+	data := make(map[string]any, len(params)+1)
+	for k, v := range params {
+		data[k] = v
+	}
+	data[agentTemplateKey] = agent
+	return data
 }
 
 const (
@@ -212,9 +332,9 @@ func (rt *ResourceType) templateFuncDisabledCategoryMetrics(parameterValue []any
 	return result, nil
 }
 
-// evalTemplate evaluates a single template with the specified paramValues. nameProvider is available to make the name
-// unique and the errorHandler will accumulate errors so that they can be reported once.
-func (rt *ResourceType) evalTemplate(r ResourceTypeTemplate, nameProvider otel.ComponentIDProvider, paramValues map[string]any, errorHandler TemplateErrorHandler) otel.ComponentList {
+// evalTemplate evaluates a single template with the specified paramValues and the values of the agent as .Agent.
+// nameProvider is available to make the name unique and the errorHandler will accumulate errors so that they can be reported once.
+func (rt *ResourceType) evalTemplate(r ResourceTypeTemplate, nameProvider otel.ComponentIDProvider, paramValues map[string]any, agent AgentTemplateValues, errorHandler TemplateErrorHandler) otel.ComponentList {
 	set := otel.ComponentList{}
 
 	// get the template for the key
@@ -230,7 +350,7 @@ func (rt *ResourceType) evalTemplate(r ResourceTypeTemplate, nameProvider otel.C
 
 	// render the template
 	var writer bytes.Buffer
-	if err := t.Execute(&writer, paramValues); err != nil {
+	if err := t.Execute(&writer, templateData(paramValues, agent, string(r))); err != nil {
 		errorHandler(err)
 		return set
 	}
@@ -316,6 +436,23 @@ func (s *ResourceTypeSpec) validate(kind Kind, errs validation.Errors) {
 	s.Logs.validateTemplates(errs, "logs", params)
 	s.Metrics.validateTemplates(errs, "metrics", params)
 	s.Traces.validateTemplates(errs, "traces", params)
+	s.Extensions.validate(errs, "extensions", params)
+
+	s.validateExtensions(kind, errs)
+}
+
+// validateExtensions ensures that only ExtensionTypes use the extensions template and that ExtensionTypes only render
+// extensions
+func (s *ResourceTypeSpec) validateExtensions(kind Kind, errs validation.Errors) {
+	if kind != KindExtensionType {
+		if s.Extensions != "" {
+			errs.Add(fmt.Errorf("extensions can only be specified for %s", KindExtensionType))
+		}
+		return
+	}
+	if len(s.TelemetryTypes()) > 0 {
+		errs.Add(fmt.Errorf("%s can only specify extensions", KindExtensionType))
+	}
 }
 
 func (s *ResourceTypeSpec) validateParameterDefinitions(kind Kind, errs validation.Errors) {
@@ -358,6 +495,7 @@ func (s ResourceTypeOutput) validateTemplates(errs validation.Errors, name strin
 	s.Receivers.validate(errs, fmt.Sprintf("%s.receivers", name), params)
 	s.Processors.validate(errs, fmt.Sprintf("%s.processors", name), params)
 	s.Exporters.validate(errs, fmt.Sprintf("%s.exporters", name), params)
+	s.Connectors.validate(errs, fmt.Sprintf("%s.connectors", name), params)
 	s.Extensions.validate(errs, fmt.Sprintf("%s.extensions", name), params)
 }
 
@@ -376,8 +514,8 @@ func (s ResourceTypeTemplate) validate(errs validation.Errors, name string, para
 		errs.Add(err)
 		return
 	}
-	// ensure that it can be executed with default values
-	if err := t.Execute(io.Discard, params); err != nil {
+	// ensure that it can be executed with default values and the empty values of an agent
+	if err := t.Execute(io.Discard, templateData(params, AgentTemplateValues{}, string(s))); err != nil {
 		errs.Add(err)
 	}
 }
@@ -27,7 +27,7 @@ import (
 func TestEvalCabinDestination(t *testing.T) {
 	dt := fileResource[*DestinationType](t, "testfiles/destinationtype-cabin.yaml")
 	d := fileResource[*Destination](t, "testfiles/destination-cabin.yaml")
-	values := dt.evalOutput(&dt.Spec.Logs, d, func(e error) {
+	values := dt.evalOutput(&dt.Spec.Logs, d, AgentTemplateValues{}, func(e error) {
 		require.NoError(t, e)
 	})
 	require.Len(t, values.Receivers, 0)
@@ -55,7 +55,7 @@ func TestEvalCabinDestination(t *testing.T) {
 func TestEvalGoogleCloud(t *testing.T) {
 	dt := fileResource[*DestinationType](t, "testfiles/destinationtype-googlecloud.yaml")
 	d := fileResource[*Destination](t, "testfiles/destination-googlecloud.yaml")
-	values := dt.eval(d, func(e error) {
+	values := dt.eval(d, AgentTemplateValues{}, func(e error) {
 		require.NoError(t, e)
 	})
 	require.Len(t, values[otel.Logs].Receivers, 0)
@@ -357,3 +357,84 @@ func TestResourceType_templateFuncHasMetricsEnabled(t *testing.T) {
 		})
 	}
 }
+
+func TestEvalParameterValue(t *testing.T) {
+	agent := AgentTemplateValues{
+		ID:       "1",
+		Hostname: "host-1",
+		Labels:   map[string]string{"datacenter": "east"},
+	}
+
+	tests := []struct {
+		name      string
+		value     any
+		expect    any
+		expectErr string
+	}{
+		{
+			name:   "not a template",
+			value:  "localhost:4317",
+			expect: "localhost:4317",
+		},
+		{
+			name:   "template without agent values",
+			value:  `{{ .Body }}`,
+			expect: `{{ .Body }}`,
+		},
+		{
+			name:   "agent values",
+			value:  "{{ .Agent.Hostname }}-{{ .Agent.Labels.datacenter }}",
+			expect: "host-1-east",
+		},
+		{
+			name:   "missing label",
+			value:  "{{ .Agent.Labels.rack }}",
+			expect: "",
+		},
+		{
+			name:   "strings",
+			value:  []any{"{{ .Agent.ID }}", 1},
+			expect: []any{"1", 1},
+		},
+		{
+			name:   "map",
+			value:  map[string]any{"datacenter": "{{ .Agent.Labels.datacenter }}"},
+			expect: map[string]any{"datacenter": "east"},
+		},
+		{
+			name:      "unknown field",
+			value:     "{{ .Agent.Region }}",
+			expectErr: "can't evaluate field Region",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			value, err := evalParameterValue(test.value, agent)
+			if test.expectErr != "" {
+				require.ErrorContains(t, err, test.expectErr)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, test.expect, value)
+		})
+	}
+}
+
+func TestValidateAgentTemplateValues(t *testing.T) {
+	sourceType := NewSourceTypeWithSpec("otlp", ResourceTypeSpec{
+		Logs: ResourceTypeOutput{
+			Receivers: `
+- otlp:
+    endpoint: {{ .Agent.Hostname }}:4317
+    datacenter: {{ .Agent.Labels.datacenter }}
+`,
+		},
+	})
+	_, err := sourceType.Validate()
+	require.NoError(t, err)
+
+	sourceType.Spec.Parameters = []ParameterDefinition{{Name: "Agent", Type: "string"}}
+	_, err = sourceType.Validate()
+	require.ErrorContains(t, err, "invalid name 'Agent' for parameter")
+}
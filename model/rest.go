@@ -32,12 +32,36 @@ type DeleteAgentsPayload struct {
 // DeleteAgentsResponse is the REST API response to DELETE /v1/agents
 type DeleteAgentsResponse = AgentsResponse
 
+// RestartAgentsPayload is the REST API body to PUT /v1/agents/restart. Agents with the specified IDs and agents matching
+// the Selector or Query, if specified, are restarted.
+type RestartAgentsPayload struct {
+	IDs      []string `json:"ids,omitempty"`
+	Selector string   `json:"selector,omitempty"`
+	Query    string   `json:"query,omitempty"`
+}
+
+// RestartAgentsResponse is the REST API response to PUT /v1/agents/restart and contains the agents that will be restarted
+type RestartAgentsResponse = AgentsResponse
+
+// DriftedAgentsResponse is the REST API response to GET /v1/agents/drift and contains the agents whose reported
+// configuration differs from the configuration rendered for them
+type DriftedAgentsResponse = AgentsResponse
+
+// RevokeAgentCredentialsResponse is the REST API response to DELETE /v1/agents/{id}/credentials and contains the agent
+// with revoked credentials
+type RevokeAgentCredentialsResponse = AgentResponse
+
 // AgentLabelsResponse is the REST API response to GET /v1/agents/{id}/labels
 type AgentLabelsResponse struct {
 	Errors []string `json:"errors"`
 	Labels *Labels  `json:"labels"`
 }
 
+// AgentHistoryResponse is the REST API response to GET /v1/agents/{id}/history
+type AgentHistoryResponse struct {
+	History *AgentHistory `json:"history"`
+}
+
 // AgentLabelsPayload is the REST API body for PATCH /v1/agents/{id}/labels
 type AgentLabelsPayload struct {
 	Labels map[string]string `json:"labels"`
@@ -65,6 +89,26 @@ type AgentVersionResponse struct {
 	AgentVersion *AgentVersion `json:"agentVersion"`
 }
 
+// EnrollmentTokensResponse is the REST API response to GET /v1/enrollment-tokens
+type EnrollmentTokensResponse struct {
+	EnrollmentTokens []*EnrollmentToken `json:"enrollmentTokens"`
+}
+
+// EnrollmentTokenResponse is the REST API response to GET /v1/enrollment-tokens/:name
+type EnrollmentTokenResponse struct {
+	EnrollmentToken *EnrollmentToken `json:"enrollmentToken"`
+}
+
+// SecretsResponse is the REST API response to GET /v1/secrets. The values of the secrets are never included.
+type SecretsResponse struct {
+	Secrets []*Secret `json:"secrets"`
+}
+
+// SecretResponse is the REST API response to GET /v1/secrets/:name. The value of the secret is never included.
+type SecretResponse struct {
+	Secret *Secret `json:"secret"`
+}
+
 // ConfigurationsResponse is the REST API response to GET /v1/configurations
 type ConfigurationsResponse struct {
 	Configurations []*Configuration `json:"configurations"`
@@ -136,6 +180,16 @@ type DestinationTypeResponse struct {
 	DestinationType *DestinationType `json:"destinationType"`
 }
 
+// ExtensionTypesResponse is the REST API response to GET /v1/extensionTypes
+type ExtensionTypesResponse struct {
+	ExtensionTypes []*ExtensionType `json:"extensionTypes"`
+}
+
+// ExtensionTypeResponse is the REST API response to GET /v1/extensionType/:name
+type ExtensionTypeResponse struct {
+	ExtensionType *ExtensionType `json:"extensionType"`
+}
+
 // ApplyResponse is the REST API response to POST /v1/apply.  This is used on
 // the server side to return updates consisting of generic ResourceStatuses.
 type ApplyResponse struct {
@@ -158,18 +212,30 @@ type ApplyPayload struct {
 // fields to be present.
 type DeletePayload struct {
 	Resources []*AnyResource `json:"resources"`
+
+	// Cascade determines what happens to the resources that depend on the deleted resources
+	Cascade CascadeMode `json:"cascade,omitempty"`
+
+	// DryRun returns the changes that would be made without making them
+	DryRun bool `json:"dryRun,omitempty"`
 }
 
 // DeleteResponse is the REST API response to POST /v1/delete
 type DeleteResponse struct {
 	Errors  []string         `json:"errors"`
 	Updates []ResourceStatus `json:"updates"`
+
+	// Agents are the IDs of the agents whose configuration would change and are only returned for a dry run
+	Agents []string `json:"agents,omitempty"`
+	DryRun bool     `json:"dryRun,omitempty"`
 }
 
 // DeleteResponseClientSide is the REST API response to POST /v1/delete
 type DeleteResponseClientSide struct {
 	Errors  []string             `json:"errors"`
 	Updates []*AnyResourceStatus `json:"updates"`
+	Agents  []string             `json:"agents,omitempty"`
+	DryRun  bool                 `json:"dryRun,omitempty"`
 }
 
 // InstallCommandResponse is the REST API response to GET /v1/agent-versions/{version}/install-command
@@ -197,7 +263,65 @@ type PostCopyConfigRequest struct {
 // PostCopyConfigResponse is the REST API response to PUT /v1/configurations/{name}/copy
 type PostCopyConfigResponse = PostCopyConfigRequest
 
+// ConfigurationRevisionsResponse is the REST API response to GET /v1/configurations/{name}/revisions
+type ConfigurationRevisionsResponse struct {
+	// Revisions of the configuration, ordered from oldest to newest
+	Revisions []*Configuration `json:"revisions"`
+}
+
+// PostRollbackRequest is the REST API body for POST /v1/configurations/{name}/rollback
+type PostRollbackRequest struct {
+	// The revision to restore
+	Revision int `json:"revision"`
+}
+
+// PostRollbackResponse is the REST API response to POST /v1/configurations/{name}/rollback
+type PostRollbackResponse struct {
+	Name string `json:"name"`
+	// The new revision created by the rollback
+	Revision int `json:"revision"`
+}
+
 // ErrorResponse is the expected response when receiving non 2xx status codes.
 type ErrorResponse struct {
 	Errors []string `json:"errors"`
 }
+
+// AuditEntriesResponse is the REST API response to GET /v1/audit
+type AuditEntriesResponse struct {
+	AuditEntries []*AuditEntry `json:"auditEntries"`
+}
+
+// RolloutsResponse is the REST API response to GET /v1/rollouts
+type RolloutsResponse struct {
+	Rollouts []*Rollout `json:"rollouts"`
+}
+
+// RolloutResponse is the REST API response to GET /v1/rollouts/:name and PUT /v1/rollouts/:name/pause, resume, and
+// abort
+type RolloutResponse struct {
+	Rollout *Rollout `json:"rollout"`
+}
+
+// UpgradeCampaignsResponse is the REST API response to GET /v1/upgrade-campaigns
+type UpgradeCampaignsResponse struct {
+	UpgradeCampaigns []*UpgradeCampaign `json:"upgradeCampaigns"`
+}
+
+// UpgradeCampaignResponse is the REST API response to GET /v1/upgrade-campaigns/:name, POST /v1/upgrade-campaigns, and
+// PUT /v1/upgrade-campaigns/:name/pause and resume
+type UpgradeCampaignResponse struct {
+	UpgradeCampaign *UpgradeCampaign `json:"upgradeCampaign"`
+}
+
+// Dependent is a resource that depends on another resource, including the resources that depend on it
+type Dependent struct {
+	Kind       Kind         `json:"kind" yaml:"kind"`
+	Name       string       `json:"name" yaml:"name"`
+	Dependents []*Dependent `json:"dependents,omitempty" yaml:"dependents,omitempty"`
+}
+
+// DependentsResponse is the REST API response to GET /v1/{kind}/:name/dependents
+type DependentsResponse struct {
+	Dependents []*Dependent `json:"dependents"`
+}
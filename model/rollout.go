@@ -0,0 +1,319 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/observiq/bindplane-op/model/validation"
+)
+
+// RolloutOptions are specified on a Configuration to roll out each new revision of the configuration to its agents in
+// waves instead of all at once. The first wave and each following wave update BatchPercent of the matching agents, or
+// BatchSize agents if BatchPercent is not specified.
+type RolloutOptions struct {
+	// BatchSize is the number of agents updated in each wave
+	BatchSize int `json:"batchSize,omitempty" yaml:"batchSize,omitempty" mapstructure:"batchSize"`
+
+	// BatchPercent is the percentage of the matching agents, from 1 to 100, updated in each wave
+	BatchPercent int `json:"batchPercent,omitempty" yaml:"batchPercent,omitempty" mapstructure:"batchPercent"`
+
+	// MaxErrorPercent is the percentage of the agents in a wave, from 0 to 100, that can fail to apply the configuration
+	// before the rollout stops. The rollout stops if any agent fails when it is 0.
+	MaxErrorPercent int `json:"maxErrorPercent,omitempty" yaml:"maxErrorPercent,omitempty" mapstructure:"maxErrorPercent"`
+
+	// OnError is the action taken when a wave exceeds MaxErrorPercent, either pause or rollback. The default is pause.
+	OnError RolloutAction `json:"onError,omitempty" yaml:"onError,omitempty" mapstructure:"onError"`
+}
+
+// RolloutAction is the action taken when a wave of a rollout fails
+type RolloutAction string
+
+const (
+	// RolloutActionPause pauses the rollout until it is resumed or aborted
+	RolloutActionPause RolloutAction = "pause"
+
+	// RolloutActionRollback aborts the rollout and restores the previous revision of the configuration
+	RolloutActionRollback RolloutAction = "rollback"
+)
+
+// WaveSize returns the number of agents to update in each wave when there are total matching agents. It is at least 1.
+func (o *RolloutOptions) WaveSize(total int) int {
+	size := o.BatchSize
+	if o.BatchPercent > 0 {
+		// round up so that small percentages of a few agents still update an agent
+		size = (total*o.BatchPercent + 99) / 100
+	}
+	if size < 1 {
+		return 1
+	}
+	return size
+}
+
+func (o *RolloutOptions) validate(errs validation.Errors) {
+	if o.BatchSize < 0 {
+		errs.Add(errors.New("rollout batchSize must not be negative"))
+	}
+	if o.BatchPercent < 0 || o.BatchPercent > 100 {
+		errs.Add(fmt.Errorf("rollout batchPercent must be between 0 and 100: %d", o.BatchPercent))
+	}
+	if o.BatchSize == 0 && o.BatchPercent == 0 {
+		errs.Add(errors.New("rollout must specify batchSize or batchPercent"))
+	}
+	if o.MaxErrorPercent < 0 || o.MaxErrorPercent > 100 {
+		errs.Add(fmt.Errorf("rollout maxErrorPercent must be between 0 and 100: %d", o.MaxErrorPercent))
+	}
+	switch o.OnError {
+	case "", RolloutActionPause, RolloutActionRollback:
+	default:
+		errs.Add(fmt.Errorf("rollout onError must be pause or rollback: %s", o.OnError))
+	}
+}
+
+// ----------------------------------------------------------------------
+
+// RolloutStatus is the status of a Rollout
+type RolloutStatus string
+
+const (
+	// RolloutRunning is a rollout that is updating agents in waves
+	RolloutRunning RolloutStatus = "running"
+
+	// RolloutPaused is a rollout that stopped updating agents until it is resumed or aborted
+	RolloutPaused RolloutStatus = "paused"
+
+	// RolloutCompleted is a rollout that updated all of the matching agents
+	RolloutCompleted RolloutStatus = "completed"
+
+	// RolloutAborted is a rollout that stopped and restored the previous revision of the configuration
+	RolloutAborted RolloutStatus = "aborted"
+
+	// RolloutSuperseded is a rollout that stopped because a newer revision of the configuration was applied
+	RolloutSuperseded RolloutStatus = "superseded"
+)
+
+// RolloutAgentStatus is the status of an agent updated by a Rollout
+type RolloutAgentStatus string
+
+const (
+	// RolloutAgentPending is an agent that was sent the configuration and has not reported its status
+	RolloutAgentPending RolloutAgentStatus = "pending"
+
+	// RolloutAgentApplied is an agent that applied the configuration
+	RolloutAgentApplied RolloutAgentStatus = "applied"
+
+	// RolloutAgentFailed is an agent that failed to apply the configuration
+	RolloutAgentFailed RolloutAgentStatus = "failed"
+)
+
+// RolloutAgent is an agent updated by a Rollout
+type RolloutAgent struct {
+	Wave   int                `json:"wave" yaml:"wave" mapstructure:"wave"`
+	Status RolloutAgentStatus `json:"status" yaml:"status" mapstructure:"status"`
+
+	// ConfigHash is the hex-encoded hash of the remote configuration sent to the agent for the revision of the rollout.
+	// The agent has applied the revision when it reports that it applied the configuration with this hash.
+	ConfigHash string `json:"configHash,omitempty" yaml:"configHash,omitempty" mapstructure:"configHash"`
+}
+
+// Rollout tracks the progress of rolling out a revision of a Configuration to its agents in waves. Agents that have not
+// been updated by the rollout continue to use PreviousRevision until the rollout completes.
+type Rollout struct {
+	// Name is the name of the Configuration
+	Name string `json:"name" yaml:"name" mapstructure:"name"`
+
+	// Revision is the revision of the Configuration being rolled out
+	Revision int `json:"revision" yaml:"revision" mapstructure:"revision"`
+
+	// PreviousRevision is the revision of the Configuration used by agents that have not been updated
+	PreviousRevision int `json:"previousRevision" yaml:"previousRevision" mapstructure:"previousRevision"`
+
+	// RollbackRevision is the revision of the Configuration created to restore PreviousRevision when the rollout was
+	// aborted
+	RollbackRevision int `json:"rollbackRevision,omitempty" yaml:"rollbackRevision,omitempty" mapstructure:"rollbackRevision"`
+
+	Status  RolloutStatus  `json:"status" yaml:"status" mapstructure:"status"`
+	Options RolloutOptions `json:"options" yaml:"options" mapstructure:"options"`
+
+	// Message explains why the rollout was paused, aborted, or superseded
+	Message string `json:"message,omitempty" yaml:"message,omitempty" mapstructure:"message"`
+
+	// Wave is the current wave, starting with 1
+	Wave          int       `json:"wave" yaml:"wave" mapstructure:"wave"`
+	WaveStartedAt time.Time `json:"waveStartedAt" yaml:"waveStartedAt" mapstructure:"waveStartedAt"`
+	StartedAt     time.Time `json:"startedAt" yaml:"startedAt" mapstructure:"startedAt"`
+	UpdatedAt     time.Time `json:"updatedAt" yaml:"updatedAt" mapstructure:"updatedAt"`
+
+	// Agents are the agents updated by the rollout by agent ID
+	Agents map[string]*RolloutAgent `json:"agents" yaml:"agents" mapstructure:"agents"`
+}
+
+// NewRollout returns a new running Rollout of the revision of the configuration, which replaces the previous revision.
+// The first wave must be started with StartWave.
+func NewRollout(configuration *Configuration, previousRevision int, now time.Time) *Rollout {
+	r := &Rollout{
+		Name:             configuration.Name(),
+		Revision:         configuration.Revision(),
+		PreviousRevision: previousRevision,
+		Status:           RolloutRunning,
+		StartedAt:        now,
+		UpdatedAt:        now,
+		Agents:           map[string]*RolloutAgent{},
+	}
+	if configuration.Spec.Rollout != nil {
+		r.Options = *configuration.Spec.Rollout
+	}
+	return r
+}
+
+// Active returns true if the rollout is running or paused. Agents that have not been updated by an active rollout use
+// the previous revision of the configuration.
+func (r *Rollout) Active() bool {
+	return r.Status == RolloutRunning || r.Status == RolloutPaused
+}
+
+// Includes returns true if the agent with the specified ID has been updated by the rollout
+func (r *Rollout) Includes(agentID string) bool {
+	_, ok := r.Agents[agentID]
+	return ok
+}
+
+// StartWave starts the next wave with the agents with the specified IDs
+func (r *Rollout) StartWave(agentIDs []string, now time.Time) {
+	r.Wave++
+	r.WaveStartedAt = now
+	r.UpdatedAt = now
+	for _, id := range agentIDs {
+		r.Agents[id] = &RolloutAgent{Wave: r.Wave, Status: RolloutAgentPending}
+	}
+}
+
+// UpdateAgentStatus updates the status of the agent in the rollout from the ConfigurationStatus of the agent. The hash
+// of the remote configuration sent to the agent for the revision of the rollout is recorded and the agent is applied
+// only when it reports that it applied the configuration with that hash.
+func (r *Rollout) UpdateAgentStatus(agent *Agent) {
+	rolloutAgent, ok := r.Agents[agent.ID]
+	if !ok {
+		return
+	}
+	status := agent.ConfigurationStatus
+	if status == nil || status.Configuration != r.Name || status.Revision != r.Revision {
+		// the revision has not been sent to the agent
+		return
+	}
+	rolloutAgent.ConfigHash = status.Hash
+
+	switch status.Status(rolloutAgent.ConfigHash) {
+	case RemoteConfigApplied:
+		rolloutAgent.Status = RolloutAgentApplied
+	case RemoteConfigFailed:
+		rolloutAgent.Status = RolloutAgentFailed
+	}
+}
+
+// WaveAgentIDs returns the IDs of the agents in the current wave with the specified status, sorted by ID
+func (r *Rollout) WaveAgentIDs(status RolloutAgentStatus) []string {
+	var ids []string
+	for id, agent := range r.Agents {
+		if agent.Wave == r.Wave && agent.Status == status {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// WaveErrorPercent returns the percentage of the agents in the current wave that failed to apply the configuration
+func (r *Rollout) WaveErrorPercent() float64 {
+	var total, failed int
+	for _, agent := range r.Agents {
+		if agent.Wave != r.Wave {
+			continue
+		}
+		total++
+		if agent.Status == RolloutAgentFailed {
+			failed++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(failed) / float64(total) * 100
+}
+
+// WaveFailed returns true if the current wave exceeds the MaxErrorPercent of the rollout
+func (r *Rollout) WaveFailed() bool {
+	return r.WaveErrorPercent() > float64(r.Options.MaxErrorPercent)
+}
+
+// AgentCount returns the number of agents updated by the rollout with the specified status
+func (r *Rollout) AgentCount(status RolloutAgentStatus) int {
+	count := 0
+	for _, agent := range r.Agents {
+		if agent.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableKindSingular returns the singular form of the Kind, e.g. "Rollout"
+func (r *Rollout) PrintableKindSingular() string {
+	return "Rollout"
+}
+
+// PrintableKindPlural returns the plural form of the Kind, e.g. "Rollouts"
+func (r *Rollout) PrintableKindPlural() string {
+	return "Rollouts"
+}
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (r *Rollout) PrintableFieldTitles() []string {
+	return []string{"Name", "Revision", "Status", "Wave", "Pending", "Applied", "Failed", "Message"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (r *Rollout) PrintableFieldValue(title string) string {
+	switch title {
+	case "Name":
+		return r.Name
+	case "Revision":
+		return strconv.Itoa(r.Revision)
+	case "Status":
+		return string(r.Status)
+	case "Wave":
+		return strconv.Itoa(r.Wave)
+	case "Pending":
+		return strconv.Itoa(r.AgentCount(RolloutAgentPending))
+	case "Applied":
+		return strconv.Itoa(r.AgentCount(RolloutAgentApplied))
+	case "Failed":
+		return strconv.Itoa(r.AgentCount(RolloutAgentFailed))
+	case "Message":
+		if r.Message == "" {
+			return "-"
+		}
+		return r.Message
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,180 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/observiq/bindplane-op/model/validation"
+)
+
+func TestRolloutOptionsValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		options RolloutOptions
+		errors  string
+	}{
+		{
+			name:    "batch size",
+			options: RolloutOptions{BatchSize: 10},
+		},
+		{
+			name:    "batch percent with rollback",
+			options: RolloutOptions{BatchPercent: 25, MaxErrorPercent: 10, OnError: RolloutActionRollback},
+		},
+		{
+			name:    "no batch",
+			options: RolloutOptions{},
+			errors:  "1 error occurred:\n\t* rollout must specify batchSize or batchPercent\n\n",
+		},
+		{
+			name:    "invalid percents",
+			options: RolloutOptions{BatchPercent: 101, MaxErrorPercent: -1},
+			errors:  "2 errors occurred:\n\t* rollout batchPercent must be between 0 and 100: 101\n\t* rollout maxErrorPercent must be between 0 and 100: -1\n\n",
+		},
+		{
+			name:    "invalid onError",
+			options: RolloutOptions{BatchSize: 1, OnError: "retry"},
+			errors:  "1 error occurred:\n\t* rollout onError must be pause or rollback: retry\n\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			errs := validation.NewErrors()
+			test.options.validate(errs)
+			err := errs.Result()
+			if test.errors == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, test.errors)
+		})
+	}
+}
+
+func TestRolloutOptionsWaveSize(t *testing.T) {
+	require.Equal(t, 3, (&RolloutOptions{BatchSize: 3}).WaveSize(10))
+	require.Equal(t, 3, (&RolloutOptions{BatchPercent: 25}).WaveSize(10))
+	require.Equal(t, 1, (&RolloutOptions{BatchPercent: 1}).WaveSize(10))
+	require.Equal(t, 1, (&RolloutOptions{BatchPercent: 50}).WaveSize(0))
+}
+
+func TestRolloutWaves(t *testing.T) {
+	now := time.Now()
+	configuration := NewConfiguration("test")
+	configuration.Spec.Rollout = &RolloutOptions{BatchSize: 2, MaxErrorPercent: 50}
+	rollout := NewRollout(configuration, 1, now)
+	require.True(t, rollout.Active())
+
+	rollout.StartWave([]string{"b", "a"}, now)
+	require.Equal(t, 1, rollout.Wave)
+	require.Equal(t, []string{"a", "b"}, rollout.WaveAgentIDs(RolloutAgentPending))
+	require.True(t, rollout.Includes("a"))
+	require.False(t, rollout.Includes("c"))
+
+	rollout.Agents["a"].Status = RolloutAgentFailed
+	rollout.Agents["b"].Status = RolloutAgentApplied
+	require.Equal(t, float64(50), rollout.WaveErrorPercent())
+	require.False(t, rollout.WaveFailed())
+
+	rollout.StartWave([]string{"c"}, now)
+	require.Equal(t, 2, rollout.Wave)
+	require.Equal(t, float64(0), rollout.WaveErrorPercent())
+	rollout.Agents["c"].Status = RolloutAgentFailed
+	require.True(t, rollout.WaveFailed())
+	require.Equal(t, 2, rollout.AgentCount(RolloutAgentFailed))
+	require.Equal(t, "2", rollout.PrintableFieldValue("Failed"))
+}
+
+func TestRolloutUpdateAgentStatus(t *testing.T) {
+	configuration := NewConfiguration("test")
+	configuration.SetRevision(2)
+	previous := NewConfiguration("test")
+	previous.SetRevision(1)
+
+	tests := []struct {
+		name   string
+		update func(agent *Agent)
+		hash   string
+		expect RolloutAgentStatus
+	}{
+		{
+			name:   "not sent",
+			update: func(agent *Agent) { agent.Status = Connected },
+			expect: RolloutAgentPending,
+		},
+		{
+			name: "previous revision applied",
+			update: func(agent *Agent) {
+				agent.ConfigurationSent(previous, "a1")
+				agent.ConfigurationReported("a1", RemoteConfigApplied)
+			},
+			expect: RolloutAgentPending,
+		},
+		{
+			name:   "sent",
+			update: func(agent *Agent) { agent.ConfigurationSent(configuration, "b2") },
+			hash:   "b2",
+			expect: RolloutAgentPending,
+		},
+		{
+			name: "applied a different configuration",
+			update: func(agent *Agent) {
+				agent.ConfigurationSent(configuration, "b2")
+				agent.ConfigurationReported("a1", RemoteConfigApplied)
+			},
+			hash:   "b2",
+			expect: RolloutAgentPending,
+		},
+		{
+			name: "applied",
+			update: func(agent *Agent) {
+				agent.ConfigurationReported("b2", RemoteConfigApplied)
+				agent.ConfigurationSent(configuration, "b2")
+			},
+			hash:   "b2",
+			expect: RolloutAgentApplied,
+		},
+		{
+			name: "failed",
+			update: func(agent *Agent) {
+				agent.ConfigurationSent(configuration, "b2")
+				agent.ConfigurationReported("b2", RemoteConfigFailed)
+			},
+			hash:   "b2",
+			expect: RolloutAgentFailed,
+		},
+		{
+			name:   "already using the revision",
+			update: func(agent *Agent) { agent.ConfigurationSent(configuration, "") },
+			expect: RolloutAgentApplied,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			rollout := NewRollout(configuration, 1, time.Now())
+			rollout.StartWave([]string{"1"}, time.Now())
+
+			agent := &Agent{ID: "1"}
+			test.update(agent)
+			rollout.UpdateAgentStatus(agent)
+			require.Equal(t, test.expect, rollout.Agents["1"].Status)
+			require.Equal(t, test.hash, rollout.Agents["1"].ConfigHash)
+		})
+	}
+}
@@ -0,0 +1,463 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/observiq/bindplane-op/internal/store/search"
+	"github.com/observiq/bindplane-op/model/validation"
+)
+
+// RedactedSecretValue replaces the values of secrets that would otherwise appear in API responses
+const RedactedSecretValue = "(redacted)"
+
+// ErrSecretsKeyRequired is returned when a Secret is encrypted or decrypted and the server does not have a secrets key
+var ErrSecretsKeyRequired = errors.New("the server must be configured with a secrets key to use secrets")
+
+// secretReferencePattern matches references to secrets in parameter values, e.g. ${secret:splunk-token}
+var secretReferencePattern = regexp.MustCompile(`\$\{secret:([^}\s]+)\}`)
+
+// Secret is a resource with a value that is encrypted at rest with the secrets key of the server. The parameters of
+// Sources, Processors, Destinations, and Configurations refer to secrets with ${secret:name} and the references are only
+// resolved when a Configuration is rendered for an agent.
+type Secret struct {
+	ResourceMeta `yaml:",inline" json:",inline" mapstructure:",squash"`
+	Spec         SecretSpec `json:"spec" yaml:"spec" mapstructure:"spec"`
+}
+
+// SecretSpec is the spec for a Secret
+type SecretSpec struct {
+	// Value is the plain text value of the secret. It is only specified when the secret is applied and is replaced by
+	// EncryptedValue before the secret is stored.
+	Value string `json:"value,omitempty" yaml:"value,omitempty" mapstructure:"value"`
+
+	// EncryptedValue is the value encrypted with the secrets key of the server and encoded in base64. It is never
+	// returned by the API.
+	EncryptedValue string `json:"encryptedValue,omitempty" yaml:"encryptedValue,omitempty" mapstructure:"encryptedValue"`
+}
+
+var _ Resource = (*Secret)(nil)
+
+// NewSecret creates a new Secret with the specified name and plain text value
+func NewSecret(name string, value string) *Secret {
+	return &Secret{
+		ResourceMeta: ResourceMeta{
+			APIVersion: V1,
+			Kind:       KindSecret,
+			Metadata: Metadata{
+				Name: name,
+			},
+		},
+		Spec: SecretSpec{
+			Value: value,
+		},
+	}
+}
+
+// GetKind returns "Secret"
+func (s *Secret) GetKind() Kind {
+	return KindSecret
+}
+
+// SecretReference returns the reference to the Secret with the specified name that can be used in parameter values,
+// e.g. ${secret:splunk-token}
+func SecretReference(name string) string {
+	return fmt.Sprintf("${secret:%s}", name)
+}
+
+// Encrypt replaces the plain text Value of the Secret with an EncryptedValue using the specified key. It does nothing
+// if the Value is empty.
+func (s *Secret) Encrypt(key string) error {
+	if s.Spec.Value == "" {
+		return nil
+	}
+	gcm, err := secretCipher(key)
+	if err != nil {
+		return err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err := io.ReadFull(rand.Reader, nonce); err != nil {
+		return fmt.Errorf("unable to encrypt secret %s: %w", s.Name(), err)
+	}
+	// the nonce is stored in front of the encrypted value so that it can be decrypted
+	sealed := gcm.Seal(nonce, nonce, []byte(s.Spec.Value), nil)
+	s.Spec.EncryptedValue = base64.StdEncoding.EncodeToString(sealed)
+	s.Spec.Value = ""
+	return nil
+}
+
+// Decrypt returns the plain text value of the Secret using the specified key
+func (s *Secret) Decrypt(key string) (string, error) {
+	if s.Spec.Value != "" {
+		return s.Spec.Value, nil
+	}
+	gcm, err := secretCipher(key)
+	if err != nil {
+		return "", err
+	}
+	sealed, err := base64.StdEncoding.DecodeString(s.Spec.EncryptedValue)
+	if err != nil || len(sealed) < gcm.NonceSize() {
+		return "", fmt.Errorf("unable to decrypt secret %s: invalid encrypted value", s.Name())
+	}
+	value, err := gcm.Open(nil, sealed[:gcm.NonceSize()], sealed[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to decrypt secret %s with the secrets key of the server: %w", s.Name(), err)
+	}
+	return string(value), nil
+}
+
+// Redacted returns a copy of the Secret without its Value or EncryptedValue
+func (s *Secret) Redacted() *Secret {
+	redacted := *s
+	redacted.Spec = SecretSpec{}
+	return &redacted
+}
+
+// secretCipher returns AES-256-GCM using the sha256 hash of the key so that keys of any length can be used
+func secretCipher(key string) (cipher.AEAD, error) {
+	if key == "" {
+		return nil, ErrSecretsKeyRequired
+	}
+	hash := sha256.Sum256([]byte(key))
+	block, err := aes.NewCipher(hash[:])
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
+
+// ----------------------------------------------------------------------
+// references
+
+// secretNames adds the names of the secrets referenced by the value to names. Values of maps and lists are also
+// searched.
+func secretNames(value any, names map[string]bool) {
+	switch value := value.(type) {
+	case string:
+		for _, match := range secretReferencePattern.FindAllStringSubmatch(value, -1) {
+			names[match[1]] = true
+		}
+	case map[string]any:
+		for _, v := range value {
+			secretNames(v, names)
+		}
+	case []any:
+		for _, v := range value {
+			secretNames(v, names)
+		}
+	}
+}
+
+// SecretNames returns the sorted names of the secrets referenced by the parameters of the spec and the parameters of
+// its inline processors
+func (s *ParameterizedSpec) SecretNames() []string {
+	names := map[string]bool{}
+	s.secretNames(names)
+	return sortedNames(names)
+}
+
+func (s *ParameterizedSpec) secretNames(names map[string]bool) {
+	for _, p := range s.Parameters {
+		secretNames(p.Value, names)
+	}
+	for _, processor := range s.Processors {
+		processor.secretNames(names)
+	}
+}
+
+// indexSecrets adds a secret field for each secret referenced by the parameters
+func (s *ParameterizedSpec) indexSecrets(index search.Indexer) {
+	for _, name := range s.SecretNames() {
+		index("secret", name)
+	}
+}
+
+// SecretNames returns the sorted names of the secrets referenced by the inline parameters of the sources, destinations,
+// and extensions of the Configuration. Secrets referenced by Sources, Processors, and Destinations resources are not
+// included.
+func (c *Configuration) SecretNames() []string {
+	names := map[string]bool{}
+	for _, list := range [][]ResourceConfiguration{c.Spec.Sources, c.Spec.Destinations, c.Spec.Extensions} {
+		for _, rc := range list {
+			rc.secretNames(names)
+		}
+	}
+	secretNames(c.Spec.ServiceTelemetry, names)
+	return sortedNames(names)
+}
+
+func sortedNames(names map[string]bool) []string {
+	result := make([]string, 0, len(names))
+	for name := range names {
+		result = append(result, name)
+	}
+	sort.Strings(result)
+	return result
+}
+
+// ----------------------------------------------------------------------
+// rendering
+
+// secretResolver replaces references to secrets with their values while a Configuration is rendered for an agent
+type secretResolver struct {
+	key    string
+	store  ResourceStore
+	values map[string]string
+}
+
+func newSecretResolver(key string, store ResourceStore) *secretResolver {
+	return &secretResolver{
+		key:    key,
+		store:  store,
+		values: map[string]string{},
+	}
+}
+
+// resolveParameters returns a copy of the parameters with the references to secrets replaced by their values.
+// Parameters are resolved before templates are evaluated so that the values of the agent used by templates are never
+// resolved.
+func (r *secretResolver) resolveParameters(ctx context.Context, parameters []Parameter) ([]Parameter, error) {
+	result := make([]Parameter, len(parameters))
+	for i, p := range parameters {
+		value, err := r.resolve(ctx, p.Value)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		result[i] = Parameter{Name: p.Name, Value: value}
+	}
+	return result, nil
+}
+
+// resolveTelemetry returns a copy of the service telemetry with the references to secrets replaced by their values
+func (r *secretResolver) resolveTelemetry(ctx context.Context, telemetry map[string]any) (map[string]any, error) {
+	if telemetry == nil {
+		return nil, nil
+	}
+	resolved, err := r.resolve(ctx, telemetry)
+	if err != nil {
+		return nil, err
+	}
+	return resolved.(map[string]any), nil
+}
+
+// resolvedResource is a parameterizedResource with the references to secrets in its parameters replaced by their
+// values
+type resolvedResource struct {
+	parameterizedResource
+	parameters []Parameter
+}
+
+// ResourceParameters returns the resolved parameters
+func (r *resolvedResource) ResourceParameters() []Parameter {
+	return r.parameters
+}
+
+// resolve returns a copy of the value with the references to secrets replaced by their values
+func (r *secretResolver) resolve(ctx context.Context, value any) (any, error) {
+	switch value := value.(type) {
+	case string:
+		return r.resolveString(ctx, value)
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for k, v := range value {
+			resolved, err := r.resolve(ctx, v)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = resolved
+		}
+		return result, nil
+	case []any:
+		result := make([]any, len(value))
+		for i, v := range value {
+			resolved, err := r.resolve(ctx, v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	case []string:
+		result := make([]string, len(value))
+		for i, v := range value {
+			resolved, err := r.resolveString(ctx, v)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = resolved
+		}
+		return result, nil
+	case map[string]string:
+		result := make(map[string]string, len(value))
+		for k, v := range value {
+			resolved, err := r.resolveString(ctx, v)
+			if err != nil {
+				return nil, err
+			}
+			result[k] = resolved
+		}
+		return result, nil
+	}
+	return value, nil
+}
+
+func (r *secretResolver) resolveString(ctx context.Context, value string) (string, error) {
+	var resolveErr error
+	resolved := secretReferencePattern.ReplaceAllStringFunc(value, func(reference string) string {
+		name := secretReferencePattern.FindStringSubmatch(reference)[1]
+		secretValue, err := r.value(ctx, name)
+		if err != nil {
+			resolveErr = err
+			return reference
+		}
+		return secretValue
+	})
+	return resolved, resolveErr
+}
+
+func (r *secretResolver) value(ctx context.Context, name string) (string, error) {
+	if value, ok := r.values[name]; ok {
+		return value, nil
+	}
+	secret, err := r.store.Secret(ctx, name)
+	if err == nil && secret == nil {
+		err = fmt.Errorf("unknown %s: %s", KindSecret, name)
+	}
+	if err != nil {
+		return "", err
+	}
+	value, err := secret.Decrypt(r.key)
+	if err != nil {
+		return "", err
+	}
+	r.values[name] = value
+	return value, nil
+}
+
+// ----------------------------------------------------------------------
+// redaction
+
+// RedactSecretStatuses returns a copy of the statuses with each Secret replaced by a copy without its value
+func RedactSecretStatuses(statuses []ResourceStatus) []ResourceStatus {
+	result := make([]ResourceStatus, len(statuses))
+	for i, status := range statuses {
+		if secret, ok := status.Resource.(*Secret); ok && secret != nil {
+			status.Resource = secret.Redacted()
+		}
+		result[i] = status
+	}
+	return result
+}
+
+// RedactSecretValues replaces each of the secret values that appear in the text with RedactedSecretValue
+func RedactSecretValues(text string, values []string) string {
+	for _, value := range values {
+		if value != "" {
+			text = strings.ReplaceAll(text, value, RedactedSecretValue)
+		}
+	}
+	return text
+}
+
+// redactSecretValues returns a copy of the value with the secret values redacted from all of its strings. Other values
+// are converted to their JSON representation so that their fields can be redacted.
+func redactSecretValues(value any, values []string) any {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return RedactSecretValues(value, values)
+	case map[string]any:
+		result := make(map[string]any, len(value))
+		for k, v := range value {
+			result[k] = redactSecretValues(v, values)
+		}
+		return result
+	case []any:
+		result := make([]any, len(value))
+		for i, v := range value {
+			result[i] = redactSecretValues(v, values)
+		}
+		return result
+	}
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var decoded any
+	if err := json.Unmarshal(bytes, &decoded); err != nil {
+		return value
+	}
+	switch decoded.(type) {
+	case string, map[string]any, []any:
+		return redactSecretValues(decoded, values)
+	}
+	return value
+}
+
+// ----------------------------------------------------------------------
+// validation
+
+// Validate ensures that the Secret has a value
+func (s *Secret) Validate() (warnings string, errors error) {
+	errs := validation.NewErrors()
+	s.validate(errs)
+	return errs.Warnings(), errs.Result()
+}
+
+// ValidateWithStore validates the Secret. No additional validation requires the store.
+func (s *Secret) ValidateWithStore(ctx context.Context, store ResourceStore) (warnings string, errors error) {
+	return s.Validate()
+}
+
+func (s *Secret) validate(errs validation.Errors) {
+	s.ResourceMeta.validate(errs)
+	if s.Spec.Value == "" && s.Spec.EncryptedValue == "" {
+		errs.Add(errors.New("secret .spec.value is required"))
+	}
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (s *Secret) PrintableFieldTitles() []string {
+	return []string{"Name", "Description"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (s *Secret) PrintableFieldValue(title string) string {
+	switch title {
+	case "Name":
+		return s.Name()
+	case "Description":
+		return s.Metadata.Description
+	default:
+		return "-"
+	}
+}
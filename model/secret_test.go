@@ -0,0 +1,180 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func testSecretDestinationType() *DestinationType {
+	return NewDestinationTypeWithSpec("otlp_token", ResourceTypeSpec{
+		Parameters: []ParameterDefinition{
+			{Name: "token", Type: "string"},
+		},
+		LogsMetricsTraces: ResourceTypeOutput{
+			Exporters: `
+- otlp:
+    endpoint: otelcol:4317
+    headers:
+      authorization: Bearer {{ .token }}
+`,
+		},
+	})
+}
+
+func TestSecretEncryptDecrypt(t *testing.T) {
+	secret := NewSecret("token", "s3cr3t")
+	require.ErrorIs(t, secret.Encrypt(""), ErrSecretsKeyRequired)
+
+	require.NoError(t, secret.Encrypt("key"))
+	require.Empty(t, secret.Spec.Value)
+	require.NotEmpty(t, secret.Spec.EncryptedValue)
+
+	value, err := secret.Decrypt("key")
+	require.NoError(t, err)
+	require.Equal(t, "s3cr3t", value)
+
+	_, err = secret.Decrypt("other-key")
+	require.Error(t, err)
+
+	_, err = secret.Decrypt("")
+	require.ErrorIs(t, err, ErrSecretsKeyRequired)
+
+	redacted := secret.Redacted()
+	require.Empty(t, redacted.Spec.EncryptedValue)
+	require.Equal(t, "token", redacted.Name())
+	require.NotEmpty(t, secret.Spec.EncryptedValue, "redacting should not modify the original")
+}
+
+func TestSecretValidate(t *testing.T) {
+	_, err := NewSecret("token", "s3cr3t").Validate()
+	require.NoError(t, err)
+
+	_, err = NewSecret("token", "").Validate()
+	require.ErrorContains(t, err, "secret .spec.value is required")
+}
+
+func TestConfigurationSecretNames(t *testing.T) {
+	configuration := NewConfigurationWithSpec("otlp", ConfigurationSpec{
+		Sources: []ResourceConfiguration{
+			{ParameterizedSpec: ParameterizedSpec{Type: "otlp", Parameters: []Parameter{{Name: "password", Value: SecretReference("source-password")}}}},
+		},
+		Destinations: []ResourceConfiguration{
+			{Name: "otlp"},
+			{ParameterizedSpec: ParameterizedSpec{Type: "otlp_token", Parameters: []Parameter{{Name: "token", Value: "${secret:token} and ${secret:source-password}"}}}},
+		},
+	})
+	require.Equal(t, []string{"source-password", "token"}, configuration.SecretNames())
+}
+
+func TestEvalConfigurationSecrets(t *testing.T) {
+	store := newTestResourceStore()
+	config := &testConfiguration{secretsKey: "key"}
+
+	otlp := testResource[*SourceType](t, "sourcetype-otlp.yaml")
+	store.sourceTypes[otlp.Name()] = otlp
+	destinationType := testSecretDestinationType()
+	store.destinationTypes[destinationType.Name()] = destinationType
+
+	secret := NewSecret("token", "s3cr3t")
+	require.NoError(t, secret.Encrypt(config.secretsKey))
+	store.secrets[secret.Name()] = secret
+
+	configuration := NewConfigurationWithSpec("otlp", ConfigurationSpec{
+		Sources: []ResourceConfiguration{{ParameterizedSpec: ParameterizedSpec{Type: "otlp"}}},
+		Destinations: []ResourceConfiguration{
+			{ParameterizedSpec: ParameterizedSpec{Type: "otlp_token", Parameters: []Parameter{{Name: "token", Value: SecretReference("token")}}}},
+		},
+	})
+
+	// previews rendered without an agent keep the reference
+	result, err := configuration.Render(context.TODO(), nil, config, store)
+	require.NoError(t, err)
+	require.Contains(t, result, "authorization: Bearer ${secret:token}")
+	require.NotContains(t, result, "s3cr3t")
+
+	agent := &Agent{ID: "01ARZ3NDEKTSV4RRFFQ69G5FAV"}
+	result, err = configuration.Render(context.TODO(), agent, config, store)
+	require.NoError(t, err)
+	require.Contains(t, result, "authorization: Bearer s3cr3t")
+	require.NotContains(t, result, "${secret:token}")
+
+	// previews rendered for an agent keep the reference
+	result, err = configuration.Preview(context.TODO(), agent, config, store)
+	require.NoError(t, err)
+	require.Contains(t, result, "authorization: Bearer ${secret:token}")
+
+	// unknown secrets are reported when rendering for an agent
+	configuration.Spec.Destinations[0].Parameters[0].Value = SecretReference("missing")
+	_, err = configuration.Render(context.TODO(), agent, config, store)
+	require.ErrorContains(t, err, "unknown Secret: missing")
+}
+
+func TestEvalConfigurationSecretsFromAgent(t *testing.T) {
+	store := newTestResourceStore()
+	config := &testConfiguration{secretsKey: "key"}
+
+	otlp := testResource[*SourceType](t, "sourcetype-otlp.yaml")
+	store.sourceTypes[otlp.Name()] = otlp
+	destinationType := testSecretDestinationType()
+	store.destinationTypes[destinationType.Name()] = destinationType
+
+	secret := NewSecret("token", "s3cr3t")
+	require.NoError(t, secret.Encrypt(config.secretsKey))
+	store.secrets[secret.Name()] = secret
+
+	configuration := NewConfigurationWithSpec("otlp", ConfigurationSpec{
+		Sources: []ResourceConfiguration{{ParameterizedSpec: ParameterizedSpec{Type: "otlp"}}},
+		Destinations: []ResourceConfiguration{
+			{ParameterizedSpec: ParameterizedSpec{Type: "otlp_token", Parameters: []Parameter{{Name: "token", Value: "{{ .Agent.Hostname }}"}}}},
+		},
+	})
+
+	// values reported by the agent are not resolved as secret references
+	agent := &Agent{ID: "01ARZ3NDEKTSV4RRFFQ69G5FAV", HostName: SecretReference("token")}
+	result, err := configuration.Render(context.TODO(), agent, config, store)
+	require.NoError(t, err)
+	require.Contains(t, result, "authorization: Bearer ${secret:token}")
+	require.NotContains(t, result, "s3cr3t")
+}
+
+func TestRedactSecrets(t *testing.T) {
+	values := []string{"s3cr3t"}
+
+	require.Equal(t, "token: (redacted)", RedactSecretValues("token: s3cr3t", values))
+	require.Equal(t, "token: s3cr3t", RedactSecretValues("token: s3cr3t", nil))
+
+	agent := &Agent{
+		ID: "1",
+		Configuration: map[string]any{
+			"collector": "headers:\n  authorization: Bearer s3cr3t\n",
+		},
+		Drift: &AgentDrift{Diff: "-  authorization: Bearer ${secret:token}\n+  authorization: Bearer s3cr3t\n"},
+	}
+	require.Same(t, agent, agent.RedactSecrets(nil))
+
+	redacted := agent.RedactSecrets(values)
+	require.Equal(t, map[string]any{"collector": "headers:\n  authorization: Bearer (redacted)\n"}, redacted.Configuration)
+	require.Equal(t, "-  authorization: Bearer ${secret:token}\n+  authorization: Bearer (redacted)\n", redacted.Drift.Diff)
+	require.Contains(t, agent.Drift.Diff, "s3cr3t", "redacting should not modify the original")
+
+	secret := NewSecret("token", "s3cr3t")
+	statuses := RedactSecretStatuses([]ResourceStatus{*NewResourceStatus(secret, StatusCreated)})
+	require.Empty(t, statuses[0].Resource.(*Secret).Spec.Value)
+	require.Equal(t, "s3cr3t", secret.Spec.Value)
+}
@@ -18,6 +18,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/observiq/bindplane-op/internal/store/search"
 	"github.com/observiq/bindplane-op/model/otel"
 	"github.com/observiq/bindplane-op/model/validation"
 )
@@ -126,3 +127,26 @@ func (s *Source) PrintableFieldValue(title string) string {
 		return "-"
 	}
 }
+
+// RemoveReferences removes the processors that refer to the Processor or ProcessorType with the specified kind and
+// name and returns true if any were removed. It is used to remove the references to a resource before it is deleted.
+func (s *Source) RemoveReferences(kind Kind, name string) bool {
+	return s.Spec.removeProcessors(kind, name)
+}
+
+// ----------------------------------------------------------------------
+// Indexed
+
+// IndexFields returns a map of field name to field value to be stored in the index
+func (s *Source) IndexFields(index search.Indexer) {
+	s.ResourceMeta.IndexFields(index)
+
+	// add the type of source
+	index("type", s.ResourceTypeName())
+
+	// add processor, processorType fields
+	s.Spec.indexProcessors(index)
+
+	// add secret fields
+	s.Spec.indexSecrets(index)
+}
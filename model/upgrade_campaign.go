@@ -0,0 +1,274 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/observiq/bindplane-op/model/validation"
+)
+
+// DefaultUpgradeCampaignTimeout is how long an agent has to complete its upgrade when the campaign does not specify a
+// Timeout
+const DefaultUpgradeCampaignTimeout = 30 * time.Minute
+
+// UpgradeCampaignStatus is the status of an UpgradeCampaign
+type UpgradeCampaignStatus string
+
+const (
+	// UpgradeCampaignRunning is a campaign that is upgrading agents in batches
+	UpgradeCampaignRunning UpgradeCampaignStatus = "running"
+
+	// UpgradeCampaignPaused is a campaign that stopped upgrading agents until it is resumed
+	UpgradeCampaignPaused UpgradeCampaignStatus = "paused"
+
+	// UpgradeCampaignCompleted is a campaign that attempted to upgrade all of its agents
+	UpgradeCampaignCompleted UpgradeCampaignStatus = "completed"
+)
+
+// UpgradeCampaignAgentStatus is the status of an agent upgraded by an UpgradeCampaign
+type UpgradeCampaignAgentStatus string
+
+const (
+	// UpgradeCampaignAgentPending is an agent that has not been sent the upgrade
+	UpgradeCampaignAgentPending UpgradeCampaignAgentStatus = "pending"
+
+	// UpgradeCampaignAgentUpgrading is an agent that was sent the upgrade and has not completed it
+	UpgradeCampaignAgentUpgrading UpgradeCampaignAgentStatus = "upgrading"
+
+	// UpgradeCampaignAgentUpgraded is an agent that completed the upgrade
+	UpgradeCampaignAgentUpgraded UpgradeCampaignAgentStatus = "upgraded"
+
+	// UpgradeCampaignAgentFailed is an agent that reported an error upgrading or did not complete the upgrade before the
+	// Timeout of the campaign
+	UpgradeCampaignAgentFailed UpgradeCampaignAgentStatus = "failed"
+
+	// UpgradeCampaignAgentSkipped is an agent that was not upgraded because it was deleted or disconnected when its
+	// upgrade was started
+	UpgradeCampaignAgentSkipped UpgradeCampaignAgentStatus = "skipped"
+)
+
+// UpgradeCampaignAgent is an agent upgraded by an UpgradeCampaign
+type UpgradeCampaignAgent struct {
+	// Batch is the batch that includes the agent, starting with 1, or 0 if the agent has not been assigned to a batch
+	Batch  int                        `json:"batch" yaml:"batch" mapstructure:"batch"`
+	Status UpgradeCampaignAgentStatus `json:"status" yaml:"status" mapstructure:"status"`
+	Error  string                     `json:"error,omitempty" yaml:"error,omitempty" mapstructure:"error"`
+
+	// StartedAt is the time that the upgrade of the agent was started
+	StartedAt time.Time `json:"startedAt,omitempty" yaml:"startedAt,omitempty" mapstructure:"startedAt"`
+}
+
+// UpgradeCampaign upgrades the agents matching a selector or query to a version in batches. Each batch starts when the
+// previous batch is complete and at most Concurrency agents are upgraded at the same time. The campaign is paused when
+// more than MaxFailurePercent of the completed upgrades have failed.
+type UpgradeCampaign struct {
+	Name     string `json:"name" yaml:"name" mapstructure:"name"`
+	Version  string `json:"version" yaml:"version" mapstructure:"version"`
+	Selector string `json:"selector,omitempty" yaml:"selector,omitempty" mapstructure:"selector"`
+	Query    string `json:"query,omitempty" yaml:"query,omitempty" mapstructure:"query"`
+
+	// BatchSize is the number of agents in each batch. All agents are in a single batch if it is 0.
+	BatchSize int `json:"batchSize,omitempty" yaml:"batchSize,omitempty" mapstructure:"batchSize"`
+
+	// Concurrency is the maximum number of agents upgraded at the same time. It is not limited if it is 0.
+	Concurrency int `json:"concurrency,omitempty" yaml:"concurrency,omitempty" mapstructure:"concurrency"`
+
+	// MaxFailurePercent is the percentage of the completed upgrades, from 0 to 100, that can fail before the campaign
+	// is paused. The campaign is paused after any failure when it is 0.
+	MaxFailurePercent int `json:"maxFailurePercent,omitempty" yaml:"maxFailurePercent,omitempty" mapstructure:"maxFailurePercent"`
+
+	// Timeout is how long an agent has to complete its upgrade before it is considered failed. The
+	// DefaultUpgradeCampaignTimeout is used if it is 0.
+	Timeout time.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty" mapstructure:"timeout"`
+
+	// AcceptedFailures is the number of failed upgrades when the campaign was last resumed. They are not counted by
+	// FailurePercent so that the campaign is not paused again for the same failures.
+	AcceptedFailures int `json:"acceptedFailures,omitempty" yaml:"acceptedFailures,omitempty" mapstructure:"acceptedFailures"`
+
+	Status UpgradeCampaignStatus `json:"status" yaml:"status" mapstructure:"status"`
+
+	// Message explains why the campaign was paused
+	Message string `json:"message,omitempty" yaml:"message,omitempty" mapstructure:"message"`
+
+	// Batch is the current batch, starting with 1
+	Batch     int       `json:"batch" yaml:"batch" mapstructure:"batch"`
+	CreatedAt time.Time `json:"createdAt" yaml:"createdAt" mapstructure:"createdAt"`
+	UpdatedAt time.Time `json:"updatedAt" yaml:"updatedAt" mapstructure:"updatedAt"`
+
+	// Agents are the agents upgraded by the campaign by agent ID
+	Agents map[string]*UpgradeCampaignAgent `json:"agents" yaml:"agents" mapstructure:"agents"`
+}
+
+// Validate checks the name and options of the campaign
+func (c *UpgradeCampaign) Validate() error {
+	errs := validation.NewErrors()
+	if c.Name == "" {
+		errs.Add(errors.New("name must be specified"))
+	} else {
+		validation.IsName(errs, c.Name)
+	}
+	if c.BatchSize < 0 {
+		errs.Add(errors.New("batchSize must not be negative"))
+	}
+	if c.Concurrency < 0 {
+		errs.Add(errors.New("concurrency must not be negative"))
+	}
+	if c.MaxFailurePercent < 0 || c.MaxFailurePercent > 100 {
+		errs.Add(fmt.Errorf("maxFailurePercent must be between 0 and 100: %d", c.MaxFailurePercent))
+	}
+	if c.Timeout < 0 {
+		errs.Add(errors.New("timeout must not be negative"))
+	}
+	return errs.Result()
+}
+
+// Start sets the agents of the campaign and starts running it
+func (c *UpgradeCampaign) Start(agentIDs []string, now time.Time) {
+	c.Status = UpgradeCampaignRunning
+	c.Message = ""
+	c.Batch = 0
+	c.CreatedAt = now
+	c.UpdatedAt = now
+	c.Agents = make(map[string]*UpgradeCampaignAgent, len(agentIDs))
+	for _, id := range agentIDs {
+		c.Agents[id] = &UpgradeCampaignAgent{Status: UpgradeCampaignAgentPending}
+	}
+}
+
+// StartBatch assigns the next BatchSize agents, ordered by ID, to the next batch. It returns false if every agent has
+// been assigned to a batch.
+func (c *UpgradeCampaign) StartBatch(now time.Time) bool {
+	ids := c.AgentIDs(0, UpgradeCampaignAgentPending)
+	if len(ids) == 0 {
+		return false
+	}
+	if c.BatchSize > 0 && c.BatchSize < len(ids) {
+		ids = ids[:c.BatchSize]
+	}
+	c.Batch++
+	c.UpdatedAt = now
+	for _, id := range ids {
+		c.Agents[id].Batch = c.Batch
+	}
+	return true
+}
+
+// AgentIDs returns the IDs of the agents in the specified batch with the specified status, sorted by ID
+func (c *UpgradeCampaign) AgentIDs(batch int, status UpgradeCampaignAgentStatus) []string {
+	var ids []string
+	for id, agent := range c.Agents {
+		if agent.Batch == batch && agent.Status == status {
+			ids = append(ids, id)
+		}
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// BatchComplete returns true if every agent in the current batch has been upgraded, failed, or was skipped
+func (c *UpgradeCampaign) BatchComplete() bool {
+	return len(c.AgentIDs(c.Batch, UpgradeCampaignAgentPending)) == 0 && len(c.AgentIDs(c.Batch, UpgradeCampaignAgentUpgrading)) == 0
+}
+
+// AgentCount returns the number of agents in the campaign with the specified status
+func (c *UpgradeCampaign) AgentCount(status UpgradeCampaignAgentStatus) int {
+	count := 0
+	for _, agent := range c.Agents {
+		if agent.Status == status {
+			count++
+		}
+	}
+	return count
+}
+
+// FailurePercent returns the percentage of the completed upgrades that failed, not counting the AcceptedFailures
+func (c *UpgradeCampaign) FailurePercent() float64 {
+	failed := c.AgentCount(UpgradeCampaignAgentFailed) - c.AcceptedFailures
+	if failed < 0 {
+		failed = 0
+	}
+	completed := failed + c.AgentCount(UpgradeCampaignAgentUpgraded)
+	if completed == 0 {
+		return 0
+	}
+	return float64(failed) / float64(completed) * 100
+}
+
+// FailureThresholdExceeded returns true if more than MaxFailurePercent of the completed upgrades failed
+func (c *UpgradeCampaign) FailureThresholdExceeded() bool {
+	return c.FailurePercent() > float64(c.MaxFailurePercent)
+}
+
+// UpgradeTimeout returns the Timeout of the campaign or the DefaultUpgradeCampaignTimeout if it is not specified
+func (c *UpgradeCampaign) UpgradeTimeout() time.Duration {
+	if c.Timeout > 0 {
+		return c.Timeout
+	}
+	return DefaultUpgradeCampaignTimeout
+}
+
+// ----------------------------------------------------------------------
+// Printable
+
+// PrintableKindSingular returns the singular form of the Kind, e.g. "UpgradeCampaign"
+func (c *UpgradeCampaign) PrintableKindSingular() string {
+	return "UpgradeCampaign"
+}
+
+// PrintableKindPlural returns the plural form of the Kind, e.g. "UpgradeCampaigns"
+func (c *UpgradeCampaign) PrintableKindPlural() string {
+	return "UpgradeCampaigns"
+}
+
+// PrintableFieldTitles returns the list of field titles, used for printing a table of resources
+func (c *UpgradeCampaign) PrintableFieldTitles() []string {
+	return []string{"Name", "Version", "Status", "Batch", "Pending", "Upgrading", "Upgraded", "Failed", "Skipped", "Message"}
+}
+
+// PrintableFieldValue returns the field value for a title, used for printing a table of resources
+func (c *UpgradeCampaign) PrintableFieldValue(title string) string {
+	switch title {
+	case "Name":
+		return c.Name
+	case "Version":
+		return c.Version
+	case "Status":
+		return string(c.Status)
+	case "Batch":
+		return strconv.Itoa(c.Batch)
+	case "Pending":
+		return strconv.Itoa(c.AgentCount(UpgradeCampaignAgentPending))
+	case "Upgrading":
+		return strconv.Itoa(c.AgentCount(UpgradeCampaignAgentUpgrading))
+	case "Upgraded":
+		return strconv.Itoa(c.AgentCount(UpgradeCampaignAgentUpgraded))
+	case "Failed":
+		return strconv.Itoa(c.AgentCount(UpgradeCampaignAgentFailed))
+	case "Skipped":
+		return strconv.Itoa(c.AgentCount(UpgradeCampaignAgentSkipped))
+	case "Message":
+		if c.Message == "" {
+			return "-"
+		}
+		return c.Message
+	default:
+		return "-"
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright  observIQ, Inc.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+//      http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package model
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestUpgradeCampaignValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		campaign UpgradeCampaign
+		errors   string
+	}{
+		{
+			name:     "valid",
+			campaign: UpgradeCampaign{Name: "prod", BatchSize: 10, Concurrency: 2, MaxFailurePercent: 5},
+		},
+		{
+			name:     "missing name",
+			campaign: UpgradeCampaign{},
+			errors:   "1 error occurred:\n\t* name must be specified\n\n",
+		},
+		{
+			name:     "invalid options",
+			campaign: UpgradeCampaign{Name: "prod", BatchSize: -1, Concurrency: -1, MaxFailurePercent: 101, Timeout: -time.Minute},
+			errors:   "4 errors occurred:\n\t* batchSize must not be negative\n\t* concurrency must not be negative\n\t* maxFailurePercent must be between 0 and 100: 101\n\t* timeout must not be negative\n\n",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			err := test.campaign.Validate()
+			if test.errors == "" {
+				require.NoError(t, err)
+			} else {
+				require.EqualError(t, err, test.errors)
+			}
+		})
+	}
+}
+
+func TestUpgradeCampaignBatches(t *testing.T) {
+	now := time.Now()
+	campaign := &UpgradeCampaign{Name: "prod", Version: "v1.2.0", BatchSize: 2, MaxFailurePercent: 50}
+	campaign.Start([]string{"d", "c", "b", "a", "e"}, now)
+	require.Equal(t, UpgradeCampaignRunning, campaign.Status)
+	require.Equal(t, 5, campaign.AgentCount(UpgradeCampaignAgentPending))
+
+	require.True(t, campaign.StartBatch(now))
+	require.Equal(t, 1, campaign.Batch)
+	require.Equal(t, []string{"a", "b"}, campaign.AgentIDs(1, UpgradeCampaignAgentPending))
+	require.False(t, campaign.BatchComplete())
+
+	campaign.Agents["a"].Status = UpgradeCampaignAgentUpgraded
+	campaign.Agents["b"].Status = UpgradeCampaignAgentFailed
+	require.True(t, campaign.BatchComplete())
+	require.Equal(t, float64(50), campaign.FailurePercent())
+	require.False(t, campaign.FailureThresholdExceeded())
+
+	require.True(t, campaign.StartBatch(now))
+	require.Equal(t, []string{"c", "d"}, campaign.AgentIDs(2, UpgradeCampaignAgentPending))
+	campaign.Agents["c"].Status = UpgradeCampaignAgentFailed
+	campaign.Agents["d"].Status = UpgradeCampaignAgentSkipped
+	require.True(t, campaign.FailureThresholdExceeded())
+
+	// accepted failures are not counted
+	campaign.AcceptedFailures = 2
+	require.Equal(t, float64(0), campaign.FailurePercent())
+	require.False(t, campaign.FailureThresholdExceeded())
+
+	require.True(t, campaign.StartBatch(now))
+	require.Equal(t, []string{"e"}, campaign.AgentIDs(3, UpgradeCampaignAgentPending))
+	campaign.Agents["e"].Status = UpgradeCampaignAgentUpgraded
+	require.False(t, campaign.StartBatch(now))
+	require.Equal(t, 3, campaign.Batch)
+}
+
+func TestUpgradeCampaignPrintableFieldValue(t *testing.T) {
+	campaign := &UpgradeCampaign{Name: "prod", Version: "v1.2.0"}
+	campaign.Start([]string{"a", "b"}, time.Now())
+	campaign.StartBatch(time.Now())
+	campaign.Agents["a"].Status = UpgradeCampaignAgentUpgraded
+
+	require.Equal(t, "prod", campaign.PrintableFieldValue("Name"))
+	require.Equal(t, "running", campaign.PrintableFieldValue("Status"))
+	require.Equal(t, "1", campaign.PrintableFieldValue("Batch"))
+	require.Equal(t, "1", campaign.PrintableFieldValue("Pending"))
+	require.Equal(t, "1", campaign.PrintableFieldValue("Upgraded"))
+	require.Equal(t, "-", campaign.PrintableFieldValue("Message"))
+}
+
+func TestUpgradeCampaignUpgradeTimeout(t *testing.T) {
+	require.Equal(t, DefaultUpgradeCampaignTimeout, (&UpgradeCampaign{}).UpgradeTimeout())
+	require.Equal(t, time.Minute, (&UpgradeCampaign{Timeout: time.Minute}).UpgradeTimeout())
+}
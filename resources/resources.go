@@ -16,8 +16,8 @@ package resources
 
 import "embed"
 
-//go:embed destination-types/* source-types/* processor-types/* agent-versions/*
-// Files contains the files embedded in resources/destination-types/*, resources/source-types/*, resources/processor-types/*, and resources/agent-versions/*
+//go:embed destination-types/* source-types/* processor-types/* extension-types/* agent-versions/*
+// Files contains the files embedded in resources/destination-types/*, resources/source-types/*, resources/processor-types/*, resources/extension-types/*, and resources/agent-versions/*
 var Files embed.FS
 
 // SeedFolders is the list of folders that we seed on startup
@@ -25,5 +25,6 @@ var SeedFolders = []string{
 	"destination-types",
 	"source-types",
 	"processor-types",
+	"extension-types",
 	"agent-versions",
 }
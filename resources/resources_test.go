@@ -87,6 +87,18 @@ func TestValidateDestinationTypes(t *testing.T) {
 	}
 }
 
+func TestValidateExtensionTypes(t *testing.T) {
+	paths := resourcePaths(t, "extension-types")
+	for _, path := range paths {
+		t.Run(path, func(t *testing.T) {
+			resource := fileResource[*model.ExtensionType](t, path)
+			warn, err := resource.Validate()
+			require.NoError(t, err)
+			require.Equal(t, "", warn)
+		})
+	}
+}
+
 func TestValidateAgentVersions(t *testing.T) {
 	paths := resourcePaths(t, "agent-versions")
 	for _, path := range paths {
@@ -29,6 +29,7 @@ gql`
           name
           id
           labels
+          resourceVersion
         }
         spec {
           type
@@ -21,6 +21,7 @@ const CONFIG_NO_PROCESSORS = {
   metadata: {
     id: "test",
     name: "test",
+    resourceVersion: "1",
     labels: {
       platform: "macos",
     },
@@ -87,6 +88,7 @@ const CONFIG_WITH_PROCESSORS = {
   metadata: {
     id: "test",
     name: "test",
+    resourceVersion: "1",
     labels: {
       platform: "macos",
     },
@@ -409,6 +411,7 @@ describe("ProcessorDialogComponent", () => {
             configuration: "test",
             resourceType: "SOURCE",
             resourceIndex: 0,
+            resourceVersion: "1",
             processors: [
               {
                 type: "custom",
@@ -480,6 +483,7 @@ describe("ProcessorDialogComponent", () => {
             configuration: "test",
             resourceType: "SOURCE",
             resourceIndex: 0,
+            resourceVersion: "1",
             processors: [
               {
                 type: "custom",
@@ -572,6 +576,7 @@ describe("ProcessorDialogComponent", () => {
             configuration: "test",
             resourceType: "DESTINATION",
             resourceIndex: 0,
+            resourceVersion: "1",
             processors: [
               {
                 type: "custom",
@@ -653,6 +658,7 @@ describe("ProcessorDialogComponent", () => {
             configuration: "test",
             resourceType: "SOURCE",
             resourceIndex: 0,
+            resourceVersion: "1",
             processors: [],
           },
         },
@@ -731,6 +737,7 @@ describe("ProcessorDialogComponent", () => {
             configuration: "test",
             resourceType: "DESTINATION",
             resourceIndex: 0,
+            resourceVersion: "1",
             processors: [],
           },
         },
@@ -805,6 +812,7 @@ describe("ProcessorDialogComponent", () => {
             configuration: "test",
             resourceType: "DESTINATION",
             resourceIndex: 0,
+            resourceVersion: "1",
             processors: [
               {
                 type: "custom",
@@ -903,6 +911,7 @@ it("can reset processor state on back", async () => {
           configuration: "test",
           resourceType: "DESTINATION",
           resourceIndex: 0,
+          resourceVersion: "1",
           processors: [
             {
               type: "custom",
@@ -317,6 +317,8 @@ export const ProcessorDialogComponent: React.FC<ProcessorDialogProps> = ({
               : ResourceTypeKind.Destination,
           resourceIndex: editProcessorsInfo?.index!,
           processors: processors,
+          // the processors are only saved if the configuration has not changed since it was read
+          resourceVersion: configuration?.metadata?.resourceVersion,
         },
       },
     });
@@ -61,6 +61,13 @@ export function renderAgentStatus(
       statusText = "Upgrading";
       color = "warning";
       break;
+    case AgentStatus.RESTARTING:
+      statusText = "Restarting";
+      color = "warning";
+      break;
+    case AgentStatus.STALE:
+      statusText = "Stale";
+      break;
     default:
       statusText = "";
       break;
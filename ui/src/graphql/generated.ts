@@ -28,6 +28,7 @@ export type Agent = {
   errorMessage?: Maybe<Scalars['String']>;
   features: Scalars['Int'];
   home?: Maybe<Scalars['String']>;
+  history: AgentHistory;
   hostName?: Maybe<Scalars['String']>;
   id: Scalars['ID'];
   labels?: Maybe<Scalars['Map']>;
@@ -36,6 +37,7 @@ export type Agent = {
   operatingSystem?: Maybe<Scalars['String']>;
   platform?: Maybe<Scalars['String']>;
   remoteAddress?: Maybe<Scalars['String']>;
+  restart?: Maybe<AgentRestart>;
   status: Scalars['Int'];
   type?: Maybe<Scalars['String']>;
   upgrade?: Maybe<AgentUpgrade>;
@@ -43,6 +45,12 @@ export type Agent = {
   version?: Maybe<Scalars['String']>;
 };
 
+
+export type AgentHistoryArgs = {
+  end?: InputMaybe<Scalars['Time']>;
+  start?: InputMaybe<Scalars['Time']>;
+};
+
 export type AgentChange = {
   __typename?: 'AgentChange';
   agent: Agent;
@@ -62,11 +70,34 @@ export type AgentConfiguration = {
   Manager?: Maybe<Scalars['Map']>;
 };
 
+export type AgentHistory = {
+  __typename?: 'AgentHistory';
+  changes: Array<AgentStatusChange>;
+  end: Scalars['Time'];
+  start: Scalars['Time'];
+  uptime: Scalars['Float'];
+};
+
+export type AgentRestart = {
+  __typename?: 'AgentRestart';
+  error?: Maybe<Scalars['String']>;
+  requestedAt: Scalars['Time'];
+  status: Scalars['Int'];
+};
+
 export type AgentSelector = {
   __typename?: 'AgentSelector';
   matchLabels?: Maybe<Scalars['Map']>;
 };
 
+export type AgentStatusChange = {
+  __typename?: 'AgentStatusChange';
+  agentId: Scalars['String'];
+  errorMessage?: Maybe<Scalars['String']>;
+  status: Scalars['Int'];
+  timestamp: Scalars['Time'];
+};
+
 export type AgentUpgrade = {
   __typename?: 'AgentUpgrade';
   error?: Maybe<Scalars['String']>;
@@ -82,6 +113,19 @@ export type Agents = {
   suggestions?: Maybe<Array<Suggestion>>;
 };
 
+export type AuditEntry = {
+  __typename?: 'AuditEntry';
+  action: Scalars['String'];
+  after?: Maybe<Scalars['Map']>;
+  before?: Maybe<Scalars['Map']>;
+  changes: Array<Scalars['String']>;
+  id: Scalars['ID'];
+  kind: Scalars['String'];
+  name: Scalars['String'];
+  timestamp: Scalars['Time'];
+  user: Scalars['String'];
+};
+
 export type Configuration = {
   __typename?: 'Configuration';
   agentCount?: Maybe<Scalars['Int']>;
@@ -90,6 +134,7 @@ export type Configuration = {
   kind: Scalars['String'];
   metadata: Metadata;
   rendered?: Maybe<Scalars['String']>;
+  rollout?: Maybe<Rollout>;
   spec: ConfigurationSpec;
 };
 
@@ -99,12 +144,22 @@ export type ConfigurationChange = {
   eventType: EventType;
 };
 
+export type ConfigurationRoute = {
+  __typename?: 'ConfigurationRoute';
+  destinations: Array<Scalars['String']>;
+  sources: Array<Scalars['String']>;
+  types?: Maybe<Array<PipelineType>>;
+};
+
 export type ConfigurationSpec = {
   __typename?: 'ConfigurationSpec';
   contentType?: Maybe<Scalars['String']>;
   destinations?: Maybe<Array<ResourceConfiguration>>;
+  extensions?: Maybe<Array<ResourceConfiguration>>;
   raw?: Maybe<Scalars['String']>;
+  routes?: Maybe<Array<ConfigurationRoute>>;
   selector?: Maybe<AgentSelector>;
+  serviceTelemetry?: Maybe<Scalars['Map']>;
   sources?: Maybe<Array<ResourceConfiguration>>;
 };
 
@@ -156,6 +211,14 @@ export enum EventType {
   Update = 'UPDATE'
 }
 
+export type ExtensionType = {
+  __typename?: 'ExtensionType';
+  apiVersion: Scalars['String'];
+  kind: Scalars['String'];
+  metadata: Metadata;
+  spec: ResourceTypeSpec;
+};
+
 export type Graph = {
   __typename?: 'Graph';
   attributes: Scalars['Map'];
@@ -197,6 +260,8 @@ export type Metadata = {
   id: Scalars['ID'];
   labels?: Maybe<Scalars['Map']>;
   name: Scalars['String'];
+  resourceVersion?: Maybe<Scalars['String']>;
+  revision?: Maybe<Scalars['Int']>;
 };
 
 export type Metric = {
@@ -226,10 +291,43 @@ export type MetricOption = {
 
 export type Mutation = {
   __typename?: 'Mutation';
+  abortRollout: Rollout;
+  pauseRollout: Rollout;
+  restartAgents: Array<Agent>;
+  resumeRollout: Rollout;
+  rollbackConfiguration?: Maybe<Configuration>;
   updateProcessors?: Maybe<Scalars['Boolean']>;
 };
 
 
+export type MutationAbortRolloutArgs = {
+  name: Scalars['String'];
+};
+
+
+export type MutationPauseRolloutArgs = {
+  name: Scalars['String'];
+};
+
+
+export type MutationRestartAgentsArgs = {
+  ids?: InputMaybe<Array<Scalars['ID']>>;
+  query?: InputMaybe<Scalars['String']>;
+  selector?: InputMaybe<Scalars['String']>;
+};
+
+
+export type MutationResumeRolloutArgs = {
+  name: Scalars['String'];
+};
+
+
+export type MutationRollbackConfigurationArgs = {
+  name: Scalars['String'];
+  revision: Scalars['Int'];
+};
+
+
 export type MutationUpdateProcessorsArgs = {
   input: UpdateProcessorsInput;
 };
@@ -339,8 +437,10 @@ export type Query = {
   agent?: Maybe<Agent>;
   agentMetrics: GraphMetrics;
   agents: Agents;
+  auditEntries: Array<AuditEntry>;
   configuration?: Maybe<Configuration>;
   configurationMetrics: GraphMetrics;
+  configurationRevisions: Array<Configuration>;
   configurations: Configurations;
   destination?: Maybe<Destination>;
   destinationType?: Maybe<DestinationType>;
@@ -348,12 +448,16 @@ export type Query = {
   destinationWithType: DestinationWithType;
   destinations: Array<Destination>;
   destinationsInConfigs: Array<Destination>;
+  extensionType?: Maybe<ExtensionType>;
+  extensionTypes: Array<ExtensionType>;
   overviewMetrics: GraphMetrics;
   overviewPage: OverviewPage;
   processor?: Maybe<Processor>;
   processorType?: Maybe<ProcessorType>;
   processorTypes: Array<ProcessorType>;
   processors: Array<Processor>;
+  rollout?: Maybe<Rollout>;
+  rollouts: Array<Rollout>;
   snapshot: Snapshot;
   source?: Maybe<Source>;
   sourceType?: Maybe<SourceType>;
@@ -379,6 +483,15 @@ export type QueryAgentsArgs = {
 };
 
 
+export type QueryAuditEntriesArgs = {
+  kind?: InputMaybe<Scalars['String']>;
+  limit?: InputMaybe<Scalars['Int']>;
+  name?: InputMaybe<Scalars['String']>;
+  offset?: InputMaybe<Scalars['Int']>;
+  user?: InputMaybe<Scalars['String']>;
+};
+
+
 export type QueryConfigurationArgs = {
   name: Scalars['String'];
 };
@@ -390,6 +503,11 @@ export type QueryConfigurationMetricsArgs = {
 };
 
 
+export type QueryConfigurationRevisionsArgs = {
+  name: Scalars['String'];
+};
+
+
 export type QueryConfigurationsArgs = {
   onlyDeployedConfigurations?: InputMaybe<Scalars['Boolean']>;
   query?: InputMaybe<Scalars['String']>;
@@ -407,11 +525,43 @@ export type QueryDestinationTypeArgs = {
 };
 
 
+export type QueryDestinationTypesArgs = {
+  limit?: InputMaybe<Scalars['Int']>;
+  offset?: InputMaybe<Scalars['Int']>;
+  query?: InputMaybe<Scalars['String']>;
+  selector?: InputMaybe<Scalars['String']>;
+  sort?: InputMaybe<Scalars['String']>;
+};
+
+
 export type QueryDestinationWithTypeArgs = {
   name: Scalars['String'];
 };
 
 
+export type QueryDestinationsArgs = {
+  limit?: InputMaybe<Scalars['Int']>;
+  offset?: InputMaybe<Scalars['Int']>;
+  query?: InputMaybe<Scalars['String']>;
+  selector?: InputMaybe<Scalars['String']>;
+  sort?: InputMaybe<Scalars['String']>;
+};
+
+
+export type QueryExtensionTypeArgs = {
+  name: Scalars['String'];
+};
+
+
+export type QueryExtensionTypesArgs = {
+  limit?: InputMaybe<Scalars['Int']>;
+  offset?: InputMaybe<Scalars['Int']>;
+  query?: InputMaybe<Scalars['String']>;
+  selector?: InputMaybe<Scalars['String']>;
+  sort?: InputMaybe<Scalars['String']>;
+};
+
+
 export type QueryOverviewMetricsArgs = {
   configIDs?: InputMaybe<Array<Scalars['ID']>>;
   destinationIDs?: InputMaybe<Array<Scalars['ID']>>;
@@ -437,6 +587,29 @@ export type QueryProcessorTypeArgs = {
 };
 
 
+export type QueryProcessorTypesArgs = {
+  limit?: InputMaybe<Scalars['Int']>;
+  offset?: InputMaybe<Scalars['Int']>;
+  query?: InputMaybe<Scalars['String']>;
+  selector?: InputMaybe<Scalars['String']>;
+  sort?: InputMaybe<Scalars['String']>;
+};
+
+
+export type QueryProcessorsArgs = {
+  limit?: InputMaybe<Scalars['Int']>;
+  offset?: InputMaybe<Scalars['Int']>;
+  query?: InputMaybe<Scalars['String']>;
+  selector?: InputMaybe<Scalars['String']>;
+  sort?: InputMaybe<Scalars['String']>;
+};
+
+
+export type QueryRolloutArgs = {
+  name: Scalars['String'];
+};
+
+
 export type QuerySnapshotArgs = {
   agentID: Scalars['String'];
   pipelineType: PipelineType;
@@ -452,6 +625,24 @@ export type QuerySourceTypeArgs = {
   name: Scalars['String'];
 };
 
+
+export type QuerySourceTypesArgs = {
+  limit?: InputMaybe<Scalars['Int']>;
+  offset?: InputMaybe<Scalars['Int']>;
+  query?: InputMaybe<Scalars['String']>;
+  selector?: InputMaybe<Scalars['String']>;
+  sort?: InputMaybe<Scalars['String']>;
+};
+
+
+export type QuerySourcesArgs = {
+  limit?: InputMaybe<Scalars['Int']>;
+  offset?: InputMaybe<Scalars['Int']>;
+  query?: InputMaybe<Scalars['String']>;
+  selector?: InputMaybe<Scalars['String']>;
+  sort?: InputMaybe<Scalars['String']>;
+};
+
 export type RelevantIfCondition = {
   __typename?: 'RelevantIfCondition';
   name: Scalars['String'];
@@ -487,6 +678,39 @@ export type ResourceTypeSpec = {
   version: Scalars['String'];
 };
 
+export type Rollout = {
+  __typename?: 'Rollout';
+  agentIds: Array<Scalars['String']>;
+  applied: Scalars['Int'];
+  failed: Scalars['Int'];
+  message: Scalars['String'];
+  name: Scalars['String'];
+  options: RolloutOptions;
+  pending: Scalars['Int'];
+  previousRevision: Scalars['Int'];
+  revision: Scalars['Int'];
+  rollbackRevision: Scalars['Int'];
+  startedAt: Scalars['Time'];
+  status: Scalars['String'];
+  updatedAt: Scalars['Time'];
+  wave: Scalars['Int'];
+  waveStartedAt: Scalars['Time'];
+};
+
+
+export type RolloutAgentIdsArgs = {
+  status?: InputMaybe<Scalars['String']>;
+  wave?: InputMaybe<Scalars['Int']>;
+};
+
+export type RolloutOptions = {
+  __typename?: 'RolloutOptions';
+  batchPercent: Scalars['Int'];
+  batchSize: Scalars['Int'];
+  maxErrorPercent: Scalars['Int'];
+  onError: Scalars['String'];
+};
+
 export type Snapshot = {
   __typename?: 'Snapshot';
   logs: Array<Log>;
@@ -574,6 +798,7 @@ export type UpdateProcessorsInput = {
   processors: Array<ProcessorInput>;
   resourceIndex: Scalars['Int'];
   resourceType: ResourceTypeKind;
+  resourceVersion?: InputMaybe<Scalars['String']>;
 };
 
 export type DestinationTypeQueryVariables = Exact<{
@@ -595,7 +820,7 @@ export type GetDestinationWithTypeQueryVariables = Exact<{
 }>;
 
 
-export type GetDestinationWithTypeQuery = { __typename?: 'Query', destinationWithType: { __typename?: 'DestinationWithType', destination?: { __typename?: 'Destination', metadata: { __typename?: 'Metadata', name: string, id: string, labels?: any | null }, spec: { __typename?: 'ParameterizedSpec', type: string, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null } } | null, destinationType?: { __typename?: 'DestinationType', metadata: { __typename?: 'Metadata', name: string, icon?: string | null, description?: string | null }, spec: { __typename?: 'ResourceTypeSpec', parameters: Array<{ __typename?: 'ParameterDefinition', label: string, name: string, description: string, required: boolean, type: ParameterType, default?: any | null, advancedConfig?: boolean | null, validValues?: Array<string> | null, relevantIf?: Array<{ __typename?: 'RelevantIfCondition', name: string, operator: RelevantIfOperatorType, value: any }> | null, documentation?: Array<{ __typename?: 'DocumentationLink', text: string, url: string }> | null, options: { __typename?: 'ParameterOptions', multiline?: boolean | null, creatable?: boolean | null, trackUnchecked?: boolean | null, sectionHeader?: boolean | null, gridColumns?: number | null, metricCategories?: Array<{ __typename?: 'MetricCategory', label: string, column: number, metrics: Array<{ __typename?: 'MetricOption', name: string, description?: string | null, kpi?: boolean | null }> }> | null } }> } } | null } };
+export type GetDestinationWithTypeQuery = { __typename?: 'Query', destinationWithType: { __typename?: 'DestinationWithType', destination?: { __typename?: 'Destination', metadata: { __typename?: 'Metadata', name: string, id: string, labels?: any | null, resourceVersion?: string | null }, spec: { __typename?: 'ParameterizedSpec', type: string, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null } } | null, destinationType?: { __typename?: 'DestinationType', metadata: { __typename?: 'Metadata', name: string, icon?: string | null, description?: string | null }, spec: { __typename?: 'ResourceTypeSpec', parameters: Array<{ __typename?: 'ParameterDefinition', label: string, name: string, description: string, required: boolean, type: ParameterType, default?: any | null, advancedConfig?: boolean | null, validValues?: Array<string> | null, relevantIf?: Array<{ __typename?: 'RelevantIfCondition', name: string, operator: RelevantIfOperatorType, value: any }> | null, documentation?: Array<{ __typename?: 'DocumentationLink', text: string, url: string }> | null, options: { __typename?: 'ParameterOptions', multiline?: boolean | null, creatable?: boolean | null, trackUnchecked?: boolean | null, sectionHeader?: boolean | null, gridColumns?: number | null, metricCategories?: Array<{ __typename?: 'MetricCategory', label: string, column: number, metrics: Array<{ __typename?: 'MetricOption', name: string, description?: string | null, kpi?: boolean | null }> }> | null } }> } } | null } };
 
 export type ConfigurationMetricsSubscriptionVariables = Exact<{
   period: Scalars['String'];
@@ -726,7 +951,7 @@ export type GetConfigurationQueryVariables = Exact<{
 }>;
 
 
-export type GetConfigurationQuery = { __typename?: 'Query', configuration?: { __typename?: 'Configuration', rendered?: string | null, metadata: { __typename?: 'Metadata', id: string, name: string, description?: string | null, labels?: any | null }, spec: { __typename?: 'ConfigurationSpec', raw?: string | null, sources?: Array<{ __typename?: 'ResourceConfiguration', type?: string | null, name?: string | null, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null, processors?: Array<{ __typename?: 'ResourceConfiguration', type?: string | null, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null }> | null }> | null, destinations?: Array<{ __typename?: 'ResourceConfiguration', type?: string | null, name?: string | null, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null, processors?: Array<{ __typename?: 'ResourceConfiguration', type?: string | null, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null }> | null }> | null, selector?: { __typename?: 'AgentSelector', matchLabels?: any | null } | null }, graph?: { __typename?: 'Graph', attributes: any, sources: Array<{ __typename?: 'Node', id: string, type: string, label: string, attributes: any }>, intermediates: Array<{ __typename?: 'Node', id: string, type: string, label: string, attributes: any }>, targets: Array<{ __typename?: 'Node', id: string, type: string, label: string, attributes: any }>, edges: Array<{ __typename?: 'Edge', id: string, source: string, target: string }> } | null } | null };
+export type GetConfigurationQuery = { __typename?: 'Query', configuration?: { __typename?: 'Configuration', rendered?: string | null, metadata: { __typename?: 'Metadata', id: string, name: string, description?: string | null, labels?: any | null, resourceVersion?: string | null }, spec: { __typename?: 'ConfigurationSpec', raw?: string | null, sources?: Array<{ __typename?: 'ResourceConfiguration', type?: string | null, name?: string | null, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null, processors?: Array<{ __typename?: 'ResourceConfiguration', type?: string | null, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null }> | null }> | null, destinations?: Array<{ __typename?: 'ResourceConfiguration', type?: string | null, name?: string | null, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null, processors?: Array<{ __typename?: 'ResourceConfiguration', type?: string | null, disabled: boolean, parameters?: Array<{ __typename?: 'Parameter', name: string, value: any }> | null }> | null }> | null, selector?: { __typename?: 'AgentSelector', matchLabels?: any | null } | null }, graph?: { __typename?: 'Graph', attributes: any, sources: Array<{ __typename?: 'Node', id: string, type: string, label: string, attributes: any }>, intermediates: Array<{ __typename?: 'Node', id: string, type: string, label: string, attributes: any }>, targets: Array<{ __typename?: 'Node', id: string, type: string, label: string, attributes: any }>, edges: Array<{ __typename?: 'Edge', id: string, source: string, target: string }> } | null } | null };
 
 export type DestinationsAndTypesQueryVariables = Exact<{ [key: string]: never; }>;
 
@@ -936,6 +1161,7 @@ export const GetDestinationWithTypeDocument = gql`
         name
         id
         labels
+        resourceVersion
       }
       spec {
         type
@@ -1880,6 +2106,7 @@ export const GetConfigurationDocument = gql`
       name
       description
       labels
+      resourceVersion
     }
     spec {
       raw
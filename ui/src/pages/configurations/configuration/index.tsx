@@ -34,6 +34,7 @@ gql`
         name
         description
         labels
+        resourceVersion
       }
       spec {
         raw
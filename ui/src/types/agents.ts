@@ -6,6 +6,8 @@ export enum AgentStatus {
   DELETED = 5,
   CONFIGURING = 6,
   UPGRADING = 7,
+  RESTARTING = 8,
+  STALE = 9,
 }
 
 export enum AgentFeatures {
@@ -28,4 +28,5 @@ export enum UpdateStatus {
   UNCHANGED = "unchanged",
   DELETED = "deleted",
   INVALID = "invalid",
+  CONFLICT = "conflict",
 }
@@ -10,6 +10,7 @@ import {
   APIVersion,
   ResourceKind,
   ResourceStatus,
+  UpdateStatus,
 } from "../../types/resources";
 import { applyResources } from "../rest/apply-resources";
 
@@ -132,6 +133,13 @@ export class BPConfiguration implements Configuration {
       );
     }
 
+    // the configuration was changed since its resourceVersion was read
+    if (update.status === UpdateStatus.CONFLICT) {
+      throw new Error(
+        `failed to apply updated configuration, ${this.name()} has changed: ${update.reason}`
+      );
+    }
+
     return update;
   }
 }
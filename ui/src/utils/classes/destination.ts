@@ -4,7 +4,11 @@ import {
   Metadata,
   ParameterizedSpec,
 } from "../../graphql/generated";
-import { APIVersion, ResourceStatus } from "../../types/resources";
+import {
+  APIVersion,
+  ResourceStatus,
+  UpdateStatus,
+} from "../../types/resources";
 import { applyResources } from "../rest/apply-resources";
 
 type MinimumDestination = Pick<Destination, "spec" | "metadata">;
@@ -66,6 +70,12 @@ export class BPDestination implements Destination {
         `failed to apply configuration, no update with name ${this.name()}`
       );
     }
+    // the destination was changed since its resourceVersion was read
+    if (update.status === UpdateStatus.CONFLICT) {
+      throw new Error(
+        `failed to apply destination, ${this.name()} has changed: ${update.reason}`
+      );
+    }
     return update;
   }
 }